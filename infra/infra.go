@@ -23,8 +23,10 @@ import (
 	"gopkg.in/alecthomas/kingpin.v2"
 
 	"github.com/prometheus/test-infra/pkg/provider"
+	"github.com/prometheus/test-infra/pkg/provider/aks"
 	"github.com/prometheus/test-infra/pkg/provider/eks"
 	"github.com/prometheus/test-infra/pkg/provider/gke"
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
 	"github.com/prometheus/test-infra/pkg/provider/kind"
 )
 
@@ -41,6 +43,35 @@ func main() {
 	app.Flag("vars", "When provided it will substitute the token holders in the yaml file. Follows the standard golang template formating - {{ .hashStable }}.").
 		Short('v').
 		StringMapVar(&dr.FlagDeploymentVars)
+	app.Flag("cluster-name-suffix", "Deterministic suffix appended to CLUSTER_NAME as CLUSTER_NAME-suffix, e.g. pr-1234-run-42. Cluster create reuses an existing cluster with the suffixed name instead of failing.").
+		StringVar(&dr.ClusterNameSuffix)
+	app.Flag("credentials-file", "YAML file with the credentials of several providers by provider name, each as file or inline data in the format of the provider --auth flag. Used by the providers without --auth, e.g. to compare GKE and EKS with one file.").
+		ExistingFileVar(&dr.CredentialsFile)
+	app.Flag("k8s-qps", "Maximum queries per second to the k8s api server. Higher values speed up large applies but can overwhelm small clusters.").
+		Default("5").
+		Float32Var(&dr.K8sQPS)
+	app.Flag("k8s-burst", "Maximum burst of queries to the k8s api server above the k8s-qps limit.").
+		Default("10").
+		IntVar(&dr.K8sBurst)
+	app.Flag("k8s-retry", "Retry policy of a class of k8s requests, read, create, update or delete, as operation:key=value,... with the attempts, base-delay, max-delay and jitter keys, e.g. read:attempts=10,max-delay=30s. Unset keys keep the defaults. Can be repeated.").
+		PlaceHolder("read:attempts=5").
+		SetValue(dr.K8sRetries)
+	app.Flag("k8s-ca-file", "PEM file with the certificate authorities trusted for the k8s api server instead of the one of the cluster or the kubeconfig, e.g. for a cluster with a private CA.").
+		ExistingFileVar(&dr.K8sCAFile)
+	app.Flag("k8s-insecure-skip-tls-verify", "UNSAFE, for dev clusters only: don't verify the certificate of the k8s api server, so the connection and the credentials can be intercepted.").
+		BoolVar(&dr.K8sInsecureSkipTLSVerify)
+	app.Flag("progress-interval", "How often to log the progress of the operations that wait for the cluster, e.g. cluster and node pool creation. 0 logs it at every check.").
+		Default("30s").
+		DurationVar(&provider.ProgressInterval)
+	var proxyURL, noProxy string
+	app.Flag("proxy-url", "Proxy for all cloud and k8s API requests, e.g. http://proxy.example.com:3128. Sets HTTP_PROXY and HTTPS_PROXY, when not set these env variables are used.").
+		StringVar(&proxyURL)
+	app.Flag("no-proxy", "Comma separated hosts, domains and CIDRs that bypass the proxy. Sets NO_PROXY, when not set the env variable is used.").
+		StringVar(&noProxy)
+	// The app action runs before the command actions, so before any client is created.
+	app.Action(func(*kingpin.ParseContext) error {
+		return provider.SetProxy(proxyURL, noProxy)
+	})
 
 	g := gke.New(dr)
 	k8sGKE := app.Command("gke", `Google container engine provider - https://cloud.google.com/kubernetes-engine/`).
@@ -49,27 +80,119 @@ func main() {
 		PlaceHolder("service-account.json").
 		Short('a').
 		StringVar(&g.Auth)
+	k8sGKE.Flag("project", "the project of the run, sets the GKE_PROJECT_ID variable. The permissions of the command are checked in the project before running it.").
+		StringVar(&g.ProjectID)
+	k8sGKE.Flag("impersonate-service-account", "a service account email impersonated by the auth for all requests, the auth needs the roles/iam.serviceAccountTokenCreator role on it.").
+		PlaceHolder("SA_EMAIL").
+		StringVar(&g.ImpersonateServiceAccount)
+
+	addK8sProxyFlag(k8sGKE, dr)
 
 	k8sGKE.Command("info", "gke info -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(g.GetDeploymentVars)
 
 	// Cluster operations.
 	k8sGKECluster := k8sGKE.Command("cluster", "manage GKE clusters").
-		Action(g.NewGKEClient).
-		Action(g.GKEDeploymentsParse)
-	k8sGKECluster.Command("create", "gke cluster create -a service-account.json -f FileOrFolder").
+		Action(g.NewGKEClient)
+	k8sGKEClusterCreate := k8sGKECluster.Command("create", "gke cluster create -a service-account.json -f FileOrFolder").
+		PreAction(g.ApplySpec).
+		Action(g.GKEDeploymentsParse).
 		Action(g.ClusterCreate)
-	k8sGKECluster.Command("delete", "gke cluster delete -a service-account.json -f FileOrFolder").
+	addSpecFileFlag(k8sGKEClusterCreate, &g.SpecFile)
+	addFanOutFlags(k8sGKEClusterCreate, dr)
+	k8sGKEClusterCreate.Flag("node-pool", "Additional node pool to create with the cluster. Can be repeated. ex: name=prometheus,machine-type=n1-highmem-8,count=2,label=isolation=prometheus,taint=dedicated=prometheus:NoSchedule").
+		SetValue(&g.NodePools)
+	k8sGKEClusterCreate.Flag("system-node-pool", "Create an untainted node pool for the kube-system workloads and taint all other node pools with prombench/dedicated=benchmark:NoSchedule. The name defaults to system. ex: machine-type=e2-standard-2,count=1").
+		StringVar(&g.SystemNodePool)
+	addNodeServiceAccountFlag(k8sGKEClusterCreate, g)
+	addGKESSHKeyFlags(k8sGKEClusterCreate, g)
+	addGKENodeStartupFlags(k8sGKEClusterCreate, g)
+	addGKENodeSecurityFlags(k8sGKEClusterCreate, g)
+	addNodeSystemConfigFlag(k8sGKEClusterCreate, &g.NodeSystemConfig)
+	k8sGKEClusterCreate.Flag("autoscaling", "Enable the cluster autoscaler for a node pool with the given node bounds. Can be repeated. ex: name=prometheus,min=1,max=5").
+		SetValue(&g.Autoscaling)
+	k8sGKEClusterCreate.Flag("auto-provisioning-cpu", "Enable node auto-provisioning with the min and max CPU cores of the whole cluster, including the nodes of all other node pools. Requires --auto-provisioning-memory. ex: 4:64").
+		PlaceHolder("MIN:MAX").StringVar(&g.AutoProvisioningCPU)
+	k8sGKEClusterCreate.Flag("auto-provisioning-memory", "Enable node auto-provisioning with the min and max memory GB of the whole cluster, including the nodes of all other node pools. Requires --auto-provisioning-cpu. ex: 16:256").
+		PlaceHolder("MIN:MAX").StringVar(&g.AutoProvisioningMemory)
+	k8sGKEClusterCreate.Flag("release-channel", "Release channel to enroll the cluster in - rapid, regular, stable or none. When not set the value from the cluster file is used.").
+		EnumVar(&g.ReleaseChannel, "rapid", "regular", "stable", "none")
+	k8sGKEClusterCreate.Flag("cluster-version", "Static control plane version for the cluster. Without a release channel this also disables node auto-upgrades.").
+		StringVar(&g.ClusterVersion)
+	k8sGKEClusterCreate.Flag("image-type", "Node image type for all node pools, e.g. COS_CONTAINERD or UBUNTU_CONTAINERD. When not set the value from the cluster file or the GKE default is used.").
+		StringVar(&g.ImageType)
+	k8sGKEClusterCreate.Flag("workload-identity", "Enable Workload Identity for the cluster and all node pools.").
+		BoolVar(&g.WorkloadIdentity)
+	k8sGKEClusterCreate.Flag("workload-identity-binding", "Allow a k8s service account to act as a GCP service account. Requires --workload-identity. Can be repeated. ex: loadgen@project.iam.gserviceaccount.com=prombench-10/loadgen").
+		SetValue(&g.WorkloadIdentityBindings)
+	k8sGKEClusterCreate.Flag("maintenance-window", "Start time of the daily maintenance window in the HH:MM UTC format.").
+		PlaceHolder("HH:MM").
+		StringVar(&g.MaintenanceWindow)
+	k8sGKEClusterCreate.Flag("zone", "Zone in the cluster region to spread the nodes of all node pools across, must include the cluster zone. Can be repeated. The node pool counts are split evenly across the zones.").
+		StringsVar(&g.Zones)
+	k8sGKEClusterCreate.Flag("skip-quota-check", "Skip checking the CPU and IP address quotas of the project before creating the cluster.").
+		BoolVar(&g.SkipQuotaCheck)
+	k8sGKEClusterCreate.Flag("logging", "Enable or disable Cloud Logging for the cluster. When not set the value from the cluster file or the GKE default is used.").
+		EnumVar(&g.Logging, "enabled", "disabled")
+	k8sGKEClusterCreate.Flag("monitoring", "Enable or disable Cloud Monitoring for the cluster. When not set the value from the cluster file or the GKE default is used.").
+		EnumVar(&g.Monitoring, "enabled", "disabled")
+	k8sGKEClusterCreate.Flag("managed-prometheus", "Enable Google Managed Prometheus with managed collection. It only scrapes the PodMonitoring and ClusterPodMonitoring resources, the query URL is printed, written to --info-file and set as the MANAGED_PROMETHEUS_QUERY_URL variable of the bootstrap files.").
+		BoolVar(&g.ManagedPrometheus)
+	k8sGKEClusterCreate.Flag("pod-cidr", "IP range of the pods, e.g. 10.4.0.0/14. Enables a VPC-native cluster. When not set the value from the cluster file or the GKE default is used.").
+		StringVar(&g.PodCIDR)
+	k8sGKEClusterCreate.Flag("service-cidr", "IP range of the services, e.g. 10.8.0.0/20. Must not overlap the pod range. Enables a VPC-native cluster.").
+		StringVar(&g.ServiceCIDR)
+	k8sGKEClusterCreate.Flag("max-pods-per-node", "Maximum number of pods per node for all node pools, between 8 and 256. Enables a VPC-native cluster. 0 keeps the value from the cluster file or the GKE default of 110.").
+		Int64Var(&g.MaxPodsPerNode)
+	addBootstrapFlags(k8sGKEClusterCreate, dr)
+	addBastionFlags(k8sGKEClusterCreate, dr, "e2-micro")
+	addScrapeFirewallFlags(k8sGKEClusterCreate, dr)
+	addProvisioningFlags(k8sGKEClusterCreate, dr)
+	k8sGKEClusterCreate.Flag("wait", "Wait for the clusters to be running. --wait=false returns once the creates are started and prints a handle per cluster for gke cluster wait, the handles are also written to --info-file.").
+		Default("true").
+		BoolVar(&g.WaitCreate)
+	k8sGKEClusterWait := k8sGKECluster.Command("wait", "gke cluster wait -a service-account.json projects/test/locations/europe-west1-b/operations/operation-1234").
+		Action(g.ClusterWait)
+	k8sGKEClusterWait.Arg("handle", "Handle printed by gke cluster create --wait=false.").
+		Required().
+		StringsVar(&g.CreateHandles)
+	k8sGKEClusterWait.Flag("info-file", "Write the provider, name, region and endpoint of the clusters as a JSON array to this file.").
+		StringVar(&dr.InfoFile)
+	addProvisioningFlags(k8sGKEClusterWait, dr)
+	k8sGKEClusterDelete := k8sGKECluster.Command("delete", "gke cluster delete -a service-account.json -f FileOrFolder").
+		Action(g.GKEDeploymentsParse).
 		Action(g.ClusterDelete)
+	addProvisioningFlags(k8sGKEClusterDelete, dr)
+	addRunSummaryFlags(k8sGKEClusterDelete, dr)
+	addDependentsFlag(k8sGKEClusterDelete, dr)
+	k8sGKEClusterDelete.Flag("force-delete", "Remove the deletion-protection=true resource label of the cluster and delete it. Without it a protected cluster is not deleted.").
+		BoolVar(&g.ForceDelete)
 
 	// Cluster node-pool operations
 	k8sGKENodePool := k8sGKE.Command("nodes", "manage GKE clusters nodepools").
 		Action(g.NewGKEClient).
 		Action(g.GKEDeploymentsParse)
-	k8sGKENodePool.Command("create", "gke nodes create -a service-account.json -f FileOrFolder").
+	k8sGKENodePoolCreate := k8sGKENodePool.Command("create", "gke nodes create -a service-account.json -f FileOrFolder").
 		Action(g.NodePoolCreate)
-	k8sGKENodePool.Command("delete", "gke nodes delete -a service-account.json -f FileOrFolder").
+	addNodeServiceAccountFlag(k8sGKENodePoolCreate, g)
+	addGKESSHKeyFlags(k8sGKENodePoolCreate, g)
+	addGKENodeStartupFlags(k8sGKENodePoolCreate, g)
+	addGKENodeSecurityFlags(k8sGKENodePoolCreate, g)
+	addNodeSystemConfigFlag(k8sGKENodePoolCreate, &g.NodeSystemConfig)
+	k8sGKENodePoolDelete := k8sGKENodePool.Command("delete", "gke nodes delete -a service-account.json -f FileOrFolder [--name prometheus]").
 		Action(g.NodePoolDelete)
+	k8sGKENodePoolDelete.Flag("name", "Name of a node pool to delete instead of the node pools from the cluster file. Can be repeated.").
+		StringsVar(&g.NodePoolNames)
+	k8sGKENodePoolDelete.Flag("force", "Allow deleting the last remaining node pools of the cluster.").
+		BoolVar(&g.Force)
+	k8sGKENodePoolReplace := k8sGKENodePool.Command("replace", "gke nodes replace -a service-account.json -f FileOrFolder nodes-v1 [--with nodes-v2]").
+		Action(g.NodePoolReplace)
+	addNodePoolReplaceFlags(k8sGKENodePoolReplace, dr, "node pool")
+	addNodeServiceAccountFlag(k8sGKENodePoolReplace, g)
+	addGKESSHKeyFlags(k8sGKENodePoolReplace, g)
+	addGKENodeStartupFlags(k8sGKENodePoolReplace, g)
+	addGKENodeSecurityFlags(k8sGKENodePoolReplace, g)
+	addNodeSystemConfigFlag(k8sGKENodePoolReplace, &g.NodeSystemConfig)
 	k8sGKENodePool.Command("check-running", "gke nodes check-running -a service-account.json -f FileOrFolder").
 		Action(g.AllNodepoolsRunning)
 	k8sGKENodePool.Command("check-deleted", "gke nodes check-deleted -a service-account.json -f FileOrFolder").
@@ -80,14 +203,95 @@ func main() {
 		Action(g.NewGKEClient).
 		Action(g.K8SDeploymentsParse).
 		Action(g.NewK8sProvider)
-	k8sGKEResource.Command("apply", "gke resource apply -a service-account.json -f manifestsFileOrFolder -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+	k8sGKEResourceApply := k8sGKEResource.Command("apply", "gke resource apply -a service-account.json -f manifestsFileOrFolder -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(g.ResourceApply)
-	k8sGKEResource.Command("delete", "gke resource delete -a service-account.json -f manifestsFileOrFolder -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+	addInjectFlags(k8sGKEResourceApply, dr)
+	addWaitFlag(k8sGKEResourceApply, dr)
+	addImagePreflightFlag(k8sGKEResourceApply, dr)
+	addImmutablePreflightFlag(k8sGKEResourceApply, dr)
+	addEstablishFlag(k8sGKEResourceApply, dr)
+	addVPAFlag(k8sGKEResourceApply, dr)
+	addServerSideFlags(k8sGKEResourceApply, dr)
+	addDNSFlags(k8sGKEResourceApply, &g.DNSZone, &g.DNSRecords, "Cloud DNS managed zone")
+	addExistingDiskFlag(k8sGKEResourceApply, &g.ExistingDisks, "compute disk, as the disk name in the cluster zone or as zone/name,")
+	addPruneFlags(k8sGKEResourceApply, dr)
+	addHelmFlags(k8sGKEResourceApply, dr)
+	k8sGKEResourceApply.Flag("reconcile-interval", "Keep running after the apply and re-apply the objects that drifted from the manifests at this interval, until interrupted. 0 disables it.").
+		Default("0").
+		DurationVar(&dr.ReconcileInterval)
+	k8sGKEResourceApply.Flag("static-ip", "Reserve or reuse a static IP address for a LoadBalancer service or an ingress, in the object-name:address-name format. Services use regional addresses and ingresses global addresses. Can be repeated.").
+		PlaceHolder("OBJECT:ADDRESS").
+		StringMapVar(&g.StaticIPs)
+	k8sGKEResourceDelete := k8sGKEResource.Command("delete", "gke resource delete -a service-account.json -f manifestsFileOrFolder -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(g.ResourceDelete)
+	addHelmFlags(k8sGKEResourceDelete, dr)
+	addDeleteFlags(k8sGKEResourceDelete, dr)
+	addDNSFlags(k8sGKEResourceDelete, &g.DNSZone, &g.DNSRecords, "Cloud DNS managed zone")
+	addExistingDiskFlag(k8sGKEResourceDelete, &g.ExistingDisks, "compute disk, as the disk name in the cluster zone or as zone/name,")
+	k8sGKEResourceDelete.Flag("static-ip", "Release the static IP addresses reserved for the objects by resource apply, in the object-name:address-name format. Addresses not reserved by infra are kept. Can be repeated.").
+		PlaceHolder("OBJECT:ADDRESS").
+		StringMapVar(&g.StaticIPs)
+	k8sGKEResourceStatus := k8sGKEResource.Command("status", "gke resource status -a service-account.json -f manifestsFileOrFolder -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(g.ResourceStatus)
+	addHelmFlags(k8sGKEResourceStatus, dr)
+	k8sGKEDescribe := k8sGKE.Command("describe", "gke describe -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test deployment/prometheus-meta -n prombench-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Describe)
+	addDescribeFlags(k8sGKEDescribe, dr)
+	k8sGKEOwners := k8sGKE.Command("owners", "gke owners -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test deployment/prometheus-meta -n prombench-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.FieldOwners)
+	addFieldOwnersFlags(k8sGKEOwners, dr)
+	k8sGKELogs := k8sGKE.Command("logs", "gke logs -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test prometheus-meta-0 -n prombench-1234 --follow").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Logs)
+	addLogsFlags(k8sGKELogs, dr)
+	k8sGKEWait := k8sGKE.Command("wait", "gke wait -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test prometheus.monitoring.coreos.com/k8s --for=condition=Available -n monitoring").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Wait)
+	addWaitConditionFlags(k8sGKEWait, dr)
+	k8sGKELabel := k8sGKE.Command("label", "gke label -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test pod/prometheus-test-0 prombench/retain=true -n prombench-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Label)
+	addMetadataFlags(k8sGKELabel, dr, "labels", &dr.Labels)
+	k8sGKEAnnotate := k8sGKE.Command("annotate", "gke annotate -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test pod -l app=loadgen prombench/phase=scale-up -n prombench-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Annotate)
+	addMetadataFlags(k8sGKEAnnotate, dr, "annotations", &dr.Annotations)
+	k8sGKECordon := k8sGKE.Command("cordon", "gke cordon -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test gke-prombench-nodes-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Cordon)
+	addNodeArg(k8sGKECordon, dr)
+	k8sGKEUncordon := k8sGKE.Command("uncordon", "gke uncordon -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test gke-prombench-nodes-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Uncordon)
+	addNodeArg(k8sGKEUncordon, dr)
+	k8sGKEDrain := k8sGKE.Command("drain", "gke drain -a service-account.json -v GKE_PROJECT_ID:test -v ZONE:europe-west1-b -v CLUSTER_NAME:test gke-prombench-nodes-1234").
+		Action(g.NewGKEClient).
+		Action(g.NewK8sProvider).
+		Action(g.Drain)
+	addDrainFlags(k8sGKEDrain, dr)
+	k8sGKEResourceDelete.Flag("keep-static-ip", "Keep the static IP addresses so they can be reused when the resources are applied again.").
+		BoolVar(&g.KeepStaticIPs)
 
 	k := kind.New(dr)
 	k8sKIND := app.Command("kind", `Kubernetes In Docker (KIND) provider - https://kind.sigs.k8s.io/docs/user/quick-start/`).
 		Action(k.SetupDeploymentResources)
+	k8sKIND.Flag("kubeconfig", "kubeconfig file used to connect to an --existing-cluster or a --context, the KIND clusters are read from KIND otherwise.").
+		Default(k.Kubeconfig).
+		StringVar(&k.Kubeconfig)
+	k8sKIND.Flag("context", "kubeconfig context used to connect to the cluster instead of the credentials from KIND. Defaults to the current context with --existing-cluster.").
+		StringVar(&k.KubeContext)
+	k8sKIND.Flag("existing-cluster", "Use an existing cluster from the kubeconfig instead of a KIND cluster. The cluster create and delete commands only check the connection to the cluster.").
+		BoolVar(&k.ExistingCluster)
 
 	k8sKIND.Command("info", "kind info -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(k.GetDeploymentVars)
@@ -95,19 +299,75 @@ func main() {
 	//Cluster operations.
 	k8sKINDCluster := k8sKIND.Command("cluster", "manage KIND clusters").
 		Action(k.KINDDeploymentsParse)
-	k8sKINDCluster.Command("create", "kind cluster create -f File -v PR_NUMBER:$PR_NUMBER -v CLUSTER_NAME:$CLUSTER_NAME").
+	k8sKINDClusterCreate := k8sKINDCluster.Command("create", "kind cluster create -f File -v PR_NUMBER:$PR_NUMBER -v CLUSTER_NAME:$CLUSTER_NAME").
 		Action(k.ClusterCreate)
-	k8sKINDCluster.Command("delete", "kind cluster delete -f File -v PR_NUMBER:$PR_NUMBER -v CLUSTER_NAME:$CLUSTER_NAME").
+	addBootstrapFlags(k8sKINDClusterCreate, dr)
+	addProvisioningFlags(k8sKINDClusterCreate, dr)
+	k8sKINDClusterDelete := k8sKINDCluster.Command("delete", "kind cluster delete -f File -v PR_NUMBER:$PR_NUMBER -v CLUSTER_NAME:$CLUSTER_NAME").
 		Action(k.ClusterDelete)
+	addProvisioningFlags(k8sKINDClusterDelete, dr)
 
 	// K8s resource operations.
 	k8sKINDResource := k8sKIND.Command("resource", `Apply and delete different k8s resources - deployments, services, config maps etc.`).
 		Action(k.NewK8sProvider).
 		Action(k.K8SDeploymentsParse)
-	k8sKINDResource.Command("apply", "kind resource apply -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+	k8sKINDResourceApply := k8sKINDResource.Command("apply", "kind resource apply -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(k.ResourceApply)
-	k8sKINDResource.Command("delete", "kind resource delete -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+	addInjectFlags(k8sKINDResourceApply, dr)
+	addWaitFlag(k8sKINDResourceApply, dr)
+	addImagePreflightFlag(k8sKINDResourceApply, dr)
+	addImmutablePreflightFlag(k8sKINDResourceApply, dr)
+	addEstablishFlag(k8sKINDResourceApply, dr)
+	addVPAFlag(k8sKINDResourceApply, dr)
+	addServerSideFlags(k8sKINDResourceApply, dr)
+	addPruneFlags(k8sKINDResourceApply, dr)
+	addHelmFlags(k8sKINDResourceApply, dr)
+	k8sKINDResourceApply.Flag("reconcile-interval", "Keep running after the apply and re-apply the objects that drifted from the manifests at this interval, until interrupted. 0 disables it.").
+		Default("0").
+		DurationVar(&dr.ReconcileInterval)
+	k8sKINDResourceDelete := k8sKINDResource.Command("delete", "kind resource delete -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(k.ResourceDelete)
+	addHelmFlags(k8sKINDResourceDelete, dr)
+	addDeleteFlags(k8sKINDResourceDelete, dr)
+	k8sKINDResourceStatus := k8sKINDResource.Command("status", "kind resource status -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(k.ResourceStatus)
+	addHelmFlags(k8sKINDResourceStatus, dr)
+	k8sKINDDescribe := k8sKIND.Command("describe", "kind describe deployment/prometheus-meta -n prombench-1234").
+		Action(k.NewK8sProvider).
+		Action(k.Describe)
+	addDescribeFlags(k8sKINDDescribe, dr)
+	k8sKINDOwners := k8sKIND.Command("owners", "kind owners deployment/prometheus-meta -n prombench-1234").
+		Action(k.NewK8sProvider).
+		Action(k.FieldOwners)
+	addFieldOwnersFlags(k8sKINDOwners, dr)
+	k8sKINDLogs := k8sKIND.Command("logs", "kind logs prometheus-meta-0 -n prombench-1234 --follow").
+		Action(k.NewK8sProvider).
+		Action(k.Logs)
+	addLogsFlags(k8sKINDLogs, dr)
+	k8sKINDWait := k8sKIND.Command("wait", "kind wait prometheus.monitoring.coreos.com/k8s --for=condition=Available -n monitoring").
+		Action(k.NewK8sProvider).
+		Action(k.Wait)
+	addWaitConditionFlags(k8sKINDWait, dr)
+	k8sKINDLabel := k8sKIND.Command("label", "kind label pod/prometheus-test-0 prombench/retain=true -n prombench-1234").
+		Action(k.NewK8sProvider).
+		Action(k.Label)
+	addMetadataFlags(k8sKINDLabel, dr, "labels", &dr.Labels)
+	k8sKINDAnnotate := k8sKIND.Command("annotate", "kind annotate pod -l app=loadgen prombench/phase=scale-up -n prombench-1234").
+		Action(k.NewK8sProvider).
+		Action(k.Annotate)
+	addMetadataFlags(k8sKINDAnnotate, dr, "annotations", &dr.Annotations)
+	k8sKINDCordon := k8sKIND.Command("cordon", "kind cordon prombench-worker").
+		Action(k.NewK8sProvider).
+		Action(k.Cordon)
+	addNodeArg(k8sKINDCordon, dr)
+	k8sKINDUncordon := k8sKIND.Command("uncordon", "kind uncordon prombench-worker").
+		Action(k.NewK8sProvider).
+		Action(k.Uncordon)
+	addNodeArg(k8sKINDUncordon, dr)
+	k8sKINDDrain := k8sKIND.Command("drain", "kind drain prombench-worker").
+		Action(k.NewK8sProvider).
+		Action(k.Drain)
+	addDrainFlags(k8sKINDDrain, dr)
 
 	// EKS based commands
 	e := eks.New(dr)
@@ -117,6 +377,13 @@ func main() {
 		PlaceHolder("credentials").
 		Short('a').
 		StringVar(&e.Auth)
+	k8sEKS.Flag("assume-role", "the ARN of a role assumed with the auth for all requests. The permissions of the command are checked for the role before running it.").
+		PlaceHolder("ROLE_ARN").
+		StringVar(&e.AssumeRole)
+	k8sEKS.Flag("assume-role-external-id", "the external id required by the trust policy of the --assume-role role.").
+		StringVar(&e.AssumeRoleExternalID)
+
+	addK8sProxyFlag(k8sEKS, dr)
 
 	k8sEKS.Command("info", "eks info -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(e.GetDeploymentVars)
@@ -125,19 +392,77 @@ func main() {
 	k8sEKSCluster := k8sEKS.Command("cluster", "manage EKS clusters").
 		Action(e.NewEKSClient).
 		Action(e.EKSDeploymentParse)
-	k8sEKSCluster.Command("create", "eks cluster create -a credentials -f FileOrFolder").
+	k8sEKSClusterCreate := k8sEKSCluster.Command("create", "eks cluster create -a credentials -f FileOrFolder").
+		PreAction(e.ApplySpec).
 		Action(e.ClusterCreate)
-	k8sEKSCluster.Command("delete", "eks cluster delete -a credentials -f FileOrFolder").
+	addSpecFileFlag(k8sEKSClusterCreate, &e.SpecFile)
+	addFanOutFlags(k8sEKSClusterCreate, dr)
+	k8sEKSClusterCreate.Flag("node-pool", "Additional node group to create with the cluster. Can be repeated. Requires -v EKS_WORKER_ROLE_ARN and -v EKS_SUBNET_IDS. ex: name=prometheus,machine-type=r5.2xlarge,count=2,label=isolation=prometheus,taint=dedicated=prometheus:NoSchedule").
+		SetValue(&e.NodePools)
+	k8sEKSClusterCreate.Flag("system-node-pool", "Create an untainted node group for the kube-system workloads and taint all other node groups with prombench/dedicated=benchmark:NoSchedule. The name defaults to system. Requires -v EKS_WORKER_ROLE_ARN and -v EKS_SUBNET_IDS. ex: machine-type=t3.large,count=1").
+		StringVar(&e.SystemNodePool)
+	addNodeRoleFlag(k8sEKSClusterCreate, e)
+	addEKSSSHKeyFlags(k8sEKSClusterCreate, e)
+	addDiskSizeFlag(k8sEKSClusterCreate, e)
+	addNitroEnclavesFlag(k8sEKSClusterCreate, e)
+	addNodeSystemConfigFlag(k8sEKSClusterCreate, &e.NodeSystemConfig)
+	k8sEKSClusterCreate.Flag("autoscaling", "Set the cluster autoscaler node bounds for a node group. Can be repeated. ex: name=prometheus,min=1,max=5").
+		SetValue(&e.Autoscaling)
+	k8sEKSClusterCreate.Flag("ami-type", "AMI type for all node groups, e.g. AL2_x86_64 or BOTTLEROCKET_x86_64. When not set the value from the cluster file or the EKS default is used.").
+		StringVar(&e.AMIType)
+	k8sEKSClusterCreate.Flag("irsa", "Enable IAM roles for service accounts by creating the IAM OIDC provider of the cluster.").
+		BoolVar(&e.IRSA)
+	k8sEKSClusterCreate.Flag("irsa-binding", "Allow a k8s service account to assume an IAM role, given as a name or ARN. Requires --irsa. Can be repeated. ex: prombench-loadgen=prombench-10/loadgen").
+		SetValue(&e.IRSABindings)
+	k8sEKSClusterCreate.Flag("zone", "Availability zone to spread the nodes of all node groups across. Can be repeated. Every node group needs a subnet in each zone.").
+		StringsVar(&e.Zones)
+	k8sEKSClusterCreate.Flag("skip-quota-check", "Skip checking the EC2 vCPU quota and the free subnet IP addresses before creating the cluster.").
+		BoolVar(&e.SkipQuotaCheck)
+	k8sEKSClusterCreate.Flag("logging", "Enable or disable all control plane log types in CloudWatch. When not set the value from the cluster file or the EKS default, disabled, is used.").
+		EnumVar(&e.Logging, "enabled", "disabled")
+	k8sEKSClusterCreate.Flag("monitoring", "Enable or disable the amazon-cloudwatch-observability addon. EKS doesn't install it by default.").
+		EnumVar(&e.Monitoring, "enabled", "disabled")
+	k8sEKSClusterCreate.Flag("managed-prometheus", "Create an Amazon Managed Service for Prometheus workspace for the cluster, deleted with the cluster. The query and remote write URLs are printed, written to --info-file and set as the MANAGED_PROMETHEUS_QUERY_URL and MANAGED_PROMETHEUS_REMOTE_WRITE_URL variables of the bootstrap files.").
+		BoolVar(&e.ManagedPrometheus)
+	k8sEKSClusterCreate.Flag("service-cidr", "IP range of the services, a /12 to /24 block within 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16 that doesn't overlap the VPC. The pods get their IPs from the VPC subnets.").
+		StringVar(&e.ServiceCIDR)
+	addBootstrapFlags(k8sEKSClusterCreate, dr)
+	addBastionFlags(k8sEKSClusterCreate, dr, "t3.micro")
+	addScrapeFirewallFlags(k8sEKSClusterCreate, dr)
+	addProvisioningFlags(k8sEKSClusterCreate, dr)
+	k8sEKSClusterDelete := k8sEKSCluster.Command("delete", "eks cluster delete -a credentials -f FileOrFolder").
 		Action(e.ClusterDelete)
+	addProvisioningFlags(k8sEKSClusterDelete, dr)
+	addRunSummaryFlags(k8sEKSClusterDelete, dr)
+	addDependentsFlag(k8sEKSClusterDelete, dr)
+	k8sEKSClusterDelete.Flag("force-delete", "Remove the deletion-protection=true tag of the cluster and delete it. Without it a protected cluster and its node groups are not deleted.").
+		BoolVar(&e.ForceDelete)
 
 	// Cluster node-pool operations
 	k8sEKSNodeGroup := k8sEKS.Command("nodes", "manage EKS clusters nodegroups").
 		Action(e.NewEKSClient).
 		Action(e.EKSDeploymentParse)
-	k8sEKSNodeGroup.Command("create", "eks nodes create -a authFile -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3").
+	k8sEKSNodeGroupCreate := k8sEKSNodeGroup.Command("create", "eks nodes create -a authFile -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3").
 		Action(e.NodeGroupCreate)
-	k8sEKSNodeGroup.Command("delete", "eks nodes delete -a authFile -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3").
+	addNodeRoleFlag(k8sEKSNodeGroupCreate, e)
+	addEKSSSHKeyFlags(k8sEKSNodeGroupCreate, e)
+	addDiskSizeFlag(k8sEKSNodeGroupCreate, e)
+	addNitroEnclavesFlag(k8sEKSNodeGroupCreate, e)
+	addNodeSystemConfigFlag(k8sEKSNodeGroupCreate, &e.NodeSystemConfig)
+	k8sEKSNodeGroupDelete := k8sEKSNodeGroup.Command("delete", "eks nodes delete -a authFile -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3 [--name prometheus]").
 		Action(e.NodeGroupDelete)
+	k8sEKSNodeGroupDelete.Flag("name", "Name of a node group to delete instead of the node groups from the cluster file. Can be repeated.").
+		StringsVar(&e.NodePoolNames)
+	k8sEKSNodeGroupDelete.Flag("force", "Allow deleting the last remaining node groups of the cluster.").
+		BoolVar(&e.Force)
+	k8sEKSNodeGroupReplace := k8sEKSNodeGroup.Command("replace", "eks nodes replace -a authFile -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3 nodes-v1 [--with nodes-v2]").
+		Action(e.NodeGroupReplace)
+	addNodePoolReplaceFlags(k8sEKSNodeGroupReplace, dr, "node group")
+	addNodeRoleFlag(k8sEKSNodeGroupReplace, e)
+	addEKSSSHKeyFlags(k8sEKSNodeGroupReplace, e)
+	addDiskSizeFlag(k8sEKSNodeGroupReplace, e)
+	addNitroEnclavesFlag(k8sEKSNodeGroupReplace, e)
+	addNodeSystemConfigFlag(k8sEKSNodeGroupReplace, &e.NodeSystemConfig)
 	k8sEKSNodeGroup.Command("check-running", "eks nodes check-running -a credentails -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3").
 		Action(e.AllNodeGroupsRunning)
 	k8sEKSNodeGroup.Command("check-deleted", "eks nodes check-deleted -a authFile -f FileOrFolder -v ZONE:eu-west-1 -v CLUSTER_NAME:test -v EKS_SUBNET_IDS: subnetId1,subnetId2,subnetId3").
@@ -148,10 +473,167 @@ func main() {
 		Action(e.NewEKSClient).
 		Action(e.K8SDeploymentsParse).
 		Action(e.NewK8sProvider)
-	k8sEKSResource.Command("apply", "eks resource apply -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+	k8sEKSResourceApply := k8sEKSResource.Command("apply", "eks resource apply -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(e.ResourceApply)
-	k8sEKSResource.Command("delete", "eks resource delete -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+	addInjectFlags(k8sEKSResourceApply, dr)
+	addWaitFlag(k8sEKSResourceApply, dr)
+	addImagePreflightFlag(k8sEKSResourceApply, dr)
+	addImmutablePreflightFlag(k8sEKSResourceApply, dr)
+	addEstablishFlag(k8sEKSResourceApply, dr)
+	addVPAFlag(k8sEKSResourceApply, dr)
+	addServerSideFlags(k8sEKSResourceApply, dr)
+	addDNSFlags(k8sEKSResourceApply, &e.DNSZone, &e.DNSRecords, "Route 53 hosted zone ID")
+	addExistingDiskFlag(k8sEKSResourceApply, &e.ExistingVolumes, "EBS volume, as the volume ID,")
+	addPruneFlags(k8sEKSResourceApply, dr)
+	addHelmFlags(k8sEKSResourceApply, dr)
+	k8sEKSResourceApply.Flag("reconcile-interval", "Keep running after the apply and re-apply the objects that drifted from the manifests at this interval, until interrupted. 0 disables it.").
+		Default("0").
+		DurationVar(&dr.ReconcileInterval)
+	k8sEKSResourceDelete := k8sEKSResource.Command("delete", "eks resource delete -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
 		Action(e.ResourceDelete)
+	addHelmFlags(k8sEKSResourceDelete, dr)
+	addDeleteFlags(k8sEKSResourceDelete, dr)
+	addDNSFlags(k8sEKSResourceDelete, &e.DNSZone, &e.DNSRecords, "Route 53 hosted zone ID")
+	addExistingDiskFlag(k8sEKSResourceDelete, &e.ExistingVolumes, "EBS volume, as the volume ID,")
+	k8sEKSResourceStatus := k8sEKSResource.Command("status", "eks resource status -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(e.ResourceStatus)
+	addHelmFlags(k8sEKSResourceStatus, dr)
+	k8sEKSDescribe := k8sEKS.Command("describe", "eks describe -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test deployment/prometheus-meta -n prombench-1234").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Describe)
+	addDescribeFlags(k8sEKSDescribe, dr)
+	k8sEKSOwners := k8sEKS.Command("owners", "eks owners -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test deployment/prometheus-meta -n prombench-1234").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.FieldOwners)
+	addFieldOwnersFlags(k8sEKSOwners, dr)
+	k8sEKSLogs := k8sEKS.Command("logs", "eks logs -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test prometheus-meta-0 -n prombench-1234 --follow").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Logs)
+	addLogsFlags(k8sEKSLogs, dr)
+	k8sEKSWait := k8sEKS.Command("wait", "eks wait -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test prometheus.monitoring.coreos.com/k8s --for=condition=Available -n monitoring").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Wait)
+	addWaitConditionFlags(k8sEKSWait, dr)
+	k8sEKSLabel := k8sEKS.Command("label", "eks label -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test pod/prometheus-test-0 prombench/retain=true -n prombench-1234").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Label)
+	addMetadataFlags(k8sEKSLabel, dr, "labels", &dr.Labels)
+	k8sEKSAnnotate := k8sEKS.Command("annotate", "eks annotate -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test pod -l app=loadgen prombench/phase=scale-up -n prombench-1234").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Annotate)
+	addMetadataFlags(k8sEKSAnnotate, dr, "annotations", &dr.Annotations)
+	k8sEKSCordon := k8sEKS.Command("cordon", "eks cordon -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test ip-10-0-1-23.us-east-2.compute.internal").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Cordon)
+	addNodeArg(k8sEKSCordon, dr)
+	k8sEKSUncordon := k8sEKS.Command("uncordon", "eks uncordon -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test ip-10-0-1-23.us-east-2.compute.internal").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Uncordon)
+	addNodeArg(k8sEKSUncordon, dr)
+	k8sEKSDrain := k8sEKS.Command("drain", "eks drain -a credentials -v ZONE:us-east-2 -v CLUSTER_NAME:test ip-10-0-1-23.us-east-2.compute.internal").
+		Action(e.NewEKSClient).
+		Action(e.NewK8sProvider).
+		Action(e.Drain)
+	addDrainFlags(k8sEKSDrain, dr)
+
+	// AKS based commands
+	z := aks.New(dr)
+	k8sAKS := app.Command("aks", "Azure Kubernetes Service - https://azure.microsoft.com/products/kubernetes-service").
+		Action(z.SetupDeploymentResources)
+	k8sAKS.Flag("auth", "filename which consist the aks service principal credentials.").
+		PlaceHolder("credentials").
+		Short('a').
+		StringVar(&z.Auth)
+	k8sAKS.Flag("managed-identity", "Authenticate as the managed identity of the Azure VM the command runs on instead of a service principal.").
+		BoolVar(&z.ManagedIdentity)
+	k8sAKS.Flag("managed-identity-client-id", "Client ID of the user-assigned managed identity used with --managed-identity. The system-assigned identity is used when not set.").
+		StringVar(&z.ManagedIdentityClientID)
+
+	addK8sProxyFlag(k8sAKS, dr)
+
+	k8sAKS.Command("info", "aks info -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(z.GetDeploymentVars)
+
+	// AKS Cluster operations
+	k8sAKSCluster := k8sAKS.Command("cluster", "manage AKS clusters").
+		Action(z.NewAKSClient).
+		Action(z.AKSDeploymentParse)
+	k8sAKSCluster.Command("create", "aks cluster create -a credentials -f FileOrFolder -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test").
+		Action(z.ClusterCreate)
+	k8sAKSCluster.Command("delete", "aks cluster delete -a credentials -f FileOrFolder -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test").
+		Action(z.ClusterDelete)
+
+	// Cluster node-pool operations
+	k8sAKSNodePool := k8sAKS.Command("nodes", "manage AKS clusters nodepools").
+		Action(z.NewAKSClient).
+		Action(z.AKSDeploymentParse)
+	k8sAKSNodePool.Command("create", "aks nodes create -a credentials -f FileOrFolder -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test").
+		Action(z.NodePoolCreate)
+	k8sAKSNodePoolDelete := k8sAKSNodePool.Command("delete", "aks nodes delete -a credentials -f FileOrFolder -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test [--name prom10]").
+		Action(z.NodePoolDelete)
+	k8sAKSNodePoolDelete.Flag("name", "Name of a node pool to delete instead of the node pools from the cluster file. Can be repeated.").
+		StringsVar(&z.NodePoolNames)
+	k8sAKSNodePoolDelete.Flag("force", "Allow deleting the last remaining node pools of the cluster.").
+		BoolVar(&z.Force)
+	k8sAKSNodePool.Command("check-running", "aks nodes check-running -a credentials -f FileOrFolder -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test").
+		Action(z.AllNodePoolsRunning)
+	k8sAKSNodePool.Command("check-deleted", "aks nodes check-deleted -a credentials -f FileOrFolder -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test").
+		Action(z.AllNodePoolsDeleted)
+
+	// K8s resource operations.
+	k8sAKSResource := k8sAKS.Command("resource", `Apply and delete different k8s resources - deployments, services, config maps etc.Required variables -v AZURE_SUBSCRIPTION_ID:sub -v AZURE_RESOURCE_GROUP:prombench -v ZONE:westeurope -v CLUSTER_NAME:test `).
+		Action(z.NewAKSClient).
+		Action(z.K8SDeploymentsParse).
+		Action(z.NewK8sProvider)
+	k8sAKSResourceApply := k8sAKSResource.Command("apply", "aks resource apply -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(z.ResourceApply)
+	addInjectFlags(k8sAKSResourceApply, dr)
+	addWaitFlag(k8sAKSResourceApply, dr)
+	addImagePreflightFlag(k8sAKSResourceApply, dr)
+	addImmutablePreflightFlag(k8sAKSResourceApply, dr)
+	addEstablishFlag(k8sAKSResourceApply, dr)
+	addVPAFlag(k8sAKSResourceApply, dr)
+	addServerSideFlags(k8sAKSResourceApply, dr)
+	addPruneFlags(k8sAKSResourceApply, dr)
+	addHelmFlags(k8sAKSResourceApply, dr)
+	k8sAKSResourceApply.Flag("reconcile-interval", "Keep running after the apply and re-apply the objects that drifted from the manifests at this interval, until interrupted. 0 disables it.").
+		Default("0").
+		DurationVar(&dr.ReconcileInterval)
+	k8sAKSResourceDelete := k8sAKSResource.Command("delete", "aks resource delete -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(z.ResourceDelete)
+	addHelmFlags(k8sAKSResourceDelete, dr)
+	addDeleteFlags(k8sAKSResourceDelete, dr)
+	k8sAKSResourceStatus := k8sAKSResource.Command("status", "aks resource status -a credentials -f manifestsFileOrFolder -v hashStable:COMMIT1 -v hashTesting:COMMIT2").
+		Action(z.ResourceStatus)
+	addHelmFlags(k8sAKSResourceStatus, dr)
+
+	// Standalone apply to the cluster of a kubeconfig.
+	a := k8s.NewStandalone(dr)
+	k8sApply := app.Command("apply", "Apply the manifests once to the cluster of a kubeconfig, without a cloud provider. ex: infra apply -f manifestsFileOrFolder -v hashStable:COMMIT1 --context prombench").
+		Action(a.Apply)
+	k8sApply.Flag("kubeconfig", "kubeconfig file used to connect to the cluster.").
+		Default(a.Kubeconfig).
+		StringVar(&a.Kubeconfig)
+	k8sApply.Flag("context", "kubeconfig context used to connect to the cluster. Defaults to the current context.").
+		StringVar(&a.KubeContext)
+	addInjectFlags(k8sApply, dr)
+	addWaitFlag(k8sApply, dr)
+	addImagePreflightFlag(k8sApply, dr)
+	addImmutablePreflightFlag(k8sApply, dr)
+	addEstablishFlag(k8sApply, dr)
+	addVPAFlag(k8sApply, dr)
+	addServerSideFlags(k8sApply, dr)
+	k8sApply.Flag("replicas", "Replicas of all deployments, statefulsets and replicasets in the manifests, e.g. 0 to apply them scaled down. Negative keeps the replicas of the manifests.").
+		Default("-1").
+		Int32Var(&dr.Replicas)
 
 	if _, err := app.Parse(os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error parsing commandline arguments"))
@@ -160,3 +642,365 @@ func main() {
 	}
 
 }
+
+// addInjectFlags adds the flags for the labels, annotations and owner reference injected into the applied objects.
+func addInjectFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("inject-label", "Label added to every applied object, e.g. prombench/run-id:1234. Can be repeated.").
+		StringMapVar(&dr.InjectLabels)
+	cmd.Flag("inject-annotation", "Annotation added to every applied object, e.g. prombench/run-id:1234. Can be repeated.").
+		StringMapVar(&dr.InjectAnnotations)
+	cmd.Flag("force-inject", "Overwrite the labels and annotations already set in the manifests with the injected values.").
+		BoolVar(&dr.ForceInject)
+	cmd.Flag("owner", "Existing object set as the owner of the applied objects in its namespace, as kind/name or kind.group/name, e.g. ConfigMap/prombench-10. Deleting the owner deletes them.").
+		StringVar(&dr.Owner)
+	cmd.Flag("owner-namespace", "Namespace of the --owner object.").
+		Default("default").
+		StringVar(&dr.OwnerNamespace)
+}
+
+// addWaitFlag adds the flag that skips waiting for the applied workloads to become ready.
+func addWaitFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("no-wait", "Return once the objects are applied instead of waiting for the deployments, statefulsets and daemonsets to become ready and the jobs to complete.").
+		BoolVar(&dr.NoWait)
+}
+
+// addDeleteFlags adds the flags for the grace period of the objects deleted by resource delete.
+func addDeleteFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("grace-period", "Grace period in seconds of the deleted objects, negative keeps the grace period of the objects, e.g. the terminationGracePeriodSeconds of the pods. 0 requires --force.").
+		Default("-1").
+		Int64Var(&dr.DeleteGracePeriod)
+	cmd.Flag("force", "Delete the pods of the deleted deployments, statefulsets, daemonsets, jobs and namespaces immediately, without waiting for their containers to stop. Removes pods stuck terminating.").
+		BoolVar(&dr.ForceDelete)
+	cmd.Flag("bulk-delete-qps", "Delete all objects of the --bulk-delete-kind kinds in the namespaces of the manifests at most this many per second, then the other objects of the manifests and the namespaces last. 0 only deletes the objects of the manifests.").
+		Default("0").
+		Float64Var(&dr.BulkDeleteQPS)
+	cmd.Flag("bulk-delete-kind", "Kind deleted by --bulk-delete-qps, as kind or kind.group, in the order they are deleted. Can be repeated.").
+		Default(k8s.DefaultBulkDeleteKinds...).
+		StringsVar(&dr.BulkDeleteKinds)
+	cmd.Flag("bulk-delete-progress", "How often --bulk-delete-qps logs the deleted objects, the rate and the estimated time left. 0 only logs the totals.").
+		Default("10s").
+		DurationVar(&dr.BulkDeleteProgress)
+}
+
+// addDependentsFlag adds the flag that keeps the cloud resources the cluster created for its objects when it is deleted.
+func addDependentsFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("keep-dependents", "Keep the load balancers, disks and addresses the cluster created for its objects. By default they are removed once the cluster is deleted.").
+		BoolVar(&dr.KeepDependents)
+}
+
+// addImagePreflightFlag adds the flag that checks the images of the manifests before applying them.
+func addImagePreflightFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("check-images", "Check that the container images of the manifests exist in their registries before applying anything, and fail listing the missing ones.").
+		BoolVar(&dr.ImagePreflight)
+}
+
+// addImmutablePreflightFlag adds the flag that checks the manifests for changes of immutable fields before applying them.
+func addImmutablePreflightFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("check-immutable", "Compare the manifests with the live objects before applying anything, and fail listing the objects and fields whose change needs the object to be deleted and created again.").
+		BoolVar(&dr.ImmutablePreflight)
+}
+
+// addEstablishFlag adds the flag that applies the CRDs and waits for them to be established before the rest of the objects.
+func addEstablishFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("wait-established", "Apply the CRDs of the manifests first and wait up to this long for them to be established before applying the rest of the objects, e.g. the deployment of an operator that watches them. Fails listing the CRDs that weren't established. 0 applies all objects in order.").
+		Default("0s").
+		DurationVar(&dr.EstablishTimeout)
+}
+
+// addVPAFlag adds the flag that waits for the recommendations of the applied VerticalPodAutoscalers.
+func addVPAFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("wait-vpa-recommendation", "Wait up to this long after the apply for every VerticalPodAutoscaler of the manifests to have a recommendation, and fail listing the ones without. 0 doesn't wait.").
+		Default("0s").
+		DurationVar(&dr.VPARecommendationTimeout)
+}
+
+// addServerSideFlags adds the flags that apply the objects with a server-side apply and prune the fields removed from the manifests.
+func addServerSideFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("server-side", "Apply the objects with a server-side apply as the infra field manager instead of creating or updating them, taking over the fields other managers own.").
+		BoolVar(&dr.ServerSideApply)
+	cmd.Flag("prune-fields", "With --server-side first remove the fields the earlier applies set that the manifests no longer do and no other field manager owns, so the objects match the manifests exactly.").
+		BoolVar(&dr.PruneFields)
+}
+
+// addDNSFlags adds the flags for the DNS records pointing at the load balancers of the services.
+// resource apply creates or updates the records and resource delete deletes them.
+func addDNSFlags(cmd *kingpin.CmdClause, zone *string, records *map[string]string, zoneHelp string) {
+	cmd.Flag("dns-zone", "The "+zoneHelp+" of the --dns-record records.").
+		StringVar(zone)
+	cmd.Flag("dns-record", "DNS record pointing at the load balancer of a LoadBalancer service, in the service-name:record format. The record is relative to the --dns-zone unless it ends with a dot. Can be repeated.").
+		PlaceHolder("SERVICE:RECORD").
+		StringMapVar(records)
+}
+
+// addExistingDiskFlag adds the flag for the PersistentVolumes of existing disks.
+// resource apply creates or updates the volumes before the manifests and resource delete deletes them, the disks are kept.
+func addExistingDiskFlag(cmd *kingpin.CmdClause, disks *map[string]string, diskHelp string) {
+	cmd.Flag("existing-disk", "PersistentVolume for an existing "+diskHelp+" in the volume-name:disk format. The volume is pinned to the zone of the disk and has no storage class. Can be repeated.").
+		PlaceHolder("VOLUME:DISK").
+		StringMapVar(disks)
+}
+
+// addBootstrapFlags adds the flags for the manifests applied right after the cluster is created.
+func addBootstrapFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("bootstrap-file", "Manifest file or folder applied once the cluster is ready, e.g. namespaces, RBAC or CRDs. The -v vars are substituted. Can be repeated.").
+		ExistingFilesOrDirsVar(&dr.BootstrapFiles)
+}
+
+// addBastionFlags adds the flags for the bastion created with a private cluster, it is deleted with the cluster.
+func addBastionFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource, machineType string) {
+	cmd.Flag("bastion", "Create a bastion VM in the network of the cluster and log how to tunnel the k8s requests of a private cluster through it. Requires --ssh-public-key and --bastion-source-range.").
+		BoolVar(&dr.Bastion.Enabled)
+	cmd.Flag("bastion-machine-type", "Machine type of the bastion, "+machineType+" when not set.").
+		StringVar(&dr.Bastion.MachineType)
+	cmd.Flag("bastion-source-range", "CIDR allowed to connect to the ssh port of the bastion, e.g. the address of the CI runners. Can be repeated.").
+		StringsVar(&dr.Bastion.SourceRanges)
+}
+
+// addScrapeFirewallFlags adds the flags for the firewall rules of the scrape ports created with the cluster, they are deleted with the cluster.
+func addScrapeFirewallFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("scrape-port", "TCP port or range of ports of the nodes opened to --scrape-source-range, e.g. 9100 or 30000-30100 for the node ports scraped from another network. Can be repeated.").
+		StringsVar(&dr.ScrapeFirewall.Ports)
+	cmd.Flag("scrape-source-range", "CIDR allowed to connect to the --scrape-port ports, e.g. the network of the Prometheus that scrapes the cluster. Can be repeated.").
+		StringsVar(&dr.ScrapeFirewall.SourceRanges)
+}
+
+// addK8sProxyFlag adds the flag for the proxy of the k8s requests of the provider commands.
+func addK8sProxyFlag(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("k8s-proxy", "Proxy for the k8s requests only, e.g. socks5://localhost:1080 for the ssh tunnel to the bastion of a private cluster. Supports http, https and socks5 urls.").
+		StringVar(&dr.K8sProxy)
+}
+
+// addSpecFileFlag adds the flag for the cluster spec file of cluster create.
+func addSpecFileFlag(cmd *kingpin.CmdClause, specFile *string) {
+	cmd.Flag("spec-file", "YAML file that describes the whole cluster - name, region, node pools, addons and networking. The flags and -v vars override its fields, a repeatable flag replaces the whole list.").
+		ExistingFileVar(specFile)
+}
+
+// addFanOutFlags adds the flags for creating one cluster per region and for the info file of the created clusters.
+func addFanOutFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("regions", "Create one cluster per region with the same command, as the ZONE variable and with the region appended to CLUSTER_NAME. The regions are created concurrently and a failed region doesn't stop the others. Can be repeated.").
+		StringsVar(&dr.FanOut.Regions)
+	cmd.Flag("regions-parallelism", "Maximum number of regions of --regions created at the same time.").
+		Default("3").
+		IntVar(&dr.FanOut.Parallelism)
+	cmd.Flag("info-file", "Write the provider, name, region and endpoint of the created clusters as a JSON array to this file, with the error of every failed region of --regions.").
+		StringVar(&dr.InfoFile)
+}
+
+// addNodeServiceAccountFlag adds the flag for the service account of the GKE nodes.
+func addNodeServiceAccountFlag(cmd *kingpin.CmdClause, g *gke.GKE) {
+	cmd.Flag("node-service-account", "Email of an existing GCP service account the nodes of all node pools run as, instead of the Compute Engine default service account. It is checked before the node pools are created.").
+		StringVar(&g.NodeServiceAccount)
+}
+
+// addNodeRoleFlag adds the flag for the IAM role of the EKS nodes.
+func addNodeRoleFlag(cmd *kingpin.CmdClause, e *eks.EKS) {
+	cmd.Flag("node-role-arn", "ARN of an existing IAM role the nodes of all node groups run as, instead of the EKS_WORKER_ROLE_ARN variable. It is checked before the node groups are created.").
+		StringVar(&e.NodeRoleARN)
+}
+
+// addGKESSHKeyFlags adds the flags for the ssh access to the GKE nodes.
+func addGKESSHKeyFlags(cmd *kingpin.CmdClause, g *gke.GKE) {
+	cmd.Flag("ssh-public-key", "OpenSSH public key file added to the ssh-keys metadata of the created node pools, so --ssh-user can log in to the nodes for debugging. Off by default.").
+		PlaceHolder("id_ed25519.pub").ExistingFileVar(&g.SSHPublicKey)
+	cmd.Flag("ssh-user", "Linux user of the --ssh-public-key on the nodes.").
+		Default("prombench").StringVar(&g.SSHUser)
+}
+
+// addEKSSSHKeyFlags adds the flags for the ssh access to the EKS nodes.
+func addEKSSSHKeyFlags(cmd *kingpin.CmdClause, e *eks.EKS) {
+	cmd.Flag("ssh-public-key", "OpenSSH public key file imported as the CLUSTER_NAME-ssh EC2 key pair of the created node groups, so the nodes can be logged in to for debugging. Off by default.").
+		PlaceHolder("id_ed25519.pub").ExistingFileVar(&e.SSHPublicKey)
+	cmd.Flag("ssh-source-security-group", "Security group allowed to connect to port 22 of the nodes. Without one EKS opens the port to all addresses. Can be repeated.").
+		StringsVar(&e.SSHSourceSecurityGroups)
+}
+
+// addGKENodeStartupFlags adds the flags for a faster startup of the GKE nodes.
+func addGKENodeStartupFlags(cmd *kingpin.CmdClause, g *gke.GKE) {
+	cmd.Flag("image-streaming", "Stream the container images on the nodes of the created node pools, so containers start before their images are pulled completely. Requires the COS_CONTAINERD image type.").
+		BoolVar(&g.ImageStreaming)
+	cmd.Flag("boot-disk-type", "Boot disk type of the nodes of the created node pools - pd-standard, pd-balanced or pd-ssd. When not set the value from the cluster file or the GKE default is used.").
+		EnumVar(&g.BootDiskType, "pd-standard", "pd-balanced", "pd-ssd")
+	cmd.Flag("boot-disk-size", "Boot disk size in GB of the nodes of the created node pools, at least 10. 0 keeps the value from the cluster file or the GKE default of 100.").
+		Int32Var(&g.BootDiskSize)
+}
+
+// addDiskSizeFlag adds the flag for the root volume size of the EKS nodes.
+func addDiskSizeFlag(cmd *kingpin.CmdClause, e *eks.EKS) {
+	cmd.Flag("disk-size", "Root volume size in GB of the nodes of the created node groups, at least 20. Larger gp2 volumes get more IOPS for the image pulls. 0 keeps the value from the cluster file or the EKS default of 20.").
+		Int64Var(&e.DiskSize)
+}
+
+// addGKENodeSecurityFlags adds the flags for the shielded and confidential GKE nodes.
+func addGKENodeSecurityFlags(cmd *kingpin.CmdClause, g *gke.GKE) {
+	cmd.Flag("shielded-nodes", "Boot the nodes of the created node pools with secure boot and integrity monitoring. With cluster create it also enables Shielded GKE Nodes for the cluster.").
+		BoolVar(&g.ShieldedNodes)
+	cmd.Flag("confidential-nodes", "Run the nodes of the created node pools as Confidential VMs with encrypted memory. Requires an n2d or c2d machine type for all of these node pools.").
+		BoolVar(&g.ConfidentialNodes)
+}
+
+// addNitroEnclavesFlag adds the flag for the Nitro Enclaves of the EKS nodes.
+func addNitroEnclavesFlag(cmd *kingpin.CmdClause, e *eks.EKS) {
+	cmd.Flag("nitro-enclaves", "Enable Nitro Enclaves on the nodes of the created node groups through the CLUSTER_NAME-nitro-enclaves launch template. Requires Nitro instance types with at least 4 vCPUs and can't be combined with --ssh-public-key or --disk-size.").
+		BoolVar(&e.NitroEnclaves)
+}
+
+// addNodeSystemConfigFlag adds the flag for the kubelet and kernel config of the nodes of the created node pools.
+func addNodeSystemConfigFlag(cmd *kingpin.CmdClause, file *string) {
+	cmd.Flag("node-system-config", "Yaml file with the sysctls, evictionHard thresholds, podPidsLimit and cpuManagerPolicy of the nodes of the created node pools. Keys the provider doesn't support are rejected.").
+		PlaceHolder("node-config.yaml").ExistingFileVar(file)
+}
+
+// addDescribeFlags adds the object, namespace and managed fields flags of the describe command.
+func addDescribeFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Arg("object", "Object to print as YAML. Kinds of other groups are given as kind.group/name, e.g. Rollout.argoproj.io/loadgen.").
+		Required().
+		StringVar(&dr.DescribeObject)
+	cmd.Flag("namespace", "Namespace of the object, ignored for cluster scoped kinds.").
+		Short('n').
+		Default("default").
+		StringVar(&dr.DescribeNamespace)
+	cmd.Flag("show-managed-fields", "Keep the managed fields in the output, they are left out by default.").
+		BoolVar(&dr.ShowManagedFields)
+}
+
+// addFieldOwnersFlags adds the object and namespace of the owners command.
+func addFieldOwnersFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Arg("object", "Object whose field managers are printed. Kinds of other groups are given as kind.group/name, e.g. Prometheus.monitoring.coreos.com/k8s.").
+		Required().
+		StringVar(&dr.FieldOwnersObject)
+	cmd.Flag("namespace", "Namespace of the object, ignored for cluster scoped kinds.").
+		Short('n').
+		Default("default").
+		StringVar(&dr.FieldOwnersNamespace)
+}
+
+// addProvisioningFlags adds the opt-in flags recording how long the cluster create and delete commands took.
+func addProvisioningFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("provisioning-pushgateway", "Push the duration of the operation, labeled by provider, operation, region and nodes, to this Prometheus Pushgateway.").
+		PlaceHolder("http://pushgateway:9091").
+		StringVar(&dr.Provisioning.PushgatewayURL)
+	cmd.Flag("provisioning-results", "Append the duration of the operation as a JSON line to this file.").
+		StringVar(&dr.Provisioning.ResultsFile)
+}
+
+// addRunSummaryFlags adds the flags of the summary of the deleted clusters written by cluster delete.
+func addRunSummaryFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("summary-file", "Write the provider, region, node pools, lifetime, labels and estimated cost of the deleted clusters as JSON to this file.").
+		StringVar(&dr.RunSummary.File)
+	cmd.Flag("node-price", "Hourly price of a node of a machine type for the estimated cost of --summary-file, e.g. n1-standard-8=0.38. Can be repeated.").
+		PlaceHolder("TYPE=PRICE").
+		StringMapVar(&dr.RunSummary.NodePrices)
+	cmd.Flag("summary-label", "Key of a cluster label or tag copied to --summary-file, e.g. pr-number. Can be repeated, all labels are copied when not set.").
+		StringsVar(&dr.RunSummary.Labels)
+}
+
+// addLogsFlags adds the pod, container, namespace and follow flags of the logs command.
+func addLogsFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Arg("pod", "Pod to print the logs of.").
+		Required().
+		StringVar(&dr.LogsPod)
+	cmd.Flag("container", "Container of the pod, can be left out for single container pods.").
+		Short('c').
+		StringVar(&dr.LogsContainer)
+	cmd.Flag("namespace", "Namespace of the pod.").
+		Short('n').
+		Default("default").
+		StringVar(&dr.LogsNamespace)
+	cmd.Flag("follow", "Keep streaming the logs until interrupted, reconnecting when the pod restarts.").
+		BoolVar(&dr.FollowLogs)
+}
+
+// addNodeArg adds the node arg of the cordon, uncordon and drain commands.
+func addNodeArg(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Arg("node", "Name of the node.").
+		Required().
+		StringVar(&dr.NodeName)
+}
+
+// addDrainFlags adds the node arg and the timeout flag of the drain command.
+func addDrainFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	addNodeArg(cmd, dr)
+	cmd.Flag("timeout", "How long to retry the evictions blocked by disruption budgets and wait for the pods to terminate. The node stays cordoned on timeout.").
+		Default("5m").
+		DurationVar(&dr.DrainTimeout)
+}
+
+// addNodePoolReplaceFlags adds the old pool arg and the replacement, surge and drain timeout flags of the nodes replace command.
+func addNodePoolReplaceFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource, pool string) {
+	cmd.Arg("pool", fmt.Sprintf("Name of the %s to replace.", pool)).
+		Required().
+		StringVar(&dr.ReplacePool)
+	cmd.Flag("with", fmt.Sprintf("Name of the %s of the cluster file created as the replacement. Defaults to the only %s of the file other than the replaced one.", pool, pool)).
+		StringVar(&dr.ReplaceWith)
+	cmd.Flag("surge", fmt.Sprintf("Number of nodes of the old %s drained at the same time, once all of them are cordoned.", pool)).
+		Default("1").
+		IntVar(&dr.ReplaceSurge)
+	cmd.Flag("drain-timeout", "How long to retry the evictions of a node blocked by disruption budgets and wait for its pods to terminate. On timeout the replacement stops with the old nodes cordoned.").
+		Default("5m").
+		DurationVar(&dr.DrainTimeout)
+}
+
+// addWaitConditionFlags adds the object, namespace, condition and timeout flags of the wait command.
+func addWaitConditionFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Arg("object", "Object to wait for. Kinds of other groups are given as kind.group/name, e.g. Prometheus.monitoring.coreos.com/k8s.").
+		Required().
+		StringVar(&dr.WaitObject)
+	cmd.Flag("namespace", "Namespace of the object, ignored for cluster scoped kinds.").
+		Short('n').
+		Default("default").
+		StringVar(&dr.WaitNamespace)
+	cmd.Flag("for", "Status condition to wait for, as condition=Type or condition=Type=Status. The status defaults to True.").
+		Required().
+		PlaceHolder("condition=Ready").
+		StringVar(&dr.WaitCondition)
+	cmd.Flag("timeout", "How long to wait before failing with the last observed condition.").
+		Default("5m").
+		DurationVar(&dr.WaitTimeout)
+}
+
+// addMetadataFlags adds the object, selector and namespace of the label and annotate commands,
+// and the key=value args merged into the labels or annotations of the objects.
+func addMetadataFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource, field string, values *map[string]string) {
+	cmd.Arg("object", "Object to patch as kind/name, or a kind with --selector. Kinds of other groups are given as kind.group, e.g. Prometheus.monitoring.coreos.com/k8s.").
+		Required().
+		StringVar(&dr.MetadataObject)
+	cmd.Arg(field, "The "+field+" to add as key=value, the other "+field+" of the objects are kept.").
+		Required().
+		StringMapVar(values)
+	cmd.Flag("selector", "Label selector of the objects of the kind to patch, e.g. app=loadgen.").
+		Short('l').
+		StringVar(&dr.MetadataSelector)
+	cmd.Flag("namespace", "Namespace of the objects, ignored for cluster scoped kinds.").
+		Short('n').
+		Default("default").
+		StringVar(&dr.MetadataNamespace)
+}
+
+// addPruneFlags adds the flags for pruning the objects removed from the manifests after an apply.
+func addPruneFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("prune", "Delete the objects that match --prune-selector but are no longer in the manifests. Only the --prune-whitelist kinds are deleted.").
+		BoolVar(&dr.Prune)
+	cmd.Flag("prune-selector", "Label selector of the objects managed by the manifests, required with --prune, e.g. prombench/run-id=1234.").
+		StringVar(&dr.PruneSelector)
+	cmd.Flag("prune-whitelist", "Kind that can be pruned, as kind or kind.group, e.g. StatefulSet or Rollout.argoproj.io. Can be repeated.").
+		Default(k8s.DefaultPruneKinds...).
+		StringsVar(&dr.PruneKinds)
+	cmd.Flag("prune-dry-run", "List the objects --prune would delete, with their kind, namespace, name and age, without deleting anything. Requires --prune-selector.").
+		BoolVar(&dr.PruneDryRun)
+}
+
+// addHelmFlags adds the flags for the Helm charts rendered and applied together with the deployment files.
+func addHelmFlags(cmd *kingpin.CmdClause, dr *provider.DeploymentResource) {
+	cmd.Flag("helm-chart", "Helm chart directory or packaged chart to render and apply together with the deployment files. Can be repeated.").
+		ExistingFilesOrDirsVar(&dr.Helm.Charts)
+	cmd.Flag("helm-values", "Helm values file for the charts. Can be repeated, later files take precedence.").
+		ExistingFilesVar(&dr.Helm.ValuesFiles)
+	cmd.Flag("helm-set", "Helm value in the helm --set format, e.g. server.replicas=2. Can be repeated and takes precedence over the values files.").
+		StringsVar(&dr.Helm.Set)
+	cmd.Flag("helm-release", "Release name used to render the charts. Defaults to the chart name.").
+		StringVar(&dr.Helm.ReleaseName)
+	cmd.Flag("helm-namespace", "Release namespace used to render the charts.").
+		Default("default").
+		StringVar(&dr.Helm.Namespace)
+}
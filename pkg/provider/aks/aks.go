@@ -0,0 +1,594 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+	k8sProvider "github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+type Resource = provider.Resource
+
+// agentPoolNamePattern follows the AKS API for the Linux agent pools: lowercase letters and digits, up to 12 characters.
+var agentPoolNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]{0,11}$`)
+
+// managedCluster is a Microsoft.ContainerService/managedClusters resource,
+// the identity and the properties are sent as they are in the cluster file,
+// see https://learn.microsoft.com/en-us/rest/api/aks/managed-clusters/create-or-update.
+type managedCluster struct {
+	Name       string                 `json:"name"`
+	Location   string                 `json:"location,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+	Identity   map[string]interface{} `json:"identity,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// agentPool is an agent pool of a cluster, the properties are sent as they are in the cluster file,
+// see https://learn.microsoft.com/en-us/rest/api/aks/agent-pools/create-or-update.
+type agentPool struct {
+	Name       string                 `json:"name"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type aksCluster struct {
+	Cluster   managedCluster `json:"cluster"`
+	NodePools []agentPool    `json:"nodepools"`
+}
+
+// AKS holds the fields used to generate an API request.
+type AKS struct {
+	Auth string
+	// Authenticate as the managed identity of the VM instead of the service principal of the auth.
+	// ManagedIdentityClientID selects a user-assigned identity, the system-assigned identity is used when empty.
+	ManagedIdentity         bool
+	ManagedIdentityClientID string
+	// Node pools to delete by name instead of the ones in the cluster deployment file.
+	NodePoolNames []string
+	// Force allows deleting the last remaining node pools of a cluster.
+	Force bool
+
+	// The ARM client used when performing AKS requests.
+	clientARM *armClient
+	// The k8s provider used when we work with the manifest files.
+	k8sProvider *k8sProvider.K8s
+	// Final DeploymentFiles files.
+	DeploymentFiles []string
+	// Final DeploymentVars.
+	DeploymentVars map[string]string
+	// DeployResource to construct DeploymentVars and DeploymentFiles
+	DeploymentResource *provider.DeploymentResource
+	// Content bytes after parsing the template variables, grouped by filename.
+	aksResources []Resource
+	// K8s resource.runtime objects after parsing the template variables, grouped by filename.
+	k8sResources []k8sProvider.Resource
+
+	ctx context.Context
+}
+
+// New is the AKS constructor
+func New(dr *provider.DeploymentResource) *AKS {
+	return &AKS{
+		DeploymentResource: dr,
+	}
+}
+
+// NewAKSClient sets the ARM client used when performing the AKS requests.
+func (c *AKS) NewAKSClient(*kingpin.ParseContext) error {
+	cred, err := c.credentials()
+	if err != nil {
+		return err
+	}
+	c.ctx = context.Background()
+	c.clientARM = newARMClient(c.ctx, cred.tokenSource(c.ctx), c.DeploymentVars["AZURE_SUBSCRIPTION_ID"], c.DeploymentVars["AZURE_RESOURCE_GROUP"])
+	return nil
+}
+
+// credentials returns the managed identity or the service principal of the auth.
+func (c *AKS) credentials() (credentials, error) {
+	if c.ManagedIdentity {
+		if c.Auth != "" {
+			return credentials{}, errors.Errorf("--auth and --managed-identity can't be used together")
+		}
+		return credentials{ManagedIdentity: true, ClientID: c.ManagedIdentityClientID}, nil
+	}
+	if c.ManagedIdentityClientID != "" {
+		return credentials{}, errors.Errorf("--managed-identity-client-id requires --managed-identity")
+	}
+	if f := c.DeploymentResource.CredentialsFile; c.Auth == "" && f != "" {
+		auth, err := provider.CredentialsAuth(f, "aks")
+		if err != nil {
+			return credentials{}, err
+		}
+		c.Auth = auth
+	} else if c.Auth == "" {
+		if c.Auth = os.Getenv("AZURE_APPLICATION_CREDENTIALS"); c.Auth == "" {
+			return credentials{}, errors.Errorf("no auth provided set the auth flag, --credentials-file, the AZURE_APPLICATION_CREDENTIALS env variable or --managed-identity")
+		}
+	}
+
+	// When the auth variable points to a file
+	// put the file content in the variable.
+	if content, err := os.ReadFile(c.Auth); err == nil {
+		c.Auth = string(content)
+	}
+
+	// Check if auth data is base64 encoded and decode it.
+	encoded, err := regexp.MatchString("^([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{3}=|[A-Za-z0-9+/]{2}==)?$", c.Auth)
+	if err != nil {
+		return credentials{}, err
+	}
+	if encoded {
+		auth, err := base64.StdEncoding.DecodeString(c.Auth)
+		if err != nil {
+			return credentials{}, errors.Wrap(err, "could not decode auth data")
+		}
+		c.Auth = string(auth)
+	}
+	return parseServicePrincipal(c.Auth)
+}
+
+// checkDeploymentVarsAndFiles checks whether the requied deployment vars are passed.
+func (c *AKS) checkDeploymentVarsAndFiles() error {
+	reqDepVars := []string{"AZURE_SUBSCRIPTION_ID", "AZURE_RESOURCE_GROUP", "ZONE", "CLUSTER_NAME"}
+	for _, k := range reqDepVars {
+		if v := c.DeploymentVars[k]; v == "" {
+			return fmt.Errorf("missing required %v variable", k)
+		}
+	}
+	if len(c.DeploymentFiles) == 0 && len(c.DeploymentResource.Helm.Charts) == 0 {
+		return fmt.Errorf("missing deployment file(s)")
+	}
+	return nil
+}
+
+// SetupDeploymentResources Sets up DeploymentVars and DeploymentFiles
+func (c *AKS) SetupDeploymentResources(*kingpin.ParseContext) error {
+	c.DeploymentFiles = c.DeploymentResource.DeploymentFiles
+	c.DeploymentVars = provider.MergeDeploymentVars(
+		c.DeploymentResource.DefaultDeploymentVars,
+		c.DeploymentResource.FlagDeploymentVars,
+	)
+	return provider.ApplyClusterNameSuffix(c.DeploymentVars, c.DeploymentResource.ClusterNameSuffix, provider.AKSClusterNameRules)
+}
+
+// AKSDeploymentParse parses the cluster/nodepools deployment file and saves the result as bytes grouped by the filename.
+// Any variables passed to the cli will be replaced in the resource files following the golang text template format.
+func (c *AKS) AKSDeploymentParse(*kingpin.ParseContext) error {
+	if err := c.checkDeploymentVarsAndFiles(); err != nil {
+		return err
+	}
+
+	deploymentResource, err := provider.DeploymentsParse(c.DeploymentFiles, c.DeploymentVars)
+	if err != nil {
+		return fmt.Errorf("Couldn't parse deployment files: %v", err)
+	}
+
+	c.aksResources = deploymentResource
+	return nil
+}
+
+// K8SDeploymentsParse parses the k8s objects deployment files and saves the result as k8s objects grouped by the filename.
+// Any variables passed to the cli will be replaced in the resources files following the golang text template format.
+func (c *AKS) K8SDeploymentsParse(*kingpin.ParseContext) error {
+	if err := c.checkDeploymentVarsAndFiles(); err != nil {
+		return err
+	}
+
+	deploymentResource, err := provider.DeploymentsParse(c.DeploymentFiles, c.DeploymentVars)
+	if err != nil {
+		return fmt.Errorf("Couldn't parse deployment files: %v", err)
+	}
+
+	helmResources, err := provider.HelmTemplate(c.DeploymentResource.Helm)
+	if err != nil {
+		return fmt.Errorf("Couldn't render the helm charts: %v", err)
+	}
+	deploymentResource = append(deploymentResource, helmResources...)
+
+	for _, deployment := range deploymentResource {
+
+		k8sObjects := make([]runtime.Object, 0)
+
+		for _, text := range strings.Split(string(deployment.Content), provider.Separator) {
+			text = strings.TrimSpace(text)
+			if len(text) == 0 {
+				continue
+			}
+
+			resource, err := k8sProvider.Decode([]byte(text))
+
+			if err != nil {
+				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, provider.Truncate(text, 100))
+			}
+			if resource == nil {
+				continue
+			}
+			k8sObjects = append(k8sObjects, resource)
+		}
+		if len(k8sObjects) > 0 {
+			c.k8sResources = append(c.k8sResources, k8sProvider.Resource{FileName: deployment.FileName, Objects: k8sObjects})
+		}
+	}
+	return nil
+}
+
+// parseCluster parses a cluster deployment file, the cluster location defaults to the ZONE variable,
+// the DNS prefix to the cluster name and the identity to a system-assigned managed identity.
+func (c *AKS) parseCluster(deployment Resource) (*aksCluster, error) {
+	req := &aksCluster{}
+	if err := yaml.UnmarshalStrict(deployment.Content, req); err != nil {
+		return nil, fmt.Errorf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
+	}
+	if req.Cluster.Name == "" {
+		return nil, fmt.Errorf("Error parsing the cluster deployment file %s: missing the cluster name", deployment.FileName)
+	}
+	if req.Cluster.Location == "" {
+		req.Cluster.Location = c.DeploymentVars["ZONE"]
+	}
+	if req.Cluster.Identity == nil {
+		req.Cluster.Identity = map[string]interface{}{"type": "SystemAssigned"}
+	}
+	if req.Cluster.Properties == nil {
+		req.Cluster.Properties = map[string]interface{}{}
+	}
+	if _, ok := req.Cluster.Properties["dnsPrefix"]; !ok {
+		req.Cluster.Properties["dnsPrefix"] = req.Cluster.Name
+	}
+	names := map[string]bool{}
+	for _, p := range req.NodePools {
+		if !agentPoolNamePattern.MatchString(p.Name) {
+			return nil, fmt.Errorf("Invalid node pool name %q in %s, it must contain only lowercase letters and digits, start with a letter and be up to 12 characters long", p.Name, deployment.FileName)
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("Duplicate node pool name %q in %s", p.Name, deployment.FileName)
+		}
+		names[p.Name] = true
+	}
+	return req, nil
+}
+
+// ClusterCreate create a new cluster or applies changes to an existing cluster.
+func (c *AKS) ClusterCreate(*kingpin.ParseContext) error {
+	for _, deployment := range c.aksResources {
+		req, err := c.parseCluster(deployment)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Cluster create request: name:'%s', location:'%s'", req.Cluster.Name, req.Cluster.Location)
+		body := map[string]interface{}{
+			"location":   req.Cluster.Location,
+			"tags":       req.Cluster.Tags,
+			"identity":   req.Cluster.Identity,
+			"properties": req.Cluster.Properties,
+		}
+		if err := c.clientARM.do(http.MethodPut, c.clientARM.clusterPath(req.Cluster.Name), body, nil); err != nil {
+			return fmt.Errorf("Couldn't create cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+
+		err = provider.RetryUntilTrue(
+			fmt.Sprintf("creating cluster:%v", req.Cluster.Name),
+			provider.AKSRetryCount,
+			func() (bool, error) { return c.clusterRunning(req.Cluster.Name) },
+		)
+		if err != nil {
+			return fmt.Errorf("creating cluster err:%v", err)
+		}
+
+		for _, pool := range req.NodePools {
+			if err := c.createNodePool(req.Cluster.Name, pool, deployment.FileName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ClusterDelete deletes an aks cluster together with its node pools.
+func (c *AKS) ClusterDelete(*kingpin.ParseContext) error {
+	for _, deployment := range c.aksResources {
+		req, err := c.parseCluster(deployment)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Removing cluster '%v'", req.Cluster.Name)
+		if err := c.clientARM.do(http.MethodDelete, c.clientARM.clusterPath(req.Cluster.Name), nil, nil); err != nil {
+			return fmt.Errorf("Couldn't delete cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+
+		err = provider.RetryUntilTrue(
+			fmt.Sprintf("deleting cluster:%v", req.Cluster.Name),
+			provider.AKSRetryCount,
+			func() (bool, error) {
+				return c.deleted(c.clientARM.clusterPath(req.Cluster.Name), "Cluster '"+req.Cluster.Name+"'")
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("removing cluster err:%v", err)
+		}
+	}
+	return nil
+}
+
+// clusterRunning checks whether a cluster is provisioned.
+func (c *AKS) clusterRunning(name string) (bool, error) {
+	return c.provisioned(c.clientARM.clusterPath(name), fmt.Sprintf("Cluster '%v'", name))
+}
+
+// provisioned checks whether the cluster or the agent pool at the path is provisioned,
+// it fails when the provisioning failed or was canceled.
+func (c *AKS) provisioned(path, what string) (bool, error) {
+	state, err := c.clientARM.provisioningState(path)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Couldn't get the status of %v: %v", what, err)
+	}
+	switch state {
+	case stateSucceeded:
+		return true, nil
+	case stateFailed, stateCanceled:
+		return false, fmt.Errorf("%v not in a status to become ready - %s", what, state)
+	}
+	log.Printf("%v status: %v", what, state)
+	return false, nil
+}
+
+// deleted checks whether the cluster or the agent pool at the path is deleted.
+func (c *AKS) deleted(path, what string) (bool, error) {
+	state, err := c.clientARM.provisioningState(path)
+	if err != nil {
+		if isNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("Couldn't get the status of %v: %v", what, err)
+	}
+	log.Printf("%v status: %v", what, state)
+	return false, nil
+}
+
+// NodePoolCreate creates new k8s node pools in an existing cluster.
+func (c *AKS) NodePoolCreate(*kingpin.ParseContext) error {
+	for _, deployment := range c.aksResources {
+		req, err := c.parseCluster(deployment)
+		if err != nil {
+			return err
+		}
+		for _, pool := range req.NodePools {
+			if err := c.createNodePool(req.Cluster.Name, pool, deployment.FileName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createNodePool creates the agent pool in the cluster and waits until it is provisioned.
+func (c *AKS) createNodePool(clusterName string, pool agentPool, fileName string) error {
+	log.Printf("Node pool create request: NodePoolName: '%s', ClusterName: '%s'", pool.Name, clusterName)
+	path := c.clientARM.agentPoolPath(clusterName, pool.Name)
+	if err := c.clientARM.do(http.MethodPut, path, map[string]interface{}{"properties": pool.Properties}, nil); err != nil {
+		return fmt.Errorf("Couldn't create node pool '%s' for cluster '%s', file:%v ,err: %v", pool.Name, clusterName, fileName, err)
+	}
+
+	err := provider.RetryUntilTrue(
+		fmt.Sprintf("creating node pool:%s for cluster:%s", pool.Name, clusterName),
+		provider.AKSRetryCount,
+		func() (bool, error) { return c.nodePoolRunning(clusterName, pool.Name) },
+	)
+	if err != nil {
+		return fmt.Errorf("creating node pool err:%v", err)
+	}
+	return nil
+}
+
+// NodePoolDelete deletes k8s node pools in an existing cluster.
+func (c *AKS) NodePoolDelete(*kingpin.ParseContext) error {
+	for _, deployment := range c.aksResources {
+		req, err := c.parseCluster(deployment)
+		if err != nil {
+			return err
+		}
+
+		names := c.NodePoolNames
+		if len(names) == 0 {
+			for _, pool := range req.NodePools {
+				names = append(names, pool.Name)
+			}
+		}
+
+		existing, err := c.clientARM.listAgentPools(req.Cluster.Name)
+		if err != nil {
+			return fmt.Errorf("Couldn't list the node pools of cluster '%s', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := provider.ValidateNodePoolDelete(existing, names, c.Force); err != nil {
+			return fmt.Errorf("Couldn't delete node pools of cluster '%s', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+
+		for _, name := range names {
+			log.Printf("Node pool delete request: NodePoolName: '%s', ClusterName: '%s'", name, req.Cluster.Name)
+			path := c.clientARM.agentPoolPath(req.Cluster.Name, name)
+			if err := c.clientARM.do(http.MethodDelete, path, nil, nil); err != nil {
+				return fmt.Errorf("Couldn't delete node pool '%s' for cluster '%s', file:%v ,err: %v", name, req.Cluster.Name, deployment.FileName, err)
+			}
+			err = provider.RetryUntilTrue(
+				fmt.Sprintf("deleting node pool:%s for cluster:%s", name, req.Cluster.Name),
+				provider.AKSRetryCount,
+				func() (bool, error) { return c.nodePoolDeleted(req.Cluster.Name, name) },
+			)
+			if err != nil {
+				return fmt.Errorf("deleting node pool err:%v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *AKS) nodePoolRunning(clusterName, name string) (bool, error) {
+	return c.provisioned(c.clientARM.agentPoolPath(clusterName, name), fmt.Sprintf("Node pool '%v' for Cluster '%v'", name, clusterName))
+}
+
+func (c *AKS) nodePoolDeleted(clusterName, name string) (bool, error) {
+	return c.deleted(c.clientARM.agentPoolPath(clusterName, name), fmt.Sprintf("Node pool '%v' for Cluster '%v'", name, clusterName))
+}
+
+// AllNodePoolsRunning returns an error if at least one node pool is not running
+func (c *AKS) AllNodePoolsRunning(*kingpin.ParseContext) error {
+	for _, deployment := range c.aksResources {
+		req, err := c.parseCluster(deployment)
+		if err != nil {
+			return err
+		}
+		for _, pool := range req.NodePools {
+			isRunning, err := c.nodePoolRunning(req.Cluster.Name, pool.Name)
+			if err != nil {
+				return fmt.Errorf("error fetching node pool info: %v", err)
+			}
+			if !isRunning {
+				return fmt.Errorf("nodepool not running name: %v", pool.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// AllNodePoolsDeleted returns an error if at least one node pool is not deleted
+func (c *AKS) AllNodePoolsDeleted(*kingpin.ParseContext) error {
+	for _, deployment := range c.aksResources {
+		req, err := c.parseCluster(deployment)
+		if err != nil {
+			return err
+		}
+		for _, pool := range req.NodePools {
+			isDeleted, err := c.nodePoolDeleted(req.Cluster.Name, pool.Name)
+			if err != nil {
+				return fmt.Errorf("error fetching node pool info: %v", err)
+			}
+			if !isDeleted {
+				return fmt.Errorf("nodepool not deleted name: %v", pool.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// NewK8sProvider sets the k8s provider used for deploying k8s manifests
+func (c *AKS) NewK8sProvider(*kingpin.ParseContext) error {
+	k, err := c.K8sClient()
+	if err != nil {
+		return err
+	}
+	c.k8sProvider = k
+	return nil
+}
+
+// K8sClient returns a k8s client for the cluster with the admin credentials from the AKS API,
+// so the manifests can be applied right after creating the cluster without a kubeconfig file.
+func (c *AKS) K8sClient() (*k8sProvider.K8s, error) {
+	kubeconfig, err := c.clientARM.adminKubeconfig(c.DeploymentVars["CLUSTER_NAME"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the cluster credentials: %v", err)
+	}
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the cluster kubeconfig: %v", err)
+	}
+	if proxy := c.DeploymentResource.K8sProxy; proxy != "" {
+		for _, cluster := range config.Clusters {
+			cluster.ProxyURL = proxy
+		}
+	}
+
+	k, err := k8sProvider.NewForDeployment(c.ctx, config, c.DeploymentResource)
+	if err != nil {
+		return nil, fmt.Errorf("k8s provider error %v", err)
+	}
+	return k, nil
+}
+
+// ResourceApply calls k8s.ResourceApply to apply the k8s objects in the manifest files.
+func (c *AKS) ResourceApply(*kingpin.ParseContext) error {
+	if err := c.k8sProvider.ApplyAndWaitEstablished(c.k8sResources, c.DeploymentResource.EstablishTimeout); err != nil {
+		return fmt.Errorf("error while applying a resource err: %v", err)
+	}
+	if c.DeploymentResource.PruneDryRun {
+		out, err := c.k8sProvider.PruneDryRun(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds)
+		if err != nil {
+			return fmt.Errorf("error while listing the objects to prune err: %v", err)
+		}
+		if _, err := os.Stdout.Write(out); err != nil {
+			return fmt.Errorf("error while writing the objects to prune err: %v", err)
+		}
+	} else if c.DeploymentResource.Prune {
+		if err := c.k8sProvider.Prune(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds); err != nil {
+			return fmt.Errorf("error while pruning objects err: %v", err)
+		}
+	}
+	if c.DeploymentResource.ReconcileInterval > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.k8sProvider.ReconcileLoop(ctx, c.k8sResources, c.DeploymentResource.ReconcileInterval)
+	}
+	return nil
+}
+
+// ResourceDelete calls k8s.ResourceDelete to apply the k8s objects in the manifest files.
+func (c *AKS) ResourceDelete(*kingpin.ParseContext) error {
+	if err := c.k8sProvider.ResourceDelete(c.k8sResources); err != nil {
+		return fmt.Errorf("error while deleting objects from a manifest file err: %v", err)
+	}
+	return nil
+}
+
+// ResourceStatus calls k8s.Status to print the readiness of the k8s objects in the manifest files,
+// it fails when an object is missing or not ready.
+func (c *AKS) ResourceStatus(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Status(c.k8sResources)
+	if _, werr := os.Stdout.Write(out); werr != nil {
+		return fmt.Errorf("error while writing the status err: %v", werr)
+	}
+	if err != nil {
+		return fmt.Errorf("error while checking the status of the objects err: %v", err)
+	}
+	return nil
+}
+
+// GetDeploymentVars shows deployment variables.
+func (c *AKS) GetDeploymentVars(*kingpin.ParseContext) error {
+	fmt.Print("-------------------\n   DeploymentVars   \n------------------- \n")
+	for key, value := range c.DeploymentVars {
+		fmt.Println(key, " : ", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestParseCluster(t *testing.T) {
+	c := New(&provider.DeploymentResource{})
+	c.DeploymentVars = map[string]string{"ZONE": "westeurope"}
+
+	req, err := c.parseCluster(Resource{FileName: "cluster_aks.yaml", Content: []byte(`
+cluster:
+  name: prombench
+  properties:
+    kubernetesVersion: "1.27"
+nodepools:
+  - name: prom10
+    properties:
+      count: 2
+      vmSize: Standard_E8ds_v5
+      nodeLabels:
+        node-name: prometheus-10
+`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Cluster.Location != "westeurope" {
+		t.Errorf("expect the location from the ZONE variable, got %q", req.Cluster.Location)
+	}
+	if req.Cluster.Identity["type"] != "SystemAssigned" {
+		t.Errorf("expect a system-assigned identity, got %v", req.Cluster.Identity)
+	}
+	if req.Cluster.Properties["dnsPrefix"] != "prombench" || req.Cluster.Properties["kubernetesVersion"] != "1.27" {
+		t.Errorf("unexpected properties %v", req.Cluster.Properties)
+	}
+	if len(req.NodePools) != 1 || req.NodePools[0].Properties["vmSize"] != "Standard_E8ds_v5" {
+		t.Errorf("unexpected node pools %+v", req.NodePools)
+	}
+}
+
+func TestParseClusterErrors(t *testing.T) {
+	c := New(&provider.DeploymentResource{})
+	for _, tc := range []struct {
+		name, content, err string
+	}{
+		{name: "hyphen", content: "cluster:\n  name: prombench\nnodepools:\n  - name: prometheus-10\n", err: `invalid node pool name "prometheus-10"`},
+		{name: "too long", content: "cluster:\n  name: prombench\nnodepools:\n  - name: prometheus1234\n", err: `invalid node pool name "prometheus1234"`},
+		{name: "duplicate", content: "cluster:\n  name: prombench\nnodepools:\n  - name: nodes10\n  - name: nodes10\n", err: `duplicate node pool name "nodes10"`},
+		{name: "missing name", content: "cluster:\n  location: westeurope\n", err: "missing the cluster name"},
+		{name: "unknown field", content: "cluster:\n  name: prombench\nnodegroups: []\n", err: "unknown field"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.parseCluster(Resource{FileName: "cluster_aks.yaml", Content: []byte(tc.content)})
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), strings.ToLower(tc.err)) {
+				t.Errorf("expect an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCredentialsOrder(t *testing.T) {
+	const (
+		flagAuth = "tenantid: flag\nclientid: client\nclientsecret: secret\n"
+		fileAuth = "tenantid: file\nclientid: client\nclientsecret: secret\n"
+		envAuth  = "tenantid: env\nclientid: client\nclientsecret: secret\n"
+	)
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.yaml")
+	if err := os.WriteFile(credentialsFile, []byte("aks:\n  data: |\n    "+strings.ReplaceAll(strings.TrimSpace(fileAuth), "\n", "\n    ")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name, auth, credentialsFile, env string
+		tenant, err                      string
+	}{
+		{name: "flag", auth: flagAuth, credentialsFile: credentialsFile, env: envAuth, tenant: "flag"},
+		{name: "credentials file", credentialsFile: credentialsFile, env: envAuth, tenant: "file"},
+		{name: "env", env: envAuth, tenant: "env"},
+		{name: "none", err: "no auth provided"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("AZURE_APPLICATION_CREDENTIALS", tc.env)
+			c := New(&provider.DeploymentResource{CredentialsFile: tc.credentialsFile})
+			c.Auth = tc.auth
+			cred, err := c.credentials()
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expect an error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cred.TenantID != tc.tenant {
+				t.Errorf("expect the credentials of the %v, got the tenant %q", tc.tenant, cred.TenantID)
+			}
+		})
+	}
+}
+
+func TestK8SDeploymentsParseShortSection(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "short.yaml")
+	if err := os.WriteFile(f, []byte("kind: Foo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := New(&provider.DeploymentResource{})
+	c.DeploymentFiles = []string{f}
+	c.DeploymentVars = map[string]string{"AZURE_SUBSCRIPTION_ID": "sub", "AZURE_RESOURCE_GROUP": "rg", "ZONE": "westeurope", "CLUSTER_NAME": "prombench"}
+	err := c.K8SDeploymentsParse(nil)
+	if err == nil || !strings.Contains(err.Error(), "section:kind: Foo...") {
+		t.Fatalf("expect the decoding error quoting the whole short section, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// armAPIVersion is the version of the Microsoft.ContainerService API used for the clusters and the agent pools.
+const armAPIVersion = "2023-08-01"
+
+// armEndpoint is the Azure Resource Manager endpoint of the public cloud.
+var armEndpoint = "https://management.azure.com"
+
+// The provisioning states of the clusters and the agent pools.
+const (
+	stateSucceeded = "Succeeded"
+	stateFailed    = "Failed"
+	stateCanceled  = "Canceled"
+)
+
+// armClient sends the managed cluster and agent pool requests of a resource group to the Azure Resource Manager API.
+type armClient struct {
+	ctx            context.Context
+	client         *http.Client
+	endpoint       string
+	subscriptionID string
+	resourceGroup  string
+}
+
+func newARMClient(ctx context.Context, ts oauth2.TokenSource, subscriptionID, resourceGroup string) *armClient {
+	return &armClient{
+		ctx:            ctx,
+		client:         oauth2.NewClient(ctx, ts),
+		endpoint:       armEndpoint,
+		subscriptionID: subscriptionID,
+		resourceGroup:  resourceGroup,
+	}
+}
+
+// armError is the error returned by the ARM API, e.g. {"error":{"code":"ResourceNotFound","message":"..."}}.
+type armError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *armError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// isNotFound reports whether the requested resource doesn't exist.
+func isNotFound(err error) bool {
+	var aerr *armError
+	return errors.As(err, &aerr) && aerr.StatusCode == http.StatusNotFound
+}
+
+// armResourceState is the provisioning state of a cluster or an agent pool.
+type armResourceState struct {
+	Name       string `json:"name"`
+	Properties struct {
+		ProvisioningState string `json:"provisioningState"`
+	} `json:"properties"`
+}
+
+func (c *armClient) clusterPath(cluster string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s",
+		url.PathEscape(c.subscriptionID), url.PathEscape(c.resourceGroup), url.PathEscape(cluster))
+}
+
+func (c *armClient) agentPoolPath(cluster, pool string) string {
+	return c.clusterPath(cluster) + "/agentPools/" + url.PathEscape(pool)
+}
+
+// do sends the request with in as the JSON body, when not nil, and decodes the response into out, when not nil.
+func (c *armClient) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		content, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, "encoding the request")
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(c.ctx, method, c.endpoint+path+"?api-version="+armAPIVersion, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrapf(err, "reading the response of %s %s", method, path)
+	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		e := struct {
+			Error armError `json:"error"`
+		}{}
+		if err := json.Unmarshal(content, &e); err != nil || e.Error.Code == "" {
+			e.Error = armError{Message: strings.TrimSpace(string(content))}
+		}
+		e.Error.StatusCode = res.StatusCode
+		return &e.Error
+	}
+	if out == nil || len(content) == 0 {
+		return nil
+	}
+	return errors.Wrapf(json.Unmarshal(content, out), "decoding the response of %s %s", method, path)
+}
+
+// provisioningState returns the provisioning state of the cluster or agent pool at the path.
+func (c *armClient) provisioningState(path string) (string, error) {
+	res := armResourceState{}
+	if err := c.do(http.MethodGet, path, nil, &res); err != nil {
+		return "", err
+	}
+	return res.Properties.ProvisioningState, nil
+}
+
+// listAgentPools returns the names of the agent pools of the cluster.
+// A cluster has at most 100 agent pools, they are returned in a single page.
+func (c *armClient) listAgentPools(cluster string) ([]string, error) {
+	res := struct {
+		Value []armResourceState `json:"value"`
+	}{}
+	if err := c.do(http.MethodGet, c.clusterPath(cluster)+"/agentPools", nil, &res); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(res.Value))
+	for _, p := range res.Value {
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
+
+// adminKubeconfig returns the kubeconfig with the cluster admin credentials, the clusters with
+// disableLocalAccounts don't have them.
+func (c *armClient) adminKubeconfig(cluster string) ([]byte, error) {
+	res := struct {
+		Kubeconfigs []struct {
+			Name  string `json:"name"`
+			Value []byte `json:"value"`
+		} `json:"kubeconfigs"`
+	}{}
+	if err := c.do(http.MethodPost, c.clusterPath(cluster)+"/listClusterAdminCredential", nil, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Kubeconfigs) == 0 {
+		return nil, errors.Errorf("no admin kubeconfig for cluster %q", cluster)
+	}
+	return res.Kubeconfigs[0].Value, nil
+}
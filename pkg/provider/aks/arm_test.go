@@ -0,0 +1,109 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// newTestARMClient returns a client of an ARM API served by the handler.
+func newTestARMClient(t *testing.T, handler http.HandlerFunc) *armClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := newARMClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), "sub", "rg")
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestARMRequest(t *testing.T) {
+	c := newTestARMClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("api-version") != armAPIVersion {
+			t.Errorf("unexpected api version %q", r.URL.Query().Get("api-version"))
+		}
+		switch r.URL.Path {
+		case "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/prombench/agentPools/nodes1":
+			fmt.Fprint(w, `{"name":"nodes1","properties":{"provisioningState":"Creating"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"ResourceNotFound","message":"The resource was not found."}}`)
+		}
+	})
+
+	state, err := c.provisioningState(c.agentPoolPath("prombench", "nodes1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != "Creating" {
+		t.Errorf("unexpected state %q", state)
+	}
+
+	_, err = c.provisioningState(c.agentPoolPath("prombench", "nodes2"))
+	if !isNotFound(err) {
+		t.Fatalf("expect a not found error, got %v", err)
+	}
+	if exp := "404 ResourceNotFound: The resource was not found."; err.Error() != exp {
+		t.Errorf("expect the error %q, got %q", exp, err)
+	}
+}
+
+func TestARMErrorWithoutBody(t *testing.T) {
+	c := newTestARMClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	})
+	err := c.do(http.MethodDelete, c.clusterPath("prombench"), nil, nil)
+	if err == nil || isNotFound(err) || !strings.Contains(err.Error(), "502 Bad Gateway: upstream unavailable") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestListAgentPools(t *testing.T) {
+	c := newTestARMClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[{"name":"mainnode"},{"name":"prom10"}]}`)
+	})
+	names, err := c.listAgentPools("prombench")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(names, ",") != "mainnode,prom10" {
+		t.Errorf("unexpected agent pools %v", names)
+	}
+}
+
+func TestAdminKubeconfig(t *testing.T) {
+	kubeconfig := "apiVersion: v1\nkind: Config\n"
+	c := newTestARMClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/managedClusters/prombench/listClusterAdminCredential") {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		}
+		fmt.Fprintf(w, `{"kubeconfigs":[{"name":"clusterAdmin","value":%q}]}`, base64.StdEncoding.EncodeToString([]byte(kubeconfig)))
+	})
+	got, err := c.adminKubeconfig("prombench")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != kubeconfig {
+		t.Errorf("unexpected kubeconfig %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	yamlGo "gopkg.in/yaml.v2"
+)
+
+// armResource is the resource of the Azure Resource Manager tokens.
+const armResource = "https://management.azure.com/"
+
+var (
+	// loginEndpoint is the Microsoft Entra ID authority issuing the service principal tokens.
+	loginEndpoint = "https://login.microsoftonline.com"
+	// imdsTokenEndpoint is the token endpoint of the Azure Instance Metadata Service for the managed identities.
+	imdsTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+// credentials authenticate the requests as a service principal or, with ManagedIdentity,
+// as the managed identity of the VM the command runs on.
+type credentials struct {
+	TenantID     string `yaml:"tenantid"`
+	ClientID     string `yaml:"clientid"`
+	ClientSecret string `yaml:"clientsecret"`
+	// ManagedIdentity isn't part of the auth file, ClientID selects a user-assigned identity, empty uses the system-assigned one.
+	ManagedIdentity bool `yaml:"-"`
+}
+
+// parseServicePrincipal parses the service principal credentials of the auth file.
+func parseServicePrincipal(auth string) (credentials, error) {
+	cred := credentials{}
+	if err := yamlGo.UnmarshalStrict([]byte(auth), &cred); err != nil {
+		return cred, errors.Wrap(err, "could not get credential values")
+	}
+	var missing []string
+	for _, f := range []struct{ name, value string }{
+		{"tenantid", cred.TenantID},
+		{"clientid", cred.ClientID},
+		{"clientsecret", cred.ClientSecret},
+	} {
+		if f.value == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return cred, errors.Errorf("the service principal credentials are missing %s", strings.Join(missing, ", "))
+	}
+	return cred, nil
+}
+
+// tokenSource returns the source of the Azure Resource Manager tokens of the credentials.
+func (c credentials) tokenSource(ctx context.Context) oauth2.TokenSource {
+	if c.ManagedIdentity {
+		return oauth2.ReuseTokenSource(nil, &managedIdentityTokenSource{ctx: ctx, clientID: c.ClientID, endpoint: imdsTokenEndpoint})
+	}
+	cfg := &clientcredentials.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", loginEndpoint, url.PathEscape(c.TenantID)),
+		Scopes:       []string{armResource + ".default"},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	return cfg.TokenSource(ctx)
+}
+
+// managedIdentityTokenSource gets the tokens of a managed identity from the Instance Metadata Service,
+// see https://learn.microsoft.com/en-us/entra/identity/managed-identities-azure-resources/how-to-use-vm-token.
+type managedIdentityTokenSource struct {
+	ctx      context.Context
+	clientID string
+	endpoint string
+}
+
+func (s *managedIdentityTokenSource) Token() (*oauth2.Token, error) {
+	q := url.Values{"api-version": {"2018-02-01"}, "resource": {armResource}}
+	if s.clientID != "" {
+		q.Set("client_id", s.clientID)
+	}
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, s.endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the managed identity token")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading the managed identity token")
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("getting the managed identity token: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	tok := struct {
+		AccessToken string      `json:"access_token"`
+		TokenType   string      `json:"token_type"`
+		ExpiresOn   json.Number `json:"expires_on"`
+	}{}
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return nil, errors.Wrap(err, "decoding the managed identity token")
+	}
+	expiresOn, err := tok.ExpiresOn.Int64()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid expiry %q of the managed identity token", tok.ExpiresOn)
+	}
+	return &oauth2.Token{AccessToken: tok.AccessToken, TokenType: tok.TokenType, Expiry: time.Unix(expiresOn, 0)}, nil
+}
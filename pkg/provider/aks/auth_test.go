@@ -0,0 +1,115 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseServicePrincipal(t *testing.T) {
+	cred, err := parseServicePrincipal("tenantid: tenant\nclientid: client\nclientsecret: secret\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cred.TenantID != "tenant" || cred.ClientID != "client" || cred.ClientSecret != "secret" || cred.ManagedIdentity {
+		t.Errorf("unexpected credentials %+v", cred)
+	}
+
+	for _, tc := range []struct {
+		name, auth, err string
+	}{
+		{name: "missing secret", auth: "tenantid: tenant\nclientid: client\n", err: "missing clientsecret"},
+		{name: "empty", auth: "", err: "missing tenantid, clientid, clientsecret"},
+		{name: "unknown field", auth: "tenantid: tenant\nsubscriptionid: sub\n", err: "field subscriptionid not found"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseServicePrincipal(tc.auth); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expect an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestServicePrincipalToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" {
+			t.Errorf("unexpected token path %v", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("client_id") != "client" || r.Form.Get("client_secret") != "secret" || r.Form.Get("scope") != "https://management.azure.com/.default" {
+			t.Errorf("unexpected token request %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"sp-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer srv.Close()
+	defer func(e string) { loginEndpoint = e }(loginEndpoint)
+	loginEndpoint = srv.URL
+
+	cred := credentials{TenantID: "tenant", ClientID: "client", ClientSecret: "secret"}
+	tok, err := cred.tokenSource(context.Background()).Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tok.AccessToken != "sp-token" {
+		t.Errorf("unexpected token %q", tok.AccessToken)
+	}
+}
+
+func TestManagedIdentityToken(t *testing.T) {
+	expiresOn := time.Now().Add(time.Hour).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			t.Error("expected the Metadata header")
+		}
+		q := r.URL.Query()
+		if q.Get("resource") != armResource || q.Get("client_id") != "identity" {
+			t.Errorf("unexpected token request %v", q)
+		}
+		// The metadata service returns the expiry as a string.
+		fmt.Fprintf(w, `{"access_token":"mi-token","token_type":"Bearer","expires_on":"%d"}`, expiresOn)
+	}))
+	defer srv.Close()
+	defer func(e string) { imdsTokenEndpoint = e }(imdsTokenEndpoint)
+	imdsTokenEndpoint = srv.URL
+
+	cred := credentials{ManagedIdentity: true, ClientID: "identity"}
+	tok, err := cred.tokenSource(context.Background()).Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tok.AccessToken != "mi-token" || tok.Expiry.Unix() != expiresOn {
+		t.Errorf("unexpected token %+v", tok)
+	}
+}
+
+func TestManagedIdentityTokenError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_request","error_description":"Identity not found"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s := &managedIdentityTokenSource{ctx: context.Background(), endpoint: srv.URL}
+	if _, err := s.Token(); err == nil || !strings.Contains(err.Error(), "Identity not found") {
+		t.Errorf("expect the error of the metadata service, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResourceRange is the min and max of a resource summed over all nodes of a cluster, e.g. the CPU cores.
+type ResourceRange struct {
+	Min int64
+	Max int64
+}
+
+// ParseResourceRange parses the MIN:MAX range of a resource, e.g. 4:64.
+// The bounds must be min >= 0, max > 0 and min <= max.
+func ParseResourceRange(resource, value string) (ResourceRange, error) {
+	minValue, maxValue, ok := strings.Cut(value, ":")
+	if !ok {
+		return ResourceRange{}, fmt.Errorf("invalid %v range %q, expected MIN:MAX", resource, value)
+	}
+	min, err := strconv.ParseInt(strings.TrimSpace(minValue), 10, 64)
+	if err != nil || min < 0 {
+		return ResourceRange{}, fmt.Errorf("invalid %v min %q, must be a number >= 0", resource, minValue)
+	}
+	max, err := strconv.ParseInt(strings.TrimSpace(maxValue), 10, 64)
+	if err != nil || max <= 0 {
+		return ResourceRange{}, fmt.Errorf("invalid %v max %q, must be a number > 0", resource, maxValue)
+	}
+	if min > max {
+		return ResourceRange{}, fmt.Errorf("invalid %v range %q, min: %d is bigger than max: %d", resource, value, min, max)
+	}
+	return ResourceRange{Min: min, Max: max}, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestParseResourceRange(t *testing.T) {
+	for value, exp := range map[string]*ResourceRange{
+		"4:64":   {Min: 4, Max: 64},
+		"0:16":   {Min: 0, Max: 16},
+		" 8 : 8": {Min: 8, Max: 8},
+		"64":     nil,
+		"-1:64":  nil,
+		"4:0":    nil,
+		"64:4":   nil,
+		"a:64":   nil,
+		"4:1.5":  nil,
+	} {
+		r, err := ParseResourceRange("cpu", value)
+		if exp == nil {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %+v", value, r)
+			}
+			continue
+		}
+		if err != nil || r != *exp {
+			t.Errorf("%q: expected %+v, got %+v %v", value, *exp, r, err)
+		}
+	}
+}
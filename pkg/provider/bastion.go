@@ -0,0 +1,69 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"net"
+)
+
+// BastionProxyPort is the local port of the SOCKS proxy opened by the ssh tunnel to a bastion.
+const BastionProxyPort = 1080
+
+// BastionOptions configure the bastion VM created in the network of a private cluster,
+// from which the api server of the cluster is reachable.
+type BastionOptions struct {
+	Enabled bool
+	// MachineType of the bastion VM, empty uses a small machine type of the provider.
+	MachineType string
+	// SourceRanges are the CIDRs allowed to connect to the bastion with ssh.
+	SourceRanges []string
+}
+
+// Validate checks the source ranges of the bastion.
+func (o BastionOptions) Validate() error {
+	if len(o.SourceRanges) == 0 {
+		return fmt.Errorf("the bastion requires at least one ssh source range")
+	}
+	for _, r := range o.SourceRanges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			return fmt.Errorf("invalid bastion source range %q, expected a CIDR, e.g. 203.0.113.0/24", r)
+		}
+	}
+	return nil
+}
+
+// BastionName returns the name of the bastion VM of a cluster and of its firewall rule or security group.
+func BastionName(cluster string) string {
+	return cluster + "-bastion"
+}
+
+// Bastion is a created bastion VM.
+type Bastion struct {
+	Name string
+	// User is the ssh user of the bastion and Address its public IP address.
+	User    string
+	Address string
+	// Endpoint is the api server address of the cluster reachable from the bastion.
+	Endpoint string
+}
+
+// ConnectionDetails describes how to run the k8s commands through the bastion.
+func (b Bastion) ConnectionDetails() string {
+	return fmt.Sprintf("Bastion '%v' is ready at %v, the api server %v is reachable through it.\n"+
+		"Open a tunnel with:\n"+
+		"  ssh -N -D %d %v@%v\n"+
+		"and run the k8s commands with --k8s-proxy=socks5://localhost:%d",
+		b.Name, b.Address, b.Endpoint, BastionProxyPort, b.User, b.Address, BastionProxyPort)
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBastionOptionsValidate(t *testing.T) {
+	if err := (BastionOptions{Enabled: true, SourceRanges: []string{"203.0.113.0/24", "0.0.0.0/0"}}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, ranges := range [][]string{nil, {"203.0.113.1"}, {"10.0.0.0/8", "office"}} {
+		if err := (BastionOptions{Enabled: true, SourceRanges: ranges}).Validate(); err == nil {
+			t.Errorf("%v: expected an error", ranges)
+		}
+	}
+}
+
+func TestBastionConnectionDetails(t *testing.T) {
+	b := Bastion{Name: BastionName("prombench"), User: "prombench", Address: "198.51.100.7", Endpoint: "10.0.0.2"}
+	details := b.ConnectionDetails()
+	for _, want := range []string{"'prombench-bastion'", "ssh -N -D 1080 prombench@198.51.100.7", "--k8s-proxy=socks5://localhost:1080", "10.0.0.2"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("want %q in the connection details, got:\n%v", want, details)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ClusterNameRules are the length and charset constraints of the cluster names of a provider.
+type ClusterNameRules struct {
+	Provider  string
+	MaxLength int
+	Pattern   *regexp.Regexp
+	// Charset describes the Pattern in the validation errors.
+	Charset string
+}
+
+var (
+	// GKEClusterNameRules follow the GKE API: lowercase letters, digits and hyphens, up to 40 characters.
+	GKEClusterNameRules = ClusterNameRules{
+		Provider:  "gke",
+		MaxLength: 40,
+		Pattern:   regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`),
+		Charset:   "lowercase letters, digits and hyphens, starting with a letter and ending with a letter or digit",
+	}
+	// EKSClusterNameRules follow the EKS API: letters, digits, hyphens and underscores, up to 100 characters.
+	EKSClusterNameRules = ClusterNameRules{
+		Provider:  "eks",
+		MaxLength: 100,
+		Pattern:   regexp.MustCompile(`^[0-9A-Za-z][A-Za-z0-9\-_]*$`),
+		Charset:   "letters, digits, hyphens and underscores, starting with a letter or digit",
+	}
+	// AKSClusterNameRules follow the AKS API: letters, digits, hyphens and underscores, up to 63 characters.
+	AKSClusterNameRules = ClusterNameRules{
+		Provider:  "aks",
+		MaxLength: 63,
+		Pattern:   regexp.MustCompile(`^[0-9A-Za-z]([A-Za-z0-9\-_]*[0-9A-Za-z])?$`),
+		Charset:   "letters, digits, hyphens and underscores, starting and ending with a letter or digit",
+	}
+	// KINDClusterNameRules keep the names of the KIND node containers and the kubeconfig context valid.
+	KINDClusterNameRules = ClusterNameRules{
+		Provider:  "kind",
+		MaxLength: 50,
+		Pattern:   regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`),
+		Charset:   "lowercase letters, digits and hyphens, starting and ending with a letter or digit",
+	}
+)
+
+// clusterNameSuffixPattern keeps the suffix valid for the cluster names of all providers.
+var clusterNameSuffixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Validate checks the name against the constraints of the provider.
+func (r ClusterNameRules) Validate(name string) error {
+	if len(name) > r.MaxLength {
+		return fmt.Errorf("%s cluster name %q is %d characters long, the maximum is %d", r.Provider, name, len(name), r.MaxLength)
+	}
+	if !r.Pattern.MatchString(name) {
+		return fmt.Errorf("invalid %s cluster name %q, it must contain only %s", r.Provider, name, r.Charset)
+	}
+	return nil
+}
+
+// ApplyClusterNameSuffix appends the suffix to the CLUSTER_NAME variable as CLUSTER_NAME-suffix
+// and validates the resulting name against the rules of the provider.
+// A deterministic suffix, e.g. the PR number and the run ID, makes the cluster names predictable,
+// so a re-run with the same suffix targets the same cluster. An empty suffix leaves the variables unchanged.
+func ApplyClusterNameSuffix(vars map[string]string, suffix string, rules ClusterNameRules) error {
+	if suffix == "" {
+		return nil
+	}
+	if !clusterNameSuffixPattern.MatchString(suffix) {
+		return fmt.Errorf("invalid cluster name suffix %q, it must contain only lowercase letters, digits and hyphens, starting and ending with a letter or digit", suffix)
+	}
+	name, ok := vars["CLUSTER_NAME"]
+	if !ok || name == "" {
+		return fmt.Errorf("the cluster name suffix requires the CLUSTER_NAME variable")
+	}
+	name = name + "-" + suffix
+	if err := rules.Validate(name); err != nil {
+		return err
+	}
+	vars["CLUSTER_NAME"] = name
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyClusterNameSuffix(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		vars    map[string]string
+		suffix  string
+		rules   ClusterNameRules
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no suffix",
+			vars:  map[string]string{"CLUSTER_NAME": "Prombench_10"},
+			rules: GKEClusterNameRules,
+			want:  "Prombench_10",
+		},
+		{
+			name:   "gke",
+			vars:   map[string]string{"CLUSTER_NAME": "prombench"},
+			suffix: "pr-1234-run-42",
+			rules:  GKEClusterNameRules,
+			want:   "prombench-pr-1234-run-42",
+		},
+		{
+			name:   "eks underscores",
+			vars:   map[string]string{"CLUSTER_NAME": "Prombench_EKS"},
+			suffix: "1234",
+			rules:  EKSClusterNameRules,
+			want:   "Prombench_EKS-1234",
+		},
+		{
+			name:   "aks",
+			vars:   map[string]string{"CLUSTER_NAME": "Prombench_AKS"},
+			suffix: "1234",
+			rules:  AKSClusterNameRules,
+			want:   "Prombench_AKS-1234",
+		},
+		{
+			name:    "aks too long",
+			vars:    map[string]string{"CLUSTER_NAME": strings.Repeat("a", 60)},
+			suffix:  "1234",
+			rules:   AKSClusterNameRules,
+			wantErr: "is 65 characters long, the maximum is 63",
+		},
+		{
+			name:    "gke uppercase",
+			vars:    map[string]string{"CLUSTER_NAME": "Prombench"},
+			suffix:  "1234",
+			rules:   GKEClusterNameRules,
+			wantErr: `invalid gke cluster name "Prombench-1234"`,
+		},
+		{
+			name:    "gke too long",
+			vars:    map[string]string{"CLUSTER_NAME": "prombench-benchmark-cluster"},
+			suffix:  "pr-1234-run-42",
+			rules:   GKEClusterNameRules,
+			wantErr: "is 42 characters long, the maximum is 40",
+		},
+		{
+			name:    "kind too long",
+			vars:    map[string]string{"CLUSTER_NAME": strings.Repeat("a", 45)},
+			suffix:  "12345",
+			rules:   KINDClusterNameRules,
+			wantErr: "the maximum is 50",
+		},
+		{
+			name:    "invalid suffix",
+			vars:    map[string]string{"CLUSTER_NAME": "prombench"},
+			suffix:  "PR_1234",
+			rules:   EKSClusterNameRules,
+			wantErr: `invalid cluster name suffix "PR_1234"`,
+		},
+		{
+			name:    "trailing hyphen",
+			vars:    map[string]string{"CLUSTER_NAME": "prombench"},
+			suffix:  "1234-",
+			rules:   EKSClusterNameRules,
+			wantErr: "invalid cluster name suffix",
+		},
+		{
+			name:    "no cluster name",
+			vars:    map[string]string{},
+			suffix:  "1234",
+			rules:   KINDClusterNameRules,
+			wantErr: "requires the CLUSTER_NAME variable",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ApplyClusterNameSuffix(tc.vars, tc.suffix, tc.rules)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("want an error with %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := tc.vars["CLUSTER_NAME"]; got != tc.want {
+				t.Errorf("want CLUSTER_NAME %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CredentialsProviders are the providers that can have a section in a credentials file.
+var CredentialsProviders = []string{"gke", "eks", "aks"}
+
+// ProviderCredentials are the credentials of a single provider in the format of its --auth flag,
+// either as a file or as the inline data.
+type ProviderCredentials struct {
+	File string `yaml:"file"`
+	Data string `yaml:"data"`
+}
+
+// Credentials holds the credentials of several providers in a single file,
+// so the runs comparing clouds share one --credentials-file instead of an --auth flag per provider.
+type Credentials struct {
+	Providers map[string]ProviderCredentials `yaml:",inline"`
+}
+
+// LoadCredentials reads and validates a credentials file, e.g.
+//
+//	gke:
+//	  file: service-account.json
+//	eks:
+//	  data: |
+//	    accesskeyid: AKIA...
+//	    secretaccesskey: ...
+//	aks:
+//	  file: service-principal.yaml
+//
+// The relative files are resolved from the directory of the credentials file.
+func LoadCredentials(filename string) (*Credentials, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading the credentials file: %w", err)
+	}
+	c := &Credentials{}
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return nil, fmt.Errorf("parsing the credentials file %v: %w", filename, err)
+	}
+	names := make([]string, 0, len(c.Providers))
+	for name := range c.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := c.Providers[name]
+		if !isCredentialsProvider(name) {
+			return nil, fmt.Errorf("the credentials file %v has credentials for the unknown provider %q, must be one of %v", filename, name, CredentialsProviders)
+		}
+		if (p.File == "") == (p.Data == "") {
+			return nil, fmt.Errorf("the credentials file %v: %v must set either file or data", filename, name)
+		}
+		if p.File != "" && !filepath.IsAbs(p.File) {
+			p.File = filepath.Join(filepath.Dir(filename), p.File)
+		}
+		if p.File != "" {
+			if _, err := os.Stat(p.File); err != nil {
+				return nil, fmt.Errorf("the credentials file %v: %v: %w", filename, name, err)
+			}
+		}
+		c.Providers[name] = p
+	}
+	return c, nil
+}
+
+// Auth returns the credentials of the provider in the format of its --auth flag,
+// the file when it is set, otherwise the inline data.
+func (c *Credentials) Auth(provider string) (string, error) {
+	p, ok := c.Providers[provider]
+	if !ok {
+		var have []string
+		for name := range c.Providers {
+			have = append(have, name)
+		}
+		if len(have) == 0 {
+			return "", fmt.Errorf("no %v credentials in the credentials file, it is empty", provider)
+		}
+		sort.Strings(have)
+		return "", fmt.Errorf("no %v credentials in the credentials file, it has credentials for: %v", provider, strings.Join(have, ", "))
+	}
+	if p.File != "" {
+		return p.File, nil
+	}
+	return p.Data, nil
+}
+
+// CredentialsAuth loads a credentials file and returns the credentials of the provider.
+func CredentialsAuth(filename, provider string) (string, error) {
+	c, err := LoadCredentials(filename)
+	if err != nil {
+		return "", err
+	}
+	auth, err := c.Auth(provider)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", filename, err)
+	}
+	return auth, nil
+}
+
+func isCredentialsProvider(name string) bool {
+	for _, p := range CredentialsProviders {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCredentialsAuth(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"credentials.yaml": `
+gke:
+  file: keys/service-account.json
+eks:
+  data: |
+    accesskeyid: AKIAEXAMPLE
+    secretaccesskey: secret
+`,
+		"keys/service-account.json": "{}",
+	})
+	filename := filepath.Join(dir, "credentials.yaml")
+
+	auth, err := CredentialsAuth(filename, "gke")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := filepath.Join(dir, "keys/service-account.json"); auth != exp {
+		t.Errorf("expect the gke credentials file %v, got %v", exp, auth)
+	}
+	if auth, err = CredentialsAuth(filename, "eks"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(auth, "accesskeyid: AKIAEXAMPLE") {
+		t.Errorf("expect the inline eks credentials, got %q", auth)
+	}
+}
+
+func TestCredentialsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, content, provider, err string
+	}{
+		{name: "missing provider", content: "gke:\n  data: '{}'\n", provider: "eks", err: "no eks credentials in the credentials file, it has credentials for: gke"},
+		{name: "empty", content: "", provider: "gke", err: "no gke credentials in the credentials file, it is empty"},
+		{name: "unknown provider", content: "azure:\n  data: x\n", provider: "gke", err: `unknown provider "azure"`},
+		{name: "file and data", content: "gke:\n  file: a.json\n  data: '{}'\n", provider: "gke", err: "must set either file or data"},
+		{name: "missing file", content: "gke:\n  file: missing.json\n", provider: "gke", err: "missing.json"},
+		{name: "unknown field", content: "gke:\n  path: a.json\n", provider: "gke", err: "field path not found"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFiles(t, dir, map[string]string{"credentials.yaml": tc.content})
+			_, err := CredentialsAuth(filepath.Join(dir, "credentials.yaml"), tc.provider)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expect an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
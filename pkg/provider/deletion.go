@@ -0,0 +1,34 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeletionProtectionLabel marks a cluster as protected from the cluster delete commands when set to true.
+// The GKE and EKS api versions used here have no native deletion protection,
+// so it is set as a GKE resource label or an EKS tag, e.g. in the cluster file.
+const DeletionProtectionLabel = "deletion-protection"
+
+// CheckDeletionProtection returns an error when the labels of the cluster protect it from deletion
+// and force is not set. With force the caller removes the label before deleting the cluster.
+func CheckDeletionProtection(cluster string, labels map[string]string, force bool) (protected bool, err error) {
+	protected = strings.EqualFold(labels[DeletionProtectionLabel], "true")
+	if protected && !force {
+		return true, fmt.Errorf("cluster %q has deletion protection, the %v=true label, remove it or delete the cluster with --force-delete", cluster, DeletionProtectionLabel)
+	}
+	return protected, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	testCases := []struct {
+		labels    map[string]string
+		force     bool
+		protected bool
+		err       bool
+	}{
+		{labels: nil},
+		{labels: map[string]string{"team": "prombench"}},
+		{labels: map[string]string{DeletionProtectionLabel: "false"}},
+		{labels: map[string]string{DeletionProtectionLabel: "true"}, protected: true, err: true},
+		{labels: map[string]string{DeletionProtectionLabel: "True"}, protected: true, err: true},
+		{labels: map[string]string{DeletionProtectionLabel: "true"}, force: true, protected: true},
+	}
+	for _, tc := range testCases {
+		protected, err := CheckDeletionProtection("prombench", tc.labels, tc.force)
+		if protected != tc.protected {
+			t.Errorf("%v force:%v: want protected %v, got %v", tc.labels, tc.force, tc.protected, protected)
+		}
+		if tc.err != (err != nil) {
+			t.Errorf("%v force:%v: want an error %v, got %v", tc.labels, tc.force, tc.err, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "--force-delete") {
+			t.Errorf("want the error to mention --force-delete, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Dependent is a cloud resource the cluster created for its k8s objects that isn't deleted with the cluster,
+// e.g. the load balancer of a LoadBalancer service or the disk of a dynamically provisioned PersistentVolume.
+type Dependent struct {
+	Kind string
+	Name string
+}
+
+func (d Dependent) String() string {
+	return d.Kind + "/" + d.Name
+}
+
+// DependentsReport aggregates the dependents removed after a cluster delete and the ones that couldn't be removed.
+type DependentsReport struct {
+	Cluster string
+	Removed []Dependent
+	Failed  []FailedDependent
+}
+
+// FailedDependent is a dependent that couldn't be removed with the error of the last attempt.
+type FailedDependent struct {
+	Dependent
+	Err error
+}
+
+// Add records the result of removing the dependent.
+func (r *DependentsReport) Add(d Dependent, err error) {
+	if err != nil {
+		log.Printf("Couldn't remove %v of cluster '%v': %v", d, r.Cluster, err)
+		r.Failed = append(r.Failed, FailedDependent{Dependent: d, Err: err})
+		return
+	}
+	log.Printf("Removed %v of cluster '%v'", d, r.Cluster)
+	r.Removed = append(r.Removed, d)
+}
+
+// Err logs the summary of the report and returns an error listing the dependents that couldn't be removed,
+// they keep costing until they are removed by hand.
+func (r *DependentsReport) Err() error {
+	if len(r.Removed) == 0 && len(r.Failed) == 0 {
+		log.Printf("No dependent resources of cluster '%v' left", r.Cluster)
+		return nil
+	}
+	log.Printf("Removed %d dependent resources of cluster '%v', %d failed", len(r.Removed), r.Cluster, len(r.Failed))
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	failed := make([]string, 0, len(r.Failed))
+	for _, f := range r.Failed {
+		failed = append(failed, fmt.Sprintf("%v: %v", f.Dependent, f.Err))
+	}
+	return fmt.Errorf("couldn't remove %d dependent resources of cluster %q, remove them by hand: %v", len(r.Failed), r.Cluster, strings.Join(failed, "; "))
+}
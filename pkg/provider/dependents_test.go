@@ -0,0 +1,45 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDependentsReport(t *testing.T) {
+	r := &DependentsReport{Cluster: "prombench"}
+	if err := r.Err(); err != nil {
+		t.Fatalf("want no error without dependents, got %v", err)
+	}
+
+	r.Add(Dependent{Kind: "disk", Name: "pvc-1"}, nil)
+	r.Add(Dependent{Kind: "forwarding rule", Name: "a123"}, nil)
+	if err := r.Err(); err != nil {
+		t.Fatalf("want no error when all dependents were removed, got %v", err)
+	}
+
+	r.Add(Dependent{Kind: "disk", Name: "pvc-2"}, errors.New("resourceInUse"))
+	err := r.Err()
+	if err == nil {
+		t.Fatal("expected an error for the failed dependent")
+	}
+	if !strings.Contains(err.Error(), "disk/pvc-2: resourceInUse") {
+		t.Errorf("want the failed dependent in the error, got %v", err)
+	}
+	if len(r.Removed) != 2 || len(r.Failed) != 1 {
+		t.Errorf("want 2 removed and 1 failed dependents, got %v and %v", r.Removed, r.Failed)
+	}
+}
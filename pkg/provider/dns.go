@@ -0,0 +1,63 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// DNSRecordTTL is the TTL in seconds of the records created for the load balancers,
+	// short so a recreated load balancer is picked up quickly.
+	DNSRecordTTL = 60
+	// DNSEndpointTimeout is how long the resource apply waits for the load balancer of a service with a DNS record.
+	DNSEndpointTimeout = 10 * time.Minute
+)
+
+// DNSRecordName returns the fully qualified name of a record in the zone with the given DNS name, e.g. example.com.
+// Names without a trailing dot are relative to the zone, names with one must already be within the zone.
+func DNSRecordName(record, zone string) (string, error) {
+	zone = strings.TrimSuffix(zone, ".") + "."
+	if record == "" || record == "." {
+		return "", fmt.Errorf("invalid empty DNS record name")
+	}
+	if !strings.HasSuffix(record, ".") {
+		return record + "." + zone, nil
+	}
+	if record != zone && !strings.HasSuffix(record, "."+zone) {
+		return "", fmt.Errorf("DNS record %v is not in the zone %v", record, zone)
+	}
+	return record, nil
+}
+
+// DNSRecord returns the record type and value pointing at a load balancer endpoint,
+// A or AAAA for an IP and a CNAME to the fully qualified hostname otherwise, e.g. for an AWS load balancer.
+func DNSRecord(endpoint string) (recordType, value string) {
+	if ip := net.ParseIP(endpoint); ip != nil {
+		if ip.To4() != nil {
+			return "A", endpoint
+		}
+		return "AAAA", endpoint
+	}
+	return "CNAME", strings.TrimSuffix(endpoint, ".") + "."
+}
+
+// IsDNSEndpointRecordType reports whether a record type is one of the types created by DNSRecord,
+// the records of the other types with the same name are left alone.
+func IsDNSEndpointRecordType(recordType string) bool {
+	return recordType == "A" || recordType == "AAAA" || recordType == "CNAME"
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestDNSRecordName(t *testing.T) {
+	testCases := []struct {
+		record, zone, name string
+		err                bool
+	}{
+		{record: "prombench-10", zone: "bench.example.com.", name: "prombench-10.bench.example.com."},
+		{record: "prombench-10", zone: "bench.example.com", name: "prombench-10.bench.example.com."},
+		{record: "grafana.prombench-10", zone: "bench.example.com.", name: "grafana.prombench-10.bench.example.com."},
+		{record: "prombench-10.bench.example.com.", zone: "bench.example.com.", name: "prombench-10.bench.example.com."},
+		{record: "bench.example.com.", zone: "bench.example.com.", name: "bench.example.com."},
+		{record: "prombench-10.example.org.", zone: "bench.example.com.", err: true},
+		{record: "prombench-10.notbench.example.com.", zone: "bench.example.com.", err: true},
+		{record: "", zone: "bench.example.com.", err: true},
+	}
+	for _, tc := range testCases {
+		name, err := DNSRecordName(tc.record, tc.zone)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%v in %v: expected an error", tc.record, tc.zone)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v in %v: unexpected error: %v", tc.record, tc.zone, err)
+		}
+		if name != tc.name {
+			t.Errorf("%v in %v: want %v, got %v", tc.record, tc.zone, tc.name, name)
+		}
+	}
+}
+
+func TestDNSRecord(t *testing.T) {
+	for endpoint, want := range map[string][2]string{
+		"34.120.1.2":                           {"A", "34.120.1.2"},
+		"2600:1901::1":                         {"AAAA", "2600:1901::1"},
+		"a1b2-123.us-east-2.elb.amazonaws.com": {"CNAME", "a1b2-123.us-east-2.elb.amazonaws.com."},
+	} {
+		recordType, value := DNSRecord(endpoint)
+		if recordType != want[0] || value != want[1] {
+			t.Errorf("%v: want %v %v, got %v %v", endpoint, want[0], want[1], recordType, value)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// assumeRoleSessionName is the session name of the assumed role, it shows in the CloudTrail events of the run.
+const assumeRoleSessionName = "prombench-infra"
+
+// permissions are the IAM actions checked before running a command with an assumed role,
+// the commands that aren't listed only read the cluster to connect to it.
+var permissions = provider.CommandPermissions{
+	"eks cluster create": {"eks:CreateCluster", "eks:DescribeCluster", "eks:CreateNodegroup", "eks:DescribeNodegroup", "iam:PassRole"},
+	"eks cluster delete": {"eks:DeleteCluster", "eks:DescribeCluster", "eks:DeleteNodegroup", "eks:ListNodegroups"},
+	"eks nodes create":   {"eks:CreateNodegroup", "eks:DescribeNodegroup", "eks:DescribeCluster", "iam:PassRole"},
+	"eks nodes delete":   {"eks:DeleteNodegroup", "eks:DescribeNodegroup", "eks:DescribeCluster"},
+	"eks nodes replace":  {"eks:CreateNodegroup", "eks:DeleteNodegroup", "eks:DescribeNodegroup", "eks:ListNodegroups", "eks:DescribeCluster", "iam:PassRole"},
+	"eks":                {"eks:DescribeCluster"},
+}
+
+// assumeRole returns a session with the credentials of the role passed from the cli,
+// so the run works in the account of the role with its permissions.
+func (c *EKS) assumeRole(base *awsSession.Session) (*awsSession.Session, error) {
+	creds := stscreds.NewCredentials(base, c.AssumeRole, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = assumeRoleSessionName
+		if c.AssumeRoleExternalID != "" {
+			p.ExternalID = aws.String(c.AssumeRoleExternalID)
+		}
+	})
+	sess, err := awsSession.NewSession(base.Config.Copy(&aws.Config{Credentials: creds}))
+	if err != nil {
+		return nil, fmt.Errorf("creating the session of role %v err: %v", c.AssumeRole, err)
+	}
+	id, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("assuming role %v, the auth credentials need the sts:AssumeRole permission on it err: %v", c.AssumeRole, err)
+	}
+	log.Printf("assumed role %v in account %v as %v", c.AssumeRole, aws.StringValue(id.Account), aws.StringValue(id.Arn))
+	return sess, nil
+}
+
+// checkPermissions returns an error when the assumed role isn't allowed an action of the command,
+// so a run in an isolated account fails before it creates anything.
+// The policies are simulated with the base session since the role usually can't simulate its own policies.
+func (c *EKS) checkPermissions(base *awsSession.Session, command string) error {
+	required := permissions.Required(command, "eks")
+	res, err := iam.New(base).SimulatePrincipalPolicy(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(c.AssumeRole),
+		ActionNames:     aws.StringSlice(required),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AccessDenied" {
+			log.Printf("skipping the permissions check of role %v, the auth credentials need the iam:SimulatePrincipalPolicy permission: %v", c.AssumeRole, err)
+			return nil
+		}
+		return fmt.Errorf("checking the permissions of role %v err: %v", c.AssumeRole, err)
+	}
+	if denied := deniedActions(res.EvaluationResults); len(denied) > 0 {
+		return fmt.Errorf("role %v is not allowed %v for %q", c.AssumeRole, strings.Join(denied, ", "), command)
+	}
+	return nil
+}
+
+// deniedActions returns the simulated actions that aren't allowed, explicitly or implicitly denied.
+func deniedActions(results []*iam.EvaluationResult) []string {
+	var denied []string
+	for _, r := range results {
+		if aws.StringValue(r.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+			denied = append(denied, aws.StringValue(r.EvalActionName))
+		}
+	}
+	return denied
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+func TestDeniedActions(t *testing.T) {
+	results := []*iam.EvaluationResult{
+		{EvalActionName: aws.String("eks:CreateCluster"), EvalDecision: aws.String(iam.PolicyEvaluationDecisionTypeAllowed)},
+		{EvalActionName: aws.String("iam:PassRole"), EvalDecision: aws.String(iam.PolicyEvaluationDecisionTypeImplicitDeny)},
+		{EvalActionName: aws.String("eks:CreateNodegroup"), EvalDecision: aws.String(iam.PolicyEvaluationDecisionTypeExplicitDeny)},
+	}
+	want := []string{"iam:PassRole", "eks:CreateNodegroup"}
+	if got := deniedActions(results); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := deniedActions(results[:1]); got != nil {
+		t.Fatalf("expected no denied actions, got %v", got)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/ssm"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+const (
+	bastionInstanceType = "t3.micro"
+	// bastionAMIParameter is the public SSM parameter with the id of the latest Amazon Linux AMI of the region.
+	bastionAMIParameter = "/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64"
+	bastionUser         = "ec2-user"
+	// bastionTag is set on the bastion instance and its security group to the name of the cluster.
+	bastionTag = "prombench-bastion"
+)
+
+// checkBastion checks the bastion options before the cluster is created.
+func (c *EKS) checkBastion() error {
+	if !c.DeploymentResource.Bastion.Enabled {
+		return nil
+	}
+	if c.SSHPublicKey == "" {
+		return fmt.Errorf("the bastion requires an ssh public key, set --ssh-public-key")
+	}
+	return c.DeploymentResource.Bastion.Validate()
+}
+
+// bastionSubnet returns the first subnet that assigns public IP addresses, the first subnet when none does.
+func bastionSubnet(subnets []*ec2.Subnet) string {
+	for _, s := range subnets {
+		if aws.BoolValue(s.MapPublicIpOnLaunch) {
+			return aws.StringValue(s.SubnetId)
+		}
+	}
+	if len(subnets) == 0 {
+		return ""
+	}
+	return aws.StringValue(subnets[0].SubnetId)
+}
+
+// bastionFilters select the resources of the bastion of a cluster.
+func bastionFilters(clusterName string) []*ec2.Filter {
+	return []*ec2.Filter{{Name: aws.String("tag:" + bastionTag), Values: aws.StringSlice([]string{clusterName})}}
+}
+
+// createBastion creates a small instance in a subnet of a cluster, with a security group that opens its ssh port
+// to the source ranges and allows it to connect to the api server, and logs how to reach the api server through it,
+// e.g. the private endpoint of a cluster without public access. A bastion that already exists is reused.
+func (c *EKS) createBastion(clusterName string) error {
+	opts := c.DeploymentResource.Bastion
+	rep, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		return fmt.Errorf("getting the network of cluster %v err: %v", clusterName, err)
+	}
+	vpc := rep.Cluster.ResourcesVpcConfig
+	clientEC2 := ec2.New(c.sessionAWS)
+	subnets, err := clientEC2.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: vpc.SubnetIds})
+	if err != nil {
+		return fmt.Errorf("getting the subnets of cluster %v err: %v", clusterName, err)
+	}
+	subnet := bastionSubnet(subnets.Subnets)
+	if subnet == "" {
+		return fmt.Errorf("cluster %v has no subnets for the bastion", clusterName)
+	}
+
+	name := provider.BastionName(clusterName)
+	tags := []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}, {Key: aws.String(bastionTag), Value: aws.String(clusterName)}}
+	groups, err := clientEC2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: bastionFilters(clusterName)})
+	if err != nil {
+		return fmt.Errorf("getting the security group of the bastion %v err: %v", name, err)
+	}
+	var group string
+	if len(groups.SecurityGroups) > 0 {
+		group = aws.StringValue(groups.SecurityGroups[0].GroupId)
+	} else {
+		res, err := clientEC2.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+			GroupName:         aws.String(name),
+			Description:       aws.String(fmt.Sprintf("ssh to the bastion of cluster %v", clusterName)),
+			VpcId:             vpc.VpcId,
+			TagSpecifications: []*ec2.TagSpecification{{ResourceType: aws.String(ec2.ResourceTypeSecurityGroup), Tags: tags}},
+		})
+		if err != nil {
+			return fmt.Errorf("creating the security group of the bastion %v err: %v", name, err)
+		}
+		group = aws.StringValue(res.GroupId)
+	}
+	ranges := make([]*ec2.IpRange, 0, len(opts.SourceRanges))
+	for _, r := range opts.SourceRanges {
+		ranges = append(ranges, &ec2.IpRange{CidrIp: aws.String(r)})
+	}
+	if _, err := clientEC2.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       aws.String(group),
+		IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(22), ToPort: aws.Int64(22), IpRanges: ranges}},
+	}); err != nil && !isDuplicatePermission(err) {
+		return fmt.Errorf("opening the ssh port of the bastion %v err: %v", name, err)
+	}
+	// The cluster security group only allows the nodes to connect to the api server.
+	if _, err := clientEC2.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: vpc.ClusterSecurityGroupId,
+		IpPermissions: []*ec2.IpPermission{{
+			IpProtocol:       aws.String("tcp"),
+			FromPort:         aws.Int64(443),
+			ToPort:           aws.Int64(443),
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String(group), Description: aws.String("api server access of the bastion " + name)}},
+		}},
+	}); err != nil && !isDuplicatePermission(err) {
+		return fmt.Errorf("allowing the bastion %v to connect to the api server err: %v", name, err)
+	}
+
+	instances, err := c.bastionInstances(clusterName, "pending", "running")
+	if err != nil {
+		return err
+	}
+	var id string
+	if len(instances) > 0 {
+		id = aws.StringValue(instances[0].InstanceId)
+		log.Printf("Reusing the bastion '%v' %v", name, id)
+	} else {
+		ami, err := ssm.New(c.sessionAWS).GetParameter(&ssm.GetParameterInput{Name: aws.String(bastionAMIParameter)})
+		if err != nil {
+			return fmt.Errorf("getting the AMI of the bastion %v err: %v", name, err)
+		}
+		instanceType := opts.MachineType
+		if instanceType == "" {
+			instanceType = bastionInstanceType
+		}
+		log.Printf("Creating the bastion '%v' in subnet '%v'", name, subnet)
+		res, err := clientEC2.RunInstances(&ec2.RunInstancesInput{
+			ImageId:      ami.Parameter.Value,
+			InstanceType: aws.String(instanceType),
+			KeyName:      aws.String(sshKeyPairName(clusterName)),
+			MinCount:     aws.Int64(1),
+			MaxCount:     aws.Int64(1),
+			NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{{
+				DeviceIndex:              aws.Int64(0),
+				SubnetId:                 aws.String(subnet),
+				AssociatePublicIpAddress: aws.Bool(true),
+				Groups:                   aws.StringSlice([]string{group}),
+			}},
+			TagSpecifications: []*ec2.TagSpecification{{ResourceType: aws.String(ec2.ResourceTypeInstance), Tags: tags}},
+		})
+		if err != nil {
+			return fmt.Errorf("creating the bastion %v err: %v", name, err)
+		}
+		id = aws.StringValue(res.Instances[0].InstanceId)
+	}
+
+	var address string
+	if err := provider.RetryUntilTrue(fmt.Sprintf("creating bastion:%v", name), provider.EKSRetryCount, func() (bool, error) {
+		res, err := clientEC2.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{id})})
+		if err != nil {
+			return false, err
+		}
+		for _, r := range res.Reservations {
+			for _, i := range r.Instances {
+				address = aws.StringValue(i.PublicIpAddress)
+				if aws.StringValue(i.State.Name) == ec2.InstanceStateNameRunning && address != "" {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}); err != nil {
+		return err
+	}
+	endpoint := strings.TrimPrefix(aws.StringValue(rep.Cluster.Endpoint), "https://")
+	log.Print(provider.Bastion{Name: name, User: bastionUser, Address: address, Endpoint: endpoint}.ConnectionDetails())
+	return nil
+}
+
+// bastionInstances returns the bastion instances of a cluster in the given states.
+func (c *EKS) bastionInstances(clusterName string, states ...string) ([]*ec2.Instance, error) {
+	filters := append(bastionFilters(clusterName), &ec2.Filter{Name: aws.String("instance-state-name"), Values: aws.StringSlice(states)})
+	var instances []*ec2.Instance
+	if err := ec2.New(c.sessionAWS).DescribeInstancesPages(&ec2.DescribeInstancesInput{Filters: filters}, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, r := range page.Reservations {
+			instances = append(instances, r.Instances...)
+		}
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("listing the bastion instances of cluster %v err: %v", clusterName, err)
+	}
+	return instances, nil
+}
+
+// deleteBastion terminates the bastion instance of a cluster and deletes its security group, if there is one.
+// It runs once the cluster is deleted, with the cluster security group that refers to the bastion security group.
+func (c *EKS) deleteBastion(clusterName string) error {
+	name := provider.BastionName(clusterName)
+	live := []string{"pending", "running", "stopping", "stopped", "shutting-down"}
+	instances, err := c.bastionInstances(clusterName, live...)
+	if err != nil {
+		return err
+	}
+	clientEC2 := ec2.New(c.sessionAWS)
+	if len(instances) > 0 {
+		ids := make([]*string, 0, len(instances))
+		for _, i := range instances {
+			ids = append(ids, i.InstanceId)
+		}
+		log.Printf("Removing the bastion '%v'", name)
+		if _, err := clientEC2.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: ids}); err != nil {
+			return fmt.Errorf("deleting the bastion %v err: %v", name, err)
+		}
+		if err := provider.RetryUntilTrue(fmt.Sprintf("deleting bastion:%v", name), provider.GlobalRetryCount, func() (bool, error) {
+			left, err := c.bastionInstances(clusterName, live...)
+			return len(left) == 0, err
+		}); err != nil {
+			return err
+		}
+	}
+
+	groups, err := clientEC2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: bastionFilters(clusterName)})
+	if err != nil {
+		return fmt.Errorf("getting the security group of the bastion %v err: %v", name, err)
+	}
+	for _, g := range groups.SecurityGroups {
+		// The network interface of the terminated instance keeps the security group in use for a while.
+		if err := c.removeDependent(dependent{
+			Dependent:    provider.Dependent{Kind: "security group", Name: aws.StringValue(g.GroupId)},
+			resourceType: "ec2:security-group",
+			id:           aws.StringValue(g.GroupId),
+		}); err != nil {
+			return fmt.Errorf("deleting the security group of the bastion %v err: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func isDuplicatePermission(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == "InvalidPermission.Duplicate"
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestBastionSubnet(t *testing.T) {
+	private := &ec2.Subnet{SubnetId: aws.String("subnet-private"), MapPublicIpOnLaunch: aws.Bool(false)}
+	public := &ec2.Subnet{SubnetId: aws.String("subnet-public"), MapPublicIpOnLaunch: aws.Bool(true)}
+	for _, tc := range []struct {
+		subnets []*ec2.Subnet
+		want    string
+	}{
+		{[]*ec2.Subnet{private, public}, "subnet-public"},
+		{[]*ec2.Subnet{private}, "subnet-private"},
+		{nil, ""},
+	} {
+		if got := bastionSubnet(tc.subnets); got != tc.want {
+			t.Errorf("want subnet %q, got %q", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// removeDeletionProtection fails for a cluster with the deletion protection tag
+// unless ForceDelete is set, in which case the tag is removed so the cleanup can delete the cluster.
+// It runs before the node groups are deleted so a protected cluster is left untouched.
+// A cluster that doesn't exist is left to the delete request.
+func (c *EKS) removeDeletionProtection(name string) error {
+	rep, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return fmt.Errorf("Couldn't get cluster '%v': %v", name, err)
+	}
+	protected, err := provider.CheckDeletionProtection(name, aws.StringValueMap(rep.Cluster.Tags), c.ForceDelete)
+	if err != nil || !protected {
+		return err
+	}
+
+	if _, err := c.clientEKS.UntagResource(&eks.UntagResourceInput{
+		ResourceArn: rep.Cluster.Arn,
+		TagKeys:     aws.StringSlice([]string{provider.DeletionProtectionLabel}),
+	}); err != nil {
+		return fmt.Errorf("Couldn't remove the deletion protection of cluster '%v': %v", name, err)
+	}
+	log.Printf("Removed the deletion protection of cluster '%v' with --force-delete", name)
+	return nil
+}
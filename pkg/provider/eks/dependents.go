@@ -0,0 +1,195 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/prometheusservice"
+	tagging "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// clusterTagValue is the value of the kubernetes.io/cluster/<name> tag the AWS cloud provider and the load balancer controller
+// set on the resources they create for the objects of a cluster, e.g. the load balancers of the LoadBalancer services.
+const clusterTagValue = "owned"
+
+// dependentTypes are the types of the dependents removed after the cluster delete, in the order they are removed.
+// The load balancers go first since their target groups, addresses and security groups are in use until then.
+var dependentTypes = []struct {
+	resourceType, kind string
+}{
+	{"elasticloadbalancing:loadbalancer", "load balancer"},
+	{"elasticloadbalancing:targetgroup", "target group"},
+	{"ec2:elastic-ip", "elastic ip"},
+	{"ec2:security-group", "security group"},
+	{"ec2:volume", "volume"},
+	{"aps:workspace", "managed Prometheus workspace"},
+}
+
+// dependent is a resource tagged as owned by the cluster, the ID is the ARN for the load balancers v2 and target groups
+// and the name or id of the ARN resource otherwise.
+type dependent struct {
+	provider.Dependent
+	resourceType string
+	id           string
+	order        int
+}
+
+// clusterTag returns the tag key the resources owned by the cluster are tagged with.
+func clusterTag(cluster string) string {
+	return "kubernetes.io/cluster/" + cluster
+}
+
+// dependentsByARN returns the dependents of the tagged ARNs in the order they are removed.
+func dependentsByARN(arns []string) ([]dependent, error) {
+	var deps []dependent
+	for _, a := range arns {
+		parsed, err := arn.Parse(a)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the arn %v err: %v", a, err)
+		}
+		parts := strings.Split(parsed.Resource, "/")
+		resourceType := parsed.Service + ":" + parts[0]
+		order := -1
+		for i, t := range dependentTypes {
+			if t.resourceType == resourceType {
+				order = i
+			}
+		}
+		if order < 0 || len(parts) < 2 {
+			return nil, fmt.Errorf("unknown dependent resource %v", a)
+		}
+		d := dependent{resourceType: resourceType, order: order, id: parts[1]}
+		switch {
+		case resourceType == "elasticloadbalancing:targetgroup", resourceType == "elasticloadbalancing:loadbalancer" && len(parts) > 2:
+			// The load balancers v2 have the app or net type before the name.
+			d.id = a
+			d.Name = parts[len(parts)-2]
+		default:
+			d.Name = parts[1]
+		}
+		d.Kind = dependentTypes[order].kind
+		deps = append(deps, d)
+	}
+	sort.SliceStable(deps, func(i, j int) bool { return deps[i].order < deps[j].order })
+	return deps, nil
+}
+
+// removeDependents removes the resources tagged as owned by the cluster, which outlive the cluster.
+// It runs once the cluster is deleted so nothing recreates them, the volumes that are still attached are reported as failed.
+func (c *EKS) removeDependents(cluster string) error {
+	resourceTypes := make([]string, 0, len(dependentTypes))
+	for _, t := range dependentTypes {
+		resourceTypes = append(resourceTypes, t.resourceType)
+	}
+	var arns []string
+	if err := tagging.New(c.sessionAWS).GetResourcesPages(&tagging.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice(resourceTypes),
+		TagFilters:          []*tagging.TagFilter{{Key: aws.String(clusterTag(cluster)), Values: aws.StringSlice([]string{clusterTagValue})}},
+	}, func(page *tagging.GetResourcesOutput, _ bool) bool {
+		for _, r := range page.ResourceTagMappingList {
+			arns = append(arns, aws.StringValue(r.ResourceARN))
+		}
+		return true
+	}); err != nil {
+		return fmt.Errorf("listing the resources tagged %v=%v err: %v", clusterTag(cluster), clusterTagValue, err)
+	}
+	deps, err := dependentsByARN(arns)
+	if err != nil {
+		return err
+	}
+
+	report := &provider.DependentsReport{Cluster: cluster}
+	for _, d := range deps {
+		report.Add(d.Dependent, c.removeDependent(d))
+	}
+	return report.Err()
+}
+
+// removeDependent deletes the dependent, retrying while it is still in use by a resource that is being deleted.
+// A dependent that is already gone counts as deleted.
+func (c *EKS) removeDependent(d dependent) error {
+	var del func() error
+	switch d.resourceType {
+	case "elasticloadbalancing:loadbalancer":
+		if strings.HasPrefix(d.id, "arn:") {
+			del = func() error {
+				_, err := elbv2.New(c.sessionAWS).DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(d.id)})
+				return err
+			}
+		} else {
+			del = func() error {
+				_, err := elb.New(c.sessionAWS).DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(d.id)})
+				return err
+			}
+		}
+	case "elasticloadbalancing:targetgroup":
+		del = func() error {
+			_, err := elbv2.New(c.sessionAWS).DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(d.id)})
+			return err
+		}
+	case "ec2:elastic-ip":
+		del = func() error {
+			_, err := ec2.New(c.sessionAWS).ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: aws.String(d.id)})
+			return err
+		}
+	case "ec2:security-group":
+		del = func() error {
+			_, err := ec2.New(c.sessionAWS).DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String(d.id)})
+			return err
+		}
+	case "ec2:volume":
+		del = func() error {
+			_, err := ec2.New(c.sessionAWS).DeleteVolume(&ec2.DeleteVolumeInput{VolumeId: aws.String(d.id)})
+			return err
+		}
+	case "aps:workspace":
+		del = func() error {
+			_, err := prometheusservice.New(c.sessionAWS).DeleteWorkspace(&prometheusservice.DeleteWorkspaceInput{WorkspaceId: aws.String(d.id)})
+			return err
+		}
+	}
+
+	var last error
+	err := provider.RetryUntilTrue(fmt.Sprintf("deleting %v", d.Dependent), provider.GlobalRetryCount, func() (bool, error) {
+		last = del()
+		aerr, ok := last.(awserr.Error)
+		switch {
+		case last == nil:
+			return true, nil
+		case !ok:
+			return false, last
+		case strings.Contains(aerr.Code(), "NotFound"):
+			return true, nil
+		// The network interfaces of a deleted load balancer keep its security groups and target groups in use for a few minutes.
+		case aerr.Code() == "DependencyViolation", aerr.Code() == elbv2.ErrCodeResourceInUseException:
+			return false, nil
+		}
+		return false, last
+	})
+	if err != nil && last != nil {
+		return last
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependentsByARN(t *testing.T) {
+	deps, err := dependentsByARN([]string{
+		"arn:aws:ec2:eu-west-1:123456789012:volume/vol-0123",
+		"arn:aws:ec2:eu-west-1:123456789012:security-group/sg-0123",
+		"arn:aws:elasticloadbalancing:eu-west-1:123456789012:targetgroup/k8s-prombench/abc",
+		"arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/net/k8s-prombench-nginx/def",
+		"arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/a0123456789",
+		"arn:aws:ec2:eu-west-1:123456789012:elastic-ip/eipalloc-0123",
+		"arn:aws:aps:eu-west-1:123456789012:workspace/ws-0123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, d := range deps {
+		got = append(got, d.String()+" "+d.id)
+	}
+	want := []string{
+		"load balancer/k8s-prombench-nginx arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/net/k8s-prombench-nginx/def",
+		"load balancer/a0123456789 a0123456789",
+		"target group/k8s-prombench arn:aws:elasticloadbalancing:eu-west-1:123456789012:targetgroup/k8s-prombench/abc",
+		"elastic ip/eipalloc-0123 eipalloc-0123",
+		"security group/sg-0123 sg-0123",
+		"volume/vol-0123 vol-0123",
+		"managed Prometheus workspace/ws-0123 ws-0123",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want the dependents in the removal order\n%v\ngot\n%v", want, got)
+	}
+
+	for _, a := range []string{"not-an-arn", "arn:aws:ec2:eu-west-1:123456789012:instance/i-0123"} {
+		if _, err := dependentsByARN([]string{a}); err == nil {
+			t.Errorf("%v: expected an error", a)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	k8sProvider "github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// ebsCSIDriver attaches the EBS volumes to the nodes, it is installed with the aws-ebs-csi-driver addon.
+const ebsCSIDriver = "ebs.csi.aws.com"
+
+// existingDisks looks up the EBS volumes passed from the cli, by PersistentVolume name.
+func (c *EKS) existingDisks() ([]k8sProvider.ExistingDisk, error) {
+	if len(c.ExistingVolumes) == 0 {
+		return nil, nil
+	}
+	names := c.existingDiskNames()
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		ids = append(ids, c.ExistingVolumes[name])
+	}
+	res, err := ec2.New(c.sessionAWS).DescribeVolumes(&ec2.DescribeVolumesInput{VolumeIds: aws.StringSlice(ids)})
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get the volumes %v err: %v", ids, err)
+	}
+	volumes := map[string]*ec2.Volume{}
+	for _, v := range res.Volumes {
+		volumes[aws.StringValue(v.VolumeId)] = v
+	}
+
+	disks := make([]k8sProvider.ExistingDisk, 0, len(names))
+	for _, name := range names {
+		v, ok := volumes[c.ExistingVolumes[name]]
+		if !ok {
+			return nil, fmt.Errorf("volume %s not found", c.ExistingVolumes[name])
+		}
+		d, err := existingDisk(name, v)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, d)
+	}
+	return disks, nil
+}
+
+// existingDisk returns the PersistentVolume of an EBS volume, in the availability zone of the volume.
+func existingDisk(name string, v *ec2.Volume) (k8sProvider.ExistingDisk, error) {
+	id := aws.StringValue(v.VolumeId)
+	switch state := aws.StringValue(v.State); state {
+	case ec2.VolumeStateAvailable:
+	case ec2.VolumeStateInUse:
+		// The volume can't be attached to a node while it is attached to another instance.
+		for _, a := range v.Attachments {
+			log.Printf("WARNING: the volume %s is attached to %s", id, aws.StringValue(a.InstanceId))
+		}
+	default:
+		return k8sProvider.ExistingDisk{}, fmt.Errorf("volume %s is %s, it must be available", id, state)
+	}
+	return k8sProvider.ExistingDisk{
+		Name:   name,
+		Disk:   id,
+		Driver: ebsCSIDriver,
+		Handle: id,
+		Zone:   aws.StringValue(v.AvailabilityZone),
+		SizeGB: aws.Int64Value(v.Size),
+	}, nil
+}
+
+// existingDiskNames returns the PersistentVolume names of the volumes passed from the cli.
+func (c *EKS) existingDiskNames() []string {
+	names := make([]string, 0, len(c.ExistingVolumes))
+	for name := range c.ExistingVolumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestExistingDisk(t *testing.T) {
+	v := &ec2.Volume{
+		VolumeId:         aws.String("vol-1234"),
+		State:            aws.String(ec2.VolumeStateAvailable),
+		AvailabilityZone: aws.String("us-east-2a"),
+		Size:             aws.Int64(100),
+	}
+	d, err := existingDisk("tsdb-0", v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Name != "tsdb-0" || d.Handle != "vol-1234" || d.Driver != ebsCSIDriver || d.Zone != "us-east-2a" || d.SizeGB != 100 {
+		t.Errorf("want the persistent volume in the zone of the volume, got %+v", d)
+	}
+
+	v.State = aws.String(ec2.VolumeStateInUse)
+	if _, err := existingDisk("tsdb-0", v); err != nil {
+		t.Errorf("unexpected error for a volume in use: %v", err)
+	}
+	v.State = aws.String(ec2.VolumeStateCreating)
+	if _, err := existingDisk("tsdb-0", v); err == nil {
+		t.Error("expected an error for a volume that isn't available")
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+	k8sProvider "github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// dnsZone returns the Route 53 client and the DNS name of the hosted zone.
+func (c *EKS) dnsZone() (*route53.Route53, string, error) {
+	if c.DNSZone == "" {
+		return nil, "", fmt.Errorf("the DNS records require --dns-zone")
+	}
+	clientRoute53 := route53.New(c.sessionAWS)
+	zone, err := clientRoute53.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(c.DNSZone)})
+	if err != nil {
+		return nil, "", fmt.Errorf("Couldn't get the hosted zone '%v': %v", c.DNSZone, err)
+	}
+	return clientRoute53, aws.StringValue(zone.HostedZone.Name), nil
+}
+
+// upsertDNSRecords points the DNS records at the load balancers of their services once they are assigned.
+// Existing A, AAAA and CNAME records with the same name are replaced.
+func (c *EKS) upsertDNSRecords() error {
+	if len(c.DNSRecords) == 0 {
+		return nil
+	}
+	clientRoute53, zoneName, err := c.dnsZone()
+	if err != nil {
+		return err
+	}
+	for service, record := range c.DNSRecords {
+		name, err := provider.DNSRecordName(record, zoneName)
+		if err != nil {
+			return err
+		}
+		obj, err := k8sProvider.LoadBalancerService(c.k8sResources, service)
+		if err != nil {
+			return err
+		}
+		endpoint, err := c.k8sProvider.WaitForServiceEndpoint(obj.Namespace, obj.Name, provider.DNSEndpointTimeout)
+		if err != nil {
+			return err
+		}
+		recordType, value := provider.DNSRecord(endpoint)
+		existing, err := c.endpointRecords(clientRoute53, name)
+		if err != nil {
+			return err
+		}
+
+		// A record can't change its type with an upsert, so the records of the other types are deleted in the same batch.
+		var changes []*route53.Change
+		for _, r := range existing {
+			if aws.StringValue(r.Type) != recordType {
+				changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: r})
+			}
+		}
+		changes = append(changes, &route53.Change{
+			Action: aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name:            aws.String(name),
+				Type:            aws.String(recordType),
+				TTL:             aws.Int64(provider.DNSRecordTTL),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(value)}},
+			},
+		})
+		if _, err := clientRoute53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(c.DNSZone),
+			ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		}); err != nil {
+			return fmt.Errorf("Couldn't update the DNS record '%v': %v", name, err)
+		}
+		log.Printf("DNS record %v %v points at %v", name, recordType, value)
+	}
+	return nil
+}
+
+// deleteDNSRecords deletes the DNS records of the services, records that don't exist are skipped.
+func (c *EKS) deleteDNSRecords() error {
+	if len(c.DNSRecords) == 0 {
+		return nil
+	}
+	clientRoute53, zoneName, err := c.dnsZone()
+	if err != nil {
+		return err
+	}
+	for _, record := range c.DNSRecords {
+		name, err := provider.DNSRecordName(record, zoneName)
+		if err != nil {
+			return err
+		}
+		existing, err := c.endpointRecords(clientRoute53, name)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			continue
+		}
+		var changes []*route53.Change
+		for _, r := range existing {
+			changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: r})
+		}
+		if _, err := clientRoute53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(c.DNSZone),
+			ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		}); err != nil {
+			return fmt.Errorf("Couldn't delete the DNS record '%v': %v", name, err)
+		}
+		log.Printf("DNS record %v deleted", name)
+	}
+	return nil
+}
+
+// endpointRecords returns the A, AAAA and CNAME records with the name.
+// The records are listed in name order, so the list starts at the name.
+func (c *EKS) endpointRecords(clientRoute53 *route53.Route53, name string) ([]*route53.ResourceRecordSet, error) {
+	rep, err := clientRoute53.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(c.DNSZone),
+		StartRecordName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't list the DNS records '%v': %v", name, err)
+	}
+	var records []*route53.ResourceRecordSet
+	for _, r := range rep.ResourceRecordSets {
+		if aws.StringValue(r.Name) == name && provider.IsDNSEndpointRecordType(aws.StringValue(r.Type)) {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
@@ -17,10 +17,14 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"regexp"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -31,7 +35,6 @@ import (
 	"gopkg.in/alecthomas/kingpin.v2"
 	yamlGo "gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes/scheme"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	awsToken "sigs.k8s.io/aws-iam-authenticator/pkg/token"
 
@@ -49,6 +52,57 @@ type eksCluster struct {
 // EKS holds the fields used to generate an API request.
 type EKS struct {
 	Auth string
+	// The ARN of a role assumed with the auth for all requests, to run in the account of the role with its permissions.
+	// AssumeRoleExternalID is the external id required by the trust policy of the role, if any.
+	AssumeRole           string
+	AssumeRoleExternalID string
+	// Additional node groups to create together with the cluster.
+	NodePools provider.NodePoolSpecs
+	// Cluster autoscaler node bounds for node groups, by node group name.
+	Autoscaling provider.NodePoolAutoscalings
+	// An untainted node group for the kube-system workloads, all other node groups get the benchmark taint.
+	SystemNodePool string
+	// The ARN of an existing IAM role the nodes of all node groups run as, instead of the EKS_WORKER_ROLE_ARN variable.
+	NodeRoleARN string
+	// An ssh public key file imported as an EC2 key pair for the nodes of the created node groups, empty disables ssh logins.
+	// SSHSourceSecurityGroups are the security groups allowed to connect, all addresses when empty.
+	SSHPublicKey            string
+	SSHSourceSecurityGroups []string
+	// The root volume size in GB of the nodes of the created node groups, 0 keeps the value from the cluster file.
+	DiskSize int64
+	// Enable Nitro Enclaves on the nodes of the created node groups with a launch template.
+	NitroEnclaves bool
+	// A node system config file with the kubelet and kernel config of the nodes of the created node groups, set with a launch template.
+	NodeSystemConfig string
+	// Node groups to delete by name instead of the ones in the cluster deployment file.
+	NodePoolNames []string
+	// Force allows deleting the last remaining node groups of a cluster.
+	Force bool
+	// ForceDelete removes the deletion protection tag of a cluster before deleting it.
+	ForceDelete bool
+	// The AMI type for all node groups, e.g. AL2_x86_64 or BOTTLEROCKET_x86_64.
+	AMIType string
+	// Enable IAM roles for service accounts and bind k8s service accounts to IAM roles.
+	IRSA         bool
+	IRSABindings provider.WorkloadIdentityBindings
+	// Availability zones of the region to spread the nodes of all node groups across.
+	Zones []string
+	// A cluster spec file whose fields are used for the cluster create flags that aren't set.
+	SpecFile string
+	// Skip the vCPU and subnet IP address quota check before creating a cluster.
+	SkipQuotaCheck bool
+	// Enable or disable the control plane logging and the CloudWatch monitoring addon, empty keeps the defaults.
+	Logging    string
+	Monitoring string
+	// Create an Amazon Managed Service for Prometheus workspace for the cluster, isolated from the self-hosted Prometheus of the deployments.
+	ManagedPrometheus bool
+	// The service IP range of the cluster, empty keeps the value from the cluster file.
+	ServiceCIDR string
+	// Existing EBS volume ids attached to the nodes through PersistentVolumes, by PersistentVolume name.
+	ExistingVolumes map[string]string
+	// DNSRecords point a record in the DNSZone hosted zone at the load balancer of a service, by service name.
+	DNSRecords map[string]string
+	DNSZone    string
 
 	ClusterName string
 	// The eks client used when performing EKS requests.
@@ -75,15 +129,23 @@ type EKS struct {
 func New(dr *provider.DeploymentResource) *EKS {
 	eks := &EKS{
 		DeploymentResource: dr,
+		DNSRecords:         map[string]string{},
+		ExistingVolumes:    map[string]string{},
 	}
 	return eks
 }
 
 // NewEKSClient sets the EKS client used when performing the GKE requests.
-func (c *EKS) NewEKSClient(*kingpin.ParseContext) error {
+func (c *EKS) NewEKSClient(parseContext *kingpin.ParseContext) error {
 	if c.Auth != "" {
+	} else if f := c.DeploymentResource.CredentialsFile; f != "" {
+		auth, err := provider.CredentialsAuth(f, "eks")
+		if err != nil {
+			return err
+		}
+		c.Auth = auth
 	} else if c.Auth = os.Getenv("AWS_APPLICATION_CREDENTIALS"); c.Auth == "" {
-		return errors.Errorf("no auth provided set the auth flag or the AWS_APPLICATION_CREDENTIALS env variable")
+		return errors.Errorf("no auth provided set the auth flag, --credentials-file or the AWS_APPLICATION_CREDENTIALS env variable")
 	}
 
 	// When the auth variable points to a file
@@ -114,6 +176,18 @@ func (c *EKS) NewEKSClient(*kingpin.ParseContext) error {
 		Credentials: credentials.NewStaticCredentialsFromCreds(*credValue),
 		Region:      aws.String(c.DeploymentVars["ZONE"]),
 	}))
+	if c.AssumeRole != "" {
+		command := "eks"
+		if parseContext.SelectedCommand != nil {
+			command = parseContext.SelectedCommand.FullCommand()
+		}
+		if err := c.checkPermissions(awsSess, command); err != nil {
+			return err
+		}
+		if awsSess, err = c.assumeRole(awsSess); err != nil {
+			return err
+		}
+	}
 
 	c.sessionAWS = awsSess
 	c.clientEKS = eks.New(awsSess)
@@ -129,7 +203,7 @@ func (c *EKS) checkDeploymentVarsAndFiles() error {
 			return fmt.Errorf("missing required %v variable", k)
 		}
 	}
-	if len(c.DeploymentFiles) == 0 {
+	if len(c.DeploymentFiles) == 0 && len(c.DeploymentResource.Helm.Charts) == 0 {
 		return fmt.Errorf("missing deployment file(s)")
 	}
 	return nil
@@ -142,7 +216,8 @@ func (c *EKS) SetupDeploymentResources(*kingpin.ParseContext) error {
 		c.DeploymentResource.DefaultDeploymentVars,
 		c.DeploymentResource.FlagDeploymentVars,
 	)
-	return nil
+	c.DeploymentResource.FanOut.SetFirstRegion(c.DeploymentVars)
+	return provider.ApplyClusterNameSuffix(c.DeploymentVars, c.DeploymentResource.ClusterNameSuffix, provider.EKSClusterNameRules)
 }
 
 // EKSDeploymentParse parses the cluster/nodegroups deployment file and saves the result as bytes grouped by the filename.
@@ -173,9 +248,14 @@ func (c *EKS) K8SDeploymentsParse(*kingpin.ParseContext) error {
 		return fmt.Errorf("Couldn't parse deployment files: %v", err)
 	}
 
+	helmResources, err := provider.HelmTemplate(c.DeploymentResource.Helm)
+	if err != nil {
+		return fmt.Errorf("Couldn't render the helm charts: %v", err)
+	}
+	deploymentResource = append(deploymentResource, helmResources...)
+
 	for _, deployment := range deploymentResource {
 
-		decode := scheme.Codecs.UniversalDeserializer().Decode
 		k8sObjects := make([]runtime.Object, 0)
 
 		for _, text := range strings.Split(string(deployment.Content), provider.Separator) {
@@ -184,10 +264,10 @@ func (c *EKS) K8SDeploymentsParse(*kingpin.ParseContext) error {
 				continue
 			}
 
-			resource, _, err := decode([]byte(text), nil, nil)
+			resource, err := k8sProvider.Decode([]byte(text))
 
 			if err != nil {
-				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, text[:100])
+				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, provider.Truncate(text, 100))
 			}
 			if resource == nil {
 				continue
@@ -203,16 +283,74 @@ func (c *EKS) K8SDeploymentsParse(*kingpin.ParseContext) error {
 
 // ClusterCreate create a new cluster or applies changes to an existing cluster.
 func (c *EKS) ClusterCreate(*kingpin.ParseContext) error {
+	if len(c.DeploymentResource.FanOut.Regions) > 0 {
+		if err := c.fanOut(); err != nil {
+			return fmt.Errorf("Multi-region cluster create failed: %v", err)
+		}
+		return nil
+	}
+	if err := c.checkNodeRole(); err != nil {
+		return fmt.Errorf("Invalid node role: %v", err)
+	}
+	if err := c.checkBastion(); err != nil {
+		return fmt.Errorf("Invalid bastion options: %v", err)
+	}
+	if err := c.DeploymentResource.ScrapeFirewall.Validate(); err != nil {
+		return fmt.Errorf("Invalid scrape firewall options: %v", err)
+	}
 	req := &eksCluster{}
+	var created []provider.ClusterInfo
 	for _, deployment := range c.eksResources {
 
 		if err := yamlGo.UnmarshalStrict(deployment.Content, req); err != nil {
 			return fmt.Errorf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
 		}
+		if err := c.addNodeGroups(req); err != nil {
+			return fmt.Errorf("Error adding node groups to cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.addSystemNodeGroup(req); err != nil {
+			return fmt.Errorf("Error adding the system node group to cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		c.setNodeRole(req)
+		if err := c.spreadZones(req); err != nil {
+			return fmt.Errorf("Error spreading the node groups of cluster '%v' across zones, file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		c.setNodeImages(req)
+		c.setLogging(req)
+		if err := c.setServiceCIDR(req); err != nil {
+			return fmt.Errorf("Error setting the service CIDR of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setAutoscaling(req); err != nil {
+			return fmt.Errorf("Error setting the node groups autoscaling for cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if len(c.IRSABindings) > 0 && !c.IRSA {
+			return fmt.Errorf("IRSA bindings for cluster '%v' require --irsa", *req.Cluster.Name)
+		}
+		if err := c.checkQuota(req); err != nil {
+			return fmt.Errorf("Quota check failed for cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setRemoteAccess(req); err != nil {
+			return fmt.Errorf("Error setting the ssh access of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setDiskSize(req); err != nil {
+			return fmt.Errorf("Error setting the disk size of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setNitroEnclaves(req); err != nil {
+			return fmt.Errorf("Error enabling the Nitro Enclaves of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setNodeSystemConfig(req); err != nil {
+			return fmt.Errorf("Error setting the node system config of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
 
 		log.Printf("Cluster create request: name:'%s'", *req.Cluster.Name)
-		_, err := c.clientEKS.CreateCluster(&req.Cluster)
+		start := time.Now()
+		exists, err := c.clusterExists(*req.Cluster.Name)
 		if err != nil {
+			return fmt.Errorf("Couldn't check whether cluster '%v' exists, file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if exists {
+			log.Printf("Cluster '%v' already exists, reusing it for the cluster name suffix", *req.Cluster.Name)
+		} else if _, err := c.clientEKS.CreateCluster(&req.Cluster); err != nil {
 			return fmt.Errorf("Couldn't create cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
 		}
 
@@ -226,11 +364,17 @@ func (c *EKS) ClusterCreate(*kingpin.ParseContext) error {
 			return fmt.Errorf("creating cluster err:%v", err)
 		}
 
+		if err := c.setupIRSA(*req.Cluster.Name); err != nil {
+			return fmt.Errorf("Couldn't set up IRSA for cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+
 		for _, nodegroupReq := range req.NodeGroups {
 			nodegroupReq.ClusterName = req.Cluster.Name
 			log.Printf("Nodegroup create request: NodeGroupName: '%s', ClusterName: '%s'", *nodegroupReq.NodegroupName, *req.Cluster.Name)
 			_, err := c.clientEKS.CreateNodegroup(&nodegroupReq)
-			if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && exists && aerr.Code() == eks.ErrCodeResourceInUseException {
+				log.Printf("Nodegroup '%s' already exists, reusing it", *nodegroupReq.NodegroupName)
+			} else if err != nil {
 				return fmt.Errorf("Couldn't create nodegroup '%v' for cluster '%v, file:%v ,err: %v", nodegroupReq.NodegroupName, req.Cluster.Name, deployment.FileName, err)
 			}
 
@@ -244,19 +388,280 @@ func (c *EKS) ClusterCreate(*kingpin.ParseContext) error {
 				return fmt.Errorf("creating nodegroup err:%v", err)
 			}
 		}
+
+		if err := c.enableMonitoring(*req.Cluster.Name, exists); err != nil {
+			return fmt.Errorf("Couldn't enable monitoring for cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if !exists {
+			c.recordProvisioning("create", req, start)
+		}
+
+		if c.DeploymentResource.Bastion.Enabled {
+			if err := c.createBastion(*req.Cluster.Name); err != nil {
+				return fmt.Errorf("Couldn't create the bastion of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+			}
+		}
+		if c.DeploymentResource.ScrapeFirewall.Enabled() {
+			if err := c.createScrapeFirewall(*req.Cluster.Name); err != nil {
+				return fmt.Errorf("Couldn't open the scrape ports of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+			}
+		}
+		info, err := c.clusterInfo(*req.Cluster.Name)
+		if err != nil {
+			return fmt.Errorf("Couldn't get the endpoint of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if c.ManagedPrometheus {
+			mp, err := c.createManagedPrometheus(*req.Cluster.Name)
+			if err != nil {
+				return fmt.Errorf("Couldn't create the managed Prometheus of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+			}
+			mp.Log(*req.Cluster.Name)
+			mp.SetVars(c.DeploymentVars)
+			info.ManagedPrometheus = &mp
+		}
+		created = append(created, info)
+	}
+	if c.DeploymentResource.InfoFile != "" {
+		if err := provider.WriteClusterInfo(c.DeploymentResource.InfoFile, created); err != nil {
+			return fmt.Errorf("Couldn't write the cluster info file: %v", err)
+		}
+	}
+	return c.bootstrap()
+}
+
+// fanOut runs cluster create once per region of the fan-out, with the cluster name suffixed with the region.
+func (c *EKS) fanOut() error {
+	if len(c.Zones) > 0 {
+		return fmt.Errorf("--zone is specific to a single region and can't be used with --regions")
+	}
+	dr := c.DeploymentResource
+	clusterName := provider.MergeDeploymentVars(dr.DefaultDeploymentVars, dr.FlagDeploymentVars)["CLUSTER_NAME"]
+	f := dr.FanOut
+	f.NameSuffix = dr.ClusterNameSuffix
+	return provider.RunFanOut(f, "eks", clusterName, dr.InfoFile)
+}
+
+// clusterInfo returns the name, region and endpoint of the cluster for the info file.
+func (c *EKS) clusterInfo(name string) (provider.ClusterInfo, error) {
+	res, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		return provider.ClusterInfo{}, err
+	}
+	return provider.ClusterInfo{
+		Provider: "eks",
+		Name:     name,
+		Region:   aws.StringValue(c.sessionAWS.Config.Region),
+		Endpoint: aws.StringValue(res.Cluster.Endpoint),
+	}, nil
+}
+
+// bootstrap applies the bootstrap files passed from the cli to the created cluster.
+// Failures are reported separately as the cluster itself was created.
+func (c *EKS) bootstrap() error {
+	files := c.DeploymentResource.BootstrapFiles
+	if len(files) == 0 {
+		return nil
+	}
+	if err := c.NewK8sProvider(nil); err != nil {
+		return fmt.Errorf("Bootstrap failed, the cluster was created: %v", err)
+	}
+	resources, err := k8sProvider.ParseFiles(files, c.DeploymentVars)
+	if err != nil {
+		return fmt.Errorf("Bootstrap failed, the cluster was created: couldn't parse the bootstrap files: %v", err)
+	}
+	log.Printf("Bootstrap started: applying %d file(s)", len(resources))
+	if err := c.k8sProvider.ResourceApply(resources); err != nil {
+		return fmt.Errorf("Bootstrap failed, the cluster was created: %v", err)
+	}
+	log.Printf("Bootstrap completed")
+	return nil
+}
+
+// addNodeGroups appends the node pools passed from the cli to the cluster request
+// and checks that all node group names are unique.
+// The node role and subnets are taken from the EKS_WORKER_ROLE_ARN and EKS_SUBNET_IDS variables.
+func (c *EKS) addNodeGroups(req *eksCluster) error {
+	if len(c.NodePools) > 0 {
+		if err := c.checkNodeGroupVars("the node pool definitions"); err != nil {
+			return err
+		}
+	}
+	for _, spec := range c.NodePools {
+		req.NodeGroups = append(req.NodeGroups, c.nodeGroup(spec))
+	}
+	return validateNodeGroupNames(req)
+}
+
+// addSystemNodeGroup adds the untainted system node group passed from the cli for the kube-system workloads
+// and sets the benchmark label and taint on all other node groups, so only the pods that tolerate it run on them.
+// Node groups that already have a taint with the same key keep it.
+func (c *EKS) addSystemNodeGroup(req *eksCluster) error {
+	if c.SystemNodePool == "" {
+		return nil
+	}
+	spec, err := provider.ParseSystemNodePool(c.SystemNodePool)
+	if err != nil {
+		return err
+	}
+	if err := c.checkNodeGroupVars("the system node group"); err != nil {
+		return err
+	}
+	for i := range req.NodeGroups {
+		ng := &req.NodeGroups[i]
+		if ng.Labels == nil {
+			ng.Labels = map[string]*string{}
+		}
+		if _, ok := ng.Labels[provider.DedicatedKey]; !ok {
+			ng.Labels[provider.DedicatedKey] = aws.String(provider.DedicatedBenchmark)
+		}
+		tainted := false
+		for _, t := range ng.Taints {
+			if aws.StringValue(t.Key) == provider.BenchmarkTaint.Key {
+				tainted = true
+				break
+			}
+		}
+		if !tainted {
+			ng.Taints = append(ng.Taints, &eks.Taint{
+				Key:    aws.String(provider.BenchmarkTaint.Key),
+				Value:  aws.String(provider.BenchmarkTaint.Value),
+				Effect: aws.String(eksTaintEffect(provider.BenchmarkTaint.Effect)),
+			})
+		}
+	}
+	req.NodeGroups = append(req.NodeGroups, c.nodeGroup(spec))
+	log.Printf("Adding the system node group %q, the other %d node group(s) get the taint %v=%v:%v", spec.Name, len(req.NodeGroups)-1, provider.BenchmarkTaint.Key, provider.BenchmarkTaint.Value, provider.BenchmarkTaint.Effect)
+	return validateNodeGroupNames(req)
+}
+
+// checkNodeGroupVars returns an error when a variable needed by the node groups from the cli is missing.
+// The EKS_WORKER_ROLE_ARN variable isn't needed when the node role is passed from the cli.
+func (c *EKS) checkNodeGroupVars(what string) error {
+	for _, k := range []string{"EKS_WORKER_ROLE_ARN", "EKS_SUBNET_IDS"} {
+		if k == "EKS_WORKER_ROLE_ARN" && c.NodeRoleARN != "" {
+			continue
+		}
+		if c.DeploymentVars[k] == "" {
+			return fmt.Errorf("missing required %v variable for %v", k, what)
+		}
 	}
 	return nil
 }
 
+// nodeGroup converts a node pool definition from the cli to an EKS node group
+// with the node role and subnets from the EKS_WORKER_ROLE_ARN and EKS_SUBNET_IDS variables.
+func (c *EKS) nodeGroup(spec provider.NodePoolSpec) eks.CreateNodegroupInput {
+	ng := eks.CreateNodegroupInput{
+		NodegroupName: aws.String(spec.Name),
+		NodeRole:      aws.String(c.DeploymentVars["EKS_WORKER_ROLE_ARN"]),
+		Subnets:       aws.StringSlice(strings.Split(c.DeploymentVars["EKS_SUBNET_IDS"], c.DeploymentVars["SEPARATOR"])),
+		InstanceTypes: aws.StringSlice([]string{spec.MachineType}),
+		Labels:        aws.StringMap(spec.Labels),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(int64(spec.Count)),
+			MinSize:     aws.Int64(int64(spec.Count)),
+			MaxSize:     aws.Int64(int64(spec.Count)),
+		},
+	}
+	for _, t := range spec.Taints {
+		ng.Taints = append(ng.Taints, &eks.Taint{
+			Key:    aws.String(t.Key),
+			Value:  aws.String(t.Value),
+			Effect: aws.String(eksTaintEffect(t.Effect)),
+		})
+	}
+	return ng
+}
+
+// validateNodeGroupNames checks that all node group names of the cluster are unique.
+func validateNodeGroupNames(req *eksCluster) error {
+	names := make([]string, 0, len(req.NodeGroups))
+	for _, ng := range req.NodeGroups {
+		names = append(names, *ng.NodegroupName)
+	}
+	return provider.ValidateNodePoolNames(names)
+}
+
+// setNodeImages sets the AMI type passed from the cli on all node groups
+// and logs a warning for AMI types that aren't compatible with the cluster version.
+// Node groups without an AMI type use the EKS default for the cluster version.
+func (c *EKS) setNodeImages(req *eksCluster) {
+	version := aws.StringValue(req.Cluster.Version)
+	for i := range req.NodeGroups {
+		ng := &req.NodeGroups[i]
+		if c.AMIType != "" {
+			ng.AmiType = aws.String(c.AMIType)
+		}
+		// EKS doesn't publish Amazon Linux 2 AMIs from 1.33.
+		if strings.HasPrefix(aws.StringValue(ng.AmiType), "AL2_") && provider.KubernetesVersionAtLeast(version, 1, 33) {
+			log.Printf("WARNING: nodegroup '%s' AMI type %s isn't supported by the cluster version %s", *ng.NodegroupName, *ng.AmiType, version)
+		}
+	}
+}
+
+// setAutoscaling sets the scaling bounds of the node groups passed from the cli.
+// The cluster autoscaler itself runs inside the cluster and uses these bounds,
+// the desired size stays as set in the node group definition.
+func (c *EKS) setAutoscaling(req *eksCluster) error {
+	for _, a := range c.Autoscaling {
+		var ng *eks.CreateNodegroupInput
+		for i := range req.NodeGroups {
+			if *req.NodeGroups[i].NodegroupName == a.Name {
+				ng = &req.NodeGroups[i]
+				break
+			}
+		}
+		if ng == nil {
+			return fmt.Errorf("autoscaling is set for node group %q that is not part of the cluster", a.Name)
+		}
+		if ng.ScalingConfig == nil {
+			ng.ScalingConfig = &eks.NodegroupScalingConfig{}
+		}
+		if ng.ScalingConfig.DesiredSize == nil {
+			ng.ScalingConfig.DesiredSize = aws.Int64(int64(a.Min))
+		}
+		if err := a.Contains(int32(*ng.ScalingConfig.DesiredSize)); err != nil {
+			return err
+		}
+		ng.ScalingConfig.MinSize = aws.Int64(int64(a.Min))
+		ng.ScalingConfig.MaxSize = aws.Int64(int64(a.Max))
+	}
+	return nil
+}
+
+// eksTaintEffect converts a k8s taint effect to the EKS api enum.
+func eksTaintEffect(effect string) string {
+	switch effect {
+	case provider.TaintEffectPreferNoSchedule:
+		return eks.TaintEffectPreferNoSchedule
+	case provider.TaintEffectNoExecute:
+		return eks.TaintEffectNoExecute
+	}
+	return eks.TaintEffectNoSchedule
+}
+
 // ClusterDelete deletes a eks Cluster
 func (c *EKS) ClusterDelete(*kingpin.ParseContext) error {
 	req := &eksCluster{}
+	if err := c.DeploymentResource.RunSummary.Validate(); err != nil {
+		return err
+	}
+	var summaries []provider.RunSummary
 	for _, deployment := range c.eksResources {
 
 		if err := yamlGo.UnmarshalStrict(deployment.Content, req); err != nil {
 			return fmt.Errorf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
 		}
 
+		if err := c.removeDeletionProtection(*req.Cluster.Name); err != nil {
+			return err
+		}
+		live, err := c.clusterForSummary(*req.Cluster.Name)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+
 		// To delete a cluster we have to manually delete all cluster
 		log.Printf("Removing all nodepools for '%s'", *req.Cluster.Name)
 
@@ -301,12 +706,16 @@ func (c *EKS) ClusterDelete(*kingpin.ParseContext) error {
 			}
 		}
 
+		if err := c.deleteScrapeFirewall(*req.Cluster.Name); err != nil {
+			return err
+		}
+
 		reqD := &eks.DeleteClusterInput{
 			Name: req.Cluster.Name,
 		}
 
 		log.Printf("Removing cluster '%v'", *reqD.Name)
-		_, err := c.clientEKS.DeleteCluster(reqD)
+		_, err = c.clientEKS.DeleteCluster(reqD)
 		if err != nil {
 			return fmt.Errorf("Couldn't delete cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
 		}
@@ -319,11 +728,68 @@ func (c *EKS) ClusterDelete(*kingpin.ParseContext) error {
 		if err != nil {
 			return fmt.Errorf("removing cluster err:%v", err)
 		}
+		c.recordProvisioning("delete", req, start)
+		if live != nil {
+			summary, err := c.runSummary(live, time.Now())
+			if err != nil {
+				return err
+			}
+			summaries = append(summaries, summary)
+		}
+
+		if err := c.deleteBastion(*req.Cluster.Name); err != nil {
+			return err
+		}
+		if c.DeploymentResource.KeepDependents {
+			continue
+		}
+		if err := c.removeDependents(*req.Cluster.Name); err != nil {
+			return err
+		}
+	}
+	if c.DeploymentResource.RunSummary.Enabled() {
+		if err := provider.WriteRunSummaries(c.DeploymentResource.RunSummary.File, summaries); err != nil {
+			return fmt.Errorf("writing the run summary file err: %v", err)
+		}
+		log.Printf("Wrote the run summary of %d cluster(s) to %v", len(summaries), c.DeploymentResource.RunSummary.File)
 	}
 	return nil
 }
 
+// recordProvisioning records the duration of a cluster create or delete with the desired nodes of the node groups in the cluster file.
+func (c *EKS) recordProvisioning(operation string, req *eksCluster, start time.Time) {
+	nodes := 0
+	for _, ng := range req.NodeGroups {
+		if ng.ScalingConfig != nil {
+			nodes += int(aws.Int64Value(ng.ScalingConfig.DesiredSize))
+		}
+	}
+	provider.RecordProvisioning(c.DeploymentResource.Provisioning, provider.ProvisioningResult{
+		Operation: operation,
+		Provider:  "eks",
+		Cluster:   *req.Cluster.Name,
+		Region:    c.DeploymentVars["ZONE"],
+		Nodes:     nodes,
+	}, start)
+}
+
 // clusterRunning checks whether a cluster is in a active state.
+// clusterExists reports whether the cluster already exists, so that a re-run with the same cluster name suffix
+// reuses it and its node groups instead of failing. Without a suffix the cluster is always created.
+func (c *EKS) clusterExists(name string) (bool, error) {
+	if c.DeploymentResource.ClusterNameSuffix == "" {
+		return false, nil
+	}
+	_, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 func (c *EKS) clusterRunning(name string) (bool, error) {
 	req := &eks.DescribeClusterInput{
 		Name: aws.String(name),
@@ -363,31 +829,62 @@ func (c *EKS) clusterDeleted(name string) (bool, error) {
 
 // NodeGroupCreate creates a new k8s nodegroup in an existing cluster.
 func (c *EKS) NodeGroupCreate(*kingpin.ParseContext) error {
+	if err := c.checkNodeRole(); err != nil {
+		return fmt.Errorf("Invalid node role: %v", err)
+	}
 	req := &eksCluster{}
 	for _, deployment := range c.eksResources {
-
-		if err := yamlGo.UnmarshalStrict(deployment.Content, req); err != nil {
-			return fmt.Errorf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
+		if err := c.parseNodeGroups(req, deployment); err != nil {
+			return err
 		}
-
 		for _, nodegroupReq := range req.NodeGroups {
-			nodegroupReq.ClusterName = req.Cluster.Name
-			log.Printf("Nodegroup create request: NodeGroupName: '%s', ClusterName: '%s'", *nodegroupReq.NodegroupName, *req.Cluster.Name)
-			_, err := c.clientEKS.CreateNodegroup(&nodegroupReq)
-			if err != nil {
-				return fmt.Errorf("Couldn't create nodegroup '%s' for cluster '%s', file:%v ,err: %v", *nodegroupReq.NodegroupName, *req.Cluster.Name, deployment.FileName, err)
+			if err := c.createNodeGroup(req, nodegroupReq, deployment.FileName); err != nil {
+				return err
 			}
+		}
+	}
+	return nil
+}
 
-			err = provider.RetryUntilTrue(
-				fmt.Sprintf("creating nodegroup:%s for cluster:%s", *nodegroupReq.NodegroupName, *req.Cluster.Name),
-				provider.GlobalRetryCount,
-				func() (bool, error) { return c.nodeGroupCreated(*nodegroupReq.NodegroupName, *req.Cluster.Name) },
-			)
+// parseNodeGroups parses the cluster deployment file into the request
+// and applies the node options of the cli to its node groups.
+func (c *EKS) parseNodeGroups(req *eksCluster, deployment Resource) error {
+	if err := yamlGo.UnmarshalStrict(deployment.Content, req); err != nil {
+		return fmt.Errorf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
+	}
+	c.setNodeRole(req)
+	if err := c.setRemoteAccess(req); err != nil {
+		return fmt.Errorf("Error setting the ssh access of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+	}
+	if err := c.setDiskSize(req); err != nil {
+		return fmt.Errorf("Error setting the disk size of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+	}
+	if err := c.setNitroEnclaves(req); err != nil {
+		return fmt.Errorf("Error enabling the Nitro Enclaves of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+	}
+	if err := c.setNodeSystemConfig(req); err != nil {
+		return fmt.Errorf("Error setting the node system config of cluster '%v', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+	}
+	return nil
+}
 
-			if err != nil {
-				return fmt.Errorf("creating nodegroup err:%v", err)
-			}
-		}
+// createNodeGroup creates the node group in the cluster of the request and waits until it is active.
+func (c *EKS) createNodeGroup(req *eksCluster, nodegroupReq eks.CreateNodegroupInput, fileName string) error {
+	nodegroupReq.ClusterName = req.Cluster.Name
+	log.Printf("Nodegroup create request: NodeGroupName: '%s', ClusterName: '%s'", *nodegroupReq.NodegroupName, *req.Cluster.Name)
+	_, err := c.clientEKS.CreateNodegroup(&nodegroupReq)
+	if err != nil {
+		return fmt.Errorf("Couldn't create nodegroup '%s' for cluster '%s', file:%v ,err: %v", *nodegroupReq.NodegroupName, *req.Cluster.Name, fileName, err)
+	}
+
+	err = provider.RetryUntilTrue(
+		fmt.Sprintf("creating nodegroup:%s for cluster:%s", *nodegroupReq.NodegroupName, *req.Cluster.Name),
+		provider.GlobalRetryCount,
+		func() (bool, error) { return c.nodeGroupCreated(*nodegroupReq.NodegroupName, *req.Cluster.Name) },
+	)
+
+	if err != nil {
+		return fmt.Errorf("creating nodegroup err:%v", err)
 	}
 	return nil
 }
@@ -400,32 +897,64 @@ func (c *EKS) NodeGroupDelete(*kingpin.ParseContext) error {
 			return fmt.Errorf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
 		}
 
-		for _, nodegroupReq := range req.NodeGroups {
-			nodegroupReq.ClusterName = req.Cluster.Name
-			log.Printf("Nodegroup delete request: NodeGroupName: '%s', ClusterName: '%s'", *nodegroupReq.NodegroupName, *req.Cluster.Name)
-			reqD := eks.DeleteNodegroupInput{
-				ClusterName:   req.Cluster.Name,
-				NodegroupName: nodegroupReq.NodegroupName,
+		names := c.NodePoolNames
+		if len(names) == 0 {
+			for _, nodegroupReq := range req.NodeGroups {
+				names = append(names, *nodegroupReq.NodegroupName)
 			}
-			_, err := c.clientEKS.DeleteNodegroup(&reqD)
-			if err != nil {
-				return fmt.Errorf("Couldn't delete nodegroup '%s' for cluster '%s, file:%v ,err: %v", *nodegroupReq.NodegroupName, *req.Cluster.Name, deployment.FileName, err)
-			}
-			err = provider.RetryUntilTrue(
-				fmt.Sprintf("deleting nodegroup:%s for cluster:%s", *nodegroupReq.NodegroupName, *req.Cluster.Name),
-				provider.GlobalRetryCount,
-				func() (bool, error) { return c.nodeGroupDeleted(*nodegroupReq.NodegroupName, *req.Cluster.Name) },
-			)
+		}
 
-			if err != nil {
-				return fmt.Errorf("deleting nodegroup err:%v", err)
-			}
+		existing, err := c.listNodeGroups(*req.Cluster.Name)
+		if err != nil {
+			return fmt.Errorf("Couldn't list the nodegroups of cluster '%s', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := provider.ValidateNodePoolDelete(existing, names, c.Force); err != nil {
+			return fmt.Errorf("Couldn't delete nodegroups of cluster '%s', file:%v ,err: %v", *req.Cluster.Name, deployment.FileName, err)
+		}
 
+		for _, name := range names {
+			if err := c.deleteNodeGroup(*req.Cluster.Name, name, deployment.FileName); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// listNodeGroups returns the names of the node groups of the cluster.
+func (c *EKS) listNodeGroups(clusterName string) ([]string, error) {
+	var existing []string
+	err := c.clientEKS.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: aws.String(clusterName)},
+		func(page *eks.ListNodegroupsOutput, _ bool) bool {
+			existing = append(existing, aws.StringValueSlice(page.Nodegroups)...)
+			return true
+		})
+	return existing, err
+}
+
+// deleteNodeGroup deletes the node group from the cluster and waits until it is gone.
+func (c *EKS) deleteNodeGroup(clusterName, name, fileName string) error {
+	log.Printf("Nodegroup delete request: NodeGroupName: '%s', ClusterName: '%s'", name, clusterName)
+	reqD := eks.DeleteNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(name),
+	}
+	_, err := c.clientEKS.DeleteNodegroup(&reqD)
+	if err != nil {
+		return fmt.Errorf("Couldn't delete nodegroup '%s' for cluster '%s, file:%v ,err: %v", name, clusterName, fileName, err)
+	}
+	err = provider.RetryUntilTrue(
+		fmt.Sprintf("deleting nodegroup:%s for cluster:%s", name, clusterName),
+		provider.GlobalRetryCount,
+		func() (bool, error) { return c.nodeGroupDeleted(name, clusterName) },
+	)
+
+	if err != nil {
+		return fmt.Errorf("deleting nodegroup err:%v", err)
+	}
+	return nil
+}
+
 func (c *EKS) nodeGroupCreated(nodegroupName, clusterName string) (bool, error) {
 	req := &eks.DescribeNodegroupInput{
 		ClusterName:   aws.String(clusterName),
@@ -529,6 +1058,17 @@ func (c *EKS) EKSK8sToken(clusterName, region string) awsToken.Token {
 
 // NewK8sProvider sets the k8s provider used for deploying k8s manifests
 func (c *EKS) NewK8sProvider(*kingpin.ParseContext) error {
+	k, err := c.K8sClient()
+	if err != nil {
+		return err
+	}
+	c.k8sProvider = k
+	return nil
+}
+
+// K8sClient returns a k8s client for the cluster with the credentials from the EKS API,
+// so the manifests can be applied right after creating the cluster without a kubeconfig file.
+func (c *EKS) K8sClient() (*k8sProvider.K8s, error) {
 
 	clusterName := c.DeploymentVars["CLUSTER_NAME"]
 	region := c.DeploymentVars["ZONE"]
@@ -539,19 +1079,20 @@ func (c *EKS) NewK8sProvider(*kingpin.ParseContext) error {
 
 	rep, err := c.clientEKS.DescribeCluster(req)
 	if err != nil {
-		return fmt.Errorf("failed to get cluster details: %v", err)
+		return nil, fmt.Errorf("failed to get cluster details: %v", err)
 	}
 
 	arnRole := *rep.Cluster.Arn
 
 	caCert, err := base64.StdEncoding.DecodeString(*rep.Cluster.CertificateAuthority.Data)
 	if err != nil {
-		return fmt.Errorf("failed to decode certificate: %v", err.Error())
+		return nil, fmt.Errorf("failed to decode certificate: %v", err.Error())
 	}
 
 	cluster := clientcmdapi.NewCluster()
 	cluster.CertificateAuthorityData = []byte(caCert)
 	cluster.Server = *rep.Cluster.Endpoint
+	cluster.ProxyURL = c.DeploymentResource.K8sProxy
 
 	clusterContext := clientcmdapi.NewContext()
 	clusterContext.Cluster = arnRole
@@ -568,19 +1109,46 @@ func (c *EKS) NewK8sProvider(*kingpin.ParseContext) error {
 	config.Kind = "Config"
 	config.APIVersion = "v1"
 
-	c.k8sProvider, err = k8sProvider.New(c.ctx, config)
+	k, err := k8sProvider.NewForDeployment(c.ctx, config, c.DeploymentResource)
 	if err != nil {
-		return fmt.Errorf("k8s provider error %v", err)
+		return nil, fmt.Errorf("k8s provider error %v", err)
 	}
-
-	return nil
+	return k, nil
 }
 
 // ResourceApply calls k8s.ResourceApply to apply the k8s objects in the manifest files.
 func (c *EKS) ResourceApply(*kingpin.ParseContext) error {
-	if err := c.k8sProvider.ResourceApply(c.k8sResources); err != nil {
+	disks, err := c.existingDisks()
+	if err != nil {
+		return fmt.Errorf("error getting the existing volumes: %v", err)
+	}
+	if err := c.k8sProvider.ApplyExistingDisks(disks); err != nil {
+		return fmt.Errorf("error applying the persistent volumes of the existing volumes: %v", err)
+	}
+	if err := c.k8sProvider.ApplyAndWaitEstablished(c.k8sResources, c.DeploymentResource.EstablishTimeout); err != nil {
 		return fmt.Errorf("error while applying a resource err: %v", err)
 	}
+	if err := c.upsertDNSRecords(); err != nil {
+		return fmt.Errorf("error updating the DNS records: %v", err)
+	}
+	if c.DeploymentResource.PruneDryRun {
+		out, err := c.k8sProvider.PruneDryRun(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds)
+		if err != nil {
+			return fmt.Errorf("error while listing the objects to prune err: %v", err)
+		}
+		if _, err := os.Stdout.Write(out); err != nil {
+			return fmt.Errorf("error while writing the objects to prune err: %v", err)
+		}
+	} else if c.DeploymentResource.Prune {
+		if err := c.k8sProvider.Prune(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds); err != nil {
+			return fmt.Errorf("error while pruning objects err: %v", err)
+		}
+	}
+	if c.DeploymentResource.ReconcileInterval > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.k8sProvider.ReconcileLoop(ctx, c.k8sResources, c.DeploymentResource.ReconcileInterval)
+	}
 	return nil
 }
 
@@ -589,9 +1157,124 @@ func (c *EKS) ResourceDelete(*kingpin.ParseContext) error {
 	if err := c.k8sProvider.ResourceDelete(c.k8sResources); err != nil {
 		return fmt.Errorf("error while deleting objects from a manifest file err: %v", err)
 	}
+	if err := c.k8sProvider.DeleteExistingDisks(c.existingDiskNames()); err != nil {
+		return fmt.Errorf("error deleting the persistent volumes of the existing volumes: %v", err)
+	}
+	if err := c.deleteDNSRecords(); err != nil {
+		return fmt.Errorf("error deleting the DNS records: %v", err)
+	}
+	return nil
+}
+
+// ResourceStatus calls k8s.Status to print the readiness of the k8s objects in the manifest files,
+// it fails when an object is missing or not ready.
+func (c *EKS) ResourceStatus(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Status(c.k8sResources)
+	if _, werr := os.Stdout.Write(out); werr != nil {
+		return fmt.Errorf("error while writing the status err: %v", werr)
+	}
+	if err != nil {
+		return fmt.Errorf("error while checking the status of the objects err: %v", err)
+	}
+	return nil
+}
+
+// Describe calls k8s.Describe to print the live object as YAML.
+func (c *EKS) Describe(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Describe(c.DeploymentResource.DescribeObject, c.DeploymentResource.DescribeNamespace, c.DeploymentResource.ShowManagedFields)
+	if err != nil {
+		return fmt.Errorf("error while describing an object err: %v", err)
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// FieldOwners calls k8s.FieldOwners to print the field managers of the object and the fields they own.
+func (c *EKS) FieldOwners(*kingpin.ParseContext) error {
+	kind, name, _ := strings.Cut(c.DeploymentResource.FieldOwnersObject, "/")
+	owners, err := c.k8sProvider.FieldOwners(kind, c.DeploymentResource.FieldOwnersNamespace, name)
+	if err != nil {
+		return fmt.Errorf("error while getting the field owners err: %v", err)
+	}
+	_, err = os.Stdout.Write(k8sProvider.FormatFieldOwners(owners))
+	return err
+}
+
+// Wait calls k8s.WaitForObjectCondition to block until the object has the status condition.
+func (c *EKS) Wait(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.WaitForObjectCondition(dr.WaitObject, dr.WaitNamespace, dr.WaitCondition, dr.WaitTimeout); err != nil {
+		return fmt.Errorf("error while waiting for the condition err: %v", err)
+	}
 	return nil
 }
 
+// Label calls k8s.Label to add the labels to the selected live objects.
+func (c *EKS) Label(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.Label(dr.MetadataObject, dr.MetadataSelector, dr.MetadataNamespace, dr.Labels); err != nil {
+		return fmt.Errorf("error while labeling the objects err: %v", err)
+	}
+	return nil
+}
+
+// Annotate calls k8s.Annotate to add the annotations to the selected live objects.
+func (c *EKS) Annotate(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.Annotate(dr.MetadataObject, dr.MetadataSelector, dr.MetadataNamespace, dr.Annotations); err != nil {
+		return fmt.Errorf("error while annotating the objects err: %v", err)
+	}
+	return nil
+}
+
+// Cordon calls k8s.CordonNode to mark the node unschedulable.
+func (c *EKS) Cordon(*kingpin.ParseContext) error {
+	if err := c.k8sProvider.CordonNode(c.DeploymentResource.NodeName); err != nil {
+		return fmt.Errorf("error while cordoning the node err: %v", err)
+	}
+	return nil
+}
+
+// Uncordon calls k8s.UncordonNode to mark the node schedulable again.
+func (c *EKS) Uncordon(*kingpin.ParseContext) error {
+	if err := c.k8sProvider.UncordonNode(c.DeploymentResource.NodeName); err != nil {
+		return fmt.Errorf("error while uncordoning the node err: %v", err)
+	}
+	return nil
+}
+
+// Drain calls k8s.DrainNode to cordon the node and evict its pods.
+func (c *EKS) Drain(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.DrainNode(dr.NodeName, dr.DrainTimeout); err != nil {
+		return fmt.Errorf("error while draining the node err: %v", err)
+	}
+	return nil
+}
+
+// Logs calls k8s.PodLogs to print the logs of the pod, or k8s.StreamPodLogs to follow them until interrupted.
+func (c *EKS) Logs(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if !dr.FollowLogs {
+		out, err := c.k8sProvider.PodLogs(dr.LogsNamespace, dr.LogsPod, dr.LogsContainer)
+		if err != nil {
+			return fmt.Errorf("error while getting the logs err: %v", err)
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logs, err := c.k8sProvider.StreamPodLogs(ctx, dr.LogsNamespace, dr.LogsPod, dr.LogsContainer)
+	if err != nil {
+		return fmt.Errorf("error while getting the logs err: %v", err)
+	}
+	defer logs.Close()
+	_, err = io.Copy(os.Stdout, logs)
+	return err
+}
+
 // GetDeploymentVars shows deployment variables.
 func (c *EKS) GetDeploymentVars(*kingpin.ParseContext) error {
 	fmt.Print("-------------------\n   DeploymentVars   \n------------------- \n")
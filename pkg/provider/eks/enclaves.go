@@ -0,0 +1,127 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+)
+
+// enclavesLaunchTemplateName returns the name of the launch template that enables Nitro Enclaves for the node groups of a cluster.
+func enclavesLaunchTemplateName(clusterName string) string {
+	return clusterName + "-nitro-enclaves"
+}
+
+// checkEnclavesInstanceType returns an error when Nitro Enclaves aren't available on the instance type.
+// They require a Nitro instance with at least 4 vCPUs and aren't available on the burstable, A1 and Mac instances.
+func checkEnclavesInstanceType(info *ec2.InstanceTypeInfo) error {
+	name := aws.StringValue(info.InstanceType)
+	switch {
+	case aws.StringValue(info.Hypervisor) != ec2.InstanceTypeHypervisorNitro:
+		return fmt.Errorf("instance type %s doesn't run on the Nitro hypervisor", name)
+	case aws.BoolValue(info.BurstablePerformanceSupported), strings.HasPrefix(name, "a1."), strings.HasPrefix(name, "mac"):
+		return fmt.Errorf("instance type %s doesn't support Nitro Enclaves", name)
+	case info.VCpuInfo == nil || aws.Int64Value(info.VCpuInfo.DefaultVCpus) < 4:
+		return fmt.Errorf("instance type %s has less than the 4 vCPUs Nitro Enclaves require", name)
+	}
+	return nil
+}
+
+// setNitroEnclaves enables Nitro Enclaves on the nodes of all node groups through a launch template,
+// after checking that all instance types of the node groups support them.
+// Managed node groups with a launch template can't set the remote access or the disk size,
+// so both must be left out. The launch template is reused when it exists and kept when the cluster is deleted.
+func (c *EKS) setNitroEnclaves(req *eksCluster) error {
+	if !c.NitroEnclaves {
+		return nil
+	}
+	for _, ng := range req.NodeGroups {
+		switch {
+		case ng.LaunchTemplate != nil:
+			return fmt.Errorf("nodegroup '%s' already sets a launch template, enable the Nitro Enclaves in it instead", aws.StringValue(ng.NodegroupName))
+		case ng.RemoteAccess != nil:
+			return fmt.Errorf("nodegroup '%s': Nitro Enclaves can't be combined with the ssh remote access", aws.StringValue(ng.NodegroupName))
+		case ng.DiskSize != nil:
+			return fmt.Errorf("nodegroup '%s': Nitro Enclaves can't be combined with a disk size", aws.StringValue(ng.NodegroupName))
+		}
+	}
+
+	clientEC2 := ec2.New(c.sessionAWS)
+	checked := map[string]error{}
+	for _, ng := range req.NodeGroups {
+		instanceTypes := aws.StringValueSlice(ng.InstanceTypes)
+		if len(instanceTypes) == 0 {
+			instanceTypes = []string{defaultInstanceType}
+		}
+		for _, instanceType := range instanceTypes {
+			err, ok := checked[instanceType]
+			if !ok {
+				res, derr := clientEC2.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{instanceType})})
+				if derr != nil || len(res.InstanceTypes) == 0 {
+					return fmt.Errorf("Couldn't get the instance type %s for nodegroup '%s': %v", instanceType, *ng.NodegroupName, derr)
+				}
+				err = checkEnclavesInstanceType(res.InstanceTypes[0])
+				checked[instanceType] = err
+			}
+			if err != nil {
+				return fmt.Errorf("nodegroup '%s': %v", *ng.NodegroupName, err)
+			}
+		}
+	}
+
+	name := enclavesLaunchTemplateName(aws.StringValue(req.Cluster.Name))
+	if err := createEnclavesLaunchTemplate(clientEC2, name); err != nil {
+		return err
+	}
+	for i := range req.NodeGroups {
+		req.NodeGroups[i].LaunchTemplate = &eks.LaunchTemplateSpecification{Name: aws.String(name)}
+	}
+	return nil
+}
+
+// createEnclavesLaunchTemplate creates the launch template that enables Nitro Enclaves,
+// unless a launch template of the same name already enables them.
+func createEnclavesLaunchTemplate(clientEC2 *ec2.EC2, name string) error {
+	res, err := clientEC2.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String(name),
+		Versions:           aws.StringSlice([]string{"$Default"}),
+	})
+	if err == nil && len(res.LaunchTemplateVersions) > 0 {
+		data := res.LaunchTemplateVersions[0].LaunchTemplateData
+		if data == nil || data.EnclaveOptions == nil || !aws.BoolValue(data.EnclaveOptions.Enabled) {
+			return fmt.Errorf("the launch template %v already exists without the Nitro Enclaves, delete it to create a new one", name)
+		}
+		log.Printf("Reusing the launch template %v", name)
+		return nil
+	}
+	if aerr, ok := err.(awserr.Error); err != nil && (!ok || !strings.HasPrefix(aerr.Code(), "InvalidLaunchTemplateName.NotFound")) {
+		return fmt.Errorf("getting the launch template %v: %v", name, err)
+	}
+	if _, err := clientEC2.CreateLaunchTemplate(&ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+		LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+			EnclaveOptions: &ec2.LaunchTemplateEnclaveOptionsRequest{Enabled: aws.Bool(true)},
+		},
+	}); err != nil {
+		return fmt.Errorf("creating the launch template %v: %v", name, err)
+	}
+	log.Printf("Created the launch template %v with the Nitro Enclaves enabled", name)
+	return nil
+}
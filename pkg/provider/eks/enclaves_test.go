@@ -0,0 +1,69 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+)
+
+func TestCheckEnclavesInstanceType(t *testing.T) {
+	instanceType := func(name, hypervisor string, vcpus int64, burstable bool) *ec2.InstanceTypeInfo {
+		return &ec2.InstanceTypeInfo{
+			InstanceType:                  aws.String(name),
+			Hypervisor:                    aws.String(hypervisor),
+			BurstablePerformanceSupported: aws.Bool(burstable),
+			VCpuInfo:                      &ec2.VCpuInfo{DefaultVCpus: aws.Int64(vcpus)},
+		}
+	}
+	if err := checkEnclavesInstanceType(instanceType("m5.xlarge", "nitro", 4, false)); err != nil {
+		t.Errorf("want m5.xlarge supported, got %v", err)
+	}
+	for _, info := range []*ec2.InstanceTypeInfo{
+		instanceType("m5.large", "nitro", 2, false),
+		instanceType("t3.xlarge", "nitro", 4, true),
+		instanceType("a1.xlarge", "nitro", 4, false),
+		instanceType("m4.xlarge", "xen", 4, false),
+		{InstanceType: aws.String("m5.metal"), VCpuInfo: &ec2.VCpuInfo{DefaultVCpus: aws.Int64(96)}},
+	} {
+		if err := checkEnclavesInstanceType(info); err == nil {
+			t.Errorf("%v: expected an error", aws.StringValue(info.InstanceType))
+		}
+	}
+}
+
+func TestSetNitroEnclavesConflicts(t *testing.T) {
+	c := &EKS{NitroEnclaves: true}
+	for _, tc := range []struct {
+		ng  eks.CreateNodegroupInput
+		err string
+	}{
+		{ng: eks.CreateNodegroupInput{LaunchTemplate: &eks.LaunchTemplateSpecification{Name: aws.String("prometheus")}}, err: "already sets a launch template"},
+		{ng: eks.CreateNodegroupInput{RemoteAccess: &eks.RemoteAccessConfig{Ec2SshKey: aws.String("prombench-1234-ssh")}}, err: "ssh remote access"},
+		{ng: eks.CreateNodegroupInput{DiskSize: aws.Int64(100)}, err: "disk size"},
+	} {
+		tc.ng.NodegroupName = aws.String("prometheus")
+		req := &eksCluster{Cluster: eks.CreateClusterInput{Name: aws.String("prombench-1234")}, NodeGroups: []eks.CreateNodegroupInput{tc.ng}}
+		if err := c.setNitroEnclaves(req); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("want an error containing %q, got %v", tc.err, err)
+		}
+	}
+	if err := (&EKS{}).setNitroEnclaves(&eksCluster{}); err != nil {
+		t.Errorf("want no error without --nitro-enclaves, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// scrapeRuleDescription is the description of the scrape rules of a cluster in its cluster security group,
+// which tells them apart from the rules EKS and the other tools add.
+func scrapeRuleDescription(clusterName string) string {
+	return provider.ScrapeFirewallName(clusterName) + ": scrape ports of the nodes of cluster " + clusterName
+}
+
+// scrapePermissions returns the ingress permissions of the scrape ports from the source ranges.
+func scrapePermissions(f provider.ScrapeFirewall, clusterName string) ([]*ec2.IpPermission, error) {
+	var perms []*ec2.IpPermission
+	for _, p := range f.Ports {
+		from, to, err := provider.ParsePortRange(p)
+		if err != nil {
+			return nil, err
+		}
+		ranges := make([]*ec2.IpRange, 0, len(f.SourceRanges))
+		for _, r := range f.SourceRanges {
+			ranges = append(ranges, &ec2.IpRange{CidrIp: aws.String(r), Description: aws.String(scrapeRuleDescription(clusterName))})
+		}
+		perms = append(perms, &ec2.IpPermission{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(from), ToPort: aws.Int64(to), IpRanges: ranges})
+	}
+	return perms, nil
+}
+
+// scrapeRules returns the scrape permissions of the cluster among the ingress permissions of a security group,
+// with only the source ranges of the scrape rules.
+func scrapeRules(perms []*ec2.IpPermission, clusterName string) []*ec2.IpPermission {
+	var rules []*ec2.IpPermission
+	for _, p := range perms {
+		var ranges []*ec2.IpRange
+		for _, r := range p.IpRanges {
+			if aws.StringValue(r.Description) == scrapeRuleDescription(clusterName) {
+				ranges = append(ranges, &ec2.IpRange{CidrIp: r.CidrIp})
+			}
+		}
+		if len(ranges) > 0 {
+			rules = append(rules, &ec2.IpPermission{IpProtocol: p.IpProtocol, FromPort: p.FromPort, ToPort: p.ToPort, IpRanges: ranges})
+		}
+	}
+	return rules
+}
+
+// clusterSecurityGroup returns the security group EKS creates for the cluster, which is attached to the nodes
+// of the managed node groups. It is empty when the cluster doesn't exist.
+func (c *EKS) clusterSecurityGroup(clusterName string) (string, error) {
+	rep, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("getting the security group of cluster %v err: %v", clusterName, err)
+	}
+	return aws.StringValue(rep.Cluster.ResourcesVpcConfig.ClusterSecurityGroupId), nil
+}
+
+// createScrapeFirewall opens the scrape ports of the nodes of the cluster to the source ranges
+// with ingress rules in the cluster security group. The rules that already exist are kept.
+func (c *EKS) createScrapeFirewall(clusterName string) error {
+	perms, err := scrapePermissions(c.DeploymentResource.ScrapeFirewall, clusterName)
+	if err != nil {
+		return err
+	}
+	group, err := c.clusterSecurityGroup(clusterName)
+	if err != nil {
+		return err
+	}
+	if group == "" {
+		return fmt.Errorf("cluster %v has no cluster security group", clusterName)
+	}
+	log.Printf("Opening the scrape ports %v of cluster '%v' to %v", c.DeploymentResource.ScrapeFirewall.Ports, clusterName, c.DeploymentResource.ScrapeFirewall.SourceRanges)
+	for _, p := range perms {
+		if _, err := ec2.New(c.sessionAWS).AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       aws.String(group),
+			IpPermissions: []*ec2.IpPermission{p},
+		}); err != nil && !isDuplicatePermission(err) {
+			return fmt.Errorf("opening the scrape ports %d-%d of cluster %v err: %v", aws.Int64Value(p.FromPort), aws.Int64Value(p.ToPort), clusterName, err)
+		}
+	}
+	return nil
+}
+
+// deleteScrapeFirewall removes the scrape rules of a cluster from its cluster security group, if there are any.
+// It runs before the cluster is deleted, while the cluster security group can still be found from the cluster.
+func (c *EKS) deleteScrapeFirewall(clusterName string) error {
+	group, err := c.clusterSecurityGroup(clusterName)
+	if err != nil || group == "" {
+		return err
+	}
+	clientEC2 := ec2.New(c.sessionAWS)
+	groups, err := clientEC2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice([]string{group})})
+	if err != nil {
+		return fmt.Errorf("getting the cluster security group %v err: %v", group, err)
+	}
+	var rules []*ec2.IpPermission
+	for _, g := range groups.SecurityGroups {
+		rules = append(rules, scrapeRules(g.IpPermissions, clusterName)...)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	if _, err := clientEC2.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{GroupId: aws.String(group), IpPermissions: rules}); err != nil {
+		return fmt.Errorf("removing the scrape rules of cluster %v err: %v", clusterName, err)
+	}
+	log.Printf("Removed %d scrape rule(s) of cluster '%v'", len(rules), clusterName)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestScrapeRules(t *testing.T) {
+	perms, err := scrapePermissions(provider.ScrapeFirewall{Ports: []string{"9100", "30000-30100"}, SourceRanges: []string{"203.0.113.0/24", "198.51.100.0/24"}}, "prombench")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(perms) != 2 || aws.Int64Value(perms[1].FromPort) != 30000 || aws.Int64Value(perms[1].ToPort) != 30100 || len(perms[1].IpRanges) != 2 {
+		t.Fatalf("unexpected permissions %v", perms)
+	}
+
+	// The live group has the rules of the cluster next to the ones of EKS and of another cluster on the same port.
+	live := append([]*ec2.IpPermission{{
+		IpProtocol:       aws.String("-1"),
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-0123")}},
+	}}, perms...)
+	live[1].IpRanges = append(live[1].IpRanges, &ec2.IpRange{CidrIp: aws.String("192.0.2.0/24"), Description: aws.String(scrapeRuleDescription("other"))})
+
+	var got []string
+	for _, r := range scrapeRules(live, "prombench") {
+		for _, ip := range r.IpRanges {
+			got = append(got, fmt.Sprintf("%v %v %d-%d", aws.StringValue(r.IpProtocol), aws.StringValue(ip.CidrIp), aws.Int64Value(r.FromPort), aws.Int64Value(r.ToPort)))
+		}
+	}
+	want := []string{"tcp 203.0.113.0/24 9100-9100", "tcp 198.51.100.0/24 9100-9100", "tcp 203.0.113.0/24 30000-30100", "tcp 198.51.100.0/24 30000-30100"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want the scrape rules %v, got %v", want, got)
+	}
+
+	if _, err := scrapePermissions(provider.ScrapeFirewall{Ports: []string{"0"}, SourceRanges: []string{"203.0.113.0/24"}}, "prombench"); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+const stsAudience = "sts.amazonaws.com"
+
+// setupIRSA creates the IAM OIDC provider for the cluster
+// and allows the k8s service accounts to assume their IAM roles.
+// The k8s service accounts still need the eks.amazonaws.com/role-arn annotation.
+func (c *EKS) setupIRSA(clusterName string) error {
+	if !c.IRSA {
+		return nil
+	}
+	clusterRes, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		return fmt.Errorf("Couldn't get the cluster OIDC issuer: %v", err)
+	}
+	if clusterRes.Cluster.Identity == nil || clusterRes.Cluster.Identity.Oidc == nil {
+		return fmt.Errorf("cluster '%v' has no OIDC issuer", clusterName)
+	}
+	issuer := aws.StringValue(clusterRes.Cluster.Identity.Oidc.Issuer)
+
+	clientIAM := iam.New(c.sessionAWS)
+	providerARN, err := c.oidcProvider(clientIAM, issuer)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range c.IRSABindings {
+		if err := assumeRoleWithWebIdentity(clientIAM, providerARN, issuer, b.CloudIdentity, b.Namespace, b.ServiceAccount); err != nil {
+			return err
+		}
+		log.Printf("IRSA binding created: %v, annotate the k8s service account with eks.amazonaws.com/role-arn and the role ARN", b)
+	}
+	return nil
+}
+
+// oidcProvider returns the ARN of the IAM OIDC provider for the issuer and creates it when it doesn't exist.
+func (c *EKS) oidcProvider(clientIAM *iam.IAM, issuer string) (string, error) {
+	issuerHostPath := strings.TrimPrefix(issuer, "https://")
+	providers, err := clientIAM.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return "", iamError(err, "listing the IAM OIDC providers")
+	}
+	for _, p := range providers.OpenIDConnectProviderList {
+		if strings.HasSuffix(aws.StringValue(p.Arn), ":oidc-provider/"+issuerHostPath) {
+			log.Printf("IAM OIDC provider already exists: %v", *p.Arn)
+			return *p.Arn, nil
+		}
+	}
+
+	thumbprint, err := issuerThumbprint(issuer)
+	if err != nil {
+		return "", err
+	}
+	res, err := clientIAM.CreateOpenIDConnectProvider(&iam.CreateOpenIDConnectProviderInput{
+		Url:            aws.String(issuer),
+		ClientIDList:   aws.StringSlice([]string{stsAudience}),
+		ThumbprintList: aws.StringSlice([]string{thumbprint}),
+	})
+	if err != nil {
+		return "", iamError(err, "creating the IAM OIDC provider")
+	}
+	log.Printf("IAM OIDC provider created: %v", *res.OpenIDConnectProviderArn)
+	return *res.OpenIDConnectProviderArn, nil
+}
+
+// issuerThumbprint returns the SHA-1 fingerprint of the root certificate served by the issuer.
+func issuerThumbprint(issuer string) (string, error) {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return "", fmt.Errorf("invalid OIDC issuer %v: %v", issuer, err)
+	}
+	conn, err := tls.Dial("tcp", u.Host+":443", &tls.Config{MinVersion: tls.VersionTLS12})
+	if err != nil {
+		return "", fmt.Errorf("Couldn't connect to the OIDC issuer %v: %v", issuer, err)
+	}
+	defer conn.Close()
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", fmt.Errorf("OIDC issuer %v returned no certificates", issuer)
+	}
+	// The OIDC thumbprint is defined as a SHA-1 fingerprint.
+	sum := sha1.Sum(certs[len(certs)-1].Raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// assumeRoleWithWebIdentity adds a statement to the trust policy of the role
+// that allows the k8s service account to assume it. Existing statements are kept.
+func assumeRoleWithWebIdentity(clientIAM *iam.IAM, providerARN, issuer, role, namespace, serviceAccount string) error {
+	// The role can be given as a name or an ARN, which may include a path.
+	roleName := role[strings.LastIndex(role, "/")+1:]
+	res, err := clientIAM.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return iamError(err, fmt.Sprintf("getting the IAM role %v", roleName))
+	}
+	doc, err := url.QueryUnescape(aws.StringValue(res.Role.AssumeRolePolicyDocument))
+	if err != nil {
+		return fmt.Errorf("decoding the trust policy of role %v: %v", roleName, err)
+	}
+
+	policy, changed, err := addWebIdentityStatement(doc, providerARN, issuer, namespace, serviceAccount)
+	if err != nil {
+		return fmt.Errorf("updating the trust policy of role %v: %v", roleName, err)
+	}
+	if !changed {
+		log.Printf("IAM role %v already trusts the service account %v/%v", roleName, namespace, serviceAccount)
+		return nil
+	}
+	if _, err := clientIAM.UpdateAssumeRolePolicy(&iam.UpdateAssumeRolePolicyInput{
+		RoleName:       aws.String(roleName),
+		PolicyDocument: aws.String(policy),
+	}); err != nil {
+		return iamError(err, fmt.Sprintf("updating the trust policy of role %v", roleName))
+	}
+	return nil
+}
+
+// addWebIdentityStatement returns the trust policy document with a statement
+// for the k8s service account and whether it was changed.
+func addWebIdentityStatement(doc, providerARN, issuer, namespace, serviceAccount string) (string, bool, error) {
+	policy := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return "", false, err
+	}
+	issuerHostPath := strings.TrimPrefix(issuer, "https://")
+	statement := map[string]interface{}{
+		"Effect":    "Allow",
+		"Principal": map[string]interface{}{"Federated": providerARN},
+		"Action":    "sts:AssumeRoleWithWebIdentity",
+		"Condition": map[string]interface{}{
+			"StringEquals": map[string]interface{}{
+				issuerHostPath + ":sub": fmt.Sprintf("system:serviceaccount:%v:%v", namespace, serviceAccount),
+				issuerHostPath + ":aud": stsAudience,
+			},
+		},
+	}
+
+	var statements []interface{}
+	switch s := policy["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		statements = []interface{}{s}
+	}
+	for _, s := range statements {
+		if reflect.DeepEqual(s, statement) {
+			return doc, false, nil
+		}
+	}
+	policy["Statement"] = append(statements, statement)
+	if _, ok := policy["Version"]; !ok {
+		policy["Version"] = "2012-10-17"
+	}
+	out, err := json.Marshal(policy)
+	if err != nil {
+		return "", false, err
+	}
+	return string(out), true, nil
+}
+
+// setNodeRole sets the node role passed from the cli on all node groups,
+// instead of the role from the cluster file or the EKS_WORKER_ROLE_ARN variable.
+func (c *EKS) setNodeRole(req *eksCluster) {
+	if c.NodeRoleARN == "" {
+		return
+	}
+	for i := range req.NodeGroups {
+		req.NodeGroups[i].NodeRole = aws.String(c.NodeRoleARN)
+	}
+}
+
+// checkNodeRole checks that the node role exists and can be assumed by EC2 instances,
+// so a wrong role fails before the node groups are created instead of when the nodes can't join the cluster.
+func (c *EKS) checkNodeRole() error {
+	if c.NodeRoleARN == "" {
+		return nil
+	}
+	if !strings.HasPrefix(c.NodeRoleARN, "arn:") || !strings.Contains(c.NodeRoleARN, ":role/") {
+		return fmt.Errorf("invalid node role %q, expected the ARN of an IAM role, e.g. arn:aws:iam::123456789012:role/prombench-nodes", c.NodeRoleARN)
+	}
+	// The ARN may include a path, the role name is the last element.
+	roleName := c.NodeRoleARN[strings.LastIndex(c.NodeRoleARN, "/")+1:]
+	res, err := iam.New(c.sessionAWS).GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case iam.ErrCodeNoSuchEntityException:
+				return fmt.Errorf("node role %v doesn't exist, create it before creating the node groups: %v", c.NodeRoleARN, err)
+			case "AccessDenied":
+				return fmt.Errorf("not allowed to get the node role %v, the auth credentials need the iam:GetRole permission: %v", c.NodeRoleARN, err)
+			}
+		}
+		return fmt.Errorf("getting the node role %v: %v", c.NodeRoleARN, err)
+	}
+	if aws.StringValue(res.Role.Arn) != c.NodeRoleARN {
+		return fmt.Errorf("node role %v doesn't exist, the role %v has the ARN %v", c.NodeRoleARN, roleName, aws.StringValue(res.Role.Arn))
+	}
+	doc, err := url.QueryUnescape(aws.StringValue(res.Role.AssumeRolePolicyDocument))
+	if err != nil {
+		return fmt.Errorf("decoding the trust policy of role %v: %v", roleName, err)
+	}
+	trusted, err := trustsService(doc, "ec2.amazonaws.com")
+	if err != nil {
+		return fmt.Errorf("parsing the trust policy of role %v: %v", roleName, err)
+	}
+	if !trusted {
+		return fmt.Errorf("node role %v can't be assumed by the nodes, its trust policy must allow the ec2.amazonaws.com service", c.NodeRoleARN)
+	}
+	log.Printf("Node groups run as the role %v", c.NodeRoleARN)
+	return nil
+}
+
+// trustsService returns true when the trust policy document allows the AWS service to assume the role.
+func trustsService(doc, service string) (bool, error) {
+	policy := struct {
+		Statement json.RawMessage
+	}{}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return false, err
+	}
+	type statement struct {
+		Effect    string
+		Principal struct {
+			Service json.RawMessage
+		}
+	}
+	// The statement and the service can both be a single value or a list.
+	var statements []statement
+	if err := json.Unmarshal(policy.Statement, &statements); err != nil {
+		var s statement
+		if err := json.Unmarshal(policy.Statement, &s); err != nil {
+			return false, err
+		}
+		statements = []statement{s}
+	}
+	for _, s := range statements {
+		if s.Effect != "Allow" || len(s.Principal.Service) == 0 {
+			continue
+		}
+		var services []string
+		if err := json.Unmarshal(s.Principal.Service, &services); err != nil {
+			var svc string
+			if err := json.Unmarshal(s.Principal.Service, &svc); err != nil {
+				return false, err
+			}
+			services = []string{svc}
+		}
+		for _, svc := range services {
+			if svc == service {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// iamError adds a hint about the required permissions to access denied errors.
+func iamError(err error, action string) error {
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AccessDenied" {
+		return fmt.Errorf("%v is not allowed, the auth credentials need the iam:ListOpenIDConnectProviders, iam:CreateOpenIDConnectProvider, iam:GetRole and iam:UpdateAssumeRolePolicy permissions: %v", action, err)
+	}
+	return fmt.Errorf("%v: %v", action, err)
+}
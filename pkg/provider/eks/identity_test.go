@@ -0,0 +1,90 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAddWebIdentityStatement(t *testing.T) {
+	const (
+		providerARN = "arn:aws:iam::123456789012:oidc-provider/oidc.eks.eu-west-1.amazonaws.com/id/ABC"
+		issuer      = "https://oidc.eks.eu-west-1.amazonaws.com/id/ABC"
+	)
+	doc := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}}`
+
+	updated, changed, err := addWebIdentityStatement(doc, providerARN, issuer, "prombench-10", "loadgen")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected the policy to change")
+	}
+	var policy struct {
+		Statement []struct {
+			Principal map[string]string
+			Action    string
+			Condition map[string]map[string]string
+		}
+	}
+	if err := json.Unmarshal([]byte(updated), &policy); err != nil {
+		t.Fatalf("decoding the updated policy: %v", err)
+	}
+	if len(policy.Statement) != 2 {
+		t.Fatalf("expected the existing and the new statement, got %v", updated)
+	}
+	s := policy.Statement[1]
+	if s.Principal["Federated"] != providerARN || s.Action != "sts:AssumeRoleWithWebIdentity" {
+		t.Errorf("unexpected statement %+v", s)
+	}
+	if sub := s.Condition["StringEquals"]["oidc.eks.eu-west-1.amazonaws.com/id/ABC:sub"]; sub != "system:serviceaccount:prombench-10:loadgen" {
+		t.Errorf("unexpected sub condition %v", sub)
+	}
+
+	// Adding the same binding again doesn't change the policy.
+	if _, changed, err := addWebIdentityStatement(updated, providerARN, issuer, "prombench-10", "loadgen"); err != nil || changed {
+		t.Errorf("expected no change, got changed: %v, err: %v", changed, err)
+	}
+}
+
+func TestTrustsService(t *testing.T) {
+	testCases := []struct {
+		doc     string
+		trusted bool
+		err     bool
+	}{
+		{doc: `{"Statement":{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}}`, trusted: true},
+		{doc: `{"Statement":[{"Effect":"Allow","Principal":{"Service":["eks.amazonaws.com","ec2.amazonaws.com"]},"Action":"sts:AssumeRole"}]}`, trusted: true},
+		{doc: `{"Statement":[{"Effect":"Allow","Principal":{"Service":"eks.amazonaws.com"},"Action":"sts:AssumeRole"}]}`},
+		{doc: `{"Statement":[{"Effect":"Deny","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`},
+		{doc: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"sts:AssumeRole"}]}`},
+		{doc: `not json`, err: true},
+	}
+	for _, tc := range testCases {
+		trusted, err := trustsService(tc.doc, "ec2.amazonaws.com")
+		if tc.err {
+			if err == nil {
+				t.Errorf("%v: expected an error", tc.doc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.doc, err)
+		}
+		if trusted != tc.trusted {
+			t.Errorf("%v: want trusted %v, got %v", tc.doc, tc.trusted, trusted)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// serviceCIDRRanges are the private ranges EKS accepts for the service CIDR.
+var serviceCIDRRanges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// setServiceCIDR sets the service IP range passed from the cli.
+// The pods get their IPs from the VPC subnets through the VPC CNI, so EKS has no pod range to set.
+func (c *EKS) setServiceCIDR(req *eksCluster) error {
+	if c.ServiceCIDR == "" {
+		return nil
+	}
+	if err := validateServiceCIDR(c.ServiceCIDR); err != nil {
+		return err
+	}
+	if req.Cluster.KubernetesNetworkConfig == nil {
+		req.Cluster.KubernetesNetworkConfig = &eks.KubernetesNetworkConfigRequest{}
+	}
+	req.Cluster.KubernetesNetworkConfig.ServiceIpv4Cidr = aws.String(c.ServiceCIDR)
+	return nil
+}
+
+// validateServiceCIDR checks the EKS requirements of the service CIDR,
+// a /12 to /24 block within one of the private ranges.
+// EKS also rejects ranges that overlap the VPC of the cluster, which is checked by the create request.
+func validateServiceCIDR(cidr string) error {
+	if err := provider.ValidateCIDRs("", cidr); err != nil {
+		return err
+	}
+	_, n, _ := net.ParseCIDR(cidr)
+	ones, _ := n.Mask.Size()
+	if ones < 12 || ones > 24 {
+		return fmt.Errorf("invalid service CIDR %v, EKS requires a block between /12 and /24", cidr)
+	}
+	for _, r := range serviceCIDRRanges {
+		_, private, _ := net.ParseCIDR(r)
+		if privateOnes, _ := private.Mask.Size(); private.Contains(n.IP) && ones >= privateOnes {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid service CIDR %v, EKS requires a range within %v", cidr, serviceCIDRRanges)
+}
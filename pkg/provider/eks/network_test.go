@@ -0,0 +1,34 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import "testing"
+
+func TestValidateServiceCIDR(t *testing.T) {
+	for cidr, valid := range map[string]bool{
+		"172.20.0.0/16":  true,
+		"10.100.0.0/16":  true,
+		"192.168.0.0/24": true,
+		"172.16.0.0/12":  true,
+		"10.0.0.0/8":     false,
+		"10.100.0.0/25":  false,
+		"100.64.0.0/16":  false,
+		"172.32.0.0/16":  false,
+		"10.100.0.1/16":  false,
+	} {
+		if err := validateServiceCIDR(cidr); valid != (err == nil) {
+			t.Errorf("%v: want valid %v, got %v", cidr, valid, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// kubeletConfigFile is the kubelet config of the EKS optimized AMIs, read by the bootstrap script.
+const kubeletConfigFile = "/etc/kubernetes/kubelet/kubelet-config.json"
+
+// nodeConfigLaunchTemplateName returns the name of the launch template with the node system config for the node groups of a cluster.
+func nodeConfigLaunchTemplateName(clusterName string) string {
+	return clusterName + "-node-config"
+}
+
+// nodeConfigUserData returns the user data that applies a node system config on the EKS optimized AMIs.
+// Managed node groups run it before their bootstrap script, so the sysctls are set
+// and the kubelet config is merged with the eviction thresholds, the pod pids limit and the cpu manager policy
+// before the kubelet starts.
+func nodeConfigUserData(cfg *provider.NodeSystemConfig) (string, error) {
+	var script strings.Builder
+	script.WriteString("#!/bin/bash\nset -ex\n")
+	if len(cfg.Sysctls) > 0 {
+		names := make([]string, 0, len(cfg.Sysctls))
+		for name := range cfg.Sysctls {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		script.WriteString("cat > /etc/sysctl.d/99-prombench.conf <<'EOF'\n")
+		for _, name := range names {
+			fmt.Fprintf(&script, "%s = %s\n", name, cfg.Sysctls[name])
+		}
+		script.WriteString("EOF\nsysctl --system\n")
+	}
+	kubelet := map[string]interface{}{}
+	if len(cfg.EvictionHard) > 0 {
+		kubelet["evictionHard"] = cfg.EvictionHard
+	}
+	if cfg.PodPidsLimit > 0 {
+		kubelet["podPidsLimit"] = cfg.PodPidsLimit
+	}
+	if cfg.CPUManagerPolicy != "" {
+		kubelet["cpuManagerPolicy"] = cfg.CPUManagerPolicy
+	}
+	if len(kubelet) > 0 {
+		patch, err := json.Marshal(kubelet)
+		if err != nil {
+			return "", fmt.Errorf("encoding the kubelet config: %v", err)
+		}
+		fmt.Fprintf(&script, "jq '. * %s' %s > /tmp/kubelet-config.json\nmv /tmp/kubelet-config.json %s\n", patch, kubeletConfigFile, kubeletConfigFile)
+	}
+	return "MIME-Version: 1.0\n" +
+		"Content-Type: multipart/mixed; boundary=\"==PROMBENCH==\"\n\n" +
+		"--==PROMBENCH==\n" +
+		"Content-Type: text/x-shellscript; charset=\"us-ascii\"\n\n" +
+		script.String() +
+		"\n--==PROMBENCH==--\n", nil
+}
+
+// setNodeSystemConfig applies the node system config file passed from the cli on the nodes of all node groups
+// through a launch template with the user data of nodeConfigUserData.
+// The user data only works on the Amazon Linux 2 AMIs, Bottlerocket and Amazon Linux 2023 configure the kubelet differently.
+// Like with the Nitro Enclaves the node groups can't set the remote access or the disk size,
+// and the launch template is reused when it exists with the same config and kept when the cluster is deleted.
+func (c *EKS) setNodeSystemConfig(req *eksCluster) error {
+	if c.NodeSystemConfig == "" {
+		return nil
+	}
+	if c.NitroEnclaves {
+		return fmt.Errorf("the node system config can't be combined with the Nitro Enclaves, both are set through a launch template")
+	}
+	cfg, err := provider.LoadNodeSystemConfig(c.NodeSystemConfig)
+	if err != nil {
+		return err
+	}
+	for _, ng := range req.NodeGroups {
+		switch {
+		case ng.LaunchTemplate != nil:
+			return fmt.Errorf("nodegroup '%s' already sets a launch template, set the node system config in it instead", aws.StringValue(ng.NodegroupName))
+		case ng.RemoteAccess != nil:
+			return fmt.Errorf("nodegroup '%s': the node system config can't be combined with the ssh remote access", aws.StringValue(ng.NodegroupName))
+		case ng.DiskSize != nil:
+			return fmt.Errorf("nodegroup '%s': the node system config can't be combined with a disk size", aws.StringValue(ng.NodegroupName))
+		case ng.AmiType != nil && !strings.HasPrefix(aws.StringValue(ng.AmiType), "AL2_"):
+			return fmt.Errorf("nodegroup '%s': the node system config requires an Amazon Linux 2 AMI type, got %s", aws.StringValue(ng.NodegroupName), aws.StringValue(ng.AmiType))
+		}
+	}
+	userData, err := nodeConfigUserData(cfg)
+	if err != nil {
+		return err
+	}
+
+	name := nodeConfigLaunchTemplateName(aws.StringValue(req.Cluster.Name))
+	if err := createNodeConfigLaunchTemplate(ec2.New(c.sessionAWS), name, base64.StdEncoding.EncodeToString([]byte(userData))); err != nil {
+		return err
+	}
+	for i := range req.NodeGroups {
+		req.NodeGroups[i].LaunchTemplate = &eks.LaunchTemplateSpecification{Name: aws.String(name)}
+	}
+	return nil
+}
+
+// createNodeConfigLaunchTemplate creates the launch template with the base64 encoded user data of a node system config,
+// unless a launch template of the same name already has the same user data.
+func createNodeConfigLaunchTemplate(clientEC2 *ec2.EC2, name, userData string) error {
+	res, err := clientEC2.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String(name),
+		Versions:           aws.StringSlice([]string{"$Default"}),
+	})
+	if err == nil && len(res.LaunchTemplateVersions) > 0 {
+		data := res.LaunchTemplateVersions[0].LaunchTemplateData
+		if data == nil || aws.StringValue(data.UserData) != userData {
+			return fmt.Errorf("the launch template %v already exists with a different node system config, delete it to create a new one", name)
+		}
+		log.Printf("Reusing the launch template %v", name)
+		return nil
+	}
+	if aerr, ok := err.(awserr.Error); err != nil && (!ok || !strings.HasPrefix(aerr.Code(), "InvalidLaunchTemplateName.NotFound")) {
+		return fmt.Errorf("getting the launch template %v: %v", name, err)
+	}
+	if _, err := clientEC2.CreateLaunchTemplate(&ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+		LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+			UserData: aws.String(userData),
+		},
+	}); err != nil {
+		return fmt.Errorf("creating the launch template %v: %v", name, err)
+	}
+	log.Printf("Created the launch template %v with the node system config", name)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestNodeConfigUserData(t *testing.T) {
+	userData, err := nodeConfigUserData(&provider.NodeSystemConfig{
+		Sysctls:          map[string]string{"vm.max_map_count": "262144", "net.core.somaxconn": "4096"},
+		EvictionHard:     map[string]string{"memory.available": "500Mi"},
+		PodPidsLimit:     4096,
+		CPUManagerPolicy: "static",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="==PROMBENCH=="
+
+--==PROMBENCH==
+Content-Type: text/x-shellscript; charset="us-ascii"
+
+#!/bin/bash
+set -ex
+cat > /etc/sysctl.d/99-prombench.conf <<'EOF'
+net.core.somaxconn = 4096
+vm.max_map_count = 262144
+EOF
+sysctl --system
+jq '. * {"cpuManagerPolicy":"static","evictionHard":{"memory.available":"500Mi"},"podPidsLimit":4096}' /etc/kubernetes/kubelet/kubelet-config.json > /tmp/kubelet-config.json
+mv /tmp/kubelet-config.json /etc/kubernetes/kubelet/kubelet-config.json
+
+--==PROMBENCH==--
+`
+	if userData != want {
+		t.Errorf("want the user data:\n%v\ngot:\n%v", want, userData)
+	}
+
+	// Without a kubelet config the kubelet config file is left alone.
+	userData, err = nodeConfigUserData(&provider.NodeSystemConfig{Sysctls: map[string]string{"vm.swappiness": "0"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(userData, "jq") {
+		t.Errorf("want no kubelet config change, got:\n%v", userData)
+	}
+}
+
+func TestSetNodeSystemConfigConflicts(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "node-config.yaml")
+	if err := os.WriteFile(file, []byte("podPidsLimit: 4096\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		enclaves bool
+		ng       eks.CreateNodegroupInput
+		err      string
+	}{
+		{enclaves: true, err: "can't be combined with the Nitro Enclaves"},
+		{ng: eks.CreateNodegroupInput{LaunchTemplate: &eks.LaunchTemplateSpecification{Name: aws.String("prometheus")}}, err: "already sets a launch template"},
+		{ng: eks.CreateNodegroupInput{RemoteAccess: &eks.RemoteAccessConfig{Ec2SshKey: aws.String("prombench-1234-ssh")}}, err: "ssh remote access"},
+		{ng: eks.CreateNodegroupInput{DiskSize: aws.Int64(100)}, err: "disk size"},
+		{ng: eks.CreateNodegroupInput{AmiType: aws.String("BOTTLEROCKET_x86_64")}, err: "requires an Amazon Linux 2 AMI type"},
+	} {
+		c := &EKS{NodeSystemConfig: file, NitroEnclaves: tc.enclaves}
+		tc.ng.NodegroupName = aws.String("prometheus")
+		req := &eksCluster{Cluster: eks.CreateClusterInput{Name: aws.String("prombench-1234")}, NodeGroups: []eks.CreateNodegroupInput{tc.ng}}
+		if err := c.setNodeSystemConfig(req); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("want an error containing %q, got %v", tc.err, err)
+		}
+	}
+	if err := (&EKS{}).setNodeSystemConfig(&eksCluster{}); err != nil {
+		t.Errorf("want no error without --node-system-config, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/prometheusservice"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// cloudWatchAddon ships the container metrics and logs of the nodes to CloudWatch.
+const cloudWatchAddon = "amazon-cloudwatch-observability"
+
+// setLogging enables or disables all control plane log types passed from the cli.
+// When not set the logging config from the cluster file is used, EKS disables control plane logging by default.
+func (c *EKS) setLogging(req *eksCluster) {
+	if c.Logging == "" {
+		return
+	}
+	req.Cluster.Logging = &eks.Logging{
+		ClusterLogging: []*eks.LogSetup{{
+			Enabled: aws.Bool(c.Logging == "enabled"),
+			Types:   aws.StringSlice(eks.LogType_Values()),
+		}},
+	}
+}
+
+// enableMonitoring installs the CloudWatch observability addon when monitoring is enabled from the cli.
+// EKS clusters don't have a monitoring addon by default, so there is nothing to do to disable it.
+// The addon of a reused cluster may already be installed.
+func (c *EKS) enableMonitoring(clusterName string, reused bool) error {
+	if c.Monitoring != "enabled" {
+		return nil
+	}
+	log.Printf("Addon create request: AddonName: '%s', ClusterName: '%s'", cloudWatchAddon, clusterName)
+	if _, err := c.clientEKS.CreateAddon(&eks.CreateAddonInput{
+		AddonName:   aws.String(cloudWatchAddon),
+		ClusterName: aws.String(clusterName),
+	}); err != nil {
+		if aerr, ok := err.(awserr.Error); ok && reused && aerr.Code() == eks.ErrCodeResourceInUseException {
+			log.Printf("Addon '%s' already exists, reusing it", cloudWatchAddon)
+			return nil
+		}
+		return fmt.Errorf("Couldn't create addon '%s': %v", cloudWatchAddon, err)
+	}
+	return nil
+}
+
+// createManagedPrometheus creates the Amazon Managed Service for Prometheus workspace of the cluster and waits until it is active.
+// The workspace has the cluster name as its alias and is tagged as owned by the cluster, so it is removed with the other
+// dependents of the cluster. The workspace of a reused cluster is reused.
+func (c *EKS) createManagedPrometheus(clusterName string) (provider.ManagedPrometheus, error) {
+	client := prometheusservice.New(c.sessionAWS)
+	id, err := c.managedPrometheusWorkspace(client, clusterName)
+	if err != nil {
+		return provider.ManagedPrometheus{}, err
+	}
+	if id != "" {
+		log.Printf("Managed Prometheus workspace '%s' of cluster '%s' already exists, reusing it", id, clusterName)
+	} else {
+		log.Printf("Managed Prometheus workspace create request: Alias: '%s'", clusterName)
+		res, err := client.CreateWorkspace(&prometheusservice.CreateWorkspaceInput{
+			Alias: aws.String(clusterName),
+			Tags:  aws.StringMap(map[string]string{clusterTag(clusterName): clusterTagValue}),
+		})
+		if err != nil {
+			return provider.ManagedPrometheus{}, fmt.Errorf("creating the workspace err: %v", err)
+		}
+		id = aws.StringValue(res.WorkspaceId)
+	}
+	if err := client.WaitUntilWorkspaceActive(&prometheusservice.DescribeWorkspaceInput{WorkspaceId: aws.String(id)}); err != nil {
+		return provider.ManagedPrometheus{}, fmt.Errorf("waiting for the workspace '%s' to be active err: %v", id, err)
+	}
+	res, err := client.DescribeWorkspace(&prometheusservice.DescribeWorkspaceInput{WorkspaceId: aws.String(id)})
+	if err != nil {
+		return provider.ManagedPrometheus{}, fmt.Errorf("describing the workspace '%s' err: %v", id, err)
+	}
+	return provider.AMPManagedPrometheus(aws.StringValue(res.Workspace.PrometheusEndpoint)), nil
+}
+
+// managedPrometheusWorkspace returns the id of the workspace owned by the cluster, empty when there is none.
+// The alias filter of the list matches by prefix, so the alias and the owner tag are compared as well.
+func (c *EKS) managedPrometheusWorkspace(client *prometheusservice.PrometheusService, clusterName string) (string, error) {
+	var id string
+	err := client.ListWorkspacesPages(&prometheusservice.ListWorkspacesInput{Alias: aws.String(clusterName)}, func(page *prometheusservice.ListWorkspacesOutput, _ bool) bool {
+		for _, w := range page.Workspaces {
+			if aws.StringValue(w.Alias) != clusterName || aws.StringValue(w.Tags[clusterTag(clusterName)]) != clusterTagValue {
+				continue
+			}
+			if aws.StringValue(w.Status.StatusCode) == prometheusservice.WorkspaceStatusCodeDeleting {
+				continue
+			}
+			id = aws.StringValue(w.WorkspaceId)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing the workspaces err: %v", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+const (
+	// defaultInstanceType is used by EKS for node groups without an instance type.
+	defaultInstanceType = "t3.medium"
+	// standardVCPUQuotaCode is the quota code of the running On-Demand standard instances vCPUs.
+	standardVCPUQuotaCode = "L-1216C47A"
+	standardVCPUMetric    = "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances vCPUs"
+)
+
+// isStandardInstance returns true for the instance families counted by the standard vCPU quota.
+func isStandardInstance(instanceType string) bool {
+	return instanceType != "" && strings.ContainsRune("acdhimrtz", rune(instanceType[0]))
+}
+
+// checkQuota compares the vCPUs and subnet IP addresses needed by the node groups of the cluster
+// against the EC2 quota of the region and the free addresses of the node group subnets.
+// Only the standard instance families are checked.
+func (c *EKS) checkQuota(req *eksCluster) error {
+	if c.SkipQuotaCheck {
+		return nil
+	}
+	clientEC2 := ec2.New(c.sessionAWS)
+
+	vcpus := map[string]int64{}
+	var required, nodes float64
+	subnets := map[string]struct{}{}
+	for _, ng := range req.NodeGroups {
+		instanceType := defaultInstanceType
+		if len(ng.InstanceTypes) > 0 {
+			instanceType = aws.StringValue(ng.InstanceTypes[0])
+		}
+		var count float64
+		if ng.ScalingConfig != nil {
+			count = float64(aws.Int64Value(ng.ScalingConfig.DesiredSize))
+		}
+		nodes += count
+		for _, s := range ng.Subnets {
+			subnets[aws.StringValue(s)] = struct{}{}
+		}
+		if !isStandardInstance(instanceType) {
+			log.Printf("WARNING: nodegroup '%s' instance type %s isn't a standard instance type, its vCPU quota isn't checked", *ng.NodegroupName, instanceType)
+			continue
+		}
+		if _, ok := vcpus[instanceType]; !ok {
+			res, err := clientEC2.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{instanceType})})
+			if err != nil || len(res.InstanceTypes) == 0 {
+				return fmt.Errorf("Couldn't get the vCPUs of instance type %s for nodegroup '%s': %v", instanceType, *ng.NodegroupName, err)
+			}
+			vcpus[instanceType] = aws.Int64Value(res.InstanceTypes[0].VCpuInfo.DefaultVCpus)
+		}
+		required += count * float64(vcpus[instanceType])
+	}
+
+	quota, err := servicequotas.New(c.sessionAWS).GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String("ec2"),
+		QuotaCode:   aws.String(standardVCPUQuotaCode),
+	})
+	if err != nil {
+		return fmt.Errorf("Couldn't get the EC2 vCPU quota: %v", err)
+	}
+	usage, err := standardVCPUUsage(clientEC2)
+	if err != nil {
+		return err
+	}
+	quotas := []provider.Quota{{
+		Metric:   standardVCPUMetric,
+		Limit:    aws.Float64Value(quota.Quota.Value),
+		Usage:    usage,
+		Required: required,
+	}}
+
+	if len(subnets) > 0 {
+		ids := make([]string, 0, len(subnets))
+		for id := range subnets {
+			ids = append(ids, id)
+		}
+		res, err := clientEC2.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(ids)})
+		if err != nil {
+			return fmt.Errorf("Couldn't get the nodegroup subnets: %v", err)
+		}
+		var free float64
+		for _, s := range res.Subnets {
+			free += float64(aws.Int64Value(s.AvailableIpAddressCount))
+		}
+		quotas = append(quotas, provider.Quota{Metric: "subnet IP addresses", Limit: free, Required: nodes})
+	}
+
+	log.Printf("Quota check for %v nodes: %v", nodes, quotas)
+	return provider.CheckQuotas(quotas)
+}
+
+// standardVCPUUsage returns the vCPUs of all running and pending standard instances in the region.
+func standardVCPUUsage(clientEC2 *ec2.EC2) (float64, error) {
+	var usage float64
+	err := clientEC2.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"pending", "running"})}},
+	}, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, r := range page.Reservations {
+			for _, i := range r.Instances {
+				if !isStandardInstance(aws.StringValue(i.InstanceType)) || i.CpuOptions == nil {
+					continue
+				}
+				usage += float64(aws.Int64Value(i.CpuOptions.CoreCount) * aws.Int64Value(i.CpuOptions.ThreadsPerCore))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Couldn't get the running instances: %v", err)
+	}
+	return usage, nil
+}
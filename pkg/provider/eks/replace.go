@@ -0,0 +1,100 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// nodeGroupLabel is the label EKS sets on every node of a managed node group with the name of the group.
+const nodeGroupLabel = "eks.amazonaws.com/nodegroup"
+
+// NodeGroupReplace replaces a node group of the cluster in place, e.g. to change the instance type between the runs of an experiment series.
+// It creates the new node group from the cluster file, drains the nodes of the old group while respecting the pod disruption budgets,
+// so its pods are rescheduled on the new group, and deletes the old group.
+// When the drain fails the old group stays cordoned, a new replace continues with the existing new group.
+func (c *EKS) NodeGroupReplace(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if len(c.eksResources) != 1 {
+		return fmt.Errorf("a node group replacement needs a single cluster deployment file, got %d", len(c.eksResources))
+	}
+	if dr.ReplaceSurge < 1 {
+		return fmt.Errorf("invalid surge %d, must be >= 1", dr.ReplaceSurge)
+	}
+	if err := c.checkNodeRole(); err != nil {
+		return fmt.Errorf("Invalid node role: %v", err)
+	}
+	deployment := c.eksResources[0]
+	req := &eksCluster{}
+	if err := c.parseNodeGroups(req, deployment); err != nil {
+		return err
+	}
+	clusterName := *req.Cluster.Name
+
+	existing, err := c.listNodeGroups(clusterName)
+	if err != nil {
+		return fmt.Errorf("Couldn't list the nodegroups of cluster '%s', file:%v ,err: %v", clusterName, deployment.FileName, err)
+	}
+	groups := make([]string, 0, len(req.NodeGroups))
+	for _, nodegroupReq := range req.NodeGroups {
+		groups = append(groups, *nodegroupReq.NodegroupName)
+	}
+	with, exists, err := provider.ReplacementPool(existing, groups, dr.ReplacePool, dr.ReplaceWith)
+	if err != nil {
+		return fmt.Errorf("Couldn't replace the nodegroup of cluster '%s', file:%v ,err: %v", clusterName, deployment.FileName, err)
+	}
+
+	log.Printf("Replacing nodegroup '%s' of cluster '%s' with '%s' [1/3]: creating the new group", dr.ReplacePool, clusterName, with)
+	if exists {
+		log.Printf("Nodegroup '%s' exists already, continuing the replacement", with)
+		err := provider.RetryUntilTrue(
+			fmt.Sprintf("creating nodegroup:%s for cluster:%s", with, clusterName),
+			provider.GlobalRetryCount,
+			func() (bool, error) { return c.nodeGroupCreated(with, clusterName) },
+		)
+		if err != nil {
+			return fmt.Errorf("waiting for nodegroup err:%v", err)
+		}
+	} else {
+		for _, nodegroupReq := range req.NodeGroups {
+			if *nodegroupReq.NodegroupName != with {
+				continue
+			}
+			if err := c.createNodeGroup(req, nodegroupReq, deployment.FileName); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("Replacing nodegroup '%s' of cluster '%s' with '%s' [2/3]: draining the old group", dr.ReplacePool, clusterName, with)
+	k, err := c.K8sClient()
+	if err != nil {
+		return fmt.Errorf("k8s provider error: %v", err)
+	}
+	if err := k.DrainNodes(nodeGroupLabel+"="+dr.ReplacePool, dr.ReplaceSurge, dr.DrainTimeout); err != nil {
+		return fmt.Errorf("Couldn't drain nodegroup '%s', the new group '%s' is kept and a new replace continues with it, err: %v", dr.ReplacePool, with, err)
+	}
+
+	log.Printf("Replacing nodegroup '%s' of cluster '%s' with '%s' [3/3]: deleting the old group", dr.ReplacePool, clusterName, with)
+	if err := c.deleteNodeGroup(clusterName, dr.ReplacePool, deployment.FileName); err != nil {
+		return err
+	}
+	log.Printf("Nodegroup '%s' of cluster '%s' replaced with '%s'", dr.ReplacePool, clusterName, with)
+	return nil
+}
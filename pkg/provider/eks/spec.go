@@ -0,0 +1,83 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// ApplySpec loads the --spec-file and uses its fields for the cluster create flags that aren't set.
+// It runs as a pre action, so the name, region and vars of the spec are set before the deployment vars are merged.
+func (c *EKS) ApplySpec(*kingpin.ParseContext) error {
+	if c.SpecFile == "" {
+		return nil
+	}
+	s, err := provider.LoadClusterSpec(c.SpecFile)
+	if err != nil {
+		return err
+	}
+	if err := c.applySpec(s); err != nil {
+		return fmt.Errorf("invalid cluster spec %v: %w", c.SpecFile, err)
+	}
+	s.ApplyDeploymentResource(c.DeploymentResource)
+	return nil
+}
+
+// applySpec maps the imageType of the spec to the AMI type and workloadIdentity to IRSA.
+// The fields EKS doesn't support are rejected instead of being ignored silently.
+func (c *EKS) applySpec(s *provider.ClusterSpec) error {
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"version", s.Version != ""},
+		{"releaseChannel", s.ReleaseChannel != ""},
+		{"networking.podCIDR", s.Networking.PodCIDR != ""},
+		{"networking.maxPodsPerNode", s.Networking.MaxPodsPerNode != 0},
+	} {
+		if f.set {
+			return fmt.Errorf("%v isn't supported by EKS, set it in the cluster file instead", f.name)
+		}
+	}
+	for _, f := range []struct {
+		flag *string
+		spec string
+	}{
+		{&c.AMIType, s.ImageType},
+		{&c.SystemNodePool, s.SystemNodePoolFlag()},
+		{&c.Logging, s.Addons.Logging},
+		{&c.Monitoring, s.Addons.Monitoring},
+		{&c.ServiceCIDR, s.Networking.ServiceCIDR},
+	} {
+		if *f.flag == "" {
+			*f.flag = f.spec
+		}
+	}
+	if len(c.NodePools) == 0 {
+		c.NodePools = s.NodePoolSpecs()
+	}
+	if len(c.Autoscaling) == 0 {
+		c.Autoscaling = s.NodePoolAutoscalings()
+	}
+	if len(c.Zones) == 0 {
+		c.Zones = s.Zones
+	}
+	// A bool flag can't tell unset from false, so the spec can only enable it.
+	c.IRSA = c.IRSA || s.Addons.WorkloadIdentity
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestApplySpec(t *testing.T) {
+	s := &provider.ClusterSpec{
+		ImageType: "BOTTLEROCKET_x86_64",
+		Zones:     []string{"eu-west-1a"},
+		Addons:    provider.SpecAddons{Logging: "enabled", WorkloadIdentity: true},
+	}
+	c := &EKS{Logging: "disabled"}
+	if err := c.applySpec(s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.AMIType != "BOTTLEROCKET_x86_64" || !c.IRSA || len(c.Zones) != 1 {
+		t.Errorf("expect the spec fields mapped to the flags, got %+v", c)
+	}
+	if c.Logging != "disabled" {
+		t.Errorf("expect the logging flag to override the spec, got %q", c.Logging)
+	}
+
+	for _, s := range []*provider.ClusterSpec{
+		{Version: "1.29"},
+		{ReleaseChannel: "stable"},
+		{Networking: provider.SpecNetworking{PodCIDR: "10.4.0.0/14"}},
+		{Networking: provider.SpecNetworking{MaxPodsPerNode: 64}},
+	} {
+		if err := (&EKS{}).applySpec(s); err == nil || !strings.Contains(err.Error(), "isn't supported by EKS") {
+			t.Errorf("expect an unsupported field error for %+v, got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// sshKeyPairName returns the name of the EC2 key pair the ssh public key is imported as for a cluster.
+func sshKeyPairName(clusterName string) string {
+	return clusterName + "-ssh"
+}
+
+// keyPairFingerprint returns the fingerprint EC2 reports for an imported key,
+// MD5 for rsa keys and the base64 SHA256 for ed25519 keys.
+func keyPairFingerprint(key provider.SSHPublicKey) (string, error) {
+	switch key.Type {
+	case "ssh-rsa":
+		return key.MD5Fingerprint(), nil
+	case "ssh-ed25519":
+		return key.SHA256Fingerprint(), nil
+	}
+	return "", fmt.Errorf("EC2 key pairs only support ssh-rsa and ssh-ed25519 keys, got %v", key.Type)
+}
+
+// setRemoteAccess imports the ssh public key passed from the cli as an EC2 key pair
+// and enables the ssh access to the nodes of all node groups with it.
+// A key pair of the same name is reused when it holds the same key, the key pair is kept when the cluster is deleted.
+func (c *EKS) setRemoteAccess(req *eksCluster) error {
+	if c.SSHPublicKey == "" {
+		if len(c.SSHSourceSecurityGroups) > 0 {
+			return fmt.Errorf("the ssh source security groups require an ssh public key")
+		}
+		return nil
+	}
+	for _, sg := range c.SSHSourceSecurityGroups {
+		if !strings.HasPrefix(sg, "sg-") {
+			return fmt.Errorf("invalid ssh source security group %q, expected a security group id, e.g. sg-0123456789abcdef0", sg)
+		}
+	}
+	key, err := provider.LoadSSHPublicKey(c.SSHPublicKey)
+	if err != nil {
+		return err
+	}
+	name := sshKeyPairName(aws.StringValue(req.Cluster.Name))
+	if err := c.importKeyPair(name, key); err != nil {
+		return err
+	}
+	applyRemoteAccess(req, name, c.SSHSourceSecurityGroups)
+	if len(c.SSHSourceSecurityGroups) == 0 {
+		log.Printf("WARNING: no ssh source security groups are set, EKS opens port 22 of the nodes to the internet")
+	}
+	return nil
+}
+
+// applyRemoteAccess sets the key pair and the security groups allowed to connect on all node groups.
+func applyRemoteAccess(req *eksCluster, keyName string, securityGroups []string) {
+	for i := range req.NodeGroups {
+		req.NodeGroups[i].RemoteAccess = &eks.RemoteAccessConfig{
+			Ec2SshKey:            aws.String(keyName),
+			SourceSecurityGroups: aws.StringSlice(securityGroups),
+		}
+	}
+}
+
+// importKeyPair imports the key as the EC2 key pair with the given name, unless it already holds the same key.
+func (c *EKS) importKeyPair(name string, key provider.SSHPublicKey) error {
+	fingerprint, err := keyPairFingerprint(key)
+	if err != nil {
+		return err
+	}
+	clientEC2 := ec2.New(c.sessionAWS)
+	res, err := clientEC2.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{KeyNames: aws.StringSlice([]string{name})})
+	if err == nil && len(res.KeyPairs) > 0 {
+		// EC2 pads the base64 fingerprints.
+		if strings.TrimRight(aws.StringValue(res.KeyPairs[0].KeyFingerprint), "=") != fingerprint {
+			return fmt.Errorf("the key pair %v already exists with another key, delete it to import the new key", name)
+		}
+		log.Printf("Reusing the key pair %v with the key %v", name, fingerprint)
+		return nil
+	}
+	if aerr, ok := err.(awserr.Error); err != nil && (!ok || aerr.Code() != "InvalidKeyPair.NotFound") {
+		return fmt.Errorf("getting the key pair %v: %v", name, err)
+	}
+	if _, err := clientEC2.ImportKeyPair(&ec2.ImportKeyPairInput{
+		KeyName:           aws.String(name),
+		PublicKeyMaterial: []byte(key.String()),
+	}); err != nil {
+		return fmt.Errorf("importing the ssh public key as the key pair %v: %v", name, err)
+	}
+	log.Printf("Imported the ssh public key %v as the key pair %v", fingerprint, name)
+	return nil
+}
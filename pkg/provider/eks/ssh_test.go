@@ -0,0 +1,76 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestKeyPairFingerprint(t *testing.T) {
+	key, err := provider.ParseSSHPublicKey("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIJX3ZE8gEHmnTda95N+6Ad3edgpLI7eKsioeFaoMZSa2 test@prombench")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := keyPairFingerprint(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "KmtMSyWrR8YIJJ+fH/0wUCKsnsME9zJ+xPcFXU661X0"; fingerprint != want {
+		t.Errorf("want the sha256 fingerprint %v for an ed25519 key, got %v", want, fingerprint)
+	}
+	if _, err := keyPairFingerprint(provider.SSHPublicKey{Type: "ecdsa-sha2-nistp256"}); err == nil {
+		t.Error("expected an error for an ecdsa key")
+	}
+}
+
+func TestApplyRemoteAccess(t *testing.T) {
+	req := &eksCluster{NodeGroups: []eks.CreateNodegroupInput{{NodegroupName: aws.String("prometheus")}, {NodegroupName: aws.String("nodes")}}}
+	applyRemoteAccess(req, sshKeyPairName("prombench-1234"), []string{"sg-0123456789abcdef0"})
+	for _, ng := range req.NodeGroups {
+		if ng.RemoteAccess == nil || aws.StringValue(ng.RemoteAccess.Ec2SshKey) != "prombench-1234-ssh" ||
+			len(ng.RemoteAccess.SourceSecurityGroups) != 1 || aws.StringValue(ng.RemoteAccess.SourceSecurityGroups[0]) != "sg-0123456789abcdef0" {
+			t.Errorf("node group %v: unexpected remote access %v", aws.StringValue(ng.NodegroupName), ng.RemoteAccess)
+		}
+	}
+}
+
+func TestSetRemoteAccessErrors(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519.pub")
+	if err := os.WriteFile(keyFile, []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIJX3ZE8gEHmnTda95N+6Ad3edgpLI7eKsioeFaoMZSa2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	req := &eksCluster{Cluster: eks.CreateClusterInput{Name: aws.String("prombench-1234")}}
+	for _, tc := range []struct {
+		c   *EKS
+		err string
+	}{
+		{c: &EKS{SSHSourceSecurityGroups: []string{"sg-0123456789abcdef0"}}, err: "require an ssh public key"},
+		{c: &EKS{SSHPublicKey: keyFile, SSHSourceSecurityGroups: []string{"prombench-nodes"}}, err: "invalid ssh source security group"},
+	} {
+		if err := tc.c.setRemoteAccess(req); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("want an error containing %q, got %v", tc.err, err)
+		}
+	}
+	if err := (&EKS{}).setRemoteAccess(req); err != nil {
+		t.Errorf("want no error without an ssh key, got %v", err)
+	}
+}
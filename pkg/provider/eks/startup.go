@@ -0,0 +1,42 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// setDiskSize sets the root volume size in GB passed from the cli on all node groups.
+// The gp2 root volumes of managed node groups get 3 IOPS per GB,
+// so a larger volume also pulls the container images of new nodes faster.
+// Managed node groups don't support the warm pools of their auto scaling groups.
+func (c *EKS) setDiskSize(req *eksCluster) error {
+	if c.DiskSize == 0 {
+		return nil
+	}
+	if c.DiskSize < 20 {
+		return fmt.Errorf("invalid disk size %dGB, must be at least 20GB", c.DiskSize)
+	}
+	for i := range req.NodeGroups {
+		ng := &req.NodeGroups[i]
+		// The disk size of node groups with a launch template is set in the template.
+		if ng.LaunchTemplate != nil {
+			return fmt.Errorf("can't set the disk size of nodegroup '%s' with a launch template", aws.StringValue(ng.NodegroupName))
+		}
+		ng.DiskSize = aws.Int64(c.DiskSize)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+)
+
+func TestSetDiskSize(t *testing.T) {
+	req := &eksCluster{NodeGroups: []eks.CreateNodegroupInput{{NodegroupName: aws.String("prometheus"), DiskSize: aws.Int64(20)}, {NodegroupName: aws.String("nodes")}}}
+	if err := (&EKS{}).setDiskSize(req); err != nil || aws.Int64Value(req.NodeGroups[0].DiskSize) != 20 || req.NodeGroups[1].DiskSize != nil {
+		t.Fatalf("want the disk sizes from the cluster file without --disk-size, got %v, err: %v", req.NodeGroups, err)
+	}
+	if err := (&EKS{DiskSize: 100}).setDiskSize(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ng := range req.NodeGroups {
+		if aws.Int64Value(ng.DiskSize) != 100 {
+			t.Errorf("node group %v: want the disk size 100, got %v", aws.StringValue(ng.NodegroupName), aws.Int64Value(ng.DiskSize))
+		}
+	}
+	if err := (&EKS{DiskSize: 10}).setDiskSize(req); err == nil {
+		t.Error("expected an error for a disk size below 20GB")
+	}
+	req.NodeGroups[0].LaunchTemplate = &eks.LaunchTemplateSpecification{Name: aws.String("prometheus")}
+	if err := (&EKS{DiskSize: 100}).setDiskSize(req); err == nil {
+		t.Error("expected an error for a node group with a launch template")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// clusterSummary is the state of a cluster read for the run summary before the cluster and its node groups are deleted.
+type clusterSummary struct {
+	cluster *eks.Cluster
+	nodes   []provider.NodeSummary
+}
+
+// clusterForSummary reads the cluster and its node groups for the run summary,
+// nil when the summary is disabled or the cluster doesn't exist.
+// The nodes of a node group are its desired size, with the first of its instance types.
+func (c *EKS) clusterForSummary(name string) (*clusterSummary, error) {
+	if !c.DeploymentResource.RunSummary.Enabled() {
+		return nil, nil
+	}
+	rep, err := c.clientEKS.DescribeCluster(&eks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Couldn't get cluster '%v': %v", name, err)
+	}
+	s := &clusterSummary{cluster: rep.Cluster}
+	var describeErr error
+	err = c.clientEKS.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: aws.String(name)}, func(page *eks.ListNodegroupsOutput, _ bool) bool {
+		for _, ng := range page.Nodegroups {
+			out, dErr := c.clientEKS.DescribeNodegroup(&eks.DescribeNodegroupInput{ClusterName: aws.String(name), NodegroupName: ng})
+			if dErr != nil {
+				describeErr = fmt.Errorf("Couldn't get nodegroup '%v' of cluster '%v': %v", aws.StringValue(ng), name, dErr)
+				return false
+			}
+			s.nodes = append(s.nodes, nodeGroupSummary(out.Nodegroup))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing the nodegroups of cluster '%v' err: %v", name, err)
+	}
+	if describeErr != nil {
+		return nil, describeErr
+	}
+	return s, nil
+}
+
+func nodeGroupSummary(ng *eks.Nodegroup) provider.NodeSummary {
+	n := provider.NodeSummary{Pool: aws.StringValue(ng.NodegroupName)}
+	if len(ng.InstanceTypes) > 0 {
+		n.MachineType = aws.StringValue(ng.InstanceTypes[0])
+	}
+	if ng.ScalingConfig != nil {
+		n.Count = int(aws.Int64Value(ng.ScalingConfig.DesiredSize))
+	}
+	return n
+}
+
+// runSummary returns the summary of the cluster deleted at deleted.
+func (c *EKS) runSummary(s *clusterSummary, deleted time.Time) (provider.RunSummary, error) {
+	return provider.NewRunSummary(c.DeploymentResource.RunSummary, "eks", aws.StringValue(s.cluster.Name), c.DeploymentVars["ZONE"],
+		aws.StringValueMap(s.cluster.Tags), s.nodes, aws.TimeValue(s.cluster.CreatedAt), deleted)
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestNodeGroupSummary(t *testing.T) {
+	for _, tc := range []struct {
+		ng   *eks.Nodegroup
+		want provider.NodeSummary
+	}{
+		{
+			ng: &eks.Nodegroup{
+				NodegroupName: aws.String("main"),
+				InstanceTypes: aws.StringSlice([]string{"m5.xlarge", "m5a.xlarge"}),
+				ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3)},
+			},
+			want: provider.NodeSummary{Pool: "main", MachineType: "m5.xlarge", Count: 3},
+		},
+		// A launch template node group has no instance types.
+		{
+			ng:   &eks.Nodegroup{NodegroupName: aws.String("templated")},
+			want: provider.NodeSummary{Pool: "templated"},
+		},
+	} {
+		if got := nodeGroupSummary(tc.ng); got != tc.want {
+			t.Errorf("want %+v, got %+v", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// spreadZones limits the subnets of all node groups to the availability zones passed from the cli.
+// A node group balances its nodes evenly across the zones of its subnets,
+// so every node group needs a subnet in each of the zones.
+func (c *EKS) spreadZones(req *eksCluster) error {
+	if len(c.Zones) == 0 {
+		return nil
+	}
+	clientEC2 := ec2.New(c.sessionAWS)
+	res, err := clientEC2.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
+	if err != nil {
+		return fmt.Errorf("Couldn't get the availability zones: %v", err)
+	}
+	available := make([]string, 0, len(res.AvailabilityZones))
+	for _, z := range res.AvailabilityZones {
+		available = append(available, aws.StringValue(z.ZoneName))
+	}
+	if err := provider.ValidateZones(c.DeploymentVars["ZONE"], c.Zones, available); err != nil {
+		return err
+	}
+
+	for i := range req.NodeGroups {
+		ng := &req.NodeGroups[i]
+		subnets, err := clientEC2.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: ng.Subnets})
+		if err != nil {
+			return fmt.Errorf("Couldn't get the subnets of nodegroup '%s': %v", *ng.NodegroupName, err)
+		}
+		var kept []*string
+		covered := map[string]bool{}
+		for _, s := range subnets.Subnets {
+			for _, z := range c.Zones {
+				if aws.StringValue(s.AvailabilityZone) == z {
+					kept = append(kept, s.SubnetId)
+					covered[z] = true
+				}
+			}
+		}
+		for _, z := range c.Zones {
+			if !covered[z] {
+				return fmt.Errorf("nodegroup '%s' has no subnet in zone %s", *ng.NodegroupName, z)
+			}
+		}
+		ng.Subnets = kept
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ScrapeFirewall opens the scrape ports of the nodes of a cluster to the source ranges,
+// e.g. for a Prometheus in another network that scrapes the node ports of the benchmark.
+type ScrapeFirewall struct {
+	// Ports are the TCP ports as a single port or a range, e.g. 9100 or 30000-30100.
+	Ports []string
+	// SourceRanges are the CIDRs allowed to connect to the ports.
+	SourceRanges []string
+}
+
+// Enabled returns true when there are ports to open.
+func (f ScrapeFirewall) Enabled() bool {
+	return len(f.Ports) > 0
+}
+
+// Validate checks the ports and the source ranges.
+func (f ScrapeFirewall) Validate() error {
+	if !f.Enabled() {
+		if len(f.SourceRanges) > 0 {
+			return fmt.Errorf("the scrape source ranges require at least one scrape port")
+		}
+		return nil
+	}
+	if len(f.SourceRanges) == 0 {
+		return fmt.Errorf("the scrape ports require at least one scrape source range")
+	}
+	for _, p := range f.Ports {
+		if _, _, err := ParsePortRange(p); err != nil {
+			return err
+		}
+	}
+	for _, r := range f.SourceRanges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			return fmt.Errorf("invalid scrape source range %q, expected a CIDR, e.g. 203.0.113.0/24", r)
+		}
+	}
+	return nil
+}
+
+// ParsePortRange parses a TCP port or a range of ports like 30000-30100, both ends included.
+func ParsePortRange(ports string) (from, to int64, err error) {
+	first, last, isRange := strings.Cut(ports, "-")
+	if !isRange {
+		last = first
+	}
+	from, errFrom := strconv.ParseInt(first, 10, 64)
+	to, errTo := strconv.ParseInt(last, 10, 64)
+	if errFrom != nil || errTo != nil || from < 1 || to > 65535 || from > to {
+		return 0, 0, fmt.Errorf("invalid scrape port %q, expected a port or a range of ports between 1 and 65535, e.g. 9100 or 30000-30100", ports)
+	}
+	return from, to, nil
+}
+
+// ScrapeFirewallName returns the name of the firewall rule of the scrape ports of a cluster,
+// which is also the network tag of its nodes where the provider targets the rules by tag.
+func ScrapeFirewallName(cluster string) string {
+	return cluster + "-scrape"
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestScrapeFirewall(t *testing.T) {
+	for _, tc := range []struct {
+		ports          string
+		from, to       int64
+		expectedErrors bool
+	}{
+		{ports: "9100", from: 9100, to: 9100},
+		{ports: "30000-30100", from: 30000, to: 30100},
+		{ports: "1-65535", from: 1, to: 65535},
+		{ports: "0", expectedErrors: true},
+		{ports: "65536", expectedErrors: true},
+		{ports: "9110-9100", expectedErrors: true},
+		{ports: "9100-", expectedErrors: true},
+		{ports: "http", expectedErrors: true},
+	} {
+		from, to, err := ParsePortRange(tc.ports)
+		if tc.expectedErrors {
+			if err == nil {
+				t.Errorf("%q: expected an error", tc.ports)
+			}
+			continue
+		}
+		if err != nil || from != tc.from || to != tc.to {
+			t.Errorf("%q: want %d-%d, got %d-%d, err: %v", tc.ports, tc.from, tc.to, from, to, err)
+		}
+	}
+
+	valid := ScrapeFirewall{Ports: []string{"9100", "30000-30100"}, SourceRanges: []string{"203.0.113.0/24"}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (ScrapeFirewall{}).Validate(); err != nil {
+		t.Errorf("want no error when disabled, got %v", err)
+	}
+	for _, invalid := range []ScrapeFirewall{
+		{Ports: []string{"9100"}},
+		{SourceRanges: []string{"203.0.113.0/24"}},
+		{Ports: []string{"9100"}, SourceRanges: []string{"203.0.113.1"}},
+		{Ports: []string{"99999"}, SourceRanges: []string{"203.0.113.0/24"}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiNetworkingV1 "k8s.io/api/networking/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+const (
+	// staticIPDescription marks the addresses reserved by infra,
+	// addresses without it were reserved outside of infra and are never released.
+	staticIPDescription = "Reserved by the prometheus/test-infra deployment tool."
+	// ingressStaticIPAnnotation assigns a global static IP address to a GCE ingress.
+	ingressStaticIPAnnotation = "kubernetes.io/ingress.global-static-ip-name"
+)
+
+// staticIPObject is a LoadBalancer service or an ingress that uses a static IP address.
+// Services use regional addresses and ingresses use global addresses.
+type staticIPObject struct {
+	service *apiCoreV1.Service
+	ingress *apiNetworkingV1.Ingress
+}
+
+func (o staticIPObject) global() bool {
+	return o.ingress != nil
+}
+
+// staticIPObjects returns the objects from the manifest files for every static IP passed from the cli.
+func (c *GKE) staticIPObjects() (map[string]staticIPObject, error) {
+	objects := map[string]staticIPObject{}
+	for _, r := range c.k8sResources {
+		for _, obj := range r.Objects {
+			switch o := obj.(type) {
+			case *apiCoreV1.Service:
+				if _, ok := c.StaticIPs[o.Name]; !ok {
+					continue
+				}
+				if o.Spec.Type != apiCoreV1.ServiceTypeLoadBalancer {
+					return nil, fmt.Errorf("service %v has type %q, a static IP requires the LoadBalancer type", o.Name, o.Spec.Type)
+				}
+				objects[o.Name] = staticIPObject{service: o}
+			case *apiNetworkingV1.Ingress:
+				if _, ok := c.StaticIPs[o.Name]; ok {
+					objects[o.Name] = staticIPObject{ingress: o}
+				}
+			}
+		}
+	}
+	for name := range c.StaticIPs {
+		if _, ok := objects[name]; !ok {
+			return nil, fmt.Errorf("no LoadBalancer service or ingress named %v for the static IP", name)
+		}
+	}
+	return objects, nil
+}
+
+// assignStaticIPs reserves the static IP addresses or reuses the existing ones
+// and sets them on the services and ingresses before they are applied.
+func (c *GKE) assignStaticIPs() error {
+	if len(c.StaticIPs) == 0 {
+		return nil
+	}
+	objects, err := c.staticIPObjects()
+	if err != nil {
+		return err
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	for name, obj := range objects {
+		address := c.StaticIPs[name]
+		ip, err := c.reserveAddress(svc, address, obj.global())
+		if err != nil {
+			return err
+		}
+		if obj.global() {
+			if obj.ingress.Annotations == nil {
+				obj.ingress.Annotations = map[string]string{}
+			}
+			obj.ingress.Annotations[ingressStaticIPAnnotation] = address
+		} else {
+			obj.service.Spec.LoadBalancerIP = ip
+		}
+		log.Printf("static IP %v (%v) assigned to %v", address, ip, name)
+	}
+	return nil
+}
+
+// reserveAddress returns the IP of the address and reserves the address when it doesn't exist.
+func (c *GKE) reserveAddress(svc *compute.Service, name string, global bool) (string, error) {
+	project, region := c.DeploymentVars["GKE_PROJECT_ID"], zoneRegion(c.DeploymentVars["ZONE"])
+	get := func() (*compute.Address, error) {
+		if global {
+			return svc.GlobalAddresses.Get(project, name).Context(c.ctx).Do()
+		}
+		return svc.Addresses.Get(project, region, name).Context(c.ctx).Do()
+	}
+
+	addr, err := get()
+	if err == nil {
+		log.Printf("reusing the static IP %v - %v", name, addr.Address)
+		return addr.Address, nil
+	}
+	if !isNotFound(err) {
+		return "", errors.Wrapf(err, "getting the static IP %v", name)
+	}
+
+	req := &compute.Address{Name: name, Description: staticIPDescription}
+	if global {
+		_, err = svc.GlobalAddresses.Insert(project, req).Context(c.ctx).Do()
+	} else {
+		_, err = svc.Addresses.Insert(project, region, req).Context(c.ctx).Do()
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "reserving the static IP %v", name)
+	}
+	err = provider.RetryUntilTrue(
+		fmt.Sprintf("reserving static IP:%v", name),
+		provider.GlobalRetryCount,
+		func() (bool, error) {
+			addr, err = get()
+			if isNotFound(err) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			return addr.Status != "RESERVING", nil
+		})
+	if err != nil {
+		return "", err
+	}
+	log.Printf("static IP reserved %v - %v", name, addr.Address)
+	return addr.Address, nil
+}
+
+// releaseStaticIPs releases the static IP addresses reserved by assignStaticIPs.
+// Addresses that were reserved outside of infra are kept.
+func (c *GKE) releaseStaticIPs() error {
+	if len(c.StaticIPs) == 0 {
+		return nil
+	}
+	if c.KeepStaticIPs {
+		log.Printf("keeping the static IPs %v", c.StaticIPs)
+		return nil
+	}
+	objects, err := c.staticIPObjects()
+	if err != nil {
+		return err
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	project, region := c.DeploymentVars["GKE_PROJECT_ID"], zoneRegion(c.DeploymentVars["ZONE"])
+	for name, obj := range objects {
+		address, global := c.StaticIPs[name], obj.global()
+
+		var addr *compute.Address
+		if global {
+			addr, err = svc.GlobalAddresses.Get(project, address).Context(c.ctx).Do()
+		} else {
+			addr, err = svc.Addresses.Get(project, region, address).Context(c.ctx).Do()
+		}
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "getting the static IP %v", address)
+		}
+		if addr.Description != staticIPDescription {
+			log.Printf("keeping the static IP %v, it wasn't reserved by infra", address)
+			continue
+		}
+
+		// The load balancer is deleted asynchronously after its service or ingress,
+		// so the address can still be in use for a while.
+		err = provider.RetryUntilTrue(
+			fmt.Sprintf("releasing static IP:%v", address),
+			provider.GlobalRetryCount,
+			func() (bool, error) {
+				var err error
+				if global {
+					_, err = svc.GlobalAddresses.Delete(project, address).Context(c.ctx).Do()
+				} else {
+					_, err = svc.Addresses.Delete(project, region, address).Context(c.ctx).Do()
+				}
+				switch {
+				case err == nil, isNotFound(err):
+					return true, nil
+				case isInUse(err):
+					return false, nil
+				}
+				return false, errors.Wrapf(err, "releasing the static IP %v", address)
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var gErr *googleapi.Error
+	return errors.As(err, &gErr) && gErr.Code == http.StatusNotFound
+}
+
+func isInUse(err error) bool {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return false
+	}
+	for _, e := range gErr.Errors {
+		if strings.Contains(e.Reason, "resourceInUse") {
+			return true
+		}
+	}
+	return false
+}
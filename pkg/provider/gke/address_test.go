@@ -0,0 +1,173 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiNetworkingV1 "k8s.io/api/networking/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+	k8sProvider "github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// fakeCompute serves the addresses of the compute API by their path relative to the project
+// and records the requests.
+type fakeCompute struct {
+	addresses map[string]*compute.Address
+	requests  []string
+}
+
+func (f *fakeCompute) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path[strings.Index(r.URL.Path, "/projects/test-project/")+len("/projects/test-project/"):]
+	f.requests = append(f.requests, r.Method+" "+path)
+	addr, ok := f.addresses[path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"code": http.StatusNotFound, "message": "not found"}})
+		return
+	}
+	json.NewEncoder(w).Encode(addr)
+}
+
+func newStaticIPGKE(t *testing.T, f *fakeCompute, objects ...runtime.Object) *GKE {
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	c := New(&provider.DeploymentResource{})
+	c.ctx = context.Background()
+	c.clientOpts = []option.ClientOption{option.WithEndpoint(srv.URL + "/compute/v1/"), option.WithHTTPClient(srv.Client())}
+	c.DeploymentVars = map[string]string{"GKE_PROJECT_ID": "test-project", "ZONE": "europe-west3-a"}
+	c.k8sResources = []k8sProvider.Resource{{FileName: "manifest.yaml", Objects: objects}}
+	return c
+}
+
+func loadBalancer(name string) *apiCoreV1.Service {
+	return &apiCoreV1.Service{
+		ObjectMeta: apiMetaV1.ObjectMeta{Name: name},
+		Spec:       apiCoreV1.ServiceSpec{Type: apiCoreV1.ServiceTypeLoadBalancer},
+	}
+}
+
+func TestStaticIPObjects(t *testing.T) {
+	ingress := &apiNetworkingV1.Ingress{ObjectMeta: apiMetaV1.ObjectMeta{Name: "grafana"}}
+	clusterIP := &apiCoreV1.Service{ObjectMeta: apiMetaV1.ObjectMeta{Name: "prometheus"}}
+
+	c := newStaticIPGKE(t, &fakeCompute{}, loadBalancer("loadgen"), ingress, clusterIP)
+	c.StaticIPs = map[string]string{"loadgen": "loadgen-ip", "grafana": "grafana-ip"}
+	objects, err := c.staticIPObjects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objects) != 2 || objects["loadgen"].global() || !objects["grafana"].global() {
+		t.Errorf("expect a regional address for the service and a global address for the ingress, got %+v", objects)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		staticIPs map[string]string
+		err       string
+	}{
+		{name: "not a load balancer", staticIPs: map[string]string{"prometheus": "prometheus-ip"}, err: `has type "", a static IP requires the LoadBalancer type`},
+		{name: "missing object", staticIPs: map[string]string{"querier": "querier-ip"}, err: "no LoadBalancer service or ingress named querier"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c.StaticIPs = tc.staticIPs
+			if _, err := c.staticIPObjects(); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expect an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestAssignStaticIPs(t *testing.T) {
+	f := &fakeCompute{addresses: map[string]*compute.Address{
+		"regions/europe-west3/addresses/loadgen-ip": {Name: "loadgen-ip", Address: "34.1.2.3", Status: "RESERVED"},
+		"global/addresses/grafana-ip":               {Name: "grafana-ip", Address: "35.4.5.6", Status: "RESERVED"},
+	}}
+	svc := loadBalancer("loadgen")
+	ingress := &apiNetworkingV1.Ingress{ObjectMeta: apiMetaV1.ObjectMeta{Name: "grafana"}}
+	c := newStaticIPGKE(t, f, svc, ingress)
+	c.StaticIPs = map[string]string{"loadgen": "loadgen-ip", "grafana": "grafana-ip"}
+
+	if err := c.assignStaticIPs(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if svc.Spec.LoadBalancerIP != "34.1.2.3" {
+		t.Errorf("expect the regional address set as the load balancer IP of the service, got %q", svc.Spec.LoadBalancerIP)
+	}
+	if got := ingress.Annotations[ingressStaticIPAnnotation]; got != "grafana-ip" {
+		t.Errorf("expect the global address name in the %v annotation of the ingress, got %q", ingressStaticIPAnnotation, got)
+	}
+	for _, r := range f.requests {
+		if !strings.HasPrefix(r, http.MethodGet) {
+			t.Errorf("expect the existing addresses to be reused, got the request %v", r)
+		}
+	}
+
+	// Without static IPs nothing is requested.
+	f.requests = nil
+	c.StaticIPs = map[string]string{}
+	if err := c.assignStaticIPs(); err != nil || len(f.requests) > 0 {
+		t.Errorf("expect no requests without static IPs, got %v %v", f.requests, err)
+	}
+}
+
+func TestReleaseStaticIPs(t *testing.T) {
+	f := &fakeCompute{addresses: map[string]*compute.Address{
+		"regions/europe-west3/addresses/loadgen-ip": {Name: "loadgen-ip", Address: "34.1.2.3", Description: "Reserved by hand."},
+	}}
+	c := newStaticIPGKE(t, f, loadBalancer("loadgen"), loadBalancer("querier"))
+	c.StaticIPs = map[string]string{"loadgen": "loadgen-ip", "querier": "querier-ip"}
+
+	// The loadgen address wasn't reserved by infra and the querier address doesn't exist, so neither is deleted.
+	if err := c.releaseStaticIPs(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, r := range f.requests {
+		if strings.HasPrefix(r, http.MethodDelete) {
+			t.Errorf("expect no address to be released, got the request %v", r)
+		}
+	}
+	if len(f.requests) != 2 {
+		t.Errorf("expect a request for each address, got %v", f.requests)
+	}
+
+	f.requests = nil
+	c.KeepStaticIPs = true
+	if err := c.releaseStaticIPs(); err != nil || len(f.requests) > 0 {
+		t.Errorf("expect no requests when keeping the static IPs, got %v %v", f.requests, err)
+	}
+}
+
+func TestAddressErrors(t *testing.T) {
+	notFound := &googleapi.Error{Code: http.StatusNotFound}
+	inUse := &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "resourceInUseByAnotherResource"}}}
+	if !isNotFound(notFound) || isNotFound(inUse) {
+		t.Errorf("expect only the 404 error to be not found")
+	}
+	if !isInUse(inUse) || isInUse(notFound) {
+		t.Errorf("expect only the resourceInUse error to be in use")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// enableAutoProvisioning enables node auto-provisioning with the CPU and memory bounds passed from the cli.
+// The bounds are totals of the whole cluster, so they include the nodes of the node pools that aren't auto-provisioned.
+// The auto-provisioned node pools use the node service account, image type and zones passed from the cli.
+func (c *GKE) enableAutoProvisioning(cluster *containerpb.Cluster) error {
+	if c.AutoProvisioningCPU == "" && c.AutoProvisioningMemory == "" {
+		return nil
+	}
+	if c.AutoProvisioningCPU == "" || c.AutoProvisioningMemory == "" {
+		return fmt.Errorf("node auto-provisioning requires both the cpu and the memory bounds")
+	}
+	cpu, err := provider.ParseResourceRange("cpu", c.AutoProvisioningCPU)
+	if err != nil {
+		return err
+	}
+	memory, err := provider.ParseResourceRange("memory", c.AutoProvisioningMemory)
+	if err != nil {
+		return err
+	}
+
+	if cluster.Autoscaling == nil {
+		cluster.Autoscaling = &containerpb.ClusterAutoscaling{}
+	}
+	cluster.Autoscaling.EnableNodeAutoprovisioning = true
+	cluster.Autoscaling.ResourceLimits = []*containerpb.ResourceLimit{
+		{ResourceType: "cpu", Minimum: cpu.Min, Maximum: cpu.Max},
+		{ResourceType: "memory", Minimum: memory.Min, Maximum: memory.Max},
+	}
+	if c.NodeServiceAccount != "" || c.ImageType != "" {
+		if cluster.Autoscaling.AutoprovisioningNodePoolDefaults == nil {
+			cluster.Autoscaling.AutoprovisioningNodePoolDefaults = &containerpb.AutoprovisioningNodePoolDefaults{}
+		}
+		if c.NodeServiceAccount != "" {
+			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.ServiceAccount = c.NodeServiceAccount
+		}
+		if c.ImageType != "" {
+			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.ImageType = c.ImageType
+		}
+	}
+	if len(c.Zones) > 0 {
+		cluster.Autoscaling.AutoprovisioningLocations = c.Zones
+	}
+	return nil
+}
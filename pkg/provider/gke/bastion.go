@@ -0,0 +1,191 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+const (
+	bastionMachineType = "e2-micro"
+	bastionImage       = "projects/debian-cloud/global/images/family/debian-12"
+	// bastionLabel is set on the bastion VM to the name of its cluster.
+	bastionLabel = "prombench-bastion"
+)
+
+// bastionZone returns the zone of the bastion VM, the cluster zone or the first zone of a regional cluster.
+func bastionZone(location string, clusterZones []string) string {
+	if strings.Count(location, "-") > 1 || len(clusterZones) == 0 {
+		return location
+	}
+	return clusterZones[0]
+}
+
+// checkBastion checks the bastion options before the cluster is created.
+func (c *GKE) checkBastion() error {
+	if !c.DeploymentResource.Bastion.Enabled {
+		return nil
+	}
+	if c.SSHPublicKey == "" {
+		return errors.New("the bastion requires an ssh public key, set --ssh-public-key")
+	}
+	return c.DeploymentResource.Bastion.Validate()
+}
+
+// createBastion creates a small VM in the network of a cluster, opens the ssh port of it to the source ranges
+// and logs how to reach the api server of the cluster through it, e.g. the private endpoint of a private cluster.
+// A bastion that already exists is reused.
+func (c *GKE) createBastion(project, location, clusterName string) error {
+	opts := c.DeploymentResource.Bastion
+	if !sshUserRe.MatchString(c.SSHUser) {
+		return errors.Errorf("invalid ssh user %q, must be a lowercase linux user name", c.SSHUser)
+	}
+	key, err := provider.LoadSSHPublicKey(c.SSHPublicKey)
+	if err != nil {
+		return err
+	}
+	cluster, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{ProjectId: project, Zone: location, ClusterId: clusterName})
+	if err != nil {
+		return errors.Wrapf(err, "getting the network of cluster:%v", clusterName)
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+
+	name := provider.BastionName(clusterName)
+	network := cluster.Network
+	if network == "" {
+		network = "default"
+	}
+	if _, err := svc.Firewalls.Insert(project, &compute.Firewall{
+		Name:         name,
+		Description:  fmt.Sprintf("ssh to the bastion of cluster %v", clusterName),
+		Network:      "global/networks/" + network,
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"22"}}},
+		SourceRanges: opts.SourceRanges,
+		TargetTags:   []string{name},
+	}).Context(c.ctx).Do(); err != nil && !isAlreadyExists(err) {
+		return errors.Wrapf(err, "creating the ssh firewall rule of the bastion %v", name)
+	}
+
+	zone := bastionZone(location, cluster.Locations)
+	machineType := opts.MachineType
+	if machineType == "" {
+		machineType = bastionMachineType
+	}
+	iface := &compute.NetworkInterface{
+		Network:       "global/networks/" + network,
+		AccessConfigs: []*compute.AccessConfig{{Type: "ONE_TO_ONE_NAT", Name: "External NAT"}},
+	}
+	if cluster.Subnetwork != "" {
+		iface.Subnetwork = fmt.Sprintf("regions/%v/subnetworks/%v", zoneRegion(zone), cluster.Subnetwork)
+	}
+	sshKeys := fmt.Sprintf("%s:%s %s", c.SSHUser, key, c.SSHUser)
+	log.Printf("Creating the bastion '%v' in zone '%v', network '%v'", name, zone, network)
+	if _, err := svc.Instances.Insert(project, zone, &compute.Instance{
+		Name:              name,
+		MachineType:       fmt.Sprintf("zones/%v/machineTypes/%v", zone, machineType),
+		Labels:            map[string]string{bastionLabel: clusterName},
+		Tags:              &compute.Tags{Items: []string{name}},
+		NetworkInterfaces: []*compute.NetworkInterface{iface},
+		Disks: []*compute.AttachedDisk{{
+			Boot:             true,
+			AutoDelete:       true,
+			InitializeParams: &compute.AttachedDiskInitializeParams{SourceImage: bastionImage},
+		}},
+		Metadata: &compute.Metadata{Items: []*compute.MetadataItems{{Key: "ssh-keys", Value: &sshKeys}}},
+	}).Context(c.ctx).Do(); err != nil && !isAlreadyExists(err) {
+		return errors.Wrapf(err, "creating the bastion %v", name)
+	}
+
+	var instance *compute.Instance
+	if err := provider.RetryUntilTrue(fmt.Sprintf("creating bastion:%v", name), provider.GlobalRetryCount, func() (bool, error) {
+		instance, err = svc.Instances.Get(project, zone, name).Context(c.ctx).Do()
+		if err != nil {
+			return false, err
+		}
+		return instance.Status == "RUNNING", nil
+	}); err != nil {
+		return err
+	}
+
+	endpoint := cluster.Endpoint
+	if private := cluster.GetPrivateClusterConfig().GetPrivateEndpoint(); private != "" {
+		endpoint = private
+	}
+	address := ""
+	for _, ac := range instance.NetworkInterfaces[0].AccessConfigs {
+		address = ac.NatIP
+	}
+	log.Print(provider.Bastion{Name: name, User: c.SSHUser, Address: address, Endpoint: endpoint}.ConnectionDetails())
+	return nil
+}
+
+// deleteBastion deletes the bastion VM of a cluster and its ssh firewall rule, if there is one.
+func (c *GKE) deleteBastion(project, clusterName string) error {
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	name := provider.BastionName(clusterName)
+	var zones []string
+	if err := svc.Instances.AggregatedList(project).Filter(fmt.Sprintf("labels.%v=%q", bastionLabel, clusterName)).Pages(c.ctx, func(l *compute.InstanceAggregatedList) error {
+		for _, scoped := range l.Items {
+			for _, instance := range scoped.Instances {
+				if instance.Name == name {
+					zones = append(zones, path.Base(instance.Zone))
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "listing the bastion of cluster:%v", clusterName)
+	}
+	for _, zone := range zones {
+		log.Printf("Removing the bastion '%v' in zone '%v'", name, zone)
+		if _, err := svc.Instances.Delete(project, zone, name).Context(c.ctx).Do(); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, "deleting the bastion %v", name)
+		}
+		if err := provider.RetryUntilTrue(fmt.Sprintf("deleting bastion:%v", name), provider.GlobalRetryCount, func() (bool, error) {
+			_, err := svc.Instances.Get(project, zone, name).Context(c.ctx).Do()
+			if isNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}); err != nil {
+			return err
+		}
+	}
+	if _, err := svc.Firewalls.Delete(project, name).Context(c.ctx).Do(); err != nil && !isNotFound(err) {
+		return errors.Wrapf(err, "deleting the ssh firewall rule of the bastion %v", name)
+	}
+	return nil
+}
+
+func isAlreadyExists(err error) bool {
+	var gErr *googleapi.Error
+	return errors.As(err, &gErr) && gErr.Code == http.StatusConflict
+}
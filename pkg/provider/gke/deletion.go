@@ -0,0 +1,64 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"log"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// removeDeletionProtection fails for a cluster with the deletion protection resource label
+// unless ForceDelete is set, in which case the label is removed so the cleanup can delete the cluster.
+// A cluster that doesn't exist is left to the delete request.
+func (c *GKE) removeDeletionProtection(projectID, zone, clusterID string) error {
+	cluster, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{
+		ProjectId: projectID,
+		Zone:      zone,
+		ClusterId: clusterID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "getting cluster:%v", clusterID)
+	}
+	protected, err := provider.CheckDeletionProtection(clusterID, cluster.ResourceLabels, c.ForceDelete)
+	if err != nil || !protected {
+		return err
+	}
+
+	labels := map[string]string{}
+	for k, v := range cluster.ResourceLabels {
+		if k != provider.DeletionProtectionLabel {
+			labels[k] = v
+		}
+	}
+	if _, err := c.clientGKE.SetLabels(c.ctx, &containerpb.SetLabelsRequest{
+		ProjectId:        projectID,
+		Zone:             zone,
+		ClusterId:        clusterID,
+		ResourceLabels:   labels,
+		LabelFingerprint: cluster.LabelFingerprint,
+	}); err != nil {
+		return errors.Wrapf(err, "removing the deletion protection of cluster:%v", clusterID)
+	}
+	log.Printf("Removed the deletion protection of cluster '%v' with --force-delete", clusterID)
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// clusterNameLabel is set by GKE on the compute resources it creates for the objects of a cluster,
+// e.g. the disks of the PersistentVolumes and the forwarding rules of the LoadBalancer services.
+const clusterNameLabel = "goog-k8s-cluster-name"
+
+// removeDependents removes the load balancers, addresses and disks with the cluster name label, which outlive the cluster.
+// It runs once the cluster is deleted so nothing recreates them. The load balancers go first since
+// their addresses are in use until then, the disks that are still attached are reported as failed.
+func (c *GKE) removeDependents(project, cluster string) error {
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	filter := fmt.Sprintf("labels.%v=%q", clusterNameLabel, cluster)
+	owned := func(labels map[string]string) bool { return labels[clusterNameLabel] == cluster }
+	report := &provider.DependentsReport{Cluster: cluster}
+
+	var targetPools []*compute.ForwardingRule
+	if err := svc.ForwardingRules.AggregatedList(project).Filter(filter).Pages(c.ctx, func(l *compute.ForwardingRuleAggregatedList) error {
+		for _, scoped := range l.Items {
+			for _, rule := range scoped.ForwardingRules {
+				if !owned(rule.Labels) {
+					continue
+				}
+				d := provider.Dependent{Kind: "forwarding rule", Name: rule.Name}
+				report.Add(d, c.deleteInUse(d, func() error {
+					_, err := svc.ForwardingRules.Delete(project, path.Base(rule.Region), rule.Name).Context(c.ctx).Do()
+					return err
+				}))
+				if strings.Contains(rule.Target, "/targetPools/") {
+					targetPools = append(targetPools, rule)
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "listing the forwarding rules of cluster:%v", cluster)
+	}
+	if err := svc.GlobalForwardingRules.List(project).Filter(filter).Pages(c.ctx, func(l *compute.ForwardingRuleList) error {
+		for _, rule := range l.Items {
+			if !owned(rule.Labels) {
+				continue
+			}
+			d := provider.Dependent{Kind: "global forwarding rule", Name: rule.Name}
+			report.Add(d, c.deleteInUse(d, func() error {
+				_, err := svc.GlobalForwardingRules.Delete(project, rule.Name).Context(c.ctx).Do()
+				return err
+			}))
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "listing the global forwarding rules of cluster:%v", cluster)
+	}
+	// The target pools of the deleted forwarding rules hold the nodes of the load balancers.
+	for _, rule := range targetPools {
+		name := path.Base(rule.Target)
+		d := provider.Dependent{Kind: "target pool", Name: name}
+		report.Add(d, c.deleteInUse(d, func() error {
+			_, err := svc.TargetPools.Delete(project, path.Base(rule.Region), name).Context(c.ctx).Do()
+			return err
+		}))
+	}
+
+	if err := svc.Addresses.AggregatedList(project).Filter(filter).Pages(c.ctx, func(l *compute.AddressAggregatedList) error {
+		for _, scoped := range l.Items {
+			for _, addr := range scoped.Addresses {
+				if !owned(addr.Labels) || addr.Description == staticIPDescription {
+					continue
+				}
+				d := provider.Dependent{Kind: "address", Name: addr.Name}
+				report.Add(d, c.deleteInUse(d, func() error {
+					_, err := svc.Addresses.Delete(project, path.Base(addr.Region), addr.Name).Context(c.ctx).Do()
+					return err
+				}))
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "listing the addresses of cluster:%v", cluster)
+	}
+	if err := svc.GlobalAddresses.List(project).Filter(filter).Pages(c.ctx, func(l *compute.AddressList) error {
+		for _, addr := range l.Items {
+			if !owned(addr.Labels) || addr.Description == staticIPDescription {
+				continue
+			}
+			d := provider.Dependent{Kind: "global address", Name: addr.Name}
+			report.Add(d, c.deleteInUse(d, func() error {
+				_, err := svc.GlobalAddresses.Delete(project, addr.Name).Context(c.ctx).Do()
+				return err
+			}))
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "listing the global addresses of cluster:%v", cluster)
+	}
+
+	if err := svc.Disks.AggregatedList(project).Filter(filter).Pages(c.ctx, func(l *compute.DiskAggregatedList) error {
+		for _, scoped := range l.Items {
+			for _, disk := range scoped.Disks {
+				if !owned(disk.Labels) {
+					continue
+				}
+				d := provider.Dependent{Kind: "disk", Name: disk.Name}
+				if len(disk.Users) > 0 {
+					report.Add(d, errors.Errorf("still attached to %v", strings.Join(disk.Users, ", ")))
+					continue
+				}
+				_, err := svc.Disks.Delete(project, path.Base(disk.Zone), disk.Name).Context(c.ctx).Do()
+				if isNotFound(err) {
+					err = nil
+				}
+				report.Add(d, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "listing the disks of cluster:%v", cluster)
+	}
+	return report.Err()
+}
+
+// deleteInUse retries the delete while the resource is in use, e.g. an address until its forwarding rule is deleted.
+// A resource that is already gone counts as deleted.
+func (c *GKE) deleteInUse(d provider.Dependent, del func() error) error {
+	var last error
+	err := provider.RetryUntilTrue(fmt.Sprintf("deleting %v", d), provider.GlobalRetryCount, func() (bool, error) {
+		last = del()
+		switch {
+		case last == nil, isNotFound(last):
+			return true, nil
+		case isInUse(last):
+			return false, nil
+		}
+		return false, last
+	})
+	if err != nil && last != nil {
+		return last
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+
+	k8sProvider "github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// pdCSIDriver attaches the compute persistent disks to the GKE nodes.
+const pdCSIDriver = "pd.csi.storage.gke.io"
+
+// existingDisks looks up the disks passed from the cli, by PersistentVolume name.
+// A disk is given as name in the zone of the cluster or as zone/name.
+func (c *GKE) existingDisks() ([]k8sProvider.ExistingDisk, error) {
+	if len(c.ExistingDisks) == 0 {
+		return nil, nil
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the compute client")
+	}
+	project := c.DeploymentVars["GKE_PROJECT_ID"]
+	disks := make([]k8sProvider.ExistingDisk, 0, len(c.ExistingDisks))
+	for _, name := range c.existingDiskNames() {
+		zone, diskName := c.DeploymentVars["ZONE"], c.ExistingDisks[name]
+		if i := strings.Index(diskName, "/"); i >= 0 {
+			zone, diskName = diskName[:i], diskName[i+1:]
+		}
+		d, err := svc.Disks.Get(project, zone, diskName).Context(c.ctx).Do()
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting the disk %v in zone %v", diskName, zone)
+		}
+		if len(d.Users) > 0 {
+			// The disk can't be attached to a node while it is attached read-write to another instance.
+			log.Printf("WARNING: the disk %v is attached to %v", d.Name, path.Base(d.Users[0]))
+		}
+		disks = append(disks, k8sProvider.ExistingDisk{
+			Name:   name,
+			Disk:   d.Name,
+			Driver: pdCSIDriver,
+			Handle: fmt.Sprintf("projects/%v/zones/%v/disks/%v", project, zone, d.Name),
+			Zone:   zone,
+			SizeGB: d.SizeGb,
+		})
+	}
+	return disks, nil
+}
+
+// existingDiskNames returns the PersistentVolume names of the disks passed from the cli.
+func (c *GKE) existingDiskNames() []string {
+	names := make([]string, 0, len(c.ExistingDisks))
+	for name := range c.ExistingDisks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
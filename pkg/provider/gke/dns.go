@@ -0,0 +1,129 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	dns "google.golang.org/api/dns/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+	k8sProvider "github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// dnsZone returns the Cloud DNS client and the DNS name of the managed zone.
+func (c *GKE) dnsZone() (*dns.Service, string, error) {
+	if c.DNSZone == "" {
+		return nil, "", errors.New("the DNS records require --dns-zone")
+	}
+	svc, err := dns.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not create the Cloud DNS client")
+	}
+	zone, err := svc.ManagedZones.Get(c.DeploymentVars["GKE_PROJECT_ID"], c.DNSZone).Context(c.ctx).Do()
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "getting the managed zone %v", c.DNSZone)
+	}
+	return svc, zone.DnsName, nil
+}
+
+// upsertDNSRecords points the DNS records at the load balancers of their services once they are assigned.
+// Existing A, AAAA and CNAME records with the same name are replaced.
+func (c *GKE) upsertDNSRecords() error {
+	if len(c.DNSRecords) == 0 {
+		return nil
+	}
+	svc, zoneName, err := c.dnsZone()
+	if err != nil {
+		return err
+	}
+	project := c.DeploymentVars["GKE_PROJECT_ID"]
+	for service, record := range c.DNSRecords {
+		name, err := provider.DNSRecordName(record, zoneName)
+		if err != nil {
+			return err
+		}
+		obj, err := k8sProvider.LoadBalancerService(c.k8sResources, service)
+		if err != nil {
+			return err
+		}
+		endpoint, err := c.k8sProvider.WaitForServiceEndpoint(obj.Namespace, obj.Name, provider.DNSEndpointTimeout)
+		if err != nil {
+			return err
+		}
+		recordType, value := provider.DNSRecord(endpoint)
+		existing, err := c.endpointRecords(svc, name)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 1 && existing[0].Type == recordType && len(existing[0].Rrdatas) == 1 && existing[0].Rrdatas[0] == value {
+			log.Printf("DNS record %v already points at %v", name, value)
+			continue
+		}
+		change := &dns.Change{
+			Deletions: existing,
+			Additions: []*dns.ResourceRecordSet{{Name: name, Type: recordType, Ttl: provider.DNSRecordTTL, Rrdatas: []string{value}}},
+		}
+		if _, err := svc.Changes.Create(project, c.DNSZone, change).Context(c.ctx).Do(); err != nil {
+			return errors.Wrapf(err, "updating the DNS record %v", name)
+		}
+		log.Printf("DNS record %v %v points at %v", name, recordType, value)
+	}
+	return nil
+}
+
+// deleteDNSRecords deletes the DNS records of the services, records that don't exist are skipped.
+func (c *GKE) deleteDNSRecords() error {
+	if len(c.DNSRecords) == 0 {
+		return nil
+	}
+	svc, zoneName, err := c.dnsZone()
+	if err != nil {
+		return err
+	}
+	for _, record := range c.DNSRecords {
+		name, err := provider.DNSRecordName(record, zoneName)
+		if err != nil {
+			return err
+		}
+		existing, err := c.endpointRecords(svc, name)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			continue
+		}
+		if _, err := svc.Changes.Create(c.DeploymentVars["GKE_PROJECT_ID"], c.DNSZone, &dns.Change{Deletions: existing}).Context(c.ctx).Do(); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, "deleting the DNS record %v", name)
+		}
+		log.Printf("DNS record %v deleted", name)
+	}
+	return nil
+}
+
+// endpointRecords returns the A, AAAA and CNAME records with the name.
+func (c *GKE) endpointRecords(svc *dns.Service, name string) ([]*dns.ResourceRecordSet, error) {
+	rep, err := svc.ResourceRecordSets.List(c.DeploymentVars["GKE_PROJECT_ID"], c.DNSZone).Name(name).Context(c.ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the DNS records %v", name)
+	}
+	var records []*dns.ResourceRecordSet
+	for _, r := range rep.Rrsets {
+		if r.Name == name && provider.IsDNSEndpointRecordType(r.Type) {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
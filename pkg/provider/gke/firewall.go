@@ -0,0 +1,94 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// tagScrapeNodes adds the network tag targeted by the scrape firewall rule to the nodes of all node pools of the cluster.
+func (c *GKE) tagScrapeNodes(cluster *containerpb.Cluster) {
+	if !c.DeploymentResource.ScrapeFirewall.Enabled() {
+		return
+	}
+	tag := provider.ScrapeFirewallName(cluster.Name)
+	for _, pool := range cluster.NodePools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		pool.Config.Tags = append(pool.Config.Tags, tag)
+	}
+}
+
+// createScrapeFirewall opens the scrape ports of the nodes of the cluster to the source ranges with a firewall rule
+// in the network of the cluster, targeting the nodes by their scrape network tag.
+// The rule of a reused cluster is updated to the current ports and source ranges.
+func (c *GKE) createScrapeFirewall(project, location, clusterName string) error {
+	opts := c.DeploymentResource.ScrapeFirewall
+	cluster, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{ProjectId: project, Zone: location, ClusterId: clusterName})
+	if err != nil {
+		return errors.Wrapf(err, "getting the network of cluster:%v", clusterName)
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	network := cluster.Network
+	if network == "" {
+		network = "default"
+	}
+	name := provider.ScrapeFirewallName(clusterName)
+	rule := &compute.Firewall{
+		Name:         name,
+		Description:  fmt.Sprintf("scrape ports of the nodes of cluster %v", clusterName),
+		Network:      "global/networks/" + network,
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: opts.Ports}},
+		SourceRanges: opts.SourceRanges,
+		TargetTags:   []string{name},
+	}
+	log.Printf("Opening the scrape ports %v of cluster '%v' to %v", opts.Ports, clusterName, opts.SourceRanges)
+	_, err = svc.Firewalls.Insert(project, rule).Context(c.ctx).Do()
+	if isAlreadyExists(err) {
+		_, err = svc.Firewalls.Update(project, name, rule).Context(c.ctx).Do()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "creating the scrape firewall rule %v", name)
+	}
+	return nil
+}
+
+// deleteScrapeFirewall deletes the scrape firewall rule of a cluster, if there is one.
+func (c *GKE) deleteScrapeFirewall(project, clusterName string) error {
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	name := provider.ScrapeFirewallName(clusterName)
+	_, err = svc.Firewalls.Delete(project, name).Context(c.ctx).Do()
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "deleting the scrape firewall rule %v", name)
+	}
+	log.Printf("Removed the scrape firewall rule '%v'", name)
+	return nil
+}
@@ -17,21 +17,25 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"regexp"
 	"strings"
+	"syscall"
+	"time"
 
 	gke "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gopkg.in/alecthomas/kingpin.v2"
 	yamlGo "gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
@@ -43,6 +47,9 @@ import (
 func New(dr *provider.DeploymentResource) *GKE {
 	return &GKE{
 		DeploymentResource: dr,
+		StaticIPs:          map[string]string{},
+		DNSRecords:         map[string]string{},
+		ExistingDisks:      map[string]string{},
 	}
 }
 
@@ -53,10 +60,84 @@ type GKE struct {
 	// The auth used to authenticate the cli.
 	// Can be a file path or an env variable that includes the json data.
 	Auth string
-	// The project id for all requests.
+	// The project of the run, it sets the GKE_PROJECT_ID variable used by the cluster files and all requests.
 	ProjectID string
+	// A service account impersonated by the auth for all requests, to run with its permissions instead of the ones of the auth.
+	ImpersonateServiceAccount string
+	// Additional node pools to create together with the cluster.
+	NodePools provider.NodePoolSpecs
+	// Cluster autoscaler node bounds for node pools, by pool name.
+	Autoscaling provider.NodePoolAutoscalings
+	// An untainted node pool for the kube-system workloads, all other node pools get the benchmark taint.
+	SystemNodePool string
+	// Node auto-provisioning CPU cores and memory GB bounds of the whole cluster in the MIN:MAX format, empty disables it.
+	AutoProvisioningCPU    string
+	AutoProvisioningMemory string
+	// Node pools to delete by name instead of the ones in the cluster deployment file.
+	NodePoolNames []string
+	// Force allows deleting the last remaining node pools of a cluster.
+	Force bool
+	// ForceDelete removes the deletion protection label of a cluster before deleting it.
+	ForceDelete bool
+	// The release channel to enroll the cluster in - rapid, regular, stable or none.
+	ReleaseChannel string
+	// A static control plane version for the cluster.
+	ClusterVersion string
+	// The start time of the daily maintenance window in the HH:MM UTC format.
+	MaintenanceWindow string
+	// The node image type for all node pools, e.g. COS_CONTAINERD or UBUNTU_CONTAINERD.
+	ImageType string
+	// The email of an existing GCP service account the nodes of all node pools run as, instead of the default service account.
+	NodeServiceAccount string
+	// An ssh public key file added to the nodes of the created node pools for SSHUser, empty disables ssh logins.
+	SSHPublicKey string
+	SSHUser      string
+	// Stream the container images on the nodes of the created node pools and their boot disk type and size in GB, empty or 0 keeps the value from the cluster file.
+	ImageStreaming bool
+	BootDiskType   string
+	BootDiskSize   int32
+	// Enable the shielded and confidential VM options on the nodes of the created node pools.
+	ShieldedNodes     bool
+	ConfidentialNodes bool
+	// A node system config file with the kubelet and kernel config of the nodes of the created node pools.
+	NodeSystemConfig string
+	// Enable Workload Identity and bind k8s service accounts to GCP service accounts.
+	WorkloadIdentity         bool
+	WorkloadIdentityBindings provider.WorkloadIdentityBindings
+	// Zones of the cluster region to spread the nodes of all node pools across.
+	Zones []string
+	// A cluster spec file whose fields are used for the cluster create flags that aren't set.
+	SpecFile string
+	// Skip the CPU and IP address quota check before creating a cluster.
+	SkipQuotaCheck bool
+	// WaitCreate is false to return once the cluster create was started, with a handle per cluster for cluster wait.
+	WaitCreate bool
+	// The handles of the cluster creates cluster wait waits for.
+	CreateHandles []string
+	// Enable or disable the Cloud Logging and Cloud Monitoring integrations, empty keeps the value from the cluster file.
+	Logging    string
+	Monitoring string
+	// Enable Google Managed Prometheus with its managed collection, isolated from the self-hosted Prometheus of the deployments.
+	ManagedPrometheus bool
+	// The pod and service IP ranges and the max pods per node of a VPC-native cluster, empty or 0 keeps the value from the cluster file.
+	PodCIDR        string
+	ServiceCIDR    string
+	MaxPodsPerNode int64
+	// Static IP address names for LoadBalancer services and ingresses, by object name.
+	StaticIPs map[string]string
+	// Keep the static IP addresses reserved by resource apply when deleting the resources.
+	KeepStaticIPs bool
+	// Existing compute disks attached to the nodes through PersistentVolumes, by PersistentVolume name.
+	// A disk is given as name in the zone of the cluster or as zone/name.
+	ExistingDisks map[string]string
+	// DNSRecords point a record in the DNSZone managed zone at the load balancer of a service, by service name.
+	DNSRecords map[string]string
+	DNSZone    string
 	// The gke client used when performing GKE requests.
 	clientGKE *gke.ClusterManagerClient
+	// The options of all GCP clients, and the token source of the impersonated service account.
+	clientOpts  []option.ClientOption
+	tokenSource oauth2.TokenSource
 	// The k8s provider used when we work with the manifest files.
 	k8sProvider *k8sProvider.K8s
 	// Final DeploymentFiles files.
@@ -74,11 +155,17 @@ type GKE struct {
 }
 
 // NewGKEClient sets the GKE client used when performing GKE requests.
-func (c *GKE) NewGKEClient(*kingpin.ParseContext) error {
+func (c *GKE) NewGKEClient(parseContext *kingpin.ParseContext) error {
 	// Set the auth env variable needed to the gke client.
 	if c.Auth != "" {
+	} else if f := c.DeploymentResource.CredentialsFile; f != "" {
+		auth, err := provider.CredentialsAuth(f, "gke")
+		if err != nil {
+			return err
+		}
+		c.Auth = auth
 	} else if c.Auth = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); c.Auth == "" {
-		return errors.Errorf("no auth provided! Need to either set the auth flag or the GOOGLE_APPLICATION_CREDENTIALS env variable")
+		return errors.Errorf("no auth provided! Need to either set the auth flag, --credentials-file or the GOOGLE_APPLICATION_CREDENTIALS env variable")
 	}
 
 	// When the auth variable points to a file
@@ -115,16 +202,22 @@ func (c *GKE) NewGKEClient(*kingpin.ParseContext) error {
 	// https://github.com/kubernetes/kubernetes/pull/80303
 	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", saFile.Name())
 
-	opts := option.WithCredentialsJSON([]byte(c.Auth))
+	c.ctx = context.Background()
+	if err := c.setClientOptions(); err != nil {
+		return err
+	}
 
-	cl, err := gke.NewClusterManagerClient(context.Background(), opts)
+	cl, err := gke.NewClusterManagerClient(c.ctx, c.clientOpts...)
 	if err != nil {
 		return errors.Wrap(err, "could not create the gke client")
 	}
 	c.clientGKE = cl
-	c.ctx = context.Background()
 
-	return nil
+	command := "gke"
+	if parseContext.SelectedCommand != nil {
+		command = parseContext.SelectedCommand.FullCommand()
+	}
+	return c.checkPermissions(command)
 }
 
 // SetupDeploymentResources Sets up DeploymentVars and DeploymentFiles
@@ -134,7 +227,11 @@ func (c *GKE) SetupDeploymentResources(*kingpin.ParseContext) error {
 		c.DeploymentResource.DefaultDeploymentVars,
 		c.DeploymentResource.FlagDeploymentVars,
 	)
-	return nil
+	c.DeploymentResource.FanOut.SetFirstRegion(c.DeploymentVars)
+	if err := provider.ApplyClusterNameSuffix(c.DeploymentVars, c.DeploymentResource.ClusterNameSuffix, provider.GKEClusterNameRules); err != nil {
+		return err
+	}
+	return c.setProject()
 }
 
 // GKEDeploymentsParse parses the cluster/nodepool deployment files and saves the result as bytes grouped by the filename.
@@ -165,9 +262,14 @@ func (c *GKE) K8SDeploymentsParse(*kingpin.ParseContext) error {
 		log.Fatalf("Couldn't parse deployment files: %v", err)
 	}
 
+	helmResources, err := provider.HelmTemplate(c.DeploymentResource.Helm)
+	if err != nil {
+		log.Fatalf("Couldn't render the helm charts: %v", err)
+	}
+	deploymentResource = append(deploymentResource, helmResources...)
+
 	for _, deployment := range deploymentResource {
 
-		decode := scheme.Codecs.UniversalDeserializer().Decode
 		k8sObjects := make([]runtime.Object, 0)
 
 		for _, text := range strings.Split(string(deployment.Content), provider.Separator) {
@@ -176,9 +278,9 @@ func (c *GKE) K8SDeploymentsParse(*kingpin.ParseContext) error {
 				continue
 			}
 
-			resource, _, err := decode([]byte(text), nil, nil)
+			resource, err := k8sProvider.Decode([]byte(text))
 			if err != nil {
-				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, text[:100])
+				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, provider.Truncate(text, 100))
 			}
 			if resource == nil {
 				continue
@@ -200,7 +302,7 @@ func (c *GKE) checkDeploymentVarsAndFiles() error {
 			return fmt.Errorf("missing required %v variable", k)
 		}
 	}
-	if len(c.DeploymentFiles) == 0 {
+	if len(c.DeploymentFiles) == 0 && len(c.DeploymentResource.Helm.Charts) == 0 {
 		return fmt.Errorf("missing deployment file(s)")
 	}
 	return nil
@@ -208,19 +310,82 @@ func (c *GKE) checkDeploymentVarsAndFiles() error {
 
 // ClusterCreate create a new cluster or applies changes to an existing cluster.
 func (c *GKE) ClusterCreate(*kingpin.ParseContext) error {
+	if len(c.DeploymentResource.FanOut.Regions) > 0 {
+		if err := c.fanOut(); err != nil {
+			log.Fatalf("Multi-region cluster create failed: %v", err)
+		}
+		return nil
+	}
+	if err := c.checkNodeServiceAccount(); err != nil {
+		log.Fatalf("Invalid node service account: %v", err)
+	}
+	if err := c.checkBastion(); err != nil {
+		log.Fatalf("Invalid bastion options: %v", err)
+	}
+	if err := c.DeploymentResource.ScrapeFirewall.Validate(); err != nil {
+		log.Fatalf("Invalid scrape firewall options: %v", err)
+	}
+	if err := c.checkNoWait(); err != nil {
+		log.Fatalf("Invalid --wait=false options: %v", err)
+	}
 	req := &containerpb.CreateClusterRequest{}
+	var created []provider.ClusterInfo
 	for _, deployment := range c.gkeResources {
 
 		if err := yamlGo.UnmarshalStrict(deployment.Content, req); err != nil {
 			log.Fatalf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
 		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		if err := c.applyClusterFlags(req.Zone, req.Cluster); err != nil {
+			log.Fatalf("Error applying the cli options to cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		c.setNodeServiceAccount(req.Cluster.NodePools)
+		if err := c.setSSHKey(req.Cluster.NodePools); err != nil {
+			log.Fatalf("Error setting the ssh key of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setNodeStartup(req.Cluster.NodePools); err != nil {
+			log.Fatalf("Error setting the node startup options of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setNodeSecurity(req.Cluster.NodePools); err != nil {
+			log.Fatalf("Error setting the node security options of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.setNodeSystemConfig(req.Cluster.NodePools); err != nil {
+			log.Fatalf("Error setting the node system config of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.enableWorkloadIdentity(req); err != nil {
+			log.Fatalf("Error enabling workload identity for cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if err := c.checkZones(req); err != nil {
+			log.Fatalf("Invalid zones for cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		c.checkNodeImages(req)
+		if err := c.checkQuota(req); err != nil {
+			log.Fatalf("Quota check failed for cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
 
 		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
 		log.Printf("Cluster create request: name:'%v', project `%s`,zone `%s`", req.Cluster.Name, req.ProjectId, req.Zone)
-		_, err := c.clientGKE.CreateCluster(c.ctx, req)
+		start := time.Now()
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		exists, err := c.clusterExists(req.Zone, req.ProjectId, req.Cluster.Name)
 		if err != nil {
+			log.Fatalf("Couldn't check whether cluster '%v' exists, file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		var op *containerpb.Operation
+		if exists {
+			log.Printf("Cluster '%v' already exists, reusing it for the cluster name suffix", req.Cluster.Name)
+		} else if op, err = c.clientGKE.CreateCluster(c.ctx, req); err != nil {
 			log.Fatalf("Couldn't create cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
 		}
+		if !c.WaitCreate {
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			h := newClusterHandle(req.ProjectId, req.Zone, req.Cluster.Name, op).String()
+			log.Printf("Cluster create started for cluster '%v', wait for it with: gke cluster wait %v", req.Cluster.Name, h)
+			fmt.Println(h)
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			created = append(created, provider.ClusterInfo{Provider: "gke", Name: req.Cluster.Name, Region: req.Zone, Handle: h})
+			continue
+		}
 
 		err = provider.RetryUntilTrue(
 			fmt.Sprintf("creating cluster:%v", req.Cluster.Name),
@@ -231,15 +396,417 @@ func (c *GKE) ClusterCreate(*kingpin.ParseContext) error {
 		if err != nil {
 			log.Fatalf("creating cluster err:%v", err)
 		}
+
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		if err := c.bindWorkloadIdentities(req.ProjectId); err != nil {
+			log.Fatalf("Couldn't bind the workload identities for cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if !exists {
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			c.recordProvisioning("create", req.Zone, req.Cluster, start)
+		}
+
+		if c.DeploymentResource.Bastion.Enabled {
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			if err := c.createBastion(req.ProjectId, req.Zone, req.Cluster.Name); err != nil {
+				log.Fatalf("Couldn't create the bastion of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+			}
+		}
+		if c.DeploymentResource.ScrapeFirewall.Enabled() {
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			if err := c.createScrapeFirewall(req.ProjectId, req.Zone, req.Cluster.Name); err != nil {
+				log.Fatalf("Couldn't open the scrape ports of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+			}
+		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		info, err := c.clusterInfo(req.Zone, req.ProjectId, req.Cluster.Name)
+		if err != nil {
+			log.Fatalf("Couldn't get the endpoint of cluster '%v', file:%v ,err: %v", req.Cluster.Name, deployment.FileName, err)
+		}
+		if info.ManagedPrometheus != nil {
+			info.ManagedPrometheus.Log(req.Cluster.Name)
+			info.ManagedPrometheus.SetVars(c.DeploymentVars)
+		}
+		created = append(created, info)
+	}
+	if c.DeploymentResource.InfoFile != "" {
+		if err := provider.WriteClusterInfo(c.DeploymentResource.InfoFile, created); err != nil {
+			log.Fatalf("Couldn't write the cluster info file: %v", err)
+		}
+	}
+	c.bootstrap()
+	return nil
+}
+
+// fanOut runs cluster create once per region of the fan-out, with the cluster name suffixed with the region.
+func (c *GKE) fanOut() error {
+	if len(c.Zones) > 0 {
+		return errors.New("--zone is specific to a single region and can't be used with --regions")
+	}
+	dr := c.DeploymentResource
+	clusterName := provider.MergeDeploymentVars(dr.DefaultDeploymentVars, dr.FlagDeploymentVars)["CLUSTER_NAME"]
+	f := dr.FanOut
+	f.NameSuffix = dr.ClusterNameSuffix
+	return provider.RunFanOut(f, "gke", clusterName, dr.InfoFile)
+}
+
+// clusterInfo returns the name, location and endpoint of the cluster for the info file,
+// and the managed Prometheus endpoints when it is enabled from the cli.
+func (c *GKE) clusterInfo(zone, projectID, clusterID string) (provider.ClusterInfo, error) {
+	cluster, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{
+		ProjectId: projectID,
+		Zone:      zone,
+		ClusterId: clusterID,
+	})
+	if err != nil {
+		return provider.ClusterInfo{}, errors.Wrapf(err, "getting cluster %q", clusterID)
+	}
+	info := provider.ClusterInfo{Provider: "gke", Name: cluster.Name, Region: cluster.Location, Endpoint: cluster.Endpoint}
+	if c.ManagedPrometheus {
+		// A reused cluster keeps the monitoring config it was created with.
+		if !cluster.GetMonitoringConfig().GetManagedPrometheusConfig().GetEnabled() {
+			return provider.ClusterInfo{}, errors.Errorf("managed Prometheus isn't enabled on cluster %q", clusterID)
+		}
+		mp := provider.GMPManagedPrometheus(projectID)
+		info.ManagedPrometheus = &mp
+	}
+	return info, nil
+}
+
+// bootstrap applies the bootstrap files passed from the cli to the created cluster.
+// Failures are reported separately as the cluster itself was created.
+func (c *GKE) bootstrap() {
+	files := c.DeploymentResource.BootstrapFiles
+	if len(files) == 0 {
+		return
+	}
+	if err := c.NewK8sProvider(nil); err != nil {
+		log.Fatalf("Bootstrap failed, the cluster was created: %v", err)
+	}
+	resources, err := k8sProvider.ParseFiles(files, c.DeploymentVars)
+	if err != nil {
+		log.Fatalf("Bootstrap failed, the cluster was created: couldn't parse the bootstrap files: %v", err)
+	}
+	log.Printf("Bootstrap started: applying %d file(s)", len(resources))
+	if err := c.k8sProvider.ResourceApply(resources); err != nil {
+		log.Fatalf("Bootstrap failed, the cluster was created: %v", err)
+	}
+	log.Printf("Bootstrap completed")
+}
+
+// applyClusterFlags sets the cluster options passed from the cli on the cluster request.
+// Options that are not set keep the values from the cluster deployment file.
+func (c *GKE) applyClusterFlags(zone string, cluster *containerpb.Cluster) error {
+	if err := c.addNodePools(cluster); err != nil {
+		return err
+	}
+	if err := c.addSystemNodePool(cluster); err != nil {
+		return err
+	}
+	if err := c.spreadZones(zone, cluster); err != nil {
+		return err
+	}
+	if err := c.enableAutoscaling(cluster); err != nil {
+		return err
+	}
+	if err := c.enableAutoProvisioning(cluster); err != nil {
+		return err
+	}
+
+	if c.ReleaseChannel != "" {
+		channel, ok := map[string]containerpb.ReleaseChannel_Channel{
+			"none":    containerpb.ReleaseChannel_UNSPECIFIED,
+			"rapid":   containerpb.ReleaseChannel_RAPID,
+			"regular": containerpb.ReleaseChannel_REGULAR,
+			"stable":  containerpb.ReleaseChannel_STABLE,
+		}[c.ReleaseChannel]
+		if !ok {
+			return fmt.Errorf("invalid release channel %q, must be one of rapid, regular, stable or none", c.ReleaseChannel)
+		}
+		cluster.ReleaseChannel = &containerpb.ReleaseChannel{Channel: channel}
+	}
+
+	if c.ClusterVersion != "" {
+		cluster.InitialClusterVersion = c.ClusterVersion
+		// Clusters enrolled in a release channel are always upgraded automatically.
+		if cluster.GetReleaseChannel().GetChannel() == containerpb.ReleaseChannel_UNSPECIFIED {
+			for _, pool := range cluster.NodePools {
+				if pool.Management == nil {
+					pool.Management = &containerpb.NodeManagement{}
+				}
+				pool.Management.AutoUpgrade = false
+			}
+		}
+	}
+
+	if c.ImageType != "" {
+		for _, pool := range cluster.NodePools {
+			if pool.Config == nil {
+				pool.Config = &containerpb.NodeConfig{}
+			}
+			pool.Config.ImageType = c.ImageType
+		}
+	}
+
+	c.tagScrapeNodes(cluster)
+
+	// Shielded nodes also make the control plane verify the identity of the nodes that join the cluster.
+	if c.ShieldedNodes {
+		cluster.ShieldedNodes = &containerpb.ShieldedNodes{Enabled: true}
+	}
+
+	// The legacy services can't be set together with the newer logging and monitoring configs.
+	switch c.Logging {
+	case "enabled":
+		cluster.LoggingService, cluster.LoggingConfig = "logging.googleapis.com/kubernetes", nil
+	case "disabled":
+		cluster.LoggingService, cluster.LoggingConfig = "none", nil
+	}
+	switch c.Monitoring {
+	case "enabled":
+		cluster.MonitoringService, cluster.MonitoringConfig = "monitoring.googleapis.com/kubernetes", nil
+	case "disabled":
+		cluster.MonitoringService, cluster.MonitoringConfig = "none", nil
+	}
+	if err := c.enableManagedPrometheus(cluster); err != nil {
+		return err
+	}
+
+	if err := c.setNetworkRanges(cluster); err != nil {
+		return err
+	}
+
+	if c.MaintenanceWindow != "" {
+		if _, err := time.Parse("15:04", c.MaintenanceWindow); err != nil {
+			return fmt.Errorf("invalid maintenance window start time %q, must be in the HH:MM format", c.MaintenanceWindow)
+		}
+		cluster.MaintenancePolicy = &containerpb.MaintenancePolicy{
+			Window: &containerpb.MaintenanceWindow{
+				Policy: &containerpb.MaintenanceWindow_DailyMaintenanceWindow{
+					DailyMaintenanceWindow: &containerpb.DailyMaintenanceWindow{StartTime: c.MaintenanceWindow},
+				},
+			},
+		}
+	}
+	return nil
+}
+
+// enableManagedPrometheus enables the managed collection of Google Managed Prometheus when it is enabled from the cli.
+// It is part of the newer monitoring config, so a legacy monitoring service is replaced with the system components monitoring.
+func (c *GKE) enableManagedPrometheus(cluster *containerpb.Cluster) error {
+	if !c.ManagedPrometheus {
+		return nil
+	}
+	if c.Monitoring == "disabled" {
+		return errors.New("managed Prometheus requires Cloud Monitoring and can't be used with --monitoring=disabled")
+	}
+	if cluster.MonitoringConfig == nil {
+		cluster.MonitoringService = ""
+		cluster.MonitoringConfig = &containerpb.MonitoringConfig{
+			ComponentConfig: &containerpb.MonitoringComponentConfig{
+				EnableComponents: []containerpb.MonitoringComponentConfig_Component{containerpb.MonitoringComponentConfig_SYSTEM_COMPONENTS},
+			},
+		}
+	}
+	cluster.MonitoringConfig.ManagedPrometheusConfig = &containerpb.ManagedPrometheusConfig{Enabled: true}
+	return nil
+}
+
+// setNetworkRanges sets the pod and service IP ranges and the max pods per node passed from the cli.
+// These require a VPC-native cluster, so IP aliases are enabled when any of them is set.
+func (c *GKE) setNetworkRanges(cluster *containerpb.Cluster) error {
+	if c.PodCIDR == "" && c.ServiceCIDR == "" && c.MaxPodsPerNode == 0 {
+		return nil
+	}
+	if err := provider.ValidateCIDRs(c.PodCIDR, c.ServiceCIDR); err != nil {
+		return err
+	}
+	if c.MaxPodsPerNode != 0 && (c.MaxPodsPerNode < 8 || c.MaxPodsPerNode > 256) {
+		return fmt.Errorf("invalid max pods per node %d, must be between 8 and 256", c.MaxPodsPerNode)
+	}
+
+	if cluster.IpAllocationPolicy == nil {
+		cluster.IpAllocationPolicy = &containerpb.IPAllocationPolicy{}
+	}
+	cluster.IpAllocationPolicy.UseIpAliases = true
+	if c.PodCIDR != "" {
+		cluster.IpAllocationPolicy.ClusterIpv4CidrBlock = c.PodCIDR
+	}
+	if c.ServiceCIDR != "" {
+		cluster.IpAllocationPolicy.ServicesIpv4CidrBlock = c.ServiceCIDR
+	}
+	if c.MaxPodsPerNode != 0 {
+		cluster.DefaultMaxPodsConstraint = &containerpb.MaxPodsConstraint{MaxPodsPerNode: c.MaxPodsPerNode}
+		for _, pool := range cluster.NodePools {
+			pool.MaxPodsConstraint = &containerpb.MaxPodsConstraint{MaxPodsPerNode: c.MaxPodsPerNode}
+		}
+	}
+	return nil
+}
+
+// checkNodeImages logs a warning for node image types that aren't compatible
+// with the control plane version of the cluster.
+// Pools without an image type use the GKE default, which is always compatible.
+func (c *GKE) checkNodeImages(req *containerpb.CreateClusterRequest) {
+	serverConfig, err := c.clientGKE.GetServerConfig(c.ctx, &containerpb.GetServerConfigRequest{
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		ProjectId: req.ProjectId,
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		Zone: req.Zone,
+	})
+	if err != nil {
+		log.Printf("WARNING: couldn't get the GKE server config to check the node images: %v", err)
+		return
+	}
+	version := req.Cluster.InitialClusterVersion
+	if version == "" || version == "latest" {
+		version = serverConfig.DefaultClusterVersion
+	}
+	valid := make(map[string]struct{}, len(serverConfig.ValidImageTypes))
+	for _, t := range serverConfig.ValidImageTypes {
+		valid[strings.ToUpper(t)] = struct{}{}
+	}
+	for _, pool := range req.Cluster.NodePools {
+		imageType := strings.ToUpper(pool.GetConfig().GetImageType())
+		if imageType == "" {
+			continue
+		}
+		if _, ok := valid[imageType]; !ok {
+			log.Printf("WARNING: node pool '%v' image type %v isn't one of the valid GKE image types %v", pool.Name, imageType, serverConfig.ValidImageTypes)
+		}
+		// Docker based node images are removed from GKE 1.24.
+		if (imageType == "COS" || imageType == "UBUNTU") && provider.KubernetesVersionAtLeast(version, 1, 24) {
+			log.Printf("WARNING: node pool '%v' image type %v isn't supported by the control plane version %v, use %v_CONTAINERD instead", pool.Name, imageType, version, imageType)
+		}
+	}
+}
+
+// addNodePools appends the node pools passed from the cli to the cluster request
+// and checks that all node pool names are unique.
+func (c *GKE) addNodePools(cluster *containerpb.Cluster) error {
+	for _, spec := range c.NodePools {
+		cluster.NodePools = append(cluster.NodePools, gkeNodePool(spec))
+	}
+	return validateNodePoolNames(cluster)
+}
+
+// addSystemNodePool adds the untainted system node pool passed from the cli for the kube-system workloads
+// and sets the benchmark label and taint on all other node pools, so only the pods that tolerate it run on them.
+// Pools that already have a taint with the same key keep it.
+func (c *GKE) addSystemNodePool(cluster *containerpb.Cluster) error {
+	if c.SystemNodePool == "" {
+		return nil
+	}
+	spec, err := provider.ParseSystemNodePool(c.SystemNodePool)
+	if err != nil {
+		return err
+	}
+	for _, pool := range cluster.NodePools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		if pool.Config.Labels == nil {
+			pool.Config.Labels = map[string]string{}
+		}
+		if _, ok := pool.Config.Labels[provider.DedicatedKey]; !ok {
+			pool.Config.Labels[provider.DedicatedKey] = provider.DedicatedBenchmark
+		}
+		tainted := false
+		for _, t := range pool.Config.Taints {
+			if t.Key == provider.BenchmarkTaint.Key {
+				tainted = true
+				break
+			}
+		}
+		if !tainted {
+			pool.Config.Taints = append(pool.Config.Taints, &containerpb.NodeTaint{
+				Key:    provider.BenchmarkTaint.Key,
+				Value:  provider.BenchmarkTaint.Value,
+				Effect: gkeTaintEffect(provider.BenchmarkTaint.Effect),
+			})
+		}
+	}
+	cluster.NodePools = append(cluster.NodePools, gkeNodePool(spec))
+	log.Printf("Adding the system node pool %q, the other %d node pool(s) get the taint %v=%v:%v", spec.Name, len(cluster.NodePools)-1, provider.BenchmarkTaint.Key, provider.BenchmarkTaint.Value, provider.BenchmarkTaint.Effect)
+	return validateNodePoolNames(cluster)
+}
+
+// gkeNodePool converts a node pool definition from the cli to a GKE node pool.
+func gkeNodePool(spec provider.NodePoolSpec) *containerpb.NodePool {
+	pool := &containerpb.NodePool{
+		Name:             spec.Name,
+		InitialNodeCount: spec.Count,
+		Config: &containerpb.NodeConfig{
+			MachineType: spec.MachineType,
+			Labels:      spec.Labels,
+		},
+	}
+	for _, t := range spec.Taints {
+		pool.Config.Taints = append(pool.Config.Taints, &containerpb.NodeTaint{
+			Key:    t.Key,
+			Value:  t.Value,
+			Effect: gkeTaintEffect(t.Effect),
+		})
+	}
+	return pool
+}
+
+// validateNodePoolNames checks that all node pool names of the cluster are unique.
+func validateNodePoolNames(cluster *containerpb.Cluster) error {
+	names := make([]string, 0, len(cluster.NodePools))
+	for _, pool := range cluster.NodePools {
+		names = append(names, pool.Name)
+	}
+	return provider.ValidateNodePoolNames(names)
+}
+
+// enableAutoscaling enables the cluster autoscaler for the node pools passed from the cli.
+func (c *GKE) enableAutoscaling(cluster *containerpb.Cluster) error {
+	for _, a := range c.Autoscaling {
+		var pool *containerpb.NodePool
+		for _, p := range cluster.NodePools {
+			if p.Name == a.Name {
+				pool = p
+				break
+			}
+		}
+		if pool == nil {
+			return fmt.Errorf("autoscaling is set for node pool %q that is not part of the cluster", a.Name)
+		}
+		if err := a.Contains(pool.InitialNodeCount); err != nil {
+			return err
+		}
+		pool.Autoscaling = &containerpb.NodePoolAutoscaling{
+			Enabled:      true,
+			MinNodeCount: a.Min,
+			MaxNodeCount: a.Max,
+		}
 	}
 	return nil
 }
 
+// gkeTaintEffect converts a k8s taint effect to the GKE api enum.
+func gkeTaintEffect(effect string) containerpb.NodeTaint_Effect {
+	switch effect {
+	case provider.TaintEffectNoSchedule:
+		return containerpb.NodeTaint_NO_SCHEDULE
+	case provider.TaintEffectPreferNoSchedule:
+		return containerpb.NodeTaint_PREFER_NO_SCHEDULE
+	case provider.TaintEffectNoExecute:
+		return containerpb.NodeTaint_NO_EXECUTE
+	}
+	return containerpb.NodeTaint_EFFECT_UNSPECIFIED
+}
+
 // ClusterDelete deletes a k8s cluster.
 func (c *GKE) ClusterDelete(*kingpin.ParseContext) error {
 	// Use CreateClusterRequest struct to pass the UnmarshalStrict validation and
 	// than use the result to create the DeleteClusterRequest
 	reqC := &containerpb.CreateClusterRequest{}
+	if err := c.DeploymentResource.RunSummary.Validate(); err != nil {
+		log.Fatalf("Invalid run summary flags: %v", err)
+	}
+	var summaries []provider.RunSummary
 	for _, deployment := range c.gkeResources {
 		if err := yamlGo.UnmarshalStrict(deployment.Content, reqC); err != nil {
 			log.Fatalf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
@@ -253,10 +820,20 @@ func (c *GKE) ClusterDelete(*kingpin.ParseContext) error {
 			ClusterId: reqC.Cluster.Name,
 		}
 		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		if err := c.removeDeletionProtection(reqD.ProjectId, reqD.Zone, reqD.ClusterId); err != nil {
+			log.Fatalf("Couldn't delete the cluster: %v", err)
+		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		live, err := c.clusterForSummary(reqD.ProjectId, reqD.Zone, reqD.ClusterId)
+		if err != nil {
+			log.Fatalf("Couldn't get the cluster for the run summary: %v", err)
+		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
 		log.Printf("Removing cluster '%v', project '%v', zone '%v'", reqD.ClusterId, reqD.ProjectId, reqD.Zone)
+		start := time.Now()
 
 		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-		err := provider.RetryUntilTrue(
+		err = provider.RetryUntilTrue(
 			fmt.Sprintf("deleting cluster:%v", reqD.ClusterId),
 			provider.GlobalRetryCount,
 			func() (bool, error) { return c.clusterDeleted(reqD) })
@@ -264,10 +841,61 @@ func (c *GKE) ClusterDelete(*kingpin.ParseContext) error {
 		if err != nil {
 			log.Fatalf("removing cluster err:%v", err)
 		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		c.recordProvisioning("delete", reqD.Zone, reqC.Cluster, start)
+		if live != nil {
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			summary, err := c.runSummary(live, reqD.Zone, time.Now())
+			if err != nil {
+				log.Fatalf("Couldn't summarize the cluster: %v", err)
+			}
+			summaries = append(summaries, summary)
+		}
+
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		if err := c.deleteBastion(reqD.ProjectId, reqD.ClusterId); err != nil {
+			log.Fatalf("removing the bastion err:%v", err)
+		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		if err := c.deleteScrapeFirewall(reqD.ProjectId, reqD.ClusterId); err != nil {
+			log.Fatalf("removing the scrape firewall rule err:%v", err)
+		}
+		if c.DeploymentResource.KeepDependents {
+			continue
+		}
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		if err := c.removeDependents(reqD.ProjectId, reqD.ClusterId); err != nil {
+			log.Fatalf("removing the dependent resources err:%v", err)
+		}
+	}
+	if c.DeploymentResource.RunSummary.Enabled() {
+		if err := provider.WriteRunSummaries(c.DeploymentResource.RunSummary.File, summaries); err != nil {
+			log.Fatalf("Couldn't write the run summary file: %v", err)
+		}
+		log.Printf("Wrote the run summary of %d cluster(s) to %v", len(summaries), c.DeploymentResource.RunSummary.File)
 	}
 	return nil
 }
 
+// recordProvisioning records the duration of a cluster create or delete with the nodes of all its node pools.
+func (c *GKE) recordProvisioning(operation, zone string, cluster *containerpb.Cluster, start time.Time) {
+	zones := len(cluster.Locations)
+	if zones == 0 {
+		zones = 1
+	}
+	nodes := 0
+	for _, pool := range cluster.NodePools {
+		nodes += int(pool.InitialNodeCount) * zones
+	}
+	provider.RecordProvisioning(c.DeploymentResource.Provisioning, provider.ProvisioningResult{
+		Operation: operation,
+		Provider:  "gke",
+		Cluster:   cluster.Name,
+		Region:    zone,
+		Nodes:     nodes,
+	}, start)
+}
+
 // clusterDeleted checks whether a cluster has been deleted.
 func (c *GKE) clusterDeleted(req *containerpb.DeleteClusterRequest) (bool, error) {
 	rep, err := c.clientGKE.DeleteCluster(c.ctx, req)
@@ -291,6 +919,26 @@ func (c *GKE) clusterDeleted(req *containerpb.DeleteClusterRequest) (bool, error
 }
 
 // clusterRunning checks whether a cluster is in a running state.
+// clusterExists reports whether the cluster already exists, so that a re-run with the same cluster name suffix
+// reuses it instead of failing. Without a suffix the cluster is always created.
+func (c *GKE) clusterExists(zone, projectID, clusterID string) (bool, error) {
+	if c.DeploymentResource.ClusterNameSuffix == "" {
+		return false, nil
+	}
+	_, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{
+		ProjectId: projectID,
+		Zone:      zone,
+		ClusterId: clusterID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "getting cluster %q", clusterID)
+	}
+	return true, nil
+}
+
 func (c *GKE) clusterRunning(zone, projectID, clusterID string) (bool, error) {
 	req := &containerpb.GetClusterRequest{
 		ProjectId: projectID,
@@ -320,51 +968,77 @@ func (c *GKE) clusterRunning(zone, projectID, clusterID string) (bool, error) {
 
 // NodePoolCreate creates a new k8s node-pool in an existing cluster.
 func (c *GKE) NodePoolCreate(*kingpin.ParseContext) error {
+	if err := c.checkNodeServiceAccount(); err != nil {
+		log.Fatalf("Invalid node service account: %v", err)
+	}
 	reqC := &containerpb.CreateClusterRequest{}
 
 	for _, deployment := range c.gkeResources {
-		if err := yamlGo.UnmarshalStrict(deployment.Content, reqC); err != nil {
-			log.Fatalf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
+		c.parseNodePools(reqC, deployment)
+		for _, node := range reqC.Cluster.NodePools {
+			c.createNodePool(reqC, node, deployment.FileName)
 		}
+	}
+	return nil
+}
 
-		for _, node := range reqC.Cluster.NodePools {
-			reqN := &containerpb.CreateNodePoolRequest{
-				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-				ProjectId: reqC.ProjectId,
-				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-				Zone: reqC.Zone,
-				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-				ClusterId: reqC.Cluster.Name,
-				NodePool:  node,
-			}
-			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-			log.Printf("Cluster nodepool create request: cluster '%v', nodepool '%v' , project `%s`,zone `%s`", reqN.ClusterId, reqN.NodePool.Name, reqN.ProjectId, reqN.Zone)
+// parseNodePools parses the cluster deployment file into the request
+// and applies the node options of the cli to its node pools.
+func (c *GKE) parseNodePools(reqC *containerpb.CreateClusterRequest, deployment Resource) {
+	if err := yamlGo.UnmarshalStrict(deployment.Content, reqC); err != nil {
+		log.Fatalf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
+	}
+	c.setNodeServiceAccount(reqC.Cluster.NodePools)
+	if err := c.setSSHKey(reqC.Cluster.NodePools); err != nil {
+		log.Fatalf("Error setting the ssh key of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+	}
+	if err := c.setNodeStartup(reqC.Cluster.NodePools); err != nil {
+		log.Fatalf("Error setting the node startup options of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+	}
+	if err := c.setNodeSecurity(reqC.Cluster.NodePools); err != nil {
+		log.Fatalf("Error setting the node security options of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+	}
+	if err := c.setNodeSystemConfig(reqC.Cluster.NodePools); err != nil {
+		log.Fatalf("Error setting the node system config of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+	}
+}
 
-			err := provider.RetryUntilTrue(
-				fmt.Sprintf("nodepool creation:%v", reqN.NodePool.Name),
-				provider.GlobalRetryCount,
-				func() (bool, error) {
-					return c.nodePoolCreated(reqN)
-				})
+// createNodePool creates the node pool in the cluster of the request and waits until it is running.
+func (c *GKE) createNodePool(reqC *containerpb.CreateClusterRequest, node *containerpb.NodePool, fileName string) {
+	reqN := &containerpb.CreateNodePoolRequest{
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		ProjectId: reqC.ProjectId,
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		Zone: reqC.Zone,
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		ClusterId: reqC.Cluster.Name,
+		NodePool:  node,
+	}
+	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+	log.Printf("Cluster nodepool create request: cluster '%v', nodepool '%v' , project `%s`,zone `%s`", reqN.ClusterId, reqN.NodePool.Name, reqN.ProjectId, reqN.Zone)
 
-			if err != nil {
-				log.Fatalf("Couldn't create cluster nodepool '%v', file:%v ,err: %v", node.Name, deployment.FileName, err)
-			}
+	err := provider.RetryUntilTrue(
+		fmt.Sprintf("nodepool creation:%v", reqN.NodePool.Name),
+		provider.GlobalRetryCount,
+		func() (bool, error) {
+			return c.nodePoolCreated(reqN)
+		})
 
-			err = provider.RetryUntilTrue(
-				fmt.Sprintf("checking nodepool running status for:%v", reqN.NodePool.Name),
-				provider.GlobalRetryCount,
-				func() (bool, error) {
-					//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-					return c.nodePoolRunning(reqN.Zone, reqN.ProjectId, reqN.ClusterId, reqN.NodePool.Name)
-				})
+	if err != nil {
+		log.Fatalf("Couldn't create cluster nodepool '%v', file:%v ,err: %v", node.Name, fileName, err)
+	}
 
-			if err != nil {
-				log.Fatalf("Couldn't create cluster nodepool '%v', file:%v ,err: %v", node.Name, deployment.FileName, err)
-			}
-		}
+	err = provider.RetryUntilTrue(
+		fmt.Sprintf("checking nodepool running status for:%v", reqN.NodePool.Name),
+		provider.GlobalRetryCount,
+		func() (bool, error) {
+			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+			return c.nodePoolRunning(reqN.Zone, reqN.ProjectId, reqN.ClusterId, reqN.NodePool.Name)
+		})
+
+	if err != nil {
+		log.Fatalf("Couldn't create cluster nodepool '%v', file:%v ,err: %v", node.Name, fileName, err)
 	}
-	return nil
 }
 
 // nodePoolCreated checks if there is any ongoing NodePool operation on the cluster
@@ -390,7 +1064,9 @@ func (c *GKE) nodePoolCreated(req *containerpb.CreateNodePoolRequest) (bool, err
 	return true, nil
 }
 
-// NodePoolDelete deletes a new k8s node-pool in an existing cluster.
+// NodePoolDelete deletes k8s node-pools in an existing cluster.
+// When node pool names are set only these pools are deleted, otherwise all pools from the deployment file.
+// Deleting the last remaining pools of the cluster requires the force option.
 func (c *GKE) NodePoolDelete(*kingpin.ParseContext) error {
 	// Use CreateNodePoolRequest struct to pass the UnmarshalStrict validation and
 	// than use the result to create the DeleteNodePoolRequest
@@ -401,32 +1077,71 @@ func (c *GKE) NodePoolDelete(*kingpin.ParseContext) error {
 			log.Fatalf("Error parsing the cluster deployment file %s:%v", deployment.FileName, err)
 		}
 
-		for _, node := range reqC.Cluster.NodePools {
-			reqD := &containerpb.DeleteNodePoolRequest{
-				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-				ProjectId: reqC.ProjectId,
-				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-				Zone:       reqC.Zone,
-				ClusterId:  reqC.Cluster.Name,
-				NodePoolId: node.Name,
+		names := c.NodePoolNames
+		if len(names) == 0 {
+			for _, node := range reqC.Cluster.NodePools {
+				names = append(names, node.Name)
 			}
-			//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-			log.Printf("Removing cluster node pool: `%v`,  cluster '%v', project '%v', zone '%v'", reqD.NodePoolId, reqD.ClusterId, reqD.ProjectId, reqD.Zone)
+		}
 
-			err := provider.RetryUntilTrue(
-				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
-				fmt.Sprintf("deleting nodepool:%v", reqD.NodePoolId),
-				provider.GlobalRetryCount,
-				func() (bool, error) { return c.nodePoolDeleted(reqD) })
+		existing, err := c.listNodePools(reqC)
+		if err != nil {
+			log.Fatalf("Couldn't list the node pools of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+		}
+		if err := provider.ValidateNodePoolDelete(existing, names, c.Force); err != nil {
+			log.Fatalf("Couldn't delete node pools of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+		}
 
-			if err != nil {
-				log.Fatalf("Couldn't delete cluster nodepool '%v', file:%v ,err: %v", node.Name, deployment.FileName, err)
-			}
+		for _, name := range names {
+			c.deleteNodePool(reqC, name, deployment.FileName)
 		}
 	}
 	return nil
 }
 
+// listNodePools returns the names of the node pools of the cluster of the request.
+func (c *GKE) listNodePools(reqC *containerpb.CreateClusterRequest) ([]string, error) {
+	pools, err := c.clientGKE.ListNodePools(c.ctx, &containerpb.ListNodePoolsRequest{
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		ProjectId: reqC.ProjectId,
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		Zone:      reqC.Zone,
+		ClusterId: reqC.Cluster.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]string, 0, len(pools.NodePools))
+	for _, pool := range pools.NodePools {
+		existing = append(existing, pool.Name)
+	}
+	return existing, nil
+}
+
+// deleteNodePool deletes the node pool from the cluster of the request and waits until it is gone.
+func (c *GKE) deleteNodePool(reqC *containerpb.CreateClusterRequest, name, fileName string) {
+	reqD := &containerpb.DeleteNodePoolRequest{
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		ProjectId: reqC.ProjectId,
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		Zone:       reqC.Zone,
+		ClusterId:  reqC.Cluster.Name,
+		NodePoolId: name,
+	}
+	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+	log.Printf("Removing cluster node pool: `%v`,  cluster '%v', project '%v', zone '%v'", reqD.NodePoolId, reqD.ClusterId, reqD.ProjectId, reqD.Zone)
+
+	err := provider.RetryUntilTrue(
+		//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+		fmt.Sprintf("deleting nodepool:%v", reqD.NodePoolId),
+		provider.GlobalRetryCount,
+		func() (bool, error) { return c.nodePoolDeleted(reqD) })
+
+	if err != nil {
+		log.Fatalf("Couldn't delete cluster nodepool '%v', file:%v ,err: %v", name, fileName, err)
+	}
+}
+
 // nodePoolDeleted checks whether a nodepool has been deleted.
 func (c *GKE) nodePoolDeleted(req *containerpb.DeleteNodePoolRequest) (bool, error) {
 
@@ -536,6 +1251,17 @@ func (c *GKE) AllNodepoolsDeleted(*kingpin.ParseContext) error {
 
 // NewK8sProvider sets the k8s provider used for deploying k8s manifests.
 func (c *GKE) NewK8sProvider(*kingpin.ParseContext) error {
+	k, err := c.K8sClient()
+	if err != nil {
+		log.Fatalf("k8s provider error: %v", err)
+	}
+	c.k8sProvider = k
+	return nil
+}
+
+// K8sClient returns a k8s client for the cluster with the credentials from the GKE API,
+// so the manifests can be applied right after creating the cluster without a kubeconfig file.
+func (c *GKE) K8sClient() (*k8sProvider.K8s, error) {
 	// Get the authentication certificate for the cluster using the GKE client.
 	req := &containerpb.GetClusterRequest{
 		ProjectId: c.DeploymentVars["GKE_PROJECT_ID"],
@@ -544,18 +1270,19 @@ func (c *GKE) NewK8sProvider(*kingpin.ParseContext) error {
 	}
 	rep, err := c.clientGKE.GetCluster(c.ctx, req)
 	if err != nil {
-		log.Fatalf("failed to get cluster details: %v", err)
+		return nil, errors.Wrapf(err, "failed to get cluster details")
 	}
 
 	// The master auth retrieved from GCP it is base64 encoded so it must be decoded first.
 	caCert, err := base64.StdEncoding.DecodeString(rep.MasterAuth.GetClusterCaCertificate())
 	if err != nil {
-		log.Fatalf("failed to decode certificate: %v", err.Error())
+		return nil, errors.Wrapf(err, "failed to decode certificate")
 	}
 
 	cluster := clientcmdapi.NewCluster()
 	cluster.CertificateAuthorityData = []byte(caCert)
 	cluster.Server = fmt.Sprintf("https://%v", rep.Endpoint)
+	cluster.ProxyURL = c.DeploymentResource.K8sProxy
 
 	context := clientcmdapi.NewContext()
 	context.Cluster = rep.Name
@@ -572,6 +1299,17 @@ func (c *GKE) NewK8sProvider(*kingpin.ParseContext) error {
 		},
 	}
 
+	if c.tokenSource != nil {
+		// The gcp auth provider reads the auth file, the k8s requests have to use the impersonated service account as well.
+		// The access token expires after an hour.
+		tok, err := c.tokenSource.Token()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the token of the impersonated service account")
+		}
+		authInfo.AuthProvider = nil
+		authInfo.Token = tok.AccessToken
+	}
+
 	config := clientcmdapi.NewConfig()
 	config.Clusters[rep.Name] = cluster
 	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
@@ -581,18 +1319,45 @@ func (c *GKE) NewK8sProvider(*kingpin.ParseContext) error {
 	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
 	config.CurrentContext = rep.Zone
 
-	c.k8sProvider, err = k8sProvider.New(c.ctx, config)
-	if err != nil {
-		log.Fatal("k8s provider error", err)
-	}
-	return nil
+	return k8sProvider.NewForDeployment(c.ctx, config, c.DeploymentResource)
 }
 
 // ResourceApply calls k8s.ResourceApply to apply the k8s objects in the manifest files.
 func (c *GKE) ResourceApply(*kingpin.ParseContext) error {
-	if err := c.k8sProvider.ResourceApply(c.k8sResources); err != nil {
+	if err := c.assignStaticIPs(); err != nil {
+		log.Fatalf("error assigning the static IPs: %v", err)
+	}
+	disks, err := c.existingDisks()
+	if err != nil {
+		log.Fatalf("error getting the existing disks: %v", err)
+	}
+	if err := c.k8sProvider.ApplyExistingDisks(disks); err != nil {
+		log.Fatalf("error applying the volumes of the existing disks: %v", err)
+	}
+	if err := c.k8sProvider.ApplyAndWaitEstablished(c.k8sResources, c.DeploymentResource.EstablishTimeout); err != nil {
 		log.Fatal("error while applying a resource err:", err)
 	}
+	if err := c.upsertDNSRecords(); err != nil {
+		log.Fatalf("error updating the DNS records: %v", err)
+	}
+	if c.DeploymentResource.PruneDryRun {
+		out, err := c.k8sProvider.PruneDryRun(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds)
+		if err != nil {
+			log.Fatal("error while listing the objects to prune err:", err)
+		}
+		if _, err := os.Stdout.Write(out); err != nil {
+			log.Fatal("error while writing the objects to prune err:", err)
+		}
+	} else if c.DeploymentResource.Prune {
+		if err := c.k8sProvider.Prune(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds); err != nil {
+			log.Fatal("error while pruning objects err:", err)
+		}
+	}
+	if c.DeploymentResource.ReconcileInterval > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.k8sProvider.ReconcileLoop(ctx, c.k8sResources, c.DeploymentResource.ReconcileInterval)
+	}
 	return nil
 }
 
@@ -601,9 +1366,127 @@ func (c *GKE) ResourceDelete(*kingpin.ParseContext) error {
 	if err := c.k8sProvider.ResourceDelete(c.k8sResources); err != nil {
 		log.Fatal("error while deleting objects from a manifest file err:", err)
 	}
+	if err := c.k8sProvider.DeleteExistingDisks(c.existingDiskNames()); err != nil {
+		log.Fatalf("error deleting the volumes of the existing disks: %v", err)
+	}
+	if err := c.releaseStaticIPs(); err != nil {
+		log.Fatalf("error releasing the static IPs: %v", err)
+	}
+	if err := c.deleteDNSRecords(); err != nil {
+		log.Fatalf("error deleting the DNS records: %v", err)
+	}
 	return nil
 }
 
+// ResourceStatus calls k8s.Status to print the readiness of the k8s objects in the manifest files,
+// it fails when an object is missing or not ready.
+func (c *GKE) ResourceStatus(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Status(c.k8sResources)
+	if _, werr := os.Stdout.Write(out); werr != nil {
+		log.Fatal("error while writing the status err:", werr)
+	}
+	if err != nil {
+		log.Fatal("error while checking the status of the objects err:", err)
+	}
+	return nil
+}
+
+// Describe calls k8s.Describe to print the live object as YAML.
+func (c *GKE) Describe(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Describe(c.DeploymentResource.DescribeObject, c.DeploymentResource.DescribeNamespace, c.DeploymentResource.ShowManagedFields)
+	if err != nil {
+		log.Fatal("error while describing an object err:", err)
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// FieldOwners calls k8s.FieldOwners to print the field managers of the object and the fields they own.
+func (c *GKE) FieldOwners(*kingpin.ParseContext) error {
+	kind, name, _ := strings.Cut(c.DeploymentResource.FieldOwnersObject, "/")
+	owners, err := c.k8sProvider.FieldOwners(kind, c.DeploymentResource.FieldOwnersNamespace, name)
+	if err != nil {
+		log.Fatal("error while getting the field owners err:", err)
+	}
+	_, err = os.Stdout.Write(k8sProvider.FormatFieldOwners(owners))
+	return err
+}
+
+// Wait calls k8s.WaitForObjectCondition to block until the object has the status condition.
+func (c *GKE) Wait(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.WaitForObjectCondition(dr.WaitObject, dr.WaitNamespace, dr.WaitCondition, dr.WaitTimeout); err != nil {
+		log.Fatal("error while waiting for the condition err:", err)
+	}
+	return nil
+}
+
+// Label calls k8s.Label to add the labels to the selected live objects.
+func (c *GKE) Label(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.Label(dr.MetadataObject, dr.MetadataSelector, dr.MetadataNamespace, dr.Labels); err != nil {
+		log.Fatal("error while labeling the objects err:", err)
+	}
+	return nil
+}
+
+// Annotate calls k8s.Annotate to add the annotations to the selected live objects.
+func (c *GKE) Annotate(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.Annotate(dr.MetadataObject, dr.MetadataSelector, dr.MetadataNamespace, dr.Annotations); err != nil {
+		log.Fatal("error while annotating the objects err:", err)
+	}
+	return nil
+}
+
+// Cordon calls k8s.CordonNode to mark the node unschedulable.
+func (c *GKE) Cordon(*kingpin.ParseContext) error {
+	if err := c.k8sProvider.CordonNode(c.DeploymentResource.NodeName); err != nil {
+		log.Fatal("error while cordoning the node err:", err)
+	}
+	return nil
+}
+
+// Uncordon calls k8s.UncordonNode to mark the node schedulable again.
+func (c *GKE) Uncordon(*kingpin.ParseContext) error {
+	if err := c.k8sProvider.UncordonNode(c.DeploymentResource.NodeName); err != nil {
+		log.Fatal("error while uncordoning the node err:", err)
+	}
+	return nil
+}
+
+// Drain calls k8s.DrainNode to cordon the node and evict its pods.
+func (c *GKE) Drain(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.DrainNode(dr.NodeName, dr.DrainTimeout); err != nil {
+		log.Fatal("error while draining the node err:", err)
+	}
+	return nil
+}
+
+// Logs calls k8s.PodLogs to print the logs of the pod, or k8s.StreamPodLogs to follow them until interrupted.
+func (c *GKE) Logs(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if !dr.FollowLogs {
+		out, err := c.k8sProvider.PodLogs(dr.LogsNamespace, dr.LogsPod, dr.LogsContainer)
+		if err != nil {
+			log.Fatal("error while getting the logs err:", err)
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logs, err := c.k8sProvider.StreamPodLogs(ctx, dr.LogsNamespace, dr.LogsPod, dr.LogsContainer)
+	if err != nil {
+		log.Fatal("error while getting the logs err:", err)
+	}
+	defer logs.Close()
+	_, err = io.Copy(os.Stdout, logs)
+	return err
+}
+
 // GetDeploymentVars shows deployment variables.
 func (c *GKE) GetDeploymentVars(parseContext *kingpin.ParseContext) error {
 	fmt.Print("-------------------\n   DeploymentVars   \n------------------- \n")
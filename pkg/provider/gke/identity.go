@@ -0,0 +1,161 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	iam "google.golang.org/api/iam/v1"
+)
+
+const workloadIdentityUserRole = "roles/iam.workloadIdentityUser"
+
+// enableWorkloadIdentity enables Workload Identity for the cluster and all its node pools.
+func (c *GKE) enableWorkloadIdentity(req *containerpb.CreateClusterRequest) error {
+	if !c.WorkloadIdentity {
+		if len(c.WorkloadIdentityBindings) > 0 {
+			return errors.New("workload identity bindings require --workload-identity")
+		}
+		return nil
+	}
+	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+	req.Cluster.WorkloadIdentityConfig = &containerpb.WorkloadIdentityConfig{WorkloadPool: workloadPool(req.ProjectId)}
+	for _, pool := range req.Cluster.NodePools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		pool.Config.WorkloadMetadataConfig = &containerpb.WorkloadMetadataConfig{Mode: containerpb.WorkloadMetadataConfig_GKE_METADATA}
+	}
+	return nil
+}
+
+// bindWorkloadIdentities allows the k8s service accounts to act as their GCP service accounts
+// by granting them the workload identity user role on the GCP service account.
+// The k8s service accounts still need the iam.gke.io/gcp-service-account annotation.
+func (c *GKE) bindWorkloadIdentities(projectID string) error {
+	if len(c.WorkloadIdentityBindings) == 0 {
+		return nil
+	}
+	svc, err := iam.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the iam client")
+	}
+	for _, b := range c.WorkloadIdentityBindings {
+		resource := "projects/-/serviceAccounts/" + b.CloudIdentity
+		member := fmt.Sprintf("serviceAccount:%v[%v/%v]", workloadPool(projectID), b.Namespace, b.ServiceAccount)
+
+		policy, err := svc.Projects.ServiceAccounts.GetIamPolicy(resource).Context(c.ctx).Do()
+		if err != nil {
+			return iamError(err, b.CloudIdentity)
+		}
+		if !addPolicyMember(policy, workloadIdentityUserRole, member) {
+			log.Printf("Workload identity binding already exists: %v", b)
+			continue
+		}
+		if _, err := svc.Projects.ServiceAccounts.SetIamPolicy(resource, &iam.SetIamPolicyRequest{Policy: policy}).Context(c.ctx).Do(); err != nil {
+			return iamError(err, b.CloudIdentity)
+		}
+		log.Printf("Workload identity binding created: %v, annotate the k8s service account with iam.gke.io/gcp-service-account: %v", b, b.CloudIdentity)
+	}
+	return nil
+}
+
+// addPolicyMember adds the member to the role in the policy and returns false when it is already there.
+func addPolicyMember(policy *iam.Policy, role, member string) bool {
+	for _, binding := range policy.Bindings {
+		if binding.Role != role {
+			continue
+		}
+		for _, m := range binding.Members {
+			if m == member {
+				return false
+			}
+		}
+		binding.Members = append(binding.Members, member)
+		return true
+	}
+	policy.Bindings = append(policy.Bindings, &iam.Binding{Role: role, Members: []string{member}})
+	return true
+}
+
+// iamError adds a hint about the required permissions to iam errors.
+func iamError(err error, serviceAccount string) error {
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		switch gErr.Code {
+		case http.StatusForbidden:
+			return errors.Wrapf(err, "not allowed to change the iam policy of service account %v, the auth service account needs the iam.serviceAccounts.getIamPolicy and iam.serviceAccounts.setIamPolicy permissions on it, e.g. with the roles/iam.serviceAccountAdmin role", serviceAccount)
+		case http.StatusNotFound:
+			return errors.Wrapf(err, "service account %v doesn't exist, create it before binding it to a k8s service account", serviceAccount)
+		}
+	}
+	return errors.Wrapf(err, "updating the iam policy of service account %v", serviceAccount)
+}
+
+// setNodeServiceAccount sets the node service account passed from the cli on the node pools,
+// instead of the Compute Engine default service account.
+func (c *GKE) setNodeServiceAccount(pools []*containerpb.NodePool) {
+	if c.NodeServiceAccount == "" {
+		return
+	}
+	for _, pool := range pools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		pool.Config.ServiceAccount = c.NodeServiceAccount
+	}
+}
+
+// checkNodeServiceAccount checks that the node service account exists and is enabled,
+// so a typo fails before the node pools are created instead of when the nodes can't start.
+func (c *GKE) checkNodeServiceAccount() error {
+	if c.NodeServiceAccount == "" {
+		return nil
+	}
+	if !strings.Contains(c.NodeServiceAccount, "@") {
+		return errors.Errorf("invalid node service account %q, expected the email of the service account, e.g. prombench-nodes@project.iam.gserviceaccount.com", c.NodeServiceAccount)
+	}
+	svc, err := iam.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the iam client")
+	}
+	sa, err := svc.Projects.ServiceAccounts.Get("projects/-/serviceAccounts/" + c.NodeServiceAccount).Context(c.ctx).Do()
+	if err != nil {
+		var gErr *googleapi.Error
+		if errors.As(err, &gErr) {
+			switch gErr.Code {
+			case http.StatusForbidden:
+				return errors.Wrapf(err, "not allowed to get the node service account %v, the auth service account needs the iam.serviceAccounts.get permission on it", c.NodeServiceAccount)
+			case http.StatusNotFound:
+				return errors.Wrapf(err, "node service account %v doesn't exist, create it before creating the node pools", c.NodeServiceAccount)
+			}
+		}
+		return errors.Wrapf(err, "getting the node service account %v", c.NodeServiceAccount)
+	}
+	if sa.Disabled {
+		return errors.Errorf("node service account %v is disabled", c.NodeServiceAccount)
+	}
+	log.Printf("Node pools run as the service account %v", c.NodeServiceAccount)
+	return nil
+}
+
+func workloadPool(projectID string) string {
+	return projectID + ".svc.id.goog"
+}
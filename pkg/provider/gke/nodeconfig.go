@@ -0,0 +1,70 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// gkeNodeSysctls are the NodeSysctls GKE sets through the Linux node config.
+var gkeNodeSysctls = []string{
+	"net.core.busy_poll",
+	"net.core.busy_read",
+	"net.core.netdev_max_backlog",
+	"net.core.optmem_max",
+	"net.core.rmem_max",
+	"net.core.somaxconn",
+	"net.core.wmem_default",
+	"net.core.wmem_max",
+	"net.ipv4.tcp_rmem",
+	"net.ipv4.tcp_tw_reuse",
+	"net.ipv4.tcp_wmem",
+}
+
+// setNodeSystemConfig sets the kubelet and kernel config of the node system config file passed from the cli on the node pools.
+// GKE sets the pod pids limit and the cpu manager policy through the kubelet config, and only the gkeNodeSysctls.
+// It doesn't support the eviction thresholds of the kubelet.
+func (c *GKE) setNodeSystemConfig(pools []*containerpb.NodePool) error {
+	if c.NodeSystemConfig == "" {
+		return nil
+	}
+	cfg, err := provider.LoadNodeSystemConfig(c.NodeSystemConfig)
+	if err != nil {
+		return err
+	}
+	if len(cfg.EvictionHard) > 0 {
+		return errors.New("GKE doesn't support the eviction thresholds of the kubelet, remove evictionHard from the node system config")
+	}
+	if err := cfg.CheckSysctls("GKE", gkeNodeSysctls); err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		if cfg.PodPidsLimit > 0 || cfg.CPUManagerPolicy != "" {
+			pool.Config.KubeletConfig = &containerpb.NodeKubeletConfig{
+				PodPidsLimit:     cfg.PodPidsLimit,
+				CpuManagerPolicy: cfg.CPUManagerPolicy,
+			}
+		}
+		if len(cfg.Sysctls) > 0 {
+			pool.Config.LinuxNodeConfig = &containerpb.LinuxNodeConfig{Sysctls: cfg.Sysctls}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// clusterHandle identifies a cluster create started with --wait=false, by the create operation
+// or by the cluster when it already existed and no operation was started.
+type clusterHandle struct {
+	project, location string
+	// operation is true when id is the id of the create operation, otherwise it is the cluster name.
+	operation bool
+	id        string
+}
+
+// newClusterHandle returns the handle of the create of a cluster, op is nil when the cluster already existed.
+func newClusterHandle(projectID, zone, clusterID string, op *containerpb.Operation) clusterHandle {
+	if op == nil {
+		return clusterHandle{project: projectID, location: zone, id: clusterID}
+	}
+	return clusterHandle{project: projectID, location: zone, operation: true, id: op.Name}
+}
+
+// String returns the handle in the resource name format of the GKE API,
+// projects/PROJECT/locations/ZONE/operations/OPERATION or projects/PROJECT/locations/ZONE/clusters/CLUSTER.
+func (h clusterHandle) String() string {
+	kind := "clusters"
+	if h.operation {
+		kind = "operations"
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/%s/%s", h.project, h.location, kind, h.id)
+}
+
+// parseClusterHandle parses a handle printed by cluster create --wait=false.
+func parseClusterHandle(s string) (clusterHandle, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "locations" || (parts[4] != "operations" && parts[4] != "clusters") {
+		return clusterHandle{}, errors.Errorf("invalid cluster handle %q, must be projects/PROJECT/locations/ZONE/operations/OPERATION or projects/PROJECT/locations/ZONE/clusters/CLUSTER", s)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return clusterHandle{}, errors.Errorf("invalid cluster handle %q, it has an empty segment", s)
+		}
+	}
+	return clusterHandle{project: parts[1], location: parts[3], operation: parts[4] == "operations", id: parts[5]}, nil
+}
+
+// checkNoWait returns an error when a step that runs after the cluster is ready is requested with --wait=false,
+// as the create returns before the cluster is ready and cluster wait doesn't run them.
+func (c *GKE) checkNoWait() error {
+	if c.WaitCreate {
+		return nil
+	}
+	var steps []string
+	if len(c.DeploymentResource.BootstrapFiles) > 0 {
+		steps = append(steps, "--bootstrap-file")
+	}
+	if c.DeploymentResource.Bastion.Enabled {
+		steps = append(steps, "--bastion")
+	}
+	if c.DeploymentResource.ScrapeFirewall.Enabled() {
+		steps = append(steps, "--scrape-port")
+	}
+	if len(c.WorkloadIdentityBindings) > 0 {
+		steps = append(steps, "--workload-identity-binding")
+	}
+	if len(steps) > 0 {
+		return errors.Errorf("%s run once the cluster is ready and can't be used with --wait=false", strings.Join(steps, ", "))
+	}
+	return nil
+}
+
+// ClusterWait waits for the cluster creates started by cluster create --wait=false, in the order of the handles,
+// and writes the info file of the clusters the same way cluster create does when it waits.
+func (c *GKE) ClusterWait(*kingpin.ParseContext) error {
+	var created []provider.ClusterInfo
+	for _, s := range c.CreateHandles {
+		h, err := parseClusterHandle(s)
+		if err != nil {
+			log.Fatalf("Couldn't wait for the cluster create: %v", err)
+		}
+		clusterID := h.id
+		var op *containerpb.Operation
+		if h.operation {
+			err := provider.RetryUntilTrue(
+				fmt.Sprintf("waiting for operation:%v", h.id),
+				provider.GlobalRetryCount,
+				func() (bool, error) {
+					var done bool
+					op, done, err = c.operationDone(h)
+					return done, err
+				})
+			if err != nil {
+				log.Fatalf("Couldn't wait for the cluster create %v, err: %v", h, err)
+			}
+			clusterID = path.Base(op.TargetLink)
+		}
+
+		err = provider.RetryUntilTrue(
+			fmt.Sprintf("creating cluster:%v", clusterID),
+			provider.GlobalRetryCount,
+			func() (bool, error) { return c.clusterRunning(h.location, h.project, clusterID) })
+		if err != nil {
+			log.Fatalf("creating cluster err:%v", err)
+		}
+		if op != nil {
+			c.recordOperation(h, clusterID, op)
+		}
+
+		info, err := c.clusterInfo(h.location, h.project, clusterID)
+		if err != nil {
+			log.Fatalf("Couldn't get the endpoint of cluster '%v', err: %v", clusterID, err)
+		}
+		log.Printf("Cluster '%v' is running", clusterID)
+		created = append(created, info)
+	}
+	if c.DeploymentResource.InfoFile != "" {
+		if err := provider.WriteClusterInfo(c.DeploymentResource.InfoFile, created); err != nil {
+			log.Fatalf("Couldn't write the cluster info file: %v", err)
+		}
+	}
+	return nil
+}
+
+// operationDone checks whether the operation of the handle is done, a failed operation is returned as an error.
+func (c *GKE) operationDone(h clusterHandle) (*containerpb.Operation, bool, error) {
+	op, err := c.clientGKE.GetOperation(c.ctx, &containerpb.GetOperationRequest{Name: h.String()})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "getting operation %v", h.id)
+	}
+	if op.Status != containerpb.Operation_DONE {
+		log.Printf("Operation '%v' status:%v", h.id, op.Status)
+		return op, false, nil
+	}
+	if op.Error != nil {
+		return op, false, errors.Errorf("operation %v failed: %v", h.id, op.Error.Message)
+	}
+	return op, true, nil
+}
+
+// recordOperation records the duration of the create from the start of its operation,
+// so a create that didn't wait is timed the same way as one that did.
+func (c *GKE) recordOperation(h clusterHandle, clusterID string, op *containerpb.Operation) {
+	if !c.DeploymentResource.Provisioning.Enabled() {
+		return
+	}
+	start, err := time.Parse(time.RFC3339Nano, op.StartTime)
+	if err != nil {
+		log.Printf("Couldn't record the provisioning duration of cluster %q, invalid operation start time %q", clusterID, op.StartTime)
+		return
+	}
+	cluster, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{Name: fmt.Sprintf("projects/%s/locations/%s/clusters/%s", h.project, h.location, clusterID)})
+	if err != nil {
+		log.Printf("Couldn't record the provisioning duration of cluster %q: %v", clusterID, err)
+		return
+	}
+	c.recordProvisioning("create", h.location, cluster, start)
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	gke "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+func TestClusterHandle(t *testing.T) {
+	for _, tc := range []struct {
+		handle clusterHandle
+		exp    string
+	}{
+		{
+			handle: newClusterHandle("test-project", "europe-west3-a", "prombench", &containerpb.Operation{Name: "operation-1234"}),
+			exp:    "projects/test-project/locations/europe-west3-a/operations/operation-1234",
+		},
+		{
+			handle: newClusterHandle("test-project", "europe-west3-a", "prombench", nil),
+			exp:    "projects/test-project/locations/europe-west3-a/clusters/prombench",
+		},
+	} {
+		if got := tc.handle.String(); got != tc.exp {
+			t.Errorf("expect the handle %v, got %v", tc.exp, got)
+		}
+		h, err := parseClusterHandle(tc.exp)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if h != tc.handle {
+			t.Errorf("expect %v to parse to %+v, got %+v", tc.exp, tc.handle, h)
+		}
+	}
+}
+
+func TestParseClusterHandleErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"operation-1234",
+		"projects/test-project/locations/europe-west3-a/operations",
+		"projects/test-project/locations/europe-west3-a/operations/operation-1234/extra",
+		"projects/test-project/zones/europe-west3-a/operations/operation-1234",
+		"projects/test-project/locations/europe-west3-a/nodePools/prombench",
+		"projects//locations/europe-west3-a/clusters/prombench",
+	} {
+		if _, err := parseClusterHandle(s); err == nil {
+			t.Errorf("%q: expect an error", s)
+		}
+	}
+}
+
+func TestCheckNoWait(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		setup func(*GKE)
+		err   string
+	}{
+		{name: "wait", setup: func(c *GKE) {
+			c.WaitCreate = true
+			c.DeploymentResource.BootstrapFiles = []string{"bootstrap.yaml"}
+		}},
+		{name: "no post create steps", setup: func(*GKE) {}},
+		{name: "bootstrap", setup: func(c *GKE) { c.DeploymentResource.BootstrapFiles = []string{"bootstrap.yaml"} }, err: "--bootstrap-file run once"},
+		{
+			name: "all post create steps",
+			setup: func(c *GKE) {
+				c.DeploymentResource.BootstrapFiles = []string{"bootstrap.yaml"}
+				c.DeploymentResource.Bastion.Enabled = true
+				c.DeploymentResource.ScrapeFirewall.Ports = []string{"9100"}
+				c.WorkloadIdentityBindings = provider.WorkloadIdentityBindings{{}}
+			},
+			err: "--bootstrap-file, --bastion, --scrape-port, --workload-identity-binding run once the cluster is ready and can't be used with --wait=false",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(&provider.DeploymentResource{})
+			tc.setup(c)
+			err := c.checkNoWait()
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expect an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+// fakeClusterManager serves the operations of the GKE API by name.
+type fakeClusterManager struct {
+	containerpb.UnimplementedClusterManagerServer
+	operations map[string]*containerpb.Operation
+}
+
+func (f *fakeClusterManager) GetOperation(_ context.Context, req *containerpb.GetOperationRequest) (*containerpb.Operation, error) {
+	return f.operations[req.Name], nil
+}
+
+func TestOperationDone(t *testing.T) {
+	const prefix = "projects/test-project/locations/europe-west3-a/operations/"
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	containerpb.RegisterClusterManagerServer(srv, &fakeClusterManager{operations: map[string]*containerpb.Operation{
+		prefix + "running": {Name: "running", Status: containerpb.Operation_RUNNING},
+		prefix + "done":    {Name: "done", Status: containerpb.Operation_DONE, TargetLink: "https://container.googleapis.com/v1/projects/test-project/zones/europe-west3-a/clusters/prombench"},
+		prefix + "failed":  {Name: "failed", Status: containerpb.Operation_DONE, Error: &status.Status{Message: "quota exceeded"}},
+	}})
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	c := New(&provider.DeploymentResource{})
+	c.ctx = context.Background()
+	c.clientGKE, err = gke.NewClusterManagerClient(c.ctx,
+		option.WithEndpoint(l.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.clientGKE.Close() })
+
+	for _, tc := range []struct {
+		id   string
+		done bool
+		err  string
+	}{
+		{id: "running"},
+		{id: "done", done: true},
+		{id: "failed", err: "operation failed failed: quota exceeded"},
+	} {
+		t.Run(tc.id, func(t *testing.T) {
+			op, done, err := c.operationDone(clusterHandle{project: "test-project", location: "europe-west3-a", operation: true, id: tc.id})
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expect the error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if done != tc.done || op.Name != tc.id {
+				t.Errorf("expect the operation %v done %v, got %v done %v", tc.id, tc.done, op.Name, done)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	crm "google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// cloudPlatformScope is the OAuth scope of the impersonated credentials, the IAM roles limit what they can do.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// permissions are the IAM permissions checked before running a command in an isolated project,
+// the commands that aren't listed only read the cluster to connect to it.
+var permissions = provider.CommandPermissions{
+	"gke cluster create": {"container.clusters.create", "container.clusters.get", "container.operations.get"},
+	"gke cluster delete": {"container.clusters.delete", "container.clusters.get", "container.operations.get"},
+	"gke cluster wait":   {"container.clusters.get", "container.operations.get"},
+	"gke nodes create":   {"container.clusters.update", "container.clusters.get", "container.operations.get"},
+	"gke nodes delete":   {"container.clusters.update", "container.clusters.get", "container.operations.get"},
+	"gke nodes replace":  {"container.clusters.update", "container.clusters.get", "container.operations.get"},
+	"gke":                {"container.clusters.get"},
+}
+
+// setProject sets the GKE_PROJECT_ID variable to the project passed from the cli,
+// so the cluster files and all requests of the run use it.
+func (c *GKE) setProject() error {
+	if c.ProjectID == "" {
+		return nil
+	}
+	if v, ok := c.DeploymentResource.FlagDeploymentVars["GKE_PROJECT_ID"]; ok && v != c.ProjectID {
+		return fmt.Errorf("the project %v conflicts with the GKE_PROJECT_ID variable %v", c.ProjectID, v)
+	}
+	c.DeploymentVars["GKE_PROJECT_ID"] = c.ProjectID
+	return nil
+}
+
+// setClientOptions sets the options of the GCP clients, they authenticate with the auth
+// or impersonate the service account passed from the cli with it.
+func (c *GKE) setClientOptions() error {
+	c.clientOpts = []option.ClientOption{option.WithCredentialsJSON([]byte(c.Auth))}
+	if c.ImpersonateServiceAccount == "" {
+		return nil
+	}
+	ts, err := impersonate.CredentialsTokenSource(c.ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: c.ImpersonateServiceAccount,
+		Scopes:          []string{cloudPlatformScope},
+	}, c.clientOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "could not impersonate the service account %v", c.ImpersonateServiceAccount)
+	}
+	// Get a token right away, it fails when the auth isn't allowed to impersonate the service account.
+	if _, err := ts.Token(); err != nil {
+		return errors.Wrapf(err, "could not impersonate the service account %v, the auth needs the roles/iam.serviceAccountTokenCreator role on it", c.ImpersonateServiceAccount)
+	}
+	log.Printf("impersonating the service account %v", c.ImpersonateServiceAccount)
+	c.tokenSource = ts
+	c.clientOpts = []option.ClientOption{option.WithTokenSource(ts)}
+	return nil
+}
+
+// checkPermissions returns an error when the identity of the run is missing a permission of the command in the project,
+// so a run in an isolated project fails before it creates anything.
+func (c *GKE) checkPermissions(command string) error {
+	if c.ProjectID == "" && c.ImpersonateServiceAccount == "" {
+		return nil
+	}
+	required := permissions.Required(command, "gke")
+	project := c.DeploymentVars["GKE_PROJECT_ID"]
+	if project == "" {
+		return errors.New("missing required GKE_PROJECT_ID variable, set it or --project")
+	}
+	svc, err := crm.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the resource manager client")
+	}
+	res, err := svc.Projects.TestIamPermissions(project, &crm.TestIamPermissionsRequest{Permissions: required}).Context(c.ctx).Do()
+	if err != nil {
+		return errors.Wrapf(err, "checking the permissions in project %v", project)
+	}
+	if missing := provider.MissingPermissions(required, res.Permissions); len(missing) > 0 {
+		return errors.Errorf("missing the permissions %v in project %v for %q", strings.Join(missing, ", "), project, command)
+	}
+	return nil
+}
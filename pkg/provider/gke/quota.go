@@ -0,0 +1,100 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"log"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// defaultMachineType is used by GKE for node pools without a machine type.
+const defaultMachineType = "e2-medium"
+
+// checkQuota compares the CPUs and external IP addresses needed by the node pools of the cluster
+// against the regional quotas of the project.
+func (c *GKE) checkQuota(req *containerpb.CreateClusterRequest) error {
+	if c.SkipQuotaCheck {
+		return nil
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+	project, location := req.ProjectId, req.Zone
+	zone, region := location, zoneRegion(location)
+
+	// Each node pool is created in every zone of the cluster.
+	zones := len(req.Cluster.Locations)
+	if zones == 0 {
+		zones = 1
+		if region == location {
+			// Regional clusters use 3 zones by default.
+			zones = 3
+			zone = location + "-b"
+		}
+	} else {
+		zone = req.Cluster.Locations[0]
+	}
+
+	required := map[string]float64{}
+	var nodes float64
+	for _, pool := range req.Cluster.NodePools {
+		machineType := pool.GetConfig().GetMachineType()
+		if machineType == "" {
+			machineType = defaultMachineType
+		}
+		mt, err := svc.MachineTypes.Get(project, zone, machineType).Context(c.ctx).Do()
+		if err != nil {
+			return errors.Wrapf(err, "getting the machine type %v of node pool '%v'", machineType, pool.Name)
+		}
+		count := float64(pool.InitialNodeCount) * float64(zones)
+		nodes += count
+		required["CPUS"] += count * float64(mt.GuestCpus)
+		// Most machine families also have their own CPU quota, e.g. N2_CPUS.
+		family := strings.ToUpper(strings.SplitN(machineType, "-", 2)[0]) + "_CPUS"
+		required[family] += count * float64(mt.GuestCpus)
+	}
+	if !req.Cluster.GetPrivateClusterConfig().GetEnablePrivateNodes() {
+		required["IN_USE_ADDRESSES"] = nodes
+	}
+
+	r, err := svc.Regions.Get(project, region).Context(c.ctx).Do()
+	if err != nil {
+		return errors.Wrapf(err, "getting the quotas of region %v", region)
+	}
+	var quotas []provider.Quota
+	for _, q := range r.Quotas {
+		if n, ok := required[q.Metric]; ok {
+			quotas = append(quotas, provider.Quota{Metric: q.Metric, Limit: q.Limit, Usage: q.Usage, Required: n})
+		}
+	}
+	log.Printf("Quota check for %v nodes in region %v: %v", nodes, region, quotas)
+	return provider.CheckQuotas(quotas)
+}
+
+// zoneRegion returns the region of a zone, e.g. europe-west1 for europe-west1-b.
+// A region is returned as is.
+func zoneRegion(location string) string {
+	if parts := strings.Split(location, "-"); len(parts) > 2 {
+		return strings.Join(parts[:2], "-")
+	}
+	return location
+}
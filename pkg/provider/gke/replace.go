@@ -0,0 +1,95 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// nodePoolLabel is the label GKE sets on every node with the name of its node pool.
+const nodePoolLabel = "cloud.google.com/gke-nodepool"
+
+// NodePoolReplace replaces a node pool of the cluster in place, e.g. to change the machine type between the runs of an experiment series.
+// It creates the new pool from the cluster file, drains the nodes of the old pool while respecting the pod disruption budgets,
+// so its pods are rescheduled on the new pool, and deletes the old pool.
+// When the drain fails the old pool stays cordoned, a new replace continues with the existing new pool.
+func (c *GKE) NodePoolReplace(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if len(c.gkeResources) != 1 {
+		log.Fatalf("A node pool replacement needs a single cluster deployment file, got %d", len(c.gkeResources))
+	}
+	if dr.ReplaceSurge < 1 {
+		log.Fatalf("Invalid surge %d, must be >= 1", dr.ReplaceSurge)
+	}
+	if err := c.checkNodeServiceAccount(); err != nil {
+		log.Fatalf("Invalid node service account: %v", err)
+	}
+	deployment := c.gkeResources[0]
+	reqC := &containerpb.CreateClusterRequest{}
+	c.parseNodePools(reqC, deployment)
+
+	existing, err := c.listNodePools(reqC)
+	if err != nil {
+		log.Fatalf("Couldn't list the node pools of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+	}
+	pools := make([]string, 0, len(reqC.Cluster.NodePools))
+	for _, node := range reqC.Cluster.NodePools {
+		pools = append(pools, node.Name)
+	}
+	with, exists, err := provider.ReplacementPool(existing, pools, dr.ReplacePool, dr.ReplaceWith)
+	if err != nil {
+		log.Fatalf("Couldn't replace the node pool of cluster '%v', file:%v ,err: %v", reqC.Cluster.Name, deployment.FileName, err)
+	}
+
+	log.Printf("Replacing node pool '%v' of cluster '%v' with '%v' [1/3]: creating the new pool", dr.ReplacePool, reqC.Cluster.Name, with)
+	if exists {
+		log.Printf("Node pool '%v' exists already, continuing the replacement", with)
+		err := provider.RetryUntilTrue(
+			fmt.Sprintf("checking nodepool running status for:%v", with),
+			provider.GlobalRetryCount,
+			func() (bool, error) {
+				//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+				return c.nodePoolRunning(reqC.Zone, reqC.ProjectId, reqC.Cluster.Name, with)
+			})
+		if err != nil {
+			log.Fatalf("Couldn't wait for cluster nodepool '%v', file:%v ,err: %v", with, deployment.FileName, err)
+		}
+	} else {
+		for _, node := range reqC.Cluster.NodePools {
+			if node.Name == with {
+				c.createNodePool(reqC, node, deployment.FileName)
+			}
+		}
+	}
+
+	log.Printf("Replacing node pool '%v' of cluster '%v' with '%v' [2/3]: draining the old pool", dr.ReplacePool, reqC.Cluster.Name, with)
+	k, err := c.K8sClient()
+	if err != nil {
+		log.Fatalf("k8s provider error: %v", err)
+	}
+	if err := k.DrainNodes(nodePoolLabel+"="+dr.ReplacePool, dr.ReplaceSurge, dr.DrainTimeout); err != nil {
+		log.Fatalf("Couldn't drain node pool '%v', the new pool '%v' is kept and a new replace continues with it, err: %v", dr.ReplacePool, with, err)
+	}
+
+	log.Printf("Replacing node pool '%v' of cluster '%v' with '%v' [3/3]: deleting the old pool", dr.ReplacePool, reqC.Cluster.Name, with)
+	c.deleteNodePool(reqC, dr.ReplacePool, deployment.FileName)
+	log.Printf("Node pool '%v' of cluster '%v' replaced with '%v'", dr.ReplacePool, reqC.Cluster.Name, with)
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+// confidentialMachineFamilies are the machine families with AMD SEV that GKE runs confidential nodes on.
+var confidentialMachineFamilies = []string{"n2d", "c2d"}
+
+// setNodeSecurity enables the shielded and confidential VM options passed from the cli on the node pools.
+// Shielded nodes boot with secure boot and integrity monitoring,
+// confidential nodes encrypt their memory and require one of the confidentialMachineFamilies.
+func (c *GKE) setNodeSecurity(pools []*containerpb.NodePool) error {
+	for _, pool := range pools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		if c.ShieldedNodes {
+			pool.Config.ShieldedInstanceConfig = &containerpb.ShieldedInstanceConfig{
+				EnableSecureBoot:          true,
+				EnableIntegrityMonitoring: true,
+			}
+		}
+		if !c.ConfidentialNodes {
+			continue
+		}
+		machineType := pool.Config.MachineType
+		if machineType == "" {
+			machineType = defaultMachineType
+		}
+		if !confidentialMachineType(machineType) {
+			return fmt.Errorf("confidential nodes of node pool '%v' require a machine type of the %v families, got %v",
+				pool.Name, strings.Join(confidentialMachineFamilies, ", "), machineType)
+		}
+		pool.Config.ConfidentialNodes = &containerpb.ConfidentialNodes{Enabled: true}
+	}
+	return nil
+}
+
+// confidentialMachineType returns whether GKE runs confidential nodes on the machine type.
+func confidentialMachineType(machineType string) bool {
+	family := strings.SplitN(machineType, "-", 2)[0]
+	for _, f := range confidentialMachineFamilies {
+		if family == f {
+			return true
+		}
+	}
+	return false
+}
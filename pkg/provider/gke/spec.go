@@ -0,0 +1,69 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// ApplySpec loads the --spec-file and uses its fields for the cluster create flags that aren't set.
+// It runs as a pre action, so the name, region and vars of the spec are set before the deployment vars are merged.
+func (c *GKE) ApplySpec(*kingpin.ParseContext) error {
+	if c.SpecFile == "" {
+		return nil
+	}
+	s, err := provider.LoadClusterSpec(c.SpecFile)
+	if err != nil {
+		return err
+	}
+	s.ApplyDeploymentResource(c.DeploymentResource)
+	c.applySpec(s)
+	return nil
+}
+
+func (c *GKE) applySpec(s *provider.ClusterSpec) {
+	for _, f := range []struct {
+		flag *string
+		spec string
+	}{
+		{&c.ClusterVersion, s.Version},
+		{&c.ReleaseChannel, s.ReleaseChannel},
+		{&c.ImageType, s.ImageType},
+		{&c.SystemNodePool, s.SystemNodePoolFlag()},
+		{&c.Logging, s.Addons.Logging},
+		{&c.Monitoring, s.Addons.Monitoring},
+		{&c.PodCIDR, s.Networking.PodCIDR},
+		{&c.ServiceCIDR, s.Networking.ServiceCIDR},
+	} {
+		if *f.flag == "" {
+			*f.flag = f.spec
+		}
+	}
+	if len(c.NodePools) == 0 {
+		c.NodePools = s.NodePoolSpecs()
+	}
+	if len(c.Autoscaling) == 0 {
+		c.Autoscaling = s.NodePoolAutoscalings()
+	}
+	if len(c.Zones) == 0 {
+		c.Zones = s.Zones
+	}
+	if c.MaxPodsPerNode == 0 {
+		c.MaxPodsPerNode = s.Networking.MaxPodsPerNode
+	}
+	// A bool flag can't tell unset from false, so the spec can only enable it.
+	c.WorkloadIdentity = c.WorkloadIdentity || s.Addons.WorkloadIdentity
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// sshUserRe matches the linux user names accepted in the ssh-keys metadata.
+var sshUserRe = regexp.MustCompile(`^[a-z_][a-z0-9_.-]{0,31}$`)
+
+// setSSHKey adds the ssh public key passed from the cli to the ssh-keys metadata of the node pools,
+// so the user can log in to the nodes of these pools. Keys set in the cluster file for the same pools are kept.
+func (c *GKE) setSSHKey(pools []*containerpb.NodePool) error {
+	if c.SSHPublicKey == "" {
+		return nil
+	}
+	if !sshUserRe.MatchString(c.SSHUser) {
+		return fmt.Errorf("invalid ssh user %q, must be a lowercase linux user name", c.SSHUser)
+	}
+	key, err := provider.LoadSSHPublicKey(c.SSHPublicKey)
+	if err != nil {
+		return err
+	}
+	entry := fmt.Sprintf("%s:%s %s", c.SSHUser, key, c.SSHUser)
+	for _, pool := range pools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		if pool.Config.Metadata == nil {
+			pool.Config.Metadata = map[string]string{}
+		}
+		keys := entry
+		if existing := pool.Config.Metadata["ssh-keys"]; existing != "" {
+			keys = existing + "\n" + entry
+		}
+		pool.Config.Metadata["ssh-keys"] = keys
+		log.Printf("Node pool '%v' accepts ssh logins as %v with the key %v", pool.Name, c.SSHUser, key.SHA256Fingerprint())
+	}
+	return nil
+}
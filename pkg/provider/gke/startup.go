@@ -0,0 +1,52 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+// setNodeStartup sets the node startup options passed from the cli on the node pools.
+// Image streaming lets the containers start before their images are pulled completely
+// and an ssd boot disk speeds up the node boot and the image pulls that still happen.
+func (c *GKE) setNodeStartup(pools []*containerpb.NodePool) error {
+	if c.BootDiskSize != 0 && c.BootDiskSize < 10 {
+		return fmt.Errorf("invalid boot disk size %dGB, must be at least 10GB", c.BootDiskSize)
+	}
+	for _, pool := range pools {
+		if pool.Config == nil {
+			pool.Config = &containerpb.NodeConfig{}
+		}
+		if c.BootDiskType != "" {
+			pool.Config.DiskType = c.BootDiskType
+		}
+		if c.BootDiskSize != 0 {
+			pool.Config.DiskSizeGb = c.BootDiskSize
+		}
+		if !c.ImageStreaming {
+			continue
+		}
+		// Image streaming is only available on the containerd COS images, which are the GKE default.
+		if imageType := strings.ToUpper(pool.Config.ImageType); imageType != "" && imageType != "COS_CONTAINERD" {
+			return fmt.Errorf("image streaming for node pool '%v' requires the COS_CONTAINERD image type, got %v", pool.Name, pool.Config.ImageType)
+		}
+		pool.Config.GcfsConfig = &containerpb.GcfsConfig{Enabled: true}
+		log.Printf("Node pool '%v' streams the container images", pool.Name)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// clusterForSummary returns the live cluster for the run summary before it is deleted,
+// nil when the summary is disabled or the cluster doesn't exist.
+func (c *GKE) clusterForSummary(projectID, zone, clusterID string) (*containerpb.Cluster, error) {
+	if !c.DeploymentResource.RunSummary.Enabled() {
+		return nil, nil
+	}
+	cluster, err := c.clientGKE.GetCluster(c.ctx, &containerpb.GetClusterRequest{
+		ProjectId: projectID,
+		Zone:      zone,
+		ClusterId: clusterID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "getting cluster:%v", clusterID)
+	}
+	return cluster, nil
+}
+
+// runSummary returns the summary of a cluster deleted at deleted,
+// with the initial node count of every node pool across its zones.
+func (c *GKE) runSummary(cluster *containerpb.Cluster, zone string, deleted time.Time) (provider.RunSummary, error) {
+	var nodes []provider.NodeSummary
+	for _, pool := range cluster.NodePools {
+		zones := len(pool.Locations)
+		if zones == 0 {
+			zones = len(cluster.Locations)
+		}
+		if zones == 0 {
+			zones = 1
+		}
+		n := provider.NodeSummary{Pool: pool.Name, Count: int(pool.InitialNodeCount) * zones}
+		if pool.Config != nil {
+			n.MachineType = pool.Config.MachineType
+		}
+		nodes = append(nodes, n)
+	}
+	var created time.Time
+	if cluster.CreateTime != "" {
+		t, err := time.Parse(time.RFC3339, cluster.CreateTime)
+		if err != nil {
+			return provider.RunSummary{}, errors.Wrapf(err, "parsing the create time of cluster:%v", cluster.Name)
+		}
+		created = t
+	}
+	return provider.NewRunSummary(c.DeploymentResource.RunSummary, "gke", cluster.Name, zone, cluster.ResourceLabels, nodes, created, deleted)
+}
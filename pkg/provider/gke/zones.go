@@ -0,0 +1,74 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// spreadZones places the nodes of all node pools in the zones passed from the cli
+// and splits the node count of every pool evenly across them.
+// GKE runs the same number of nodes in every zone of a pool, so the count is per zone after this.
+func (c *GKE) spreadZones(zone string, cluster *containerpb.Cluster) error {
+	if len(c.Zones) == 0 {
+		return nil
+	}
+	found := false
+	for _, z := range c.Zones {
+		found = found || z == zone
+	}
+	if !found {
+		return fmt.Errorf("the zones %v must include the cluster zone %v", c.Zones, zone)
+	}
+	cluster.Locations = c.Zones
+	for _, pool := range cluster.NodePools {
+		perZone := provider.NodesPerZone(pool.InitialNodeCount, len(c.Zones))
+		if total := perZone * int32(len(c.Zones)); total != pool.InitialNodeCount {
+			log.Printf("WARNING: node pool '%v' count %d doesn't divide evenly across %d zones, creating %d nodes", pool.Name, pool.InitialNodeCount, len(c.Zones), total)
+		}
+		pool.InitialNodeCount = perZone
+	}
+	return nil
+}
+
+// checkZones returns an error when a zone passed from the cli doesn't exist in the region of the cluster.
+func (c *GKE) checkZones(req *containerpb.CreateClusterRequest) error {
+	if len(c.Zones) == 0 {
+		return nil
+	}
+	svc, err := compute.NewService(c.ctx, c.clientOpts...)
+	if err != nil {
+		return errors.Wrap(err, "could not create the compute client")
+	}
+	//nolint:staticcheck // SA1019 - Ignore "Do not use.".
+	project, region := req.ProjectId, zoneRegion(req.Zone)
+	r, err := svc.Regions.Get(project, region).Context(c.ctx).Do()
+	if err != nil {
+		return errors.Wrapf(err, "getting the zones of region %v", region)
+	}
+	available := make([]string, 0, len(r.Zones))
+	for _, z := range r.Zones {
+		// The zones are returned as urls.
+		available = append(available, path.Base(z))
+	}
+	return provider.ValidateZones(region, c.Zones, available)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+// HelmOptions selects the Helm charts rendered by the k8s resource commands and their values.
+type HelmOptions struct {
+	// Chart directories or packaged .tgz charts.
+	Charts []string
+	// Values files merged in order, later files take precedence over earlier ones.
+	ValuesFiles []string
+	// Values in the helm --set format, e.g. server.replicas=2. They take precedence over the values files.
+	Set []string
+	// The release name and namespace used in the chart templates.
+	// When not set the chart name and the default namespace are used.
+	ReleaseName string
+	Namespace   string
+}
+
+// HelmTemplate renders the charts the same as `helm template` and returns the objects
+// grouped by the chart template filename, in the order Helm installs them.
+// CRDs from the chart crds directory come first, hooks are skipped as they are only run by Helm itself.
+func HelmTemplate(opts HelmOptions) ([]Resource, error) {
+	if len(opts.Charts) == 0 {
+		return nil, nil
+	}
+	vals, err := helmValues(opts)
+	if err != nil {
+		return nil, err
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	var resources []Resource
+	for _, path := range opts.Charts {
+		chrt, err := loader.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading the helm chart %s: %v", path, err)
+		}
+		if err := chartutil.ProcessDependencies(chrt, vals); err != nil {
+			return nil, fmt.Errorf("processing the dependencies of the helm chart %s: %v", path, err)
+		}
+		release := opts.ReleaseName
+		if release == "" {
+			release = chrt.Name()
+		}
+		caps := chartutil.DefaultCapabilities
+		renderVals, err := chartutil.ToRenderValues(chrt, vals, chartutil.ReleaseOptions{
+			Name:      release,
+			Namespace: namespace,
+			Revision:  1,
+			IsInstall: true,
+		}, caps)
+		if err != nil {
+			return nil, fmt.Errorf("helm chart %s values: %v", path, err)
+		}
+		files, err := engine.Render(chrt, renderVals)
+		if err != nil {
+			return nil, fmt.Errorf("rendering the helm chart %s: %v", path, err)
+		}
+		for name := range files {
+			if strings.HasSuffix(name, "NOTES.txt") {
+				delete(files, name)
+			}
+		}
+		hooks, manifests, err := releaseutil.SortManifests(files, caps.APIVersions, releaseutil.InstallOrder)
+		if err != nil {
+			return nil, fmt.Errorf("sorting the helm chart %s objects: %v", path, err)
+		}
+
+		for _, crd := range chrt.CRDObjects() {
+			resources = append(resources, Resource{FileName: chartFileName(path, chrt.Name(), crd.Filename), Content: crd.File.Data})
+		}
+		for _, h := range hooks {
+			log.Printf("Skipping the helm hook %v from %v", h.Name, h.Path)
+		}
+		for _, m := range manifests {
+			resources = append(resources, Resource{FileName: chartFileName(path, chrt.Name(), m.Name), Content: []byte(m.Content)})
+		}
+	}
+	return resources, nil
+}
+
+// chartFileName returns the path of a chart file, Helm names them relative to the chart name.
+func chartFileName(path, chartName, name string) string {
+	return filepath.Join(path, strings.TrimPrefix(name, chartName+"/"))
+}
+
+// helmValues merges the values files and the --set values.
+func helmValues(opts HelmOptions) (map[string]interface{}, error) {
+	vals := map[string]interface{}{}
+	for _, f := range opts.ValuesFiles {
+		v, err := chartutil.ReadValuesFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading the helm values file %s: %v", f, err)
+		}
+		vals = mergeValues(vals, v)
+	}
+	for _, s := range opts.Set {
+		if err := strvals.ParseInto(s, vals); err != nil {
+			return nil, fmt.Errorf("parsing the helm value %q: %v", s, err)
+		}
+	}
+	return vals, nil
+}
+
+// mergeValues merges b into a recursively, values in b take precedence.
+func mergeValues(a, b map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(a))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		if v, ok := v.(map[string]interface{}); ok {
+			if av, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeValues(av, v)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
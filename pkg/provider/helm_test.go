@@ -0,0 +1,102 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHelmTemplate(t *testing.T) {
+	dir := t.TempDir()
+	chart := filepath.Join(dir, "loadgen")
+	writeTestFiles(t, chart, map[string]string{
+		"Chart.yaml": "apiVersion: v2\nname: loadgen\nversion: 0.1.0\n",
+		"values.yaml": `
+replicas: 1
+querier:
+  image: prom/loadgen:latest
+  interval: 10s
+`,
+		"crds/crd.yaml":            "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: loadgens.prombench.io\n",
+		"templates/_helpers.tpl":   `{{- define "loadgen.name" -}}{{ .Release.Name }}-querier{{- end -}}`,
+		"templates/NOTES.txt":      "Installed {{ .Release.Name }}.",
+		"templates/namespace.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: {{ .Release.Namespace }}\n",
+		"templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "loadgen.name" . }}
+  namespace: {{ .Release.Namespace }}
+data:
+  replicas: "{{ .Values.replicas }}"
+  image: {{ .Values.querier.image }}
+  interval: {{ .Values.querier.interval }}
+`,
+		"templates/hook.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    helm.sh/hook: pre-install
+`,
+	})
+	values := filepath.Join(dir, "values-pr.yaml")
+	writeTestFiles(t, dir, map[string]string{"values-pr.yaml": "querier:\n  image: prom/loadgen:pr-10\n"})
+
+	resources, err := HelmTemplate(HelmOptions{
+		Charts:      []string{chart},
+		ValuesFiles: []string{values},
+		Set:         []string{"replicas=3"},
+		ReleaseName: "prombench",
+		Namespace:   "prombench-10",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, r := range resources {
+		names = append(names, strings.TrimPrefix(r.FileName, chart+"/"))
+	}
+	if want := "crds/crd.yaml,templates/namespace.yaml,templates/configmap.yaml"; strings.Join(names, ",") != want {
+		t.Fatalf("want the resources %v, got %v", want, names)
+	}
+	content := string(resources[2].Content)
+	for _, want := range []string{
+		"name: prombench-querier",
+		"namespace: prombench-10",
+		`replicas: "3"`,
+		"image: prom/loadgen:pr-10",
+		"interval: 10s",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in the rendered config map:\n%v", want, content)
+		}
+	}
+}
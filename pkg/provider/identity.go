@@ -0,0 +1,72 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkloadIdentityBinding allows a k8s service account to act as a cloud identity,
+// a GCP service account for GKE Workload Identity or an IAM role for EKS IRSA.
+type WorkloadIdentityBinding struct {
+	CloudIdentity  string
+	Namespace      string
+	ServiceAccount string
+}
+
+func (b WorkloadIdentityBinding) String() string {
+	return fmt.Sprintf("%v=%v/%v", b.CloudIdentity, b.Namespace, b.ServiceAccount)
+}
+
+// WorkloadIdentityBindings is a repeatable cli flag value holding workload identity bindings
+// in the IDENTITY=NAMESPACE/SERVICE_ACCOUNT format, e.g.
+// loadgen@project.iam.gserviceaccount.com=prombench-10/loadgen
+type WorkloadIdentityBindings []WorkloadIdentityBinding
+
+// Set implements the kingpin.Value interface.
+func (w *WorkloadIdentityBindings) Set(value string) error {
+	b, err := ParseWorkloadIdentityBinding(value)
+	if err != nil {
+		return err
+	}
+	*w = append(*w, b)
+	return nil
+}
+
+func (w *WorkloadIdentityBindings) String() string {
+	bindings := make([]string, 0, len(*w))
+	for _, b := range *w {
+		bindings = append(bindings, b.String())
+	}
+	return strings.Join(bindings, ",")
+}
+
+// IsCumulative allows the flag to be repeated.
+func (w *WorkloadIdentityBindings) IsCumulative() bool {
+	return true
+}
+
+// ParseWorkloadIdentityBinding parses a single binding in the IDENTITY=NAMESPACE/SERVICE_ACCOUNT format.
+func ParseWorkloadIdentityBinding(value string) (WorkloadIdentityBinding, error) {
+	identity, ksa, ok := strings.Cut(value, "=")
+	if !ok || identity == "" {
+		return WorkloadIdentityBinding{}, fmt.Errorf("invalid workload identity binding %q, expected IDENTITY=NAMESPACE/SERVICE_ACCOUNT", value)
+	}
+	ns, sa, ok := strings.Cut(ksa, "/")
+	if !ok || ns == "" || sa == "" || strings.Contains(sa, "/") {
+		return WorkloadIdentityBinding{}, fmt.Errorf("invalid k8s service account %q in workload identity binding %q, expected NAMESPACE/SERVICE_ACCOUNT", ksa, value)
+	}
+	return WorkloadIdentityBinding{CloudIdentity: identity, Namespace: ns, ServiceAccount: sa}, nil
+}
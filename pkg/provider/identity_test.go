@@ -0,0 +1,55 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestParseWorkloadIdentityBinding(t *testing.T) {
+	testCases := []struct {
+		value   string
+		binding WorkloadIdentityBinding
+		err     bool
+	}{
+		{
+			value:   "loadgen@project.iam.gserviceaccount.com=prombench-10/loadgen",
+			binding: WorkloadIdentityBinding{CloudIdentity: "loadgen@project.iam.gserviceaccount.com", Namespace: "prombench-10", ServiceAccount: "loadgen"},
+		},
+		{
+			value:   "arn:aws:iam::123456789012:role/prombench-loadgen=prombench-10/loadgen",
+			binding: WorkloadIdentityBinding{CloudIdentity: "arn:aws:iam::123456789012:role/prombench-loadgen", Namespace: "prombench-10", ServiceAccount: "loadgen"},
+		},
+		{value: "prombench-10/loadgen", err: true},
+		{value: "=prombench-10/loadgen", err: true},
+		{value: "loadgen@project.iam.gserviceaccount.com=loadgen", err: true},
+		{value: "loadgen@project.iam.gserviceaccount.com=prombench-10/", err: true},
+		{value: "loadgen@project.iam.gserviceaccount.com=a/b/c", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			b, err := ParseWorkloadIdentityBinding(tc.value)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", b)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b != tc.binding {
+				t.Errorf("\nexpect %#v\ngot %#v", tc.binding, b)
+			}
+		})
+	}
+}
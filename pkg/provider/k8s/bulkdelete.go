@@ -0,0 +1,219 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// DefaultBulkDeleteKinds are the kinds deleted by BulkDelete when none are given, in the order they are deleted:
+// the controllers first so they don't recreate the objects deleted after them, then the pods and the rest.
+var DefaultBulkDeleteKinds = []string{
+	"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "CronJob", "Job", "Pod",
+	"Service", "Ingress", "ConfigMap", "Secret", "PersistentVolumeClaim",
+	"ServiceAccount", "RoleBinding", "Role", "NetworkPolicy",
+}
+
+// bulkDeleteMaxErrors is the number of failed deletes listed in the error of BulkDelete.
+const bulkDeleteMaxErrors = 5
+
+// BulkDeleteOptions are the options of the bulk delete of ResourceDelete.
+type BulkDeleteOptions struct {
+	// QPS is the maximum number of deletes per second, on top of the rate limits of the client.
+	QPS float64
+	// Kinds are deleted one after the other in this order, as kind or kind.group.
+	Kinds []string
+	// Progress is how often the progress is logged, 0 only logs the totals.
+	Progress time.Duration
+}
+
+// bulkTarget is an object listed by BulkDelete.
+type bulkTarget struct {
+	mapping   *meta.RESTMapping
+	namespace string
+	name      string
+}
+
+// bulkDelete deletes all objects of the kinds in the namespaces of the deployments at most QPS objects per second,
+// e.g. to tear down a namespace with thousands of objects without flooding the api server.
+// The objects are listed page by page and deleted in the order of the kinds, then the objects of the deployments
+// that are not in these namespaces are deleted and the namespaces last.
+// A failed delete doesn't stop the others, the failures are returned once the namespaces are deleted.
+func (c *K8s) bulkDelete(deployments []Resource) error {
+	opts := c.BulkDelete
+	if opts.QPS <= 0 {
+		return errors.Errorf("invalid bulk delete qps %v, must be > 0", opts.QPS)
+	}
+	if len(opts.Kinds) == 0 {
+		return errors.New("the bulk delete requires at least one kind")
+	}
+	if opts.Progress < 0 {
+		return errors.Errorf("invalid bulk delete progress interval %v, must be >= 0", opts.Progress)
+	}
+
+	namespaces, rest, err := splitNamespaces(deployments)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) == 0 {
+		return c.deleteObjects(deployments)
+	}
+	targets, err := c.bulkTargets(namespaces, opts.Kinds)
+	if err != nil {
+		return err
+	}
+	log.Printf("bulk delete started - %d object(s) in %d namespace(s) at %v deletes/s", len(targets), len(namespaces), opts.QPS)
+
+	limiter := flowcontrol.NewTokenBucketRateLimiter(float32(opts.QPS), 1)
+	defer limiter.Stop()
+	start := time.Now()
+	lastProgress := start
+	var deleted int
+	var failures []string
+	for i, t := range targets {
+		limiter.Accept()
+		client := c.dynamicClient.Resource(t.mapping.Resource).Namespace(t.namespace)
+		if err := client.Delete(c.ctx, t.name, c.deleteOptions()); err != nil && !IsNotFound(err) {
+			failures = append(failures, fmt.Sprintf("%v: %v", t.ref(), err))
+		} else {
+			deleted++
+		}
+		if opts.Progress > 0 && time.Since(lastProgress) >= opts.Progress {
+			lastProgress = time.Now()
+			log.Printf("bulk delete progress - %s", formatBulkProgress(i+1, len(targets), len(failures), time.Since(start)))
+		}
+	}
+	log.Printf("bulk delete completed - %d object(s) deleted, %d failed in %v", deleted, len(failures), time.Since(start).Round(time.Second))
+
+	if err := c.deleteObjects(rest); err != nil {
+		return err
+	}
+	var nsObjects []runtime.Object
+	for _, ns := range namespaces {
+		nsObjects = append(nsObjects, ns)
+	}
+	if err := c.deleteObjects([]Resource{{FileName: "bulk delete namespaces", Objects: nsObjects}}); err != nil {
+		return err
+	}
+	return bulkDeleteError(failures)
+}
+
+func (t bulkTarget) ref() objectRef {
+	return objectRef{Kind: t.mapping.GroupVersionKind.Kind, Namespace: t.namespace, Name: t.name}
+}
+
+// splitNamespaces returns the namespaces of the deployments
+// and the other objects of the deployments that are not in one of these namespaces.
+func splitNamespaces(deployments []Resource) ([]*apiCoreV1.Namespace, []Resource, error) {
+	var namespaces []*apiCoreV1.Namespace
+	names := map[string]bool{}
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			if ns, ok := resource.(*apiCoreV1.Namespace); ok && !names[ns.Name] {
+				namespaces = append(namespaces, ns)
+				names[ns.Name] = true
+			}
+		}
+	}
+	var rest []Resource
+	for _, deployment := range deployments {
+		var objects []runtime.Object
+		for _, resource := range deployment.Objects {
+			if _, ok := resource.(*apiCoreV1.Namespace); ok {
+				continue
+			}
+			accessor, err := meta.Accessor(resource)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "reading the metadata of an object of %v", deployment.FileName)
+			}
+			namespace := accessor.GetNamespace()
+			if namespace == "" {
+				namespace = "default"
+			}
+			if names[namespace] {
+				continue
+			}
+			objects = append(objects, resource)
+		}
+		if len(objects) > 0 {
+			rest = append(rest, Resource{FileName: deployment.FileName, Objects: objects})
+		}
+	}
+	return namespaces, rest, nil
+}
+
+// bulkTargets lists the objects of the kinds in the namespaces, in the order of the kinds.
+// The kinds the cluster doesn't serve are skipped.
+func (c *K8s) bulkTargets(namespaces []*apiCoreV1.Namespace, kinds []string) ([]bulkTarget, error) {
+	var targets []bulkTarget
+	for _, kind := range kinds {
+		mapping, err := c.kindMapping(kind)
+		if err != nil {
+			if meta.IsNoMatchError(errors.Cause(err)) {
+				log.Printf("bulk delete - skipping the kind %v, the cluster doesn't serve it", kind)
+				continue
+			}
+			return nil, errors.Wrapf(err, "bulk delete kinds")
+		}
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			return nil, errors.Errorf("bulk delete kinds: %v is not namespaced", kind)
+		}
+		for _, ns := range namespaces {
+			items, err := c.List(mapping.Resource, ns.Name, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				targets = append(targets, bulkTarget{mapping: mapping, namespace: ns.Name, name: item.GetName()})
+			}
+		}
+	}
+	return targets, nil
+}
+
+// formatBulkProgress formats the progress of a bulk delete with its rate and the estimated time left.
+func formatBulkProgress(done, total, failed int, elapsed time.Duration) string {
+	rate := float64(done) / elapsed.Seconds()
+	progress := fmt.Sprintf("%d/%d object(s) (%d%%), %d failed, %.1f/s", done, total, done*100/total, failed, rate)
+	if rate > 0 && done < total {
+		left := time.Duration(float64(total-done) / rate * float64(time.Second))
+		progress += fmt.Sprintf(", %v left", left.Round(time.Second))
+	}
+	return progress
+}
+
+// bulkDeleteError returns an error listing the first failed deletes, nil without failures.
+func bulkDeleteError(failures []string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	listed := failures
+	if len(listed) > bulkDeleteMaxErrors {
+		listed = listed[:bulkDeleteMaxErrors]
+	}
+	msg := strings.Join(listed, "; ")
+	if more := len(failures) - len(listed); more > 0 {
+		msg += fmt.Sprintf("; and %d more", more)
+	}
+	return errors.Errorf("bulk delete failed for %d object(s): %s", len(failures), msg)
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+const bulkDeleteManifest = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: prombench
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: prombench
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: prombench
+`
+
+func TestBulkDelete(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	var objects []runtime.Object
+	const total = listPageSize + 17
+	for i := 0; i < total; i++ {
+		objects = append(objects, &apiCoreV1.ConfigMap{
+			TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: apiMetaV1.ObjectMeta{Name: fmt.Sprintf("cm-%04d", i), Namespace: "prombench"},
+		})
+	}
+	objects = append(objects,
+		&apiCoreV1.Pod{TypeMeta: apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"}, ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen-0", Namespace: "prombench"}},
+		// Not in the namespaces of the manifests.
+		&apiCoreV1.ConfigMap{TypeMeta: apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, ObjectMeta: apiMetaV1.ObjectMeta{Name: "cm-other", Namespace: "default"}},
+	)
+
+	fake := dynamicFake.NewSimpleDynamicClient(scheme.Scheme, objects...)
+	var requests int
+	c := newFakeK8s(
+		&apiCoreV1.Namespace{ObjectMeta: apiMetaV1.ObjectMeta{Name: "prombench"}},
+		&rbac.ClusterRole{ObjectMeta: apiMetaV1.ObjectMeta{Name: "prombench"}},
+	)
+	c.mapper = mapper
+	c.dynamicClient = pagedClient{Interface: fake, requests: &requests}
+	// CronJob isn't served by the mapper and is skipped.
+	c.BulkDelete = BulkDeleteOptions{QPS: 1e6, Kinds: []string{"ConfigMap", "CronJob", "Pod"}}
+
+	if err := c.ResourceDelete(decodeManifest(t, bulkDeleteManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var deleted []string
+	for _, a := range fake.Actions() {
+		if d, ok := a.(k8sTesting.DeleteAction); ok {
+			deleted = append(deleted, d.GetResource().Resource+"/"+d.GetName())
+		}
+	}
+	if len(deleted) != total+1 {
+		t.Fatalf("want %d deleted objects, got %d", total+1, len(deleted))
+	}
+	if last := deleted[len(deleted)-1]; last != "pods/loadgen-0" {
+		t.Errorf("want the pods deleted after the configmaps, got the last delete %v", last)
+	}
+	if _, err := fake.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("default").Get(c.ctx, "cm-other", apiMetaV1.GetOptions{}); err != nil {
+		t.Errorf("want the configmap of another namespace kept, got: %v", err)
+	}
+	if _, err := c.clt.CoreV1().Namespaces().Get(c.ctx, "prombench", apiMetaV1.GetOptions{}); !IsNotFound(err) {
+		t.Errorf("want the namespace deleted, got: %v", err)
+	}
+	if _, err := c.clt.RbacV1().ClusterRoles().Get(c.ctx, "prombench", apiMetaV1.GetOptions{}); !IsNotFound(err) {
+		t.Errorf("want the cluster role of the manifests deleted, got: %v", err)
+	}
+
+	c.BulkDelete.QPS = -1
+	if err := c.ResourceDelete(decodeManifest(t, bulkDeleteManifest)); err == nil {
+		t.Error("expected an error for a negative qps")
+	}
+}
+
+func TestBulkDeleteError(t *testing.T) {
+	if err := bulkDeleteError(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var failures []string
+	for i := 0; i < bulkDeleteMaxErrors+2; i++ {
+		failures = append(failures, fmt.Sprintf("cm-%d: forbidden", i))
+	}
+	err := bulkDeleteError(failures)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "7 object(s)") || !strings.Contains(msg, "and 2 more") || strings.Contains(msg, "cm-5") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+}
+
+func TestFormatBulkProgress(t *testing.T) {
+	if got, want := formatBulkProgress(100, 400, 1, 10*time.Second), "100/400 object(s) (25%), 1 failed, 10.0/s, 30s left"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// The backoff between the connection attempts of Connect.
+const (
+	connectBackoffInitial = time.Second
+	connectBackoffMax     = 30 * time.Second
+)
+
+// Connect returns a k8s client once it is created and the api server is reachable.
+// Both are retried with an exponential backoff until the timeout expires,
+// e.g. when a pod starts before its in-cluster config or the api server are ready.
+// A timeout of 0 tries only once.
+func Connect(ctx context.Context, config *clientcmdapi.Config, limits RateLimits, tls TLSOptions, timeout time.Duration) (*K8s, error) {
+	var c *K8s
+	err := retryWithBackoff(ctx, timeout, connectBackoffInitial, connectBackoffMax, func() error {
+		var err error
+		if c, err = New(ctx, config, limits, tls); err != nil {
+			return err
+		}
+		return c.CheckConnection()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// retryWithBackoff calls fn until it succeeds or the timeout expires,
+// doubling the wait between the attempts from initial up to max.
+func retryWithBackoff(ctx context.Context, timeout, initial, max time.Duration, fn func() error) error {
+	deadline := time.Now().Add(timeout)
+	backoff := initial
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errors.Wrapf(err, "k8s connection failed after %d attempts in %s", attempt, timeout)
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		log.Printf("k8s connection failed, retrying in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(err, "k8s connection cancelled after %d attempts", attempt)
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > max {
+			backoff = max
+		}
+	}
+}
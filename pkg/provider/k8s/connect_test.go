@@ -0,0 +1,58 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryWithBackoff(t *testing.T) {
+	errNotReady := errors.New("not ready")
+
+	attempts := 0
+	err := retryWithBackoff(context.Background(), time.Second, time.Millisecond, 4*time.Millisecond, func() error {
+		if attempts++; attempts < 4 {
+			return errNotReady
+		}
+		return nil
+	})
+	if err != nil || attempts != 4 {
+		t.Errorf("expected success after 4 attempts, got %d attempts and err: %v", attempts, err)
+	}
+
+	attempts = 0
+	err = retryWithBackoff(context.Background(), 20*time.Millisecond, time.Millisecond, 4*time.Millisecond, func() error {
+		attempts++
+		return errNotReady
+	})
+	if !errors.Is(err, errNotReady) || !strings.Contains(err.Error(), "k8s connection failed after") {
+		t.Errorf("expected the timeout error to include the last error, got: %v", err)
+	}
+	if attempts < 2 {
+		t.Errorf("expected the connection to be retried, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	err = retryWithBackoff(context.Background(), 0, time.Millisecond, time.Millisecond, func() error {
+		attempts++
+		return errNotReady
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("expected a single failed attempt with a 0 timeout, got %d attempts and err: %v", attempts, err)
+	}
+}
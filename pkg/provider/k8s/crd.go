@@ -0,0 +1,164 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// crdGVR is the api resource used to read the status of the CRDs, whatever version they were applied with.
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// apiExtensionsEstablished is the condition of a CRD whose api is served.
+const apiExtensionsEstablished = "Established"
+
+// EstablishError is returned by ApplyAndWaitEstablished when some CRDs aren't established before the timeout.
+type EstablishError struct {
+	Timeout time.Duration
+	// CRDs are the names of the CRDs that aren't established, sorted.
+	CRDs []string
+	// States has the last observed state of each CRD, by name.
+	States map[string]string
+}
+
+func (e *EstablishError) Error() string {
+	var pending []string
+	for _, name := range e.CRDs {
+		pending = append(pending, fmt.Sprintf("%v (%v)", name, e.States[name]))
+	}
+	return fmt.Sprintf("CRDs not established after %v: %v", e.Timeout, strings.Join(pending, "; "))
+}
+
+// ApplyAndWaitEstablished applies the CRDs in the resources first, waits for all of them to be Established
+// and then applies the rest of the objects, e.g. so that an operator doesn't fail watching
+// or creating the custom resources of CRDs the api server doesn't serve yet.
+// The objects keep the order of the files within each of the two groups.
+// On timeout the rest of the objects isn't applied and an *EstablishError with the pending CRDs is returned.
+// A zero timeout applies all objects in order without waiting, the same as ResourceApply.
+func (c *K8s) ApplyAndWaitEstablished(deployments []Resource, timeout time.Duration) error {
+	crds, rest := splitCRDs(deployments)
+	if timeout <= 0 || len(crds) == 0 {
+		return c.ResourceApply(deployments)
+	}
+	if err := c.ResourceApply(crds); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, deployment := range crds {
+		for _, resource := range deployment.Objects {
+			accessor, err := meta.Accessor(resource)
+			if err != nil {
+				return errors.Wrapf(err, "reading the CRD metadata of '%v'", deployment.FileName)
+			}
+			names = append(names, accessor.GetName())
+		}
+	}
+	if err := c.waitEstablished(names, timeout); err != nil {
+		return err
+	}
+
+	// The discovery cache doesn't know the kinds of the new CRDs yet.
+	if m, ok := c.mapper.(interface{ Reset() }); ok {
+		m.Reset()
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	return c.ResourceApply(rest)
+}
+
+// waitEstablished blocks until the CRDs with the given names have the Established condition.
+func (c *K8s) waitEstablished(names []string, timeout time.Duration) error {
+	client := c.dynamicClient.Resource(crdGVR)
+	pending := map[string]string{}
+	for _, name := range names {
+		pending[name] = "not checked yet"
+	}
+
+	err := wait.PollImmediate(waitPollInterval, timeout, func() (bool, error) {
+		for name := range pending {
+			obj, err := client.Get(c.ctx, name, apiMetaV1.GetOptions{})
+			if apiErrors.IsNotFound(err) {
+				pending[name] = "not found"
+				continue
+			}
+			if err != nil {
+				return false, errors.Wrapf(err, "getting CustomResourceDefinition/%v", name)
+			}
+			cond, err := findCondition(obj, apiExtensionsEstablished)
+			if err != nil {
+				return false, errors.Wrapf(err, "reading the conditions of CustomResourceDefinition/%v", name)
+			}
+			if cond == nil {
+				pending[name] = "no Established condition"
+				continue
+			}
+			if cond.Status != apiMetaV1.ConditionTrue {
+				pending[name] = formatCondition(cond)
+				continue
+			}
+			log.Printf("CRD established - %v", name)
+			delete(pending, name)
+		}
+		if len(pending) > 0 {
+			log.Printf("Waiting for %d CRD(s) to be established.", len(pending))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		e := &EstablishError{Timeout: timeout, States: pending}
+		for name := range pending {
+			e.CRDs = append(e.CRDs, name)
+		}
+		sort.Strings(e.CRDs)
+		return e
+	}
+	return err
+}
+
+// splitCRDs returns the files with only the CRDs and the files with the rest of the objects, skipping the empty ones.
+func splitCRDs(deployments []Resource) (crds, rest []Resource) {
+	for _, deployment := range deployments {
+		c := Resource{FileName: deployment.FileName}
+		r := Resource{FileName: deployment.FileName}
+		for _, resource := range deployment.Objects {
+			gvk := resource.GetObjectKind().GroupVersionKind()
+			if gvk.Group == crdGVR.Group && gvk.Kind == "CustomResourceDefinition" {
+				c.Objects = append(c.Objects, resource)
+			} else {
+				r.Objects = append(r.Objects, resource)
+			}
+		}
+		if len(c.Objects) > 0 {
+			crds = append(crds, c)
+		}
+		if len(r.Objects) > 0 {
+			rest = append(rest, r)
+		}
+	}
+	return crds, rest
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func newCRD(name string, conditions ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": name},
+		"status":     map[string]interface{}{"conditions": conditions},
+	}}
+}
+
+func TestWaitEstablished(t *testing.T) {
+	established := map[string]interface{}{"type": "Established", "status": "True"}
+	pending := map[string]interface{}{"type": "Established", "status": "False", "reason": "Installing"}
+
+	c := newFakeK8s()
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{crdGVR: "CustomResourceDefinitionList"},
+		newCRD("prometheuses.monitoring.coreos.com", established),
+		newCRD("alertmanagers.monitoring.coreos.com", pending),
+		newCRD("probes.monitoring.coreos.com"),
+	)
+
+	if err := c.waitEstablished([]string{"prometheuses.monitoring.coreos.com"}, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := c.waitEstablished([]string{
+		"prometheuses.monitoring.coreos.com",
+		"probes.monitoring.coreos.com",
+		"alertmanagers.monitoring.coreos.com",
+		"podmonitors.monitoring.coreos.com",
+	}, time.Millisecond)
+	var e *EstablishError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected an EstablishError, got %v", err)
+	}
+	expected := []string{"alertmanagers.monitoring.coreos.com", "podmonitors.monitoring.coreos.com", "probes.monitoring.coreos.com"}
+	if !reflect.DeepEqual(e.CRDs, expected) {
+		t.Errorf("expected pending CRDs %v, got %v", expected, e.CRDs)
+	}
+	for name, state := range map[string]string{
+		"alertmanagers.monitoring.coreos.com": "Established=False, reason: Installing",
+		"podmonitors.monitoring.coreos.com":   "not found",
+		"probes.monitoring.coreos.com":        "no Established condition",
+	} {
+		if e.States[name] != state {
+			t.Errorf("expected state %q of %v, got %q", state, name, e.States[name])
+		}
+	}
+}
+
+func TestSplitCRDs(t *testing.T) {
+	crd := newCRD("prometheuses.monitoring.coreos.com")
+	cm := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]interface{}{"name": "config"}}}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": map[string]interface{}{"name": "operator"}}}
+
+	crds, rest := splitCRDs([]Resource{
+		{FileName: "crds.yaml", Objects: []runtime.Object{crd}},
+		{FileName: "operator.yaml", Objects: []runtime.Object{cm, crd, deployment}},
+		{FileName: "config.yaml", Objects: []runtime.Object{cm}},
+	})
+	expectedCRDs := []Resource{
+		{FileName: "crds.yaml", Objects: []runtime.Object{crd}},
+		{FileName: "operator.yaml", Objects: []runtime.Object{crd}},
+	}
+	expectedRest := []Resource{
+		{FileName: "operator.yaml", Objects: []runtime.Object{cm, deployment}},
+		{FileName: "config.yaml", Objects: []runtime.Object{cm}},
+	}
+	if !reflect.DeepEqual(crds, expectedCRDs) {
+		t.Errorf("expected CRDs %v, got %v", expectedCRDs, crds)
+	}
+	if !reflect.DeepEqual(rest, expectedRest) {
+		t.Errorf("expected the rest %v, got %v", expectedRest, rest)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// deleteOptions returns the options of the objects deleted by ResourceDelete,
+// the dependents are deleted first and then the object with the DeleteGracePeriod.
+func (c *K8s) deleteOptions() apiMetaV1.DeleteOptions {
+	delPolicy := apiMetaV1.DeletePropagationForeground
+	return apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy, GracePeriodSeconds: c.DeleteGracePeriod}
+}
+
+// checkDeleteOptions returns an error for a grace period of 0 without ForceDelete,
+// it removes the pods from the API before their containers are stopped.
+func (c *K8s) checkDeleteOptions() error {
+	if c.DeleteGracePeriod == nil {
+		return nil
+	}
+	switch {
+	case *c.DeleteGracePeriod < 0:
+		return errors.Errorf("invalid grace period %d, must be >= 0", *c.DeleteGracePeriod)
+	case *c.DeleteGracePeriod == 0 && !c.ForceDelete:
+		return errors.New("a grace period of 0 deletes the pods immediately and requires the force delete")
+	}
+	return nil
+}
+
+// forceDeletePods deletes the pods of a deleted workload or namespace immediately,
+// instead of waiting for the garbage collector to delete them with their own grace period.
+// Pods stuck terminating, e.g. on an unreachable node, are removed from the API as well.
+func (c *K8s) forceDeletePods(namespace string, selector *apiMetaV1.LabelSelector) error {
+	if !c.ForceDelete {
+		return nil
+	}
+	sel := ""
+	if selector != nil {
+		s, err := apiMetaV1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return errors.Wrapf(err, "invalid pod selector in namespace: %v", namespace)
+		}
+		sel = s.String()
+	}
+	pods, err := c.ListPods(namespace, sel)
+	if err != nil {
+		return err
+	}
+	zero := int64(0)
+	for _, pod := range pods {
+		if err := c.clt.CoreV1().Pods(namespace).Delete(c.ctx, pod.Name, apiMetaV1.DeleteOptions{GracePeriodSeconds: &zero}); err != nil && !IsNotFound(err) {
+			return errors.Wrapf(err, "force delete failed - kind: Pod, name: %v", pod.Name)
+		}
+	}
+	if len(pods) > 0 {
+		log.Printf("force deleted %d pods in namespace: %v, selector: %v", len(pods), namespace, sel)
+	}
+	return nil
+}
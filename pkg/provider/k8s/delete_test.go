@@ -0,0 +1,94 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const deleteManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+spec:
+  selector:
+    matchLabels:
+      app: loadgen
+  template:
+    metadata:
+      labels:
+        app: loadgen
+    spec:
+      containers:
+      - name: loadgen
+        image: loadgen
+`
+
+func TestDeleteOptions(t *testing.T) {
+	c := newFakeK8s()
+	if opts := c.deleteOptions(); opts.GracePeriodSeconds != nil || *opts.PropagationPolicy != apiMetaV1.DeletePropagationForeground {
+		t.Errorf("want a foreground delete with the grace period of the object by default, got %+v", opts)
+	}
+	grace := int64(30)
+	c.DeleteGracePeriod = &grace
+	if opts := c.deleteOptions(); opts.GracePeriodSeconds == nil || *opts.GracePeriodSeconds != 30 {
+		t.Errorf("want a grace period of 30s, got %+v", opts)
+	}
+	if err := c.checkDeleteOptions(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	grace = 0
+	if err := c.checkDeleteOptions(); err == nil {
+		t.Error("expected an error for a grace period of 0 without the force delete")
+	}
+	c.ForceDelete = true
+	if err := c.checkDeleteOptions(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestForceDelete(t *testing.T) {
+	pod := func(name, app string) *apiCoreV1.Pod {
+		return &apiCoreV1.Pod{ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Namespace: "prombench", Labels: map[string]string{"app": app}}}
+	}
+	resources := decodeManifest(t, deleteManifest)
+	objects := []runtime.Object{resources[0].Objects[0].DeepCopyObject(), pod("loadgen-1", "loadgen"), pod("loadgen-2", "loadgen"), pod("prometheus-0", "prometheus")}
+
+	c := newFakeK8s(objects...)
+	if err := c.ResourceDelete(resources); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if pods, _ := c.ListPods("prombench", ""); len(pods) != 3 {
+		t.Errorf("want the pods left to the garbage collector without the force delete, got %d pods", len(pods))
+	}
+
+	c = newFakeK8s(objects...)
+	c.ForceDelete = true
+	if err := c.ResourceDelete(resources); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	pods, err := c.ListPods("prombench", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 1 || pods[0].Name != "prometheus-0" {
+		t.Errorf("want only the pods of the deleted deployment deleted, got %v", pods)
+	}
+}
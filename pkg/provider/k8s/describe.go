@@ -0,0 +1,71 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Describe returns the live object as YAML, e.g. to look at a failing benchmark without switching to kubectl.
+// The object is given as kind/name, with kinds of other groups as kind.group/name, e.g. Rollout.argoproj.io/loadgen.
+// The namespace is ignored for cluster scoped kinds and defaults to "default" for namespaced kinds.
+// The managed fields are noisy and left out unless showManagedFields is set.
+func (c *K8s) Describe(object, namespace string, showManagedFields bool) ([]byte, error) {
+	kind, name, ok := strings.Cut(object, "/")
+	if !ok || kind == "" || name == "" {
+		return nil, fmt.Errorf("invalid object %q, expected kind/name", object)
+	}
+	live, err := c.liveObject(kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !showManagedFields {
+		live.SetManagedFields(nil)
+	}
+
+	out, err := yaml.Marshal(live.Object)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshaling %v as yaml", object)
+	}
+	return out, nil
+}
+
+// liveObject gets the object of the kind, given as kind or kind.group, from the cluster.
+// The namespace is ignored for cluster scoped kinds and defaults to "default" for namespaced kinds.
+func (c *K8s) liveObject(kind, namespace, name string) (*unstructured.Unstructured, error) {
+	mapping, err := c.kindMapping(kind)
+	if err != nil {
+		return nil, err
+	}
+	var live *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if namespace == "" {
+			namespace = "default"
+		}
+		live, err = c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+	} else {
+		live, err = c.dynamicClient.Resource(mapping.Resource).Get(c.ctx, name, apiMetaV1.GetOptions{})
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %v/%v", kind, name)
+	}
+	return live, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const describeLiveManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus-meta
+  namespace: prombench-1234
+  managedFields:
+  - manager: infra
+    operation: Update
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: prombench-1234
+`
+
+func TestDescribe(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	c := newFakeK8s()
+	c.mapper = mapper
+	objects := decodeManifest(t, describeLiveManifest)[0].Objects
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, objects...)
+
+	out, err := c.Describe("Deployment/prometheus-meta", "prombench-1234", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"kind: Deployment", "name: prometheus-meta", "replicas: 2"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("want %q in the output, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "managedFields") {
+		t.Errorf("want the managed fields left out, got:\n%s", out)
+	}
+
+	out, err = c.Describe("Deployment/prometheus-meta", "prombench-1234", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "manager: infra") {
+		t.Errorf("want the managed fields in the output, got:\n%s", out)
+	}
+
+	// The namespace is ignored for cluster scoped kinds.
+	if out, err = c.Describe("Namespace/prombench-1234", "default", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "kind: Namespace") {
+		t.Errorf("want the namespace in the output, got:\n%s", out)
+	}
+
+	for _, object := range []string{"Deployment/missing", "Deployment", "Unknown/prometheus-meta"} {
+		if _, err := c.Describe(object, "prombench-1234", false); err == nil {
+			t.Errorf("expected an error describing %v", object)
+		}
+	}
+}
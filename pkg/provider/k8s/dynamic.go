@@ -0,0 +1,80 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// objectRef identifies a single object for the operations that work with any object kind.
+type objectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (o objectRef) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%v/%v", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%v/%v/%v", o.Kind, o.Namespace, o.Name)
+}
+
+// dynamicResource returns the dynamic client for the object's kind
+// together with a reference to the object.
+// Namespaced objects without a namespace use the "default" namespace, the same as when applying them.
+func (c *K8s) dynamicResource(resource runtime.Object) (dynamic.ResourceInterface, objectRef, error) {
+	gvk := resource.GetObjectKind().GroupVersionKind()
+	accessor, err := meta.Accessor(resource)
+	if err != nil {
+		return nil, objectRef{}, errors.Wrapf(err, "reading the object metadata - kind: %v", gvk.Kind)
+	}
+	ref := objectRef{Kind: gvk.Kind, Name: accessor.GetName()}
+
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, ref, errors.Wrapf(err, "finding the api resource - kind: %v, name: %v", gvk.Kind, ref.Name)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return c.dynamicClient.Resource(mapping.Resource), ref, nil
+	}
+
+	ref.Namespace = accessor.GetNamespace()
+	if ref.Namespace == "" {
+		ref.Namespace = "default"
+	}
+	return c.dynamicClient.Resource(mapping.Resource).Namespace(ref.Namespace), ref, nil
+}
+
+// kindMapping returns the api resource of a kind given as kind or kind.group, e.g. Deployment or Rollout.argoproj.io.
+// Kinds without a group are resolved the same way as kubectl, preferring the groups of the built-in kinds.
+func (c *K8s) kindMapping(kind string) (*meta.RESTMapping, error) {
+	gk := schema.ParseGroupKind(kind)
+	gvk, err := c.mapper.KindFor(schema.GroupVersionResource{Group: gk.Group, Resource: strings.ToLower(gk.Kind)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unknown kind %v", kind)
+	}
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding the api resource of kind %v", kind)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"errors"
+	"fmt"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ApplyError is returned by ResourceApply when an object can't be applied.
+// It keeps the api error, so callers can branch with the Is* helpers instead of matching the message.
+type ApplyError struct {
+	FileName  string
+	Kind      string
+	Namespace string
+	Name      string
+	Err       error
+}
+
+func newApplyError(fileName string, resource runtime.Object, err error) *ApplyError {
+	e := &ApplyError{
+		FileName: fileName,
+		Kind:     resource.GetObjectKind().GroupVersionKind().Kind,
+		Err:      err,
+	}
+	if m, mErr := meta.Accessor(resource); mErr == nil {
+		e.Namespace, e.Name = m.GetNamespace(), m.GetName()
+	}
+	return e
+}
+
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("error applying '%v' err:%v", e.FileName, e.Err)
+}
+
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// IsConflict returns true when the object was changed since it was read, the apply can be retried right away.
+func IsConflict(err error) bool {
+	return apiErrors.IsConflict(err)
+}
+
+// IsNotFound returns true when the object or its namespace doesn't exist.
+func IsNotFound(err error) bool {
+	return apiErrors.IsNotFound(err)
+}
+
+// IsAlreadyExists returns true when the object was created by someone else since it was listed.
+func IsAlreadyExists(err error) bool {
+	return apiErrors.IsAlreadyExists(err)
+}
+
+// IsForbidden returns true when the credentials aren't allowed to apply the object, retrying doesn't help.
+func IsForbidden(err error) bool {
+	return apiErrors.IsForbidden(err) || apiErrors.IsUnauthorized(err)
+}
+
+// IsInvalid returns true when the api server rejected the object, retrying doesn't help.
+func IsInvalid(err error) bool {
+	return apiErrors.IsInvalid(err) || apiErrors.IsBadRequest(err)
+}
+
+// IsTransient returns true for errors that may succeed when retried later,
+// e.g. conflicts, throttling or an api server that is temporarily unavailable.
+func IsTransient(err error) bool {
+	return IsConflict(err) ||
+		apiErrors.IsServerTimeout(err) ||
+		apiErrors.IsTimeout(err) ||
+		apiErrors.IsTooManyRequests(err) ||
+		apiErrors.IsServiceUnavailable(err) ||
+		apiErrors.IsInternalError(err)
+}
+
+// AsApplyError returns the ApplyError of err, if any.
+func AsApplyError(err error) (*ApplyError, bool) {
+	var e *ApplyError
+	ok := errors.As(err, &e)
+	return e, ok
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+const applyErrorManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench-1234
+`
+
+func TestApplyErrors(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	for _, tc := range []struct {
+		name                                              string
+		err                                               error
+		conflict, notFound, forbidden, invalid, transient bool
+	}{
+		{name: "conflict", err: apiErrors.NewConflict(gr, "loadgen", fmt.Errorf("the object has been modified")), conflict: true, transient: true},
+		{name: "not found", err: apiErrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "prombench-1234"), notFound: true},
+		{name: "forbidden", err: apiErrors.NewForbidden(gr, "loadgen", fmt.Errorf("no rbac")), forbidden: true},
+		{name: "unauthorized", err: apiErrors.NewUnauthorized("expired token"), forbidden: true},
+		{name: "invalid", err: apiErrors.NewBadRequest("spec.replicas: Invalid value"), invalid: true},
+		{name: "throttled", err: apiErrors.NewTooManyRequests("slow down", 1), transient: true},
+		{name: "unavailable", err: apiErrors.NewServiceUnavailable("restarting"), transient: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s()
+			c.clt.(*fake.Clientset).PrependReactor("create", "deployments", func(k8sTesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.err
+			})
+			err := c.ResourceApply(decodeManifest(t, applyErrorManifest))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range []string{"error applying 'manifest.yaml'", "resource creation failed - kind: Deployment, name: loadgen", tc.err.Error()} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("want %q in the error, got %v", want, err)
+				}
+			}
+			ae, ok := AsApplyError(err)
+			if !ok {
+				t.Fatalf("want an ApplyError, got %T", err)
+			}
+			if ae.Kind != "Deployment" || ae.Namespace != "prombench-1234" || ae.Name != "loadgen" || ae.FileName != "manifest.yaml" {
+				t.Errorf("unexpected apply error fields %+v", ae)
+			}
+			for name, got := range map[string][2]bool{
+				"IsConflict":  {IsConflict(err), tc.conflict},
+				"IsNotFound":  {IsNotFound(err), tc.notFound},
+				"IsForbidden": {IsForbidden(err), tc.forbidden},
+				"IsInvalid":   {IsInvalid(err), tc.invalid},
+				"IsTransient": {IsTransient(err), tc.transient},
+			} {
+				if got[0] != got[1] {
+					t.Errorf("%v: want %v, got %v", name, got[1], got[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	eventsV1 "k8s.io/api/events/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxEvents caps the number of events returned by GetEvents.
+const maxEvents = 20
+
+// GetEvents returns the most recent events in the namespace, newest first and at most maxEvents.
+// When involved objects are given only the events for objects with these names
+// or names derived from them, e.g. the replica sets and pods of a deployment, are returned.
+func (c *K8s) GetEvents(namespace string, involvedObjects ...string) ([]apiCoreV1.Event, error) {
+	list, err := c.clt.CoreV1().Events(namespace).List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing events in namespace: %v", namespace)
+	}
+
+	var events []apiCoreV1.Event
+	for _, e := range list.Items {
+		if len(involvedObjects) == 0 || involves(e.InvolvedObject.Name, involvedObjects) {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).After(eventTime(events[j]))
+	})
+	if len(events) > maxEvents {
+		events = events[:maxEvents]
+	}
+	return events, nil
+}
+
+func involves(name string, objects []string) bool {
+	for _, o := range objects {
+		if name == o || strings.HasPrefix(name, o+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// eventTime returns the last time the event was seen.
+func eventTime(e apiCoreV1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.FirstTimestamp.Time
+}
+
+// logEvents logs the recent events of an object to explain why it didn't become ready.
+func (c *K8s) logEvents(namespace, name string) {
+	events, err := c.GetEvents(namespace, name)
+	if err != nil {
+		log.Printf("couldn't get the events for %v/%v: %v", namespace, name, err)
+		return
+	}
+	if len(events) == 0 {
+		log.Printf("no events for %v/%v", namespace, name)
+		return
+	}
+	log.Printf("recent events for %v/%v:", namespace, name)
+	for _, e := range events {
+		log.Printf("\t%v %v %v/%v %v: %v", eventTime(e).Format(time.RFC3339), e.Type, e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Reason, e.Message)
+	}
+}
+
+// ObjectEvent is an event about an object created through the events.k8s.io API,
+// e.g. so that kubectl get events shows the actions of a tool next to the events of the cluster.
+type ObjectEvent struct {
+	// Regarding is the object the event is about, only the kind, api version, namespace and name are needed.
+	Regarding apiCoreV1.ObjectReference
+	// Reason is a short CamelCase reason and Action what the tool did, e.g. Scaled and Scale.
+	Reason string
+	Action string
+	Note   string
+	// Warning events report failures, the other events are Normal.
+	Warning bool
+	// ReportingController and ReportingInstance identify the tool and its replica that created the event.
+	ReportingController string
+	ReportingInstance   string
+}
+
+// ObjectReference returns the reference of a live object for the events about it.
+// The reference includes the uid, so kubectl describe shows the events with the object.
+func (c *K8s) ObjectReference(gvr schema.GroupVersionResource, namespace, name string) (apiCoreV1.ObjectReference, error) {
+	obj, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+	if err != nil {
+		return apiCoreV1.ObjectReference{}, errors.Wrapf(err, "getting %v %v/%v", gvr.Resource, namespace, name)
+	}
+	return apiCoreV1.ObjectReference{
+		Kind:            obj.GetKind(),
+		APIVersion:      obj.GetAPIVersion(),
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		UID:             obj.GetUID(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}, nil
+}
+
+// maxEventNote is the longest note accepted by the events API.
+const maxEventNote = 1024
+
+// CreateEvent creates the event in the namespace of the object it is about.
+// A note longer than the events API accepts is truncated.
+func (c *K8s) CreateEvent(e ObjectEvent) error {
+	now := time.Now()
+	eventType := apiCoreV1.EventTypeNormal
+	if e.Warning {
+		eventType = apiCoreV1.EventTypeWarning
+	}
+	if len(e.Note) > maxEventNote {
+		e.Note = e.Note[:maxEventNote-3] + "..."
+	}
+	namespace := e.Regarding.Namespace
+	if namespace == "" {
+		namespace = apiMetaV1.NamespaceDefault
+	}
+	event := &eventsV1.Event{
+		ObjectMeta: apiMetaV1.ObjectMeta{
+			// The same name format as the events of the client-go event recorder.
+			Name:      fmt.Sprintf("%v.%x", e.Regarding.Name, now.UnixNano()),
+			Namespace: namespace,
+		},
+		EventTime:           apiMetaV1.NewMicroTime(now),
+		ReportingController: e.ReportingController,
+		ReportingInstance:   e.ReportingInstance,
+		Action:              e.Action,
+		Reason:              e.Reason,
+		Regarding:           e.Regarding,
+		Note:                e.Note,
+		Type:                eventType,
+	}
+	if _, err := c.clt.EventsV1().Events(namespace).Create(c.ctx, event, apiMetaV1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "creating the %v event of %v/%v", e.Reason, e.Regarding.Kind, e.Regarding.Name)
+	}
+	return nil
+}
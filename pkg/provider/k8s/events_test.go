@@ -0,0 +1,133 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsV1 "k8s.io/api/apps/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestGetEvents(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := func(i int, object string, at time.Time) *apiCoreV1.Event {
+		return &apiCoreV1.Event{
+			ObjectMeta:     apiMetaV1.ObjectMeta{Name: fmt.Sprintf("event-%d", i), Namespace: "prombench"},
+			InvolvedObject: apiCoreV1.ObjectReference{Kind: "Pod", Name: object},
+			Reason:         "FailedScheduling",
+			LastTimestamp:  apiMetaV1.NewTime(at),
+		}
+	}
+	objects := []runtime.Object{
+		event(0, "prometheus-7d9f8-abcde", start.Add(time.Minute)),
+		event(1, "prometheus", start.Add(3*time.Minute)),
+		event(2, "prometheus-7d9f8", start.Add(2*time.Minute)),
+		event(3, "prometheus-loadgen-xyz", start),
+		event(4, "node-exporter-xyz", start.Add(4*time.Minute)),
+	}
+	for i := 5; i < 5+maxEvents; i++ {
+		objects = append(objects, event(i, "fake-webserver-xyz", start.Add(time.Duration(i)*time.Hour)))
+	}
+	c := newFakeK8s(objects...)
+
+	events, err := c.GetEvents("prombench", "prometheus")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, e := range events {
+		names = append(names, e.Name)
+	}
+	// prometheus-loadgen-xyz also has the prometheus- prefix.
+	if fmt.Sprint(names) != "[event-1 event-2 event-0 event-3]" {
+		t.Errorf("expected the prometheus events newest first, got %v", names)
+	}
+
+	all, err := c.GetEvents("prombench")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != maxEvents {
+		t.Errorf("expected the events to be capped at %d, got %d", maxEvents, len(all))
+	}
+	if all[0].Name != fmt.Sprintf("event-%d", 4+maxEvents) {
+		t.Errorf("expected the newest event first, got %v", all[0].Name)
+	}
+}
+
+func TestCreateEvent(t *testing.T) {
+	c := newFakeK8s()
+	regarding := apiCoreV1.ObjectReference{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "prombench", Name: "loadgen"}
+	for _, e := range []ObjectEvent{
+		{Regarding: regarding, Reason: "Scaled", Action: "Scale", Note: "Scaled from 1 to 5 replicas", ReportingController: "scaler", ReportingInstance: "scaler-0"},
+		{Regarding: regarding, Reason: "ScaleFailed", Action: "Scale", Note: strings.Repeat("x", 2*maxEventNote), Warning: true},
+	} {
+		if err := c.CreateEvent(e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	list, err := c.clt.EventsV1().Events("prombench").List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("want 2 events, got %d", len(list.Items))
+	}
+	byReason := map[string]int{}
+	for i, e := range list.Items {
+		byReason[e.Reason] = i
+		if e.Regarding != regarding || e.Action != "Scale" || e.EventTime.IsZero() || !strings.HasPrefix(e.Name, "loadgen.") {
+			t.Errorf("unexpected event %+v", e)
+		}
+	}
+	scaled := list.Items[byReason["Scaled"]]
+	if scaled.Type != apiCoreV1.EventTypeNormal || scaled.Note != "Scaled from 1 to 5 replicas" || scaled.ReportingController != "scaler" || scaled.ReportingInstance != "scaler-0" {
+		t.Errorf("unexpected event %+v", scaled)
+	}
+	failed := list.Items[byReason["ScaleFailed"]]
+	if failed.Type != apiCoreV1.EventTypeWarning || len(failed.Note) != maxEventNote || !strings.HasSuffix(failed.Note, "...") {
+		t.Errorf("want a truncated warning event, got type %v and a note of %d bytes", failed.Type, len(failed.Note))
+	}
+}
+
+func TestObjectReference(t *testing.T) {
+	c := newFakeK8s()
+	live := decodeManifest(t, patchLiveManifest)[0].Objects[0].(*appsV1.Deployment)
+	live.UID = "1234-5678"
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, live)
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	ref, err := c.ObjectReference(deployments, "prombench", "loadgen")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := apiCoreV1.ObjectReference{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "prombench", Name: "loadgen", UID: "1234-5678"}
+	ref.ResourceVersion = ""
+	if ref != want {
+		t.Errorf("want %+v, got %+v", want, ref)
+	}
+	if _, err := c.ObjectReference(deployments, "prombench", "missing"); err == nil {
+		t.Error("expected an error for a missing object")
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// imageCheckTimeout limits every registry request of the image preflight.
+const imageCheckTimeout = 30 * time.Second
+
+// errImageNotFound is returned when the registry doesn't have the image or the registry doesn't exist.
+var errImageNotFound = errors.New("image not found")
+
+// manifestMediaTypes are accepted from the registry, so images with only an OCI index or a manifest list are found too.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// CheckImages checks that the images of the containers in the objects exist in their registries,
+// so a typo in an image tag fails before the apply instead of as an ImagePullBackOff after the wait times out.
+// Images that are not found are returned as an error, images that can't be checked, e.g. in a private registry
+// without anonymous pulls, are only logged. Containers with the Never pull policy are skipped.
+func (c *K8s) CheckImages(deployments []Resource) error {
+	return imageChecker{client: &http.Client{Timeout: imageCheckTimeout}}.checkAll(c.ctx, deployments)
+}
+
+type imageChecker struct {
+	client *http.Client
+}
+
+func (ic imageChecker) checkAll(ctx context.Context, deployments []Resource) error {
+	images := Images(deployments)
+	errs := make([]error, len(images))
+	var wg sync.WaitGroup
+	for i, image := range images {
+		wg.Add(1)
+		go func(i int, image string) {
+			defer wg.Done()
+			errs[i] = ic.check(ctx, image)
+		}(i, image)
+	}
+	wg.Wait()
+
+	var missing []string
+	for i, err := range errs {
+		switch {
+		case err == nil:
+		case errors.Is(err, errImageNotFound):
+			missing = append(missing, fmt.Sprintf("%v: %v", images[i], err))
+		default:
+			log.Printf("WARNING: couldn't check image %v, applying anyway: %v", images[i], err)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%d image(s) can't be pulled:\n  %v", len(missing), strings.Join(missing, "\n  "))
+	}
+	log.Printf("Checked %d image(s)", len(images))
+	return nil
+}
+
+// Images returns the sorted images of the containers and init containers in the workload objects,
+// without the containers that never pull their image.
+func Images(deployments []Resource) []string {
+	seen := map[string]bool{}
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			spec := podSpec(resource)
+			if spec == nil {
+				continue
+			}
+			for _, container := range append(append([]apiCoreV1.Container{}, spec.InitContainers...), spec.Containers...) {
+				if container.Image != "" && container.ImagePullPolicy != apiCoreV1.PullNever {
+					seen[container.Image] = true
+				}
+			}
+		}
+	}
+	images := make([]string, 0, len(seen))
+	for image := range seen {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// podSpec returns the pod spec of the workload kinds, nil for other kinds.
+func podSpec(obj runtime.Object) *apiCoreV1.PodSpec {
+	switch o := obj.(type) {
+	case *apiCoreV1.Pod:
+		return &o.Spec
+	case *appsV1.Deployment:
+		return &o.Spec.Template.Spec
+	case *appsV1.StatefulSet:
+		return &o.Spec.Template.Spec
+	case *appsV1.DaemonSet:
+		return &o.Spec.Template.Spec
+	case *appsV1.ReplicaSet:
+		return &o.Spec.Template.Spec
+	case *batchV1.Job:
+		return &o.Spec.Template.Spec
+	case *batchV1.CronJob:
+		return &o.Spec.JobTemplate.Spec.Template.Spec
+	}
+	return nil
+}
+
+// imageReference is an image name split into the registry host, the repository and the tag or digest.
+type imageReference struct {
+	registry   string
+	repository string
+	reference  string
+}
+
+// parseImageReference splits an image name the way the container runtimes do:
+// images without a registry host are on Docker Hub, in the library namespace without a namespace,
+// and images without a tag or digest use the latest tag.
+func parseImageReference(image string) (imageReference, error) {
+	name, ref := image, "latest"
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref = name[:i], name[i+1:]
+	}
+	// A digest takes precedence over the tag.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		if ref == "latest" {
+			ref = name[i+1:]
+		}
+		name = name[:i]
+	}
+
+	if name == "" {
+		return imageReference{}, fmt.Errorf("invalid image name %q", image)
+	}
+	r := imageReference{registry: "docker.io", repository: name, reference: ref}
+	if i := strings.Index(name, "/"); i >= 0 && (strings.ContainsAny(name[:i], ".:") || name[:i] == "localhost") {
+		r.registry, r.repository = name[:i], name[i+1:]
+	}
+	if r.registry == "docker.io" {
+		r.registry = "registry-1.docker.io"
+		if !strings.Contains(r.repository, "/") {
+			r.repository = "library/" + r.repository
+		}
+	}
+	if r.repository == "" || r.reference == "" || strings.ToLower(r.repository) != r.repository {
+		return imageReference{}, fmt.Errorf("invalid image name %q", image)
+	}
+	return r, nil
+}
+
+// check sends a HEAD request for the manifest of the image, with an anonymous token when the registry asks for one.
+func (ic imageChecker) check(ctx context.Context, image string) error {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return errors.Wrapf(errImageNotFound, "%v", err)
+	}
+	manifestURL := fmt.Sprintf("https://%v/v2/%v/manifests/%v", ref.registry, ref.repository, ref.reference)
+
+	resp, err := ic.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := ic.anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return err
+		}
+		if resp, err = ic.headManifest(ctx, manifestURL, token); err != nil {
+			return err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return errImageNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("the registry needs credentials, status %v", resp.Status)
+	}
+	return fmt.Errorf("unexpected registry response %v", resp.Status)
+}
+
+func (ic imageChecker) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := ic.client.Do(req)
+	if err != nil {
+		// A registry host that doesn't exist is most likely a typo as well.
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, errors.Wrapf(errImageNotFound, "registry host %v", dnsErr.Name)
+		}
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// anonymousToken gets a pull token from the realm of a Bearer challenge, e.g.
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull".
+func (ic imageChecker) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("the registry needs credentials, unsupported auth challenge %q", challenge)
+	}
+	values := url.Values{}
+	var realm string
+	for _, param := range strings.Split(params, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			continue
+		}
+		v = strings.Trim(v, `"`)
+		if k == "realm" {
+			realm = v
+			continue
+		}
+		values.Set(k, v)
+	}
+	if realm == "" {
+		return "", fmt.Errorf("the registry needs credentials, auth challenge %q without a realm", challenge)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := ic.client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting a registry token")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the registry needs credentials, getting an anonymous token returned %v", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", errors.Wrapf(err, "decoding the registry token")
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
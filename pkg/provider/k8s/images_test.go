@@ -0,0 +1,148 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	for _, tc := range []struct {
+		image string
+		want  imageReference
+	}{
+		{"nginx", imageReference{"registry-1.docker.io", "library/nginx", "latest"}},
+		{"prom/prometheus:v2.45.0", imageReference{"registry-1.docker.io", "prom/prometheus", "v2.45.0"}},
+		{"quay.io/prometheus/node-exporter:v1.6.0", imageReference{"quay.io", "prometheus/node-exporter", "v1.6.0"}},
+		{"localhost:5000/loadgen", imageReference{"localhost:5000", "loadgen", "latest"}},
+		{"gcr.io/project/app:v1@sha256:abc", imageReference{"gcr.io", "project/app", "sha256:abc"}},
+		{"localhost/app", imageReference{"localhost", "app", "latest"}},
+	} {
+		got, err := parseImageReference(tc.image)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.image, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%v: want %+v, got %+v", tc.image, tc.want, got)
+		}
+	}
+	for _, image := range []string{"Prom/Prometheus", "nginx:", "@sha256:abc"} {
+		if _, err := parseImageReference(image); err == nil {
+			t.Errorf("%v: expected an error", image)
+		}
+	}
+}
+
+const imagesManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+      containers:
+      - name: loadgen
+        image: prom/prometheus:v2.45.0
+      - name: local
+        image: loadgen:dev
+        imagePullPolicy: Never
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: querier
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: querier
+            image: busybox
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`
+
+func TestImages(t *testing.T) {
+	got := Images(decodeManifest(t, imagesManifest))
+	if want := []string{"busybox", "prom/prometheus:v2.45.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestCheckImages(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			if r.URL.Query().Get("scope") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token":"anonymous"}`)
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%v/token",service="registry",scope="repository:prombench:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method != http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/v2/prombench/loadgen/manifests/v1":
+		case strings.HasPrefix(r.URL.Path, "/v2/private/"):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	registry := srv.Listener.Addr().String()
+	ic := imageChecker{client: srv.Client()}
+
+	manifest := func(images ...string) []Resource {
+		m := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: loadgen\nspec:\n  containers:\n"
+		for i, image := range images {
+			m += fmt.Sprintf("  - name: c%d\n    image: %v/%v\n", i, registry, image)
+		}
+		return decodeManifest(t, m)
+	}
+
+	if err := ic.checkAll(context.Background(), manifest("prombench/loadgen:v1")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// Images that can't be checked don't fail the apply.
+	if err := ic.checkAll(context.Background(), manifest("prombench/loadgen:v1", "private/loadgen:v1")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := ic.checkAll(context.Background(), manifest("prombench/loadgen:v1", "prombench/loadgen:v2", "prombench/querier:v1"))
+	if err == nil {
+		t.Fatal("expected an error for the missing images")
+	}
+	for _, want := range []string{"2 image(s)", "prombench/loadgen:v2", "prombench/querier:v1"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("want %q in the error, got %v", want, err)
+		}
+	}
+}
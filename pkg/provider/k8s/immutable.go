@@ -0,0 +1,153 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// immutableFields are the fields the API server doesn't allow to change once an object is created, by kind.
+// Changing them fails the apply, the object has to be deleted and created again.
+var immutableFields = map[schema.GroupKind][]string{
+	{Kind: "Service"}:                                                 {"spec.clusterIP"},
+	{Kind: "PersistentVolumeClaim"}:                                   {"spec.accessModes", "spec.storageClassName", "spec.volumeMode", "spec.selector", "spec.dataSource"},
+	{Kind: "PersistentVolume"}:                                        {"spec.volumeMode", "spec.nodeAffinity", "spec.csi", "spec.hostPath", "spec.local", "spec.nfs", "spec.gcePersistentDisk", "spec.awsElasticBlockStore"},
+	{Group: "apps", Kind: "Deployment"}:                               {"spec.selector"},
+	{Group: "apps", Kind: "DaemonSet"}:                                {"spec.selector"},
+	{Group: "apps", Kind: "StatefulSet"}:                              {"spec.selector", "spec.serviceName", "spec.podManagementPolicy", "spec.volumeClaimTemplates"},
+	{Group: "batch", Kind: "Job"}:                                     {"spec.selector", "spec.completionMode", "spec.template"},
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}:         {"roleRef"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:  {"roleRef"},
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                {"spec.controller"},
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: {"spec.scope"},
+}
+
+// ImmutableChange is an object of the manifests whose apply would change immutable fields of its live object.
+type ImmutableChange struct {
+	FileName string
+	Object   string
+	// Fields describe the changed fields as the path with the live and the wanted value.
+	Fields []string
+}
+
+func (ic ImmutableChange) String() string {
+	return fmt.Sprintf("%v: %v: %v", ic.FileName, ic.Object, strings.Join(ic.Fields, ", "))
+}
+
+// CheckImmutableFields fails listing the objects whose apply would change immutable fields of their live objects,
+// so the objects that need to be recreated are known before anything is applied instead of failing halfway through.
+func (c *K8s) CheckImmutableFields(deployments []Resource) error {
+	changes, err := c.ImmutableChanges(deployments)
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		lines := make([]string, 0, len(changes))
+		for _, ic := range changes {
+			lines = append(lines, ic.String())
+		}
+		return fmt.Errorf("%d object(s) change immutable fields and need to be deleted and created again:\n  %v", len(changes), strings.Join(lines, "\n  "))
+	}
+	log.Printf("Checked the immutable fields of the live objects")
+	return nil
+}
+
+// ImmutableChanges returns the objects whose apply would change immutable fields of their live objects.
+// Objects that don't exist yet are created, so they have no changes.
+func (c *K8s) ImmutableChanges(deployments []Resource) ([]ImmutableChange, error) {
+	var changes []ImmutableChange
+	for _, deployment := range deployments {
+		for _, obj := range deployment.Objects {
+			fields, ref, err := c.immutableDiff(obj)
+			if err != nil {
+				return nil, newApplyError(deployment.FileName, obj, err)
+			}
+			if len(fields) > 0 {
+				changes = append(changes, ImmutableChange{FileName: deployment.FileName, Object: ref.String(), Fields: fields})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// immutableDiff returns the changed immutable fields of the object compared to its live object.
+// Only the fields set in the object are compared like for the drift, so defaults set by the cluster are no change.
+func (c *K8s) immutableDiff(obj runtime.Object) ([]string, objectRef, error) {
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	paths := immutableFields[gk]
+	if len(paths) == 0 && gk != (schema.GroupKind{Kind: "ConfigMap"}) && gk != (schema.GroupKind{Kind: "Secret"}) {
+		return nil, objectRef{}, nil
+	}
+	client, ref, err := c.dynamicResource(obj)
+	if err != nil {
+		return nil, ref, err
+	}
+	live, err := client.Get(c.ctx, ref.Name, apiMetaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return nil, ref, nil
+	}
+	if err != nil {
+		return nil, ref, errors.Wrapf(err, "getting %v", ref)
+	}
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, ref, errors.Wrapf(err, "converting %v", ref)
+	}
+
+	// The data of the config maps and secrets marked as immutable can't change.
+	if immutable, _, _ := unstructured.NestedBool(live.Object, "immutable"); immutable {
+		paths = []string{"data", "binaryData"}
+		secretStringData(desired)
+	}
+
+	var fields []string
+	for _, path := range paths {
+		field := strings.Split(path, ".")
+		d, _, _ := unstructured.NestedFieldNoCopy(desired, field...)
+		l, _, _ := unstructured.NestedFieldNoCopy(live.Object, field...)
+		if diff := unstructuredDiff("."+path, d, l); diff != "" {
+			fields = append(fields, diff)
+		}
+	}
+	return fields, ref, nil
+}
+
+// secretStringData moves the stringData of a secret to its data, the way the API server stores it.
+func secretStringData(desired map[string]interface{}) {
+	stringData, ok := desired["stringData"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	data, ok := desired["data"].(map[string]interface{})
+	if !ok {
+		data = map[string]interface{}{}
+		desired["data"] = data
+	}
+	for k, v := range stringData {
+		if s, ok := v.(string); ok {
+			data[k] = base64.StdEncoding.EncodeToString([]byte(s))
+		}
+	}
+	delete(desired, "stringData")
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const immutableManifest = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: prometheus
+  namespace: prombench
+spec:
+  clusterIP: 10.0.0.10
+  ports:
+  - port: 9090
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: prometheus
+  namespace: prombench
+spec:
+  serviceName: prometheus
+  replicas: 1
+  selector:
+    matchLabels:
+      app: prometheus
+  template:
+    metadata:
+      labels:
+        app: prometheus
+    spec:
+      containers:
+      - name: prometheus
+        image: prom/prometheus:v2.45.0
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      accessModes: [ReadWriteOnce]
+      resources:
+        requests:
+          storage: 10Gi
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rules
+  namespace: prombench
+immutable: true
+data:
+  rules.yaml: groups
+`
+
+func TestImmutableChanges(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, meta.RESTScopeNamespace)
+
+	for _, tc := range []struct {
+		name string
+		// old and new edit the manifest to get the applied objects, the live objects are the ones of the manifest.
+		old, new string
+		want     []ImmutableChange
+	}{
+		{name: "no changes"},
+		{name: "mutable field", old: "replicas: 1", new: "replicas: 3"},
+		{name: "image", old: "prom/prometheus:v2.45.0", new: "prom/prometheus:v2.46.0"},
+		{
+			name: "cluster ip",
+			old:  "clusterIP: 10.0.0.10",
+			new:  "clusterIP: 10.0.0.20",
+			want: []ImmutableChange{{FileName: "manifest.yaml", Object: "Service/prombench/prometheus", Fields: []string{".spec.clusterIP is 10.0.0.10, want 10.0.0.20"}}},
+		},
+		{
+			name: "volume claim templates",
+			old:  "storage: 10Gi",
+			new:  "storage: 20Gi",
+			want: []ImmutableChange{{FileName: "manifest.yaml", Object: "StatefulSet/prombench/prometheus", Fields: []string{".spec.volumeClaimTemplates[0].spec.resources.requests.storage is 10Gi, want 20Gi"}}},
+		},
+		{
+			name: "immutable config map",
+			old:  "rules.yaml: groups",
+			new:  "rules.yaml: other",
+			want: []ImmutableChange{{FileName: "manifest.yaml", Object: "ConfigMap/prombench/rules", Fields: []string{".data.rules.yaml is groups, want other"}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s()
+			c.mapper = mapper
+			c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, immutableManifest)[0].Objects...)
+
+			resources := decodeManifest(t, strings.Replace(immutableManifest, tc.old, tc.new, 1))
+			resources[0].FileName = "manifest.yaml"
+			got, err := c.ImmutableChanges(resources)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want the changes %v, got %v", tc.want, got)
+			}
+			if err := c.CheckImmutableFields(resources); (err != nil) != (len(tc.want) > 0) {
+				t.Errorf("unexpected check result: %v", err)
+			}
+		})
+	}
+
+	t.Run("missing objects", func(t *testing.T) {
+		c := newFakeK8s()
+		c.mapper = mapper
+		c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme)
+		if err := c.CheckImmutableFields(decodeManifest(t, immutableManifest)); err != nil {
+			t.Errorf("want no changes for the objects that are created, got %v", err)
+		}
+	})
+}
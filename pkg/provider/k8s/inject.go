@@ -0,0 +1,56 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// injectMetadata merges the injected labels and annotations into the object metadata.
+// Labels and annotations already set in the manifest are kept unless ForceInject is set.
+func (c *K8s) injectMetadata(resource runtime.Object) error {
+	if len(c.InjectLabels) == 0 && len(c.InjectAnnotations) == 0 {
+		return nil
+	}
+	obj, err := meta.Accessor(resource)
+	if err != nil {
+		return errors.Wrapf(err, "accessing the object metadata")
+	}
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	obj.SetLabels(mergeMetadata(obj.GetLabels(), c.InjectLabels, c.ForceInject, "label", kind, obj.GetName()))
+	obj.SetAnnotations(mergeMetadata(obj.GetAnnotations(), c.InjectAnnotations, c.ForceInject, "annotation", kind, obj.GetName()))
+	return nil
+}
+
+// mergeMetadata returns the current values with the injected values added.
+func mergeMetadata(current, injected map[string]string, force bool, field, kind, name string) map[string]string {
+	if len(injected) == 0 {
+		return current
+	}
+	if current == nil {
+		current = make(map[string]string, len(injected))
+	}
+	for k, v := range injected {
+		if existing, ok := current[k]; ok && existing != v && !force {
+			log.Printf("keeping the existing %v %v=%v, not injecting %v - kind: %v, name: %v", field, k, existing, v, kind, name)
+			continue
+		}
+		current[k] = v
+	}
+	return current
+}
@@ -17,9 +17,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/alecthomas/kingpin.v2"
 	appsV1 "k8s.io/api/apps/v1"
 	batchV1 "k8s.io/api/batch/v1"
@@ -30,12 +33,19 @@ import (
 	apiServerExtensionsV1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiServerExtensionsClient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/retry"
@@ -57,8 +67,13 @@ type Resource struct {
 
 // K8s holds the fields used to generate API request from within a cluster.
 type K8s struct {
-	clt          *kubernetes.Clientset
+	clt          kubernetes.Interface
 	ApiExtClient *apiServerExtensionsClient.Clientset
+	// dynamicClient and mapper are used for the operations that work with any object kind.
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+	// applyDuration is only exposed after calling RegisterMetrics.
+	applyDuration *prometheus.HistogramVec
 	// DeploymentFiles files provided from the cli.
 	DeploymentFiles []string
 	// Variables to substitute in the DeploymentFiles.
@@ -66,12 +81,53 @@ type K8s struct {
 	DeploymentVars map[string]string
 	// K8s resource.runtime objects after parsing the template variables, grouped by filename.
 	resources []Resource
+	// Labels and annotations added to every applied object.
+	InjectLabels      map[string]string
+	InjectAnnotations map[string]string
+	// ForceInject overwrites the labels and annotations already set in the manifests.
+	ForceInject bool
+	// Owner, as kind/name in OwnerNamespace, is set as the owner of the applied objects in its namespace,
+	// so deleting it deletes them through the garbage collector.
+	Owner          string
+	OwnerNamespace string
+	// NoWait returns right after the deployments, statefulsets, daemonsets and jobs are applied
+	// instead of waiting for them to become ready.
+	NoWait bool
+	// ImagePreflight checks that the images of the objects exist in their registries before anything is applied.
+	ImagePreflight bool
+	// ImmutablePreflight checks that the objects don't change immutable fields of their live objects before anything is applied.
+	ImmutablePreflight bool
+	// VPARecommendationTimeout waits up to this long after the apply for the VerticalPodAutoscalers of the objects
+	// to have a recommendation, 0 doesn't wait.
+	VPARecommendationTimeout time.Duration
+	// ServerSideApply applies all objects with a server-side apply instead of creating or updating them,
+	// PruneFields also removes the fields the applies set before that are no longer in the manifests.
+	ServerSideApply bool
+	PruneFields     bool
+	// DeleteGracePeriod is the grace period in seconds of the objects deleted by ResourceDelete, nil keeps their own,
+	// e.g. the terminationGracePeriodSeconds of the pods. ForceDelete also deletes the pods of the deleted workloads
+	// and namespaces immediately instead of waiting for them to terminate.
+	DeleteGracePeriod *int64
+	ForceDelete       bool
+	// BulkDelete makes ResourceDelete delete all objects in the namespaces of the manifests at a limited rate,
+	// disabled when its QPS is 0.
+	BulkDelete BulkDeleteOptions
 
 	ctx context.Context
 }
 
+// RateLimits are the client side rate limits of the k8s REST client.
+// Zero values keep the client-go defaults of 5 QPS and a burst of 10.
+// Retries are the retry policies of the requests by operation class, nil doesn't retry.
+type RateLimits struct {
+	QPS     float32
+	Burst   int
+	Retries provider.RetryPolicies
+}
+
 // New returns a k8s client that can apply and delete resources.
-func New(ctx context.Context, config *clientcmdapi.Config) (*K8s, error) {
+// The TLS options override the verification of the api server certificate of the config.
+func New(ctx context.Context, config *clientcmdapi.Config, limits RateLimits, tls TLSOptions) (*K8s, error) {
 	var restConfig *rest.Config
 	var err error
 	if config == nil {
@@ -82,6 +138,18 @@ func New(ctx context.Context, config *clientcmdapi.Config) (*K8s, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "k8s config error")
 	}
+	if err := tls.apply(restConfig); err != nil {
+		return nil, err
+	}
+	if limits.QPS > 0 {
+		restConfig.QPS = limits.QPS
+	}
+	if limits.Burst > 0 {
+		restConfig.Burst = limits.Burst
+	}
+	if limits.Retries != nil {
+		restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper { return newRetryTransport(rt, limits.Retries) })
+	}
 
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
@@ -93,14 +161,69 @@ func New(ctx context.Context, config *clientcmdapi.Config) (*K8s, error) {
 		return nil, errors.Wrapf(err, "k8s api extensions client error")
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "k8s dynamic client error")
+	}
+
 	return &K8s{
 		ctx:            ctx,
 		clt:            clientset,
 		ApiExtClient:   apiExtClientset,
+		dynamicClient:  dynamicClient,
+		mapper:         restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+		applyDuration:  newApplyDurationHistogram(),
 		DeploymentVars: make(map[string]string),
 	}, nil
 }
 
+// NewForClientset returns a client of the clientset with the given resources as the parsed deployment files,
+// e.g. for a fake clientset in the tests of the tools built on the provider.
+// The operations that need the dynamic client, like Scale, aren't available.
+func NewForClientset(ctx context.Context, clt kubernetes.Interface, resources []Resource) *K8s {
+	return &K8s{
+		ctx:            ctx,
+		clt:            clt,
+		resources:      resources,
+		applyDuration:  newApplyDurationHistogram(),
+		DeploymentVars: make(map[string]string),
+	}
+}
+
+// NewForDeployment returns a k8s client with the rate limits, the retries, the TLS options and the apply and delete options
+// of the deployment resource, the client used by the resource commands of all providers.
+func NewForDeployment(ctx context.Context, config *clientcmdapi.Config, dr *provider.DeploymentResource) (*K8s, error) {
+	c, err := New(ctx, config, RateLimits{QPS: dr.K8sQPS, Burst: dr.K8sBurst, Retries: dr.K8sRetries}, TLSOptions{CAFile: dr.K8sCAFile, InsecureSkipVerify: dr.K8sInsecureSkipTLSVerify})
+	if err != nil {
+		return nil, err
+	}
+	c.InjectLabels = dr.InjectLabels
+	c.InjectAnnotations = dr.InjectAnnotations
+	c.ForceInject = dr.ForceInject
+	c.Owner = dr.Owner
+	c.OwnerNamespace = dr.OwnerNamespace
+	c.NoWait = dr.NoWait
+	c.ImagePreflight = dr.ImagePreflight
+	c.ImmutablePreflight = dr.ImmutablePreflight
+	c.VPARecommendationTimeout = dr.VPARecommendationTimeout
+	c.ServerSideApply = dr.ServerSideApply
+	c.PruneFields = dr.PruneFields
+	c.DeleteGracePeriod = dr.GracePeriod()
+	c.ForceDelete = dr.ForceDelete
+	c.BulkDelete = BulkDeleteOptions{QPS: dr.BulkDeleteQPS, Kinds: dr.BulkDeleteKinds, Progress: dr.BulkDeleteProgress}
+	return c, nil
+}
+
+// CheckConnection returns an error when the k8s api server isn't reachable.
+func (c *K8s) CheckConnection() error {
+	v, err := c.clt.Discovery().ServerVersion()
+	if err != nil {
+		return errors.Wrapf(err, "k8s api server not reachable")
+	}
+	log.Printf("k8s api server version: %v", v.GitVersion)
+	return nil
+}
+
 // GetResources is a getter function for Resources field in K8s.
 func (c *K8s) GetResources() []Resource {
 	return c.resources
@@ -114,9 +237,55 @@ func (c *K8s) DeploymentsParse(*kingpin.ParseContext) error {
 		log.Fatalf("Couldn't parse deployment files: %v", err)
 	}
 
+	resources, err := decodeResources(deploymentResource)
+	if err != nil {
+		return err
+	}
+	c.resources = append(c.resources, resources...)
+	return nil
+}
+
+// ParseFiles parses the k8s objects of the files the same way as DeploymentsParse
+// and returns them grouped by the filename, without storing them in the client.
+func ParseFiles(files []string, vars map[string]string) ([]Resource, error) {
+	deploymentResource, err := provider.DeploymentsParse(files, vars)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResources(deploymentResource)
+}
+
+// unstructuredKinds are the kinds without Go types in the scheme,
+// they are decoded as unstructured objects and applied with the dynamic client.
+var unstructuredKinds = []schema.GroupKind{{Group: vpaResource.Group, Kind: "VerticalPodAutoscaler"}}
+
+// Decode decodes a single k8s object of a manifest, the objects of the unstructuredKinds as *unstructured.Unstructured.
+func Decode(data []byte) (runtime.Object, error) {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err == nil || !runtime.IsNotRegisteredError(err) {
+		return obj, err
+	}
+	content, jsonErr := yaml.ToJSON(data)
+	if jsonErr != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	if _, _, jsonErr := unstructured.UnstructuredJSONScheme.Decode(content, nil, u); jsonErr != nil {
+		return nil, err
+	}
+	for _, gk := range unstructuredKinds {
+		if gk == u.GroupVersionKind().GroupKind() {
+			return u, nil
+		}
+	}
+	return nil, err
+}
+
+// decodeResources decodes the k8s objects of the parsed files.
+func decodeResources(deploymentResource []provider.Resource) ([]Resource, error) {
+	var resources []Resource
 	for _, deployment := range deploymentResource {
 
-		decode := scheme.Codecs.UniversalDeserializer().Decode
 		k8sObjects := make([]runtime.Object, 0)
 
 		for _, text := range strings.Split(string(deployment.Content), provider.Separator) {
@@ -125,9 +294,9 @@ func (c *K8s) DeploymentsParse(*kingpin.ParseContext) error {
 				continue
 			}
 
-			resource, _, err := decode([]byte(text), nil, nil)
+			resource, err := Decode([]byte(text))
 			if err != nil {
-				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, text[:100])
+				return nil, errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, provider.Truncate(text, 100))
 			}
 			if resource == nil {
 				continue
@@ -135,19 +304,58 @@ func (c *K8s) DeploymentsParse(*kingpin.ParseContext) error {
 			k8sObjects = append(k8sObjects, resource)
 		}
 		if len(k8sObjects) > 0 {
-			c.resources = append(c.resources, Resource{FileName: deployment.FileName, Objects: k8sObjects})
+			resources = append(resources, Resource{FileName: deployment.FileName, Objects: k8sObjects})
 		}
 	}
-	return nil
+	return resources, nil
 }
 
 // ResourceApply applies k8s objects.
 // The input is a slice of structs containing the filename and the slice of k8s objects present in the file.
 func (c *K8s) ResourceApply(deployments []Resource) error {
-
-	var err error
+	if c.PruneFields && !c.ServerSideApply {
+		return errors.New("pruning the fields requires the server-side apply")
+	}
+	owner, err := c.resolveOwner()
+	if err != nil {
+		return err
+	}
+	if c.ImagePreflight {
+		if err := c.CheckImages(deployments); err != nil {
+			return err
+		}
+	}
+	if c.ImmutablePreflight {
+		if err := c.CheckImmutableFields(deployments); err != nil {
+			return err
+		}
+	}
+	if hasNetworkPolicy(deployments) {
+		c.warnNetworkPolicyEnforcement()
+	}
+	vpas := vpaRefs(deployments)
+	if len(vpas) > 0 {
+		if err := c.checkVPAAPI(); err != nil {
+			return err
+		}
+	}
 	for _, deployment := range deployments {
 		for _, resource := range deployment.Objects {
+			if err := c.injectMetadata(resource); err != nil {
+				return newApplyError(deployment.FileName, resource, err)
+			}
+			if err := c.setOwner(resource, owner); err != nil {
+				return newApplyError(deployment.FileName, resource, err)
+			}
+			start := time.Now()
+			if c.ServerSideApply {
+				err = c.serverSideApply(resource)
+				c.observeApplyDuration(resource, start)
+				if err != nil {
+					return newApplyError(deployment.FileName, resource, err)
+				}
+				continue
+			}
 			switch kind := strings.ToLower(resource.GetObjectKind().GroupVersionKind().Kind); kind {
 			case "clusterrole":
 				err = c.clusterRoleApply(resource)
@@ -161,6 +369,8 @@ func (c *K8s) ResourceApply(deployments []Resource) error {
 				err = c.deploymentApply(resource)
 			case "ingress":
 				err = c.ingressApply(resource)
+			case "ingressclass":
+				err = c.ingressClassApply(resource)
 			case "namespace":
 				err = c.nameSpaceApply(resource)
 			case "role":
@@ -173,29 +383,56 @@ func (c *K8s) ResourceApply(deployments []Resource) error {
 				err = c.serviceAccountApply(resource)
 			case "secret":
 				err = c.secretApply(resource)
+			case "persistentvolume":
+				err = c.persistentVolumeApply(resource)
 			case "persistentvolumeclaim":
 				err = c.persistentVolumeClaimApply(resource)
 			case "customresourcedefinition":
 				err = c.customResourceApply(resource)
 			case "statefulset":
 				err = c.statefulSetApply(resource)
+			case "replicaset":
+				err = c.replicaSetApply(resource)
 			case "job":
 				err = c.jobApply(resource)
+			case "resourcequota":
+				err = c.resourceQuotaApply(resource)
+			case "limitrange":
+				err = c.limitRangeApply(resource)
+			case "networkpolicy":
+				err = c.networkPolicyApply(resource)
+			case "verticalpodautoscaler":
+				err = c.vpaApply(resource)
 			default:
 				err = fmt.Errorf("creating request for unimplimented resource type:%v", kind)
 			}
+			c.observeApplyDuration(resource, start)
 			if err != nil {
-				return fmt.Errorf("error applying '%v' err:%v", deployment.FileName, err)
+				return newApplyError(deployment.FileName, resource, err)
 			}
 		}
 	}
+	if len(vpas) > 0 && c.VPARecommendationTimeout > 0 {
+		return c.waitVPARecommendations(vpas, c.VPARecommendationTimeout)
+	}
 	return nil
 }
 
 // ResourceDelete deletes k8s objects.
 // The input is a slice of structs containing the filename and the slice of k8s objects present in the file.
+// With BulkDelete all objects in the namespaces of the files are deleted as well, see bulkDelete.
 func (c *K8s) ResourceDelete(deployments []Resource) error {
+	if err := c.checkDeleteOptions(); err != nil {
+		return err
+	}
+	if c.BulkDelete.QPS != 0 {
+		return c.bulkDelete(deployments)
+	}
+	return c.deleteObjects(deployments)
+}
 
+// deleteObjects deletes the objects of the deployments in order.
+func (c *K8s) deleteObjects(deployments []Resource) error {
 	var err error
 	for _, deployment := range deployments {
 		for _, resource := range deployment.Objects {
@@ -212,6 +449,8 @@ func (c *K8s) ResourceDelete(deployments []Resource) error {
 				err = c.deploymentDelete(resource)
 			case "ingress":
 				err = c.ingressDelete(resource)
+			case "ingressclass":
+				err = c.ingressClassDelete(resource)
 			case "namespace":
 				err = c.namespaceDelete(resource)
 			case "role":
@@ -224,14 +463,26 @@ func (c *K8s) ResourceDelete(deployments []Resource) error {
 				err = c.serviceAccountDelete(resource)
 			case "secret":
 				err = c.secretDelete(resource)
+			case "persistentvolume":
+				err = c.persistentVolumeDelete(resource)
 			case "persistentvolumeclaim":
 				err = c.persistentVolumeClaimDelete(resource)
 			case "customresourcedefinition":
 				err = c.customResourceDelete(resource)
 			case "statefulset":
 				err = c.statefulSetDelete(resource)
+			case "replicaset":
+				err = c.replicaSetDelete(resource)
 			case "job":
 				err = c.jobDelete(resource)
+			case "resourcequota":
+				err = c.resourceQuotaDelete(resource)
+			case "limitrange":
+				err = c.limitRangeDelete(resource)
+			case "networkpolicy":
+				err = c.networkPolicyDelete(resource)
+			case "verticalpodautoscaler":
+				err = c.vpaDelete(resource)
 			default:
 				err = fmt.Errorf("deleting request for unimplimented resource type:%v", kind)
 			}
@@ -406,6 +657,9 @@ func (c *K8s) daemonSetApply(resource runtime.Object) error {
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
 	}
+	if c.NoWait {
+		return nil
+	}
 	return c.daemonsetReady(resource)
 }
 
@@ -449,10 +703,17 @@ func (c *K8s) deploymentApply(resource runtime.Object) error {
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
 	}
-	return provider.RetryUntilTrue(
+	if c.NoWait {
+		return nil
+	}
+	if err := provider.RetryUntilTrue(
 		fmt.Sprintf("applying deployment:%v", req.Name),
 		provider.GlobalRetryCount,
-		func() (bool, error) { return c.deploymentReady(resource) })
+		func() (bool, error) { return c.deploymentReady(resource) }); err != nil {
+		c.logEvents(req.Namespace, req.Name)
+		return err
+	}
+	return nil
 }
 
 func (c *K8s) statefulSetApply(resource runtime.Object) error {
@@ -495,10 +756,70 @@ func (c *K8s) statefulSetApply(resource runtime.Object) error {
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
 	}
 
-	return provider.RetryUntilTrue(
+	if c.NoWait {
+		return nil
+	}
+	if err := provider.RetryUntilTrue(
 		fmt.Sprintf("applying statefulSet:%v", req.Name),
 		provider.GlobalRetryCount,
-		func() (bool, error) { return c.statefulSetReady(resource) })
+		func() (bool, error) { return c.statefulSetReady(resource) }); err != nil {
+		c.logEvents(req.Namespace, req.Name)
+		return err
+	}
+	return nil
+}
+
+func (c *K8s) replicaSetApply(resource runtime.Object) error {
+	req := resource.(*appsV1.ReplicaSet)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.AppsV1().ReplicaSets(req.Namespace)
+		list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
+		}
+		var exists bool
+		for _, l := range list.Items {
+			if l.Name == req.Name {
+				exists = true
+				break
+			}
+		}
+
+		if exists {
+			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				_, err := client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.Name)
+			}
+			log.Printf("resource updated - kind: %v, name: %v", kind, req.Name)
+		} else {
+			if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+				return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.Name)
+			}
+			log.Printf("resource created - kind: %v, name: %v", kind, req.Name)
+		}
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+
+	if c.NoWait {
+		return nil
+	}
+	if err := provider.RetryUntilTrue(
+		fmt.Sprintf("applying replicaSet:%v", req.Name),
+		provider.GlobalRetryCount,
+		func() (bool, error) { return c.replicaSetReady(resource) }); err != nil {
+		c.logEvents(req.Namespace, req.Name)
+		return err
+	}
+	return nil
 }
 
 func (c *K8s) jobApply(resource runtime.Object) error {
@@ -539,6 +860,9 @@ func (c *K8s) jobApply(resource runtime.Object) error {
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
 	}
+	if c.NoWait {
+		return nil
+	}
 	const Infinite int = 1<<31 - 1
 	return provider.RetryUntilTrue(
 		fmt.Sprintf("running job:%v", req.Name),
@@ -588,20 +912,80 @@ func (c *K8s) customResourceApply(resource runtime.Object) error {
 }
 
 func (c *K8s) ingressApply(resource runtime.Object) error {
-	req := resource.(*apiNetworkingV1.Ingress)
+	req, ok := resource.(*apiNetworkingV1.Ingress)
 	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if !ok {
+		return fmt.Errorf("unknown object version: %v kind:'%v', only networking.k8s.io/v1 is supported", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind)
+	}
 	if len(req.Namespace) == 0 {
 		req.Namespace = "default"
 	}
 
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
+		if className := req.Spec.IngressClassName; className != nil {
+			if _, err := c.clt.NetworkingV1().IngressClasses().Get(c.ctx, *className, apiMetaV1.GetOptions{}); err != nil {
+				if !apiErrors.IsNotFound(err) {
+					return errors.Wrapf(err, "error getting the ingress class: %v, kind: %v, name: %v", *className, kind, req.Name)
+				}
+				log.Printf("WARNING: ingress class %v doesn't exist, the ingress won't be served until it is created - kind: %v, name: %v", *className, kind, req.Name)
+			}
+		}
+
 		client := c.clt.NetworkingV1().Ingresses(req.Namespace)
 		list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
 		if err != nil {
 			return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
 		}
 
+		var exists bool
+		for _, l := range list.Items {
+			if l.Name == req.Name {
+				exists = true
+				if req.ResourceVersion == "" {
+					req.ResourceVersion = l.ResourceVersion
+				}
+				// The status holds the addresses assigned by the ingress controller
+				// and isn't part of the manifest so keep the current one.
+				req.Status = l.Status
+				break
+			}
+		}
+
+		if exists {
+			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				_, err := client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.Name)
+			}
+			log.Printf("resource updated - kind: %v, name: %v", kind, req.Name)
+			return nil
+		} else if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource created - kind: %v, name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) ingressClassApply(resource runtime.Object) error {
+	req, ok := resource.(*apiNetworkingV1.IngressClass)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if !ok {
+		return fmt.Errorf("unknown object version: %v kind:'%v', only networking.k8s.io/v1 is supported", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind)
+	}
+
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.NetworkingV1().IngressClasses()
+		list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
+		}
+
 		var exists bool
 		for _, l := range list.Items {
 			if l.Name == req.Name {
@@ -890,6 +1274,49 @@ func (c *K8s) secretApply(resource runtime.Object) error {
 	return nil
 }
 
+// persistentVolumeApply keeps the claim of a bound volume, the volume would otherwise be released from it.
+// The claim of a released volume is removed so it becomes available again for a new claim.
+func (c *K8s) persistentVolumeApply(resource runtime.Object) error {
+	req := resource.(*apiCoreV1.PersistentVolume)
+	kind := req.GetObjectKind().GroupVersionKind().Kind
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.CoreV1().PersistentVolumes()
+		list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
+		}
+
+		var existing *apiCoreV1.PersistentVolume
+		for i := range list.Items {
+			if list.Items[i].Name == req.Name {
+				existing = &list.Items[i]
+				break
+			}
+		}
+
+		if existing != nil {
+			if req.Spec.ClaimRef == nil && existing.Status.Phase == apiCoreV1.VolumeBound {
+				req.Spec.ClaimRef = existing.Spec.ClaimRef
+			}
+			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				_, err := client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.Name)
+			}
+			log.Printf("resource updated - kind: %v, name: %v", kind, req.Name)
+			return nil
+		} else if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource created - kind: %v, name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
 func (c *K8s) persistentVolumeClaimApply(resource runtime.Object) error {
 	req := resource.(*apiCoreV1.PersistentVolumeClaim)
 	kind := req.GetObjectKind().GroupVersionKind().Kind
@@ -931,6 +1358,137 @@ func (c *K8s) persistentVolumeClaimApply(resource runtime.Object) error {
 	return nil
 }
 
+func (c *K8s) resourceQuotaApply(resource runtime.Object) error {
+	req := resource.(*apiCoreV1.ResourceQuota)
+	kind := req.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+	if err := validateResourceQuota(req); err != nil {
+		return errors.Wrapf(err, "invalid resource - kind: %v, name: %v", kind, req.Name)
+	}
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.CoreV1().ResourceQuotas(req.Namespace)
+		list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
+		}
+
+		var exists bool
+		for _, l := range list.Items {
+			if l.Name == req.Name {
+				exists = true
+				break
+			}
+		}
+
+		if exists {
+			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				_, err := client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.Name)
+			}
+			log.Printf("resource updated - kind: %v, name: %v", kind, req.Name)
+			return nil
+		} else if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource created - kind: %v, name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) limitRangeApply(resource runtime.Object) error {
+	req := resource.(*apiCoreV1.LimitRange)
+	kind := req.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+	if err := validateLimitRange(req); err != nil {
+		return errors.Wrapf(err, "invalid resource - kind: %v, name: %v", kind, req.Name)
+	}
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.CoreV1().LimitRanges(req.Namespace)
+		list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
+		}
+
+		var exists bool
+		for _, l := range list.Items {
+			if l.Name == req.Name {
+				exists = true
+				break
+			}
+		}
+
+		if exists {
+			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				_, err := client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.Name)
+			}
+			log.Printf("resource updated - kind: %v, name: %v", kind, req.Name)
+			return nil
+		} else if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource created - kind: %v, name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) networkPolicyApply(resource runtime.Object) error {
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	req, ok := resource.(*apiNetworkingV1.NetworkPolicy)
+	if !ok {
+		return fmt.Errorf("unknown object version: %v kind:'%v', only networking.k8s.io/v1 is supported", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind)
+	}
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+	if err := validateNetworkPolicy(req); err != nil {
+		return errors.Wrapf(err, "invalid resource - kind: %v, name: %v", kind, req.Name)
+	}
+
+	client := c.clt.NetworkingV1().NetworkPolicies(req.Namespace)
+	list, err := client.List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "error listing resource : %v, name: %v", kind, req.Name)
+	}
+
+	var exists bool
+	for _, l := range list.Items {
+		if l.Name == req.Name {
+			exists = true
+			break
+		}
+	}
+
+	if exists {
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			_, err := client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource updated - kind: %v, name: %v", kind, req.Name)
+		return nil
+	} else if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.Name)
+	}
+	log.Printf("resource created - kind: %v, name: %v", kind, req.Name)
+	return nil
+}
+
 // Functions to delete different K8s objects.
 func (c *K8s) clusterRoleDelete(resource runtime.Object) error {
 	req := resource.(*rbac.ClusterRole)
@@ -939,8 +1497,7 @@ func (c *K8s) clusterRoleDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.RbacV1().ClusterRoles()
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -957,8 +1514,7 @@ func (c *K8s) clusterRoleBindingDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.RbacV1().ClusterRoleBindings()
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -977,8 +1533,7 @@ func (c *K8s) configMapDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.CoreV1().ConfigMaps(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -998,10 +1553,12 @@ func (c *K8s) daemonsetDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.AppsV1().DaemonSets(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
+		if err := c.forceDeletePods(req.Namespace, req.Spec.Selector); err != nil {
+			return err
+		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
@@ -1019,10 +1576,12 @@ func (c *K8s) deploymentDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.AppsV1().Deployments(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
+		if err := c.forceDeletePods(req.Namespace, req.Spec.Selector); err != nil {
+			return err
+		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
@@ -1040,10 +1599,35 @@ func (c *K8s) statefulSetDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.AppsV1().StatefulSets(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+		}
+		if err := c.forceDeletePods(req.Namespace, req.Spec.Selector); err != nil {
+			return err
+		}
+		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) replicaSetDelete(resource runtime.Object) error {
+	req := resource.(*appsV1.ReplicaSet)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.AppsV1().ReplicaSets(req.Namespace)
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
+		if err := c.forceDeletePods(req.Namespace, req.Spec.Selector); err != nil {
+			return err
+		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
@@ -1061,10 +1645,17 @@ func (c *K8s) jobDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.BatchV1().Jobs(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
+		selector := req.Spec.Selector
+		if selector == nil {
+			// The selector of a job is generated from the job-name label when it isn't set.
+			selector = &apiMetaV1.LabelSelector{MatchLabels: map[string]string{"job-name": req.Name}}
+		}
+		if err := c.forceDeletePods(req.Namespace, selector); err != nil {
+			return err
+		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
 	default:
 		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
@@ -1082,8 +1673,7 @@ func (c *K8s) customResourceDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1beta1":
 		client := c.ApiExtClient.ApiextensionsV1beta1().CustomResourceDefinitions()
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1095,26 +1685,48 @@ func (c *K8s) customResourceDelete(resource runtime.Object) error {
 }
 
 func (c *K8s) ingressDelete(resource runtime.Object) error {
-	req := resource.(*apiExtensionsV1beta1.Ingress)
 	kind := resource.GetObjectKind().GroupVersionKind().Kind
-	if len(req.Namespace) == 0 {
-		req.Namespace = "default"
-	}
 
-	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
-	case "v1beta1":
+	switch req := resource.(type) {
+	case *apiNetworkingV1.Ingress:
+		if len(req.Namespace) == 0 {
+			req.Namespace = "default"
+		}
+		client := c.clt.NetworkingV1().Ingresses(req.Namespace)
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	case *apiExtensionsV1beta1.Ingress:
+		if len(req.Namespace) == 0 {
+			req.Namespace = "default"
+		}
 		client := c.clt.ExtensionsV1beta1().Ingresses(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
 	default:
-		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+		return fmt.Errorf("unknown object version: %v kind:'%v'", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind)
 	}
 	return nil
 }
 
+func (c *K8s) ingressClassDelete(resource runtime.Object) error {
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	req, ok := resource.(*apiNetworkingV1.IngressClass)
+	if !ok {
+		return fmt.Errorf("unknown object version: %v kind:'%v'", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind)
+	}
+
+	client := c.clt.NetworkingV1().IngressClasses()
+	if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+		return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+	}
+	log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	return nil
+}
+
 func (c *K8s) namespaceDelete(resource runtime.Object) error {
 	req := resource.(*apiCoreV1.Namespace)
 	kind := resource.GetObjectKind().GroupVersionKind().Kind
@@ -1122,11 +1734,13 @@ func (c *K8s) namespaceDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.CoreV1().Namespaces()
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleting - kind: %v , name: %v", kind, req.Name)
+		if err := c.forceDeletePods(req.Name, nil); err != nil {
+			return err
+		}
 		return provider.RetryUntilTrue(
 			fmt.Sprintf("deleting namespace:%v", req.Name),
 			2*provider.GlobalRetryCount,
@@ -1146,8 +1760,7 @@ func (c *K8s) roleDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.RbacV1().Roles(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1167,8 +1780,7 @@ func (c *K8s) roleBindingDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.RbacV1().RoleBindings(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1188,8 +1800,7 @@ func (c *K8s) serviceDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.CoreV1().Services(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1209,8 +1820,7 @@ func (c *K8s) serviceAccountDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.CoreV1().ServiceAccounts(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1229,8 +1839,23 @@ func (c *K8s) secretDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.CoreV1().Secrets(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) persistentVolumeDelete(resource runtime.Object) error {
+	req := resource.(*apiCoreV1.PersistentVolume)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.CoreV1().PersistentVolumes()
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1249,8 +1874,26 @@ func (c *K8s) persistentVolumeClaimDelete(resource runtime.Object) error {
 	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
 	case "v1":
 		client := c.clt.CoreV1().PersistentVolumeClaims(req.Namespace)
-		delPolicy := apiMetaV1.DeletePropagationForeground
-		if err := client.Delete(c.ctx, req.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &delPolicy}); err != nil {
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) resourceQuotaDelete(resource runtime.Object) error {
+	req := resource.(*apiCoreV1.ResourceQuota)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.CoreV1().ResourceQuotas(req.Namespace)
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
 			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
 		}
 		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
@@ -1260,6 +1903,42 @@ func (c *K8s) persistentVolumeClaimDelete(resource runtime.Object) error {
 	return nil
 }
 
+func (c *K8s) limitRangeDelete(resource runtime.Object) error {
+	req := resource.(*apiCoreV1.LimitRange)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.CoreV1().LimitRanges(req.Namespace)
+		if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+			return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+		}
+		log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	default:
+		return fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+	return nil
+}
+
+func (c *K8s) networkPolicyDelete(resource runtime.Object) error {
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	req, ok := resource.(*apiNetworkingV1.NetworkPolicy)
+	if !ok {
+		return fmt.Errorf("unknown object version: %v kind:'%v', only networking.k8s.io/v1 is supported", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind)
+	}
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+	client := c.clt.NetworkingV1().NetworkPolicies(req.Namespace)
+	if err := client.Delete(c.ctx, req.Name, c.deleteOptions()); err != nil {
+		return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.Name)
+	}
+	log.Printf("resource deleted - kind: %v , name: %v", kind, req.Name)
+	return nil
+}
+
 func (c *K8s) serviceExists(resource runtime.Object) (bool, error) {
 	req := resource.(*apiCoreV1.Service)
 	kind := resource.GetObjectKind().GroupVersionKind().Kind
@@ -1320,6 +1999,14 @@ func (c *K8s) deploymentReady(resource runtime.Object) (bool, error) {
 		if req.Spec.Replicas != nil {
 			replicas = *req.Spec.Replicas
 		}
+		// Until the controller has seen the update the status is for the previous replicas.
+		if res.Status.ObservedGeneration < res.Generation {
+			return false, nil
+		}
+		// A deployment scaled to zero is ready once all its pods are gone.
+		if replicas == 0 {
+			return res.Status.Replicas == 0, nil
+		}
 		if res.Status.AvailableReplicas == replicas {
 			return true, nil
 		}
@@ -1349,6 +2036,49 @@ func (c *K8s) statefulSetReady(resource runtime.Object) (bool, error) {
 		if req.Spec.Replicas != nil {
 			replicas = *req.Spec.Replicas
 		}
+		if res.Status.ObservedGeneration < res.Generation {
+			return false, nil
+		}
+		// A statefulset scaled to zero is ready once all its pods are gone.
+		if replicas == 0 {
+			return res.Status.Replicas == 0, nil
+		}
+		if res.Status.ReadyReplicas == replicas {
+			return true, nil
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown object version: %v kind:'%v', name:'%v'", v, kind, req.Name)
+	}
+}
+
+func (c *K8s) replicaSetReady(resource runtime.Object) (bool, error) {
+	req := resource.(*appsV1.ReplicaSet)
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	if len(req.Namespace) == 0 {
+		req.Namespace = "default"
+	}
+
+	switch v := resource.GetObjectKind().GroupVersionKind().Version; v {
+	case "v1":
+		client := c.clt.AppsV1().ReplicaSets(req.Namespace)
+
+		res, err := client.Get(c.ctx, req.Name, apiMetaV1.GetOptions{})
+		if err != nil {
+			return false, errors.Wrapf(err, "Checking ReplicaSet resource:'%v' status failed err:%v", req.Name, err)
+		}
+
+		replicas := int32(1)
+		if req.Spec.Replicas != nil {
+			replicas = *req.Spec.Replicas
+		}
+		if res.Status.ObservedGeneration < res.Generation {
+			return false, nil
+		}
+		// A replicaset scaled to zero is ready once all its pods are gone.
+		if replicas == 0 {
+			return res.Status.Replicas == 0, nil
+		}
 		if res.Status.ReadyReplicas == replicas {
 			return true, nil
 		}
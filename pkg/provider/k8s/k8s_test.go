@@ -0,0 +1,355 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiNetworkingV1 "k8s.io/api/networking/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// newFakeK8s returns a provider backed by a fake clientset holding the given objects.
+func newFakeK8s(objects ...runtime.Object) *K8s {
+	return &K8s{
+		ctx:            context.Background(),
+		clt:            fake.NewSimpleClientset(objects...),
+		applyDuration:  newApplyDurationHistogram(),
+		DeploymentVars: make(map[string]string),
+	}
+}
+
+// decodeManifest decodes a multi document manifest the same way as DeploymentsParse.
+func decodeManifest(t *testing.T, manifest string) []Resource {
+	t.Helper()
+	var objects []runtime.Object
+	for _, text := range strings.Split(manifest, provider.Separator) {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		obj, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(text), nil, nil)
+		if err != nil {
+			t.Fatalf("decoding manifest: %v", err)
+		}
+		objects = append(objects, obj)
+	}
+	return []Resource{{FileName: "manifest.yaml", Objects: objects}}
+}
+
+const ingressManifest = `
+apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: nginx
+spec:
+  controller: k8s.io/ingress-nginx
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: prometheus
+  namespace: prombench
+spec:
+  ingressClassName: nginx
+  rules:
+  - http:
+      paths:
+      - path: /prometheus-pr
+        pathType: Prefix
+        backend:
+          service:
+            name: prometheus-pr
+            port:
+              number: 80
+`
+
+func TestIngressApplyUpdate(t *testing.T) {
+	existing := &apiNetworkingV1.Ingress{
+		ObjectMeta: apiMetaV1.ObjectMeta{Name: "prometheus", Namespace: "prombench", ResourceVersion: "1"},
+		Spec: apiNetworkingV1.IngressSpec{
+			Rules: []apiNetworkingV1.IngressRule{{
+				IngressRuleValue: apiNetworkingV1.IngressRuleValue{HTTP: &apiNetworkingV1.HTTPIngressRuleValue{
+					Paths: []apiNetworkingV1.HTTPIngressPath{{Path: "/old"}},
+				}},
+			}},
+		},
+		Status: apiNetworkingV1.IngressStatus{LoadBalancer: apiCoreV1.LoadBalancerStatus{
+			Ingress: []apiCoreV1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+		}},
+	}
+	c := newFakeK8s(existing)
+
+	if err := c.ResourceApply(decodeManifest(t, ingressManifest)); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+
+	got, err := c.clt.NetworkingV1().Ingresses("prombench").Get(c.ctx, "prometheus", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the updated ingress: %v", err)
+	}
+	if p := got.Spec.Rules[0].HTTP.Paths[0].Path; p != "/prometheus-pr" {
+		t.Errorf("expected the updated path /prometheus-pr, got %v", p)
+	}
+	if got.Spec.IngressClassName == nil || *got.Spec.IngressClassName != "nginx" {
+		t.Errorf("expected the nginx ingress class, got %v", got.Spec.IngressClassName)
+	}
+	if lb := got.Status.LoadBalancer.Ingress; len(lb) != 1 || lb[0].IP != "10.0.0.1" {
+		t.Errorf("expected the assigned address to be preserved, got %v", lb)
+	}
+	if _, err := c.clt.NetworkingV1().IngressClasses().Get(c.ctx, "nginx", apiMetaV1.GetOptions{}); err != nil {
+		t.Errorf("expected the ingress class to be created: %v", err)
+	}
+
+	// Applying the same manifest again is an update of both objects.
+	if err := c.ResourceApply(decodeManifest(t, ingressManifest)); err != nil {
+		t.Fatalf("unexpected error applying again: %v", err)
+	}
+
+	if err := c.ResourceDelete(decodeManifest(t, ingressManifest)); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	ingresses, err := c.clt.NetworkingV1().Ingresses("prombench").List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing ingresses: %v", err)
+	}
+	if len(ingresses.Items) != 0 {
+		t.Errorf("expected the ingress to be deleted, got %v", ingresses.Items)
+	}
+}
+
+func TestIngressApplyUnsupportedVersion(t *testing.T) {
+	c := newFakeK8s()
+	manifest := `
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: prometheus
+spec:
+  backend:
+    serviceName: prometheus
+    servicePort: 80
+`
+	if err := c.ResourceApply(decodeManifest(t, manifest)); err == nil {
+		t.Error("expected an error for networking.k8s.io/v1beta1")
+	}
+}
+
+func TestResourceApplyInjectMetadata(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: prometheus
+  namespace: prombench
+  labels:
+    app: prometheus
+    prombench/run-id: manifest
+data:
+  key: value
+`
+	for _, tc := range []struct {
+		force bool
+		runID string
+	}{
+		{force: false, runID: "manifest"},
+		{force: true, runID: "1234"},
+	} {
+		c := newFakeK8s()
+		c.InjectLabels = map[string]string{"prombench/run-id": "1234", "prombench/pr": "10"}
+		c.InjectAnnotations = map[string]string{"prombench/owner": "ci"}
+		c.ForceInject = tc.force
+
+		if err := c.ResourceApply(decodeManifest(t, manifest)); err != nil {
+			t.Fatalf("unexpected apply error: %v", err)
+		}
+		got, err := c.clt.CoreV1().ConfigMaps("prombench").Get(c.ctx, "prometheus", apiMetaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("getting the config map: %v", err)
+		}
+		if got.Labels["app"] != "prometheus" || got.Labels["prombench/pr"] != "10" {
+			t.Errorf("expected the manifest and injected labels, got %v", got.Labels)
+		}
+		if got.Labels["prombench/run-id"] != tc.runID {
+			t.Errorf("force %v: expected the run-id label %v, got %v", tc.force, tc.runID, got.Labels["prombench/run-id"])
+		}
+		if got.Annotations["prombench/owner"] != "ci" {
+			t.Errorf("expected the injected annotation, got %v", got.Annotations)
+		}
+	}
+}
+
+func TestNewForDeployment(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["test"] = &clientcmdapi.Cluster{Server: "https://127.0.0.1:6443"}
+	config.AuthInfos["test"] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts["test"] = &clientcmdapi.Context{Cluster: "test", AuthInfo: "test"}
+	config.CurrentContext = "test"
+
+	dr := provider.NewDeploymentResource()
+	dr.InjectLabels["prombench"] = "10"
+	dr.Owner = "configmap/prombench-10"
+	dr.NoWait = true
+	dr.ServerSideApply = true
+	dr.DeleteGracePeriod = 0
+	c, err := NewForDeployment(context.Background(), config, dr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.InjectLabels["prombench"] != "10" || c.Owner != dr.Owner || !c.NoWait || !c.ServerSideApply {
+		t.Errorf("the apply options of the deployment resource weren't set: %+v", c)
+	}
+	if c.DeleteGracePeriod == nil || *c.DeleteGracePeriod != 0 {
+		t.Errorf("want the delete grace period 0, got %v", c.DeleteGracePeriod)
+	}
+}
+
+func TestDeploymentReadyZeroReplicas(t *testing.T) {
+	zero, two := int32(0), int32(2)
+	for _, tc := range []struct {
+		name     string
+		replicas *int32
+		status   appsV1.DeploymentStatus
+		ready    bool
+	}{
+		{name: "scaling down with pods left", replicas: &zero, status: appsV1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1}},
+		{name: "scaled down", replicas: &zero, status: appsV1.DeploymentStatus{ObservedGeneration: 2}, ready: true},
+		{name: "update not observed", replicas: &zero, status: appsV1.DeploymentStatus{ObservedGeneration: 1}},
+		{name: "scaling up from zero", replicas: &two, status: appsV1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2}},
+		{name: "scaled up", replicas: &two, status: appsV1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, AvailableReplicas: 2}, ready: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s(&appsV1.Deployment{
+				ObjectMeta: apiMetaV1.ObjectMeta{Name: "fake-webserver", Namespace: "default", Generation: 2},
+				Spec:       appsV1.DeploymentSpec{Replicas: tc.replicas},
+				Status:     tc.status,
+			})
+			req := &appsV1.Deployment{
+				TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: apiMetaV1.ObjectMeta{Name: "fake-webserver"},
+				Spec:       appsV1.DeploymentSpec{Replicas: tc.replicas},
+			}
+			ready, err := c.deploymentReady(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ready != tc.ready {
+				t.Errorf("want ready %v, got %v", tc.ready, ready)
+			}
+		})
+	}
+}
+
+func TestReplicaSetApply(t *testing.T) {
+	const manifest = `
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: fake-webserver
+  namespace: prombench-1234
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: fake-webserver
+  template:
+    metadata:
+      labels:
+        app: fake-webserver
+    spec:
+      containers:
+      - name: fake-webserver
+        image: docker.io/prominfra/fake-webserver:master
+`
+	c := newFakeK8s()
+	c.NoWait = true
+	if err := c.ResourceApply(decodeManifest(t, manifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Applying again updates the existing replicaset.
+	if err := c.ResourceApply(decodeManifest(t, strings.Replace(manifest, "replicas: 2", "replicas: 5", 1))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rs, err := c.clt.AppsV1().ReplicaSets("prombench-1234").Get(c.ctx, "fake-webserver", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *rs.Spec.Replicas != 5 {
+		t.Errorf("want the replicaset updated to 5 replicas, got %v", *rs.Spec.Replicas)
+	}
+
+	rs.Status = appsV1.ReplicaSetStatus{Replicas: 5, ReadyReplicas: 4}
+	if _, err := c.clt.AppsV1().ReplicaSets("prombench-1234").UpdateStatus(c.ctx, rs, apiMetaV1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	req := decodeManifest(t, strings.Replace(manifest, "replicas: 2", "replicas: 5", 1))[0].Objects[0]
+	if ready, err := c.replicaSetReady(req); err != nil || ready {
+		t.Errorf("want the replicaset with 4 of 5 ready pods not ready, got %v err: %v", ready, err)
+	}
+	rs.Status.ReadyReplicas = 5
+	if _, err := c.clt.AppsV1().ReplicaSets("prombench-1234").UpdateStatus(c.ctx, rs, apiMetaV1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if ready, err := c.replicaSetReady(req); err != nil || !ready {
+		t.Errorf("want the replicaset with 5 ready pods ready, got %v err: %v", ready, err)
+	}
+
+	if err := c.ResourceDelete(decodeManifest(t, manifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list, _ := c.clt.AppsV1().ReplicaSets("prombench-1234").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+		t.Errorf("want the replicaset deleted, got %v", list.Items)
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	dir := t.TempDir()
+	manifest := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: \"{{ .NAMESPACE }}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "namespace.yaml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resources, err := ParseFiles([]string{dir}, map[string]string{"NAMESPACE": "prombench-10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 || len(resources[0].Objects) != 1 {
+		t.Fatalf("want 1 resource with 1 object, got %v", resources)
+	}
+	ns, ok := resources[0].Objects[0].(*apiCoreV1.Namespace)
+	if !ok {
+		t.Fatalf("want a namespace, got %T", resources[0].Objects[0])
+	}
+	if ns.Name != "prombench-10" {
+		t.Errorf("want the namespace prombench-10, got %v", ns.Name)
+	}
+}
+
+func TestDecodeResourcesShortSection(t *testing.T) {
+	_, err := decodeResources([]provider.Resource{{FileName: "short.yaml", Content: []byte("kind: Foo\n---\nfoo")}})
+	if err == nil || !strings.Contains(err.Error(), "decoding the resource file:short.yaml, section:kind: Foo...") {
+		t.Fatalf("expected the decoding error quoting the whole short section, got %v", err)
+	}
+}
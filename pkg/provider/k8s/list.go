@@ -0,0 +1,49 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"github.com/pkg/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// listPageSize is the number of objects requested per page by List.
+const listPageSize = 500
+
+// List returns all objects of the resource that match the label selector,
+// following the continue tokens so large lists are not truncated at the first page.
+// An empty namespace lists the objects of all namespaces, or the cluster scoped objects.
+func (c *K8s) List(gvr schema.GroupVersionResource, namespace, selector string) ([]unstructured.Unstructured, error) {
+	var client dynamic.ResourceInterface = c.dynamicClient.Resource(gvr)
+	if namespace != "" {
+		client = c.dynamicClient.Resource(gvr).Namespace(namespace)
+	}
+
+	var items []unstructured.Unstructured
+	opts := apiMetaV1.ListOptions{LabelSelector: selector, Limit: listPageSize}
+	for {
+		list, err := client.List(c.ctx, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing resource: %v, namespace: %q, selector: %q", gvr.String(), namespace, selector)
+		}
+		items = append(items, list.Items...)
+		if list.GetContinue() == "" {
+			return items, nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// pagedClient serves the lists of the fake dynamic client in pages of at most the requested limit,
+// with the offset of the next page as the continue token, like an api server with a large list.
+type pagedClient struct {
+	dynamic.Interface
+	requests *int
+}
+
+func (p pagedClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return pagedResource{NamespaceableResourceInterface: p.Interface.Resource(gvr), requests: p.requests}
+}
+
+type pagedResource struct {
+	dynamic.NamespaceableResourceInterface
+	requests *int
+}
+
+func (p pagedResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return pagedNamespace{ResourceInterface: p.NamespaceableResourceInterface.Namespace(namespace), requests: p.requests}
+}
+
+func (p pagedResource) List(ctx context.Context, opts apiMetaV1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return listPage(ctx, p.NamespaceableResourceInterface, opts, p.requests)
+}
+
+type pagedNamespace struct {
+	dynamic.ResourceInterface
+	requests *int
+}
+
+func (p pagedNamespace) List(ctx context.Context, opts apiMetaV1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return listPage(ctx, p.ResourceInterface, opts, p.requests)
+}
+
+func listPage(ctx context.Context, client dynamic.ResourceInterface, opts apiMetaV1.ListOptions, requests *int) (*unstructured.UnstructuredList, error) {
+	*requests++
+	offset := 0
+	if opts.Continue != "" {
+		var err error
+		if offset, err = strconv.Atoi(opts.Continue); err != nil {
+			return nil, fmt.Errorf("invalid continue token %q", opts.Continue)
+		}
+	}
+	limit := int(opts.Limit)
+	opts.Limit, opts.Continue = 0, ""
+	full, err := client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	// The fake returns the objects in a random order.
+	sort.Slice(full.Items, func(i, j int) bool { return full.Items[i].GetName() < full.Items[j].GetName() })
+	end := len(full.Items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+		full.SetContinue(strconv.Itoa(end))
+	}
+	full.Items = full.Items[offset:end]
+	return full, nil
+}
+
+func TestListPagination(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	var objects []runtime.Object
+	const total = 2*listPageSize + 17
+	for i := 0; i < total; i++ {
+		labels := map[string]string{"prombench/run-id": "1234"}
+		if i%10 == 0 {
+			labels["prombench/run-id"] = "other"
+		}
+		objects = append(objects, &apiCoreV1.ConfigMap{
+			TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: apiMetaV1.ObjectMeta{Name: fmt.Sprintf("cm-%04d", i), Namespace: "prombench", Labels: labels},
+		})
+	}
+	// One in another namespace.
+	objects = append(objects, &apiCoreV1.ConfigMap{
+		TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: apiMetaV1.ObjectMeta{Name: "cm-other", Namespace: "default", Labels: map[string]string{"prombench/run-id": "1234"}},
+	})
+
+	var requests int
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = pagedClient{Interface: dynamicFake.NewSimpleDynamicClient(scheme.Scheme, objects...), requests: &requests}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	items, err := c.List(gvr, "prombench", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != total {
+		t.Errorf("want all %d objects, got %d", total, len(items))
+	}
+	if want := 3; requests != want {
+		t.Errorf("want %d page requests, got %d", want, requests)
+	}
+	seen := map[string]bool{}
+	for _, item := range items {
+		if seen[item.GetName()] {
+			t.Errorf("object %v returned twice", item.GetName())
+		}
+		seen[item.GetName()] = true
+	}
+
+	items, err = c.List(gvr, "prombench", "prombench/run-id=1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := total - (total+9)/10; len(items) != want {
+		t.Errorf("want %d objects matching the selector, got %d", want, len(items))
+	}
+
+	// All namespaces.
+	items, err = c.List(gvr, "", "prombench/run-id=1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := total - (total+9)/10 + 1; len(items) != want {
+		t.Errorf("want %d objects in all namespaces, got %d", want, len(items))
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// logsReconnectInterval is how long StreamPodLogs waits before reconnecting to a pod.
+var logsReconnectInterval = 2 * time.Second
+
+// PodLogs returns the current logs of a container of the pod. An empty container is fine for single container pods.
+func (c *K8s) PodLogs(namespace, pod, container string) ([]byte, error) {
+	logs, err := c.clt.CoreV1().Pods(namespace).GetLogs(pod, &apiCoreV1.PodLogOptions{Container: container}).DoRaw(c.ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the logs of pod %v/%v", namespace, pod)
+	}
+	return logs, nil
+}
+
+// StreamPodLogs follows the logs of a container of the pod until the context is cancelled or the reader is closed.
+// The stream ends when the container restarts or the connection breaks, so it reconnects and continues
+// with the lines logged since the disconnect, which can repeat the lines of the same second.
+// The reader returns io.EOF once the context is cancelled. An empty container is fine for single container pods.
+func (c *K8s) StreamPodLogs(ctx context.Context, namespace, pod, container string) (io.ReadCloser, error) {
+	// Closing the reader cancels the context, which also ends a stream that is waiting for new lines.
+	ctx, cancel := context.WithCancel(ctx)
+	req := c.clt.CoreV1().Pods(namespace).GetLogs(pod, &apiCoreV1.PodLogOptions{Container: container, Follow: true})
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "streaming the logs of pod %v/%v", namespace, pod)
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		defer cancel()
+		for {
+			_, err := io.Copy(w, stream)
+			stream.Close()
+			if ctx.Err() != nil {
+				w.Close()
+				return
+			}
+			if errors.Is(err, io.ErrClosedPipe) {
+				return
+			}
+			since := apiMetaV1.Now()
+			log.Printf("logs of pod %v/%v disconnected, reconnecting", namespace, pod)
+
+			// Retry until the pod is back, e.g. while the container is restarting.
+			for {
+				select {
+				case <-ctx.Done():
+					w.Close()
+					return
+				case <-time.After(logsReconnectInterval):
+				}
+				opts := &apiCoreV1.PodLogOptions{Container: container, Follow: true, SinceTime: &since}
+				if stream, err = c.clt.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx); err == nil {
+					break
+				}
+				log.Printf("reconnecting to the logs of pod %v/%v failed: %v", namespace, pod, err)
+			}
+		}
+	}()
+	return logsReader{PipeReader: r, cancel: cancel}, nil
+}
+
+// logsReader stops following the logs when it is closed.
+type logsReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (l logsReader) Close() error {
+	l.cancel()
+	return l.PipeReader.Close()
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodLogs(t *testing.T) {
+	c := newFakeK8s(&apiCoreV1.Pod{ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen-0", Namespace: "prombench"}})
+	logs, err := c.PodLogs("prombench", "loadgen-0", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(logs) != "fake logs" {
+		t.Errorf("want the logs of the pod, got %q", logs)
+	}
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	defer func(interval time.Duration) { logsReconnectInterval = interval }(logsReconnectInterval)
+	logsReconnectInterval = time.Millisecond
+
+	c := newFakeK8s(&apiCoreV1.Pod{ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen-0", Namespace: "prombench"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The fake logs end after every request, like a restarting container, so the stream reconnects.
+	r, err := c.StreamPodLogs(ctx, "prombench", "loadgen-0", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Repeat("fake logs", 3)
+	buf := make([]byte, len(want))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buf) != want {
+		t.Errorf("want %q, got %q", want, buf)
+	}
+
+	cancel()
+	done := make(chan error)
+	go func() {
+		_, err := io.Copy(io.Discard, r)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("want the stream to end without an error once the context is cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream didn't end after the context was cancelled")
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStreamPodLogsClose(t *testing.T) {
+	defer func(interval time.Duration) { logsReconnectInterval = interval }(logsReconnectInterval)
+	logsReconnectInterval = time.Millisecond
+
+	c := newFakeK8s()
+	r, err := c.StreamPodLogs(context.Background(), "prombench", "loadgen-0", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := r.Read(make([]byte, 1)); err == nil {
+		t.Error("expected an error reading a closed stream")
+	}
+}
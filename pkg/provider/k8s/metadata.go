@@ -0,0 +1,123 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Label adds the labels to the live objects without applying the manifests again,
+// e.g. to mark a pod for retention or to record the phase of an experiment while the benchmark runs.
+// The objects are given as kind/name, or as a kind together with a label selector to label all matching objects.
+// The labels are added with a merge patch, so the other labels of the objects are kept.
+// The namespace is ignored for cluster scoped kinds and defaults to "default" for namespaced kinds.
+func (c *K8s) Label(object, selector, namespace string, labels map[string]string) error {
+	for k, v := range labels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %v", k, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q of label %q: %v", v, k, strings.Join(errs, ", "))
+		}
+	}
+	return c.patchMetadata("labels", object, selector, namespace, labels)
+}
+
+// Annotate adds the annotations to the live objects, selected the same way as by Label.
+// The annotations are added with a merge patch, so the other annotations of the objects are kept.
+func (c *K8s) Annotate(object, selector, namespace string, annotations map[string]string) error {
+	for k := range annotations {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key %q: %v", k, strings.Join(errs, ", "))
+		}
+	}
+	return c.patchMetadata("annotations", object, selector, namespace, annotations)
+}
+
+// patchMetadata merges the values into the metadata field, labels or annotations, of the selected objects.
+func (c *K8s) patchMetadata(field, object, selector, namespace string, values map[string]string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("no %v to set", field)
+	}
+	kind, name, hasName := strings.Cut(object, "/")
+	switch {
+	case kind == "" || (hasName && name == ""):
+		return fmt.Errorf("invalid object %q, expected kind/name or a kind with a selector", object)
+	case hasName && selector != "":
+		return fmt.Errorf("the object %q is selected by name, it can't have a selector too", object)
+	case !hasName && selector == "":
+		return fmt.Errorf("the kind %q requires a selector, e.g. app=prometheus, or a name as kind/name", object)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{field: values},
+	})
+	if err != nil {
+		return err
+	}
+	if hasName {
+		return c.Patch(kind, namespace, name, types.MergePatchType, patch)
+	}
+
+	names, err := c.selectNames(kind, selector, namespace)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no %v objects match the selector %q", kind, selector)
+	}
+	for _, n := range names {
+		if err := c.Patch(kind, namespace, n, types.MergePatchType, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectNames returns the sorted names of the objects of the kind that match the label selector.
+func (c *K8s) selectNames(kind, selector, namespace string) ([]string, error) {
+	mapping, err := c.kindMapping(kind)
+	if err != nil {
+		return nil, err
+	}
+	opts := apiMetaV1.ListOptions{LabelSelector: selector}
+	client := c.dynamicClient.Resource(mapping.Resource)
+	var list *unstructured.UnstructuredList
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if namespace == "" {
+			namespace = "default"
+		}
+		list, err = client.Namespace(namespace).List(c.ctx, opts)
+	} else {
+		list, err = client.List(c.ctx, opts)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the %v objects matching %q", kind, selector)
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		names = append(names, item.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
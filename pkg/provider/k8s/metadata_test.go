@@ -0,0 +1,119 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const metadataLiveManifest = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: loadgen-1
+  namespace: prombench
+  labels: {app: loadgen}
+  annotations: {prombench/run-id: "1234"}
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: loadgen-2
+  namespace: prombench
+  labels: {app: loadgen}
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: prometheus
+  namespace: prombench
+  labels: {app: prometheus}
+`
+
+func TestLabelAndAnnotate(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	newClient := func() *K8s {
+		c := newFakeK8s()
+		c.mapper = mapper
+		c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, metadataLiveManifest)[0].Objects...)
+		return c
+	}
+	get := func(t *testing.T, c *K8s, name string) (map[string]string, map[string]string) {
+		t.Helper()
+		live, err := c.dynamicClient.Resource(pods).Namespace("prombench").Get(c.ctx, name, apiMetaV1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return live.GetLabels(), live.GetAnnotations()
+	}
+
+	c := newClient()
+	if err := c.Annotate("Pod/loadgen-1", "", "prombench", map[string]string{"prombench/retain": "true"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, annotations := get(t, c, "loadgen-1"); !reflect.DeepEqual(annotations, map[string]string{"prombench/run-id": "1234", "prombench/retain": "true"}) {
+		t.Errorf("the existing annotations should be kept, got %v", annotations)
+	}
+
+	if err := c.Label("Pod", "app=loadgen", "prombench", map[string]string{"phase": "scale-up"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, want := range map[string]map[string]string{
+		"loadgen-1":  {"app": "loadgen", "phase": "scale-up"},
+		"loadgen-2":  {"app": "loadgen", "phase": "scale-up"},
+		"prometheus": {"app": "prometheus"},
+	} {
+		if labels, _ := get(t, c, name); !reflect.DeepEqual(labels, want) {
+			t.Errorf("%v: want labels %v, got %v", name, want, labels)
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		object   string
+		selector string
+		labels   map[string]string
+		wantErr  string
+	}{
+		{name: "no labels", object: "Pod/loadgen-1", wantErr: "no labels to set"},
+		{name: "name and selector", object: "Pod/loadgen-1", selector: "app=loadgen", labels: map[string]string{"a": "b"}, wantErr: "it can't have a selector too"},
+		{name: "kind without selector", object: "Pod", labels: map[string]string{"a": "b"}, wantErr: "requires a selector"},
+		{name: "empty name", object: "Pod/", labels: map[string]string{"a": "b"}, wantErr: "invalid object"},
+		{name: "no match", object: "Pod", selector: "app=missing", labels: map[string]string{"a": "b"}, wantErr: `no Pod objects match the selector "app=missing"`},
+		{name: "invalid key", object: "Pod/loadgen-1", labels: map[string]string{"a b": "c"}, wantErr: `invalid label key "a b"`},
+		{name: "invalid value", object: "Pod/loadgen-1", labels: map[string]string{"a": "b c"}, wantErr: `invalid value "b c" of label "a"`},
+		{name: "missing object", object: "Pod/missing", labels: map[string]string{"a": "b"}, wantErr: "resource patch failed"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newClient().Label(tc.object, tc.selector, "prombench", tc.labels)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("want an error with %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	if err := newClient().Annotate("Pod/loadgen-1", "", "prombench", map[string]string{"-retain": "true"}); err == nil || !strings.Contains(err.Error(), "invalid annotation key") {
+		t.Errorf("want an error for the invalid annotation key, got %v", err)
+	}
+}
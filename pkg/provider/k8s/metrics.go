@@ -0,0 +1,41 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newApplyDurationHistogram() *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "k8s_apply_duration_seconds",
+		Help: "Time taken to apply a single object, including waiting for it to become ready for the kinds that are waited on.",
+		// From 50ms up to ~17m as applying a deployment also waits for its replicas.
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 15),
+	}, []string{"kind"})
+}
+
+// RegisterMetrics registers the provider metrics with the given registry.
+// The metrics are always recorded, registering them is optional.
+func (c *K8s) RegisterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(c.applyDuration)
+}
+
+func (c *K8s) observeApplyDuration(resource runtime.Object, start time.Time) {
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	c.applyDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
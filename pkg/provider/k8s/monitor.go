@@ -0,0 +1,150 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+)
+
+// The Prometheus operator resources are applied with the dynamic client,
+// so the operator Go types aren't needed as a dependency.
+var (
+	serviceMonitorResource = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "servicemonitors"}
+	podMonitorResource     = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "podmonitors"}
+)
+
+// MonitorEndpoint is a single scrape endpoint of a ServiceMonitor or PodMonitor.
+type MonitorEndpoint struct {
+	// Port is the name of the service or container port to scrape.
+	Port string
+	// Path defaults to /metrics when empty.
+	Path string
+	// Interval defaults to the Prometheus scrape interval when 0.
+	Interval time.Duration
+}
+
+// Monitor holds the minimal parameters of a ServiceMonitor or PodMonitor.
+type Monitor struct {
+	Name string
+	// Namespace defaults to "default" when empty.
+	Namespace string
+	// Labels of the monitor object, e.g. to match the monitor selector of a Prometheus.
+	Labels map[string]string
+	// Selector matches the labels of the scraped services or pods.
+	Selector  map[string]string
+	Endpoints []MonitorEndpoint
+}
+
+// ApplyServiceMonitor creates or updates a ServiceMonitor that scrapes the services matching the selector.
+func (c *K8s) ApplyServiceMonitor(m Monitor) error {
+	return c.applyMonitor(serviceMonitorResource, "ServiceMonitor", "endpoints", m)
+}
+
+// ApplyPodMonitor creates or updates a PodMonitor that scrapes the pods matching the selector.
+func (c *K8s) ApplyPodMonitor(m Monitor) error {
+	return c.applyMonitor(podMonitorResource, "PodMonitor", "podMetricsEndpoints", m)
+}
+
+func (c *K8s) applyMonitor(gvr schema.GroupVersionResource, kind, endpointsField string, m Monitor) error {
+	obj, err := monitorObject(gvr, kind, endpointsField, m)
+	if err != nil {
+		return err
+	}
+	if err := c.injectMetadata(obj); err != nil {
+		return err
+	}
+	client := c.dynamicClient.Resource(gvr).Namespace(obj.GetNamespace())
+
+	if _, err := client.Get(c.ctx, m.Name, apiMetaV1.GetOptions{}); apiErrors.IsNotFound(err) {
+		if _, err := client.Create(c.ctx, obj, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed, is the Prometheus operator installed? - kind: %v, name: %v", kind, m.Name)
+		}
+		log.Printf("resource created - kind: %v, name: %v", kind, m.Name)
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "error getting resource - kind: %v, name: %v", kind, m.Name)
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		live, err := client.Get(c.ctx, m.Name, apiMetaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		obj.SetResourceVersion(live.GetResourceVersion())
+		_, err = client.Update(c.ctx, obj, apiMetaV1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, m.Name)
+	}
+	log.Printf("resource updated - kind: %v, name: %v", kind, m.Name)
+	return nil
+}
+
+// monitorObject builds the monitor custom resource.
+func monitorObject(gvr schema.GroupVersionResource, kind, endpointsField string, m Monitor) (*unstructured.Unstructured, error) {
+	if m.Name == "" {
+		return nil, fmt.Errorf("%v name is required", kind)
+	}
+	if len(m.Selector) == 0 {
+		return nil, fmt.Errorf("%v %v: a selector is required", kind, m.Name)
+	}
+	if len(m.Endpoints) == 0 {
+		return nil, fmt.Errorf("%v %v: at least one endpoint is required", kind, m.Name)
+	}
+	namespace := m.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	endpoints := make([]interface{}, 0, len(m.Endpoints))
+	for _, e := range m.Endpoints {
+		if e.Port == "" {
+			return nil, fmt.Errorf("%v %v: an endpoint port is required", kind, m.Name)
+		}
+		endpoint := map[string]interface{}{"port": e.Port}
+		if e.Path != "" {
+			endpoint["path"] = e.Path
+		}
+		if e.Interval > 0 {
+			endpoint["interval"] = model.Duration(e.Interval).String()
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	selector := make(map[string]interface{}, len(m.Selector))
+	for k, v := range m.Selector {
+		selector[k] = v
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"selector":     map[string]interface{}{"matchLabels": selector},
+			endpointsField: endpoints,
+		},
+	}}
+	obj.SetGroupVersionKind(gvr.GroupVersion().WithKind(kind))
+	obj.SetName(m.Name)
+	obj.SetNamespace(namespace)
+	obj.SetLabels(m.Labels)
+	return obj, nil
+}
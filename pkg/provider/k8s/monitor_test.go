@@ -0,0 +1,75 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestApplyServiceMonitor(t *testing.T) {
+	c := newFakeK8s()
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+	c.InjectLabels = map[string]string{"prombench/run-id": "1234"}
+
+	m := Monitor{
+		Name:      "loadgen",
+		Namespace: "prombench",
+		Selector:  map[string]string{"app": "loadgen"},
+		Endpoints: []MonitorEndpoint{{Port: "metrics", Interval: 90 * time.Second}},
+	}
+	if err := c.ApplyServiceMonitor(m); err != nil {
+		t.Fatalf("unexpected error creating: %v", err)
+	}
+	m.Endpoints[0].Path = "/debug/metrics"
+	if err := c.ApplyServiceMonitor(m); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+
+	obj, err := c.dynamicClient.Resource(serviceMonitorResource).Namespace("prombench").Get(c.ctx, "loadgen", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.GetLabels()["prombench/run-id"] != "1234" {
+		t.Errorf("expected the injected label, got %v", obj.GetLabels())
+	}
+	selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	if !reflect.DeepEqual(selector, m.Selector) {
+		t.Errorf("expected selector %v, got %v", m.Selector, selector)
+	}
+	endpoints, _, _ := unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+	expected := []interface{}{map[string]interface{}{"port": "metrics", "path": "/debug/metrics", "interval": "1m30s"}}
+	if !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("expected endpoints %v, got %v", expected, endpoints)
+	}
+}
+
+func TestMonitorObjectValidation(t *testing.T) {
+	for _, m := range []Monitor{
+		{Selector: map[string]string{"app": "loadgen"}, Endpoints: []MonitorEndpoint{{Port: "metrics"}}},
+		{Name: "loadgen", Endpoints: []MonitorEndpoint{{Port: "metrics"}}},
+		{Name: "loadgen", Selector: map[string]string{"app": "loadgen"}},
+		{Name: "loadgen", Selector: map[string]string{"app": "loadgen"}, Endpoints: []MonitorEndpoint{{Path: "/metrics"}}},
+	} {
+		if _, err := monitorObject(podMonitorResource, "PodMonitor", "podMetricsEndpoints", m); err == nil {
+			t.Errorf("expected an error for %+v", m)
+		}
+	}
+}
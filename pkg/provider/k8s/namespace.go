@@ -0,0 +1,104 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// EnsureNamespace creates the namespace with the labels and annotations or adds them to an existing namespace,
+// e.g. the labels selected by a NetworkPolicy or the pod security admission labels of a benchmark namespace.
+// The given keys overwrite the existing values and the other labels and annotations of the namespace are kept,
+// so it can be called on every run and only updates the namespace when the metadata changed.
+func (c *K8s) EnsureNamespace(name string, labels, annotations map[string]string) error {
+	client := c.clt.CoreV1().Namespaces()
+
+	_, err := client.Get(c.ctx, name, apiMetaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		ns := &apiCoreV1.Namespace{ObjectMeta: apiMetaV1.ObjectMeta{
+			Name:        name,
+			Labels:      mergeNamespaceMetadata(nil, labels),
+			Annotations: mergeNamespaceMetadata(nil, annotations),
+		}}
+		ns.SetGroupVersionKind(apiCoreV1.SchemeGroupVersion.WithKind("Namespace"))
+		if err := c.injectMetadata(ns); err != nil {
+			return err
+		}
+		if _, err := client.Create(c.ctx, ns, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed - kind: Namespace, name: %v", name)
+		}
+		log.Printf("resource created - kind: Namespace, name: %v", name)
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "error getting resource - kind: Namespace, name: %v", name)
+	}
+
+	var updated bool
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		live, err := client.Get(c.ctx, name, apiMetaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if live.Status.Phase == apiCoreV1.NamespaceTerminating {
+			return fmt.Errorf("the namespace is terminating")
+		}
+		if hasMetadata(live.Labels, labels) && hasMetadata(live.Annotations, annotations) {
+			return nil
+		}
+		live.Labels = mergeNamespaceMetadata(live.Labels, labels)
+		live.Annotations = mergeNamespaceMetadata(live.Annotations, annotations)
+		_, err = client.Update(c.ctx, live, apiMetaV1.UpdateOptions{})
+		updated = err == nil
+		return err
+	}); err != nil {
+		return errors.Wrapf(err, "resource update failed - kind: Namespace, name: %v", name)
+	}
+	if updated {
+		log.Printf("resource updated - kind: Namespace, name: %v", name)
+	} else {
+		log.Printf("resource unchanged - kind: Namespace, name: %v", name)
+	}
+	return nil
+}
+
+// mergeNamespaceMetadata returns the current values with the given values set, nil when both are empty.
+func mergeNamespaceMetadata(current, values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return current
+	}
+	if current == nil {
+		current = make(map[string]string, len(values))
+	}
+	for k, v := range values {
+		current[k] = v
+	}
+	return current
+}
+
+// hasMetadata returns true when the current values already include all given values.
+func hasMetadata(current, values map[string]string) bool {
+	for k, v := range values {
+		if cv, ok := current[k]; !ok || cv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureNamespace(t *testing.T) {
+	c := newFakeK8s(&apiCoreV1.Namespace{ObjectMeta: apiMetaV1.ObjectMeta{
+		Name:   "prombench-1234",
+		Labels: map[string]string{"team": "prombench", "pod-security.kubernetes.io/enforce": "privileged"},
+	}})
+
+	labels := map[string]string{"pod-security.kubernetes.io/enforce": "baseline", "prombench/network": "isolated"}
+	if err := c.EnsureNamespace("prombench-1234", labels, map[string]string{"owner": "ci"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ns, err := c.clt.CoreV1().Namespaces().Get(c.ctx, "prombench-1234", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The labels are merged, the given ones overwrite the existing values.
+	exp := map[string]string{"team": "prombench", "pod-security.kubernetes.io/enforce": "baseline", "prombench/network": "isolated"}
+	if !reflect.DeepEqual(exp, ns.Labels) {
+		t.Errorf("\nexpect labels %v\ngot %v", exp, ns.Labels)
+	}
+	if exp := map[string]string{"owner": "ci"}; !reflect.DeepEqual(exp, ns.Annotations) {
+		t.Errorf("expect annotations %v, got %v", exp, ns.Annotations)
+	}
+
+	// Unchanged metadata doesn't update the namespace.
+	clt := c.clt.(*fake.Clientset)
+	clt.ClearActions()
+	if err := c.EnsureNamespace("prombench-1234", labels, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, a := range clt.Actions() {
+		if a.GetVerb() == "update" {
+			t.Errorf("expect no update of an unchanged namespace, got %v", a)
+		}
+	}
+
+	if err := c.EnsureNamespace("prombench-5678", labels, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created, err := c.clt.CoreV1().Namespaces().Get(c.ctx, "prombench-5678", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expect the namespace created: %v", err)
+	}
+	if !reflect.DeepEqual(labels, created.Labels) {
+		t.Errorf("expect labels %v, got %v", labels, created.Labels)
+	}
+}
+
+func TestEnsureNamespaceTerminating(t *testing.T) {
+	c := newFakeK8s(&apiCoreV1.Namespace{
+		ObjectMeta: apiMetaV1.ObjectMeta{Name: "prombench-1234"},
+		Status:     apiCoreV1.NamespaceStatus{Phase: apiCoreV1.NamespaceTerminating},
+	})
+	err := c.EnsureNamespace("prombench-1234", map[string]string{"a": "b"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "terminating") {
+		t.Errorf("expect a terminating namespace error, got %v", err)
+	}
+}
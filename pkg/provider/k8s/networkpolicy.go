@@ -0,0 +1,180 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiNetworkingV1 "k8s.io/api/networking/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// networkPolicyEnforcers are the names of the daemonsets and containers of the network plugins that enforce NetworkPolicies,
+// e.g. anetd for GKE Dataplane V2 and aws-network-policy-agent for the network policy agent of the EKS VPC CNI.
+var networkPolicyEnforcers = []string{
+	"anetd",
+	"antrea-agent",
+	"aws-network-policy-agent",
+	"calico-node",
+	"canal",
+	"cilium",
+	"kube-router",
+	"ovnkube-node",
+	"weave-net",
+}
+
+// validateNetworkPolicy checks the policy before it is sent to the api server,
+// so a typo in a manifest fails the apply instead of leaving the pods unrestricted or isolated.
+func validateNetworkPolicy(req *apiNetworkingV1.NetworkPolicy) error {
+	if _, err := apiMetaV1.LabelSelectorAsSelector(&req.Spec.PodSelector); err != nil {
+		return fmt.Errorf("spec.podSelector: %v", err)
+	}
+	for i, t := range req.Spec.PolicyTypes {
+		if t != apiNetworkingV1.PolicyTypeIngress && t != apiNetworkingV1.PolicyTypeEgress {
+			return fmt.Errorf("spec.policyTypes[%d] %q must be Ingress or Egress", i, t)
+		}
+	}
+	for i, rule := range req.Spec.Ingress {
+		if err := validateNetworkPolicyPorts(rule.Ports); err != nil {
+			return fmt.Errorf("spec.ingress[%d].%v", i, err)
+		}
+		if err := validateNetworkPolicyPeers(rule.From); err != nil {
+			return fmt.Errorf("spec.ingress[%d].from%v", i, err)
+		}
+	}
+	for i, rule := range req.Spec.Egress {
+		if err := validateNetworkPolicyPorts(rule.Ports); err != nil {
+			return fmt.Errorf("spec.egress[%d].%v", i, err)
+		}
+		if err := validateNetworkPolicyPeers(rule.To); err != nil {
+			return fmt.Errorf("spec.egress[%d].to%v", i, err)
+		}
+	}
+	return nil
+}
+
+func validateNetworkPolicyPorts(ports []apiNetworkingV1.NetworkPolicyPort) error {
+	for i, p := range ports {
+		if p.Protocol != nil {
+			switch *p.Protocol {
+			case apiCoreV1.ProtocolTCP, apiCoreV1.ProtocolUDP, apiCoreV1.ProtocolSCTP:
+			default:
+				return fmt.Errorf("ports[%d].protocol %q must be TCP, UDP or SCTP", i, *p.Protocol)
+			}
+		}
+		if p.EndPort == nil {
+			continue
+		}
+		if p.Port == nil || p.Port.StrVal != "" {
+			return fmt.Errorf("ports[%d].endPort requires a numeric port", i)
+		}
+		if *p.EndPort < p.Port.IntVal {
+			return fmt.Errorf("ports[%d].endPort %v must not be lower than the port %v", i, *p.EndPort, p.Port.IntVal)
+		}
+	}
+	return nil
+}
+
+// validateNetworkPolicyPeers returns the index of the invalid peer in the error,
+// the caller prefixes it with the from or to field.
+func validateNetworkPolicyPeers(peers []apiNetworkingV1.NetworkPolicyPeer) error {
+	for i, p := range peers {
+		if p.IPBlock == nil {
+			if p.PodSelector == nil && p.NamespaceSelector == nil {
+				return fmt.Errorf("[%d] must set a podSelector, namespaceSelector or ipBlock", i)
+			}
+			if _, err := apiMetaV1.LabelSelectorAsSelector(p.PodSelector); err != nil {
+				return fmt.Errorf("[%d].podSelector: %v", i, err)
+			}
+			if _, err := apiMetaV1.LabelSelectorAsSelector(p.NamespaceSelector); err != nil {
+				return fmt.Errorf("[%d].namespaceSelector: %v", i, err)
+			}
+			continue
+		}
+		if p.PodSelector != nil || p.NamespaceSelector != nil {
+			return fmt.Errorf("[%d] the ipBlock can't be combined with a podSelector or namespaceSelector", i)
+		}
+		_, cidr, err := net.ParseCIDR(p.IPBlock.CIDR)
+		if err != nil {
+			return fmt.Errorf("[%d].ipBlock.cidr %q is not a valid CIDR", i, p.IPBlock.CIDR)
+		}
+		for j, e := range p.IPBlock.Except {
+			ip, except, err := net.ParseCIDR(e)
+			if err != nil {
+				return fmt.Errorf("[%d].ipBlock.except[%d] %q is not a valid CIDR", i, j, e)
+			}
+			exceptOnes, _ := except.Mask.Size()
+			cidrOnes, _ := cidr.Mask.Size()
+			if !cidr.Contains(ip) || exceptOnes <= cidrOnes {
+				return fmt.Errorf("[%d].ipBlock.except[%d] %v must be a strict subset of the cidr %v", i, j, e, p.IPBlock.CIDR)
+			}
+		}
+	}
+	return nil
+}
+
+// warnNetworkPolicyEnforcement logs a warning when no network plugin that enforces NetworkPolicies runs in the cluster,
+// e.g. the kindnet plugin of KIND or a GKE cluster without network policy enforcement,
+// since the api server accepts the policies anyway and a benchmark would run without its restrictions.
+func (c *K8s) warnNetworkPolicyEnforcement() {
+	enforcer, err := c.networkPolicyEnforcer()
+	if err != nil {
+		log.Printf("WARNING: could not check whether the network plugin of the cluster enforces NetworkPolicies: %v", err)
+		return
+	}
+	if enforcer == "" {
+		log.Printf("WARNING: no network plugin that enforces NetworkPolicies found in the cluster (looked for the daemonsets or containers %v), the NetworkPolicies are accepted but have no effect", strings.Join(networkPolicyEnforcers, ", "))
+		return
+	}
+	log.Printf("NetworkPolicies are enforced by %v", enforcer)
+}
+
+// networkPolicyEnforcer returns the namespace/name of the daemonset of the network plugin that enforces NetworkPolicies,
+// empty when there is none.
+func (c *K8s) networkPolicyEnforcer() (string, error) {
+	daemonSets, err := c.clt.AppsV1().DaemonSets(apiMetaV1.NamespaceAll).List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, ds := range daemonSets.Items {
+		names := []string{ds.Name}
+		for _, container := range ds.Spec.Template.Spec.Containers {
+			names = append(names, container.Name)
+		}
+		for _, name := range names {
+			for _, enforcer := range networkPolicyEnforcers {
+				if name == enforcer {
+					return ds.Namespace + "/" + ds.Name, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// hasNetworkPolicy returns whether the resources include a NetworkPolicy.
+func hasNetworkPolicy(deployments []Resource) bool {
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			if _, ok := resource.(*apiNetworkingV1.NetworkPolicy); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
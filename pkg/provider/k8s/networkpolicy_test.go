@@ -0,0 +1,149 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const networkPolicyManifest = `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: prometheus-scrape
+  namespace: prombench-1234
+spec:
+  podSelector:
+    matchLabels:
+      app: fake-webserver
+  policyTypes:
+  - Ingress
+  - Egress
+  ingress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: prombench-1234
+      podSelector:
+        matchLabels:
+          app: prometheus
+    ports:
+    - protocol: TCP
+      port: 8080
+      endPort: 8090
+  egress:
+  - to:
+    - ipBlock:
+        cidr: 10.0.0.0/8
+        except:
+        - 10.1.0.0/16
+`
+
+func TestNetworkPolicy(t *testing.T) {
+	c := newFakeK8s()
+	if err := c.ResourceApply(decodeManifest(t, networkPolicyManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	policy, err := c.clt.NetworkingV1().NetworkPolicies("prombench-1234").Get(c.ctx, "prometheus-scrape", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := policy.Spec.Ingress[0].Ports[0].Port.IntVal; got != 8080 {
+		t.Errorf("want the ingress port 8080, got %v", got)
+	}
+
+	// Applying again updates the existing policy.
+	if err := c.ResourceApply(decodeManifest(t, strings.Replace(networkPolicyManifest, "endPort: 8090", "endPort: 8099", 1))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	policy, err = c.clt.NetworkingV1().NetworkPolicies("prombench-1234").Get(c.ctx, "prometheus-scrape", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *policy.Spec.Ingress[0].Ports[0].EndPort; got != 8099 {
+		t.Errorf("want the policy updated to the end port 8099, got %v", got)
+	}
+
+	if err := c.ResourceDelete(decodeManifest(t, networkPolicyManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list, _ := c.clt.NetworkingV1().NetworkPolicies("prombench-1234").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+		t.Errorf("want the policy deleted, got %v", list.Items)
+	}
+}
+
+func TestNetworkPolicyInvalid(t *testing.T) {
+	for name, replace := range map[string][2]string{
+		"unknown policy type": {"- Egress", "- Forward"},
+		"unknown protocol":    {"protocol: TCP", "protocol: ICMP"},
+		"invalid cidr":        {"cidr: 10.0.0.0/8", "cidr: 10.0.0.0"},
+		"end port below port": {"port: 8080", "port: 9090"},
+		"except outside cidr": {"- 10.1.0.0/16", "- 192.168.0.0/16"},
+		"except equals cidr":  {"- 10.1.0.0/16", "- 10.0.0.0/8"},
+		"ipBlock with selector": {"    - ipBlock:", `    - podSelector:
+        matchLabels:
+          app: prometheus
+      ipBlock:`},
+		"invalid selector": {"      podSelector:\n        matchLabels:\n          app: prometheus", `      podSelector:
+        matchExpressions:
+        - key: app
+          operator: Equals`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			manifest := strings.Replace(networkPolicyManifest, replace[0], replace[1], 1)
+			if manifest == networkPolicyManifest {
+				t.Fatalf("the replaced text %q isn't in the manifest", replace[0])
+			}
+			c := newFakeK8s()
+			if err := c.ResourceApply(decodeManifest(t, manifest)); err == nil {
+				t.Fatal("expected an error for an invalid spec")
+			}
+			if list, _ := c.clt.NetworkingV1().NetworkPolicies("prombench-1234").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+				t.Errorf("want no policy created, got %v", list.Items)
+			}
+		})
+	}
+}
+
+func TestNetworkPolicyEnforcer(t *testing.T) {
+	daemonSet := func(namespace, name, container string) *appsV1.DaemonSet {
+		return &appsV1.DaemonSet{
+			ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsV1.DaemonSetSpec{Template: apiCoreV1.PodTemplateSpec{Spec: apiCoreV1.PodSpec{
+				Containers: []apiCoreV1.Container{{Name: container}},
+			}}},
+		}
+	}
+
+	c := newFakeK8s(daemonSet("kube-system", "kindnet", "kindnet-cni"))
+	if enforcer, err := c.networkPolicyEnforcer(); err != nil || enforcer != "" {
+		t.Errorf("want no enforcer for kindnet, got %q err: %v", enforcer, err)
+	}
+
+	c = newFakeK8s(daemonSet("calico-system", "calico-node", "calico-node"))
+	if enforcer, _ := c.networkPolicyEnforcer(); enforcer != "calico-system/calico-node" {
+		t.Errorf("want the calico enforcer, got %q", enforcer)
+	}
+
+	// The EKS VPC CNI only enforces the policies with its network policy agent container.
+	c = newFakeK8s(daemonSet("kube-system", "aws-node", "aws-network-policy-agent"))
+	if enforcer, _ := c.networkPolicyEnforcer(); enforcer != "kube-system/aws-node" {
+		t.Errorf("want the aws-node enforcer, got %q", enforcer)
+	}
+}
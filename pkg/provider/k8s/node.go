@@ -0,0 +1,228 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// CordonNode marks the node unschedulable, so no new pods are scheduled on it, e.g. to simulate a node maintenance.
+func (c *K8s) CordonNode(name string) error {
+	return c.setUnschedulable(name, true)
+}
+
+// UncordonNode marks the node schedulable again after CordonNode or DrainNode.
+func (c *K8s) UncordonNode(name string) error {
+	return c.setUnschedulable(name, false)
+}
+
+func (c *K8s) setUnschedulable(name string, unschedulable bool) error {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%v}}`, unschedulable)
+	if _, err := c.clt.CoreV1().Nodes().Patch(c.ctx, name, types.StrategicMergePatchType, []byte(patch), apiMetaV1.PatchOptions{}); err != nil {
+		return errors.Wrapf(err, "setting unschedulable to %v - kind: Node, name: %v", unschedulable, name)
+	}
+	if unschedulable {
+		log.Printf("node cordoned - name: %v", name)
+	} else {
+		log.Printf("node uncordoned - name: %v", name)
+	}
+	return nil
+}
+
+// drainState is the state of a pod being drained.
+type drainState int
+
+const (
+	drainPending drainState = iota
+	// drainBlocked pods can't be evicted yet as that would violate their pod disruption budget.
+	drainBlocked
+	// drainEvicted pods are terminating after their eviction.
+	drainEvicted
+)
+
+// DrainNode cordons the node and evicts its pods through the eviction api, so the pod disruption budgets are respected.
+// Evictions blocked by a disruption budget are retried until the timeout expires and the progress is logged on every change.
+// DaemonSet pods and mirror pods are left running, they would be recreated on the same node.
+// On timeout the node stays cordoned and the error lists the pods that are left.
+func (c *K8s) DrainNode(name string, timeout time.Duration) error {
+	if err := c.CordonNode(name); err != nil {
+		return err
+	}
+	list, err := c.clt.CoreV1().Pods(apiCoreV1.NamespaceAll).List(c.ctx, apiMetaV1.ListOptions{FieldSelector: "spec.nodeName=" + name})
+	if err != nil {
+		return errors.Wrapf(err, "listing the pods of node %v", name)
+	}
+	pending := map[types.UID]apiCoreV1.Pod{}
+	states := map[types.UID]drainState{}
+	for _, pod := range list.Items {
+		if pod.Spec.NodeName != name || !drainable(pod) {
+			continue
+		}
+		pending[pod.UID] = pod
+		states[pod.UID] = drainPending
+	}
+	total := len(pending)
+	log.Printf("Draining node %v, evicting %d pods", name, total)
+
+	var last string
+	err = wait.PollImmediate(waitPollInterval, timeout, func() (bool, error) {
+		for uid, pod := range pending {
+			live, err := c.clt.CoreV1().Pods(pod.Namespace).Get(c.ctx, pod.Name, apiMetaV1.GetOptions{})
+			if apiErrors.IsNotFound(err) || (err == nil && live.UID != uid) {
+				log.Printf("pod evicted - namespace: %v, name: %v", pod.Namespace, pod.Name)
+				delete(pending, uid)
+				continue
+			}
+			if err != nil {
+				return false, errors.Wrapf(err, "checking the eviction - kind: Pod, namespace: %v, name: %v", pod.Namespace, pod.Name)
+			}
+			if states[uid] == drainEvicted {
+				continue
+			}
+			err = c.clt.CoreV1().Pods(pod.Namespace).EvictV1(c.ctx, &policyV1.Eviction{
+				ObjectMeta: apiMetaV1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			})
+			switch {
+			case err == nil:
+				states[uid] = drainEvicted
+			case apiErrors.IsTooManyRequests(err):
+				states[uid] = drainBlocked
+			case apiErrors.IsNotFound(err):
+				delete(pending, uid)
+			default:
+				return false, errors.Wrapf(err, "evicting - kind: Pod, namespace: %v, name: %v", pod.Namespace, pod.Name)
+			}
+		}
+		if progress := drainProgress(total, pending, states); progress != last {
+			log.Printf("Draining node %v: %v", name, progress)
+			last = progress
+		}
+		return len(pending) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		left := make([]string, 0, len(pending))
+		for uid, pod := range pending {
+			state := "terminating"
+			if states[uid] != drainEvicted {
+				state = "blocked by a disruption budget"
+			}
+			left = append(left, fmt.Sprintf("%v/%v (%v)", pod.Namespace, pod.Name, state))
+		}
+		sort.Strings(left)
+		return errors.Errorf("draining node %v timed out after %s, the node stays cordoned, %d pods left: %v", name, timeout, len(left), strings.Join(left, ", "))
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("node drained - name: %v", name)
+	return nil
+}
+
+// DrainNodes cordons all nodes matching the label selector, so the evicted pods are only rescheduled on the other nodes,
+// then drains them surge nodes at a time with DrainNode, e.g. to move the pods of a node pool to its replacement.
+// The progress is logged after every batch, and the first batch that fails stops the drain with the nodes left cordoned.
+func (c *K8s) DrainNodes(selector string, surge int, timeout time.Duration) error {
+	if surge < 1 {
+		return errors.Errorf("invalid surge %d, must be >= 1", surge)
+	}
+	list, err := c.clt.CoreV1().Nodes().List(c.ctx, apiMetaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.Wrapf(err, "listing the nodes matching %v", selector)
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, node := range list.Items {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		log.Printf("No nodes match %v, nothing to drain", selector)
+		return nil
+	}
+	for _, name := range names {
+		if err := c.CordonNode(name); err != nil {
+			return err
+		}
+	}
+	log.Printf("Draining %d nodes matching %v, %d at a time", len(names), selector, surge)
+
+	for start := 0; start < len(names); start += surge {
+		batch := names[start:]
+		if len(batch) > surge {
+			batch = batch[:surge]
+		}
+		var (
+			wg   sync.WaitGroup
+			mtx  sync.Mutex
+			errs []string
+		)
+		for _, name := range batch {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				if err := c.DrainNode(name, timeout); err != nil {
+					mtx.Lock()
+					errs = append(errs, err.Error())
+					mtx.Unlock()
+				}
+			}(name)
+		}
+		wg.Wait()
+		if len(errs) > 0 {
+			sort.Strings(errs)
+			return errors.Errorf("draining the nodes matching %v: %v", selector, strings.Join(errs, "; "))
+		}
+		log.Printf("Drained %d/%d nodes matching %v", start+len(batch), len(names), selector)
+	}
+	return nil
+}
+
+// drainable returns false for the pods that are left on a drained node.
+func drainable(pod apiCoreV1.Pod) bool {
+	if _, ok := pod.Annotations[apiCoreV1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" && ref.Controller != nil && *ref.Controller {
+			return false
+		}
+	}
+	return true
+}
+
+// drainProgress describes how many pods of a drain are evicted, terminating and blocked.
+func drainProgress(total int, pending map[types.UID]apiCoreV1.Pod, states map[types.UID]drainState) string {
+	var terminating, blocked int
+	for uid := range pending {
+		switch states[uid] {
+		case drainEvicted:
+			terminating++
+		case drainBlocked:
+			blocked++
+		}
+	}
+	return fmt.Sprintf("%d/%d pods evicted, %d terminating, %d blocked by disruption budgets", total-len(pending), total, terminating, blocked)
+}
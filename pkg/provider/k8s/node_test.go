@@ -0,0 +1,181 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func drainPod(name, node string) *apiCoreV1.Pod {
+	return &apiCoreV1.Pod{
+		ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Namespace: "prombench-1234", UID: types.UID(name)},
+		Spec:       apiCoreV1.PodSpec{NodeName: node},
+	}
+}
+
+// newDrainK8s returns a provider whose evictions delete the pods, except for the blocked pods.
+// The evictions of concurrent drains are recorded in the order they were received.
+func newDrainK8s(blocked map[string]bool, objects ...runtime.Object) (*K8s, *[]string) {
+	c := newFakeK8s(objects...)
+	clt := c.clt.(*fake.Clientset)
+	var (
+		mtx     sync.Mutex
+		evicted []string
+	)
+	clt.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8sTesting.CreateAction).GetObject().(*policyV1.Eviction)
+		if blocked[eviction.Name] {
+			return true, nil, apiErrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		mtx.Lock()
+		evicted = append(evicted, eviction.Name)
+		mtx.Unlock()
+		return true, nil, clt.Tracker().Delete(apiCoreV1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+	})
+	return c, &evicted
+}
+
+func TestCordonNode(t *testing.T) {
+	c := newFakeK8s(&apiCoreV1.Node{ObjectMeta: apiMetaV1.ObjectMeta{Name: "node-1"}})
+	for _, unschedulable := range []bool{true, false} {
+		var err error
+		if unschedulable {
+			err = c.CordonNode("node-1")
+		} else {
+			err = c.UncordonNode("node-1")
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		node, err := c.clt.CoreV1().Nodes().Get(c.ctx, "node-1", apiMetaV1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node.Spec.Unschedulable != unschedulable {
+			t.Errorf("want unschedulable %v, got %v", unschedulable, node.Spec.Unschedulable)
+		}
+	}
+	if err := c.CordonNode("missing"); err == nil {
+		t.Error("expected an error cordoning a missing node")
+	}
+}
+
+func TestDrainNode(t *testing.T) {
+	isController := true
+	daemon := drainPod("node-exporter", "node-1")
+	daemon.OwnerReferences = []apiMetaV1.OwnerReference{{Kind: "DaemonSet", Name: "node-exporter", Controller: &isController}}
+	mirror := drainPod("kube-proxy", "node-1")
+	mirror.Annotations = map[string]string{apiCoreV1.MirrorPodAnnotationKey: "hash"}
+	objects := []runtime.Object{
+		&apiCoreV1.Node{ObjectMeta: apiMetaV1.ObjectMeta{Name: "node-1"}},
+		drainPod("prometheus-0", "node-1"),
+		drainPod("loadgen-1", "node-1"),
+		drainPod("loadgen-2", "node-2"),
+		daemon,
+		mirror,
+	}
+
+	c, evicted := newDrainK8s(nil, objects...)
+	if err := c.DrainNode("node-1", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(*evicted, ","); got != "prometheus-0,loadgen-1" && got != "loadgen-1,prometheus-0" {
+		t.Errorf("want the pods of node-1 evicted except the daemonset and mirror pods, got %v", got)
+	}
+	node, err := c.clt.CoreV1().Nodes().Get(c.ctx, "node-1", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !node.Spec.Unschedulable {
+		t.Error("want the drained node cordoned")
+	}
+
+	c, _ = newDrainK8s(map[string]bool{"prometheus-0": true}, objects...)
+	err = c.DrainNode("node-1", 100*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "1 pods left: prombench-1234/prometheus-0 (blocked by a disruption budget)") {
+		t.Errorf("want a timeout error with the blocked pod, got %v", err)
+	}
+}
+
+func TestDrainNodes(t *testing.T) {
+	pool := func(name, pool string) *apiCoreV1.Node {
+		return &apiCoreV1.Node{ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Labels: map[string]string{"pool": pool}}}
+	}
+	objects := []runtime.Object{
+		pool("old-1", "old"),
+		pool("old-2", "old"),
+		pool("old-3", "old"),
+		pool("new-1", "new"),
+		drainPod("prometheus-0", "old-1"),
+		drainPod("loadgen-1", "old-2"),
+		drainPod("loadgen-2", "old-3"),
+		drainPod("loadgen-3", "new-1"),
+	}
+
+	c, evicted := newDrainK8s(nil, objects...)
+	if err := c.DrainNodes("pool=old", 2, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*evicted) != 3 {
+		t.Errorf("want the 3 pods of the old nodes evicted, got %v", *evicted)
+	}
+	for _, name := range []string{"old-1", "old-2", "old-3", "new-1"} {
+		node, err := c.clt.CoreV1().Nodes().Get(c.ctx, name, apiMetaV1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strings.HasPrefix(name, "old"); node.Spec.Unschedulable != want {
+			t.Errorf("node %v: want unschedulable %v, got %v", name, want, node.Spec.Unschedulable)
+		}
+	}
+
+	// A node that can't be drained stops the drain, the later batches aren't evicted but stay cordoned.
+	c, evicted = newDrainK8s(map[string]bool{"prometheus-0": true}, objects...)
+	err := c.DrainNodes("pool=old", 1, 100*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "draining node old-1 timed out") {
+		t.Errorf("want the timeout error of old-1, got %v", err)
+	}
+	if len(*evicted) != 0 {
+		t.Errorf("want no pods of the later nodes evicted, got %v", *evicted)
+	}
+	node, err := c.clt.CoreV1().Nodes().Get(c.ctx, "old-3", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !node.Spec.Unschedulable {
+		t.Error("want all nodes cordoned before the first drain")
+	}
+
+	if err := c.DrainNodes("pool=missing", 1, time.Minute); err != nil {
+		t.Errorf("want nothing to drain without matching nodes, got %v", err)
+	}
+	if err := c.DrainNodes("pool=old", 0, time.Minute); err == nil {
+		t.Error("expected an error for a surge of 0")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// owner is the parent object resolved from the Owner flag.
+type owner struct {
+	ref       apiMetaV1.OwnerReference
+	namespace string
+}
+
+// resolveOwner returns the owner set in the client, or nil when no owner is set.
+// The owner must exist and be namespaced, as the garbage collector
+// only cascades from a namespaced owner to dependents in the same namespace.
+func (c *K8s) resolveOwner() (*owner, error) {
+	if c.Owner == "" {
+		return nil, nil
+	}
+	kind, name, ok := strings.Cut(c.Owner, "/")
+	if !ok || kind == "" || name == "" {
+		return nil, fmt.Errorf("invalid owner %q, expected kind/name", c.Owner)
+	}
+	mapping, err := c.kindMapping(kind)
+	if err != nil {
+		return nil, errors.Wrapf(err, "owner %v", c.Owner)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return nil, fmt.Errorf("owner %v is cluster scoped, only namespaced owners are supported", c.Owner)
+	}
+	namespace := c.OwnerNamespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	live, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the owner %v in namespace %v", c.Owner, namespace)
+	}
+	return &owner{
+		ref: apiMetaV1.OwnerReference{
+			APIVersion: mapping.GroupVersionKind.GroupVersion().String(),
+			Kind:       mapping.GroupVersionKind.Kind,
+			Name:       live.GetName(),
+			UID:        live.GetUID(),
+		},
+		namespace: namespace,
+	}, nil
+}
+
+// setOwner adds the owner reference to a namespaced object in the namespace of the owner.
+// Cluster scoped objects, objects in other namespaces and the owner itself are left unchanged.
+func (c *K8s) setOwner(resource runtime.Object, o *owner) error {
+	if o == nil {
+		return nil
+	}
+	_, ref, err := c.dynamicResource(resource)
+	if err != nil {
+		return err
+	}
+	if ref.Namespace == "" || ref.Namespace != o.namespace {
+		log.Printf("not setting the owner %v/%v, the object is not in namespace %v - %v", o.ref.Kind, o.ref.Name, o.namespace, ref)
+		return nil
+	}
+	if ref.Kind == o.ref.Kind && ref.Name == o.ref.Name {
+		return nil
+	}
+	obj, err := meta.Accessor(resource)
+	if err != nil {
+		return errors.Wrapf(err, "accessing the object metadata")
+	}
+	refs := obj.GetOwnerReferences()
+	for _, r := range refs {
+		if r.UID == o.ref.UID {
+			return nil
+		}
+	}
+	obj.SetOwnerReferences(append(refs, o.ref))
+	return nil
+}
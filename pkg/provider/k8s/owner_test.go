@@ -0,0 +1,126 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const ownerLiveManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: run
+  namespace: prombench
+  uid: 1234-abcd
+`
+
+const ownerDependentsManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: loadgen
+  namespace: other
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: prombench
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: run
+  namespace: prombench
+`
+
+func newOwnerK8s(t *testing.T) *K8s {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, ownerLiveManifest)[0].Objects...)
+	return c
+}
+
+func TestSetOwner(t *testing.T) {
+	c := newOwnerK8s(t)
+	c.Owner = "ConfigMap/run"
+	c.OwnerNamespace = "prombench"
+	o, err := c.resolveOwner()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	objects := decodeManifest(t, ownerDependentsManifest)[0].Objects
+	for _, obj := range objects {
+		// Setting the owner twice must not duplicate the reference.
+		for i := 0; i < 2; i++ {
+			if err := c.setOwner(obj, o); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	for i, want := range []int{1, 0, 0, 0} {
+		accessor, err := meta.Accessor(objects[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		refs := accessor.GetOwnerReferences()
+		if len(refs) != want {
+			t.Errorf("%v/%v: want %d owner references, got %v", objects[i].GetObjectKind().GroupVersionKind().Kind, accessor.GetName(), want, refs)
+			continue
+		}
+		if want == 1 && (refs[0].Kind != "ConfigMap" || refs[0].APIVersion != "v1" || refs[0].Name != "run" || refs[0].UID != "1234-abcd") {
+			t.Errorf("unexpected owner reference %v", refs[0])
+		}
+	}
+}
+
+func TestResolveOwnerErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, owner, namespace string
+	}{
+		{name: "invalid format", owner: "ConfigMap"},
+		{name: "unknown kind", owner: "Rollout.argoproj.io/loadgen", namespace: "prombench"},
+		{name: "missing owner", owner: "ConfigMap/missing", namespace: "prombench"},
+		{name: "owner in another namespace", owner: "ConfigMap/run"},
+		{name: "cluster scoped owner", owner: "Namespace/prombench"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newOwnerK8s(t)
+			c.Owner = tc.owner
+			c.OwnerNamespace = tc.namespace
+			if _, err := c.resolveOwner(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
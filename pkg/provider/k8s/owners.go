@@ -0,0 +1,151 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FieldOwner is a managed fields entry of a live object with the fields it owns as readable paths,
+// e.g. .spec.template.spec.containers[name=prometheus].image.
+type FieldOwner struct {
+	Manager     string
+	Operation   string
+	Subresource string
+	APIVersion  string
+	Time        time.Time
+	Fields      []string
+}
+
+// FieldOwners returns the field managers of the live object and the fields each of them owns, from its managed fields,
+// e.g. to find out which operator conflicts with a server-side apply. The kind is given as kind or kind.group.
+// The owners are sorted by manager and operation, the fields of every owner by path.
+func (c *K8s) FieldOwners(kind, namespace, name string) ([]FieldOwner, error) {
+	if kind == "" || name == "" {
+		return nil, fmt.Errorf("invalid object %v/%v, expected kind/name", kind, name)
+	}
+	live, err := c.liveObject(kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var owners []FieldOwner
+	for _, entry := range live.GetManagedFields() {
+		o := FieldOwner{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			Subresource: entry.Subresource,
+			APIVersion:  entry.APIVersion,
+		}
+		if entry.Time != nil {
+			o.Time = entry.Time.Time
+		}
+		if entry.FieldsV1 != nil {
+			var fields map[string]interface{}
+			if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+				return nil, errors.Wrapf(err, "parsing the managed fields of %v", entry.Manager)
+			}
+			o.Fields = fieldPaths("", fields, nil)
+			sort.Strings(o.Fields)
+		}
+		owners = append(owners, o)
+	}
+	sort.SliceStable(owners, func(i, j int) bool {
+		if owners[i].Manager != owners[j].Manager {
+			return owners[i].Manager < owners[j].Manager
+		}
+		return owners[i].Operation < owners[j].Operation
+	})
+	return owners, nil
+}
+
+// fieldPaths returns the paths of the fields of a FieldsV1 set.
+// Only the leaves are listed, and the lists and maps that are owned as a whole, marked by the "." key.
+func fieldPaths(prefix string, fields map[string]interface{}, paths []string) []string {
+	for k, v := range fields {
+		if k == "." {
+			paths = append(paths, prefix)
+			continue
+		}
+		path := prefix + fieldSegment(k)
+		if children, ok := v.(map[string]interface{}); ok && len(children) > 0 {
+			paths = fieldPaths(path, children, paths)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// fieldSegment returns a key of a FieldsV1 set as a path segment: f:name is a field,
+// k:{...} a list item by its key fields, v:value a set item by its value and i:n a list item by its index.
+func fieldSegment(key string) string {
+	prefix, value, _ := strings.Cut(key, ":")
+	switch prefix {
+	case "f":
+		return "." + value
+	case "i":
+		return "[" + value + "]"
+	case "v":
+		return "[=" + strings.Trim(value, `"`) + "]"
+	case "k":
+		var keys map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &keys); err != nil {
+			return "[" + value + "]"
+		}
+		names := make([]string, 0, len(keys))
+		for n := range keys {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for i, n := range names {
+			names[i] = fmt.Sprintf("%v=%v", n, keys[n])
+		}
+		return "[" + strings.Join(names, ",") + "]"
+	}
+	return "." + key
+}
+
+// FormatFieldOwners prints the owners grouped by manager, with the operation, subresource, api version and time
+// of every managed fields entry followed by its fields.
+func FormatFieldOwners(owners []FieldOwner) []byte {
+	var b bytes.Buffer
+	for i, o := range owners {
+		if i == 0 || owners[i-1].Manager != o.Manager {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "%v:\n", o.Manager)
+		}
+		op := o.Operation
+		if o.Subresource != "" {
+			op += " " + o.Subresource
+		}
+		at := "-"
+		if !o.Time.IsZero() {
+			at = o.Time.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(&b, "  %v %v %v\n", op, o.APIVersion, at)
+		for _, f := range o.Fields {
+			fmt.Fprintf(&b, "    %v\n", f)
+		}
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const ownersLiveManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus
+  namespace: prombench
+  managedFields:
+  - manager: prometheus-operator
+    operation: Update
+    apiVersion: apps/v1
+    time: "2026-10-14T10:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:template:
+          f:spec:
+            f:containers:
+              k:{"name":"prometheus"}:
+                .: {}
+                f:image: {}
+                f:ports:
+                  k:{"containerPort":9090,"protocol":"TCP"}:
+                    f:containerPort: {}
+  - manager: kube-controller-manager
+    operation: Update
+    subresource: status
+    apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:status:
+        f:replicas: {}
+  - manager: infra
+    operation: Apply
+    apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:metadata:
+        f:finalizers:
+          v:"prombench/cleanup": {}
+      f:spec:
+        f:replicas: {}
+  - manager: infra
+    operation: Update
+    apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:template:
+          f:spec:
+            f:tolerations:
+              i:0: {}
+spec:
+  replicas: 2
+`
+
+func TestFieldOwners(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, ownersLiveManifest)[0].Objects...)
+
+	owners, err := c.FieldOwners("Deployment", "prombench", "prometheus")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(FormatFieldOwners(owners))
+	want := `infra:
+  Apply apps/v1 -
+    .metadata.finalizers[=prombench/cleanup]
+    .spec.replicas
+  Update apps/v1 -
+    .spec.template.spec.tolerations[0]
+
+kube-controller-manager:
+  Update status apps/v1 -
+    .status.replicas
+
+prometheus-operator:
+  Update apps/v1 2026-10-14T10:00:00Z
+    .spec.template.spec.containers[name=prometheus]
+    .spec.template.spec.containers[name=prometheus].image
+    .spec.template.spec.containers[name=prometheus].ports[containerPort=9090,protocol=TCP].containerPort
+`
+	if out != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, out)
+	}
+	if got := owners[3].Fields; !reflect.DeepEqual(got, []string{
+		".spec.template.spec.containers[name=prometheus]",
+		".spec.template.spec.containers[name=prometheus].image",
+		".spec.template.spec.containers[name=prometheus].ports[containerPort=9090,protocol=TCP].containerPort",
+	}) {
+		t.Errorf("unexpected fields %q", got)
+	}
+
+	if _, err := c.FieldOwners("Deployment", "prombench", "missing"); err == nil {
+		t.Error("expected an error for a missing object")
+	}
+}
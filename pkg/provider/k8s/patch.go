@@ -0,0 +1,87 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Patch patches a single existing object, e.g. to set an annotation or the replicas
+// without applying the whole manifest again.
+// The kind is given as kind or kind.group, e.g. Deployment or Rollout.argoproj.io.
+// The namespace is ignored for cluster scoped kinds and defaults to "default" for namespaced kinds.
+// Strategic merge patches are only supported by the built-in kinds, custom resources need a merge or JSON patch.
+func (c *K8s) Patch(kind, namespace, name string, patchType types.PatchType, data []byte) error {
+	switch patchType {
+	case types.JSONPatchType, types.MergePatchType, types.StrategicMergePatchType:
+	default:
+		return fmt.Errorf("unsupported patch type %q, must be json, merge or strategic merge", patchType)
+	}
+	mapping, err := c.kindMapping(kind)
+	if err != nil {
+		return err
+	}
+	ref := objectRef{Kind: mapping.GroupVersionKind.Kind, Name: name}
+
+	client := c.dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ref.Namespace = namespace
+		if ref.Namespace == "" {
+			ref.Namespace = "default"
+		}
+		_, err = client.Namespace(ref.Namespace).Patch(c.ctx, name, patchType, data, apiMetaV1.PatchOptions{})
+	} else {
+		_, err = client.Patch(c.ctx, name, patchType, data, apiMetaV1.PatchOptions{})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "resource patch failed - %v", ref)
+	}
+	log.Printf("resource patched - %v", ref)
+	return nil
+}
+
+// restartedAtAnnotation is the pod template annotation set by kubectl rollout restart.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RolloutRestart restarts the pods of a Deployment, StatefulSet or DaemonSet with a rolling update,
+// the same as kubectl rollout restart, e.g. to read a changed ConfigMap without changing the replicas.
+// It doesn't wait for the rollout to complete.
+func (c *K8s) RolloutRestart(kind, namespace, name string) error {
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+	default:
+		return fmt.Errorf("unsupported kind %q for a rollout restart, must be Deployment, StatefulSet or DaemonSet", kind)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.Patch(kind+".apps", namespace, name, types.MergePatchType, patch)
+}
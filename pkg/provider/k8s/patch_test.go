@@ -0,0 +1,155 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+const patchLiveManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+  annotations:
+    prombench/run-id: "1234"
+spec:
+  replicas: 1
+`
+
+func TestPatch(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	for _, tc := range []struct {
+		name      string
+		patchType types.PatchType
+		data      string
+		// field is the path of the patched field and want its value after the patch.
+		// The fake dynamic client can't apply strategic merge patches to unstructured objects,
+		// so without a field only the patch request is checked.
+		field []string
+		want  interface{}
+	}{
+		{
+			name:      "json",
+			patchType: types.JSONPatchType,
+			data:      `[{"op": "replace", "path": "/spec/replicas", "value": 5}]`,
+			field:     []string{"spec", "replicas"},
+			want:      int64(5),
+		},
+		{
+			name:      "merge",
+			patchType: types.MergePatchType,
+			data:      `{"metadata": {"annotations": {"prombench/phase": "scale-up"}}}`,
+			field:     []string{"metadata", "annotations", "prombench/phase"},
+			want:      "scale-up",
+		},
+		{
+			name:      "strategic merge",
+			patchType: types.StrategicMergePatchType,
+			data:      `{"metadata": {"annotations": {"prombench/run-id": "5678"}}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s()
+			c.mapper = mapper
+			client := dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, patchLiveManifest)[0].Objects...)
+			var patch k8sTesting.PatchAction
+			client.PrependReactor("patch", "deployments", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+				patch = action.(k8sTesting.PatchAction)
+				if tc.field == nil {
+					return true, &unstructured.Unstructured{}, nil
+				}
+				return false, nil, nil
+			})
+			c.dynamicClient = client
+
+			if err := c.Patch("Deployment", "prombench", "loadgen", tc.patchType, []byte(tc.data)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if patch == nil || patch.GetPatchType() != tc.patchType || string(patch.GetPatch()) != tc.data || patch.GetNamespace() != "prombench" || patch.GetName() != "loadgen" {
+				t.Fatalf("unexpected patch request %v", patch)
+			}
+			if tc.field == nil {
+				return
+			}
+			live, err := c.dynamicClient.Resource(deployments).Namespace("prombench").Get(c.ctx, "loadgen", apiMetaV1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _, err := unstructured.NestedFieldNoCopy(live.Object, tc.field...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("want %v %v, got %v", tc.field, tc.want, got)
+			}
+		})
+	}
+
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme)
+	if err := c.Patch("Deployment", "prombench", "missing", types.MergePatchType, []byte(`{}`)); err == nil {
+		t.Error("expected an error when patching a missing object")
+	}
+	if err := c.Patch("Deployment", "prombench", "loadgen", types.ApplyPatchType, []byte(`{}`)); err == nil {
+		t.Error("expected an error for an unsupported patch type")
+	}
+}
+
+func TestRolloutRestart(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, patchLiveManifest)[0].Objects...)
+
+	before := time.Now().Truncate(time.Second)
+	if err := c.RolloutRestart("Deployment", "prombench", "loadgen"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	live, err := c.dynamicClient.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace("prombench").Get(c.ctx, "loadgen", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	restartedAt, _, err := unstructured.NestedString(live.Object, "spec", "template", "metadata", "annotations", restartedAtAnnotation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts, err := time.Parse(time.RFC3339, restartedAt); err != nil || ts.Before(before) {
+		t.Errorf("want the %v annotation set to the restart time, got %q", restartedAtAnnotation, restartedAt)
+	}
+	if replicas, _, _ := unstructured.NestedInt64(live.Object, "spec", "replicas"); replicas != 1 {
+		t.Errorf("want the replicas unchanged, got %d", replicas)
+	}
+
+	if err := c.RolloutRestart("Service", "prombench", "loadgen"); err == nil {
+		t.Error("expected an error for an unsupported kind")
+	}
+}
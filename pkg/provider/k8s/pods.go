@@ -0,0 +1,53 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListPods returns the pods in the namespace matching the label selector, e.g. app=loadgen.
+func (c *K8s) ListPods(namespace, selector string) ([]apiCoreV1.Pod, error) {
+	list, err := c.clt.CoreV1().Pods(namespace).List(c.ctx, apiMetaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing pods in namespace: %v, selector: %v", namespace, selector)
+	}
+	return list.Items, nil
+}
+
+// DeletePod deletes a single pod without waiting for it to terminate.
+func (c *K8s) DeletePod(namespace, name string) error {
+	if err := c.clt.CoreV1().Pods(namespace).Delete(c.ctx, name, apiMetaV1.DeleteOptions{}); err != nil {
+		return errors.Wrapf(err, "resource delete failed - kind: Pod, name: %v", name)
+	}
+	log.Printf("resource deleted - kind: Pod, name: %v", name)
+	return nil
+}
+
+// PodReady returns true when the pod isn't being deleted and its Ready condition is true.
+func PodReady(pod apiCoreV1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == apiCoreV1.PodReady {
+			return c.Status == apiCoreV1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// DefaultPruneKinds are the kinds pruned when no whitelist is given.
+// Kinds that hold state, e.g. PersistentVolumeClaims and Secrets, are left out on purpose.
+var DefaultPruneKinds = []string{"Deployment", "Service", "ConfigMap"}
+
+// pruneKey identifies an object across groups, so objects of different kinds with the same name don't match.
+type pruneKey struct {
+	schema.GroupKind
+	Namespace string
+	Name      string
+}
+
+// Orphan is an object selected by Prune, it matches the label selector but is no longer in the deployments.
+type Orphan struct {
+	pruneKey
+	Created time.Time
+	mapping *meta.RESTMapping
+}
+
+func (o Orphan) ref() objectRef {
+	return objectRef{Kind: o.Kind, Namespace: o.Namespace, Name: o.Name}
+}
+
+// Orphans returns the objects of the whitelisted kinds that match the label selector
+// but are no longer in the deployments, e.g. after removing a manifest between two applies.
+// Kinds are given as kind or kind.group, e.g. Deployment or Rollout.argoproj.io.
+// The selector is required so only the objects managed by the deployments are considered.
+func (c *K8s) Orphans(deployments []Resource, selector string, kinds []string) ([]Orphan, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, errors.New("pruning requires a label selector for the objects managed by the manifests")
+	}
+	if len(kinds) == 0 {
+		return nil, errors.New("pruning requires at least one kind in the whitelist")
+	}
+
+	desired := map[pruneKey]bool{}
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			_, ref, err := c.dynamicResource(resource)
+			if err != nil {
+				return nil, err
+			}
+			gk := resource.GetObjectKind().GroupVersionKind().GroupKind()
+			desired[pruneKey{GroupKind: gk, Namespace: ref.Namespace, Name: ref.Name}] = true
+		}
+	}
+
+	var orphans []Orphan
+	for _, kind := range kinds {
+		mapping, err := c.kindMapping(kind)
+		if err != nil {
+			return nil, errors.Wrapf(err, "prune whitelist")
+		}
+		items, err := c.List(mapping.Resource, "", selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			key := pruneKey{GroupKind: mapping.GroupVersionKind.GroupKind(), Namespace: item.GetNamespace(), Name: item.GetName()}
+			if desired[key] {
+				continue
+			}
+			orphans = append(orphans, Orphan{pruneKey: key, Created: item.GetCreationTimestamp().Time, mapping: mapping})
+		}
+	}
+	return orphans, nil
+}
+
+// Prune deletes the Orphans of the deployments.
+func (c *K8s) Prune(deployments []Resource, selector string, kinds []string) error {
+	orphans, err := c.Orphans(deployments, selector, kinds)
+	if err != nil {
+		return err
+	}
+	for _, o := range orphans {
+		client := c.dynamicClient.Resource(o.mapping.Resource)
+		propagation := apiMetaV1.DeletePropagationBackground
+		if o.mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			err = client.Namespace(o.Namespace).Delete(c.ctx, o.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &propagation})
+		} else {
+			err = client.Delete(c.ctx, o.Name, apiMetaV1.DeleteOptions{PropagationPolicy: &propagation})
+		}
+		if err != nil {
+			return errors.Wrapf(err, "resource prune failed - %v", o.ref())
+		}
+		log.Printf("resource pruned - %v", o.ref())
+	}
+	return nil
+}
+
+// PruneDryRun returns the Orphans of the deployments as a table with their kind, namespace, name and age,
+// without deleting anything, so they can be reviewed before pruning.
+func (c *K8s) PruneDryRun(deployments []Resource, selector string, kinds []string) ([]byte, error) {
+	orphans, err := c.Orphans(deployments, selector, kinds)
+	if err != nil {
+		return nil, err
+	}
+	return formatOrphans(orphans, time.Now()), nil
+}
+
+// formatOrphans formats the orphans as a table like kubectl get, ordered by kind, namespace and name.
+func formatOrphans(orphans []Orphan, now time.Time) []byte {
+	if len(orphans) == 0 {
+		return []byte("No objects would be pruned.\n")
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		a, b := orphans[i], orphans[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tAGE")
+	for _, o := range orphans {
+		kind := o.Kind
+		if o.Group != "" {
+			kind += "." + o.Group
+		}
+		namespace := o.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", kind, namespace, o.Name, duration.HumanDuration(now.Sub(o.Created)))
+	}
+	w.Flush()
+	fmt.Fprintf(&buf, "%d object(s) would be pruned.\n", len(orphans))
+	return buf.Bytes()
+}
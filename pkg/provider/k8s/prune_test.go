@@ -0,0 +1,182 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const pruneLiveManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+  labels:
+    prombench/run-id: "1234"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: removed
+  namespace: prombench
+  labels:
+    prombench/run-id: "1234"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: removed
+  namespace: prombench
+  labels:
+    prombench/run-id: "1234"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: removed
+  namespace: prombench
+  labels:
+    prombench/run-id: "1234"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unmanaged
+  namespace: prombench
+`
+
+const pruneDesiredManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+  labels:
+    prombench/run-id: "1234"
+`
+
+func pruneMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	for _, kind := range []string{"Service", "Secret", "ConfigMap"} {
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: kind}, meta.RESTScopeNamespace)
+	}
+	return mapper
+}
+
+func TestPrune(t *testing.T) {
+	mapper := pruneMapper()
+
+	for _, tc := range []struct {
+		name  string
+		kinds []string
+		// remaining lists the live objects left after pruning, as kind/name.
+		remaining []string
+	}{
+		{name: "default kinds", kinds: DefaultPruneKinds, remaining: []string{"ConfigMap/unmanaged", "Deployment/loadgen", "Secret/removed"}},
+		{name: "deployments only", kinds: []string{"Deployment.apps"}, remaining: []string{"ConfigMap/unmanaged", "Deployment/loadgen", "Secret/removed", "Service/removed"}},
+		{name: "with secrets", kinds: []string{"Secret", "Service"}, remaining: []string{"ConfigMap/unmanaged", "Deployment/loadgen", "Deployment/removed"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s()
+			c.mapper = mapper
+			c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, pruneLiveManifest)[0].Objects...)
+
+			if err := c.Prune(decodeManifest(t, pruneDesiredManifest), "prombench/run-id=1234", tc.kinds); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var remaining []string
+			for _, gvr := range []schema.GroupVersionResource{
+				{Group: "apps", Version: "v1", Resource: "deployments"},
+				{Version: "v1", Resource: "services"},
+				{Version: "v1", Resource: "secrets"},
+				{Version: "v1", Resource: "configmaps"},
+			} {
+				list, err := c.dynamicClient.Resource(gvr).Namespace("prombench").List(c.ctx, apiMetaV1.ListOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, item := range list.Items {
+					remaining = append(remaining, item.GetKind()+"/"+item.GetName())
+				}
+			}
+			sort.Strings(remaining)
+			if !reflect.DeepEqual(remaining, tc.remaining) {
+				t.Errorf("want remaining objects %v, got %v", tc.remaining, remaining)
+			}
+		})
+	}
+
+	c := newFakeK8s()
+	if err := c.Prune(nil, "", DefaultPruneKinds); err == nil {
+		t.Error("expected an error when pruning without a selector")
+	}
+}
+
+func TestPruneDryRun(t *testing.T) {
+	c := newFakeK8s()
+	c.mapper = pruneMapper()
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, pruneLiveManifest)[0].Objects...)
+
+	orphans, err := c.Orphans(decodeManifest(t, pruneDesiredManifest), "prombench/run-id=1234", DefaultPruneKinds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, o := range orphans {
+		got = append(got, o.Kind+"/"+o.Name)
+	}
+	if want := []string{"Deployment/removed", "Service/removed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want orphans %v, got %v", want, got)
+	}
+	if _, err := c.PruneDryRun(decodeManifest(t, pruneDesiredManifest), "prombench/run-id=1234", DefaultPruneKinds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list, err := c.dynamicClient.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace("prombench").List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("want the dry run to delete nothing, got %d deployments", len(list.Items))
+	}
+
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	out := string(formatOrphans([]Orphan{
+		{pruneKey: pruneKey{GroupKind: schema.GroupKind{Kind: "Service"}, Namespace: "prombench", Name: "removed"}, Created: now.Add(-90 * time.Minute)},
+		{pruneKey: pruneKey{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "prombench", Name: "removed"}, Created: now.Add(-3 * 24 * time.Hour)},
+	}, now))
+	want := `KIND              NAMESPACE   NAME      AGE
+Deployment.apps   prombench   removed   3d
+Service           prombench   removed   90m
+2 object(s) would be pruned.
+`
+	if out != want {
+		t.Errorf("want the table:\n%v\ngot:\n%v", want, out)
+	}
+	if out := string(formatOrphans(nil, now)); !strings.Contains(out, "No objects") {
+		t.Errorf("want no objects, got %q", out)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// validateResourceQuota checks the quota before it is sent to the api server,
+// so a typo in a manifest fails the apply instead of leaving the run without a cap.
+// The quantities are already parsed when the manifest is decoded, so only the values are checked here.
+func validateResourceQuota(req *apiCoreV1.ResourceQuota) error {
+	if len(req.Spec.Hard) == 0 {
+		return fmt.Errorf("spec.hard must set at least one resource")
+	}
+	for _, name := range sortedResourceNames(req.Spec.Hard) {
+		if q := req.Spec.Hard[name]; q.Sign() < 0 {
+			return fmt.Errorf("spec.hard.%v must not be negative, got %v", name, q.String())
+		}
+	}
+	return nil
+}
+
+// validateLimitRange checks that every limit has a known type, no negative quantities
+// and that for every resource min <= defaultRequest <= default <= max, as the api server requires.
+func validateLimitRange(req *apiCoreV1.LimitRange) error {
+	if len(req.Spec.Limits) == 0 {
+		return fmt.Errorf("spec.limits must set at least one limit")
+	}
+	for i, l := range req.Spec.Limits {
+		switch l.Type {
+		case apiCoreV1.LimitTypeContainer, apiCoreV1.LimitTypePod, apiCoreV1.LimitTypePersistentVolumeClaim:
+		default:
+			return fmt.Errorf("spec.limits[%d].type %q must be Container, Pod or PersistentVolumeClaim", i, l.Type)
+		}
+		if l.Type == apiCoreV1.LimitTypePod && (len(l.Default) > 0 || len(l.DefaultRequest) > 0) {
+			return fmt.Errorf("spec.limits[%d] the default and defaultRequest are only supported for the Container type", i)
+		}
+
+		// The order of the fields from the lowest to the highest bound.
+		fields := []struct {
+			name   string
+			values apiCoreV1.ResourceList
+		}{
+			{"min", l.Min},
+			{"defaultRequest", l.DefaultRequest},
+			{"default", l.Default},
+			{"max", l.Max},
+		}
+		for j, lower := range fields {
+			for _, name := range sortedResourceNames(lower.values) {
+				q := lower.values[name]
+				if q.Sign() < 0 {
+					return fmt.Errorf("spec.limits[%d].%v.%v must not be negative, got %v", i, lower.name, name, q.String())
+				}
+				for _, upper := range fields[j+1:] {
+					if u, ok := upper.values[name]; ok && q.Cmp(u) > 0 {
+						return fmt.Errorf("spec.limits[%d].%v.%v %v must not be greater than %v.%v %v", i, lower.name, name, q.String(), upper.name, name, u.String())
+					}
+				}
+			}
+		}
+		for _, name := range sortedResourceNames(l.MaxLimitRequestRatio) {
+			if q := l.MaxLimitRequestRatio[name]; q.Cmp(resource.MustParse("1")) < 0 {
+				return fmt.Errorf("spec.limits[%d].maxLimitRequestRatio.%v must be at least 1, got %v", i, name, q.String())
+			}
+		}
+	}
+	return nil
+}
+
+// sortedResourceNames returns the resource names of the list in a stable order for the error messages.
+func sortedResourceNames(list apiCoreV1.ResourceList) []apiCoreV1.ResourceName {
+	names := make([]apiCoreV1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const quotaManifest = `
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: prombench
+  namespace: prombench-1234
+spec:
+  hard:
+    requests.cpu: "16"
+    limits.memory: 64Gi
+    pods: "50"
+---
+apiVersion: v1
+kind: LimitRange
+metadata:
+  name: prombench
+  namespace: prombench-1234
+spec:
+  limits:
+  - type: Container
+    min:
+      cpu: 10m
+    defaultRequest:
+      cpu: 100m
+      memory: 128Mi
+    default:
+      cpu: 500m
+      memory: 512Mi
+    max:
+      cpu: "4"
+      memory: 16Gi
+`
+
+func TestResourceQuotaLimitRange(t *testing.T) {
+	c := newFakeK8s()
+	if err := c.ResourceApply(decodeManifest(t, quotaManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	quota, err := c.clt.CoreV1().ResourceQuotas("prombench-1234").Get(c.ctx, "prombench", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pods := quota.Spec.Hard[apiCoreV1.ResourcePods]; pods.Value() != 50 {
+		t.Errorf("want a quota of 50 pods, got %v", pods.String())
+	}
+	limits, err := c.clt.CoreV1().LimitRanges("prombench-1234").Get(c.ctx, "prombench", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limits.Spec.Limits) != 1 || limits.Spec.Limits[0].Type != apiCoreV1.LimitTypeContainer {
+		t.Errorf("unexpected limits %v", limits.Spec.Limits)
+	}
+
+	// Applying again updates the existing objects.
+	if err := c.ResourceApply(decodeManifest(t, strings.Replace(quotaManifest, `pods: "50"`, `pods: "100"`, 1))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	quota, err = c.clt.CoreV1().ResourceQuotas("prombench-1234").Get(c.ctx, "prombench", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pods := quota.Spec.Hard[apiCoreV1.ResourcePods]; pods.Value() != 100 {
+		t.Errorf("want the quota updated to 100 pods, got %v", pods.String())
+	}
+
+	if err := c.ResourceDelete(decodeManifest(t, quotaManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list, _ := c.clt.CoreV1().ResourceQuotas("prombench-1234").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+		t.Errorf("want the quota deleted, got %v", list.Items)
+	}
+	if list, _ := c.clt.CoreV1().LimitRanges("prombench-1234").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+		t.Errorf("want the limit range deleted, got %v", list.Items)
+	}
+}
+
+func TestResourceQuotaLimitRangeInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		manifest string
+	}{
+		{
+			name: "empty quota",
+			manifest: `
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: prombench
+spec: {}
+`,
+		},
+		{
+			name: "negative quota",
+			manifest: `
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: prombench
+spec:
+  hard:
+    pods: "-1"
+`,
+		},
+		{
+			name: "unknown limit type",
+			manifest: `
+apiVersion: v1
+kind: LimitRange
+metadata:
+  name: prombench
+spec:
+  limits:
+  - type: Node
+    max:
+      cpu: "4"
+`,
+		},
+		{
+			name: "default above max",
+			manifest: `
+apiVersion: v1
+kind: LimitRange
+metadata:
+  name: prombench
+spec:
+  limits:
+  - type: Container
+    default:
+      memory: 32Gi
+    max:
+      memory: 16Gi
+`,
+		},
+		{
+			name: "min above default request",
+			manifest: `
+apiVersion: v1
+kind: LimitRange
+metadata:
+  name: prombench
+spec:
+  limits:
+  - type: Container
+    min:
+      cpu: "1"
+    defaultRequest:
+      cpu: 100m
+`,
+		},
+		{
+			name: "pod default",
+			manifest: `
+apiVersion: v1
+kind: LimitRange
+metadata:
+  name: prombench
+spec:
+  limits:
+  - type: Pod
+    default:
+      cpu: "1"
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s()
+			if err := c.ResourceApply(decodeManifest(t, tc.manifest)); err == nil {
+				t.Fatal("expected an error for an invalid spec")
+			}
+			if list, _ := c.clt.CoreV1().ResourceQuotas("default").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+				t.Errorf("want no quota created, got %v", list.Items)
+			}
+			if list, _ := c.clt.CoreV1().LimitRanges("default").List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+				t.Errorf("want no limit range created, got %v", list.Items)
+			}
+		})
+	}
+
+	// A quantity that doesn't parse already fails when the manifest is decoded.
+	invalid := strings.Replace(quotaManifest, `pods: "50"`, `pods: fifty`, 1)
+	if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(strings.Split(invalid, "---")[0]), nil, nil); err == nil {
+		t.Error("expected an error decoding an invalid quantity")
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ReconcileLoop re-applies the resources every interval until the context is cancelled,
+// so changes made to the objects in the cluster during a benchmark are reverted.
+// Only the objects that drifted from the resources are applied again.
+// Reconcile errors are logged and retried at the next interval.
+func (c *K8s) ReconcileLoop(ctx context.Context, resources []Resource, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.Errorf("invalid reconcile interval %s, must be > 0", interval)
+	}
+	log.Printf("Reconciling the resources every %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Reconcile loop stopped")
+			return nil
+		case <-ticker.C:
+			if err := c.reconcile(resources); err != nil {
+				log.Printf("Reconcile failed, retrying in %s: %v", interval, err)
+			}
+		}
+	}
+}
+
+// reconcile applies the objects that differ from the live objects in the cluster.
+func (c *K8s) reconcile(resources []Resource) error {
+	for _, r := range resources {
+		var drifted []runtime.Object
+		for _, obj := range r.Objects {
+			diff, ref, err := c.drift(obj)
+			if err != nil {
+				return err
+			}
+			if diff == "" {
+				continue
+			}
+			log.Printf("drift detected - %v: %v", ref, diff)
+			drifted = append(drifted, obj)
+		}
+		if len(drifted) == 0 {
+			continue
+		}
+		if err := c.ResourceApply([]Resource{{FileName: r.FileName, Objects: drifted}}); err != nil {
+			return err
+		}
+		log.Printf("drift corrected - %d objects from %v", len(drifted), r.FileName)
+	}
+	return nil
+}
+
+// drift returns a description of the first difference between the object and its live object,
+// or an empty string when the live object matches.
+// Only the fields set in the object are compared, so defaults and fields set by controllers are ignored.
+func (c *K8s) drift(obj runtime.Object) (string, objectRef, error) {
+	client, ref, err := c.dynamicResource(obj)
+	if err != nil {
+		return "", ref, err
+	}
+	live, err := client.Get(c.ctx, ref.Name, apiMetaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return "the object is missing", ref, nil
+	}
+	if err != nil {
+		return "", ref, errors.Wrapf(err, "getting %v", ref)
+	}
+
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", ref, errors.Wrapf(err, "converting %v", ref)
+	}
+	// The status and most of the metadata are set by the cluster.
+	// Secrets store stringData as data.
+	delete(desired, "status")
+	delete(desired, "stringData")
+	if m, ok := desired["metadata"].(map[string]interface{}); ok {
+		desired["metadata"] = map[string]interface{}{"labels": m["labels"], "annotations": m["annotations"]}
+	}
+	return unstructuredDiff("", desired, live.Object), ref, nil
+}
+
+// unstructuredDiff returns the path and values of the first field set in desired that differs in live.
+// Null values and empty lists in desired are treated as not set.
+func unstructuredDiff(path string, desired, live interface{}) string {
+	switch d := desired.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		l, _ := live.(map[string]interface{})
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if diff := unstructuredDiff(path+"."+k, d[k], l[k]); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	case []interface{}:
+		if len(d) == 0 {
+			return ""
+		}
+		l, _ := live.([]interface{})
+		if len(l) != len(d) {
+			return fmt.Sprintf("%v has %d items, want %d", path, len(l), len(d))
+		}
+		for i := range d {
+			if diff := unstructuredDiff(fmt.Sprintf("%v[%d]", path, i), d[i], l[i]); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	default:
+		if !reflect.DeepEqual(desired, live) {
+			return fmt.Sprintf("%v is %v, want %v", path, live, desired)
+		}
+		return ""
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const reconcileManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+  labels:
+    app: loadgen
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: loadgen
+  template:
+    metadata:
+      labels:
+        app: loadgen
+    spec:
+      containers:
+      - name: querier
+        image: prom/loadgen:latest
+`
+
+func TestDrift(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	for _, tc := range []struct {
+		name string
+		// old and new edit the manifest to get the live object.
+		old, new string
+		drift    string
+	}{
+		{name: "no drift"},
+		{name: "server defaults", old: "  replicas: 2\n", new: "  replicas: 2\n  strategy:\n    type: RollingUpdate\n"},
+		{name: "scaled", old: "replicas: 2", new: "replicas: 5", drift: ".spec.replicas is 5, want 2"},
+		{name: "image", old: "prom/loadgen:latest", new: "prom/loadgen:debug", drift: ".spec.template.spec.containers[0].image is prom/loadgen:debug, want prom/loadgen:latest"},
+		{name: "label", old: "    app: loadgen\nspec", new: "    team: prombench\nspec", drift: ".metadata.labels.app is <nil>, want loadgen"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			live := strings.Replace(reconcileManifest, tc.old, tc.new, 1)
+			c := newFakeK8s()
+			c.mapper = mapper
+			c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, live)[0].Objects...)
+
+			diff, ref, err := c.drift(decodeManifest(t, reconcileManifest)[0].Objects[0])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ref.String() != "Deployment/prombench/loadgen" {
+				t.Errorf("unexpected object reference %v", ref)
+			}
+			if diff != tc.drift {
+				t.Errorf("want drift %q, got %q", tc.drift, diff)
+			}
+		})
+	}
+
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme)
+	diff, _, err := c.drift(decodeManifest(t, reconcileManifest)[0].Objects[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != "the object is missing" {
+		t.Errorf("expected a missing object, got %q", diff)
+	}
+}
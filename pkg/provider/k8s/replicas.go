@@ -0,0 +1,87 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// SetReplicas sets the replicas of all deployments, statefulsets and replicasets of the resources.
+func SetReplicas(resources []Resource, n int32) error {
+	return SetKindReplicas(resources, "", n)
+}
+
+// SetKindReplicas sets the replicas of the objects of the given kind, e.g. Deployment,
+// or of all kinds with replicas when the kind is empty.
+func SetKindReplicas(resources []Resource, kind string, n int32) error {
+	return SetReplicasFunc(resources, kind, func(string) int32 { return n })
+}
+
+// SetReplicasFunc sets the replicas of the objects of the given kind to the value returned for their name,
+// e.g. to split the replicas between deployments. An empty kind selects all kinds with replicas.
+// It returns an error when the resources have no such object, so a wrong kind doesn't go unnoticed.
+func SetReplicasFunc(resources []Resource, kind string, replicas func(name string) int32) error {
+	var found bool
+	for _, r := range resources {
+		for _, obj := range r.Objects {
+			if kind != "" && !strings.EqualFold(obj.GetObjectKind().GroupVersionKind().Kind, kind) {
+				continue
+			}
+			// Every object gets its own replicas value, so changing one later doesn't change the others.
+			switch req := obj.(type) {
+			case *appsV1.Deployment:
+				n := replicas(req.Name)
+				req.Spec.Replicas = &n
+			case *appsV1.StatefulSet:
+				n := replicas(req.Name)
+				req.Spec.Replicas = &n
+			case *appsV1.ReplicaSet:
+				n := replicas(req.Name)
+				req.Spec.Replicas = &n
+			default:
+				continue
+			}
+			found = true
+		}
+	}
+	if !found {
+		if kind == "" {
+			kind = "deployments, statefulsets or replicasets"
+		}
+		return fmt.Errorf("no %v to set the replicas of in the resources", kind)
+	}
+	return nil
+}
+
+// FilterObjects returns copies of the objects for which keep returns true, grouped by file like the resources.
+// Files without such objects are left out. Changing the copies, e.g. with SetReplicas, leaves the resources unchanged.
+func FilterObjects(resources []Resource, keep func(runtime.Object) bool) []Resource {
+	var filtered []Resource
+	for _, r := range resources {
+		var objects []runtime.Object
+		for _, obj := range r.Objects {
+			if keep(obj) {
+				objects = append(objects, obj.DeepCopyObject())
+			}
+		}
+		if len(objects) > 0 {
+			filtered = append(filtered, Resource{FileName: r.FileName, Objects: objects})
+		}
+	}
+	return filtered
+}
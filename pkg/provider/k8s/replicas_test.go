@@ -0,0 +1,106 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const replicasManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+spec:
+  replicas: 2
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: prometheus
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: loadgen-config
+`
+
+func replicasOf(t *testing.T, resources []Resource) map[string]int32 {
+	t.Helper()
+	replicas := map[string]int32{}
+	for _, r := range resources {
+		for _, obj := range r.Objects {
+			switch req := obj.(type) {
+			case *appsV1.Deployment:
+				replicas[req.Name] = *req.Spec.Replicas
+			case *appsV1.StatefulSet:
+				replicas[req.Name] = *req.Spec.Replicas
+			}
+		}
+	}
+	return replicas
+}
+
+func TestSetReplicas(t *testing.T) {
+	resources := decodeManifest(t, replicasManifest)
+	if err := SetReplicas(resources, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := replicasOf(t, resources); got["loadgen"] != 5 || got["prometheus"] != 5 {
+		t.Errorf("want 5 replicas for all objects, got %v", got)
+	}
+
+	if err := SetKindReplicas(resources, "deployment", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := replicasOf(t, resources); got["loadgen"] != 3 || got["prometheus"] != 5 {
+		t.Errorf("want 3 replicas only for the deployment, got %v", got)
+	}
+
+	if err := SetKindReplicas(resources, "DaemonSet", 3); err == nil {
+		t.Error("expected an error for a kind that isn't in the resources")
+	}
+	if err := SetReplicas(nil, 1); err == nil {
+		t.Error("expected an error for resources without objects with replicas")
+	}
+}
+
+func TestFilterObjects(t *testing.T) {
+	resources := decodeManifest(t, replicasManifest)
+	deployments := FilterObjects(resources, func(obj runtime.Object) bool {
+		_, ok := obj.(*appsV1.Deployment)
+		return ok
+	})
+	if len(deployments) != 1 || len(deployments[0].Objects) != 1 || deployments[0].FileName != "manifest.yaml" {
+		t.Fatalf("want only the deployment of manifest.yaml, got %v", deployments)
+	}
+	split := map[string]int32{"loadgen": 7}
+	if err := SetReplicasFunc(deployments, "Deployment", func(name string) int32 { return split[name] }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := replicasOf(t, deployments); got["loadgen"] != 7 {
+		t.Errorf("want 7 replicas for the filtered deployment, got %v", got)
+	}
+	if got := replicasOf(t, resources); got["loadgen"] != 2 {
+		t.Errorf("want the replicas of the resources unchanged, got %v", got)
+	}
+	if none := FilterObjects(resources, func(runtime.Object) bool { return false }); len(none) != 0 {
+		t.Errorf("want no resources without matching objects, got %v", none)
+	}
+}
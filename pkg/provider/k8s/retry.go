@@ -0,0 +1,149 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// retryTransport retries the k8s requests that failed with a transient error by the policy of their operation class,
+// so every client of the provider gets the same retries instead of each call retrying on its own.
+type retryTransport struct {
+	next     http.RoundTripper
+	policies provider.RetryPolicies
+	sleep    func(context.Context, time.Duration) error
+
+	mtx  sync.Mutex
+	rand *rand.Rand
+}
+
+func newRetryTransport(next http.RoundTripper, policies provider.RetryPolicies) *retryTransport {
+	return &retryTransport{
+		next:     next,
+		policies: policies,
+		sleep:    sleepContext,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// retryOperation returns the operation class of the request by its method.
+func retryOperation(method string) provider.RetryOperation {
+	switch method {
+	case http.MethodPost:
+		return provider.RetryCreate
+	case http.MethodPut, http.MethodPatch:
+		return provider.RetryUpdate
+	case http.MethodDelete:
+		return provider.RetryDelete
+	}
+	return provider.RetryRead
+}
+
+// RoundTrip sends every retry as a clone of the request with a new body, the request of the caller isn't modified.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op := retryOperation(req.Method)
+	policy, ok := t.policies[op]
+	// A request body that can't be read again can't be retried.
+	if !ok || policy.Attempts <= 1 || (req.Body != nil && req.GetBody == nil) {
+		return t.next.RoundTrip(req)
+	}
+	r := req
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(r)
+		if attempt >= policy.Attempts || !retriable(op, resp, err) {
+			return resp, err
+		}
+
+		t.mtx.Lock()
+		delay := policy.Delay(attempt, t.rand.Float64())
+		t.mtx.Unlock()
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if after := retryAfter(resp); after > delay {
+				delay = after
+				if delay > policy.MaxDelay {
+					delay = policy.MaxDelay
+				}
+			}
+			resp.Body.Close()
+		}
+		log.Printf("k8s %v request %v %v failed, attempt %d/%d, retrying in %s: %v", op, req.Method, req.URL.Path, attempt, policy.Attempts, delay, reason)
+
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		r = req.Clone(req.Context())
+		if req.Body != nil {
+			if r.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// sleepContext waits for the delay and returns the error of the context when it is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retriable returns true for the responses and errors that may succeed when retried later:
+// throttling, an api server or a proxy in front of it that is temporarily unavailable and connection errors.
+// A create may already have been processed when the connection failed after it was sent,
+// so it is only retried when the connection was refused.
+func retriable(op provider.RetryOperation, resp *http.Response, err error) bool {
+	if err != nil {
+		if op == provider.RetryCreate {
+			return errors.Is(err, syscall.ECONNREFUSED)
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return op != provider.RetryCreate
+	}
+	return false
+}
+
+// retryAfter returns the delay of the Retry-After header in seconds the api server sets when it throttles, 0 without it.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// fakeRoundTripper returns the statuses in order and records the request bodies.
+type fakeRoundTripper struct {
+	statuses []int
+	headers  http.Header
+	bodies   []string
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		f.bodies = append(f.bodies, string(b))
+	}
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Header: f.headers, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestRetryTransport(t *testing.T) {
+	policies := provider.RetryPolicies{
+		provider.RetryRead:   {Attempts: 3, BaseDelay: time.Second, MaxDelay: 4 * time.Second},
+		provider.RetryCreate: {Attempts: 3, BaseDelay: time.Second, MaxDelay: 4 * time.Second},
+		provider.RetryUpdate: {Attempts: 3, BaseDelay: time.Second, MaxDelay: 4 * time.Second},
+	}
+	for name, tc := range map[string]struct {
+		method   string
+		statuses []int
+		header   http.Header
+		want     int
+		delays   []time.Duration
+	}{
+		"read retried until it succeeds": {
+			method: http.MethodGet, statuses: []int{503, 429, 200}, want: 200, delays: []time.Duration{time.Second, 2 * time.Second},
+		},
+		"read gives up after the attempts": {
+			method: http.MethodGet, statuses: []int{503}, want: 503, delays: []time.Duration{time.Second, 2 * time.Second},
+		},
+		"not found isn't retried": {
+			method: http.MethodGet, statuses: []int{404}, want: 404,
+		},
+		"create isn't retried after a gateway timeout": {
+			method: http.MethodPost, statuses: []int{504, 201}, want: 504,
+		},
+		"create is retried when throttled": {
+			method: http.MethodPost, statuses: []int{429, 201}, want: 201, delays: []time.Duration{time.Second},
+		},
+		"retry after capped at the max delay": {
+			method: http.MethodPut, statuses: []int{429, 200}, header: http.Header{"Retry-After": []string{"60"}}, want: 200, delays: []time.Duration{4 * time.Second},
+		},
+		"delete without a policy isn't retried": {
+			method: http.MethodDelete, statuses: []int{503, 200}, want: 503,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			next := &fakeRoundTripper{statuses: tc.statuses, headers: tc.header}
+			rt := newRetryTransport(next, policies)
+			var delays []time.Duration
+			rt.sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			req, err := http.NewRequest(tc.method, "https://k8s/api/v1/namespaces/default/pods", bytes.NewReader([]byte(`{"kind":"Pod"}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := req.Body
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if req.Body != body {
+				t.Error("want the request of the caller unmodified by the retries")
+			}
+			if resp.StatusCode != tc.want {
+				t.Errorf("want the status %d, got %d", tc.want, resp.StatusCode)
+			}
+			if len(delays) != len(tc.delays) {
+				t.Fatalf("want the delays %v, got %v", tc.delays, delays)
+			}
+			for i := range delays {
+				if delays[i] != tc.delays[i] {
+					t.Errorf("want the delays %v, got %v", tc.delays, delays)
+				}
+			}
+			// Every attempt sends the whole body again.
+			for _, b := range next.bodies {
+				if b != `{"kind":"Pod"}` {
+					t.Errorf("want the body sent again on every attempt, got %q", next.bodies)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportCanceled(t *testing.T) {
+	policies := provider.RetryPolicies{
+		provider.RetryRead: {Attempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour},
+	}
+	rt := newRetryTransport(&fakeRoundTripper{statuses: []int{503}}, policies)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://k8s/api/v1/namespaces/default/pods", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want the error of the context, got %v", err)
+	}
+	if waited := time.Since(start); waited > 10*time.Second {
+		t.Errorf("want the retry delay to end with the context, waited %s", waited)
+	}
+}
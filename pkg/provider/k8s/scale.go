@@ -0,0 +1,179 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// ScaleTarget is an object scaled through its scale subresource,
+// e.g. an Argo Rollout or any other custom resource that supports scaling.
+type ScaleTarget struct {
+	Resource  schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+func (t ScaleTarget) String() string {
+	return fmt.Sprintf("%v/%v/%v", t.Resource.GroupResource(), t.Namespace, t.Name)
+}
+
+// ParseScaleTarget parses a scale target in the group/version/resource/name format,
+// e.g. argoproj.io/v1alpha1/rollouts/loadgen. Use core as the group of the core api resources.
+func ParseScaleTarget(value, namespace string) (ScaleTarget, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 4 {
+		return ScaleTarget{}, fmt.Errorf("invalid scale target %q, expected group/version/resource/name", value)
+	}
+	for _, p := range parts[1:] {
+		if p == "" {
+			return ScaleTarget{}, fmt.Errorf("invalid scale target %q, expected group/version/resource/name", value)
+		}
+	}
+	group := parts[0]
+	if group == "core" {
+		group = ""
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	return ScaleTarget{
+		Resource:  schema.GroupVersionResource{Group: group, Version: parts[1], Resource: parts[2]},
+		Namespace: namespace,
+		Name:      parts[3],
+	}, nil
+}
+
+// CheckScaleTarget returns an error when the target resource doesn't exist or doesn't have a scale subresource.
+func (c *K8s) CheckScaleTarget(t ScaleTarget) error {
+	gv := t.Resource.GroupVersion().String()
+	list, err := c.clt.Discovery().ServerResourcesForGroupVersion(gv)
+	if err != nil {
+		return errors.Wrapf(err, "finding the api resources of %v", gv)
+	}
+	var found bool
+	for _, r := range list.APIResources {
+		switch r.Name {
+		case t.Resource.Resource:
+			found = true
+		case t.Resource.Resource + "/scale":
+			return nil
+		}
+	}
+	if !found {
+		return fmt.Errorf("resource %v not found in %v", t.Resource.Resource, gv)
+	}
+	return fmt.Errorf("resource %v doesn't support scaling, it has no scale subresource", t.Resource.GroupResource())
+}
+
+// SelectScaleTargets returns the objects of the resource in the namespace that match the label selector as scale targets,
+// sorted by name, e.g. to scale existing deployments without their manifests.
+// It returns an error when no object matches, as there would be nothing to scale.
+func (c *K8s) SelectScaleTargets(gvr schema.GroupVersionResource, namespace, selector string) ([]ScaleTarget, error) {
+	if _, err := labels.Parse(selector); err != nil {
+		return nil, errors.Wrapf(err, "invalid label selector %q", selector)
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	items, err := c.List(gvr, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no %v in namespace %v match the selector %q", gvr.GroupResource(), namespace, selector)
+	}
+	targets := make([]ScaleTarget, 0, len(items))
+	for _, item := range items {
+		targets = append(targets, ScaleTarget{Resource: gvr, Namespace: namespace, Name: item.GetName()})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Name < targets[j].Name })
+	return targets, nil
+}
+
+// Scale sets the replicas of the target through its scale subresource
+// and waits until the target reports the same number of replicas.
+func (c *K8s) Scale(t ScaleTarget, replicas int32) error {
+	client := c.dynamicClient.Resource(t.Resource).Namespace(t.Namespace)
+	scale, err := client.Get(c.ctx, t.Name, apiMetaV1.GetOptions{}, "scale")
+	if err != nil {
+		return errors.Wrapf(err, "getting the scale of %v", t)
+	}
+	if err := unstructured.SetNestedField(scale.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return errors.Wrapf(err, "setting the replicas of %v", t)
+	}
+	if _, err := client.Update(c.ctx, scale, apiMetaV1.UpdateOptions{}, "scale"); err != nil {
+		return errors.Wrapf(err, "updating the scale of %v", t)
+	}
+	log.Printf("resource scaled - %v, replicas: %d", t, replicas)
+
+	return provider.RetryUntilTrue(
+		fmt.Sprintf("scaling %v to %d", t, replicas),
+		provider.GlobalRetryCount,
+		func() (bool, error) { return c.scaleReady(t, replicas) },
+	)
+}
+
+// scaleReady returns true once the status of the target scale reports the given replicas.
+func (c *K8s) scaleReady(t ScaleTarget, replicas int32) (bool, error) {
+	scale, err := c.dynamicClient.Resource(t.Resource).Namespace(t.Namespace).Get(c.ctx, t.Name, apiMetaV1.GetOptions{}, "scale")
+	if err != nil {
+		return false, errors.Wrapf(err, "checking the scale of %v", t)
+	}
+	current, _, err := unstructured.NestedInt64(scale.Object, "status", "replicas")
+	if err != nil {
+		return false, errors.Wrapf(err, "reading the replicas of %v", t)
+	}
+	return current == int64(replicas), nil
+}
+
+// ScaleSelector returns the label selector of the target pods from the status of the target scale.
+func (c *K8s) ScaleSelector(t ScaleTarget) (string, error) {
+	scale, err := c.dynamicClient.Resource(t.Resource).Namespace(t.Namespace).Get(c.ctx, t.Name, apiMetaV1.GetOptions{}, "scale")
+	if err != nil {
+		return "", errors.Wrapf(err, "getting the scale of %v", t)
+	}
+	selector, _, err := unstructured.NestedString(scale.Object, "status", "selector")
+	if err != nil {
+		return "", errors.Wrapf(err, "reading the selector of %v", t)
+	}
+	if selector == "" {
+		return "", fmt.Errorf("the scale of %v has no pod selector", t)
+	}
+	return selector, nil
+}
+
+// ScaleReplicas returns the replicas requested in the spec of the target scale.
+func (c *K8s) ScaleReplicas(t ScaleTarget) (int32, error) {
+	scale, err := c.dynamicClient.Resource(t.Resource).Namespace(t.Namespace).Get(c.ctx, t.Name, apiMetaV1.GetOptions{}, "scale")
+	if err != nil {
+		return 0, errors.Wrapf(err, "getting the scale of %v", t)
+	}
+	replicas, _, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	if err != nil {
+		return 0, errors.Wrapf(err, "reading the replicas of %v", t)
+	}
+	return int32(replicas), nil
+}
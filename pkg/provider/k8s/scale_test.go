@@ -0,0 +1,122 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestParseScaleTarget(t *testing.T) {
+	for value, want := range map[string]*ScaleTarget{
+		"argoproj.io/v1alpha1/rollouts/loadgen": {
+			Resource:  schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"},
+			Namespace: "prombench",
+			Name:      "loadgen",
+		},
+		"core/v1/replicationcontrollers/loadgen": {
+			Resource:  schema.GroupVersionResource{Version: "v1", Resource: "replicationcontrollers"},
+			Namespace: "prombench",
+			Name:      "loadgen",
+		},
+		"argoproj.io/v1alpha1/rollouts":  nil,
+		"argoproj.io/v1alpha1//loadgen":  nil,
+		"argoproj.io/v1alpha1/rollouts/": nil,
+	} {
+		got, err := ParseScaleTarget(value, "prombench")
+		if want == nil {
+			if err == nil {
+				t.Errorf("%v: expected an error", value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", value, err)
+			continue
+		}
+		if got != *want {
+			t.Errorf("%v: want %v, got %v", value, *want, got)
+		}
+	}
+}
+
+func TestCheckScaleTarget(t *testing.T) {
+	c := newFakeK8s()
+	c.clt.Discovery().(*fakeDiscovery.FakeDiscovery).Resources = []*apiMetaV1.APIResourceList{{
+		GroupVersion: "argoproj.io/v1alpha1",
+		APIResources: []apiMetaV1.APIResource{
+			{Name: "rollouts"},
+			{Name: "rollouts/scale"},
+			{Name: "analysisruns"},
+		},
+	}}
+	for value, ok := range map[string]bool{
+		"argoproj.io/v1alpha1/rollouts/loadgen":     true,
+		"argoproj.io/v1alpha1/analysisruns/loadgen": false,
+		"argoproj.io/v1alpha1/experiments/loadgen":  false,
+		"argoproj.io/v1/rollouts/loadgen":           false,
+	} {
+		target, err := ParseScaleTarget(value, "")
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		if err := c.CheckScaleTarget(target); ok != (err == nil) {
+			t.Errorf("%v: want supported %v, got error %v", value, ok, err)
+		}
+	}
+}
+
+func TestSelectScaleTargets(t *testing.T) {
+	deployment := func(name, namespace, app string) *appsV1.Deployment {
+		return &appsV1.Deployment{
+			TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+		}
+	}
+	c := newFakeK8s()
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme,
+		deployment("loadgen-b", "prombench", "loadgen"),
+		deployment("loadgen-a", "prombench", "loadgen"),
+		deployment("prometheus", "prombench", "prometheus"),
+		deployment("loadgen", "default", "loadgen"),
+	)
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	targets, err := c.SelectScaleTargets(gvr, "prombench", "app=loadgen")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []ScaleTarget{
+		{Resource: gvr, Namespace: "prombench", Name: "loadgen-a"},
+		{Resource: gvr, Namespace: "prombench", Name: "loadgen-b"},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("want %v, got %v", expected, targets)
+	}
+
+	if _, err := c.SelectScaleTargets(gvr, "prombench", "app=missing"); err == nil || !strings.Contains(err.Error(), "no deployments.apps in namespace prombench") {
+		t.Errorf("expected an error for no match, got %v", err)
+	}
+	if _, err := c.SelectScaleTargets(gvr, "prombench", "app in (loadgen"); err == nil {
+		t.Error("expected an error for an invalid selector")
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// FieldManager is the field manager of the server-side applies.
+// It is also the manager the api server records for the updates of the regular applies, from the user agent of the infra binary.
+const FieldManager = "infra"
+
+// serverSideApply applies the object with a server-side apply as FieldManager,
+// taking over the fields that conflict with other managers.
+// With PruneFields the fields FieldManager owns in the live object that are no longer in the manifest are removed first.
+// A server-side apply only removes the fields it applied before itself, not the ones set by the updates of the regular applies,
+// so without pruning these stay forever once the manifest doesn't set them anymore.
+func (c *K8s) serverSideApply(resource runtime.Object) error {
+	client, ref, err := c.dynamicResource(resource)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
+	if err != nil {
+		return errors.Wrapf(err, "converting %v", ref)
+	}
+	desired := &unstructured.Unstructured{Object: content}
+	if ref.Namespace != "" {
+		desired.SetNamespace(ref.Namespace)
+	}
+	// The status is ignored by the apply and the converted typed objects have empty timestamps.
+	delete(desired.Object, "status")
+	unstructured.RemoveNestedField(desired.Object, "metadata", "creationTimestamp")
+
+	if c.PruneFields {
+		live, err := client.Get(c.ctx, ref.Name, apiMetaV1.GetOptions{})
+		if err != nil && !apiErrors.IsNotFound(err) {
+			return errors.Wrapf(err, "getting %v", ref)
+		}
+		if err == nil {
+			removed, err := pruneManagedFields(live, desired, FieldManager)
+			if err != nil {
+				return errors.Wrapf(err, "pruning %v", ref)
+			}
+			if len(removed) > 0 {
+				if _, err := client.Update(c.ctx, live, apiMetaV1.UpdateOptions{FieldManager: FieldManager}); err != nil {
+					return errors.Wrapf(err, "removing the pruned fields of %v", ref)
+				}
+				log.Printf("resource pruned - %v, fields: %v", ref, strings.Join(removed, ", "))
+			}
+		}
+	}
+
+	data, err := json.Marshal(desired.Object)
+	if err != nil {
+		return errors.Wrapf(err, "marshaling %v", ref)
+	}
+	force := true
+	if _, err := client.Patch(c.ctx, ref.Name, types.ApplyPatchType, data, apiMetaV1.PatchOptions{FieldManager: FieldManager, Force: &force}); err != nil {
+		return errors.Wrapf(err, "server-side apply failed - %v", ref)
+	}
+	log.Printf("resource applied - %v", ref)
+	return c.waitServerSideApplied(resource, ref)
+}
+
+// waitServerSideApplied waits for the deployments, statefulsets and replicasets to become ready and the jobs to complete,
+// the same as the regular applies.
+func (c *K8s) waitServerSideApplied(resource runtime.Object, ref objectRef) error {
+	if c.NoWait {
+		return nil
+	}
+	var ready func(runtime.Object) (bool, error)
+	switch resource.(type) {
+	case *appsV1.Deployment:
+		ready = c.deploymentReady
+	case *appsV1.StatefulSet:
+		ready = c.statefulSetReady
+	case *appsV1.ReplicaSet:
+		ready = c.replicaSetReady
+	case *batchV1.Job:
+		ready = c.jobReady
+	default:
+		return nil
+	}
+	if err := provider.RetryUntilTrue(
+		fmt.Sprintf("applying %v", ref),
+		provider.GlobalRetryCount,
+		func() (bool, error) { return ready(resource) }); err != nil {
+		c.logEvents(ref.Namespace, ref.Name)
+		return err
+	}
+	return nil
+}
+
+// pruneManagedFields removes the fields of the live object that the manager owns, no other manager owns
+// and the desired object doesn't set, and returns their paths.
+// The ownership comes from the managed fields entries of the object, not from the ones of its subresources, e.g. status.
+func pruneManagedFields(live, desired *unstructured.Unstructured, manager string) ([]string, error) {
+	owned, others := map[string]interface{}{}, map[string]interface{}{}
+	for _, entry := range live.GetManagedFields() {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, errors.Wrapf(err, "parsing the managed fields of %v", entry.Manager)
+		}
+		if entry.Manager == manager && entry.Subresource == "" {
+			mergeFieldSets(owned, fields)
+		} else {
+			mergeFieldSets(others, fields)
+		}
+	}
+	var removed []string
+	pruned := pruneFields("", live.Object, desired.Object, owned, others, &removed)
+	live.Object = pruned.(map[string]interface{})
+	return removed, nil
+}
+
+// mergeFieldSets adds the fields of the FieldsV1 set src to dst.
+func mergeFieldSets(dst, src map[string]interface{}) {
+	for k, v := range src {
+		children, ok := v.(map[string]interface{})
+		if !ok {
+			if _, exists := dst[k]; !exists {
+				dst[k] = v
+			}
+			continue
+		}
+		d, ok := dst[k].(map[string]interface{})
+		if !ok {
+			d = map[string]interface{}{}
+			dst[k] = d
+		}
+		mergeFieldSets(d, children)
+	}
+}
+
+// pruneFields removes the fields of the live value in the owned FieldsV1 set that aren't in the desired value
+// or in the others set, and returns the live value. The paths of the removed fields are appended to removed.
+func pruneFields(path string, live, desired interface{}, owned, others map[string]interface{}, removed *[]string) interface{} {
+	switch l := live.(type) {
+	case map[string]interface{}:
+		d, _ := desired.(map[string]interface{})
+		for key, sub := range owned {
+			if !strings.HasPrefix(key, "f:") {
+				continue
+			}
+			name := strings.TrimPrefix(key, "f:")
+			lv, ok := l[name]
+			if !ok {
+				continue
+			}
+			otherSub, otherOwned := others[key]
+			dv, ok := d[name]
+			if !ok {
+				if !otherOwned {
+					delete(l, name)
+					*removed = append(*removed, path+fieldSegment(key))
+				}
+				continue
+			}
+			ownedChildren, _ := sub.(map[string]interface{})
+			otherChildren, _ := otherSub.(map[string]interface{})
+			l[name] = pruneFields(path+fieldSegment(key), lv, dv, ownedChildren, otherChildren, removed)
+		}
+		return l
+	case []interface{}:
+		d, _ := desired.([]interface{})
+		kept := make([]interface{}, 0, len(l))
+		for _, item := range l {
+			key, ok := listItemKey(item, owned)
+			if !ok {
+				kept = append(kept, item)
+				continue
+			}
+			otherSub, otherOwned := others[key]
+			var match interface{}
+			found := false
+			for _, di := range d {
+				if listItemMatches(di, key) {
+					match, found = di, true
+					break
+				}
+			}
+			if !found {
+				if otherOwned {
+					kept = append(kept, item)
+				} else {
+					*removed = append(*removed, path+fieldSegment(key))
+				}
+				continue
+			}
+			ownedChildren, _ := owned[key].(map[string]interface{})
+			otherChildren, _ := otherSub.(map[string]interface{})
+			kept = append(kept, pruneFields(path+fieldSegment(key), item, match, ownedChildren, otherChildren, removed))
+		}
+		return kept
+	}
+	return live
+}
+
+// listItemKey returns the k:{...} or v:value key of the owned FieldsV1 set that identifies the list item.
+func listItemKey(item interface{}, owned map[string]interface{}) (string, bool) {
+	for key := range owned {
+		if (strings.HasPrefix(key, "k:") || strings.HasPrefix(key, "v:")) && listItemMatches(item, key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// listItemMatches returns whether the list item has the key fields of a k:{...} key or the value of a v:value key.
+func listItemMatches(item interface{}, key string) bool {
+	prefix, value, _ := strings.Cut(key, ":")
+	switch prefix {
+	case "k":
+		var keys map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &keys); err != nil {
+			return false
+		}
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range keys {
+			if fmt.Sprint(m[k]) != fmt.Sprint(v) {
+				return false
+			}
+		}
+		return true
+	case "v":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return false
+		}
+		return fmt.Sprint(item) == fmt.Sprint(v)
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+// serverSideLiveManifest was applied with an old label, args and a sidecar by infra,
+// paused by kubectl edit and annotated by the deployment controller.
+const serverSideLiveManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus
+  namespace: prombench
+  labels:
+    app: prometheus
+    old: "true"
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+  managedFields:
+  - manager: infra
+    operation: Update
+    apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:metadata:
+        f:labels:
+          .: {}
+          f:app: {}
+          f:old: {}
+      f:spec:
+        f:replicas: {}
+        f:template:
+          f:spec:
+            f:containers:
+              k:{"name":"prometheus"}:
+                .: {}
+                f:name: {}
+                f:image: {}
+                f:args: {}
+              k:{"name":"sidecar"}:
+                .: {}
+                f:name: {}
+                f:image: {}
+  - manager: kubectl-edit
+    operation: Update
+    apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:paused: {}
+  - manager: kube-controller-manager
+    operation: Update
+    apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:metadata:
+        f:annotations:
+          .: {}
+          f:deployment.kubernetes.io/revision: {}
+spec:
+  replicas: 2
+  paused: true
+  template:
+    spec:
+      containers:
+      - name: prometheus
+        image: prom/prometheus:v2.45.0
+        args: ["--storage.tsdb.retention.time=1d"]
+      - name: sidecar
+        image: busybox
+`
+
+const serverSideDesiredManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus
+  namespace: prombench
+  labels:
+    app: prometheus
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: prometheus
+        image: prom/prometheus:v2.46.0
+`
+
+func TestServerSideApplyPruneFields(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.NoWait = true
+	c.ServerSideApply = true
+	c.PruneFields = true
+	client := dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, serverSideLiveManifest)[0].Objects...)
+	// The fake client doesn't implement server-side applies.
+	var apply k8sTesting.PatchAction
+	client.PrependReactor("patch", "deployments", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		apply = action.(k8sTesting.PatchAction)
+		return true, &unstructured.Unstructured{}, nil
+	})
+	c.dynamicClient = client
+
+	if err := c.ResourceApply(decodeManifest(t, serverSideDesiredManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if apply == nil || apply.GetPatchType() != types.ApplyPatchType || apply.GetName() != "prometheus" || apply.GetNamespace() != "prombench" {
+		t.Fatalf("unexpected apply request %v", apply)
+	}
+
+	live, err := client.Resource(deployments).Namespace("prombench").Get(c.ctx, "prometheus", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := live.GetLabels()["old"]; ok {
+		t.Error("want the label removed from the manifest pruned")
+	}
+	containers, _, _ := unstructured.NestedSlice(live.Object, "spec", "template", "spec", "containers")
+	if len(containers) != 1 {
+		t.Fatalf("want the sidecar removed from the manifest pruned, got %v", containers)
+	}
+	if _, ok := containers[0].(map[string]interface{})["args"]; ok {
+		t.Error("want the args removed from the manifest pruned")
+	}
+	// The fields of the other managers are kept.
+	if paused, _, _ := unstructured.NestedBool(live.Object, "spec", "paused"); !paused {
+		t.Error("want the field owned by another manager kept")
+	}
+	if live.GetAnnotations()["deployment.kubernetes.io/revision"] != "3" {
+		t.Error("want the annotation of the deployment controller kept")
+	}
+}
+
+func TestPruneManagedFields(t *testing.T) {
+	live := &unstructured.Unstructured{}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(decodeManifest(t, serverSideLiveManifest)[0].Objects[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	live.Object = content
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(decodeManifest(t, serverSideDesiredManifest)[0].Objects[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := pruneManagedFields(live, &unstructured.Unstructured{Object: desired}, FieldManager)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(removed)
+	want := []string{
+		".metadata.labels.old",
+		".spec.template.spec.containers[name=prometheus].args",
+		".spec.template.spec.containers[name=sidecar]",
+	}
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("want removed %v, got %v", want, removed)
+	}
+
+	// Nothing is owned by another manager name.
+	removed, err = pruneManagedFields(live, &unstructured.Unstructured{Object: desired}, "helm")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("want nothing removed for a manager without fields, got %v", removed)
+	}
+}
+
+func TestPruneFieldsRequiresServerSide(t *testing.T) {
+	c := newFakeK8s()
+	c.PruneFields = true
+	if err := c.ResourceApply(decodeManifest(t, serverSideDesiredManifest)); err == nil {
+		t.Error("want an error for pruning without the server-side apply")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serviceEndpointPollInterval is how often WaitForServiceEndpoint checks the service status.
+var serviceEndpointPollInterval = 5 * time.Second
+
+// WaitForServiceEndpoint waits until the cloud load balancer of a LoadBalancer service is assigned
+// and returns its external IP, or its hostname for load balancers without an IP, e.g. on EKS.
+// An empty namespace uses the "default" namespace.
+func (c *K8s) WaitForServiceEndpoint(namespace, name string, timeout time.Duration) (string, error) {
+	if namespace == "" {
+		namespace = "default"
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		svc, err := c.clt.CoreV1().Services(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+		if err != nil {
+			return "", errors.Wrapf(err, "getting the service %v/%v", namespace, name)
+		}
+		if svc.Spec.Type != apiCoreV1.ServiceTypeLoadBalancer {
+			return "", fmt.Errorf("service %v/%v has type %q, an external endpoint requires the LoadBalancer type", namespace, name, svc.Spec.Type)
+		}
+		if endpoint := loadBalancerEndpoint(svc.Status.LoadBalancer); endpoint != "" {
+			log.Printf("service %v/%v endpoint assigned - %v", namespace, name, endpoint)
+			return endpoint, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out after %s waiting for the load balancer of service %v/%v", timeout, namespace, name)
+		}
+		time.Sleep(serviceEndpointPollInterval)
+	}
+}
+
+// loadBalancerEndpoint returns the IP of the first load balancer ingress, or its hostname when it has no IP.
+func loadBalancerEndpoint(status apiCoreV1.LoadBalancerStatus) string {
+	for _, ingress := range status.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+	return ""
+}
+
+// LoadBalancerService returns the LoadBalancer service with the given name from the resources,
+// e.g. to find the namespace of a service passed from the cli.
+func LoadBalancerService(resources []Resource, name string) (*apiCoreV1.Service, error) {
+	for _, r := range resources {
+		for _, obj := range r.Objects {
+			svc, ok := obj.(*apiCoreV1.Service)
+			if !ok || svc.Name != name {
+				continue
+			}
+			if svc.Spec.Type != apiCoreV1.ServiceTypeLoadBalancer {
+				return nil, fmt.Errorf("service %v has type %q, an external endpoint requires the LoadBalancer type", name, svc.Spec.Type)
+			}
+			return svc, nil
+		}
+	}
+	return nil, fmt.Errorf("no LoadBalancer service named %v in the manifest files", name)
+}
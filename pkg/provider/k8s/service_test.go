@@ -0,0 +1,111 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestWaitForServiceEndpoint(t *testing.T) {
+	defer func(interval time.Duration) { serviceEndpointPollInterval = interval }(serviceEndpointPollInterval)
+	serviceEndpointPollInterval = time.Millisecond
+
+	service := func(name string, serviceType apiCoreV1.ServiceType) *apiCoreV1.Service {
+		return &apiCoreV1.Service{
+			ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Namespace: "prombench"},
+			Spec:       apiCoreV1.ServiceSpec{Type: serviceType},
+		}
+	}
+	for _, tc := range []struct {
+		name    string
+		ingress []apiCoreV1.LoadBalancerIngress
+		want    string
+	}{
+		{name: "ip", ingress: []apiCoreV1.LoadBalancerIngress{{IP: "34.1.2.3"}}, want: "34.1.2.3"},
+		{name: "hostname", ingress: []apiCoreV1.LoadBalancerIngress{{Hostname: "a1b2.elb.amazonaws.com"}}, want: "a1b2.elb.amazonaws.com"},
+		{name: "ip and hostname", ingress: []apiCoreV1.LoadBalancerIngress{{IP: "34.1.2.3", Hostname: "a1b2.elb.amazonaws.com"}}, want: "34.1.2.3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFakeK8s(service("prometheus", apiCoreV1.ServiceTypeLoadBalancer))
+			// The load balancer is assigned on the third check.
+			var gets int
+			c.clt.(*fake.Clientset).PrependReactor("get", "services", func(k8sTesting.Action) (bool, runtime.Object, error) {
+				gets++
+				svc := service("prometheus", apiCoreV1.ServiceTypeLoadBalancer)
+				if gets >= 3 {
+					svc.Status.LoadBalancer.Ingress = tc.ingress
+				}
+				return true, svc, nil
+			})
+
+			endpoint, err := c.WaitForServiceEndpoint("prombench", "prometheus", time.Minute)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if endpoint != tc.want || gets != 3 {
+				t.Errorf("want endpoint %v after 3 checks, got %v after %d", tc.want, endpoint, gets)
+			}
+		})
+	}
+
+	c := newFakeK8s(service("pending", apiCoreV1.ServiceTypeLoadBalancer), service("internal", apiCoreV1.ServiceTypeClusterIP))
+	if _, err := c.WaitForServiceEndpoint("prombench", "pending", 10*time.Millisecond); err == nil {
+		t.Error("expected a timeout error")
+	}
+	if _, err := c.WaitForServiceEndpoint("prombench", "internal", time.Minute); err == nil {
+		t.Error("expected an error for a ClusterIP service")
+	}
+	if _, err := c.WaitForServiceEndpoint("prombench", "missing", time.Minute); err == nil {
+		t.Error("expected an error for a missing service")
+	}
+}
+
+func TestLoadBalancerService(t *testing.T) {
+	resources := decodeManifest(t, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: prometheus
+  namespace: prombench-10
+spec:
+  type: LoadBalancer
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: internal
+  namespace: prombench-10
+spec:
+  type: ClusterIP
+`)
+	svc, err := LoadBalancerService(resources, "prometheus")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if svc.Namespace != "prombench-10" {
+		t.Errorf("want the service in prombench-10, got %v", svc.Namespace)
+	}
+	for _, name := range []string{"internal", "missing"} {
+		if _, err := LoadBalancerService(resources, name); err == nil {
+			t.Errorf("%v: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+// Standalone applies the deployment files once to the cluster of a kubeconfig, without a cloud provider,
+// so scripts get the same apply semantics as the benchmark tooling instead of an ad-hoc kubectl apply.
+type Standalone struct {
+	DeploymentResource *provider.DeploymentResource
+	// Kubeconfig and KubeContext select the cluster, an empty context uses the current context.
+	Kubeconfig  string
+	KubeContext string
+}
+
+// NewStandalone returns a Standalone that uses the default kubeconfig.
+func NewStandalone(dr *provider.DeploymentResource) *Standalone {
+	return &Standalone{
+		DeploymentResource: dr,
+		Kubeconfig:         homedir.HomeDir() + "/.kube/config",
+	}
+}
+
+// Apply parses the deployment files and applies their objects once.
+func (s *Standalone) Apply(*kingpin.ParseContext) error {
+	if len(s.DeploymentResource.DeploymentFiles) == 0 {
+		return fmt.Errorf("missing required deployment files, set them with -f")
+	}
+	vars := provider.MergeDeploymentVars(s.DeploymentResource.DefaultDeploymentVars, s.DeploymentResource.FlagDeploymentVars)
+	resources, err := ParseFiles(s.DeploymentResource.DeploymentFiles, vars)
+	if err != nil {
+		return err
+	}
+	if s.DeploymentResource.Replicas >= 0 {
+		if err := SetReplicas(resources, s.DeploymentResource.Replicas); err != nil {
+			return err
+		}
+	}
+
+	apiConfig, err := clientcmd.LoadFromFile(s.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	if s.KubeContext != "" {
+		if _, ok := apiConfig.Contexts[s.KubeContext]; !ok {
+			return fmt.Errorf("context %q not found in kubeconfig %v", s.KubeContext, s.Kubeconfig)
+		}
+		apiConfig.CurrentContext = s.KubeContext
+	}
+	c, err := NewForDeployment(context.Background(), apiConfig, s.DeploymentResource)
+	if err != nil {
+		return err
+	}
+	if err := c.CheckConnection(); err != nil {
+		return errors.Wrapf(err, "couldn't connect to the cluster, context: %q", apiConfig.CurrentContext)
+	}
+	return c.ApplyAndWaitEstablished(resources, s.DeploymentResource.EstablishTimeout)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider"
+)
+
+const noWaitManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench-1234
+spec:
+  replicas: 2
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: loadgen-querier
+  namespace: prombench-1234
+`
+
+func TestNoWait(t *testing.T) {
+	c := newFakeK8s()
+	c.NoWait = true
+	start := time.Now()
+	if err := c.ResourceApply(decodeManifest(t, noWaitManifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Waiting checks the objects for the first time only after 10s.
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("want the apply to return without waiting, took %v", d)
+	}
+	if _, err := c.clt.AppsV1().Deployments("prombench-1234").Get(c.ctx, "loadgen", apiMetaV1.GetOptions{}); err != nil {
+		t.Errorf("want the deployment applied: %v", err)
+	}
+	if _, err := c.clt.BatchV1().Jobs("prombench-1234").Get(c.ctx, "loadgen-querier", apiMetaV1.GetOptions{}); err != nil {
+		t.Errorf("want the job applied: %v", err)
+	}
+}
+
+const standaloneKubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: prombench
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: prombench
+  context:
+    cluster: prombench
+current-context: prombench
+`
+
+func TestStandaloneApplyErrors(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte(standaloneKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(dir, "manifest.yaml")
+	if err := os.WriteFile(manifest, []byte(noWaitManifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		files   []string
+		context string
+		err     string
+	}{
+		{name: "no files", err: "missing required deployment files"},
+		{name: "unknown context", files: []string{manifest}, context: "missing", err: `context "missing" not found`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dr := provider.NewDeploymentResource()
+			dr.DeploymentFiles = tc.files
+			s := NewStandalone(dr)
+			s.Kubeconfig = kubeconfig
+			s.KubeContext = tc.context
+			err := s.Apply(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("want an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
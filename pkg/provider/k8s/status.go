@@ -0,0 +1,171 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ObjectStatus is the readiness of an object of the manifests at the time of the check.
+type ObjectStatus struct {
+	objectRef
+	Exists bool
+	Ready  bool
+	// Detail explains the readiness, e.g. 2/3 available.
+	Detail string
+}
+
+// Status checks once, without waiting, whether all objects in the resources exist and are ready,
+// e.g. as a CI gate after the setup of a benchmark.
+// The table of the statuses is returned together with an error when an object is missing or not ready.
+func (c *K8s) Status(deployments []Resource) ([]byte, error) {
+	var statuses []ObjectStatus
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			client, ref, err := c.dynamicResource(resource)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error checking the status of '%v'", deployment.FileName)
+			}
+			s := ObjectStatus{objectRef: ref}
+			obj, err := client.Get(c.ctx, ref.Name, apiMetaV1.GetOptions{})
+			switch {
+			case apiErrors.IsNotFound(err):
+				s.Detail = "not found"
+			case err != nil:
+				return nil, errors.Wrapf(err, "getting %v", ref)
+			default:
+				s.Exists = true
+				s.Ready, s.Detail = objectReadiness(obj)
+			}
+			statuses = append(statuses, s)
+		}
+	}
+
+	out := formatStatuses(statuses)
+	notReady := 0
+	for _, s := range statuses {
+		if !s.Ready {
+			notReady++
+		}
+	}
+	if notReady > 0 {
+		return out, fmt.Errorf("%d of %d object(s) not ready", notReady, len(statuses))
+	}
+	return out, nil
+}
+
+// objectReadiness returns whether an object is ready, with the same rules as the apply waits for the workloads:
+// deployments, statefulsets and daemonsets when the pods of their latest generation are ready and jobs once they complete.
+// The claims must be bound, the namespaces active and the LoadBalancer services must have an address.
+// Objects of the other kinds are ready when their Ready or Available condition is true, or when they exist without them.
+func objectReadiness(obj *unstructured.Unstructured) (bool, string) {
+	generation, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	stale := generation < obj.GetGeneration()
+	status := func(field string) int64 {
+		v, _, _ := unstructured.NestedInt64(obj.Object, "status", field)
+		return v
+	}
+
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet":
+		replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		field, state := "availableReplicas", "available"
+		if obj.GetKind() == "StatefulSet" {
+			field, state = "readyReplicas", "ready"
+		}
+		switch {
+		case stale:
+			return false, "rollout not observed yet"
+		// Scaled to zero is ready once all pods are gone.
+		case replicas == 0:
+			return status("replicas") == 0, fmt.Sprintf("%d/0 pods", status("replicas"))
+		}
+		return status(field) >= replicas, fmt.Sprintf("%d/%d %s", status(field), replicas, state)
+	case "DaemonSet":
+		if stale {
+			return false, "rollout not observed yet"
+		}
+		return status("numberUnavailable") == 0, fmt.Sprintf("%d/%d ready", status("numberReady"), status("desiredNumberScheduled"))
+	case "Job":
+		if cond, _ := findCondition(obj, "Failed"); cond != nil && cond.Status == apiMetaV1.ConditionTrue {
+			return false, "failed: " + cond.Reason
+		}
+		completions, found, _ := unstructured.NestedInt64(obj.Object, "spec", "completions")
+		if !found {
+			completions = 1
+		}
+		return status("succeeded") >= completions, fmt.Sprintf("%d/%d succeeded", status("succeeded"), completions)
+	case "PersistentVolumeClaim", "Namespace":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		want := "Bound"
+		if obj.GetKind() == "Namespace" {
+			want = "Active"
+		}
+		if phase == "" {
+			phase = "no phase"
+		}
+		return phase == want, strings.ToLower(phase)
+	case "Service":
+		if t, _, _ := unstructured.NestedString(obj.Object, "spec", "type"); t != "LoadBalancer" {
+			return true, "exists"
+		}
+		ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+		if len(ingress) == 0 {
+			return false, "load balancer pending"
+		}
+		return true, "load balancer ready"
+	}
+	for _, conditionType := range []string{"Ready", "Available"} {
+		cond, _ := findCondition(obj, conditionType)
+		if cond == nil {
+			continue
+		}
+		if cond.ObservedGeneration != 0 && cond.ObservedGeneration < obj.GetGeneration() {
+			return false, fmt.Sprintf("%v condition not updated for the latest generation", cond.Type)
+		}
+		return cond.Status == apiMetaV1.ConditionTrue, formatCondition(cond)
+	}
+	return true, "exists"
+}
+
+// formatStatuses prints the statuses as a table in the order of the manifests.
+func formatStatuses(statuses []ObjectStatus) []byte {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tREADY\tDETAIL")
+	for _, s := range statuses {
+		namespace := s.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		ready := "no"
+		if s.Ready {
+			ready = "yes"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", s.Kind, namespace, s.Name, ready, s.Detail)
+	}
+	w.Flush()
+	return buf.Bytes()
+}
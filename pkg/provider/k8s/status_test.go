@@ -0,0 +1,169 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const statusManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus
+  namespace: prombench
+spec:
+  replicas: 2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+spec:
+  replicas: 3
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: prombench
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: setup
+  namespace: prombench
+`
+
+const statusLiveManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prometheus
+  namespace: prombench
+spec:
+  replicas: 2
+status:
+  availableReplicas: 2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: loadgen
+  namespace: prombench
+spec:
+  replicas: 3
+status:
+  availableReplicas: 1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: prombench
+`
+
+func TestStatus(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	c := newFakeK8s()
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(scheme.Scheme, decodeManifest(t, statusLiveManifest)[0].Objects...)
+
+	out, err := c.Status(decodeManifest(t, statusManifest))
+	if err == nil || err.Error() != "2 of 4 object(s) not ready" {
+		t.Errorf("want 2 of 4 objects not ready, got %v", err)
+	}
+	want := `KIND         NAMESPACE   NAME         READY   DETAIL
+Deployment   prombench   prometheus   yes     2/2 available
+Deployment   prombench   loadgen      no      1/3 available
+ConfigMap    prombench   config       yes     exists
+Job          prombench   setup        no      not found
+`
+	if string(out) != want {
+		t.Errorf("want the table:\n%v\ngot:\n%v", want, string(out))
+	}
+
+	out, err = c.Status(decodeManifest(t, statusLiveManifest)[:1])
+	if err == nil {
+		t.Fatalf("want an error for the loadgen deployment, got none:\n%s", out)
+	}
+	ready := decodeManifest(t, statusLiveManifest)
+	ready[0].Objects = ready[0].Objects[:1]
+	if _, err := c.Status(ready); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestObjectReadiness(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		obj    map[string]interface{}
+		ready  bool
+		detail string
+	}{
+		{
+			name:   "stale deployment",
+			obj:    map[string]interface{}{"kind": "Deployment", "metadata": map[string]interface{}{"generation": int64(2)}, "status": map[string]interface{}{"observedGeneration": int64(1), "availableReplicas": int64(1)}},
+			detail: "rollout not observed yet",
+		},
+		{
+			name:   "statefulset scaled to zero",
+			obj:    map[string]interface{}{"kind": "StatefulSet", "spec": map[string]interface{}{"replicas": int64(0)}, "status": map[string]interface{}{"replicas": int64(1)}},
+			detail: "1/0 pods",
+		},
+		{
+			name:   "daemonset",
+			obj:    map[string]interface{}{"kind": "DaemonSet", "status": map[string]interface{}{"numberReady": int64(3), "desiredNumberScheduled": int64(3)}},
+			ready:  true,
+			detail: "3/3 ready",
+		},
+		{
+			name:   "failed job",
+			obj:    map[string]interface{}{"kind": "Job", "status": map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Failed", "status": "True", "reason": "BackoffLimitExceeded"}}}},
+			detail: "failed: BackoffLimitExceeded",
+		},
+		{
+			name:   "pending claim",
+			obj:    map[string]interface{}{"kind": "PersistentVolumeClaim", "status": map[string]interface{}{"phase": "Pending"}},
+			detail: "pending",
+		},
+		{
+			name:   "load balancer",
+			obj:    map[string]interface{}{"kind": "Service", "spec": map[string]interface{}{"type": "LoadBalancer"}},
+			detail: "load balancer pending",
+		},
+		{
+			name:   "custom resource",
+			obj:    map[string]interface{}{"kind": "Prometheus", "status": map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Available", "status": "True"}}}},
+			ready:  true,
+			detail: "Available=True",
+		},
+	} {
+		ready, detail := objectReadiness(&unstructured.Unstructured{Object: tc.obj})
+		if ready != tc.ready || !strings.HasPrefix(detail, tc.detail) {
+			t.Errorf("%v: want ready %v with %q, got %v with %q", tc.name, tc.ready, tc.detail, ready, detail)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"crypto/x509"
+	"log"
+	"os"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+)
+
+// TLSOptions override how the client verifies the certificate of the api server,
+// the zero value keeps the full verification with the certificate authority of the config.
+type TLSOptions struct {
+	// CAFile is a PEM bundle of the certificate authorities trusted instead of the one of the config,
+	// e.g. for a cluster with a private CA or behind a TLS terminating proxy.
+	CAFile string
+	// InsecureSkipVerify disables the verification of the api server certificate.
+	// UNSAFE: anyone on the network path can impersonate the api server and read the credentials, only use it with dev clusters.
+	InsecureSkipVerify bool
+}
+
+// apply sets the TLS options on the rest config, replacing its certificate authority.
+func (t TLSOptions) apply(restConfig *rest.Config) error {
+	if t.CAFile != "" && t.InsecureSkipVerify {
+		return errors.New("a custom CA file and skipping the TLS verification can't be used together")
+	}
+	if t.CAFile != "" {
+		data, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return errors.Wrapf(err, "reading the k8s CA file")
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(data) {
+			return errors.Errorf("the k8s CA file %v has no PEM certificates", t.CAFile)
+		}
+		restConfig.TLSClientConfig.CAFile = ""
+		restConfig.TLSClientConfig.CAData = data
+	}
+	if t.InsecureSkipVerify {
+		log.Printf("WARNING: the certificate of the k8s api server isn't verified, the connection and the credentials can be intercepted")
+		// client-go refuses a certificate authority together with the insecure flag.
+		restConfig.TLSClientConfig.CAFile = ""
+		restConfig.TLSClientConfig.CAData = nil
+		restConfig.TLSClientConfig.Insecure = true
+	}
+	return nil
+}
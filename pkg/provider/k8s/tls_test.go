@@ -0,0 +1,88 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestTLSOptions(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"gitVersion":"v1.27.3"}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["test"] = &clientcmdapi.Cluster{Server: srv.URL}
+	config.AuthInfos["test"] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts["test"] = &clientcmdapi.Context{Cluster: "test", AuthInfo: "test"}
+	config.CurrentContext = "test"
+
+	for name, tc := range map[string]struct {
+		tls TLSOptions
+		// configCA is the certificate authority of the config, replaced by the options.
+		configCA   []byte
+		newErr     string
+		connectErr bool
+	}{
+		"default verification":  {connectErr: true},
+		"custom CA":             {tls: TLSOptions{CAFile: caFile}, configCA: []byte("stale")},
+		"insecure":              {tls: TLSOptions{InsecureSkipVerify: true}, configCA: []byte("stale")},
+		"both":                  {tls: TLSOptions{CAFile: caFile, InsecureSkipVerify: true}, newErr: "can't be used together"},
+		"missing CA file":       {tls: TLSOptions{CAFile: filepath.Join(dir, "missing.pem")}, newErr: "reading the k8s CA file"},
+		"CA file without certs": {tls: TLSOptions{CAFile: invalidFile}, newErr: "has no PEM certificates"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DeepCopy()
+			cfg.Clusters["test"].CertificateAuthorityData = tc.configCA
+			c, err := New(context.Background(), cfg, RateLimits{}, tc.tls)
+			if tc.newErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.newErr) {
+					t.Fatalf("want an error with %q, got %v", tc.newErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = c.CheckConnection()
+			if tc.connectErr && err == nil {
+				t.Error("want the unknown certificate authority rejected")
+			}
+			if !tc.connectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// metricsGroupVersion is the group version of the resource metrics API served by metrics-server.
+const metricsGroupVersion = "metrics.k8s.io/v1beta1"
+
+var (
+	podMetricsResource  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	nodeMetricsResource = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+)
+
+// Usage is the CPU usage in cores and the memory working set in bytes of a pod, a container or a node.
+type Usage struct {
+	CPU    float64
+	Memory int64
+}
+
+// PodMetrics is the usage of a pod, in total and by container, averaged over the window that ends at the timestamp.
+type PodMetrics struct {
+	Namespace  string
+	Name       string
+	Timestamp  time.Time
+	Window     time.Duration
+	Usage      Usage
+	Containers map[string]Usage
+}
+
+// NodeMetrics is the usage of a node averaged over the window that ends at the timestamp.
+type NodeMetrics struct {
+	Name      string
+	Timestamp time.Time
+	Window    time.Duration
+	Usage     Usage
+}
+
+// GetPodMetrics returns the usage of the pods in the namespace that match the label selector, sorted by name.
+// An empty namespace returns the pods of all namespaces and an empty selector all pods.
+func (c *K8s) GetPodMetrics(namespace, selector string) ([]PodMetrics, error) {
+	if err := c.checkMetricsAPI(); err != nil {
+		return nil, err
+	}
+	list, err := c.dynamicClient.Resource(podMetricsResource).Namespace(namespace).List(c.ctx, apiMetaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, metricsError(err, "listing the pod metrics")
+	}
+	metrics := make([]PodMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		m := PodMetrics{Namespace: item.GetNamespace(), Name: item.GetName(), Containers: map[string]Usage{}}
+		if m.Timestamp, m.Window, err = metricsWindow(item); err != nil {
+			return nil, errors.Wrapf(err, "pod %v/%v", m.Namespace, m.Name)
+		}
+		containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the containers of pod %v/%v", m.Namespace, m.Name)
+		}
+		for _, container := range containers {
+			obj, ok := container.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid container metrics of pod %v/%v", m.Namespace, m.Name)
+			}
+			name, _, _ := unstructured.NestedString(obj, "name")
+			usage, err := parseUsage(obj, "usage")
+			if err != nil {
+				return nil, errors.Wrapf(err, "container %v of pod %v/%v", name, m.Namespace, m.Name)
+			}
+			m.Containers[name] = usage
+			m.Usage.CPU += usage.CPU
+			m.Usage.Memory += usage.Memory
+		}
+		metrics = append(metrics, m)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Namespace != metrics[j].Namespace {
+			return metrics[i].Namespace < metrics[j].Namespace
+		}
+		return metrics[i].Name < metrics[j].Name
+	})
+	return metrics, nil
+}
+
+// GetNodeMetrics returns the usage of the nodes that match the label selector, sorted by name.
+// An empty selector returns all nodes.
+func (c *K8s) GetNodeMetrics(selector string) ([]NodeMetrics, error) {
+	if err := c.checkMetricsAPI(); err != nil {
+		return nil, err
+	}
+	list, err := c.dynamicClient.Resource(nodeMetricsResource).List(c.ctx, apiMetaV1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, metricsError(err, "listing the node metrics")
+	}
+	metrics := make([]NodeMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		m := NodeMetrics{Name: item.GetName()}
+		if m.Timestamp, m.Window, err = metricsWindow(item); err != nil {
+			return nil, errors.Wrapf(err, "node %v", m.Name)
+		}
+		if m.Usage, err = parseUsage(item.Object, "usage"); err != nil {
+			return nil, errors.Wrapf(err, "node %v", m.Name)
+		}
+		metrics = append(metrics, m)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics, nil
+}
+
+// checkMetricsAPI returns an error when the cluster doesn't serve the resource metrics API,
+// which is the case when metrics-server isn't installed.
+func (c *K8s) checkMetricsAPI() error {
+	if _, err := c.clt.Discovery().ServerResourcesForGroupVersion(metricsGroupVersion); err != nil {
+		return errors.Wrapf(err, "the %v API isn't available, is metrics-server installed?", metricsGroupVersion)
+	}
+	return nil
+}
+
+// metricsError explains the errors of an installed but broken metrics-server.
+func metricsError(err error, action string) error {
+	if apiErrors.IsServiceUnavailable(err) {
+		return errors.Wrapf(err, "%v, metrics-server is installed but not ready", action)
+	}
+	return errors.Wrap(err, action)
+}
+
+// metricsWindow returns the timestamp and the window of a pod or node metrics object.
+func metricsWindow(item unstructured.Unstructured) (time.Time, time.Duration, error) {
+	var timestamp time.Time
+	if value, _, _ := unstructured.NestedString(item.Object, "timestamp"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, 0, errors.Wrapf(err, "invalid timestamp %q", value)
+		}
+		timestamp = t
+	}
+	var window time.Duration
+	if value, _, _ := unstructured.NestedString(item.Object, "window"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return time.Time{}, 0, errors.Wrapf(err, "invalid window %q", value)
+		}
+		window = d
+	}
+	return timestamp, window, nil
+}
+
+// parseUsage parses the cpu and memory quantities of a field of an object, e.g. the usage field of a metrics object.
+func parseUsage(obj map[string]interface{}, field string) (Usage, error) {
+	usage, _, err := unstructured.NestedStringMap(obj, field)
+	if err != nil {
+		return Usage{}, errors.Wrapf(err, "reading the %v", field)
+	}
+	var u Usage
+	if value, ok := usage["cpu"]; ok {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return Usage{}, errors.Wrapf(err, "invalid cpu usage %q", value)
+		}
+		u.CPU = q.AsApproximateFloat64()
+	}
+	if value, ok := usage["memory"]; ok {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return Usage{}, errors.Wrapf(err, "invalid memory usage %q", value)
+		}
+		u.Memory = q.Value()
+	}
+	return u, nil
+}
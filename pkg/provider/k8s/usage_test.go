@@ -0,0 +1,126 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func metricsObject(kind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": metricsGroupVersion,
+		"kind":       kind,
+		"timestamp":  "2026-10-14T10:00:00Z",
+		"window":     "30s",
+	}}
+	for k, v := range fields {
+		obj.Object[k] = v
+	}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{"app": name})
+	return obj
+}
+
+// newFakeMetricsK8s returns a provider that serves the resource metrics API with the given objects.
+// The objects are created through their resource, the fake client can't guess it from the PodMetrics and NodeMetrics kinds.
+func newFakeMetricsK8s(t *testing.T, objects ...*unstructured.Unstructured) *K8s {
+	t.Helper()
+	c := newFakeK8s()
+	c.clt.Discovery().(*fakeDiscovery.FakeDiscovery).Resources = []*apiMetaV1.APIResourceList{{
+		GroupVersion: metricsGroupVersion,
+		APIResources: []apiMetaV1.APIResource{{Name: "pods", Namespaced: true}, {Name: "nodes"}},
+	}}
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		podMetricsResource:  "PodMetricsList",
+		nodeMetricsResource: "NodeMetricsList",
+	})
+	for _, obj := range objects {
+		gvr := nodeMetricsResource
+		if obj.GetKind() == "PodMetrics" {
+			gvr = podMetricsResource
+		}
+		if _, err := c.dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Create(c.ctx, obj, apiMetaV1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c
+}
+
+func TestGetPodMetrics(t *testing.T) {
+	c := newFakeMetricsK8s(t,
+		metricsObject("PodMetrics", "prombench", "prometheus", map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "prometheus", "usage": map[string]interface{}{"cpu": "1500m", "memory": "2Gi"}},
+			map[string]interface{}{"name": "config-reloader", "usage": map[string]interface{}{"cpu": "250000000n", "memory": "64Mi"}},
+		}}),
+		metricsObject("PodMetrics", "prombench", "loadgen", map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "loadgen", "usage": map[string]interface{}{"cpu": "100m", "memory": "128Mi"}},
+		}}),
+	)
+	metrics, err := c.GetPodMetrics("prombench", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 || metrics[0].Name != "loadgen" || metrics[1].Name != "prometheus" {
+		t.Fatalf("want the loadgen and prometheus pods sorted by name, got %+v", metrics)
+	}
+	m := metrics[1]
+	if m.Usage.CPU != 1.75 || m.Usage.Memory != 2<<30+64<<20 {
+		t.Errorf("want the usage summed over the containers, got %+v", m.Usage)
+	}
+	if m.Containers["config-reloader"].CPU != 0.25 {
+		t.Errorf("want 0.25 cores for the config-reloader container, got %+v", m.Containers)
+	}
+	if !m.Timestamp.Equal(time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)) || m.Window != 30*time.Second {
+		t.Errorf("unexpected timestamp %v and window %v", m.Timestamp, m.Window)
+	}
+
+	metrics, err = c.GetPodMetrics("prombench", "app=loadgen")
+	if err != nil || len(metrics) != 1 || metrics[0].Name != "loadgen" {
+		t.Errorf("want only the loadgen pod for the selector, got %+v, err: %v", metrics, err)
+	}
+}
+
+func TestGetNodeMetrics(t *testing.T) {
+	c := newFakeMetricsK8s(t,
+		metricsObject("NodeMetrics", "", "node-b", map[string]interface{}{"usage": map[string]interface{}{"cpu": "3", "memory": "8Gi"}}),
+		metricsObject("NodeMetrics", "", "node-a", map[string]interface{}{"usage": map[string]interface{}{"cpu": "500m", "memory": "1Gi"}}),
+	)
+	metrics, err := c.GetNodeMetrics("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 || metrics[0].Name != "node-a" || metrics[0].Usage != (Usage{CPU: 0.5, Memory: 1 << 30}) || metrics[1].Usage.CPU != 3 {
+		t.Errorf("unexpected node metrics %+v", metrics)
+	}
+}
+
+func TestMetricsServerMissing(t *testing.T) {
+	c := newFakeK8s()
+	if _, err := c.GetNodeMetrics(""); err == nil || !strings.Contains(err.Error(), "is metrics-server installed") {
+		t.Errorf("want an error about the missing metrics-server, got %v", err)
+	}
+	if _, err := c.GetPodMetrics("prombench", ""); err == nil || !strings.Contains(err.Error(), "is metrics-server installed") {
+		t.Errorf("want an error about the missing metrics-server, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExistingDiskLabel is set on the PersistentVolumes of existing disks to the name of the disk,
+// claims select the volume of a disk with it or with the volume name.
+const ExistingDiskLabel = "existing-disk"
+
+// ExistingDisk is a disk created outside of the cluster, e.g. pre-populated with TSDB blocks,
+// that is surfaced as a PersistentVolume so benchmarks don't ingest the data again.
+type ExistingDisk struct {
+	// Name of the PersistentVolume.
+	Name string
+	// Name of the disk in the cloud provider.
+	Disk string
+	// The CSI driver that attaches the disk and its volume handle.
+	Driver string
+	Handle string
+	// The zone of the disk, the pods using the volume are only scheduled on nodes in this zone.
+	Zone   string
+	SizeGB int64
+	// The filesystem of the disk, empty uses the default of the driver.
+	FSType string
+}
+
+// PersistentVolumes returns the PersistentVolumes for the existing disks.
+// The volumes are retained when they are deleted so the disks are kept for the next run,
+// and have no storage class so only the claims that ask for them are bound to them.
+func PersistentVolumes(disks []ExistingDisk) Resource {
+	r := Resource{FileName: "existing disks"}
+	for _, d := range disks {
+		pv := &apiCoreV1.PersistentVolume{
+			TypeMeta: apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"},
+			ObjectMeta: apiMetaV1.ObjectMeta{
+				Name:   d.Name,
+				Labels: map[string]string{ExistingDiskLabel: d.Disk},
+			},
+			Spec: apiCoreV1.PersistentVolumeSpec{
+				Capacity: apiCoreV1.ResourceList{
+					apiCoreV1.ResourceStorage: *resource.NewQuantity(d.SizeGB<<30, resource.BinarySI),
+				},
+				AccessModes:                   []apiCoreV1.PersistentVolumeAccessMode{apiCoreV1.ReadWriteOnce},
+				PersistentVolumeReclaimPolicy: apiCoreV1.PersistentVolumeReclaimRetain,
+				PersistentVolumeSource: apiCoreV1.PersistentVolumeSource{
+					CSI: &apiCoreV1.CSIPersistentVolumeSource{
+						Driver:       d.Driver,
+						VolumeHandle: d.Handle,
+						FSType:       d.FSType,
+					},
+				},
+				NodeAffinity: &apiCoreV1.VolumeNodeAffinity{
+					Required: &apiCoreV1.NodeSelector{
+						NodeSelectorTerms: []apiCoreV1.NodeSelectorTerm{{
+							MatchExpressions: []apiCoreV1.NodeSelectorRequirement{{
+								Key:      apiCoreV1.LabelTopologyZone,
+								Operator: apiCoreV1.NodeSelectorOpIn,
+								Values:   []string{d.Zone},
+							}},
+						}},
+					},
+				},
+			},
+		}
+		r.Objects = append(r.Objects, pv)
+	}
+	return r
+}
+
+// ApplyExistingDisks applies the PersistentVolumes of the existing disks
+// once the CSI drivers of the disks are installed and there are nodes in the zones of the disks.
+func (c *K8s) ApplyExistingDisks(disks []ExistingDisk) error {
+	if len(disks) == 0 {
+		return nil
+	}
+	drivers := map[string]bool{}
+	for _, d := range disks {
+		if drivers[d.Driver] {
+			continue
+		}
+		drivers[d.Driver] = true
+		if _, err := c.clt.StorageV1().CSIDrivers().Get(c.ctx, d.Driver, apiMetaV1.GetOptions{}); err != nil {
+			return errors.Wrapf(err, "getting the CSI driver %v of the disk %v, is it installed in the cluster?", d.Driver, d.Disk)
+		}
+	}
+	if err := c.CheckDiskZones(disks); err != nil {
+		return err
+	}
+	return c.ResourceApply([]Resource{PersistentVolumes(disks)})
+}
+
+// DeleteExistingDisks deletes the PersistentVolumes of the existing disks by name, the disks are kept.
+func (c *K8s) DeleteExistingDisks(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	disks := make([]ExistingDisk, 0, len(names))
+	for _, name := range names {
+		disks = append(disks, ExistingDisk{Name: name})
+	}
+	return c.ResourceDelete([]Resource{PersistentVolumes(disks)})
+}
+
+// CheckDiskZones returns an error when there is no node in the zone of an existing disk,
+// the pods using its volume would stay pending.
+func (c *K8s) CheckDiskZones(disks []ExistingDisk) error {
+	if len(disks) == 0 {
+		return nil
+	}
+	nodes, err := c.clt.CoreV1().Nodes().List(c.ctx, apiMetaV1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "listing the nodes")
+	}
+	zones := map[string]bool{}
+	for _, n := range nodes.Items {
+		zone, ok := n.Labels[apiCoreV1.LabelTopologyZone]
+		if !ok {
+			zone = n.Labels[apiCoreV1.LabelFailureDomainBetaZone]
+		}
+		zones[zone] = true
+	}
+	var missing []string
+	for _, d := range disks {
+		if !zones[d.Zone] {
+			missing = append(missing, fmt.Sprintf("%v (%v)", d.Disk, d.Zone))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return errors.Errorf("no node in the zone of the disks %v, the pods using them can't be scheduled - create the nodes in the zone of the disks", strings.Join(missing, ", "))
+	}
+	return nil
+}
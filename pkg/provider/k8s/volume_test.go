@@ -0,0 +1,108 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPersistentVolumes(t *testing.T) {
+	disks := []ExistingDisk{
+		{Name: "tsdb-0", Disk: "tsdb-blocks-0", Driver: "pd.csi.storage.gke.io", Handle: "projects/test/zones/europe-west1-b/disks/tsdb-blocks-0", Zone: "europe-west1-b", SizeGB: 100},
+		{Name: "tsdb-1", Disk: "tsdb-blocks-1", Driver: "pd.csi.storage.gke.io", Handle: "projects/test/zones/europe-west1-c/disks/tsdb-blocks-1", Zone: "europe-west1-c", SizeGB: 20},
+	}
+	c := newFakeK8s(
+		&apiCoreV1.Node{ObjectMeta: apiMetaV1.ObjectMeta{Name: "b", Labels: map[string]string{apiCoreV1.LabelTopologyZone: "europe-west1-b"}}},
+		&apiCoreV1.Node{ObjectMeta: apiMetaV1.ObjectMeta{Name: "c", Labels: map[string]string{apiCoreV1.LabelTopologyZone: "europe-west1-c"}}},
+	)
+	if err := c.ApplyExistingDisks(disks); err == nil {
+		t.Fatal("expected an error without the CSI driver")
+	}
+	if _, err := c.clt.StorageV1().CSIDrivers().Create(c.ctx, &storageV1.CSIDriver{ObjectMeta: apiMetaV1.ObjectMeta{Name: "pd.csi.storage.gke.io"}}, apiMetaV1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ApplyExistingDisks(disks); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+
+	pv, err := c.clt.CoreV1().PersistentVolumes().Get(c.ctx, "tsdb-0", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the volume: %v", err)
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy != apiCoreV1.PersistentVolumeReclaimRetain {
+		t.Errorf("want the disk retained, got the %v reclaim policy", pv.Spec.PersistentVolumeReclaimPolicy)
+	}
+	if got := pv.Spec.Capacity[apiCoreV1.ResourceStorage]; got.String() != "100Gi" {
+		t.Errorf("want a 100Gi capacity, got %v", got.String())
+	}
+	if pv.Spec.CSI.VolumeHandle != disks[0].Handle || pv.Labels[ExistingDiskLabel] != "tsdb-blocks-0" {
+		t.Errorf("want the volume of the disk, got %+v", pv)
+	}
+	if zone := pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0]; zone.Key != apiCoreV1.LabelTopologyZone || zone.Values[0] != "europe-west1-b" {
+		t.Errorf("want the volume pinned to the zone of the disk, got %+v", zone)
+	}
+
+	// A bound volume keeps its claim when it is applied again and a released volume is made available.
+	claim := &apiCoreV1.ObjectReference{Namespace: "prombench", Name: "tsdb"}
+	for name, phase := range map[string]apiCoreV1.PersistentVolumePhase{"tsdb-0": apiCoreV1.VolumeBound, "tsdb-1": apiCoreV1.VolumeReleased} {
+		pv, err := c.clt.CoreV1().PersistentVolumes().Get(c.ctx, name, apiMetaV1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pv.Spec.ClaimRef = claim
+		pv.Status.Phase = phase
+		if _, err := c.clt.CoreV1().PersistentVolumes().Update(c.ctx, pv, apiMetaV1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.ApplyExistingDisks(disks); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+	for name, bound := range map[string]bool{"tsdb-0": true, "tsdb-1": false} {
+		pv, err := c.clt.CoreV1().PersistentVolumes().Get(c.ctx, name, apiMetaV1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kept := pv.Spec.ClaimRef != nil; kept != bound {
+			t.Errorf("%v: want the claim kept %v, got %+v", name, bound, pv.Spec.ClaimRef)
+		}
+	}
+
+	if err := c.DeleteExistingDisks([]string{"tsdb-0", "tsdb-1"}); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if list, _ := c.clt.CoreV1().PersistentVolumes().List(c.ctx, apiMetaV1.ListOptions{}); len(list.Items) != 0 {
+		t.Errorf("want the volumes deleted, got %d", len(list.Items))
+	}
+}
+
+func TestCheckDiskZones(t *testing.T) {
+	node := func(name string, labels map[string]string) *apiCoreV1.Node {
+		return &apiCoreV1.Node{ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	c := newFakeK8s(
+		node("a", map[string]string{apiCoreV1.LabelTopologyZone: "us-east-2a"}),
+		node("b", map[string]string{apiCoreV1.LabelFailureDomainBetaZone: "us-east-2b"}),
+	)
+	if err := c.CheckDiskZones([]ExistingDisk{{Disk: "vol-1", Zone: "us-east-2a"}, {Disk: "vol-2", Zone: "us-east-2b"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := c.CheckDiskZones([]ExistingDisk{{Disk: "vol-3", Zone: "us-east-2c"}}); err == nil {
+		t.Error("expected an error for a disk without nodes in its zone")
+	}
+}
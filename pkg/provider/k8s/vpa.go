@@ -0,0 +1,218 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// The VerticalPodAutoscaler objects are applied with the dynamic client,
+// so the autoscaler Go types aren't needed as a dependency.
+const vpaGroupVersion = "autoscaling.k8s.io/v1"
+
+var vpaResource = schema.GroupVersionResource{Group: "autoscaling.k8s.io", Version: "v1", Resource: "verticalpodautoscalers"}
+
+// ContainerRecommendation is the resources the VerticalPodAutoscaler recommends for a container.
+// UncappedTarget is the target before the resource policy of the VerticalPodAutoscaler limited it.
+type ContainerRecommendation struct {
+	Target         Usage
+	LowerBound     Usage
+	UpperBound     Usage
+	UncappedTarget Usage
+}
+
+// VPARecommendation is the current recommendation of a VerticalPodAutoscaler, by container name.
+// Containers is empty while the recommender hasn't computed a recommendation yet.
+type VPARecommendation struct {
+	Namespace  string
+	Name       string
+	Containers map[string]ContainerRecommendation
+}
+
+// GetVPARecommendation returns the current recommendation of the VerticalPodAutoscaler.
+// An empty namespace is the "default" namespace, the same as when applying it.
+func (c *K8s) GetVPARecommendation(namespace, name string) (VPARecommendation, error) {
+	if namespace == "" {
+		namespace = "default"
+	}
+	if err := c.checkVPAAPI(); err != nil {
+		return VPARecommendation{}, err
+	}
+	obj, err := c.dynamicClient.Resource(vpaResource).Namespace(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+	if err != nil {
+		return VPARecommendation{}, errors.Wrapf(err, "getting VerticalPodAutoscaler/%v/%v", namespace, name)
+	}
+	return parseVPARecommendation(obj)
+}
+
+// parseVPARecommendation reads the container recommendations of the status of a VerticalPodAutoscaler.
+func parseVPARecommendation(obj *unstructured.Unstructured) (VPARecommendation, error) {
+	r := VPARecommendation{Namespace: obj.GetNamespace(), Name: obj.GetName(), Containers: map[string]ContainerRecommendation{}}
+	containers, _, err := unstructured.NestedSlice(obj.Object, "status", "recommendation", "containerRecommendations")
+	if err != nil {
+		return VPARecommendation{}, errors.Wrapf(err, "reading the recommendation of VerticalPodAutoscaler/%v/%v", r.Namespace, r.Name)
+	}
+	for _, container := range containers {
+		fields, ok := container.(map[string]interface{})
+		if !ok {
+			return VPARecommendation{}, fmt.Errorf("invalid container recommendation of VerticalPodAutoscaler/%v/%v", r.Namespace, r.Name)
+		}
+		name, _, _ := unstructured.NestedString(fields, "containerName")
+		var cr ContainerRecommendation
+		for field, u := range map[string]*Usage{"target": &cr.Target, "lowerBound": &cr.LowerBound, "upperBound": &cr.UpperBound, "uncappedTarget": &cr.UncappedTarget} {
+			if *u, err = parseUsage(fields, field); err != nil {
+				return VPARecommendation{}, errors.Wrapf(err, "container %v of VerticalPodAutoscaler/%v/%v", name, r.Namespace, r.Name)
+			}
+		}
+		r.Containers[name] = cr
+	}
+	return r, nil
+}
+
+// checkVPAAPI returns an error when the cluster doesn't serve the VerticalPodAutoscaler API,
+// which is the case when the vertical pod autoscaler isn't installed.
+func (c *K8s) checkVPAAPI() error {
+	if _, err := c.clt.Discovery().ServerResourcesForGroupVersion(vpaGroupVersion); err != nil {
+		return errors.Wrapf(err, "the %v API isn't available, is the vertical pod autoscaler installed?", vpaGroupVersion)
+	}
+	return nil
+}
+
+// isVPA returns whether the object is a VerticalPodAutoscaler.
+func isVPA(resource runtime.Object) bool {
+	gvk := resource.GetObjectKind().GroupVersionKind()
+	return gvk.Group == vpaResource.Group && gvk.Kind == "VerticalPodAutoscaler"
+}
+
+// vpaRefs returns the VerticalPodAutoscalers of the files.
+func vpaRefs(deployments []Resource) []objectRef {
+	var refs []objectRef
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			if obj, ok := resource.(*unstructured.Unstructured); ok && isVPA(obj) {
+				ref := objectRef{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+				if ref.Namespace == "" {
+					ref.Namespace = "default"
+				}
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+func (c *K8s) vpaApply(resource runtime.Object) error {
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	req, ok := resource.(*unstructured.Unstructured)
+	if !ok || req.GetAPIVersion() != vpaGroupVersion {
+		return fmt.Errorf("unknown object version: %v kind:'%v', only %v is supported", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind, vpaGroupVersion)
+	}
+	if len(req.GetNamespace()) == 0 {
+		req.SetNamespace("default")
+	}
+
+	client := c.dynamicClient.Resource(vpaResource).Namespace(req.GetNamespace())
+	if _, err := client.Get(c.ctx, req.GetName(), apiMetaV1.GetOptions{}); apiErrors.IsNotFound(err) {
+		if _, err := client.Create(c.ctx, req, apiMetaV1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "resource creation failed - kind: %v, name: %v", kind, req.GetName())
+		}
+		log.Printf("resource created - kind: %v, name: %v", kind, req.GetName())
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "error getting resource - kind: %v, name: %v", kind, req.GetName())
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		live, err := client.Get(c.ctx, req.GetName(), apiMetaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		req.SetResourceVersion(live.GetResourceVersion())
+		_, err = client.Update(c.ctx, req, apiMetaV1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return errors.Wrapf(err, "resource update failed - kind: %v, name: %v", kind, req.GetName())
+	}
+	log.Printf("resource updated - kind: %v, name: %v", kind, req.GetName())
+	return nil
+}
+
+// vpaDelete deletes the VerticalPodAutoscaler, it is skipped when the cluster doesn't serve the API
+// as there can't be any VerticalPodAutoscalers then.
+func (c *K8s) vpaDelete(resource runtime.Object) error {
+	kind := resource.GetObjectKind().GroupVersionKind().Kind
+	req, ok := resource.(*unstructured.Unstructured)
+	if !ok || req.GetAPIVersion() != vpaGroupVersion {
+		return fmt.Errorf("unknown object version: %v kind:'%v', only %v is supported", resource.GetObjectKind().GroupVersionKind().GroupVersion(), kind, vpaGroupVersion)
+	}
+	if len(req.GetNamespace()) == 0 {
+		req.SetNamespace("default")
+	}
+	if err := c.checkVPAAPI(); err != nil {
+		log.Printf("resource delete skipped - kind: %v, name: %v: %v", kind, req.GetName(), err)
+		return nil
+	}
+	if err := c.dynamicClient.Resource(vpaResource).Namespace(req.GetNamespace()).Delete(c.ctx, req.GetName(), c.deleteOptions()); err != nil {
+		return errors.Wrapf(err, "resource delete failed - kind: %v, name: %v", kind, req.GetName())
+	}
+	log.Printf("resource deleted - kind: %v , name: %v", kind, req.GetName())
+	return nil
+}
+
+// waitVPARecommendations blocks until all VerticalPodAutoscalers have a recommendation for at least one container.
+func (c *K8s) waitVPARecommendations(refs []objectRef, timeout time.Duration) error {
+	pending := map[objectRef]bool{}
+	for _, ref := range refs {
+		pending[ref] = true
+	}
+	err := wait.PollImmediate(waitPollInterval, timeout, func() (bool, error) {
+		for ref := range pending {
+			r, err := c.GetVPARecommendation(ref.Namespace, ref.Name)
+			if err != nil {
+				return false, err
+			}
+			if len(r.Containers) > 0 {
+				log.Printf("VPA recommendation available - %v", ref)
+				delete(pending, ref)
+			}
+		}
+		if len(pending) > 0 {
+			log.Printf("Waiting for %d VPA recommendation(s).", len(pending))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		var names []string
+		for ref := range pending {
+			names = append(names, ref.String())
+		}
+		sort.Strings(names)
+		return errors.Errorf("VPA recommendations not available after %v: %v", timeout, strings.Join(names, ", "))
+	}
+	return err
+}
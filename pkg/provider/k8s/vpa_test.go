@@ -0,0 +1,151 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+const vpaManifest = `
+apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: prometheus
+  namespace: prombench
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: StatefulSet
+    name: prometheus
+  updatePolicy:
+    updateMode: "Off"
+`
+
+// newFakeVPAK8s returns a provider that serves the VerticalPodAutoscaler API.
+func newFakeVPAK8s() *K8s {
+	c := newFakeK8s()
+	c.clt.Discovery().(*fakeDiscovery.FakeDiscovery).Resources = []*apiMetaV1.APIResourceList{{
+		GroupVersion: vpaGroupVersion,
+		APIResources: []apiMetaV1.APIResource{{Name: "verticalpodautoscalers", Namespaced: true, Kind: "VerticalPodAutoscaler"}},
+	}}
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		vpaResource: "VerticalPodAutoscalerList",
+	})
+	return c
+}
+
+func decodeVPA(t *testing.T) []Resource {
+	t.Helper()
+	obj, err := Decode([]byte(vpaManifest))
+	if err != nil {
+		t.Fatalf("unexpected error decoding: %v", err)
+	}
+	if _, ok := obj.(*unstructured.Unstructured); !ok {
+		t.Fatalf("want the VerticalPodAutoscaler decoded as unstructured, got %T", obj)
+	}
+	return []Resource{{FileName: "vpa.yaml", Objects: []runtime.Object{obj}}}
+}
+
+func TestDecodeUnregisteredKind(t *testing.T) {
+	if _, err := Decode([]byte("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n")); err == nil {
+		t.Error("expected an error for a kind that isn't decoded as unstructured")
+	}
+}
+
+func TestApplyVPA(t *testing.T) {
+	c := newFakeVPAK8s()
+	c.InjectLabels = map[string]string{"prombench/run-id": "1234"}
+	if err := c.ResourceApply(decodeVPA(t)); err != nil {
+		t.Fatalf("unexpected error creating: %v", err)
+	}
+	if err := c.ResourceApply(decodeVPA(t)); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+
+	client := c.dynamicClient.Resource(vpaResource).Namespace("prombench")
+	live, err := client.Get(c.ctx, "prometheus", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live.GetLabels()["prombench/run-id"] != "1234" {
+		t.Errorf("expected the injected label, got %v", live.GetLabels())
+	}
+	if mode, _, _ := unstructured.NestedString(live.Object, "spec", "updatePolicy", "updateMode"); mode != "Off" {
+		t.Errorf("expected the update mode of the manifest, got %q", mode)
+	}
+
+	r, err := c.GetVPARecommendation("prombench", "prometheus")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.Containers) != 0 {
+		t.Errorf("want no recommendation before the recommender ran, got %v", r.Containers)
+	}
+	if err := c.waitVPARecommendations(vpaRefs(decodeVPA(t)), time.Millisecond); err == nil || !strings.Contains(err.Error(), "VerticalPodAutoscaler/prombench/prometheus") {
+		t.Errorf("want a timeout listing the VerticalPodAutoscaler, got %v", err)
+	}
+
+	if err := unstructured.SetNestedSlice(live.Object, []interface{}{map[string]interface{}{
+		"containerName": "prometheus",
+		"target":        map[string]interface{}{"cpu": "250m", "memory": "1Gi"},
+		"lowerBound":    map[string]interface{}{"cpu": "100m", "memory": "512Mi"},
+		"upperBound":    map[string]interface{}{"cpu": "2", "memory": "4Gi"},
+	}}, "status", "recommendation", "containerRecommendations"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Update(c.ctx, live, apiMetaV1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.waitVPARecommendations(vpaRefs(decodeVPA(t)), time.Second); err != nil {
+		t.Fatalf("unexpected error waiting: %v", err)
+	}
+	r, err = c.GetVPARecommendation("prombench", "prometheus")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ContainerRecommendation{Target: Usage{CPU: 0.25, Memory: 1 << 30}, LowerBound: Usage{CPU: 0.1, Memory: 512 << 20}, UpperBound: Usage{CPU: 2, Memory: 4 << 30}}
+	if got := r.Containers["prometheus"]; got != want {
+		t.Errorf("want recommendation %+v, got %+v", want, got)
+	}
+
+	if err := c.ResourceDelete(decodeVPA(t)); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+	if _, err := client.Get(c.ctx, "prometheus", apiMetaV1.GetOptions{}); err == nil {
+		t.Error("expected the VerticalPodAutoscaler to be deleted")
+	}
+}
+
+func TestVPANotInstalled(t *testing.T) {
+	c := newFakeK8s()
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+	if err := c.ResourceApply(decodeVPA(t)); err == nil || !strings.Contains(err.Error(), "is the vertical pod autoscaler installed") {
+		t.Errorf("want an error about the missing vertical pod autoscaler, got %v", err)
+	}
+	if _, err := c.GetVPARecommendation("prombench", "prometheus"); err == nil || !strings.Contains(err.Error(), "is the vertical pod autoscaler installed") {
+		t.Errorf("want an error about the missing vertical pod autoscaler, got %v", err)
+	}
+	if err := c.ResourceDelete(decodeVPA(t)); err != nil {
+		t.Errorf("want the delete skipped without the vertical pod autoscaler, got %v", err)
+	}
+}
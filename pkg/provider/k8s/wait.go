@@ -0,0 +1,237 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// waitPollInterval is how often the wait helpers check the objects.
+const waitPollInterval = 2 * time.Second
+
+// WaitForDeletion blocks until all objects in the resources are deleted or the timeout expires.
+// Objects with finalizers can stay around for a while after ResourceDelete returns
+// so this can be used to make sure they don't collide with the next deployment.
+// On timeout the error lists the objects that are still terminating together with their pending finalizers.
+func (c *K8s) WaitForDeletion(deployments []Resource, timeout time.Duration) error {
+	// Pending objects and the last known state of each one.
+	pending := map[objectRef]string{}
+	for _, deployment := range deployments {
+		for _, resource := range deployment.Objects {
+			_, ref, err := c.dynamicResource(resource)
+			if err != nil {
+				return errors.Wrapf(err, "error waiting for deletion of '%v'", deployment.FileName)
+			}
+			pending[ref] = "not checked yet"
+		}
+	}
+
+	err := wait.PollImmediate(waitPollInterval, timeout, func() (bool, error) {
+		for _, deployment := range deployments {
+			for _, resource := range deployment.Objects {
+				client, ref, err := c.dynamicResource(resource)
+				if err != nil {
+					return false, err
+				}
+				if _, ok := pending[ref]; !ok {
+					continue
+				}
+
+				obj, err := client.Get(c.ctx, ref.Name, apiMetaV1.GetOptions{})
+				if apiErrors.IsNotFound(err) {
+					log.Printf("resource deleted - %v", ref)
+					delete(pending, ref)
+					continue
+				}
+				if err != nil {
+					return false, errors.Wrapf(err, "checking resource deletion - %v", ref)
+				}
+
+				state := "terminating"
+				if obj.GetDeletionTimestamp() == nil {
+					state = "not marked for deletion"
+				}
+				if f := obj.GetFinalizers(); len(f) > 0 {
+					state += fmt.Sprintf(", pending finalizers: %v", strings.Join(f, ", "))
+				}
+				pending[ref] = state
+			}
+		}
+		if len(pending) > 0 {
+			log.Printf("Waiting for %d object(s) to be deleted.", len(pending))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		var remaining []string
+		for ref, state := range pending {
+			remaining = append(remaining, fmt.Sprintf("%v (%v)", ref, state))
+		}
+		sort.Strings(remaining)
+		return fmt.Errorf("objects not deleted after %v: %v", timeout, strings.Join(remaining, "; "))
+	}
+	return err
+}
+
+// WaitForCondition blocks until the status condition of the object has the given status, like kubectl wait --for=condition=...,
+// e.g. to wait for a custom resource managed by an operator, like a Prometheus becoming Available.
+// The condition type and status are compared case insensitively and an empty namespace is for cluster scoped resources.
+// A condition whose observedGeneration is older than the object generation is stale and doesn't count.
+// On timeout the last observed condition is returned together with the error, nil when the object never had it.
+func (c *K8s) WaitForCondition(gvr schema.GroupVersionResource, namespace, name, conditionType, status string, timeout time.Duration) (*apiMetaV1.Condition, error) {
+	client := c.dynamicClient.Resource(gvr)
+	ref := fmt.Sprintf("%v/%v", gvr.Resource, name)
+	if namespace != "" {
+		ref = fmt.Sprintf("%v/%v/%v", gvr.Resource, namespace, name)
+	}
+
+	var (
+		last  *apiMetaV1.Condition
+		stale bool
+	)
+	err := wait.PollImmediate(waitPollInterval, timeout, func() (bool, error) {
+		var (
+			obj *unstructured.Unstructured
+			err error
+		)
+		if namespace == "" {
+			obj, err = client.Get(c.ctx, name, apiMetaV1.GetOptions{})
+		} else {
+			obj, err = client.Namespace(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+		}
+		if apiErrors.IsNotFound(err) {
+			// Operators often create the waited for objects asynchronously.
+			log.Printf("Waiting for %v to be created.", ref)
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "getting %v", ref)
+		}
+
+		last, err = findCondition(obj, conditionType)
+		if err != nil {
+			return false, errors.Wrapf(err, "reading the conditions of %v", ref)
+		}
+		if last == nil {
+			log.Printf("Waiting for %v to have the %v condition.", ref, conditionType)
+			return false, nil
+		}
+		stale = last.ObservedGeneration != 0 && last.ObservedGeneration < obj.GetGeneration()
+		if stale || !strings.EqualFold(string(last.Status), status) {
+			log.Printf("Waiting for %v condition %v=%v, current: %v", ref, conditionType, status, formatCondition(last))
+			return false, nil
+		}
+		log.Printf("Condition met - %v %v=%v", ref, last.Type, last.Status)
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		if last == nil {
+			return nil, fmt.Errorf("%v condition %v=%v not met after %v, the object doesn't have the condition", ref, conditionType, status, timeout)
+		}
+		if stale {
+			return last, fmt.Errorf("%v condition %v=%v not met after %v, the condition wasn't updated for the latest generation, last observed: %v", ref, conditionType, status, timeout, formatCondition(last))
+		}
+		return last, fmt.Errorf("%v condition %v=%v not met after %v, last observed: %v", ref, conditionType, status, timeout, formatCondition(last))
+	}
+	return last, err
+}
+
+// WaitForObjectCondition resolves the kind of an object given as kind/name, the same as for Describe,
+// and waits for a condition in the kubectl format condition=Type or condition=Type=Status, the status defaults to True.
+func (c *K8s) WaitForObjectCondition(object, namespace, condition string, timeout time.Duration) error {
+	conditionType, status, err := ParseWaitCondition(condition)
+	if err != nil {
+		return err
+	}
+	kind, name, ok := strings.Cut(object, "/")
+	if !ok || kind == "" || name == "" {
+		return fmt.Errorf("invalid object %q, expected kind/name", object)
+	}
+	mapping, err := c.kindMapping(kind)
+	if err != nil {
+		return err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	} else if namespace == "" {
+		namespace = "default"
+	}
+	_, err = c.WaitForCondition(mapping.Resource, namespace, name, conditionType, status, timeout)
+	return err
+}
+
+// ParseWaitCondition parses a condition in the kubectl format condition=Type or condition=Type=Status.
+func ParseWaitCondition(value string) (conditionType, status string, err error) {
+	if !strings.HasPrefix(value, "condition=") {
+		return "", "", fmt.Errorf("invalid wait condition %q, expected condition=Type or condition=Type=Status", value)
+	}
+	conditionType, status, ok := strings.Cut(strings.TrimPrefix(value, "condition="), "=")
+	if !ok {
+		status = string(apiMetaV1.ConditionTrue)
+	}
+	if conditionType == "" || status == "" {
+		return "", "", fmt.Errorf("invalid wait condition %q, expected condition=Type or condition=Type=Status", value)
+	}
+	return conditionType, status, nil
+}
+
+// findCondition returns the status condition of the given type, nil when the object doesn't have it.
+func findCondition(obj *unstructured.Unstructured, conditionType string) (*apiMetaV1.Condition, error) {
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range conditions {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, _, _ := unstructured.NestedString(m, "type")
+		if !strings.EqualFold(t, conditionType) {
+			continue
+		}
+		cond := &apiMetaV1.Condition{Type: t}
+		s, _, _ := unstructured.NestedString(m, "status")
+		cond.Status = apiMetaV1.ConditionStatus(s)
+		cond.Reason, _, _ = unstructured.NestedString(m, "reason")
+		cond.Message, _, _ = unstructured.NestedString(m, "message")
+		cond.ObservedGeneration, _, _ = unstructured.NestedInt64(m, "observedGeneration")
+		return cond, nil
+	}
+	return nil, nil
+}
+
+func formatCondition(cond *apiMetaV1.Condition) string {
+	s := fmt.Sprintf("%v=%v", cond.Type, cond.Status)
+	if cond.Reason != "" {
+		s += ", reason: " + cond.Reason
+	}
+	if cond.Message != "" {
+		s += ", message: " + cond.Message
+	}
+	return s
+}
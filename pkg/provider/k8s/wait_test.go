@@ -0,0 +1,223 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+var prometheusGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "prometheuses"}
+
+func newPrometheusCR(generation int64, conditions ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "Prometheus",
+		"metadata":   map[string]interface{}{"name": "k8s", "namespace": "monitoring", "generation": generation},
+		"status":     map[string]interface{}{"conditions": conditions},
+	}}
+}
+
+func newConditionK8s(objects ...runtime.Object) *K8s {
+	c := newFakeK8s()
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "Prometheus"}, meta.RESTScopeNamespace)
+	c.mapper = mapper
+	c.dynamicClient = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{prometheusGVR: "PrometheusList"}, objects...)
+	return c
+}
+
+const waitForDeletionManifest = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: prombench
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: prombench
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: data
+`
+
+func newDeletionK8s(objects ...runtime.Object) (*K8s, *dynamicFake.FakeDynamicClient) {
+	c := newFakeK8s()
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	c.mapper = mapper
+	fake := dynamicFake.NewSimpleDynamicClient(scheme.Scheme, objects...)
+	c.dynamicClient = fake
+	return c, fake
+}
+
+func TestWaitForDeletion(t *testing.T) {
+	t.Run("deleted", func(t *testing.T) {
+		c, fake := newDeletionK8s()
+		if err := c.WaitForDeletion(decodeManifest(t, waitForDeletionManifest), time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The namespace is cluster scoped and the config map without a namespace is in the default namespace.
+		var got []string
+		for _, a := range fake.Actions() {
+			got = append(got, a.GetNamespace()+"/"+a.GetResource().Resource+"/"+a.(k8sTesting.GetAction).GetName())
+		}
+		exp := []string{"/namespaces/prombench", "prombench/configmaps/config", "default/configmaps/data"}
+		if strings.Join(got, " ") != strings.Join(exp, " ") {
+			t.Errorf("expected the gets %v, got %v", exp, got)
+		}
+	})
+
+	t.Run("remaining", func(t *testing.T) {
+		now := apiMetaV1.Now()
+		c, _ := newDeletionK8s(
+			&apiCoreV1.ConfigMap{
+				TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: apiMetaV1.ObjectMeta{Name: "config", Namespace: "prombench", DeletionTimestamp: &now, Finalizers: []string{"example.com/cleanup", "example.com/backup"}},
+			},
+			&apiCoreV1.ConfigMap{
+				TypeMeta:   apiMetaV1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: apiMetaV1.ObjectMeta{Name: "data", Namespace: "default"},
+			},
+		)
+		err := c.WaitForDeletion(decodeManifest(t, waitForDeletionManifest), time.Millisecond)
+		exp := "objects not deleted after 1ms: ConfigMap/default/data (not marked for deletion); " +
+			"ConfigMap/prombench/config (terminating, pending finalizers: example.com/cleanup, example.com/backup)"
+		if err == nil || err.Error() != exp {
+			t.Fatalf("expected the error %q, got %v", exp, err)
+		}
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		c, fake := newDeletionK8s()
+		fake.PrependReactor("get", "configmaps", func(k8sTesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		err := c.WaitForDeletion(decodeManifest(t, waitForDeletionManifest), time.Minute)
+		if err == nil || !strings.Contains(err.Error(), "checking resource deletion - ConfigMap/prombench/config: connection refused") {
+			t.Fatalf("expected the get error, got %v", err)
+		}
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		c, _ := newDeletionK8s()
+		c.mapper = meta.NewDefaultRESTMapper(nil)
+		if err := c.WaitForDeletion(decodeManifest(t, waitForDeletionManifest), time.Millisecond); err == nil {
+			t.Fatal("expected an error for a kind not served by the cluster")
+		}
+	})
+}
+
+func TestWaitForCondition(t *testing.T) {
+	available := map[string]interface{}{"type": "Available", "status": "True", "observedGeneration": int64(2)}
+	degraded := map[string]interface{}{"type": "Available", "status": "False", "reason": "Degraded", "message": "1/2 replicas"}
+
+	for _, tc := range []struct {
+		name   string
+		object runtime.Object
+		status string
+		err    string
+		last   string
+	}{
+		{name: "met", object: newPrometheusCR(2, available), status: "True"},
+		{name: "case insensitive", object: newPrometheusCR(2, available), status: "true"},
+		{name: "other status", object: newPrometheusCR(1, degraded), status: "False"},
+		{name: "not met", object: newPrometheusCR(1, degraded), status: "True", err: "last observed: Available=False, reason: Degraded, message: 1/2 replicas", last: "False"},
+		{name: "stale", object: newPrometheusCR(3, available), status: "True", err: "wasn't updated for the latest generation", last: "True"},
+		{name: "missing condition", object: newPrometheusCR(1), status: "True", err: "doesn't have the condition"},
+		{name: "missing object", status: "True", err: "doesn't have the condition"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var objects []runtime.Object
+			if tc.object != nil {
+				objects = append(objects, tc.object)
+			}
+			c := newConditionK8s(objects...)
+			last, err := c.WaitForCondition(prometheusGVR, "monitoring", "k8s", "available", tc.status, time.Millisecond)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+			}
+			if tc.last == "" {
+				if last != nil {
+					t.Errorf("expected no last observed condition, got %+v", last)
+				}
+				return
+			}
+			if last == nil || string(last.Status) != tc.last {
+				t.Errorf("expected the last observed status %v, got %+v", tc.last, last)
+			}
+		})
+	}
+}
+
+func TestWaitForObjectCondition(t *testing.T) {
+	c := newConditionK8s(newPrometheusCR(1, map[string]interface{}{"type": "Available", "status": "True"}))
+	if err := c.WaitForObjectCondition("Prometheus.monitoring.coreos.com/k8s", "monitoring", "condition=Available", time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range []struct{ object, condition string }{
+		{"Prometheus.monitoring.coreos.com/k8s", "Available"},
+		{"Prometheus.monitoring.coreos.com", "condition=Available"},
+		{"Unknown/k8s", "condition=Available"},
+	} {
+		if err := c.WaitForObjectCondition(tc.object, "monitoring", tc.condition, time.Millisecond); err == nil {
+			t.Errorf("expected an error waiting for %v %v", tc.object, tc.condition)
+		}
+	}
+}
+
+func TestParseWaitCondition(t *testing.T) {
+	for value, exp := range map[string][2]string{
+		"condition=Ready":           {"Ready", "True"},
+		"condition=Available=False": {"Available", "False"},
+		"condition=Ready=":          {},
+		"condition=":                {},
+		"delete":                    {},
+	} {
+		conditionType, status, err := ParseWaitCondition(value)
+		if exp[0] == "" {
+			if err == nil {
+				t.Errorf("%v: expected an error", value)
+			}
+			continue
+		}
+		if err != nil || conditionType != exp[0] || status != exp[1] {
+			t.Errorf("%v: expected %v, got %v %v %v", value, exp, conditionType, status, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchRetryInterval is how long to wait before watching again after the watch failed.
+var watchRetryInterval = 5 * time.Second
+
+// GetConfigMap returns the ConfigMap, or nil when it doesn't exist.
+func (c *K8s) GetConfigMap(namespace, name string) (*apiCoreV1.ConfigMap, error) {
+	cm, err := c.clt.CoreV1().ConfigMaps(namespace).Get(c.ctx, name, apiMetaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the ConfigMap %v/%v", namespace, name)
+	}
+	return cm, nil
+}
+
+// WatchConfigMap calls fn with the ConfigMap every time it is created or changed, and with nil when it is deleted,
+// until the context is cancelled. The events of a single watch are delivered in order.
+// The watch is started again when the api server closes it or it fails,
+// so fn can be called again with a ConfigMap that didn't change.
+func (c *K8s) WatchConfigMap(ctx context.Context, namespace, name string, fn func(*apiCoreV1.ConfigMap)) {
+	opts := apiMetaV1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()}
+	for ctx.Err() == nil {
+		w, err := c.clt.CoreV1().ConfigMaps(namespace).Watch(ctx, opts)
+		if err != nil {
+			log.Printf("Watching the ConfigMap %v/%v failed, retrying in %s: %v", namespace, name, watchRetryInterval, err)
+			// A resource version that is too old can't be watched, start from the current state.
+			opts.ResourceVersion = ""
+			select {
+			case <-ctx.Done():
+			case <-time.After(watchRetryInterval):
+			}
+			continue
+		}
+		for ev := range w.ResultChan() {
+			if cm, ok := ev.Object.(*apiCoreV1.ConfigMap); ok && cm.Name != name {
+				continue
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				if cm, ok := ev.Object.(*apiCoreV1.ConfigMap); ok {
+					opts.ResourceVersion = cm.ResourceVersion
+					fn(cm)
+				}
+			case watch.Deleted:
+				if cm, ok := ev.Object.(*apiCoreV1.ConfigMap); ok {
+					opts.ResourceVersion = cm.ResourceVersion
+				}
+				fn(nil)
+			case watch.Error:
+				log.Printf("Watching the ConfigMap %v/%v failed: %v", namespace, name, apiErrors.FromObject(ev.Object))
+				opts.ResourceVersion = ""
+			}
+		}
+		w.Stop()
+	}
+}
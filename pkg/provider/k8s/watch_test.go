@@ -0,0 +1,64 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestWatchConfigMap(t *testing.T) {
+	c := newFakeK8s()
+	clt := c.clt.(*fake.Clientset)
+	watcher := watch.NewFake()
+	clt.PrependWatchReactor("configmaps", k8sTesting.DefaultWatchReactor(watcher, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan *apiCoreV1.ConfigMap, 10)
+	go c.WatchConfigMap(ctx, "prombench", "scaler", func(cm *apiCoreV1.ConfigMap) { events <- cm })
+
+	configMap := func(name, max string) *apiCoreV1.ConfigMap {
+		return &apiCoreV1.ConfigMap{
+			ObjectMeta: apiMetaV1.ObjectMeta{Name: name, Namespace: "prombench"},
+			Data:       map[string]string{"max": max},
+		}
+	}
+	watcher.Add(configMap("scaler", "10"))
+	watcher.Add(configMap("other", "20"))
+	watcher.Modify(configMap("scaler", "30"))
+	watcher.Delete(configMap("scaler", "30"))
+
+	for _, want := range []string{"10", "30", ""} {
+		select {
+		case cm := <-events:
+			var got string
+			if cm != nil {
+				got = cm.Data["max"]
+			}
+			if got != want {
+				t.Errorf("want max %q, got %q", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the ConfigMap with max %q", want)
+		}
+	}
+}
@@ -16,13 +16,20 @@ package kind
 import (
 	"context"
 	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
+	yamlGo "gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/homedir"
 	"sigs.k8s.io/kind/pkg/cluster"
 	"sigs.k8s.io/kind/pkg/cmd"
@@ -53,7 +60,11 @@ type KIND struct {
 
 	ctx context.Context
 	// KIND kuberconfig file
-	kubeconfig string
+	Kubeconfig string
+	// The kubeconfig context to use, defaults to the current context.
+	KubeContext string
+	// When set the cluster in the kubeconfig is used as is and it is never created or deleted.
+	ExistingCluster bool
 }
 
 // New is the KIND constructor.
@@ -64,7 +75,7 @@ func New(dr *provider.DeploymentResource) *KIND {
 			cluster.ProviderWithLogger(cmd.NewLogger()),
 		),
 		ctx:        context.Background(),
-		kubeconfig: homedir.HomeDir() + "/.kube/config",
+		Kubeconfig: homedir.HomeDir() + "/.kube/config",
 	}
 }
 
@@ -81,7 +92,7 @@ func (c *KIND) SetupDeploymentResources(*kingpin.ParseContext) error {
 		customDeploymentVars,
 		c.DeploymentResource.FlagDeploymentVars,
 	)
-	return nil
+	return provider.ApplyClusterNameSuffix(c.DeploymentVars, c.DeploymentResource.ClusterNameSuffix, provider.KINDClusterNameRules)
 }
 
 // KINDDeploymentsParse parses the environment/kind deployment files and saves the result as bytes grouped by the filename.
@@ -108,9 +119,13 @@ func (c *KIND) K8SDeploymentsParse(*kingpin.ParseContext) error {
 	if err != nil {
 		return err
 	}
+	helmResources, err := provider.HelmTemplate(c.DeploymentResource.Helm)
+	if err != nil {
+		return err
+	}
+	deploymentResource = append(deploymentResource, helmResources...)
 	for _, deployment := range deploymentResource {
 
-		decode := scheme.Codecs.UniversalDeserializer().Decode
 		k8sObjects := make([]runtime.Object, 0)
 
 		for _, text := range strings.Split(string(deployment.Content), provider.Separator) {
@@ -119,9 +134,9 @@ func (c *KIND) K8SDeploymentsParse(*kingpin.ParseContext) error {
 				continue
 			}
 
-			resource, _, err := decode([]byte(text), nil, nil)
+			resource, err := k8sProvider.Decode([]byte(text))
 			if err != nil {
-				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, text[:100])
+				return errors.Wrapf(err, "decoding the resource file:%v, section:%v...", deployment.FileName, provider.Truncate(text, 100))
 			}
 			if resource == nil {
 				continue
@@ -143,54 +158,200 @@ func (c *KIND) checkDeploymentVarsAndFiles() error {
 			return fmt.Errorf("missing required %v variable", k)
 		}
 	}
-	if len(c.DeploymentFiles) == 0 {
+	if len(c.DeploymentFiles) == 0 && len(c.DeploymentResource.Helm.Charts) == 0 {
 		return fmt.Errorf("missing deployment file(s)")
 	}
 	return nil
 }
 
 // ClusterCreate create a new cluster or applies changes to an existing cluster.
-func (c *KIND) ClusterCreate(*kingpin.ParseContext) error {
+func (c *KIND) ClusterCreate(ctx *kingpin.ParseContext) error {
+	if c.ExistingCluster {
+		log.Printf("Using the existing cluster from kubeconfig '%v', skipping the cluster creation", c.Kubeconfig)
+		return c.NewK8sProvider(ctx)
+	}
+	start := time.Now()
 	for _, deployment := range c.kindResources {
+		exists, err := c.clusterExists(c.DeploymentVars["CLUSTER_NAME"])
+		if err != nil {
+			return err
+		}
+		if exists {
+			log.Printf("Cluster '%v' already exists, reusing it for the cluster name suffix", c.DeploymentVars["CLUSTER_NAME"])
+			continue
+		}
 		CreateWithConfigFile := cluster.CreateWithRawConfig(deployment.Content)
 
-		err := c.kindProvider.Create(c.DeploymentVars["CLUSTER_NAME"], CreateWithConfigFile)
+		err = c.kindProvider.Create(c.DeploymentVars["CLUSTER_NAME"], CreateWithConfigFile)
 		if err != nil {
 			return err
 		}
 	}
+	c.recordProvisioning("create", start)
+	return c.bootstrap(ctx)
+}
+
+// clusterExists reports whether a KIND cluster with the name already exists.
+// Only a cluster name suffix makes the name deterministic, so without it the cluster is always created.
+func (c *KIND) clusterExists(name string) (bool, error) {
+	if c.DeploymentResource.ClusterNameSuffix == "" {
+		return false, nil
+	}
+	clusters, err := c.kindProvider.List()
+	if err != nil {
+		return false, errors.Wrapf(err, "listing the KIND clusters")
+	}
+	for _, cl := range clusters {
+		if cl == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// bootstrap applies the bootstrap files passed from the cli to the created cluster.
+// Failures are reported separately as the cluster itself was created.
+func (c *KIND) bootstrap(ctx *kingpin.ParseContext) error {
+	files := c.DeploymentResource.BootstrapFiles
+	if len(files) == 0 {
+		return nil
+	}
+	if err := c.NewK8sProvider(ctx); err != nil {
+		return errors.Wrapf(err, "bootstrap failed, the cluster was created")
+	}
+	resources, err := k8sProvider.ParseFiles(files, c.DeploymentVars)
+	if err != nil {
+		return errors.Wrapf(err, "bootstrap failed, the cluster was created: couldn't parse the bootstrap files")
+	}
+	log.Printf("Bootstrap started: applying %d file(s)", len(resources))
+	if err := c.k8sProvider.ResourceApply(resources); err != nil {
+		return errors.Wrapf(err, "bootstrap failed, the cluster was created")
+	}
+	log.Printf("Bootstrap completed")
 	return nil
 }
 
 // ClusterDelete deletes a k8s cluster.
 func (c *KIND) ClusterDelete(*kingpin.ParseContext) error {
-	err := c.kindProvider.Delete(c.DeploymentVars["CLUSTER_NAME"], c.kubeconfig)
+	if c.ExistingCluster {
+		log.Printf("Using the existing cluster from kubeconfig '%v', skipping the cluster deletion", c.Kubeconfig)
+		return nil
+	}
+	start := time.Now()
+	err := c.kindProvider.Delete(c.DeploymentVars["CLUSTER_NAME"], c.Kubeconfig)
 	if err != nil {
 		return err
 	}
+	c.recordProvisioning("delete", start)
 	return nil
 }
 
+// recordProvisioning records the duration of a cluster create or delete with the nodes of the cluster config.
+// A config without nodes creates a single control plane node.
+func (c *KIND) recordProvisioning(operation string, start time.Time) {
+	nodes := 0
+	for _, deployment := range c.kindResources {
+		config := struct {
+			Nodes []interface{} `yaml:"nodes"`
+		}{}
+		if err := yamlGo.Unmarshal(deployment.Content, &config); err == nil {
+			nodes += len(config.Nodes)
+		}
+	}
+	if nodes == 0 {
+		nodes = 1
+	}
+	provider.RecordProvisioning(c.DeploymentResource.Provisioning, provider.ProvisioningResult{
+		Operation: operation,
+		Provider:  "kind",
+		Cluster:   c.DeploymentVars["CLUSTER_NAME"],
+		Region:    "local",
+		Nodes:     nodes,
+	}, start)
+}
+
 // NewK8sProvider sets the k8s provider used for deploying k8s manifests.
 func (c *KIND) NewK8sProvider(*kingpin.ParseContext) error {
 	var err error
-	apiConfig, err := clientcmd.LoadFromFile(c.kubeconfig)
+	var apiConfig *clientcmdapi.Config
+	c.k8sProvider, apiConfig, err = c.k8sClient()
 	if err != nil {
 		return err
 	}
+	if c.ExistingCluster {
+		if err := c.k8sProvider.CheckConnection(); err != nil {
+			return errors.Wrapf(err, "couldn't connect to the existing cluster, context: %q", apiConfig.CurrentContext)
+		}
+		log.Printf("Connected to the existing cluster, context: %q", apiConfig.CurrentContext)
+	}
+	return nil
+}
 
-	c.k8sProvider, err = k8sProvider.New(c.ctx, apiConfig)
+// K8sClient returns a k8s client for the cluster with the credentials read from its KIND control plane node,
+// so the manifests can be applied right after creating the cluster without reading a kubeconfig file.
+// An existing cluster or a kubeconfig context use the kubeconfig file.
+func (c *KIND) K8sClient() (*k8sProvider.K8s, error) {
+	k, _, err := c.k8sClient()
+	return k, err
+}
+
+func (c *KIND) k8sClient() (*k8sProvider.K8s, *clientcmdapi.Config, error) {
+	apiConfig, err := c.apiConfig()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	return nil
+	k, err := k8sProvider.NewForDeployment(c.ctx, apiConfig, c.DeploymentResource)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k, apiConfig, nil
+}
+
+// apiConfig returns the kubeconfig of the cluster, from KIND or from the kubeconfig file.
+func (c *KIND) apiConfig() (*clientcmdapi.Config, error) {
+	if !c.ExistingCluster && c.KubeContext == "" {
+		kubeconfig, err := c.kindProvider.KubeConfig(c.DeploymentVars["CLUSTER_NAME"], false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the kubeconfig of the KIND cluster %v", c.DeploymentVars["CLUSTER_NAME"])
+		}
+		return clientcmd.Load([]byte(kubeconfig))
+	}
+	apiConfig, err := clientcmd.LoadFromFile(c.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	if c.KubeContext != "" {
+		if _, ok := apiConfig.Contexts[c.KubeContext]; !ok {
+			return nil, fmt.Errorf("context %q not found in kubeconfig %v", c.KubeContext, c.Kubeconfig)
+		}
+		apiConfig.CurrentContext = c.KubeContext
+	}
+	return apiConfig, nil
 }
 
 // ResourceApply calls k8s.ResourceApply to apply the k8s objects in the manifest files.
 func (c *KIND) ResourceApply(*kingpin.ParseContext) error {
-	if err := c.k8sProvider.ResourceApply(c.k8sResources); err != nil {
+	if err := c.k8sProvider.ApplyAndWaitEstablished(c.k8sResources, c.DeploymentResource.EstablishTimeout); err != nil {
 		return err
 	}
+	if c.DeploymentResource.PruneDryRun {
+		out, err := c.k8sProvider.PruneDryRun(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stdout.Write(out); err != nil {
+			return err
+		}
+	} else if c.DeploymentResource.Prune {
+		if err := c.k8sProvider.Prune(c.k8sResources, c.DeploymentResource.PruneSelector, c.DeploymentResource.PruneKinds); err != nil {
+			return err
+		}
+	}
+	if c.DeploymentResource.ReconcileInterval > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.k8sProvider.ReconcileLoop(ctx, c.k8sResources, c.DeploymentResource.ReconcileInterval)
+	}
 	return nil
 }
 
@@ -202,6 +363,97 @@ func (c *KIND) ResourceDelete(*kingpin.ParseContext) error {
 	return nil
 }
 
+// ResourceStatus calls k8s.Status to print the readiness of the k8s objects in the manifest files,
+// it fails when an object is missing or not ready.
+func (c *KIND) ResourceStatus(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Status(c.k8sResources)
+	if _, werr := os.Stdout.Write(out); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// Describe calls k8s.Describe to print the live object as YAML.
+func (c *KIND) Describe(*kingpin.ParseContext) error {
+	out, err := c.k8sProvider.Describe(c.DeploymentResource.DescribeObject, c.DeploymentResource.DescribeNamespace, c.DeploymentResource.ShowManagedFields)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// FieldOwners calls k8s.FieldOwners to print the field managers of the object and the fields they own.
+func (c *KIND) FieldOwners(*kingpin.ParseContext) error {
+	kind, name, _ := strings.Cut(c.DeploymentResource.FieldOwnersObject, "/")
+	owners, err := c.k8sProvider.FieldOwners(kind, c.DeploymentResource.FieldOwnersNamespace, name)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(k8sProvider.FormatFieldOwners(owners))
+	return err
+}
+
+// Wait calls k8s.WaitForObjectCondition to block until the object has the status condition.
+func (c *KIND) Wait(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if err := c.k8sProvider.WaitForObjectCondition(dr.WaitObject, dr.WaitNamespace, dr.WaitCondition, dr.WaitTimeout); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Label calls k8s.Label to add the labels to the selected live objects.
+func (c *KIND) Label(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	return c.k8sProvider.Label(dr.MetadataObject, dr.MetadataSelector, dr.MetadataNamespace, dr.Labels)
+}
+
+// Annotate calls k8s.Annotate to add the annotations to the selected live objects.
+func (c *KIND) Annotate(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	return c.k8sProvider.Annotate(dr.MetadataObject, dr.MetadataSelector, dr.MetadataNamespace, dr.Annotations)
+}
+
+// Cordon calls k8s.CordonNode to mark the node unschedulable.
+func (c *KIND) Cordon(*kingpin.ParseContext) error {
+	return c.k8sProvider.CordonNode(c.DeploymentResource.NodeName)
+}
+
+// Uncordon calls k8s.UncordonNode to mark the node schedulable again.
+func (c *KIND) Uncordon(*kingpin.ParseContext) error {
+	return c.k8sProvider.UncordonNode(c.DeploymentResource.NodeName)
+}
+
+// Drain calls k8s.DrainNode to cordon the node and evict its pods.
+func (c *KIND) Drain(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	return c.k8sProvider.DrainNode(dr.NodeName, dr.DrainTimeout)
+}
+
+// Logs calls k8s.PodLogs to print the logs of the pod, or k8s.StreamPodLogs to follow them until interrupted.
+func (c *KIND) Logs(*kingpin.ParseContext) error {
+	dr := c.DeploymentResource
+	if !dr.FollowLogs {
+		out, err := c.k8sProvider.PodLogs(dr.LogsNamespace, dr.LogsPod, dr.LogsContainer)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logs, err := c.k8sProvider.StreamPodLogs(ctx, dr.LogsNamespace, dr.LogsPod, dr.LogsContainer)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+	_, err = io.Copy(os.Stdout, logs)
+	return err
+}
+
 // GetDeploymentVars shows deployment variables.
 func (c *KIND) GetDeploymentVars(parseContext *kingpin.ParseContext) error {
 	fmt.Print("-------------------\n   DeploymentVars   \n------------------- \n")
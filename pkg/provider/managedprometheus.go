@@ -0,0 +1,76 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ManagedPrometheus describes the endpoints of the provider managed Prometheus of a cluster,
+// e.g. to compare it with the self-hosted Prometheus under test.
+type ManagedPrometheus struct {
+	// QueryURL is the base URL of the Prometheus HTTP API, the clients append /api/v1/query and the other API paths.
+	QueryURL string `json:"queryURL"`
+	// RemoteWriteURL receives the samples of an agent in the cluster, empty when the provider scrapes the cluster itself.
+	RemoteWriteURL string `json:"remoteWriteURL,omitempty"`
+	// Scrape describes what the managed Prometheus collects.
+	Scrape string `json:"scrape"`
+}
+
+// The deployment vars set to the managed Prometheus endpoints for the bootstrap files of cluster create.
+const (
+	ManagedPrometheusQueryURLVar       = "MANAGED_PROMETHEUS_QUERY_URL"
+	ManagedPrometheusRemoteWriteURLVar = "MANAGED_PROMETHEUS_REMOTE_WRITE_URL"
+)
+
+// GMPManagedPrometheus returns the endpoints of Google Managed Prometheus in the project.
+// The managed collectors only scrape the targets selected by PodMonitoring and ClusterPodMonitoring resources,
+// so they don't change what the self-hosted Prometheus scrapes.
+func GMPManagedPrometheus(project string) ManagedPrometheus {
+	return ManagedPrometheus{
+		QueryURL: fmt.Sprintf("https://monitoring.googleapis.com/v1/projects/%s/location/global/prometheus", project),
+		Scrape:   "managed collection of the PodMonitoring and ClusterPodMonitoring resources (monitoring.googleapis.com/v1)",
+	}
+}
+
+// AMPManagedPrometheus returns the endpoints of an Amazon Managed Service for Prometheus workspace
+// from its Prometheus endpoint, e.g. https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1234/.
+// The workspace only receives the samples remote written to it, by an agent deployed separately from the self-hosted Prometheus.
+func AMPManagedPrometheus(workspaceEndpoint string) ManagedPrometheus {
+	base := strings.TrimSuffix(workspaceEndpoint, "/")
+	return ManagedPrometheus{
+		QueryURL:       base,
+		RemoteWriteURL: base + "/api/v1/remote_write",
+		Scrape:         "the samples remote written to the workspace with SigV4 auth",
+	}
+}
+
+// SetVars sets the deployment vars of the endpoints, the remote write one only when there is one.
+func (m ManagedPrometheus) SetVars(vars map[string]string) {
+	vars[ManagedPrometheusQueryURLVar] = m.QueryURL
+	if m.RemoteWriteURL != "" {
+		vars[ManagedPrometheusRemoteWriteURLVar] = m.RemoteWriteURL
+	}
+}
+
+// Log prints the endpoints of the managed Prometheus of the cluster.
+func (m ManagedPrometheus) Log(cluster string) {
+	log.Printf("Managed Prometheus of cluster '%s': query URL: %s", cluster, m.QueryURL)
+	if m.RemoteWriteURL != "" {
+		log.Printf("Managed Prometheus of cluster '%s': remote write URL: %s", cluster, m.RemoteWriteURL)
+	}
+	log.Printf("Managed Prometheus of cluster '%s': scrapes %s", cluster, m.Scrape)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManagedPrometheus(t *testing.T) {
+	amp := AMPManagedPrometheus("https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1234/")
+	if want := "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1234"; amp.QueryURL != want {
+		t.Errorf("want the query URL %q, got %q", want, amp.QueryURL)
+	}
+	if want := "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1234/api/v1/remote_write"; amp.RemoteWriteURL != want {
+		t.Errorf("want the remote write URL %q, got %q", want, amp.RemoteWriteURL)
+	}
+
+	gmp := GMPManagedPrometheus("prombench")
+	if want := "https://monitoring.googleapis.com/v1/projects/prombench/location/global/prometheus"; gmp.QueryURL != want {
+		t.Errorf("want the query URL %q, got %q", want, gmp.QueryURL)
+	}
+	vars := map[string]string{}
+	gmp.SetVars(vars)
+	if want := map[string]string{ManagedPrometheusQueryURLVar: gmp.QueryURL}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("want the vars %v without a remote write URL, got %v", want, vars)
+	}
+	amp.SetVars(vars)
+	if vars[ManagedPrometheusQueryURLVar] != amp.QueryURL || vars[ManagedPrometheusRemoteWriteURLVar] != amp.RemoteWriteURL {
+		t.Errorf("want the vars of the workspace, got %v", vars)
+	}
+
+	file := filepath.Join(t.TempDir(), "clusters.json")
+	clusters := []ClusterInfo{
+		{Provider: "eks", Name: "prombench", Region: "us-east-1", ManagedPrometheus: &amp},
+		{Provider: "eks", Name: "prombench-2", Region: "us-east-1"},
+	}
+	if err := WriteClusterInfo(file, clusters); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := ReadClusterInfo(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, clusters) {
+		t.Errorf("want the clusters %+v, got %+v", clusters, got)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"net"
+)
+
+// ValidateCIDRs returns an error when the pod or the service range isn't a valid IPv4 CIDR
+// or when the two ranges overlap. Empty ranges are skipped.
+func ValidateCIDRs(podCIDR, serviceCIDR string) error {
+	var nets []*net.IPNet
+	for _, r := range []struct{ name, cidr string }{{"pod", podCIDR}, {"service", serviceCIDR}} {
+		if r.cidr == "" {
+			continue
+		}
+		ip, n, err := net.ParseCIDR(r.cidr)
+		if err != nil || ip.To4() == nil {
+			return fmt.Errorf("invalid %v CIDR %q, must be an IPv4 range like 10.4.0.0/14", r.name, r.cidr)
+		}
+		if !ip.Equal(n.IP) {
+			return fmt.Errorf("invalid %v CIDR %q, the address isn't the start of the range, did you mean %v?", r.name, r.cidr, n)
+		}
+		nets = append(nets, n)
+	}
+	if len(nets) == 2 && (nets[0].Contains(nets[1].IP) || nets[1].Contains(nets[0].IP)) {
+		return fmt.Errorf("the pod CIDR %v and the service CIDR %v overlap", podCIDR, serviceCIDR)
+	}
+	return nil
+}
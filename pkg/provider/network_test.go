@@ -0,0 +1,38 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestValidateCIDRs(t *testing.T) {
+	for _, tc := range []struct {
+		pod, service string
+		err          bool
+	}{
+		{pod: "10.4.0.0/14", service: "10.8.0.0/20"},
+		{pod: "10.4.0.0/14"},
+		{service: "172.20.0.0/16"},
+		{},
+		{pod: "10.4.0.0/14", service: "10.5.0.0/20", err: true},
+		{pod: "10.8.0.0/24", service: "10.0.0.0/8", err: true},
+		{pod: "10.4.0.1/14", err: true},
+		{service: "fd00::/108", err: true},
+		{pod: "/14", err: true},
+	} {
+		err := ValidateCIDRs(tc.pod, tc.service)
+		if tc.err != (err != nil) {
+			t.Errorf("pod %q, service %q: want error %v, got %v", tc.pod, tc.service, tc.err, err)
+		}
+	}
+}
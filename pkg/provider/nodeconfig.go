@@ -0,0 +1,158 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NodeSystemConfig is the kubelet and kernel config of the nodes of the created node pools,
+// passed to cluster create and node pool create with --node-system-config.
+type NodeSystemConfig struct {
+	// Sysctls are the kernel parameters of the nodes by name, only the NodeSysctls can be set.
+	Sysctls map[string]string `yaml:"sysctls"`
+	// EvictionHard are the hard eviction thresholds of the kubelet by signal,
+	// as a quantity like 500Mi or a percentage like 10%.
+	EvictionHard map[string]string `yaml:"evictionHard"`
+	// PodPidsLimit is the maximum number of processes of a pod, 0 keeps the kubelet default.
+	PodPidsLimit int64 `yaml:"podPidsLimit"`
+	// CPUManagerPolicy is none or static, empty keeps the kubelet default.
+	CPUManagerPolicy string `yaml:"cpuManagerPolicy"`
+}
+
+// NodeSysctls are the kernel parameters that can be set on the nodes,
+// the network buffers and the memory map and file limits TSDB benchmarks are sensitive to.
+var NodeSysctls = []string{
+	"fs.aio-max-nr",
+	"fs.file-max",
+	"fs.inotify.max_user_instances",
+	"fs.inotify.max_user_watches",
+	"net.core.busy_poll",
+	"net.core.busy_read",
+	"net.core.netdev_max_backlog",
+	"net.core.optmem_max",
+	"net.core.rmem_max",
+	"net.core.somaxconn",
+	"net.core.wmem_default",
+	"net.core.wmem_max",
+	"net.ipv4.tcp_rmem",
+	"net.ipv4.tcp_tw_reuse",
+	"net.ipv4.tcp_wmem",
+	"vm.dirty_background_ratio",
+	"vm.dirty_ratio",
+	"vm.max_map_count",
+	"vm.swappiness",
+}
+
+// EvictionSignals are the eviction signals of the kubelet that can have a hard threshold.
+var EvictionSignals = []string{"imagefs.available", "imagefs.inodesFree", "memory.available", "nodefs.available", "nodefs.inodesFree", "pid.available"}
+
+// sysctlValue matches the values of the NodeSysctls, one or more integers separated by spaces like 4096 87380 6291456.
+var sysctlValue = regexp.MustCompile(`^[0-9]+( [0-9]+)*$`)
+
+// LoadNodeSystemConfig reads and validates a node system config file.
+func LoadNodeSystemConfig(filename string) (*NodeSystemConfig, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading the node system config: %w", err)
+	}
+	c := &NodeSystemConfig{}
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return nil, fmt.Errorf("parsing the node system config %v: %w", filename, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid node system config %v: %w", filename, err)
+	}
+	return c, nil
+}
+
+func (c *NodeSystemConfig) validate() error {
+	for _, name := range sortedKeys(c.Sysctls) {
+		if !contains(NodeSysctls, name) {
+			return fmt.Errorf("unsupported sysctl %q, must be one of %v", name, strings.Join(NodeSysctls, ", "))
+		}
+		if !sysctlValue.MatchString(c.Sysctls[name]) {
+			return fmt.Errorf("invalid value %q of sysctl %v, must be integers separated by spaces", c.Sysctls[name], name)
+		}
+	}
+	for _, signal := range sortedKeys(c.EvictionHard) {
+		if !contains(EvictionSignals, signal) {
+			return fmt.Errorf("unsupported eviction signal %q, must be one of %v", signal, strings.Join(EvictionSignals, ", "))
+		}
+		if err := validateEvictionThreshold(c.EvictionHard[signal]); err != nil {
+			return fmt.Errorf("invalid eviction threshold of %v: %w", signal, err)
+		}
+	}
+	if c.PodPidsLimit != 0 && (c.PodPidsLimit < 1024 || c.PodPidsLimit >= 4194304) {
+		return fmt.Errorf("invalid pod pids limit %d, must be >= 1024 and < 4194304", c.PodPidsLimit)
+	}
+	switch c.CPUManagerPolicy {
+	case "", "none", "static":
+	default:
+		return fmt.Errorf("invalid cpu manager policy %q, must be none or static", c.CPUManagerPolicy)
+	}
+	return nil
+}
+
+// validateEvictionThreshold checks a threshold given as a percentage or a quantity.
+func validateEvictionThreshold(value string) error {
+	if p := strings.TrimSuffix(value, "%"); p != value {
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil || f <= 0 || f > 100 {
+			return fmt.Errorf("%q, the percentage must be > 0 and <= 100", value)
+		}
+		return nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil || q.Sign() <= 0 {
+		return fmt.Errorf("%q, must be a percentage like 10%% or a quantity like 500Mi", value)
+	}
+	return nil
+}
+
+// CheckSysctls returns an error for the first sysctl of the config that isn't in the supported ones of a provider.
+func (c *NodeSystemConfig) CheckSysctls(provider string, supported []string) error {
+	for _, name := range sortedKeys(c.Sysctls) {
+		if !contains(supported, name) {
+			return fmt.Errorf("%v doesn't support the sysctl %v, the supported ones are %v", provider, name, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
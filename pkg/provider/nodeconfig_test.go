@@ -0,0 +1,83 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadNodeSystemConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"valid.yaml": `
+sysctls:
+  vm.max_map_count: "262144"
+  net.ipv4.tcp_rmem: "4096 87380 6291456"
+evictionHard:
+  memory.available: 500Mi
+  nodefs.available: 10%
+podPidsLimit: 4096
+cpuManagerPolicy: static
+`,
+		"unknown-key.yaml":        "swap: true\n",
+		"unknown-sysctl.yaml":     "sysctls:\n  kernel.panic: \"10\"\n",
+		"sysctl-value.yaml":       "sysctls:\n  vm.max_map_count: \"1; reboot\"\n",
+		"unknown-signal.yaml":     "evictionHard:\n  cpu.available: 10%\n",
+		"threshold.yaml":          "evictionHard:\n  memory.available: lots\n",
+		"percentage.yaml":         "evictionHard:\n  memory.available: 120%\n",
+		"pids.yaml":               "podPidsLimit: 100\n",
+		"cpu-manager.yaml":        "cpuManagerPolicy: dynamic\n",
+		"negative-threshold.yaml": "evictionHard:\n  memory.available: -1Gi\n",
+	})
+
+	c, err := LoadNodeSystemConfig(filepath.Join(dir, "valid.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &NodeSystemConfig{
+		Sysctls:          map[string]string{"vm.max_map_count": "262144", "net.ipv4.tcp_rmem": "4096 87380 6291456"},
+		EvictionHard:     map[string]string{"memory.available": "500Mi", "nodefs.available": "10%"},
+		PodPidsLimit:     4096,
+		CPUManagerPolicy: "static",
+	}
+	if !reflect.DeepEqual(want, c) {
+		t.Errorf("want %+v, got %+v", want, c)
+	}
+
+	for file, msg := range map[string]string{
+		"unknown-key.yaml":        "field swap not found",
+		"unknown-sysctl.yaml":     `unsupported sysctl "kernel.panic"`,
+		"sysctl-value.yaml":       "must be integers separated by spaces",
+		"unknown-signal.yaml":     `unsupported eviction signal "cpu.available"`,
+		"threshold.yaml":          "must be a percentage like 10% or a quantity like 500Mi",
+		"percentage.yaml":         "the percentage must be > 0 and <= 100",
+		"pids.yaml":               "invalid pod pids limit 100",
+		"cpu-manager.yaml":        `invalid cpu manager policy "dynamic"`,
+		"negative-threshold.yaml": "must be a percentage like 10% or a quantity like 500Mi",
+	} {
+		if _, err := LoadNodeSystemConfig(filepath.Join(dir, file)); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("%v: want an error with %q, got %v", file, msg, err)
+		}
+	}
+
+	if err := c.CheckSysctls("GKE", []string{"net.ipv4.tcp_rmem"}); err == nil || !strings.Contains(err.Error(), "GKE doesn't support the sysctl vm.max_map_count") {
+		t.Errorf("want an error for the unsupported sysctl, got %v", err)
+	}
+	if err := c.CheckSysctls("EKS", NodeSysctls); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Taint effects accepted in a node pool definition.
+const (
+	TaintEffectNoSchedule       = "NoSchedule"
+	TaintEffectPreferNoSchedule = "PreferNoSchedule"
+	TaintEffectNoExecute        = "NoExecute"
+)
+
+// NodePoolTaint is a k8s taint applied to all nodes of a pool.
+type NodePoolTaint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// NodePoolSpec describes a node pool passed from the cli.
+type NodePoolSpec struct {
+	Name        string
+	MachineType string
+	Count       int32
+	Labels      map[string]string
+	Taints      []NodePoolTaint
+}
+
+// NodePoolSpecs is a repeatable cli flag value holding node pool definitions.
+// Each definition is a comma separated list of key=value pairs, e.g.
+// name=prometheus,machine-type=n1-highmem-8,count=2,label=isolation=prometheus,taint=dedicated=prometheus:NoSchedule
+// The label and taint keys can be repeated.
+type NodePoolSpecs []NodePoolSpec
+
+// Set implements the kingpin.Value interface.
+func (n *NodePoolSpecs) Set(value string) error {
+	spec, err := ParseNodePoolSpec(value)
+	if err != nil {
+		return err
+	}
+	*n = append(*n, spec)
+	return nil
+}
+
+func (n *NodePoolSpecs) String() string {
+	names := make([]string, 0, len(*n))
+	for _, s := range *n {
+		names = append(names, s.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// IsCumulative allows the flag to be repeated.
+func (n *NodePoolSpecs) IsCumulative() bool {
+	return true
+}
+
+// ParseNodePoolSpec parses a single node pool definition.
+func ParseNodePoolSpec(value string) (NodePoolSpec, error) {
+	spec := NodePoolSpec{Labels: map[string]string{}}
+	for _, field := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok || v == "" {
+			return spec, fmt.Errorf("invalid node pool field %q, expected key=value", field)
+		}
+		switch k {
+		case "name":
+			spec.Name = v
+		case "machine-type":
+			spec.MachineType = v
+		case "count":
+			c, err := strconv.ParseInt(v, 10, 32)
+			if err != nil || c < 0 {
+				return spec, fmt.Errorf("invalid node pool count %q", v)
+			}
+			spec.Count = int32(c)
+		case "label":
+			lk, lv, ok := strings.Cut(v, "=")
+			if !ok || lk == "" {
+				return spec, fmt.Errorf("invalid node pool label %q, expected label=key=value", v)
+			}
+			spec.Labels[lk] = lv
+		case "taint":
+			taint, err := parseNodePoolTaint(v)
+			if err != nil {
+				return spec, err
+			}
+			spec.Taints = append(spec.Taints, taint)
+		default:
+			return spec, fmt.Errorf("unknown node pool field %q", k)
+		}
+	}
+	if spec.Name == "" {
+		return spec, fmt.Errorf("node pool definition %q is missing a name", value)
+	}
+	if spec.MachineType == "" {
+		return spec, fmt.Errorf("node pool %q is missing a machine-type", spec.Name)
+	}
+	if spec.Count == 0 {
+		spec.Count = 1
+	}
+	return spec, nil
+}
+
+// parseNodePoolTaint parses a taint in the kubectl format key=value:Effect.
+func parseNodePoolTaint(value string) (NodePoolTaint, error) {
+	kv, effect, ok := strings.Cut(value, ":")
+	if !ok {
+		return NodePoolTaint{}, fmt.Errorf("invalid node pool taint %q, expected key=value:Effect", value)
+	}
+	k, v, _ := strings.Cut(kv, "=")
+	if k == "" {
+		return NodePoolTaint{}, fmt.Errorf("invalid node pool taint %q, missing key", value)
+	}
+	switch effect {
+	case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+	default:
+		return NodePoolTaint{}, fmt.Errorf("invalid node pool taint effect %q", effect)
+	}
+	return NodePoolTaint{Key: k, Value: v, Effect: effect}, nil
+}
+
+// The label and taint of the dedicated system and benchmark node pools.
+const (
+	DedicatedKey          = "prombench/dedicated"
+	DedicatedSystem       = "system"
+	DedicatedBenchmark    = "benchmark"
+	DefaultSystemPoolName = "system"
+)
+
+// BenchmarkTaint is set on all benchmark node pools when a system node pool is created,
+// so only the pods that tolerate it run on them.
+var BenchmarkTaint = NodePoolTaint{Key: DedicatedKey, Value: DedicatedBenchmark, Effect: TaintEffectNoSchedule}
+
+// ParseSystemNodePool parses the definition of the untainted system node pool for the kube-system workloads,
+// in the node pool format without the name, which defaults to system, e.g. machine-type=e2-standard-2,count=1.
+// The pool gets the prombench/dedicated=system label.
+func ParseSystemNodePool(value string) (NodePoolSpec, error) {
+	spec, err := ParseNodePoolSpec("name=" + DefaultSystemPoolName + "," + value)
+	if err != nil {
+		return spec, err
+	}
+	if len(spec.Taints) > 0 {
+		return spec, fmt.Errorf("the system node pool %q can't have taints, the kube-system workloads don't tolerate them", spec.Name)
+	}
+	spec.Labels[DedicatedKey] = DedicatedSystem
+	return spec, nil
+}
+
+// ValidateNodePoolNames returns an error when a node pool name is used more than once.
+func ValidateNodePoolNames(names []string) error {
+	seen := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		if _, ok := seen[n]; ok {
+			return fmt.Errorf("duplicate node pool name %q", n)
+		}
+		seen[n] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateNodePoolDelete returns an error when deleting the given pools would leave the cluster
+// without any node pool, unless force is set.
+func ValidateNodePoolDelete(existing, deleting []string, force bool) error {
+	if force {
+		return nil
+	}
+	del := make(map[string]struct{}, len(deleting))
+	for _, n := range deleting {
+		del[n] = struct{}{}
+	}
+	for _, n := range existing {
+		if _, ok := del[n]; !ok {
+			return nil
+		}
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("refusing to delete the last remaining node pools %v of the cluster, use --force to delete them anyway", existing)
+}
+
+// ReplacementPool returns the pool of the cluster file that replaces the old pool of the cluster,
+// the pool named with or the only pool of the file other than old, and whether it exists already,
+// e.g. when a replacement that failed while draining is run again.
+func ReplacementPool(existing, pools []string, old, with string) (string, bool, error) {
+	has := func(names []string, name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(existing, old) {
+		return "", false, fmt.Errorf("node pool %q is not in the cluster, existing pools: %v", old, existing)
+	}
+	if with == "" {
+		var candidates []string
+		for _, n := range pools {
+			if n != old {
+				candidates = append(candidates, n)
+			}
+		}
+		if len(candidates) != 1 {
+			return "", false, fmt.Errorf("the cluster file has the node pools %v, use --with to choose the one replacing %q", candidates, old)
+		}
+		with = candidates[0]
+	}
+	if with == old {
+		return "", false, fmt.Errorf("node pool %q can't replace itself, the replacement needs a new name", old)
+	}
+	if !has(pools, with) {
+		return "", false, fmt.Errorf("node pool %q is not in the cluster file, pools: %v", with, pools)
+	}
+	return with, has(existing, with), nil
+}
+
+// NodePoolAutoscaling holds the cluster autoscaler node bounds for a single pool.
+type NodePoolAutoscaling struct {
+	Name string
+	Min  int32
+	Max  int32
+}
+
+// Contains returns an error when the node count is outside the autoscaling bounds.
+func (a NodePoolAutoscaling) Contains(count int32) error {
+	if count < a.Min || count > a.Max {
+		return fmt.Errorf("node pool %q count %d is outside the autoscaling bounds min: %d, max: %d", a.Name, count, a.Min, a.Max)
+	}
+	return nil
+}
+
+// NodePoolAutoscalings is a repeatable cli flag value that enables the cluster autoscaler for node pools.
+// Each definition is a comma separated list of key=value pairs, e.g.
+// name=prometheus,min=1,max=5
+type NodePoolAutoscalings []NodePoolAutoscaling
+
+// Set implements the kingpin.Value interface.
+func (n *NodePoolAutoscalings) Set(value string) error {
+	a, err := ParseNodePoolAutoscaling(value)
+	if err != nil {
+		return err
+	}
+	for _, e := range *n {
+		if e.Name == a.Name {
+			return fmt.Errorf("duplicate autoscaling definition for node pool %q", a.Name)
+		}
+	}
+	*n = append(*n, a)
+	return nil
+}
+
+func (n *NodePoolAutoscalings) String() string {
+	names := make([]string, 0, len(*n))
+	for _, a := range *n {
+		names = append(names, a.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// IsCumulative allows the flag to be repeated.
+func (n *NodePoolAutoscalings) IsCumulative() bool {
+	return true
+}
+
+// ParseNodePoolAutoscaling parses and validates a single autoscaling definition.
+// The bounds must be min >= 0, max > 0 and min <= max.
+func ParseNodePoolAutoscaling(value string) (NodePoolAutoscaling, error) {
+	var (
+		a              NodePoolAutoscaling
+		hasMin, hasMax bool
+	)
+	for _, field := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok || v == "" {
+			return a, fmt.Errorf("invalid autoscaling field %q, expected key=value", field)
+		}
+		switch k {
+		case "name":
+			a.Name = v
+		case "min", "max":
+			c, err := strconv.ParseInt(v, 10, 32)
+			if err != nil || c < 0 {
+				return a, fmt.Errorf("invalid autoscaling %v %q, must be a number >= 0", k, v)
+			}
+			if k == "min" {
+				a.Min, hasMin = int32(c), true
+			} else {
+				a.Max, hasMax = int32(c), true
+			}
+		default:
+			return a, fmt.Errorf("unknown autoscaling field %q", k)
+		}
+	}
+	if a.Name == "" {
+		return a, fmt.Errorf("autoscaling definition %q is missing a name", value)
+	}
+	if !hasMin || !hasMax {
+		return a, fmt.Errorf("autoscaling definition for node pool %q requires both min and max", a.Name)
+	}
+	if a.Max == 0 {
+		return a, fmt.Errorf("invalid autoscaling max for node pool %q, must be > 0", a.Name)
+	}
+	if a.Min > a.Max {
+		return a, fmt.Errorf("invalid autoscaling bounds for node pool %q, min: %d is bigger than max: %d", a.Name, a.Min, a.Max)
+	}
+	return a, nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNodePoolSpec(t *testing.T) {
+	testCases := []struct {
+		value string
+		spec  NodePoolSpec
+		err   bool
+	}{
+		{
+			value: "name=prometheus,machine-type=n1-highmem-8,count=3,label=isolation=prometheus,label=node-name=prom,taint=dedicated=prometheus:NoSchedule",
+			spec: NodePoolSpec{
+				Name:        "prometheus",
+				MachineType: "n1-highmem-8",
+				Count:       3,
+				Labels:      map[string]string{"isolation": "prometheus", "node-name": "prom"},
+				Taints:      []NodePoolTaint{{Key: "dedicated", Value: "prometheus", Effect: TaintEffectNoSchedule}},
+			},
+		},
+		{
+			value: "name=loadgen,machine-type=n1-standard-2",
+			spec:  NodePoolSpec{Name: "loadgen", MachineType: "n1-standard-2", Count: 1, Labels: map[string]string{}},
+		},
+		{value: "machine-type=n1-standard-2", err: true},
+		{value: "name=loadgen", err: true},
+		{value: "name=loadgen,machine-type=n1-standard-2,count=-1", err: true},
+		{value: "name=loadgen,machine-type=n1-standard-2,taint=dedicated=loadgen:Sometimes", err: true},
+		{value: "name=loadgen,machine-type=n1-standard-2,disk=100", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			spec, err := ParseNodePoolSpec(tc.value)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.spec, spec) {
+				t.Errorf("\nexpect %#v\ngot %#v", tc.spec, spec)
+			}
+		})
+	}
+}
+
+func TestParseSystemNodePool(t *testing.T) {
+	spec, err := ParseSystemNodePool("machine-type=e2-standard-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := NodePoolSpec{Name: "system", MachineType: "e2-standard-2", Count: 1, Labels: map[string]string{DedicatedKey: DedicatedSystem}}
+	if !reflect.DeepEqual(want, spec) {
+		t.Errorf("\nexpect %#v\ngot %#v", want, spec)
+	}
+
+	spec, err = ParseSystemNodePool("name=kube-system,machine-type=e2-standard-4,count=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Name != "kube-system" || spec.Count != 2 {
+		t.Errorf("want the name kube-system with 2 nodes, got %q with %d", spec.Name, spec.Count)
+	}
+
+	if _, err := ParseSystemNodePool("machine-type=e2-standard-2,taint=dedicated=system:NoSchedule"); err == nil {
+		t.Error("expected an error for a tainted system node pool")
+	}
+	if _, err := ParseSystemNodePool("count=1"); err == nil {
+		t.Error("expected an error without a machine type")
+	}
+}
+
+func TestValidateNodePoolNames(t *testing.T) {
+	if err := ValidateNodePoolNames([]string{"main-node", "prometheus", "loadgen"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateNodePoolNames([]string{"main-node", "prometheus", "main-node"}); err == nil {
+		t.Error("expected an error for duplicate node pool names")
+	}
+}
+
+func TestValidateNodePoolDelete(t *testing.T) {
+	testCases := []struct {
+		name               string
+		existing, deleting []string
+		force              bool
+		err                bool
+	}{
+		{name: "some pools remain", existing: []string{"main-node", "prometheus"}, deleting: []string{"prometheus"}},
+		{name: "last pool", existing: []string{"main-node"}, deleting: []string{"main-node"}, err: true},
+		{name: "all pools", existing: []string{"main-node", "prometheus"}, deleting: []string{"prometheus", "main-node"}, err: true},
+		{name: "all pools with force", existing: []string{"main-node", "prometheus"}, deleting: []string{"prometheus", "main-node"}, force: true},
+		{name: "already deleted", existing: []string{"main-node"}, deleting: []string{"prometheus"}},
+		{name: "no pools", deleting: []string{"prometheus"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateNodePoolDelete(tc.existing, tc.deleting, tc.force)
+			if tc.err && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.err && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReplacementPool(t *testing.T) {
+	testCases := []struct {
+		name            string
+		existing, pools []string
+		old, with       string
+		replacement     string
+		exists, err     bool
+	}{
+		{name: "only new pool", existing: []string{"main-node", "nodes-v1"}, pools: []string{"nodes-v2"}, old: "nodes-v1", replacement: "nodes-v2"},
+		{name: "old pool in the file", existing: []string{"nodes-v1"}, pools: []string{"nodes-v1", "nodes-v2"}, old: "nodes-v1", replacement: "nodes-v2"},
+		{name: "with", existing: []string{"nodes-v1"}, pools: []string{"main-node", "nodes-v2"}, old: "nodes-v1", with: "nodes-v2", replacement: "nodes-v2"},
+		{name: "resumed", existing: []string{"nodes-v1", "nodes-v2"}, pools: []string{"nodes-v2"}, old: "nodes-v1", replacement: "nodes-v2", exists: true},
+		{name: "several pools", existing: []string{"nodes-v1"}, pools: []string{"main-node", "nodes-v2"}, old: "nodes-v1", err: true},
+		{name: "missing old pool", existing: []string{"main-node"}, pools: []string{"nodes-v2"}, old: "nodes-v1", err: true},
+		{name: "with not in the file", existing: []string{"nodes-v1"}, pools: []string{"nodes-v2"}, old: "nodes-v1", with: "nodes-v3", err: true},
+		{name: "itself", existing: []string{"nodes-v1"}, pools: []string{"nodes-v1"}, old: "nodes-v1", with: "nodes-v1", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			replacement, exists, err := ReplacementPool(tc.existing, tc.pools, tc.old, tc.with)
+			if tc.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if replacement != tc.replacement || exists != tc.exists {
+				t.Errorf("want %q, exists %v, got %q, exists %v", tc.replacement, tc.exists, replacement, exists)
+			}
+		})
+	}
+}
+
+func TestParseNodePoolAutoscaling(t *testing.T) {
+	testCases := []struct {
+		value string
+		a     NodePoolAutoscaling
+		err   bool
+	}{
+		{value: "name=prometheus,min=1,max=5", a: NodePoolAutoscaling{Name: "prometheus", Min: 1, Max: 5}},
+		{value: "name=prometheus,min=0,max=1", a: NodePoolAutoscaling{Name: "prometheus", Min: 0, Max: 1}},
+		{value: "name=prometheus,min=3,max=3", a: NodePoolAutoscaling{Name: "prometheus", Min: 3, Max: 3}},
+		{value: "name=prometheus,min=5,max=1", err: true},
+		{value: "name=prometheus,min=-1,max=1", err: true},
+		{value: "name=prometheus,min=0,max=0", err: true},
+		{value: "name=prometheus,max=3", err: true},
+		{value: "min=1,max=3", err: true},
+		{value: "name=prometheus,min=1,max=3,desired=2", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			a, err := ParseNodePoolAutoscaling(tc.value)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.a != a {
+				t.Errorf("\nexpect %#v\ngot %#v", tc.a, a)
+			}
+			if err := a.Contains(a.Min - 1); err == nil {
+				t.Errorf("expected an error for a count below min")
+			}
+		})
+	}
+
+	var n NodePoolAutoscalings
+	if err := n.Set("name=prometheus,min=1,max=5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := n.Set("name=prometheus,min=1,max=3"); err == nil {
+		t.Error("expected an error for a duplicate node pool")
+	}
+}
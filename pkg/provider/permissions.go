@@ -0,0 +1,40 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+// CommandPermissions are the cloud permissions a command needs, by the full command, e.g. "gke cluster create".
+type CommandPermissions map[string][]string
+
+// Required returns the permissions of the command, the default permissions for the commands that aren't listed.
+func (p CommandPermissions) Required(command, defaultCommand string) []string {
+	if perms, ok := p[command]; ok {
+		return perms
+	}
+	return p[defaultCommand]
+}
+
+// MissingPermissions returns the required permissions that weren't granted, in the order of required.
+func MissingPermissions(required, granted []string) []string {
+	ok := map[string]bool{}
+	for _, g := range granted {
+		ok[g] = true
+	}
+	var missing []string
+	for _, r := range required {
+		if !ok[r] {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingPermissions(t *testing.T) {
+	required := []string{"container.clusters.create", "container.clusters.get", "container.operations.get"}
+	if missing := MissingPermissions(required, []string{"container.clusters.get"}); !reflect.DeepEqual(missing, []string{"container.clusters.create", "container.operations.get"}) {
+		t.Errorf("want the permissions that weren't granted, got %v", missing)
+	}
+	if missing := MissingPermissions(required, required); len(missing) != 0 {
+		t.Errorf("want no missing permissions, got %v", missing)
+	}
+}
+
+func TestCommandPermissions(t *testing.T) {
+	p := CommandPermissions{
+		"gke cluster create": {"container.clusters.create"},
+		"gke":                {"container.clusters.get"},
+	}
+	if got := p.Required("gke cluster create", "gke"); !reflect.DeepEqual(got, []string{"container.clusters.create"}) {
+		t.Errorf("want the permissions of the command, got %v", got)
+	}
+	if got := p.Required("gke resource apply", "gke"); !reflect.DeepEqual(got, []string{"container.clusters.get"}) {
+		t.Errorf("want the default permissions, got %v", got)
+	}
+}
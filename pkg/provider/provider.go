@@ -26,11 +26,16 @@ import (
 
 const (
 	EKSRetryCount    = 100
+	AKSRetryCount    = 100
 	GlobalRetryCount = 50
 	Separator        = "---"
 	globalRetryTime  = 10 * time.Second
 )
 
+// ProgressInterval is how often RetryUntilTrue logs the progress of an operation that is still running,
+// 0 logs it at every check.
+var ProgressInterval = 30 * time.Second
+
 // DeploymentResource holds list of variables and corresponding files.
 type DeploymentResource struct {
 	// DeploymentFiles files provided from the cli.
@@ -39,6 +44,117 @@ type DeploymentResource struct {
 	FlagDeploymentVars map[string]string
 	// Default DeploymentVars.
 	DefaultDeploymentVars map[string]string
+	// CredentialsFile holds the credentials of several providers, used when the --auth flag of the provider isn't set.
+	CredentialsFile string
+	// Client side rate limits of the k8s REST client, 0 keeps the client-go defaults.
+	K8sQPS   float32
+	K8sBurst int
+	// Retry policies of the k8s requests by operation class.
+	K8sRetries RetryPolicies
+	// K8sCAFile replaces the certificate authority of the k8s api server, K8sInsecureSkipTLSVerify skips its verification, unsafe outside of dev clusters.
+	K8sCAFile                string
+	K8sInsecureSkipTLSVerify bool
+	// Labels and annotations added to every object applied by the k8s provider.
+	InjectLabels      map[string]string
+	InjectAnnotations map[string]string
+	// ForceInject overwrites the labels and annotations already set in the manifests.
+	ForceInject bool
+	// Owner of the applied objects as kind/name in OwnerNamespace.
+	Owner          string
+	OwnerNamespace string
+	// NoWait skips waiting for the applied workloads to become ready.
+	NoWait bool
+	// ImagePreflight checks that the images of the manifests can be pulled before applying them.
+	ImagePreflight bool
+	// ImmutablePreflight checks that the manifests don't change immutable fields of the live objects before applying them.
+	ImmutablePreflight bool
+	// EstablishTimeout applies the CRDs of the manifests first and waits up to this long for them to be established
+	// before applying the rest of the objects, 0 applies all objects in order.
+	EstablishTimeout time.Duration
+	// VPARecommendationTimeout waits up to this long after the apply for the VerticalPodAutoscalers of the manifests
+	// to have a recommendation, 0 doesn't wait.
+	VPARecommendationTimeout time.Duration
+	// ServerSideApply applies the objects with a server-side apply, PruneFields also removes the fields
+	// that earlier applies set and the manifests no longer do.
+	ServerSideApply bool
+	PruneFields     bool
+	// Helm charts rendered and applied by the k8s resource commands, together with the deployment files.
+	Helm HelmOptions
+	// ReconcileInterval keeps re-applying the drifted objects after resource apply, 0 disables it.
+	ReconcileInterval time.Duration
+	// Prune deletes the objects of the PruneKinds that match the PruneSelector and are no longer in the manifests.
+	Prune         bool
+	PruneSelector string
+	PruneKinds    []string
+	// PruneDryRun lists the objects Prune would delete instead of deleting them.
+	PruneDryRun bool
+	// ClusterNameSuffix is appended to CLUSTER_NAME, cluster create reuses an existing cluster with the suffixed name.
+	ClusterNameSuffix string
+	// FanOut creates one cluster per region with cluster create.
+	FanOut FanOut
+	// InfoFile is where cluster create writes the name, region and endpoint of the created clusters.
+	InfoFile string
+	// BootstrapFiles are applied by cluster create once the cluster is ready.
+	BootstrapFiles []string
+	// Provisioning records the duration of the cluster create and delete commands.
+	Provisioning ProvisioningMetrics
+	// RunSummary is written by cluster delete with the nodes, lifetime and estimated cost of the deleted clusters.
+	RunSummary RunSummaryOptions
+	// DescribeObject is printed as YAML by the describe command, as kind/name in DescribeNamespace.
+	DescribeObject    string
+	DescribeNamespace string
+	// ShowManagedFields keeps the managed fields in the describe output.
+	ShowManagedFields bool
+	// FieldOwnersObject is the object as kind/name in FieldOwnersNamespace whose field managers are printed by the owners command.
+	FieldOwnersObject    string
+	FieldOwnersNamespace string
+	// LogsPod is the pod whose logs are printed by the logs command, FollowLogs keeps streaming them.
+	LogsPod       string
+	LogsContainer string
+	LogsNamespace string
+	FollowLogs    bool
+	// WaitObject is waited for by the wait command as kind/name in WaitNamespace,
+	// until it has the WaitCondition in the condition=Type[=Status] format or WaitTimeout expires.
+	WaitObject    string
+	WaitNamespace string
+	WaitCondition string
+	WaitTimeout   time.Duration
+	// MetadataObject is labeled or annotated by the label and annotate commands, as kind/name in MetadataNamespace
+	// or as a kind with the MetadataSelector to select all matching objects.
+	MetadataObject    string
+	MetadataSelector  string
+	MetadataNamespace string
+	Labels            map[string]string
+	Annotations       map[string]string
+	// NodeName is the node cordoned, uncordoned or drained by the node commands,
+	// the drain evicts its pods until DrainTimeout expires.
+	NodeName     string
+	DrainTimeout time.Duration
+	// ReplacePool is the node pool replaced by nodes replace with a pool of the cluster file, ReplaceWith when set,
+	// its nodes are drained ReplaceSurge at a time, each until the DrainTimeout expires.
+	ReplacePool  string
+	ReplaceWith  string
+	ReplaceSurge int
+	// Replicas are set on all deployments, statefulsets and replicasets applied by the standalone apply,
+	// negative keeps the replicas of the files.
+	Replicas int32
+	// DeleteGracePeriod in seconds of the objects deleted by resource delete, negative keeps the grace period of the objects.
+	// ForceDelete deletes the pods of the deleted workloads immediately.
+	DeleteGracePeriod int64
+	ForceDelete       bool
+	// BulkDeleteQPS deletes all objects of the BulkDeleteKinds in the namespaces of the manifests at most this many per second,
+	// logging the progress every BulkDeleteProgress. 0 only deletes the objects of the manifests.
+	BulkDeleteQPS      float64
+	BulkDeleteKinds    []string
+	BulkDeleteProgress time.Duration
+	// KeepDependents keeps the load balancers, disks and addresses the cluster created for its objects when it is deleted.
+	KeepDependents bool
+	// Bastion is created by cluster create in the network of the cluster and deleted with it.
+	Bastion BastionOptions
+	// ScrapeFirewall rules are created by cluster create for the nodes of the cluster and deleted with it.
+	ScrapeFirewall ScrapeFirewall
+	// K8sProxy is the proxy of the k8s requests, e.g. the ssh tunnel to the bastion of a private cluster.
+	K8sProxy string
 }
 
 // NewDeploymentResource returns DeploymentResource with default values.
@@ -46,6 +162,14 @@ func NewDeploymentResource() *DeploymentResource {
 	return &DeploymentResource{
 		DeploymentFiles:    []string{},
 		FlagDeploymentVars: map[string]string{},
+		InjectLabels:       map[string]string{},
+		InjectAnnotations:  map[string]string{},
+		Labels:             map[string]string{},
+		Annotations:        map[string]string{},
+		RunSummary:         RunSummaryOptions{NodePrices: map[string]string{}},
+		Replicas:           -1,
+		DeleteGracePeriod:  -1,
+		K8sRetries:         DefaultRetryPolicies(),
 		DefaultDeploymentVars: map[string]string{
 			"NGINX_SERVICE_TYPE":          "LoadBalancer",
 			"LOADGEN_SCALE_UP_REPLICAS":   "10",
@@ -55,6 +179,14 @@ func NewDeploymentResource() *DeploymentResource {
 	}
 }
 
+// GracePeriod returns the DeleteGracePeriod, nil when it is negative.
+func (d *DeploymentResource) GracePeriod() *int64 {
+	if d.DeleteGracePeriod < 0 {
+		return nil
+	}
+	return &d.DeleteGracePeriod
+}
+
 // Resource holds the file content after parsing the template variables.
 type Resource struct {
 	FileName string
@@ -62,21 +194,34 @@ type Resource struct {
 }
 
 // RetryUntilTrue returns when there is an error or the requested operation returns true.
+// The progress is logged as structured lines so the provisioning time of every phase can be collected from the logs.
 func RetryUntilTrue(name string, retryCount int, fn func() (bool, error)) error {
+	start := time.Now()
+	var lastProgress time.Time
 	for i := 1; i <= retryCount; i++ {
 		time.Sleep(globalRetryTime)
 		if ready, err := fn(); err != nil {
+			log.Print(progressLine(name, "failed", time.Since(start), i, retryCount))
 			return err
 		} else if !ready {
-			log.Printf("Request for '%v' is in progress. Checking in %v", name, globalRetryTime)
+			if time.Since(lastProgress) >= ProgressInterval {
+				log.Print(progressLine(name, "in_progress", time.Since(start), i, retryCount))
+				lastProgress = time.Now()
+			}
 			continue
 		}
-		log.Printf("Request for '%v' is done!", name)
+		log.Print(progressLine(name, "done", time.Since(start), i, retryCount))
 		return nil
 	}
+	log.Print(progressLine(name, "timeout", time.Since(start), retryCount, retryCount))
 	return fmt.Errorf("Request for '%v' hasn't completed after retrying %d times", name, retryCount)
 }
 
+// progressLine formats the progress of an operation in the logfmt format.
+func progressLine(phase, status string, elapsed time.Duration, attempt, retryCount int) string {
+	return fmt.Sprintf("progress phase=%q status=%s elapsed=%s attempt=%d/%d", phase, status, elapsed.Round(time.Second), attempt, retryCount)
+}
+
 // applyTemplateVars applies golang templates to deployment files.
 func applyTemplateVars(content []byte, deploymentVars map[string]string) ([]byte, error) {
 	fileContentParsed := bytes.NewBufferString("")
@@ -98,11 +243,22 @@ func applyTemplateVars(content []byte, deploymentVars map[string]string) ([]byte
 
 // DeploymentsParse parses the deployment files and returns the result as bytes grouped by the filename.
 // Any variables passed to the cli will be replaced in the resources files following the golang text template format.
+//
+// Directories that contain a kustomization file are built with Kustomize instead of being walked for yaml files.
+// The template variables are applied to the Kustomize output, so a kustomization takes precedence
+// over the plain files in its directory but the variables can still be used in the overlays.
+// To skip the template parsing of a kustomization use the "noparse" suffix for its directory name.
 func DeploymentsParse(deploymentFiles []string, deploymentVars map[string]string) ([]Resource, error) {
 	var fileList []string
+	kustomizations := map[string]bool{}
 	for _, name := range deploymentFiles {
 		if file, err := os.Stat(name); err == nil && file.IsDir() {
 			if err := filepath.Walk(name, func(path string, f os.FileInfo, err error) error {
+				if f != nil && f.IsDir() && isKustomizeDir(path) {
+					fileList = append(fileList, path)
+					kustomizations[path] = true
+					return filepath.SkipDir
+				}
 				if filepath.Ext(path) == ".yaml" || filepath.Ext(path) == ".yml" {
 					fileList = append(fileList, path)
 				}
@@ -118,9 +274,18 @@ func DeploymentsParse(deploymentFiles []string, deploymentVars map[string]string
 	deploymentObjects := make([]Resource, 0)
 	for _, name := range fileList {
 		absFileName := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
-		content, err := os.ReadFile(name)
-		if err != nil {
-			log.Fatalf("Error reading file %v:%v", name, err)
+		var content []byte
+		var err error
+		if kustomizations[name] {
+			content, err = kustomizeBuild(name)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			content, err = os.ReadFile(name)
+			if err != nil {
+				log.Fatalf("Error reading file %v:%v", name, err)
+			}
 		}
 		// Don't parse file with the suffix "noparse".
 		if !strings.HasSuffix(absFileName, "noparse") {
@@ -134,6 +299,15 @@ func DeploymentsParse(deploymentFiles []string, deploymentVars map[string]string
 	return deploymentObjects, nil
 }
 
+// Truncate returns the first n bytes of s, used to quote the start of a section of a resource file in the errors.
+// Shorter strings are returned as they are.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
 // MergeDeploymentVars merges multiple maps based on the order.
 func MergeDeploymentVars(ms ...map[string]string) map[string]string {
 	res := map[string]string{}
@@ -0,0 +1,178 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// ProvisioningJob is the job label of the provisioning durations pushed to the Pushgateway.
+const ProvisioningJob = "infra"
+
+// ProvisioningMetrics records how long the cluster create and delete commands took, to track the provisioning time
+// across runs. It is opt-in, nothing is recorded unless a Pushgateway URL or a results file is set.
+type ProvisioningMetrics struct {
+	PushgatewayURL string
+	// ResultsFile gets a JSON line appended for every operation.
+	ResultsFile string
+}
+
+// ProvisioningResult is the duration of a completed cluster create or delete.
+type ProvisioningResult struct {
+	// Operation is create or delete.
+	Operation       string    `json:"operation"`
+	Provider        string    `json:"provider"`
+	Cluster         string    `json:"cluster"`
+	Region          string    `json:"region"`
+	Nodes           int       `json:"nodes"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Time            time.Time `json:"time"`
+}
+
+// Enabled returns whether the durations are recorded.
+func (m ProvisioningMetrics) Enabled() bool {
+	return m.PushgatewayURL != "" || m.ResultsFile != ""
+}
+
+// Record appends the result to the results file and pushes it to the Pushgateway, when set.
+// The push is grouped by the provider and the operation, so every push replaces the previous duration of the same operation
+// and the history is kept by Prometheus scraping the Pushgateway.
+func (m ProvisioningMetrics) Record(r ProvisioningResult) error {
+	if m.ResultsFile != "" {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return errors.Wrapf(err, "marshaling the provisioning result")
+		}
+		f, err := os.OpenFile(m.ResultsFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return errors.Wrapf(err, "opening the results file")
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			f.Close()
+			return errors.Wrapf(err, "writing the results file")
+		}
+		if err := f.Close(); err != nil {
+			return errors.Wrapf(err, "writing the results file")
+		}
+	}
+
+	if m.PushgatewayURL != "" {
+		duration := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "infra_provisioning_duration_seconds",
+			Help: "How long the last cluster create or delete took.",
+			ConstLabels: prometheus.Labels{
+				"region": r.Region,
+				"nodes":  strconv.Itoa(r.Nodes),
+			},
+		})
+		duration.Set(r.DurationSeconds)
+		grouping := map[string]string{"provider": r.Provider, "operation": r.Operation}
+		if err := pushGrouped(m.PushgatewayURL, ProvisioningJob, grouping, duration); err != nil {
+			return errors.Wrapf(err, "pushing the provisioning duration to the Pushgateway")
+		}
+	}
+	return nil
+}
+
+// RecordProvisioning records the duration since start when the metrics are enabled.
+// A failure to record is only logged, the operation itself has completed.
+func RecordProvisioning(m ProvisioningMetrics, r ProvisioningResult, start time.Time) {
+	if !m.Enabled() {
+		return
+	}
+	r.Time = time.Now()
+	d := r.Time.Sub(start)
+	r.DurationSeconds = d.Seconds()
+	if err := m.Record(r); err != nil {
+		log.Printf("Couldn't record the provisioning duration of %v cluster %q: %v", r.Operation, r.Cluster, err)
+		return
+	}
+	log.Printf("Recorded the provisioning duration of %v cluster %q: %s", r.Operation, r.Cluster, d.Round(time.Second))
+}
+
+// pushGrouped replaces the metrics of the grouping on the Pushgateway like push.Pusher.Push,
+// with the grouping labels in sorted order so the pushed path is the same on every run.
+func pushGrouped(pushgatewayURL, job string, grouping map[string]string, c prometheus.Collector) error {
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(c); err != nil {
+		return err
+	}
+	mfs, err := reg.Gather()
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	enc := expfmt.NewEncoder(buf, expfmt.FmtProtoDelim)
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(http.MethodPut, groupingURL(pushgatewayURL, job, grouping), buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", string(expfmt.FmtProtoDelim))
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(res.Body)
+		return errors.Errorf("unexpected status code %d while pushing to %s: %s", res.StatusCode, req.URL, body)
+	}
+	return nil
+}
+
+// groupingURL returns the Pushgateway URL of the job and the grouping labels sorted by name.
+func groupingURL(pushgatewayURL, job string, grouping map[string]string) string {
+	names := make([]string, 0, len(grouping))
+	for name := range grouping {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	components := append([]string{}, groupingComponent("job", job)...)
+	for _, name := range names {
+		components = append(components, groupingComponent(name, grouping[name])...)
+	}
+	return strings.TrimSuffix(pushgatewayURL, "/") + "/metrics/" + strings.Join(components, "/")
+}
+
+// groupingComponent encodes a grouping label like the Pushgateway client,
+// as base64 when the value is empty or contains a slash.
+func groupingComponent(name, value string) []string {
+	if value == "" {
+		return []string{name + "@base64", "="}
+	}
+	if strings.Contains(value, "/") {
+		return []string{name + "@base64", base64.RawURLEncoding.EncodeToString([]byte(value))}
+	}
+	return []string{name, url.QueryEscape(value)}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProvisioningMetrics(t *testing.T) {
+	var path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	results := filepath.Join(t.TempDir(), "results.jsonl")
+	m := ProvisioningMetrics{PushgatewayURL: srv.URL, ResultsFile: results}
+	if !m.Enabled() {
+		t.Fatal("want the metrics enabled")
+	}
+	r := ProvisioningResult{Operation: "create", Provider: "gke", Cluster: "prombench-10", Region: "europe-west1-b", Nodes: 3, DurationSeconds: 312.5}
+	for i := 0; i < 2; i++ {
+		if err := m.Record(r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if want := "/metrics/job/infra/operation/create/provider/gke"; path != want {
+		t.Errorf("want the push grouped at %v, got %v", want, path)
+	}
+	if !strings.Contains(body, "infra_provisioning_duration_seconds") {
+		t.Errorf("want the duration pushed, got %q", body)
+	}
+
+	out, err := os.ReadFile(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want a line appended per result, got %q", out)
+	}
+	var got ProvisioningResult
+	if err := json.Unmarshal([]byte(lines[1]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != r {
+		t.Errorf("want %+v, got %+v", r, got)
+	}
+
+	if (ProvisioningMetrics{}).Enabled() {
+		t.Error("want the metrics disabled by default")
+	}
+	m = ProvisioningMetrics{ResultsFile: filepath.Join(t.TempDir(), "missing", "results.jsonl")}
+	if err := m.Record(r); err == nil {
+		t.Error("expected an error writing to a missing directory")
+	}
+}
+
+func TestRecordProvisioning(t *testing.T) {
+	results := filepath.Join(t.TempDir(), "results.jsonl")
+	start := time.Now().Add(-time.Minute)
+	RecordProvisioning(ProvisioningMetrics{ResultsFile: results}, ProvisioningResult{Operation: "delete", Provider: "kind"}, start)
+
+	out, err := os.ReadFile(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ProvisioningResult
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.DurationSeconds < 60 || got.Time.Before(start) {
+		t.Errorf("want the duration since the start, got %+v", got)
+	}
+}
+
+func TestGroupingURL(t *testing.T) {
+	for _, tc := range []struct {
+		grouping map[string]string
+		want     string
+	}{
+		{grouping: map[string]string{"provider": "eks", "operation": "delete"}, want: "http://pgw:9091/metrics/job/infra/operation/delete/provider/eks"},
+		{grouping: map[string]string{"region": "", "cluster": "a/b"}, want: "http://pgw:9091/metrics/job/infra/cluster@base64/YS9i/region@base64/="},
+	} {
+		if got := groupingURL("http://pgw:9091/", "infra", tc.grouping); got != tc.want {
+			t.Errorf("want %v, got %v", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+)
+
+// SetProxy routes the cloud and k8s API requests through the proxy.
+// The GCP, AWS and k8s clients all read the proxy from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY env variables,
+// so the proxy is set in the env and must be set before the first request, when the clients read it.
+// An empty proxyURL keeps the proxy from the env.
+func SetProxy(proxyURL, noProxy string) error {
+	if noProxy != "" {
+		if err := os.Setenv("NO_PROXY", noProxy); err != nil {
+			return err
+		}
+	}
+	if proxyURL == "" {
+		if p := envProxy(); p != "" {
+			log.Printf("Using the proxy %v from the env", p)
+		}
+		return nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid proxy url %q, expected e.g. http://proxy.example.com:3128", proxyURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy url %q, the scheme must be http, https or socks5", proxyURL)
+	}
+	for _, env := range []string{"HTTP_PROXY", "HTTPS_PROXY"} {
+		if err := os.Setenv(env, proxyURL); err != nil {
+			return err
+		}
+	}
+	log.Printf("Using the proxy %v", u.Redacted())
+	return nil
+}
+
+// envProxy returns the proxy set in the env with the password redacted, the same env variables as the http package.
+func envProxy() string {
+	for _, env := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		if v := os.Getenv(env); v != "" {
+			if u, err := url.Parse(v); err == nil {
+				return u.Redacted()
+			}
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Quota is a cloud quota metric with the amount a cluster create request needs.
+type Quota struct {
+	Metric   string
+	Limit    float64
+	Usage    float64
+	Required float64
+}
+
+// Available returns the amount of the quota that isn't used yet.
+func (q Quota) Available() float64 {
+	return q.Limit - q.Usage
+}
+
+func (q Quota) String() string {
+	return fmt.Sprintf("%v: required %g, available %g", q.Metric, q.Required, q.Available())
+}
+
+// CheckQuotas returns an error that lists all quotas without enough room for the required amount.
+func CheckQuotas(quotas []Quota) error {
+	var shortfalls []string
+	for _, q := range quotas {
+		if q.Required > q.Available() {
+			shortfalls = append(shortfalls, fmt.Sprintf("%v: required %g, available %g (limit %g, usage %g), short by %g",
+				q.Metric, q.Required, q.Available(), q.Limit, q.Usage, q.Required-q.Available()))
+		}
+	}
+	if len(shortfalls) == 0 {
+		return nil
+	}
+	return fmt.Errorf("insufficient quota, use --skip-quota-check to create the cluster anyway:\n  %v", strings.Join(shortfalls, "\n  "))
+}
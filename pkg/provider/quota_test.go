@@ -0,0 +1,41 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckQuotas(t *testing.T) {
+	enough := []Quota{
+		{Metric: "CPUS", Limit: 24, Usage: 8, Required: 16},
+		{Metric: "IN_USE_ADDRESSES", Limit: 8, Required: 2},
+	}
+	if err := CheckQuotas(enough); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	short := append(enough, Quota{Metric: "N2_CPUS", Limit: 24, Usage: 20, Required: 16})
+	err := CheckQuotas(short)
+	if err == nil {
+		t.Fatal("expected an error for the N2_CPUS quota")
+	}
+	if !strings.Contains(err.Error(), "N2_CPUS: required 16, available 4 (limit 24, usage 20), short by 12") {
+		t.Errorf("expected the shortfall in the error, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "IN_USE_ADDRESSES") {
+		t.Errorf("expected only the short quotas in the error, got: %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ClusterInfo describes a created cluster in the info file of cluster create.
+type ClusterInfo struct {
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+	Region   string `json:"region"`
+	Endpoint string `json:"endpoint,omitempty"`
+	// Handle is what cluster wait takes to wait for a cluster create started with --wait=false, the endpoint isn't known yet then.
+	Handle string `json:"handle,omitempty"`
+	// ManagedPrometheus are the endpoints of the provider managed Prometheus, when enabled at create time.
+	ManagedPrometheus *ManagedPrometheus `json:"managedPrometheus,omitempty"`
+	// Error is why the cluster of a region of a fan-out wasn't created.
+	Error string `json:"error,omitempty"`
+}
+
+// WriteClusterInfo writes the clusters as a JSON array to the file.
+func WriteClusterInfo(file string, clusters []ClusterInfo) error {
+	out, err := json.MarshalIndent(clusters, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(out, '\n'), 0o644)
+}
+
+// ReadClusterInfo reads the clusters from an info file written by WriteClusterInfo.
+func ReadClusterInfo(file string) ([]ClusterInfo, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var clusters []ClusterInfo
+	if err := json.Unmarshal(content, &clusters); err != nil {
+		return nil, fmt.Errorf("parsing the cluster info file %v: %v", file, err)
+	}
+	return clusters, nil
+}
+
+// FanOut creates one cluster per region with the same cluster create command, e.g. for the benchmarks of a federated Prometheus.
+type FanOut struct {
+	Regions []string
+	// Parallelism is the number of regions created at the same time.
+	Parallelism int
+	// NameSuffix is the cluster name suffix the command of every region appends after the region.
+	NameSuffix string
+}
+
+// regionClusterName returns the name of the cluster of the region created by the command of the region.
+func (f FanOut) regionClusterName(clusterName, region string) string {
+	name := clusterName + "-" + region
+	if f.NameSuffix != "" {
+		name += "-" + f.NameSuffix
+	}
+	return name
+}
+
+// Validate checks the regions and the parallelism.
+func (f FanOut) Validate() error {
+	if f.Parallelism < 1 {
+		return fmt.Errorf("invalid regions parallelism %d, must be >= 1", f.Parallelism)
+	}
+	seen := map[string]bool{}
+	for _, r := range f.Regions {
+		if !clusterNameSuffixPattern.MatchString(r) {
+			return fmt.Errorf("invalid region %q, it must contain only lowercase letters, digits and hyphens", r)
+		}
+		if seen[r] {
+			return fmt.Errorf("the region %q is given more than once", r)
+		}
+		seen[r] = true
+	}
+	return nil
+}
+
+// SetFirstRegion sets the ZONE variable to the first region of the fan-out, so the command that starts the fan-out
+// checks and parses the files the same way as the commands of the regions. It never creates a cluster itself.
+func (f FanOut) SetFirstRegion(vars map[string]string) {
+	if len(f.Regions) > 0 {
+		vars["ZONE"] = f.Regions[0]
+	}
+}
+
+// fanOutFlags are the flags of the fan-out itself, left out of the commands of the regions.
+var fanOutFlags = []string{"--regions", "--regions-parallelism", "--info-file"}
+
+// RegionArgs returns the args of the cluster create command of a region: the args of the fan-out without its flags,
+// with the region as the ZONE variable and the cluster name suffixed with the region, e.g. prombench-europe-west1.
+func RegionArgs(args []string, region, clusterName, infoFile string) []string {
+	var res []string
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(args[i], "=")
+		if !contains(fanOutFlags, name) {
+			res = append(res, args[i])
+			continue
+		}
+		if !hasValue {
+			// Skip the value given as the next arg.
+			i++
+		}
+	}
+	return append(res,
+		"--vars", "ZONE:"+region,
+		"--vars", "CLUSTER_NAME:"+clusterName+"-"+region,
+		"--info-file", infoFile,
+	)
+}
+
+// RunFanOut runs the cluster create command of every region as a separate process of the same binary,
+// at most Parallelism at a time, and writes the clusters of all regions to the info file.
+// The output of every region is prefixed with the region. The regions are independent,
+// a failed region doesn't stop the others and the returned error lists all failed regions.
+func RunFanOut(f FanOut, provider, clusterName, infoFile string) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "fan-out")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	results := make([][]ClusterInfo, len(f.Regions))
+	sem := make(chan struct{}, f.Parallelism)
+	var wg sync.WaitGroup
+	for i, region := range f.Regions {
+		wg.Add(1)
+		go func(i int, region string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			regionInfo := filepath.Join(dir, region+".json")
+			log.Printf("Cluster create started in region %v", region)
+			cmd := exec.Command(os.Args[0], RegionArgs(os.Args[1:], region, clusterName, regionInfo)...)
+			stdout := &prefixWriter{prefix: "[" + region + "] ", w: os.Stdout}
+			stderr := &prefixWriter{prefix: "[" + region + "] ", w: os.Stderr}
+			cmd.Stdout, cmd.Stderr = stdout, stderr
+			runErr := cmd.Run()
+			stdout.flush()
+			stderr.flush()
+			clusters, err := ReadClusterInfo(regionInfo)
+			if runErr == nil && err == nil {
+				log.Printf("Cluster create completed in region %v", region)
+				results[i] = clusters
+				return
+			}
+			if runErr == nil {
+				runErr = err
+			}
+			log.Printf("Cluster create failed in region %v: %v", region, runErr)
+			results[i] = []ClusterInfo{{Provider: provider, Name: f.regionClusterName(clusterName, region), Region: region, Error: runErr.Error()}}
+		}(i, region)
+	}
+	wg.Wait()
+	return fanOutResult(results, infoFile)
+}
+
+// fanOutResult writes the clusters of the regions to the info file and returns the aggregated failures.
+func fanOutResult(results [][]ClusterInfo, infoFile string) error {
+	var all []ClusterInfo
+	var failed []string
+	for _, clusters := range results {
+		for _, c := range clusters {
+			all = append(all, c)
+			if c.Error != "" {
+				failed = append(failed, fmt.Sprintf("%v: %v", c.Region, c.Error))
+			}
+		}
+	}
+	if infoFile != "" {
+		if err := WriteClusterInfo(infoFile, all); err != nil {
+			return fmt.Errorf("writing the cluster info file: %v", err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("cluster create failed in %d of %d regions, the other clusters were created - %v", len(failed), len(results), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// prefixWriter prefixes every line written to w, e.g. with the region of a fan-out.
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+
+	mu sync.Mutex
+	// partial is the last line while it isn't complete.
+	partial []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.partial = append(p.partial, b...)
+	for {
+		i := bytes.IndexByte(p.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(p.w, "%s%s", p.prefix, p.partial[:i+1]); err != nil {
+			return 0, err
+		}
+		p.partial = p.partial[i+1:]
+	}
+	return len(b), nil
+}
+
+// flush writes the last line when it doesn't end with a newline.
+func (p *prefixWriter) flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.partial) > 0 {
+		fmt.Fprintf(p.w, "%s%s\n", p.prefix, p.partial)
+		p.partial = nil
+	}
+}
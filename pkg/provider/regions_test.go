@@ -0,0 +1,108 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegionArgs(t *testing.T) {
+	args := []string{
+		"gke", "cluster", "create", "-a", "sa.json", "-v", "CLUSTER_NAME:prombench",
+		"--regions", "europe-west1", "--regions=us-east1", "--regions-parallelism=2", "--info-file", "clusters.json",
+		"-f", "cluster.yaml", "--cluster-name-suffix=1234",
+	}
+	got := RegionArgs(args, "europe-west1", "prombench", "/tmp/europe-west1.json")
+	want := []string{
+		"gke", "cluster", "create", "-a", "sa.json", "-v", "CLUSTER_NAME:prombench",
+		"-f", "cluster.yaml", "--cluster-name-suffix=1234",
+		"--vars", "ZONE:europe-west1",
+		"--vars", "CLUSTER_NAME:prombench-europe-west1",
+		"--info-file", "/tmp/europe-west1.json",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestFanOutValidate(t *testing.T) {
+	if err := (FanOut{Regions: []string{"europe-west1", "us-east1"}, Parallelism: 2}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, tc := range []struct {
+		f       FanOut
+		wantErr string
+	}{
+		{f: FanOut{Regions: []string{"europe-west1"}}, wantErr: "invalid regions parallelism 0"},
+		{f: FanOut{Regions: []string{"Europe_West1"}, Parallelism: 1}, wantErr: `invalid region "Europe_West1"`},
+		{f: FanOut{Regions: []string{"us-east1", "us-east1"}, Parallelism: 1}, wantErr: `the region "us-east1" is given more than once`},
+	} {
+		if err := tc.f.Validate(); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%+v: want an error with %q, got %v", tc.f, tc.wantErr, err)
+		}
+	}
+
+	vars := map[string]string{"ZONE": "asia-east1"}
+	FanOut{Regions: []string{"europe-west1", "us-east1"}}.SetFirstRegion(vars)
+	if vars["ZONE"] != "europe-west1" {
+		t.Errorf("want ZONE set to the first region, got %v", vars["ZONE"])
+	}
+	if name := (FanOut{NameSuffix: "1234"}).regionClusterName("prombench", "us-east1"); name != "prombench-us-east1-1234" {
+		t.Errorf("want the suffix after the region, got %v", name)
+	}
+}
+
+func TestFanOutResult(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "clusters.json")
+	results := [][]ClusterInfo{
+		{{Provider: "gke", Name: "prombench-europe-west1", Region: "europe-west1", Endpoint: "203.0.113.10"}},
+		{{Provider: "gke", Name: "prombench-us-east1", Region: "us-east1", Error: "exit status 1"}},
+		{{Provider: "gke", Name: "prombench-asia-east1", Region: "asia-east1", Endpoint: "203.0.113.30"}},
+	}
+	err := fanOutResult(results, file)
+	if err == nil || !strings.Contains(err.Error(), "cluster create failed in 1 of 3 regions, the other clusters were created - us-east1: exit status 1") {
+		t.Errorf("want the failed region in the error, got %v", err)
+	}
+	clusters, err := ReadClusterInfo(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ClusterInfo{results[0][0], results[1][0], results[2][0]}
+	if !reflect.DeepEqual(want, clusters) {
+		t.Errorf("want %+v, got %+v", want, clusters)
+	}
+
+	if err := fanOutResult(results[:1], ""); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &prefixWriter{prefix: "[us-east1] ", w: &out}
+	for _, s := range []string{"Cluster create ", "request\nCreating ", "cluster\n", "done"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.flush()
+	want := "[us-east1] Cluster create request\n[us-east1] Creating cluster\n[us-east1] done\n"
+	if out.String() != want {
+		t.Errorf("want %q, got %q", want, out.String())
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryOperation is a class of operations that share a retry policy.
+type RetryOperation string
+
+// The operation classes of the k8s requests, by the HTTP method of the request.
+const (
+	// RetryRead is for get, list and watch requests, they have no side effects and are retried aggressively.
+	RetryRead RetryOperation = "read"
+	// RetryCreate is for create requests, a retried create can fail as already existing so they are retried cautiously.
+	RetryCreate RetryOperation = "create"
+	// RetryUpdate is for update and patch requests.
+	RetryUpdate RetryOperation = "update"
+	// RetryDelete is for delete requests.
+	RetryDelete RetryOperation = "delete"
+)
+
+var retryOperations = []RetryOperation{RetryRead, RetryCreate, RetryUpdate, RetryDelete}
+
+// RetryPolicy is how the operations of a class are retried after a transient error.
+// The delay starts at BaseDelay and doubles after every attempt up to MaxDelay,
+// Jitter is the fraction of the delay that is randomized so concurrent clients don't retry in lockstep.
+type RetryPolicy struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Jitter    float64
+}
+
+// Delay returns the delay before the attempt following the given attempt, r is a random number in [0, 1).
+func (p RetryPolicy) Delay(attempt int, r float64) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d - time.Duration(float64(d)*p.Jitter*r)
+}
+
+func (p RetryPolicy) validate() error {
+	switch {
+	case p.Attempts < 1:
+		return fmt.Errorf("attempts %d must be >= 1", p.Attempts)
+	case p.BaseDelay < 0 || p.MaxDelay < p.BaseDelay:
+		return fmt.Errorf("the base delay %s must be >= 0 and not above the max delay %s", p.BaseDelay, p.MaxDelay)
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("jitter %v must be between 0 and 1", p.Jitter)
+	}
+	return nil
+}
+
+func (p RetryPolicy) String() string {
+	return fmt.Sprintf("attempts=%d,base-delay=%s,max-delay=%s,jitter=%v", p.Attempts, p.BaseDelay, p.MaxDelay, p.Jitter)
+}
+
+// RetryPolicies are the retry policies by operation class.
+// It is a repeatable flag value in the operation:key=value,... format, e.g. read:attempts=10,max-delay=10s,
+// the keys that aren't set keep the value of the current policy.
+type RetryPolicies map[RetryOperation]RetryPolicy
+
+// DefaultRetryPolicies retry the transient errors of the reads quickly and often
+// and give the api server more time between fewer attempts for the writes.
+func DefaultRetryPolicies() RetryPolicies {
+	return RetryPolicies{
+		RetryRead:   {Attempts: 5, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second, Jitter: 0.2},
+		RetryCreate: {Attempts: 2, BaseDelay: time.Second, MaxDelay: 10 * time.Second, Jitter: 0.2},
+		RetryUpdate: {Attempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second, Jitter: 0.2},
+		RetryDelete: {Attempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second, Jitter: 0.2},
+	}
+}
+
+// Set parses a policy in the operation:key=value,... format and sets it for the operation.
+// The keys are attempts, base-delay, max-delay and jitter.
+func (p RetryPolicies) Set(value string) error {
+	op, spec, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("invalid retry policy %q, must be operation:key=value,... e.g. read:attempts=10", value)
+	}
+	operation := RetryOperation(op)
+	known := false
+	for _, o := range retryOperations {
+		known = known || o == operation
+	}
+	if !known {
+		return fmt.Errorf("unknown retry operation %q, must be one of %v", op, retryOperations)
+	}
+
+	policy := p[operation]
+	for _, kv := range strings.Split(spec, ",") {
+		key, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return fmt.Errorf("invalid retry policy field %q of %v, must be key=value", kv, op)
+		}
+		var err error
+		switch key {
+		case "attempts":
+			policy.Attempts, err = strconv.Atoi(v)
+		case "base-delay":
+			policy.BaseDelay, err = time.ParseDuration(v)
+		case "max-delay":
+			policy.MaxDelay, err = time.ParseDuration(v)
+		case "jitter":
+			policy.Jitter, err = strconv.ParseFloat(v, 64)
+		default:
+			return fmt.Errorf("unknown retry policy field %q of %v, must be attempts, base-delay, max-delay or jitter", key, op)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid retry policy field %q of %v: %v", kv, op, err)
+		}
+	}
+	if err := policy.validate(); err != nil {
+		return fmt.Errorf("invalid retry policy of %v: %v", op, err)
+	}
+	p[operation] = policy
+	return nil
+}
+
+func (p RetryPolicies) String() string {
+	ops := make([]string, 0, len(p))
+	for op := range p {
+		ops = append(ops, string(op))
+	}
+	sort.Strings(ops)
+	policies := make([]string, 0, len(ops))
+	for _, op := range ops {
+		policies = append(policies, op+":"+p[RetryOperation(op)].String())
+	}
+	return strings.Join(policies, " ")
+}
+
+// IsCumulative makes the flag repeatable, once per operation.
+func (p RetryPolicies) IsCumulative() bool {
+	return true
+}
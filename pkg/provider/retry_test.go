@@ -0,0 +1,66 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{Attempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		if got := p.Delay(attempt, 0); got != want {
+			t.Errorf("attempt %d: want %s, got %s", attempt, want, got)
+		}
+	}
+	if got := p.Delay(1, 0.5); got != 75*time.Millisecond {
+		t.Errorf("want the jitter to take up to half of the delay, got %s", got)
+	}
+}
+
+func TestRetryPoliciesSet(t *testing.T) {
+	p := DefaultRetryPolicies()
+	if err := p.Set("read:attempts=10,max-delay=30s"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := RetryPolicy{Attempts: 10, BaseDelay: 200 * time.Millisecond, MaxDelay: 30 * time.Second, Jitter: 0.2}
+	if p[RetryRead] != want {
+		t.Errorf("want the set fields changed and the others kept, got %v", p[RetryRead])
+	}
+	if p[RetryCreate] != DefaultRetryPolicies()[RetryCreate] {
+		t.Errorf("want the other operations kept, got %v", p[RetryCreate])
+	}
+
+	for _, invalid := range []string{
+		"read",
+		"list:attempts=3",
+		"read:attempts",
+		"read:timeout=1s",
+		"read:attempts=0",
+		"read:base-delay=1m",
+		"delete:jitter=2",
+		"update:max-delay=soon",
+	} {
+		if err := DefaultRetryPolicies().Set(invalid); err == nil {
+			t.Errorf("%v: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ClusterSpec describes a whole cluster in a single file passed to cluster create with --spec-file.
+// The create flags override the spec fields, a repeatable flag replaces the whole list of the spec.
+type ClusterSpec struct {
+	// Name and Region set the CLUSTER_NAME and ZONE variables, GKE also accepts a zone as the region.
+	Name   string `yaml:"name"`
+	Region string `yaml:"region"`
+	// Vars are added to the -v variables.
+	Vars map[string]string `yaml:"vars"`
+	// Files are the cluster deployment files used when -f isn't set.
+	Files []string `yaml:"files"`
+
+	Version        string   `yaml:"version"`
+	ReleaseChannel string   `yaml:"releaseChannel"`
+	ImageType      string   `yaml:"imageType"`
+	Zones          []string `yaml:"zones"`
+
+	NodePools      []SpecNodePool `yaml:"nodePools"`
+	SystemNodePool *SpecNodePool  `yaml:"systemNodePool"`
+	Addons         SpecAddons     `yaml:"addons"`
+	Networking     SpecNetworking `yaml:"networking"`
+	// Bootstrap are the manifest files or folders applied once the cluster is ready.
+	Bootstrap []string `yaml:"bootstrap"`
+
+	nodePools   NodePoolSpecs
+	autoscaling NodePoolAutoscalings
+}
+
+// SpecNodePool is a node pool of a cluster spec, with the fields of the --node-pool flag.
+type SpecNodePool struct {
+	Name        string            `yaml:"name"`
+	MachineType string            `yaml:"machineType"`
+	Count       int32             `yaml:"count"`
+	Labels      map[string]string `yaml:"labels"`
+	// Taints are in the kubectl format key=value:Effect.
+	Taints      []string         `yaml:"taints"`
+	Autoscaling *SpecAutoscaling `yaml:"autoscaling"`
+}
+
+// SpecAutoscaling holds the cluster autoscaler node bounds of a spec node pool.
+type SpecAutoscaling struct {
+	Min int32 `yaml:"min"`
+	Max int32 `yaml:"max"`
+}
+
+// SpecAddons enables the cluster integrations, logging and monitoring are enabled or disabled.
+type SpecAddons struct {
+	Logging          string `yaml:"logging"`
+	Monitoring       string `yaml:"monitoring"`
+	WorkloadIdentity bool   `yaml:"workloadIdentity"`
+}
+
+// SpecNetworking holds the IP ranges and the max pods per node of the cluster.
+type SpecNetworking struct {
+	PodCIDR        string `yaml:"podCIDR"`
+	ServiceCIDR    string `yaml:"serviceCIDR"`
+	MaxPodsPerNode int64  `yaml:"maxPodsPerNode"`
+}
+
+// LoadClusterSpec reads and validates a cluster spec.
+// Unknown fields are rejected and the relative files and bootstrap paths are resolved from the directory of the spec.
+func LoadClusterSpec(filename string) (*ClusterSpec, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading the cluster spec: %w", err)
+	}
+	s := &ClusterSpec{}
+	if err := yaml.UnmarshalStrict(content, s); err != nil {
+		return nil, fmt.Errorf("parsing the cluster spec %v: %w", filename, err)
+	}
+	dir := filepath.Dir(filename)
+	for _, paths := range []*[]string{&s.Files, &s.Bootstrap} {
+		for i, p := range *paths {
+			if !filepath.IsAbs(p) {
+				(*paths)[i] = filepath.Join(dir, p)
+			}
+		}
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid cluster spec %v: %w", filename, err)
+	}
+	return s, nil
+}
+
+func (s *ClusterSpec) validate() error {
+	switch s.ReleaseChannel {
+	case "", "rapid", "regular", "stable", "none":
+	default:
+		return fmt.Errorf("invalid releaseChannel %q, must be rapid, regular, stable or none", s.ReleaseChannel)
+	}
+	for _, a := range []struct{ name, value string }{{"logging", s.Addons.Logging}, {"monitoring", s.Addons.Monitoring}} {
+		if a.value != "" && a.value != "enabled" && a.value != "disabled" {
+			return fmt.Errorf("invalid addons.%v %q, must be enabled or disabled", a.name, a.value)
+		}
+	}
+	if err := ValidateCIDRs(s.Networking.PodCIDR, s.Networking.ServiceCIDR); err != nil {
+		return err
+	}
+	if s.Networking.MaxPodsPerNode < 0 {
+		return fmt.Errorf("invalid networking.maxPodsPerNode %d, must be >= 0", s.Networking.MaxPodsPerNode)
+	}
+	for _, p := range append(append([]string{}, s.Files...), s.Bootstrap...) {
+		if _, err := os.Stat(p); err != nil {
+			return err
+		}
+	}
+
+	var names []string
+	for i, p := range s.NodePools {
+		if p.Name == "" {
+			return fmt.Errorf("node pool %d is missing a name", i)
+		}
+		spec, err := ParseNodePoolSpec(p.flagValue())
+		if err != nil {
+			return err
+		}
+		s.nodePools = append(s.nodePools, spec)
+		names = append(names, p.Name)
+	}
+	if p := s.SystemNodePool; p != nil {
+		if len(p.Taints) > 0 {
+			return fmt.Errorf("the system node pool can't have taints, the kube-system workloads don't tolerate them")
+		}
+		spec, err := ParseSystemNodePool(s.SystemNodePoolFlag())
+		if err != nil {
+			return err
+		}
+		names = append(names, spec.Name)
+	}
+	if err := ValidateNodePoolNames(names); err != nil {
+		return err
+	}
+
+	pools := append([]SpecNodePool{}, s.NodePools...)
+	if s.SystemNodePool != nil {
+		pools = append(pools, *s.SystemNodePool)
+		if pools[len(pools)-1].Name == "" {
+			pools[len(pools)-1].Name = DefaultSystemPoolName
+		}
+	}
+	for _, p := range pools {
+		if p.Autoscaling == nil {
+			continue
+		}
+		a, err := ParseNodePoolAutoscaling(fmt.Sprintf("name=%v,min=%d,max=%d", p.Name, p.Autoscaling.Min, p.Autoscaling.Max))
+		if err != nil {
+			return err
+		}
+		count := p.Count
+		if count == 0 {
+			count = 1
+		}
+		if err := a.Contains(count); err != nil {
+			return err
+		}
+		s.autoscaling = append(s.autoscaling, a)
+	}
+	return nil
+}
+
+// flagValue returns the node pool in the format of the --node-pool flag,
+// so the spec pools are parsed and validated the same way.
+func (p SpecNodePool) flagValue() string {
+	var fields []string
+	if p.Name != "" {
+		fields = append(fields, "name="+p.Name)
+	}
+	if p.MachineType != "" {
+		fields = append(fields, "machine-type="+p.MachineType)
+	}
+	if p.Count != 0 {
+		fields = append(fields, "count="+strconv.FormatInt(int64(p.Count), 10))
+	}
+	for k, v := range p.Labels {
+		fields = append(fields, "label="+k+"="+v)
+	}
+	for _, t := range p.Taints {
+		fields = append(fields, "taint="+t)
+	}
+	return strings.Join(fields, ",")
+}
+
+// NodePoolSpecs returns the node pools of the spec.
+func (s *ClusterSpec) NodePoolSpecs() NodePoolSpecs {
+	return s.nodePools
+}
+
+// NodePoolAutoscalings returns the autoscaling bounds of the node pools and the system node pool of the spec.
+func (s *ClusterSpec) NodePoolAutoscalings() NodePoolAutoscalings {
+	return s.autoscaling
+}
+
+// SystemNodePoolFlag returns the system node pool in the format of the --system-node-pool flag,
+// empty when the spec has no system node pool.
+func (s *ClusterSpec) SystemNodePoolFlag() string {
+	if s.SystemNodePool == nil {
+		return ""
+	}
+	return s.SystemNodePool.flagValue()
+}
+
+// ApplyDeploymentResource adds the name, the region and the vars of the spec to the -v variables
+// and sets the deployment and bootstrap files when they aren't set from the cli.
+func (s *ClusterSpec) ApplyDeploymentResource(dr *DeploymentResource) {
+	vars := map[string]string{}
+	for k, v := range s.Vars {
+		vars[k] = v
+	}
+	if s.Name != "" {
+		vars["CLUSTER_NAME"] = s.Name
+	}
+	if s.Region != "" {
+		vars["ZONE"] = s.Region
+	}
+	for k, v := range vars {
+		if _, ok := dr.FlagDeploymentVars[k]; !ok {
+			dr.FlagDeploymentVars[k] = v
+		}
+	}
+	if len(dr.DeploymentFiles) == 0 {
+		dr.DeploymentFiles = s.Files
+	}
+	if len(dr.BootstrapFiles) == 0 {
+		dr.BootstrapFiles = s.Bootstrap
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testClusterSpec = `
+name: prombench-10
+region: europe-west1-b
+vars:
+  GKE_PROJECT_ID: test
+files: [cluster.yaml]
+releaseChannel: stable
+nodePools:
+- name: prometheus
+  machineType: n1-highmem-8
+  count: 2
+  labels: {isolation: prometheus}
+  taints: ["dedicated=prometheus:NoSchedule"]
+  autoscaling: {min: 1, max: 5}
+systemNodePool:
+  machineType: e2-standard-2
+  autoscaling: {min: 1, max: 2}
+addons:
+  logging: disabled
+networking:
+  podCIDR: 10.4.0.0/14
+  serviceCIDR: 10.8.0.0/20
+bootstrap: [bootstrap]
+`
+
+func writeClusterSpec(t *testing.T, spec string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"spec.yaml":                spec,
+		"cluster.yaml":             "cluster: {}\n",
+		"bootstrap/namespace.yaml": "kind: Namespace\n",
+	})
+	return filepath.Join(dir, "spec.yaml")
+}
+
+func TestLoadClusterSpec(t *testing.T) {
+	filename := writeClusterSpec(t, testClusterSpec)
+	s, err := LoadClusterSpec(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dir := filepath.Dir(filename)
+
+	expPools := NodePoolSpecs{{
+		Name:        "prometheus",
+		MachineType: "n1-highmem-8",
+		Count:       2,
+		Labels:      map[string]string{"isolation": "prometheus"},
+		Taints:      []NodePoolTaint{{Key: "dedicated", Value: "prometheus", Effect: TaintEffectNoSchedule}},
+	}}
+	if !reflect.DeepEqual(expPools, s.NodePoolSpecs()) {
+		t.Errorf("\nexpect %#v\ngot %#v", expPools, s.NodePoolSpecs())
+	}
+	expAutoscaling := NodePoolAutoscalings{{Name: "prometheus", Min: 1, Max: 5}, {Name: DefaultSystemPoolName, Min: 1, Max: 2}}
+	if !reflect.DeepEqual(expAutoscaling, s.NodePoolAutoscalings()) {
+		t.Errorf("\nexpect %#v\ngot %#v", expAutoscaling, s.NodePoolAutoscalings())
+	}
+	if exp := "machine-type=e2-standard-2"; s.SystemNodePoolFlag() != exp {
+		t.Errorf("expect system node pool %q, got %q", exp, s.SystemNodePoolFlag())
+	}
+
+	// The -v vars and the cli files take precedence over the spec.
+	dr := NewDeploymentResource()
+	dr.FlagDeploymentVars["CLUSTER_NAME"] = "prombench-11"
+	dr.BootstrapFiles = []string{"crds"}
+	s.ApplyDeploymentResource(dr)
+	expVars := map[string]string{"CLUSTER_NAME": "prombench-11", "ZONE": "europe-west1-b", "GKE_PROJECT_ID": "test"}
+	if !reflect.DeepEqual(expVars, dr.FlagDeploymentVars) {
+		t.Errorf("\nexpect %v\ngot %v", expVars, dr.FlagDeploymentVars)
+	}
+	if exp := []string{filepath.Join(dir, "cluster.yaml")}; !reflect.DeepEqual(exp, dr.DeploymentFiles) {
+		t.Errorf("expect deployment files %v, got %v", exp, dr.DeploymentFiles)
+	}
+	if exp := []string{"crds"}; !reflect.DeepEqual(exp, dr.BootstrapFiles) {
+		t.Errorf("expect bootstrap files %v, got %v", exp, dr.BootstrapFiles)
+	}
+}
+
+func TestLoadClusterSpecErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec string
+		err  string
+	}{
+		{name: "unknown field", spec: "name: test\nnodepools: []\n", err: "field nodepools not found"},
+		{name: "release channel", spec: "releaseChannel: beta\n", err: "invalid releaseChannel"},
+		{name: "logging", spec: "addons: {logging: on}\n", err: "invalid addons.logging"},
+		{name: "overlapping cidrs", spec: "networking: {podCIDR: 10.0.0.0/8, serviceCIDR: 10.8.0.0/20}\n", err: "overlap"},
+		{name: "missing file", spec: "files: [missing.yaml]\n", err: "missing.yaml"},
+		{name: "pool without name", spec: "nodePools: [{machineType: n1-standard-2}]\n", err: "missing a name"},
+		{name: "pool without machine type", spec: "nodePools: [{name: loadgen}]\n", err: "missing a machine-type"},
+		{name: "taint", spec: "nodePools: [{name: loadgen, machineType: n1-standard-2, taints: [dedicated=loadgen]}]\n", err: "invalid node pool taint"},
+		{name: "duplicate pool", spec: "nodePools: [{name: system, machineType: n1-standard-2}]\nsystemNodePool: {machineType: e2-standard-2}\n", err: "duplicate node pool name"},
+		{name: "system pool taint", spec: "systemNodePool: {machineType: e2-standard-2, taints: [a=b:NoSchedule]}\n", err: "can't have taints"},
+		{name: "autoscaling bounds", spec: "nodePools: [{name: loadgen, machineType: n1-standard-2, count: 6, autoscaling: {min: 1, max: 5}}]\n", err: "outside the autoscaling bounds"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadClusterSpec(writeClusterSpec(t, tc.spec))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expect an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	if _, err := LoadClusterSpec(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expect a not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SSHPublicKey is an OpenSSH public key, e.g. from ~/.ssh/id_ed25519.pub.
+type SSHPublicKey struct {
+	Type string
+	// Data is the base64 encoded key blob.
+	Data string
+}
+
+// sshKeyTypes are the supported public key types.
+var sshKeyTypes = []string{"ssh-ed25519", "ssh-rsa", "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521"}
+
+// LoadSSHPublicKey reads the OpenSSH public key from a file, the comment of the key is dropped.
+func LoadSSHPublicKey(filename string) (SSHPublicKey, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return SSHPublicKey{}, fmt.Errorf("reading the ssh public key: %w", err)
+	}
+	k, err := ParseSSHPublicKey(string(content))
+	if err != nil {
+		return SSHPublicKey{}, fmt.Errorf("ssh public key file %v: %w", filename, err)
+	}
+	return k, nil
+}
+
+// ParseSSHPublicKey parses a public key in the authorized_keys format, e.g. ssh-ed25519 AAAA... user@host.
+// Private keys are rejected so they are never sent to a cloud provider by mistake.
+func ParseSSHPublicKey(key string) (SSHPublicKey, error) {
+	key = strings.TrimSpace(key)
+	if strings.Contains(key, "PRIVATE KEY") {
+		return SSHPublicKey{}, fmt.Errorf("this is a private key, use the public key, e.g. the .pub file")
+	}
+	if strings.Contains(key, "\n") {
+		return SSHPublicKey{}, fmt.Errorf("expected a single public key")
+	}
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return SSHPublicKey{}, fmt.Errorf("invalid public key, expected the type and the key, e.g. ssh-ed25519 AAAA...")
+	}
+	k := SSHPublicKey{Type: fields[0], Data: fields[1]}
+	supported := false
+	for _, t := range sshKeyTypes {
+		supported = supported || k.Type == t
+	}
+	if !supported {
+		return SSHPublicKey{}, fmt.Errorf("unsupported key type %q, must be one of %v", k.Type, sshKeyTypes)
+	}
+	blob, err := base64.StdEncoding.DecodeString(k.Data)
+	if err != nil {
+		return SSHPublicKey{}, fmt.Errorf("invalid %v key data: %w", k.Type, err)
+	}
+	// The blob starts with the length prefixed key type.
+	if len(blob) < 4 || int(binary.BigEndian.Uint32(blob)) > len(blob)-4 || string(blob[4:4+binary.BigEndian.Uint32(blob)]) != k.Type {
+		return SSHPublicKey{}, fmt.Errorf("invalid key data, it isn't a %v key", k.Type)
+	}
+	return k, nil
+}
+
+// String returns the key in the authorized_keys format, without a comment.
+func (k SSHPublicKey) String() string {
+	return k.Type + " " + k.Data
+}
+
+// MD5Fingerprint returns the MD5 fingerprint of the key as colon separated hex, e.g. 43:51:43:a1:...
+func (k SSHPublicKey) MD5Fingerprint() string {
+	blob, _ := base64.StdEncoding.DecodeString(k.Data)
+	sum := md5.Sum(blob)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// SHA256Fingerprint returns the unpadded base64 SHA256 fingerprint of the key, the same as ssh-keygen -l without the SHA256: prefix.
+func (k SSHPublicKey) SHA256Fingerprint() string {
+	blob, _ := base64.StdEncoding.DecodeString(k.Data)
+	sum := sha256.Sum256(blob)
+	return base64.RawStdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RunSummarySchemaVersion is the version of the run summary file format,
+// increased only when a field is removed or its meaning changes.
+const RunSummarySchemaVersion = 1
+
+// RunSummaryOptions configure the summary of the infra of a run written by cluster delete.
+type RunSummaryOptions struct {
+	// File is where the summary of the deleted clusters is written, nothing is written when empty.
+	File string
+	// NodePrices are the hourly prices of a node by machine or instance type, as decimal numbers.
+	NodePrices map[string]string
+	// Labels are the keys of the cluster labels or tags copied to the summary, all of them when empty.
+	Labels []string
+}
+
+// Enabled returns whether the summary is written.
+func (o RunSummaryOptions) Enabled() bool {
+	return o.File != ""
+}
+
+// Validate checks the node prices, so that a typo fails before the clusters are deleted.
+func (o RunSummaryOptions) Validate() error {
+	if !o.Enabled() {
+		if len(o.NodePrices) > 0 || len(o.Labels) > 0 {
+			return errors.New("--node-price and --summary-label require --summary-file")
+		}
+		return nil
+	}
+	_, err := o.prices()
+	return err
+}
+
+func (o RunSummaryOptions) prices() (map[string]float64, error) {
+	prices := map[string]float64{}
+	for machineType, value := range o.NodePrices {
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || price < 0 || math.IsInf(price, 0) {
+			return nil, fmt.Errorf("invalid node price %q of %v, expected the hourly price as a decimal number >= 0", value, machineType)
+		}
+		prices[machineType] = price
+	}
+	return prices, nil
+}
+
+// RunSummaryFile is the format of the run summary file.
+type RunSummaryFile struct {
+	SchemaVersion int          `json:"schema_version"`
+	Clusters      []RunSummary `json:"clusters"`
+}
+
+// RunSummary is the infra of a deleted cluster over its lifetime.
+type RunSummary struct {
+	Provider string `json:"provider"`
+	Cluster  string `json:"cluster"`
+	Region   string `json:"region"`
+	// Labels are the labels or tags of the cluster selected by RunSummaryOptions.Labels, e.g. the run id or the PR number.
+	Labels map[string]string `json:"labels"`
+	Nodes  []NodeSummary     `json:"nodes"`
+	// Created is zero when the provider didn't report the creation time of the cluster.
+	Created         time.Time `json:"created"`
+	Deleted         time.Time `json:"deleted"`
+	LifetimeSeconds float64   `json:"lifetime_seconds"`
+	// EstimatedCost is the price of all nodes over the lifetime, nil when a machine type has no price.
+	EstimatedCost *float64 `json:"estimated_cost"`
+	// UnpricedMachineTypes are the machine types without a price, sorted.
+	UnpricedMachineTypes []string `json:"unpriced_machine_types,omitempty"`
+}
+
+// NodeSummary is a node pool of a summarized cluster.
+type NodeSummary struct {
+	Pool        string `json:"pool"`
+	MachineType string `json:"machine_type"`
+	Count       int    `json:"count"`
+	// HourlyPrice is the price of one node, nil when the machine type has no price.
+	HourlyPrice *float64 `json:"hourly_price"`
+}
+
+// NewRunSummary returns the summary of a cluster deleted at deleted, with the labels selected by the options
+// and the cost of the nodes estimated with the node prices of the options.
+func NewRunSummary(o RunSummaryOptions, providerName, cluster, region string, labels map[string]string, nodes []NodeSummary, created, deleted time.Time) (RunSummary, error) {
+	prices, err := o.prices()
+	if err != nil {
+		return RunSummary{}, err
+	}
+	s := RunSummary{
+		Provider: providerName,
+		Cluster:  cluster,
+		Region:   region,
+		Labels:   map[string]string{},
+		Nodes:    []NodeSummary{},
+		Created:  created,
+		Deleted:  deleted,
+	}
+	for k, v := range labels {
+		s.Labels[k] = v
+	}
+	if len(o.Labels) > 0 {
+		s.Labels = map[string]string{}
+		for _, k := range o.Labels {
+			if v, ok := labels[k]; ok {
+				s.Labels[k] = v
+			}
+		}
+	}
+	if !created.IsZero() && deleted.After(created) {
+		s.LifetimeSeconds = math.Round(deleted.Sub(created).Seconds())
+	}
+
+	var (
+		hourly   float64
+		unpriced = map[string]bool{}
+	)
+	for _, n := range nodes {
+		if price, ok := prices[n.MachineType]; ok {
+			p := price
+			n.HourlyPrice = &p
+			hourly += price * float64(n.Count)
+		} else if n.Count > 0 {
+			unpriced[n.MachineType] = true
+		}
+		s.Nodes = append(s.Nodes, n)
+	}
+	for t := range unpriced {
+		s.UnpricedMachineTypes = append(s.UnpricedMachineTypes, t)
+	}
+	sort.Strings(s.UnpricedMachineTypes)
+	if len(unpriced) == 0 && !created.IsZero() {
+		cost := math.Round(hourly*s.LifetimeSeconds/36) / 100
+		s.EstimatedCost = &cost
+	}
+	return s, nil
+}
+
+// WriteRunSummaries writes the summaries of the clusters to the file.
+func WriteRunSummaries(file string, clusters []RunSummary) error {
+	if clusters == nil {
+		clusters = []RunSummary{}
+	}
+	out, err := json.MarshalIndent(RunSummaryFile{SchemaVersion: RunSummarySchemaVersion, Clusters: clusters}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(out, '\n'), 0o644)
+}
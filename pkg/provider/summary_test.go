@@ -0,0 +1,112 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewRunSummary(t *testing.T) {
+	created := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	deleted := created.Add(90 * time.Minute)
+	labels := map[string]string{"pr-number": "1234", "run-id": "abc", "team": "prometheus"}
+	nodes := []NodeSummary{
+		{Pool: "main", MachineType: "n1-standard-8", Count: 3},
+		{Pool: "prometheus", MachineType: "n1-highmem-16", Count: 2},
+	}
+	o := RunSummaryOptions{
+		File:       "summary.json",
+		NodePrices: map[string]string{"n1-standard-8": "0.38", "n1-highmem-16": "0.95"},
+		Labels:     []string{"pr-number", "run-id", "missing"},
+	}
+
+	s, err := NewRunSummary(o, "gke", "prombench-1234", "europe-west1-b", labels, nodes, created, deleted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"pr-number": "1234", "run-id": "abc"}; !reflect.DeepEqual(s.Labels, want) {
+		t.Errorf("want labels %v, got %v", want, s.Labels)
+	}
+	if s.LifetimeSeconds != 5400 {
+		t.Errorf("want a lifetime of 5400s, got %v", s.LifetimeSeconds)
+	}
+	// (3*0.38 + 2*0.95) * 1.5h
+	if s.EstimatedCost == nil || *s.EstimatedCost != 4.56 {
+		t.Errorf("want an estimated cost of 4.56, got %v", s.EstimatedCost)
+	}
+	if s.Nodes[1].HourlyPrice == nil || *s.Nodes[1].HourlyPrice != 0.95 {
+		t.Errorf("want the hourly price of the node pool, got %v", s.Nodes[1].HourlyPrice)
+	}
+
+	// All labels without a selection and no cost when a machine type has no price.
+	o.Labels = nil
+	delete(o.NodePrices, "n1-highmem-16")
+	s, err = NewRunSummary(o, "gke", "prombench-1234", "europe-west1-b", labels, nodes, created, deleted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(s.Labels, labels) {
+		t.Errorf("want all labels %v, got %v", labels, s.Labels)
+	}
+	if s.EstimatedCost != nil {
+		t.Errorf("want no estimated cost, got %v", *s.EstimatedCost)
+	}
+	if want := []string{"n1-highmem-16"}; !reflect.DeepEqual(s.UnpricedMachineTypes, want) {
+		t.Errorf("want unpriced machine types %v, got %v", want, s.UnpricedMachineTypes)
+	}
+}
+
+func TestRunSummaryOptionsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		o  RunSummaryOptions
+		ok bool
+	}{
+		{o: RunSummaryOptions{}, ok: true},
+		{o: RunSummaryOptions{File: "summary.json", NodePrices: map[string]string{"m5.xlarge": "0.192"}}, ok: true},
+		{o: RunSummaryOptions{File: "summary.json", NodePrices: map[string]string{"m5.xlarge": "cheap"}}},
+		{o: RunSummaryOptions{File: "summary.json", NodePrices: map[string]string{"m5.xlarge": "-1"}}},
+		{o: RunSummaryOptions{NodePrices: map[string]string{"m5.xlarge": "0.192"}}},
+		{o: RunSummaryOptions{Labels: []string{"run-id"}}},
+	} {
+		if err := tc.o.Validate(); tc.ok != (err == nil) {
+			t.Errorf("%+v: want valid %v, got error %v", tc.o, tc.ok, err)
+		}
+	}
+}
+
+func TestWriteRunSummaries(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "summary.json")
+	if err := WriteRunSummaries(file, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("invalid summary file: %v", err)
+	}
+	if got["schema_version"] != float64(RunSummarySchemaVersion) {
+		t.Errorf("want schema version %d, got %v", RunSummarySchemaVersion, got["schema_version"])
+	}
+	if clusters, ok := got["clusters"].([]interface{}); !ok || len(clusters) != 0 {
+		t.Errorf("want an empty clusters array, got %v", got["clusters"])
+	}
+}
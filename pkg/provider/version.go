@@ -0,0 +1,47 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strconv"
+	"strings"
+)
+
+// KubernetesMinorVersion returns the major and minor version of a k8s version string
+// as used by the cloud providers, e.g. 1.27, v1.27.3 or 1.27.3-gke.100.
+func KubernetesMinorVersion(version string) (major, minor int, ok bool) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// KubernetesVersionAtLeast returns true when the version is at least major.minor.
+// Versions that can't be parsed always return false.
+func KubernetesVersionAtLeast(version string, major, minor int) bool {
+	vMajor, vMinor, ok := KubernetesMinorVersion(version)
+	if !ok {
+		return false
+	}
+	return vMajor > major || (vMajor == major && vMinor >= minor)
+}
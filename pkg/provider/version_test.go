@@ -0,0 +1,37 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestKubernetesVersionAtLeast(t *testing.T) {
+	testCases := []struct {
+		version string
+		atLeast bool
+	}{
+		{"1.24", true},
+		{"1.27.3-gke.100", true},
+		{"v1.30.1", true},
+		{"2.0", true},
+		{"1.23.17-gke.1700", false},
+		{"1.9", false},
+		{"latest", false},
+		{"", false},
+	}
+	for _, tc := range testCases {
+		if got := KubernetesVersionAtLeast(tc.version, 1, 24); got != tc.atLeast {
+			t.Errorf("%q at least 1.24: want %v, got %v", tc.version, tc.atLeast, got)
+		}
+	}
+}
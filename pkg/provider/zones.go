@@ -0,0 +1,45 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "fmt"
+
+// ValidateZones returns an error when a requested zone isn't one of the zones available in the region
+// or is requested more than once.
+func ValidateZones(region string, requested, available []string) error {
+	avail := make(map[string]struct{}, len(available))
+	for _, z := range available {
+		avail[z] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(requested))
+	for _, z := range requested {
+		if _, ok := seen[z]; ok {
+			return fmt.Errorf("duplicate zone %q", z)
+		}
+		seen[z] = struct{}{}
+		if _, ok := avail[z]; !ok {
+			return fmt.Errorf("zone %q isn't one of the zones %v of region %v", z, available, region)
+		}
+	}
+	return nil
+}
+
+// NodesPerZone splits the total node count of a pool evenly across the zones.
+// Providers run the same node count in every zone, so a total that doesn't divide evenly is rounded up.
+func NodesPerZone(total int32, zones int) int32 {
+	if zones <= 1 {
+		return total
+	}
+	return (total + int32(zones) - 1) / int32(zones)
+}
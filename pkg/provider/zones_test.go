@@ -0,0 +1,51 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "testing"
+
+func TestValidateZones(t *testing.T) {
+	available := []string{"europe-west1-b", "europe-west1-c", "europe-west1-d"}
+	for _, tc := range []struct {
+		zones []string
+		err   bool
+	}{
+		{zones: []string{"europe-west1-b", "europe-west1-d"}},
+		{zones: []string{"europe-west1-b", "europe-west1-a"}, err: true},
+		{zones: []string{"europe-west1-b", "europe-west1-b"}, err: true},
+	} {
+		err := ValidateZones("europe-west1", tc.zones, available)
+		if tc.err != (err != nil) {
+			t.Errorf("zones %v: want error %v, got %v", tc.zones, tc.err, err)
+		}
+	}
+}
+
+func TestNodesPerZone(t *testing.T) {
+	for _, tc := range []struct {
+		total   int32
+		zones   int
+		perZone int32
+	}{
+		{total: 6, zones: 3, perZone: 2},
+		{total: 4, zones: 3, perZone: 2},
+		{total: 1, zones: 3, perZone: 1},
+		{total: 5, zones: 1, perZone: 5},
+		{total: 5, zones: 0, perZone: 5},
+	} {
+		if got := NodesPerZone(tc.total, tc.zones); got != tc.perZone {
+			t.Errorf("%d nodes in %d zones: want %d per zone, got %d", tc.total, tc.zones, tc.perZone, got)
+		}
+	}
+}
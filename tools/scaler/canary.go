@@ -0,0 +1,99 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// canary keeps max replicas in total and moves them from the stable to the canary deployment,
+// one weight of the schedule per step. The last weight is kept once the schedule is done.
+type canary struct {
+	total          int32
+	stable, canary string
+	// weights are the percentages of the total that run as canary, by step.
+	weights []float64
+}
+
+func (c canary) replicas(int) int32 {
+	return c.total
+}
+
+// split returns the replicas of the stable and the canary deployment for the step.
+func (c canary) split(step int) (stableReplicas, canaryReplicas int32) {
+	if step >= len(c.weights) {
+		step = len(c.weights) - 1
+	}
+	canaryReplicas = int32(math.Round(float64(c.total) * c.weights[step] / 100))
+	return c.total - canaryReplicas, canaryReplicas
+}
+
+// parseCanaryWeights parses the comma separated canary percentages of the canary pattern, e.g. 0,10,25,50,100.
+// The weights must be between 0 and 100.
+func parseCanaryWeights(weights string) ([]float64, error) {
+	if strings.TrimSpace(weights) == "" {
+		return nil, errors.New("the canary pattern requires comma separated canary weights in percent, e.g. 0,10,25,50,100")
+	}
+	var parsed []float64
+	for _, w := range strings.Split(weights, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+		if err != nil || math.IsNaN(v) || v < 0 || v > 100 {
+			return nil, errors.Errorf("invalid canary weight %q, must be a percentage between 0 and 100", w)
+		}
+		parsed = append(parsed, v)
+	}
+	return parsed, nil
+}
+
+// canaryStep applies the split of the step to the stable and canary deployments and waits for an interval.
+// The other deployments from the files are not applied while the canary pattern runs.
+func (s *scale) canaryStep(c canary, step int, interval time.Duration) error {
+	stableReplicas, canaryReplicas := c.split(step)
+	log.Printf("Shifting replicas - %v: %d, %v: %d", c.stable, stableReplicas, c.canary, canaryReplicas)
+	s.split = map[string]int32{c.stable: stableReplicas, c.canary: canaryReplicas}
+	return s.scaleTo(c.total, interval)
+}
+
+// checkCanaryTargets checks that the stable and canary deployments of the canary phases are in the files.
+func (s *scale) checkCanaryTargets(p *plan) error {
+	deployments := map[string]bool{}
+	for _, deployment := range s.k8sClient.GetResources() {
+		for _, resource := range deployment.Objects {
+			if o, ok := scalableObject(resource); ok {
+				deployments[o.name] = true
+			}
+		}
+	}
+	for _, ph := range p.Phases {
+		c, ok := ph.pattern.(canary)
+		if !ok {
+			continue
+		}
+		if len(s.scaleTargets) > 0 {
+			return errors.Errorf("phase %q: the canary pattern applies the deployments from the files and can't be used with --scale-target or --selector", ph.Name)
+		}
+		for _, name := range []string{c.stable, c.canary} {
+			if !deployments[name] {
+				return errors.Errorf("phase %q: the canary pattern deployment %q is not in the deployment files", ph.Name, name)
+			}
+		}
+	}
+	return nil
+}
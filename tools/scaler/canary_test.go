@@ -0,0 +1,60 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCanaryPattern(t *testing.T) {
+	p, err := newPattern(&phase{
+		Pattern:          "canary",
+		Max:              10,
+		Interval:         time.Minute,
+		StableDeployment: "loadgen",
+		CanaryDeployment: "loadgen-canary",
+		CanaryWeights:    "0, 10, 25, 50, 100",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := p.(canary)
+	// 25% of 10 rounds up to 3, the last weight is kept after the schedule.
+	for step, want := range [][2]int32{{10, 0}, {9, 1}, {7, 3}, {5, 5}, {0, 10}, {0, 10}} {
+		stable, canary := c.split(step)
+		if stable != want[0] || canary != want[1] {
+			t.Errorf("step %d: want %d stable and %d canary replicas, got %d and %d", step, want[0], want[1], stable, canary)
+		}
+		if stable+canary != 10 {
+			t.Errorf("step %d: want 10 replicas in total, got %d", step, stable+canary)
+		}
+		if r := c.replicas(step); r != 10 {
+			t.Errorf("step %d: want the total of 10 replicas, got %d", step, r)
+		}
+	}
+
+	for _, invalid := range []*phase{
+		{Pattern: "canary", Max: 10, StableDeployment: "loadgen", CanaryDeployment: "loadgen-canary"},
+		{Pattern: "canary", Max: 10, StableDeployment: "loadgen", CanaryDeployment: "loadgen-canary", CanaryWeights: "0,150"},
+		{Pattern: "canary", Max: 10, StableDeployment: "loadgen", CanaryDeployment: "loadgen-canary", CanaryWeights: "0,-10"},
+		{Pattern: "canary", Max: 10, StableDeployment: "loadgen", CanaryDeployment: "loadgen-canary", CanaryWeights: "0,a"},
+		{Pattern: "canary", Max: 10, CanaryDeployment: "loadgen-canary", CanaryWeights: "0,50"},
+		{Pattern: "canary", Max: 10, StableDeployment: "loadgen", CanaryDeployment: "loadgen", CanaryWeights: "0,50"},
+	} {
+		if _, err := newPattern(invalid); err == nil {
+			t.Errorf("%+v: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "sync"
+
+// replicaCap caps the sum of the replicas of all deployments of a per-deployment plan,
+// so their combined load doesn't oversubscribe the cluster.
+type replicaCap struct {
+	max int64
+
+	mu sync.Mutex
+	// wanted are the latest replicas of every deployment before capping, granted the replicas they got.
+	wanted, granted map[string]int64
+}
+
+func newReplicaCap(max int32) *replicaCap {
+	return &replicaCap{max: int64(max), wanted: map[string]int64{}, granted: map[string]int64{}}
+}
+
+// reserve returns the replicas a deployment gets for the wanted replicas and the sum wanted by all deployments.
+// When the sum exceeds max the wanted replicas of every deployment are reduced by the same ratio.
+// The other deployments keep their replicas until their next apply, so a deployment never gets more than they leave of max.
+func (c *replicaCap) reserve(deployment string, wanted int32) (replicas int32, sum int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wanted[deployment] = int64(wanted)
+	for _, w := range c.wanted {
+		sum += w
+	}
+	r := int64(wanted)
+	if sum > c.max {
+		r = r * c.max / sum
+	}
+	var others int64
+	for d, g := range c.granted {
+		if d != deployment {
+			others += g
+		}
+	}
+	if free := c.max - others; r > free {
+		r = free
+	}
+	if r < 0 {
+		r = 0
+	}
+	c.granted[deployment] = r
+	return int32(r), sum
+}
+
+// capReplicas returns the replicas of the deployment within the global max replicas, unchanged without them.
+func (s *scale) capReplicas(replicas int32) int32 {
+	if s.replicaCap == nil {
+		return replicas
+	}
+	capped, sum := s.replicaCap.reserve(s.deployment, replicas)
+	if capped < replicas {
+		s.logf("Capping %d replicas to %d, %d less, the deployments want %d replicas in total and the global max replicas is %d", replicas, capped, replicas-capped, sum, s.replicaCap.max)
+	}
+	return capped
+}
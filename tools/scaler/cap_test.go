@@ -0,0 +1,53 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func TestReplicaCap(t *testing.T) {
+	c := newReplicaCap(30)
+	for _, tc := range []struct {
+		deployment string
+		wanted     int32
+		replicas   int32
+		sum        int64
+	}{
+		// Within the cap the replicas are kept.
+		{deployment: "prometheus", wanted: 10, replicas: 10, sum: 10},
+		{deployment: "loadgen", wanted: 20, replicas: 20, sum: 30},
+		// 40 + 20 is twice the cap, but loadgen still runs 20 until its next apply.
+		{deployment: "prometheus", wanted: 40, replicas: 10, sum: 60},
+		{deployment: "loadgen", wanted: 20, replicas: 10, sum: 60},
+		{deployment: "prometheus", wanted: 40, replicas: 20, sum: 60},
+		// Once the load drops again the replicas aren't capped anymore.
+		{deployment: "prometheus", wanted: 5, replicas: 5, sum: 25},
+	} {
+		replicas, sum := c.reserve(tc.deployment, tc.wanted)
+		if replicas != tc.replicas || sum != tc.sum {
+			t.Errorf("%v wants %d: want %d replicas of %d in total, got %d of %d", tc.deployment, tc.wanted, tc.replicas, tc.sum, replicas, sum)
+		}
+	}
+}
+
+func TestCapReplicas(t *testing.T) {
+	s := newScaler()
+	if r := s.capReplicas(100); r != 100 {
+		t.Errorf("want the replicas unchanged without a cap, got %d", r)
+	}
+	s.replicaCap = newReplicaCap(10)
+	s.deployment = "loadgen"
+	if r := s.capReplicas(100); r != 10 {
+		t.Errorf("want the replicas capped to 10, got %d", r)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// chaos keeps max replicas and deletes random pods at every step instead of scaling.
+type chaos struct {
+	count int32
+	// killRate is the number of pods deleted per step.
+	killRate int
+	// maxUnavailable caps the pods that are not ready at the same time, including the deleted ones.
+	maxUnavailable int
+}
+
+func (c chaos) replicas(int) int32 {
+	return c.count
+}
+
+// podSelector selects the pods of a single scaled object.
+type podSelector struct {
+	namespace string
+	selector  string
+}
+
+// chaosStep applies the replicas when they changed, deletes random pods of the scaled objects
+// and waits for an interval.
+func (s *scale) chaosStep(c chaos, interval time.Duration) error {
+	if s.current != c.count {
+		if err := s.apply(c.count, c.count); err != nil {
+			return err
+		}
+	}
+	selectors, err := s.podSelectors()
+	if err == nil {
+		for _, sel := range selectors {
+			if err = s.killPods(sel, c); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error deleting pods"))
+		if err := s.recordError(err); err != nil {
+			return err
+		}
+	}
+	s.health.progress(interval)
+	s.clock.Sleep(interval)
+	return nil
+}
+
+// killPods deletes random ready pods of the selector within the kill rate and max unavailable limits.
+func (s *scale) killPods(sel podSelector, c chaos) error {
+	pods, err := s.k8sClient.ListPods(sel.namespace, sel.selector)
+	if err != nil {
+		return err
+	}
+	victims := chaosVictims(pods, c, s.rand)
+	if len(victims) == 0 {
+		log.Printf("Not deleting pods of %v, max unavailable %d reached", sel.selector, c.maxUnavailable)
+		return nil
+	}
+	var killed []string
+	for _, pod := range victims {
+		if err := s.k8sClient.DeletePod(sel.namespace, pod.Name); err != nil {
+			s.recordKillEvent(killed, err)
+			return err
+		}
+		s.metrics.inc(s.metrics.killedPods, s.traceID)
+		killed = append(killed, pod.Name)
+	}
+	s.recordKillEvent(killed, nil)
+	s.metrics.push()
+	return nil
+}
+
+// chaosVictims returns up to killRate random ready pods,
+// fewer when deleting them would make more than maxUnavailable pods unavailable.
+// Missing pods, e.g. the ones not recreated yet, count as unavailable.
+func chaosVictims(pods []apiCoreV1.Pod, c chaos, r *rand.Rand) []apiCoreV1.Pod {
+	var ready []apiCoreV1.Pod
+	for _, pod := range pods {
+		if k8s.PodReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+	unavailable := int(c.count) - len(ready)
+	if unavailable < 0 {
+		unavailable = 0
+	}
+	n := c.killRate
+	if budget := c.maxUnavailable - unavailable; budget < n {
+		n = budget
+	}
+	if n > len(ready) {
+		n = len(ready)
+	}
+	if n <= 0 {
+		return nil
+	}
+	r.Shuffle(len(ready), func(i, j int) { ready[i], ready[j] = ready[j], ready[i] })
+	return ready[:n]
+}
+
+// podSelectors returns the pod selectors of the scale targets or of the deployments from the files.
+func (s *scale) podSelectors() ([]podSelector, error) {
+	if len(s.scaleTargets) > 0 {
+		var selectors []podSelector
+		for _, t := range s.scaleTargets {
+			selector, err := s.k8sClient.ScaleSelector(t)
+			if err != nil {
+				return nil, err
+			}
+			selectors = append(selectors, podSelector{namespace: t.Namespace, selector: selector})
+		}
+		return selectors, nil
+	}
+	var selectors []podSelector
+	for _, deployment := range s.k8sClient.GetResources() {
+		for _, resource := range deployment.Objects {
+			o, ok := scalableObject(resource)
+			if !ok || s.skipDeployment(o.name) {
+				continue
+			}
+			selector, err := apiMetaV1.LabelSelectorAsSelector(o.selector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid selector of %v %v", o.resource.Resource, o.name)
+			}
+			selectors = append(selectors, podSelector{namespace: o.namespace, selector: selector.String()})
+		}
+	}
+	return selectors, nil
+}
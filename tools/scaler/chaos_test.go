@@ -0,0 +1,73 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPods(ready, notReady, deleting int) []apiCoreV1.Pod {
+	var pods []apiCoreV1.Pod
+	add := func(n int, status apiCoreV1.ConditionStatus, deleted bool) {
+		for i := 0; i < n; i++ {
+			pod := apiCoreV1.Pod{
+				ObjectMeta: apiMetaV1.ObjectMeta{Name: fmt.Sprintf("pod-%d", len(pods))},
+				Status: apiCoreV1.PodStatus{Conditions: []apiCoreV1.PodCondition{
+					{Type: apiCoreV1.PodReady, Status: status},
+				}},
+			}
+			if deleted {
+				now := apiMetaV1.Now()
+				pod.DeletionTimestamp = &now
+			}
+			pods = append(pods, pod)
+		}
+	}
+	add(ready, apiCoreV1.ConditionTrue, false)
+	add(notReady, apiCoreV1.ConditionFalse, false)
+	add(deleting, apiCoreV1.ConditionTrue, true)
+	return pods
+}
+
+func TestChaosVictims(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		pods    []apiCoreV1.Pod
+		chaos   chaos
+		victims int
+	}{
+		{name: "all ready", pods: testPods(5, 0, 0), chaos: chaos{count: 5, killRate: 2, maxUnavailable: 3}, victims: 2},
+		{name: "limited by max unavailable", pods: testPods(3, 1, 1), chaos: chaos{count: 5, killRate: 2, maxUnavailable: 3}, victims: 1},
+		{name: "max unavailable reached", pods: testPods(2, 1, 1), chaos: chaos{count: 5, killRate: 2, maxUnavailable: 3}, victims: 0},
+		{name: "missing pods", pods: testPods(3, 0, 0), chaos: chaos{count: 5, killRate: 2, maxUnavailable: 3}, victims: 1},
+		{name: "fewer ready pods than the kill rate", pods: testPods(1, 0, 0), chaos: chaos{count: 1, killRate: 3, maxUnavailable: 5}, victims: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			victims := chaosVictims(tc.pods, tc.chaos, rand.New(rand.NewSource(1)))
+			if len(victims) != tc.victims {
+				t.Fatalf("want %d victims, got %d", tc.victims, len(victims))
+			}
+			for _, v := range victims {
+				if v.DeletionTimestamp != nil || v.Status.Conditions[0].Status != apiCoreV1.ConditionTrue {
+					t.Errorf("pod %v isn't ready and shouldn't be deleted", v.Name)
+				}
+			}
+		})
+	}
+}
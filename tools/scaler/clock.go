@@ -0,0 +1,83 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// clock is the time source of the scaling loop, the wall clock unless the scaler runs a --simulate.
+type clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// realClock is the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// virtualClock only moves when the loop sleeps, so a simulation of any length runs instantly.
+type virtualClock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+func newVirtualClock(start time.Time) *virtualClock {
+	return &virtualClock{now: start}
+}
+
+func (c *virtualClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+// stoppableClock is a clock whose sleeps end early once stop is closed,
+// so the other workers of a per-deployment plan stop soon after one of them failed.
+// A virtual clock doesn't block and only stops moving.
+type stoppableClock struct {
+	clock
+	stop <-chan struct{}
+}
+
+func (c stoppableClock) Sleep(d time.Duration) {
+	select {
+	case <-c.stop:
+		return
+	default:
+	}
+	if _, ok := c.clock.(*virtualClock); ok || d <= 0 {
+		c.clock.Sleep(d)
+		return
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-c.stop:
+	}
+}
+
+func (c *virtualClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
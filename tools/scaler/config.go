@@ -0,0 +1,156 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiCoreV1 "k8s.io/api/core/v1"
+)
+
+// The keys of the config ConfigMap.
+const (
+	configMin      = "min"
+	configMax      = "max"
+	configInterval = "interval"
+)
+
+// parseConfigMap parses the config ConfigMap in the [namespace/]name format.
+func parseConfigMap(value string) (namespace, name string, err error) {
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok {
+		namespace, name = "default", value
+	}
+	if namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", errors.Errorf("invalid config-configmap %q, expected [namespace/]name", value)
+	}
+	return namespace, name, nil
+}
+
+// configPhase returns a copy of the base phase with the min, max and interval from the ConfigMap data.
+// The keys missing from the data keep the values of the base phase.
+func configPhase(base *phase, data map[string]string) (*phase, error) {
+	ph := *base
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := strings.TrimSpace(data[k])
+		switch k {
+		case configMin, configMax:
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return nil, errors.Errorf("invalid %v %q, must be an integer", k, v)
+			}
+			if k == configMin {
+				ph.Min = int32(n)
+			} else {
+				ph.Max = int32(n)
+			}
+		case configInterval:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, errors.Errorf("invalid interval %q, must be a duration like 15m", v)
+			}
+			ph.Interval = d
+		default:
+			return nil, errors.Errorf("unknown key %q, must be one of %v, %v or %v", k, configMin, configMax, configInterval)
+		}
+	}
+	if err := ph.validate(); err != nil {
+		return nil, err
+	}
+	return &ph, nil
+}
+
+// watchConfig returns the base phase with the config from the ConfigMap
+// and watches the ConfigMap to reload the config at the next step after every change.
+// An invalid config fails the start, while invalid updates are ignored with a warning.
+// When the ConfigMap is missing or deleted the base phase from the cli args is used.
+func (s *scale) watchConfig(base *phase) (*phase, error) {
+	namespace, name, err := parseConfigMap(s.configMap)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := s.k8sClient.GetConfigMap(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	current := base
+	if cm != nil {
+		if current, err = s.confirmedConfigPhase(base, cm.Data); err != nil {
+			return nil, errors.Wrapf(err, "ConfigMap %v", s.configMap)
+		}
+	} else {
+		log.Printf("The ConfigMap %v doesn't exist, using the cli args until it is created", s.configMap)
+	}
+
+	s.reload = make(chan *phase, 1)
+	go s.k8sClient.WatchConfigMap(context.Background(), namespace, name, func(cm *apiCoreV1.ConfigMap) {
+		var data map[string]string
+		if cm != nil {
+			data = cm.Data
+		}
+		ph, err := s.confirmedConfigPhase(base, data)
+		if err != nil {
+			log.Printf("Warning: ignoring the invalid config update of the ConfigMap %v: %v", s.configMap, err)
+			s.metrics.configReloads.WithLabelValues("invalid").Inc()
+			return
+		}
+		if ph.Min == current.Min && ph.Max == current.Max && ph.Interval == current.Interval {
+			return
+		}
+		current = ph
+		s.metrics.configReloads.WithLabelValues("success").Inc()
+		// Only the latest config is kept until the next step picks it up.
+		select {
+		case <-s.reload:
+		default:
+		}
+		s.reload <- ph
+	})
+	return current, nil
+}
+
+// confirmedConfigPhase returns the phase of the config like configPhase,
+// or an error when the config makes it destructive without --confirm-destructive.
+func (s *scale) confirmedConfigPhase(base *phase, data map[string]string) (*phase, error) {
+	ph, err := configPhase(base, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDestructive(&plan{Phases: []*phase{ph}}); err != nil {
+		return nil, err
+	}
+	return ph, nil
+}
+
+// reloadedPhase returns the reloaded phase, or the given phase when the config didn't change.
+func (s *scale) reloadedPhase(ph *phase) *phase {
+	select {
+	case next := <-s.reload:
+		log.Printf("Reloaded the config from the ConfigMap %v:\n\t max: %d\n\t min: %d\n\t interval: %s", s.configMap, next.Max, next.Min, next.Interval)
+		return next
+	default:
+		return ph
+	}
+}
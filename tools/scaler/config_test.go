@@ -0,0 +1,84 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigPhase(t *testing.T) {
+	base := &phase{Name: "burst", Pattern: "burst", Min: 1, Max: 10, Interval: 15 * time.Minute}
+	if err := base.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		data    map[string]string
+		want    *phase
+		invalid bool
+	}{
+		{name: "no keys", want: base},
+		{name: "all keys", data: map[string]string{"min": "2", "max": " 20 ", "interval": "5m"}, want: &phase{Min: 2, Max: 20, Interval: 5 * time.Minute}},
+		{name: "partial", data: map[string]string{"max": "5"}, want: &phase{Min: 1, Max: 5, Interval: 15 * time.Minute}},
+		{name: "min bigger than max", data: map[string]string{"min": "20"}, invalid: true},
+		{name: "invalid number", data: map[string]string{"max": "ten"}, invalid: true},
+		{name: "invalid interval", data: map[string]string{"interval": "0s"}, invalid: true},
+		{name: "unknown key", data: map[string]string{"pattern": "step"}, invalid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ph, err := configPhase(base, tc.data)
+			if tc.invalid {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", ph)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ph.Min != tc.want.Min || ph.Max != tc.want.Max || ph.Interval != tc.want.Interval {
+				t.Errorf("want min: %d, max: %d, interval: %s, got min: %d, max: %d, interval: %s", tc.want.Min, tc.want.Max, tc.want.Interval, ph.Min, ph.Max, ph.Interval)
+			}
+			if got := ph.pattern.replicas(0); got != ph.Max {
+				t.Errorf("want the pattern of the config to start at max %d, got %d", ph.Max, got)
+			}
+		})
+	}
+	if base.Max != 10 {
+		t.Errorf("the base phase was changed, max: %d", base.Max)
+	}
+}
+
+func TestParseConfigMap(t *testing.T) {
+	for value, want := range map[string][2]string{
+		"scaler":              {"default", "scaler"},
+		"prombench-10/scaler": {"prombench-10", "scaler"},
+		"/scaler":             {},
+		"ns/":                 {},
+		"a/b/c":               {},
+	} {
+		namespace, name, err := parseConfigMap(value)
+		if want[1] == "" {
+			if err == nil {
+				t.Errorf("%q: expected an error", value)
+			}
+			continue
+		}
+		if err != nil || namespace != want[0] || name != want[1] {
+			t.Errorf("%q: want %v/%v, got %v/%v, err: %v", value, want[0], want[1], namespace, name, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"log"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// checkConvergence compares the ready pods of the scaled objects with the replicas applied last,
+// at the end of every cycle once its interval has passed, and exports the difference.
+// The scaled objects converged when the difference is within the tolerance,
+// e.g. to assert that the system under test reached the steady state of the pattern.
+func (s *scale) checkConvergence() {
+	if !s.convergence || s.applied == nil {
+		return
+	}
+	ready, expected, err := s.readyReplicas()
+	if err != nil {
+		log.Printf("Error reading the ready pods for the convergence: %v", err)
+		return
+	}
+	controlError := expected - ready
+	converged := withinTolerance(controlError, s.convergenceTolerance)
+	if converged != s.converged {
+		if converged {
+			s.logf("Converged - %d of %d replicas ready", ready, expected)
+		} else {
+			s.logf("Not converged - %d of %d replicas ready, tolerance %d", ready, expected, s.convergenceTolerance)
+		}
+		s.converged = converged
+	}
+	s.metrics.readyReplicas.Set(float64(ready))
+	s.metrics.convergenceError.Set(float64(controlError))
+	if converged {
+		s.metrics.converged.Set(1)
+	} else {
+		s.metrics.converged.Set(0)
+	}
+	s.metrics.push()
+}
+
+// readyPods returns the number of ready pods selected by the scale of a target.
+func (s *scale) readyPods(t k8s.ScaleTarget) (int32, error) {
+	selector, err := s.k8sClient.ScaleSelector(t)
+	if err != nil {
+		return 0, err
+	}
+	pods, err := s.k8sClient.ListPods(t.Namespace, selector)
+	if err != nil {
+		return 0, err
+	}
+	var ready int32
+	for _, pod := range pods {
+		if k8s.PodReady(pod) {
+			ready++
+		}
+	}
+	return ready, nil
+}
+
+// expectedReplicas returns the replicas applied to all targets, every target gets the applied replicas
+// unless the canary pattern split them between the stable and the canary target.
+func expectedReplicas(targets []k8s.ScaleTarget, applied int32, split map[string]int32) int32 {
+	if split != nil {
+		var expected int32
+		for _, t := range targets {
+			expected += split[t.Name]
+		}
+		return expected
+	}
+	return applied * int32(len(targets))
+}
+
+// withinTolerance returns whether the difference of the expected and the ready replicas is at most the tolerance either way.
+func withinTolerance(controlError, tolerance int32) bool {
+	return controlError <= tolerance && -controlError <= tolerance
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+func TestExpectedReplicas(t *testing.T) {
+	targets := []k8s.ScaleTarget{{Name: "loadgen"}, {Name: "loadgen-canary"}}
+	if got := expectedReplicas(targets, 4, nil); got != 8 {
+		t.Errorf("want the applied replicas of every target, got %d", got)
+	}
+	if got := expectedReplicas(targets, 10, map[string]int32{"loadgen": 7, "loadgen-canary": 3}); got != 10 {
+		t.Errorf("want the replicas of the canary split, got %d", got)
+	}
+}
+
+func TestWithinTolerance(t *testing.T) {
+	for _, tc := range []struct {
+		controlError, tolerance int32
+		converged               bool
+	}{
+		{0, 0, true},
+		{1, 0, false},
+		{-1, 0, false},
+		{2, 2, true},
+		{-2, 2, true},
+		{3, 2, false},
+	} {
+		if got := withinTolerance(tc.controlError, tc.tolerance); got != tc.converged {
+			t.Errorf("error %d, tolerance %d: want converged %v, got %v", tc.controlError, tc.tolerance, tc.converged, got)
+		}
+	}
+}
+
+func TestCheckConvergenceBeforeFirstApply(t *testing.T) {
+	// Nothing was applied yet, so there are no replicas to converge to.
+	s := newScaler()
+	s.convergence = true
+	s.checkConvergence()
+}
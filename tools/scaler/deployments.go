@@ -0,0 +1,186 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// deploymentWorker runs the plan of a single deployment of a per-deployment plan.
+type deploymentWorker struct {
+	s    *scale
+	plan *plan
+}
+
+// deploymentWorkers checks that every deployment of the per-deployment plan is in the files
+// and returns a worker for each, with its own scaling state and metrics.
+// A simulation doesn't check the files, every worker gets its own virtual clock instead.
+func (s *scale) deploymentWorkers(p *plan) ([]deploymentWorker, error) {
+	if s.simulation == nil {
+		if err := s.checkDeploymentFiles(p); err != nil {
+			return nil, err
+		}
+	}
+
+	workers := make([]deploymentWorker, 0, len(p.Deployments))
+	for _, name := range p.deploymentNames() {
+		w, err := s.forDeployment(name)
+		if err != nil {
+			return nil, err
+		}
+		if s.simulation != nil {
+			w.useVirtualClock(p.Deployments[name], s.started)
+		}
+		workers = append(workers, deploymentWorker{s: w, plan: p.Deployments[name]})
+	}
+	return workers, nil
+}
+
+// checkDeploymentFiles checks that every deployment of the per-deployment plan is in the files
+// and warns about the deployments of the files that aren't in the plan.
+func (s *scale) checkDeploymentFiles(p *plan) error {
+	inFiles := map[string]bool{}
+	for _, deployment := range s.k8sClient.GetResources() {
+		for _, resource := range deployment.Objects {
+			if o, ok := scalableObject(resource); ok {
+				inFiles[o.name] = true
+			}
+		}
+	}
+	var missing []string
+	for _, name := range p.deploymentNames() {
+		if !inFiles[name] {
+			missing = append(missing, name)
+		}
+		delete(inFiles, name)
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("the deployments %v of the plan are not in the deployment files", strings.Join(missing, ", "))
+	}
+	for name := range inFiles {
+		log.Printf("WARNING: deployment %q is not in the plan and isn't scaled", name)
+	}
+	return nil
+}
+
+// forDeployment returns a copy of the scaler that only scales the named deployment,
+// with the same settings and its own scaling state, error counts and metrics.
+func (s *scale) forDeployment(name string) (*scale, error) {
+	m, err := s.metrics.forDeployment(name)
+	if err != nil {
+		return nil, err
+	}
+	w := *s
+	w.deployment = name
+	w.metrics = m
+	// rand.Rand isn't safe for concurrent use.
+	w.rand = rand.New(rand.NewSource(s.rand.Int63()))
+	w.errStats = errorStats{}
+	return &w, nil
+}
+
+// runDeployments runs the plans of the deployments concurrently, each on its own schedule.
+// It returns once all plans have completed, or once the other workers stopped after a deployment failed,
+// with the errors of all deployments that failed. The errors of all deployments are then summarized on exit.
+func (s *scale) runDeployments(workers []deploymentWorker) error {
+	type result struct {
+		w   *scale
+		err error
+	}
+	stop := make(chan struct{})
+	results := make(chan result, len(workers))
+	for _, w := range workers {
+		w.s.stop = stop
+		w.s.clock = stoppableClock{clock: w.s.clock, stop: stop}
+		go func(w deploymentWorker) {
+			err := w.s.runPlan(w.plan)
+			w.s.endLoad()
+			results <- result{w: w.s, err: err}
+		}(w)
+	}
+	errs := deploymentErrors{}
+	for range workers {
+		r := <-results
+		s.errStats.merge(fmt.Sprintf("deployment %q: ", r.w.deployment), r.w.errStats)
+		if r.err == nil {
+			continue
+		}
+		if len(errs) == 0 {
+			log.Printf("Deployment %q failed, stopping the other deployments: %v", r.w.deployment, r.err)
+			close(stop)
+		}
+		errs[r.w.deployment] = r.err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	log.Printf("The plans of all deployments completed")
+	return nil
+}
+
+// deploymentErrors are the errors of the failed deployments of a per-deployment plan, by deployment.
+type deploymentErrors map[string]error
+
+func (e deploymentErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, 0, len(e))
+	for _, name := range names {
+		msgs = append(msgs, fmt.Sprintf("deployment %q: %v", name, e[name]))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is matches the error of any deployment, so the exit code of a per-deployment plan follows the errors of all deployments.
+func (e deploymentErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// stopped returns true once another worker of a per-deployment plan failed and the worker should stop.
+func (s *scale) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// skipDeployment returns whether a deployment from the files isn't scaled by this scaler,
+// i.e. it is not the deployment of a worker of a per-deployment plan.
+func (s *scale) skipDeployment(name string) bool {
+	return s.deployment != "" && name != s.deployment
+}
+
+// logf logs with the deployment of a per-deployment plan worker as the prefix.
+func (s *scale) logf(format string, args ...interface{}) {
+	if s.deployment != "" {
+		format = "[" + s.deployment + "] " + format
+	}
+	log.Printf(format, args...)
+}
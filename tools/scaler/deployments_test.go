@@ -0,0 +1,202 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const deploymentsPlan = `
+deployments:
+  prometheus:
+    loop: true
+    phases:
+    - pattern: sine
+      max: 4
+      min: 1
+      interval: 1m
+      period: 30m
+      duration: 1h
+  loadgen:
+    phases:
+    - pattern: burst
+      max: 20
+      min: 1
+      interval: 5m
+`
+
+func TestLoadDeploymentsPlan(t *testing.T) {
+	write := func(content string) string {
+		f := filepath.Join(t.TempDir(), "plan.yaml")
+		if err := os.WriteFile(f, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	p, err := loadPlan(write(deploymentsPlan))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := p.deploymentNames(); strings.Join(names, ",") != "loadgen,prometheus" {
+		t.Errorf("want the loadgen and prometheus deployments, got %v", names)
+	}
+	if ph := p.Deployments["prometheus"].Phases[0]; ph.pattern == nil || ph.Name != "phase-0" {
+		t.Errorf("want the phases of every deployment validated, got %+v", ph)
+	}
+
+	for name, content := range map[string]string{
+		"phases and deployments": deploymentsPlan + "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n",
+		"no phases":              "deployments:\n  loadgen:\n    loop: true\n",
+		"invalid phase":          "deployments:\n  loadgen:\n    phases:\n    - pattern: burst\n      max: 1\n",
+		"loop without duration":  "deployments:\n  loadgen:\n    loop: true\n    phases:\n    - pattern: hold\n      max: 1\n      interval: 1m\n",
+		"nested":                 "deployments:\n  loadgen:\n    deployments:\n      querier:\n        phases: []\n    phases:\n    - pattern: hold\n      max: 1\n      interval: 1m\n",
+		"canary": "deployments:\n  loadgen:\n    phases:\n    - pattern: canary\n      max: 10\n      interval: 1m\n" +
+			"      stableDeployment: loadgen\n      canaryDeployment: loadgen-canary\n      canaryWeights: 0,50\n",
+	} {
+		if _, err := loadPlan(write(content)); err == nil {
+			t.Errorf("%v: expected an error", name)
+		}
+	}
+}
+
+func TestDeploymentMetrics(t *testing.T) {
+	s := newScaler()
+	if err := s.metrics.registerShared(map[string]string{"scaler": "loadgen-a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"prometheus", "loadgen"} {
+		w, err := s.forDeployment(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if w.deployment != name || w.rand == s.rand || w.metrics.warmup != s.metrics.warmup {
+			t.Errorf("want a scaler for deployment %v with its own state and the shared warmup, got %+v", name, w)
+		}
+		w.metrics.targetReplicas.Set(float64(len(name)))
+	}
+	if _, err := s.forDeployment("loadgen"); err == nil {
+		t.Error("expected an error registering the metrics of a deployment twice")
+	}
+
+	families, err := s.metrics.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := map[string]float64{}
+	for _, f := range families {
+		for _, metric := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range metric.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["scaler"] != "loadgen-a" {
+				t.Errorf("%v: want the constant labels, got %v", f.GetName(), labels)
+			}
+			if f.GetName() == "scaler_target_replicas" {
+				targets[labels[deploymentLabel]] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	if len(targets) != 2 || targets["prometheus"] != 10 || targets["loadgen"] != 7 {
+		t.Errorf("want the target replicas by deployment, got %v", targets)
+	}
+
+	if err := newScalerMetrics().registerShared(map[string]string{deploymentLabel: "loadgen"}); err == nil {
+		t.Error("expected an error for the deployment metric label")
+	}
+}
+
+func TestSkipDeployment(t *testing.T) {
+	s := &scale{}
+	if s.skipDeployment("loadgen") {
+		t.Error("want all deployments scaled without a per-deployment plan")
+	}
+	s.deployment = "prometheus"
+	if s.skipDeployment("prometheus") || !s.skipDeployment("loadgen") {
+		t.Error("want only the deployment of the worker scaled")
+	}
+}
+
+func TestRunDeploymentsFailure(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(f, []byte(`
+deployments:
+  prometheus:
+    phases:
+    - pattern: hold
+      max: 4
+      interval: 1m
+  loadgen:
+    phases:
+    - pattern: hold
+      max: 2
+      interval: 1m
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := loadPlan(f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := newScaler()
+	s.started = time.Now()
+	s.hookTimeout = time.Minute
+	s.errStats.record(errors.New("configmap reload failed"), time.Now())
+	var workers []deploymentWorker
+	for _, name := range p.deploymentNames() {
+		w, err := s.forDeployment(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		workers = append(workers, deploymentWorker{s: w, plan: p.Deployments[name]})
+	}
+	// The loadgen fails its first cycle, the prometheus holds its replicas outside the active windows until it's stopped.
+	workers[0].s.preCycleHook, workers[0].s.strictHooks = "exit 1", true
+	workers[0].s.errStats.record(errors.New("connection refused"), time.Now())
+	workers[1].s.activeWindows, _ = parseActiveWindows([]string{"1h-2h"})
+	workers[1].s.errStats.record(errors.New("connection refused"), time.Now())
+
+	done := make(chan error, 1)
+	go func() { done <- s.runDeployments(workers) }()
+	select {
+	case err = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("want the other deployments stopped after a deployment failed")
+	}
+	var errs deploymentErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs["loadgen"] == nil {
+		t.Fatalf("want the error of the loadgen deployment, got %v", err)
+	}
+	if !errors.Is(err, errHookFailure) || exitCode(err) != exitHookFailure {
+		t.Errorf("want the exit code of the hook failure, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `deployment "loadgen": pre-cycle hook`) {
+		t.Errorf("want the error by deployment, got %v", err)
+	}
+	if s.errStats.consecutive != 3 || s.errStats.total != 3 {
+		t.Errorf("want the failures of all deployments, got %+v", s.errStats)
+	}
+	summary := s.errStats.summary(time.Now())
+	for _, want := range []string{`deployment "loadgen": connection refused`, `deployment "prometheus": connection refused`, "configmap reload failed"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("want %q in the error summary, got %q", want, summary)
+		}
+	}
+}
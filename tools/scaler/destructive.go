@@ -0,0 +1,72 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// destructiveReason returns why a phase is destructive for the scaled objects, empty when it isn't.
+// The chaos pattern deletes pods and a phase whose replicas can reach 0 scales the objects to zero.
+// The canary pattern moves the replicas between two deployments and is never counted as scaling to zero.
+func destructiveReason(ph *phase) string {
+	lowest := ph.Min
+	switch ph.Pattern {
+	case "chaos":
+		return "the chaos pattern deletes pods"
+	case "canary":
+		return ""
+	case "hold":
+		lowest = ph.Max
+	}
+	// A load target needs at least one replica for any rate above 0.
+	if ph.PerReplicaRPS > 0 && lowest == 0 {
+		lowest = ph.MinReplicas
+	}
+	if lowest == 0 {
+		return fmt.Sprintf("the %s pattern scales to zero replicas", ph.Pattern)
+	}
+	return ""
+}
+
+// destructivePhases lists the destructive phases of the plan and of the deployments of a per-deployment plan.
+func destructivePhases(p *plan) []string {
+	var phases []string
+	add := func(prefix string, d *plan) {
+		for _, ph := range d.Phases {
+			if reason := destructiveReason(ph); reason != "" {
+				phases = append(phases, fmt.Sprintf("%sphase %q: %s", prefix, ph.Name, reason))
+			}
+		}
+	}
+	add("", p)
+	for _, name := range p.deploymentNames() {
+		add(fmt.Sprintf("deployment %q ", name), p.Deployments[name])
+	}
+	return phases
+}
+
+// checkDestructive refuses a plan with destructive phases unless they were confirmed with --confirm-destructive.
+func (s *scale) checkDestructive(p *plan) error {
+	if s.confirmDestructive {
+		return nil
+	}
+	if phases := destructivePhases(p); len(phases) > 0 {
+		return errors.Errorf("refusing to run destructive phases without --confirm-destructive: %s", strings.Join(phases, "; "))
+	}
+	return nil
+}
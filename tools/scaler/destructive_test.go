@@ -0,0 +1,89 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDestructiveReason(t *testing.T) {
+	for _, tc := range []struct {
+		ph          *phase
+		destructive bool
+	}{
+		{ph: &phase{Name: "burst", Pattern: "burst", Min: 1, Max: 10}},
+		{ph: &phase{Name: "burst to zero", Pattern: "burst", Min: 0, Max: 10}, destructive: true},
+		{ph: &phase{Name: "chaos", Pattern: "chaos", Min: 10, Max: 10, KillRate: 1, MaxUnavailable: 1}, destructive: true},
+		// hold only uses max.
+		{ph: &phase{Name: "hold", Pattern: "hold", Min: 0, Max: 5}},
+		{ph: &phase{Name: "hold zero", Pattern: "hold", Max: 0}, destructive: true},
+		// canary moves the replicas between the deployments.
+		{ph: &phase{Name: "canary", Pattern: "canary", Min: 0, Max: 10}},
+		// Any rate above 0 needs a replica.
+		{ph: &phase{Name: "rate", Pattern: "burst", Min: 100, Max: 1000, PerReplicaRPS: 250}},
+		{ph: &phase{Name: "rate bounded", Pattern: "burst", Min: 0, Max: 1000, PerReplicaRPS: 250, MinReplicas: 1}},
+		{ph: &phase{Name: "rate zero", Pattern: "burst", Min: 0, Max: 1000, PerReplicaRPS: 250}, destructive: true},
+	} {
+		if got := destructiveReason(tc.ph) != ""; got != tc.destructive {
+			t.Errorf("%s: want destructive %v, got %v", tc.ph.Name, tc.destructive, got)
+		}
+	}
+}
+
+func TestCheckDestructive(t *testing.T) {
+	p := &plan{
+		Deployments: map[string]*plan{
+			"loadgen": {Phases: []*phase{
+				{Name: "soak", Pattern: "hold", Max: 5, Interval: time.Minute},
+				{Name: "chaos", Pattern: "chaos", Max: 5, Interval: time.Minute, KillRate: 1, MaxUnavailable: 1},
+			}},
+			"querier": {Phases: []*phase{{Name: "soak", Pattern: "hold", Max: 2, Interval: time.Minute}}},
+		},
+	}
+	s := newScaler()
+	err := s.checkDestructive(p)
+	if err == nil {
+		t.Fatal("expected an error for the chaos phase")
+	}
+	if msg := err.Error(); !strings.Contains(msg, `deployment "loadgen" phase "chaos"`) || !strings.Contains(msg, "--confirm-destructive") || strings.Contains(msg, "querier") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	s.confirmDestructive = true
+	if err := s.checkDestructive(p); err != nil {
+		t.Errorf("unexpected error with --confirm-destructive: %v", err)
+	}
+
+	s.confirmDestructive = false
+	if err := s.checkDestructive(&plan{Phases: []*phase{{Name: "burst", Pattern: "burst", Min: 1, Max: 10}}}); err != nil {
+		t.Errorf("unexpected error for a non-destructive plan: %v", err)
+	}
+}
+
+func TestConfirmedConfigPhase(t *testing.T) {
+	base := &phase{Name: "burst", Pattern: "burst", Min: 1, Max: 10, Interval: 15 * time.Minute}
+	if err := base.validate(); err != nil {
+		t.Fatal(err)
+	}
+	s := newScaler()
+	if _, err := s.confirmedConfigPhase(base, map[string]string{"min": "0"}); err == nil {
+		t.Error("expected an error for a config scaling to zero")
+	}
+	s.confirmDestructive = true
+	if ph, err := s.confirmedConfigPhase(base, map[string]string{"min": "0"}); err != nil || ph.Min != 0 {
+		t.Errorf("want min 0 with --confirm-destructive, got %+v, %v", ph, err)
+	}
+}
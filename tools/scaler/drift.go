@@ -0,0 +1,96 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+var errReplicaDrift = errors.New("replicas changed outside of the scaler")
+
+// checkDrift compares the replicas in the cluster with the replicas the scaler applied last,
+// e.g. to find an HPA or a person that changes the workload during an experiment.
+// Drift is only logged unless fail on drift is set, the next apply sets the replicas again.
+func (s *scale) checkDrift() error {
+	if !s.detectDrift || s.applied == nil {
+		return nil
+	}
+	live := map[string]int32{}
+	var drifts []string
+	for _, t := range s.replicaTargets() {
+		// Only the stable and canary deployments are applied by the canary pattern, each with its own replicas.
+		split, ok := s.appliedSplit[t.Name]
+		if s.appliedSplit != nil && !ok {
+			continue
+		}
+		replicas, err := s.k8sClient.ScaleReplicas(t)
+		if err != nil {
+			log.Printf("Error reading the replicas for the drift detection: %v", err)
+			continue
+		}
+		if ok {
+			drifts = append(drifts, replicaDrifts(split, map[string]int32{t.String(): replicas})...)
+			continue
+		}
+		live[t.String()] = replicas
+	}
+	drifts = append(drifts, replicaDrifts(*s.applied, live)...)
+	sort.Strings(drifts)
+	for _, d := range drifts {
+		log.Printf("Replica drift detected - %v", d)
+		s.metrics.replicaDrifts.Inc()
+	}
+	if len(drifts) > 0 && s.failOnDrift {
+		return errors.Wrapf(errReplicaDrift, "%v", strings.Join(drifts, ", "))
+	}
+	return nil
+}
+
+// replicaDrifts describes the targets whose live replicas differ from the applied replicas, sorted by target.
+func replicaDrifts(applied int32, live map[string]int32) []string {
+	var drifts []string
+	for target, replicas := range live {
+		if replicas != applied {
+			drifts = append(drifts, fmt.Sprintf("%v has %d replicas, the scaler applied %d", target, replicas, applied))
+		}
+	}
+	sort.Strings(drifts)
+	return drifts
+}
+
+// replicaTargets returns the scale targets or the deployments, statefulsets and replicasets from the files,
+// which are read through their scale subresource.
+func (s *scale) replicaTargets() []k8s.ScaleTarget {
+	if len(s.scaleTargets) > 0 {
+		return s.scaleTargets
+	}
+	var targets []k8s.ScaleTarget
+	for _, deployment := range s.k8sClient.GetResources() {
+		for _, resource := range deployment.Objects {
+			o, ok := scalableObject(resource)
+			if !ok || s.skipDeployment(o.name) {
+				continue
+			}
+			targets = append(targets, k8s.ScaleTarget{Resource: o.resource, Namespace: o.namespace, Name: o.name})
+		}
+	}
+	return targets
+}
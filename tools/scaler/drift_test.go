@@ -0,0 +1,45 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReplicaDrifts(t *testing.T) {
+	live := map[string]int32{
+		"deployments.apps/prombench/loadgen": 10,
+		"deployments.apps/prombench/querier": 4,
+		"deployments.apps/prombench/writer":  3,
+	}
+	expected := []string{
+		"deployments.apps/prombench/loadgen has 10 replicas, the scaler applied 4",
+		"deployments.apps/prombench/writer has 3 replicas, the scaler applied 4",
+	}
+	if drifts := replicaDrifts(4, live); !reflect.DeepEqual(drifts, expected) {
+		t.Errorf("want drifts %v, got %v", expected, drifts)
+	}
+	if drifts := replicaDrifts(4, map[string]int32{"deployments.apps/prombench/querier": 4}); len(drifts) != 0 {
+		t.Errorf("want no drift, got %v", drifts)
+	}
+}
+
+func TestCheckDriftBeforeFirstApply(t *testing.T) {
+	// The scaler has no model of the replicas before its first apply, so there is nothing to compare.
+	s := &scale{detectDrift: true, failOnDrift: true}
+	if err := s.checkDrift(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
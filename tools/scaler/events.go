@@ -0,0 +1,164 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// eventController is the reporting controller of the events created by the scaler.
+const eventController = "prombench.prometheus.io/scaler"
+
+// eventRecorder creates a Kubernetes Event for the scaled objects on every replica change and failed apply,
+// so kubectl get events shows what the scaler did. The events are rate limited by a token bucket
+// of burst events refilled one every interval, so a short scaling interval doesn't flood the event store.
+type eventRecorder struct {
+	// resolve returns the reference of a scaled object and create creates an event, both through the k8s client.
+	resolve  func(gvr schema.GroupVersionResource, namespace, name string) (apiCoreV1.ObjectReference, error)
+	create   func(k8s.ObjectEvent) error
+	instance string
+	burst    int
+	every    time.Duration
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	// suppressed counts the scaling events dropped by the rate limit since the last created one.
+	suppressed int
+}
+
+func newEventRecorder(k *k8s.K8s, burst int, every time.Duration) *eventRecorder {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+	return &eventRecorder{
+		resolve:  k.ObjectReference,
+		create:   k.CreateEvent,
+		instance: instance,
+		burst:    burst,
+		every:    every,
+		tokens:   float64(burst),
+	}
+}
+
+// allow takes a token at now and returns the number of scaling events suppressed before it,
+// or false when no token is left.
+func (r *eventRecorder) allow(now time.Time) (bool, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.last.IsZero() {
+		r.tokens += float64(now.Sub(r.last)) / float64(r.every)
+		if r.tokens > float64(r.burst) {
+			r.tokens = float64(r.burst)
+		}
+	}
+	r.last = now
+	if r.tokens < 1 {
+		r.suppressed++
+		return false, 0
+	}
+	r.tokens--
+	suppressed := r.suppressed
+	r.suppressed = 0
+	return true, suppressed
+}
+
+// record creates the events of one scaling event, one per scaled object, when the rate limit allows it.
+// Failures are only logged as they shouldn't interrupt the scaling.
+func (r *eventRecorder) record(now time.Time, events []k8s.ObjectEvent) {
+	ok, suppressed := r.allow(now)
+	if !ok {
+		return
+	}
+	for _, e := range events {
+		if suppressed > 0 {
+			e.Note += fmt.Sprintf(" (%d earlier scaling events were dropped by the rate limit)", suppressed)
+		}
+		e.Action = "Scale"
+		e.ReportingController, e.ReportingInstance = eventController, r.instance
+		if err := r.create(e); err != nil {
+			log.Printf("Error creating the %v event: %v", e.Reason, err)
+		}
+	}
+}
+
+var deploymentsResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+// recordEvent creates the events of an apply when the events are enabled, before the applied replicas are updated:
+// Scaled when the replicas changed, ScaleFailed when the apply failed.
+func (s *scale) recordEvent(replicas, target int32, applyErr error) {
+	if s.events == nil {
+		return
+	}
+	if applyErr == nil && s.applied != nil && *s.applied == replicas && reflect.DeepEqual(s.split, s.appliedSplit) {
+		return
+	}
+	var events []k8s.ObjectEvent
+	for _, obj := range s.eventObjects() {
+		ref, err := s.events.resolve(obj.Resource, obj.Namespace, obj.Name)
+		if err != nil {
+			log.Printf("Error resolving the object of the scaling event: %v", err)
+			continue
+		}
+		r := replicas
+		if s.split != nil {
+			r = s.split[obj.Name]
+		}
+		e := k8s.ObjectEvent{Regarding: ref, Reason: "Scaled"}
+		switch {
+		case applyErr != nil:
+			e.Reason, e.Warning = "ScaleFailed", true
+			e.Note = fmt.Sprintf("Scaling to %d replicas failed: %v", r, applyErr)
+		case s.applied == nil:
+			e.Note = fmt.Sprintf("Scaled to %d replicas, target %d", r, target)
+		default:
+			from := *s.applied
+			if s.appliedSplit != nil {
+				from = s.appliedSplit[obj.Name]
+			}
+			e.Note = fmt.Sprintf("Scaled from %d to %d replicas, target %d", from, r, target)
+		}
+		if s.traceID != "" {
+			e.Note += ", trace_id=" + s.traceID
+		}
+		events = append(events, e)
+	}
+	s.events.record(s.clock.Now(), events)
+}
+
+// eventObjects returns the objects scaled by this scaler, the scale targets or the deployments of updateReplicas.
+func (s *scale) eventObjects() []k8s.ScaleTarget {
+	if len(s.scaleTargets) > 0 {
+		return s.scaleTargets
+	}
+	var objects []k8s.ScaleTarget
+	for _, d := range s.scaledDeployments() {
+		for _, obj := range d.Objects {
+			o, _ := scalableObject(obj)
+			objects = append(objects, k8s.ScaleTarget{Resource: o.resource, Namespace: o.namespace, Name: o.name})
+		}
+	}
+	return objects
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apiCoreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// newTestEventRecorder returns a recorder that resolves the objects without a cluster and keeps the created events.
+func newTestEventRecorder(burst int, every time.Duration, created *[]k8s.ObjectEvent) *eventRecorder {
+	return &eventRecorder{
+		resolve: func(gvr schema.GroupVersionResource, namespace, name string) (apiCoreV1.ObjectReference, error) {
+			if name == "missing" {
+				return apiCoreV1.ObjectReference{}, errors.New("not found")
+			}
+			return apiCoreV1.ObjectReference{Kind: "Rollout", Namespace: namespace, Name: name, UID: "1234"}, nil
+		},
+		create: func(e k8s.ObjectEvent) error {
+			*created = append(*created, e)
+			return nil
+		},
+		instance: "scaler-0",
+		burst:    burst,
+		every:    every,
+		tokens:   float64(burst),
+	}
+}
+
+func TestEventRateLimit(t *testing.T) {
+	var created []k8s.ObjectEvent
+	r := newTestEventRecorder(2, time.Minute, &created)
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	event := []k8s.ObjectEvent{{Reason: "Scaled", Note: "Scaled to 5 replicas"}}
+
+	// The burst is used up right away, the third event is dropped until a token is refilled.
+	for i, at := range []time.Duration{0, time.Second, 2 * time.Second, 30 * time.Second, 62 * time.Second} {
+		r.record(start.Add(at), event)
+		if want := []int{1, 2, 2, 2, 3}[i]; len(created) != want {
+			t.Fatalf("event at %s: want %d created events, got %d", at, want, len(created))
+		}
+	}
+	last := created[2]
+	if !strings.HasSuffix(last.Note, "(2 earlier scaling events were dropped by the rate limit)") {
+		t.Errorf("want the dropped events counted in the note, got %q", last.Note)
+	}
+	if last.Action != "Scale" || last.ReportingController != eventController || last.ReportingInstance != "scaler-0" {
+		t.Errorf("unexpected event %+v", last)
+	}
+	if strings.Contains(created[0].Note, "dropped") {
+		t.Errorf("want no dropped events in the first note, got %q", created[0].Note)
+	}
+
+	// The tokens are refilled up to the burst.
+	r.record(start.Add(time.Hour), event)
+	r.record(start.Add(time.Hour), event)
+	r.record(start.Add(time.Hour), event)
+	if len(created) != 5 {
+		t.Errorf("want the burst refilled to 2 events, got %d created events", len(created))
+	}
+}
+
+func TestRecordEvent(t *testing.T) {
+	var created []k8s.ObjectEvent
+	s := newScaler()
+	s.clock = newVirtualClock(time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))
+	s.scaleTargets = []k8s.ScaleTarget{{Resource: schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}, Namespace: "prombench", Name: "loadgen"}}
+
+	// Without the events enabled nothing is recorded.
+	s.recordEvent(5, 5, nil)
+
+	s.events = newTestEventRecorder(10, time.Minute, &created)
+	applied := func(r int32) { s.applied = &r }
+	s.recordEvent(5, 5, nil)
+	applied(5)
+	// The same replicas again aren't a change.
+	s.recordEvent(5, 5, nil)
+	s.traceID = "abcd"
+	s.recordEvent(8, 10, nil)
+	applied(8)
+	s.traceID = ""
+	s.recordEvent(10, 10, errors.New("forbidden"))
+
+	want := []struct {
+		reason  string
+		note    string
+		warning bool
+	}{
+		{reason: "Scaled", note: "Scaled to 5 replicas, target 5"},
+		{reason: "Scaled", note: "Scaled from 5 to 8 replicas, target 10, trace_id=abcd"},
+		{reason: "ScaleFailed", note: "Scaling to 10 replicas failed: forbidden", warning: true},
+	}
+	if len(created) != len(want) {
+		t.Fatalf("want %d events, got %+v", len(want), created)
+	}
+	for i, w := range want {
+		e := created[i]
+		if e.Reason != w.reason || e.Note != w.note || e.Warning != w.warning {
+			t.Errorf("event %d: want %v %q warning:%v, got %v %q warning:%v", i, w.reason, w.note, w.warning, e.Reason, e.Note, e.Warning)
+		}
+		if e.Regarding.Name != "loadgen" || e.Regarding.Namespace != "prombench" || e.Regarding.UID != "1234" {
+			t.Errorf("event %d: unexpected object %+v", i, e.Regarding)
+		}
+	}
+
+	// An object that can't be resolved is skipped.
+	s.scaleTargets[0].Name = "missing"
+	s.recordEvent(1, 1, nil)
+	if len(created) != len(want) {
+		t.Errorf("want no event for an object that can't be resolved, got %+v", created[len(want):])
+	}
+}
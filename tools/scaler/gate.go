@@ -0,0 +1,143 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	promV1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// healthGate pauses the scaling while the Prometheus under test is unhealthy, e.g. while it restarts
+// or is out of memory, so no load is generated that it can't measure.
+type healthGate struct {
+	// url is the Prometheus whose /-/healthy endpoint is checked, or which is queried when the query is set.
+	url string
+	// query is healthy when it returns at least one sample and none of them is 0, e.g. up{job="prometheus"}.
+	query string
+	// interval is how often the health is checked again while paused.
+	interval time.Duration
+	timeout  time.Duration
+	api      promV1.API
+}
+
+// newHealthGate returns the gate of the Prometheus at url, nil when the url is empty.
+func newHealthGate(url, query string, interval, timeout time.Duration) (*healthGate, error) {
+	if url == "" {
+		if query != "" {
+			return nil, errors.New("--health-gate-query requires the --health-gate-url of the queried Prometheus")
+		}
+		return nil, nil
+	}
+	if interval <= 0 {
+		return nil, errors.Errorf("invalid health-gate-interval %s, must be > 0", interval)
+	}
+	if timeout <= 0 {
+		return nil, errors.Errorf("invalid health-gate-timeout %s, must be > 0", timeout)
+	}
+	g := &healthGate{url: strings.TrimSuffix(url, "/"), query: query, interval: interval, timeout: timeout}
+	if query != "" {
+		client, err := api.NewClient(api.Config{Address: g.url})
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating the Prometheus client of the health gate")
+		}
+		g.api = promV1.NewAPI(client)
+	}
+	return g, nil
+}
+
+// check returns why the Prometheus is unhealthy, nil when it is healthy.
+func (g *healthGate) check() error {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	if g.query == "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url+"/-/healthy", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return errors.Errorf("%s/-/healthy returned %v", g.url, resp.Status)
+		}
+		return nil
+	}
+
+	result, _, err := g.api.Query(ctx, g.query, time.Now())
+	if err != nil {
+		return errors.Wrapf(err, "querying %q", g.query)
+	}
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return errors.Errorf("the health gate query %q must return an instant vector, got %v", g.query, result.Type())
+	}
+	if len(vector) == 0 {
+		return errors.Errorf("the health gate query %q returned no samples", g.query)
+	}
+	for _, sample := range vector {
+		if sample.Value == 0 {
+			return errors.Errorf("the health gate query %q is 0 for %v", g.query, sample.Metric)
+		}
+	}
+	return nil
+}
+
+// holdWhileUnhealthy waits while the health gate reports the Prometheus under test as unhealthy, so the replicas are held,
+// and returns true when the phase ended while waiting. The pause and the resume are logged once each.
+func (s *scale) holdWhileUnhealthy(ph *phase, start time.Time) bool {
+	if s.healthGate == nil {
+		return false
+	}
+	var pausedSince time.Time
+	for {
+		if s.stopped() {
+			return true
+		}
+		err := s.healthGate.check()
+		if err == nil {
+			if !pausedSince.IsZero() {
+				s.logf("Resuming the scaling, the Prometheus under test is healthy again after %s", s.clock.Now().Sub(pausedSince).Round(time.Second))
+			}
+			s.metrics.paused.Set(0)
+			return false
+		}
+		if pausedSince.IsZero() {
+			s.logf("Pausing the scaling at %d replicas, the Prometheus under test is unhealthy: %v", s.current, err)
+			pausedSince = s.clock.Now()
+			s.metrics.paused.Set(1)
+			s.metrics.push()
+		}
+		wait := s.healthGate.interval
+		if ph.Duration > 0 {
+			left := ph.Duration - s.clock.Now().Sub(start)
+			if left <= 0 {
+				return true
+			}
+			if left < wait {
+				wait = left
+			}
+		}
+		s.health.progress(wait)
+		s.clock.Sleep(wait)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHealthGateCheck(t *testing.T) {
+	healthy := true
+	up := "1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-/healthy":
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		case "/api/v1/query":
+			w.Header().Set("Content-Type", "application/json")
+			samples := ""
+			if up != "" {
+				samples = fmt.Sprintf(`{"metric":{"job":"prometheus"},"value":[1700000000,%q]}`, up)
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[%s]}}`, samples)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	g, err := newHealthGate(srv.URL+"/", "", time.Second, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.check(); err != nil {
+		t.Errorf("want healthy, got %v", err)
+	}
+	healthy = false
+	if err := g.check(); err == nil {
+		t.Error("want unhealthy when /-/healthy fails")
+	}
+
+	g, err = newHealthGate(srv.URL, `up{job="prometheus"}`, time.Second, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.check(); err != nil {
+		t.Errorf("want the query to be healthy, got %v", err)
+	}
+	for _, v := range []string{"0", ""} {
+		up = v
+		if err := g.check(); err == nil {
+			t.Errorf("up %q: want unhealthy", v)
+		}
+	}
+
+	for _, tc := range []struct {
+		url, query        string
+		interval, timeout time.Duration
+	}{
+		{query: "up"},
+		{url: srv.URL, timeout: time.Second},
+		{url: srv.URL, interval: time.Second},
+	} {
+		if _, err := newHealthGate(tc.url, tc.query, tc.interval, tc.timeout); err == nil {
+			t.Errorf("%+v: expected an error", tc)
+		}
+	}
+	if g, err := newHealthGate("", "", 0, 0); g != nil || err != nil {
+		t.Errorf("want no gate without a url, got %v, %v", g, err)
+	}
+}
+
+func TestHoldWhileUnhealthy(t *testing.T) {
+	checks := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		checks++
+		if checks <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	s := newScaler()
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	s.clock = newVirtualClock(start)
+	var err error
+	if s.healthGate, err = newHealthGate(srv.URL, "", 30*time.Second, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	ph := &phase{Name: "burst"}
+	if s.holdWhileUnhealthy(ph, start) {
+		t.Error("want the scaling to resume once healthy")
+	}
+	if waited := s.clock.Now().Sub(start); waited != time.Minute {
+		t.Errorf("want a pause of 2 check intervals, got %s", waited)
+	}
+	if v := testutil.ToFloat64(s.metrics.paused); v != 0 {
+		t.Errorf("want scaler_paused 0 after resuming, got %v", v)
+	}
+
+	// Unhealthy for longer than the phase.
+	checks = -10
+	ph.Duration = 45 * time.Second
+	if !s.holdWhileUnhealthy(ph, s.clock.Now()) {
+		t.Error("want the phase to end while paused")
+	}
+	if v := testutil.ToFloat64(s.metrics.paused); v != 1 {
+		t.Errorf("want scaler_paused 1 while paused, got %v", v)
+	}
+}
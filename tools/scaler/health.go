@@ -0,0 +1,83 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// health tracks the state reported by the liveness and readiness endpoints.
+type health struct {
+	mtx sync.Mutex
+	// ready is set once the k8s client is connected and the first cycle has started.
+	ready bool
+	// lastProgress is the time of the last apply and interval the wait before the next one.
+	lastProgress time.Time
+	interval     time.Duration
+	now          func() time.Time
+}
+
+func newHealth() *health {
+	return &health{now: time.Now}
+}
+
+// progress records that the scaling loop applied replicas and will wait for the interval.
+// The first call marks the scaler as ready.
+func (h *health) progress(interval time.Duration) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.ready = true
+	h.lastProgress = h.now()
+	h.interval = interval
+}
+
+// stuck returns an error when the loop made no progress for more than 2 intervals.
+func (h *health) stuck() error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if !h.ready {
+		return nil
+	}
+	if since := h.now().Sub(h.lastProgress); since > 2*h.interval {
+		return fmt.Errorf("no scaling progress for %s, interval is %s", since.Round(time.Second), h.interval)
+	}
+	return nil
+}
+
+func (h *health) isReady() bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.ready
+}
+
+// healthz reports the scaler as live unless the scaling loop is stuck.
+func (h *health) healthz(w http.ResponseWriter, _ *http.Request) {
+	if err := h.stuck(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// readyz reports the scaler as ready once the first scaling cycle has started.
+func (h *health) readyz(w http.ResponseWriter, _ *http.Request) {
+	if !h.isReady() {
+		http.Error(w, "scaling hasn't started", http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	now := time.Unix(0, 0)
+	h := newHealth()
+	h.now = func() time.Time { return now }
+
+	check := func(handler http.HandlerFunc, code int) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != code {
+			t.Errorf("want status %d, got %d: %s", code, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Live but not ready before the first cycle.
+	check(h.healthz, http.StatusOK)
+	check(h.readyz, http.StatusServiceUnavailable)
+
+	h.progress(time.Minute)
+	check(h.healthz, http.StatusOK)
+	check(h.readyz, http.StatusOK)
+
+	now = now.Add(2 * time.Minute)
+	check(h.healthz, http.StatusOK)
+
+	// No progress beyond 2 intervals.
+	now = now.Add(time.Second)
+	check(h.healthz, http.StatusServiceUnavailable)
+	check(h.readyz, http.StatusOK)
+
+	h.progress(time.Minute)
+	check(h.healthz, http.StatusOK)
+}
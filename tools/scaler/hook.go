@@ -0,0 +1,115 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var errHookFailure = errors.New("cycle hook failed")
+
+// hookVars returns the variables describing a scaling cycle passed to the hooks.
+// The interval is the one of the step, it changes from step to step with an interval ramp.
+func hookVars(hook string, ph *phase, step int, target, current int32, interval time.Duration) map[string]string {
+	return map[string]string{
+		"SCALER_HOOK":             hook,
+		"SCALER_PHASE":            ph.Name,
+		"SCALER_PATTERN":          ph.Pattern,
+		"SCALER_STEP":             fmt.Sprint(step),
+		"SCALER_TARGET_REPLICAS":  fmt.Sprint(target),
+		"SCALER_CURRENT_REPLICAS": fmt.Sprint(current),
+		"SCALER_MIN":              fmt.Sprint(ph.Min),
+		"SCALER_MAX":              fmt.Sprint(ph.Max),
+		"SCALER_INTERVAL":         interval.String(),
+	}
+}
+
+// runHook runs a pre or post cycle hook.
+// Failures are only logged unless the hooks are strict.
+func (s *scale) runHook(hook, cmd string, vars map[string]string) error {
+	if cmd == "" {
+		return nil
+	}
+	if s.traceID != "" {
+		vars["SCALER_TRACE_ID"] = s.traceID
+	}
+	if s.deployment != "" {
+		vars["SCALER_DEPLOYMENT"] = s.deployment
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.hookTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := execHook(ctx, cmd, vars)
+	if err == nil {
+		log.Printf("%s-cycle hook completed in %s", hook, time.Since(start).Round(time.Millisecond))
+		return nil
+	}
+	if s.strictHooks {
+		return errors.Wrapf(errHookFailure, "%s-cycle hook: %v", hook, err)
+	}
+	log.Printf("WARNING: %s-cycle hook failed: %v", hook, err)
+	return nil
+}
+
+// execHook posts the variables as json when the hook is a url,
+// otherwise it runs the hook with sh and passes the variables as env vars.
+func execHook(ctx context.Context, hook string, vars map[string]string) error {
+	if strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://") {
+		body, err := json.Marshal(vars)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return errors.Errorf("unexpected status %v", resp.Status)
+		}
+		return nil
+	}
+
+	env := os.Environ()
+	for k, v := range vars {
+		env = append(env, k+"="+v)
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook)
+	cmd.Env = env
+	out, err := cmd.CombinedOutput()
+	if len(out) > 0 {
+		log.Printf("hook output: %s", bytes.TrimSpace(out))
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
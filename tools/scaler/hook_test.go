@@ -0,0 +1,79 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestRunHook(t *testing.T) {
+	ph := &phase{Name: "burst", Pattern: "burst", Min: 1, Max: 20, Interval: time.Minute}
+	vars := hookVars("pre", ph, 3, 1, 20, ph.Interval)
+
+	t.Run("command", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out")
+		s := &scale{hookTimeout: time.Minute}
+		if err := s.runHook("pre", `echo "$SCALER_HOOK $SCALER_PHASE $SCALER_STEP $SCALER_TARGET_REPLICAS $SCALER_CURRENT_REPLICAS" > `+out, vars); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "pre burst 3 1 20\n"; string(got) != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("url", func(t *testing.T) {
+		var got map[string]string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decoding the hook body: %v", err)
+			}
+		}))
+		defer srv.Close()
+
+		s := &scale{hookTimeout: time.Minute}
+		if err := s.runHook("pre", srv.URL, vars); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got["SCALER_TARGET_REPLICAS"] != "1" || got["SCALER_INTERVAL"] != "1m0s" {
+			t.Errorf("unexpected hook body %v", got)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		s := &scale{hookTimeout: time.Minute}
+		if err := s.runHook("post", "exit 1", vars); err != nil {
+			t.Errorf("expected hook failures to be non-fatal, got: %v", err)
+		}
+		s.strictHooks = true
+		if err := s.runHook("post", "exit 1", vars); !errors.Is(err, errHookFailure) {
+			t.Errorf("expected a hook failure with strict hooks, got: %v", err)
+		}
+		s.hookTimeout = 10 * time.Millisecond
+		if err := s.runHook("post", "sleep 1", vars); !errors.Is(err, errHookFailure) {
+			t.Errorf("expected a hook timeout with strict hooks, got: %v", err)
+		}
+	})
+}
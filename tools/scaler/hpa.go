@@ -0,0 +1,159 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	promV1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// hpaQueryTimeout is how long the metric query of every step of the hpa pattern may take.
+const hpaQueryTimeout = 30 * time.Second
+
+// hpa computes the replicas with the algorithm of the Kubernetes HorizontalPodAutoscaler from the current value of a metric,
+// without an actual HPA: ceil(current replicas * metric / target), unchanged while the ratio is within the tolerance.
+// The downscale stabilization keeps the highest recommendation of the window, so the replicas only go down once the
+// metric stayed low for the whole window, and the result is kept within min and max.
+// The replicas of the previous step are the current replicas of the next one, the first step starts at min.
+type hpa struct {
+	min, max          int32
+	target, tolerance float64
+	// window is the number of previous steps in the downscale stabilization window.
+	window int
+	query  func() (float64, error)
+	// current, recommendations and lastRaw are the state of the running phase, reset at its first step.
+	// The min is at least 1, so a current of 0 is a pattern that hasn't run yet.
+	current         int32
+	recommendations []int32
+	lastRaw         float64
+}
+
+// newHPA returns the hpa pattern of a phase that queries its metric with the instant query of the phase.
+func newHPA(ph *phase) (*hpa, error) {
+	if ph.PrometheusURL == "" || ph.Query == "" {
+		return nil, errors.New("the hpa pattern requires a Prometheus url and a query")
+	}
+	client, err := api.NewClient(api.Config{Address: ph.PrometheusURL})
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating the Prometheus client")
+	}
+	return newHPAWithQuery(ph, func() (float64, error) { return queryMetric(promV1.NewAPI(client), ph.Query) })
+}
+
+// newHPAWithQuery validates the hpa options of a phase and returns the pattern that gets the metric from query.
+func newHPAWithQuery(ph *phase, query func() (float64, error)) (*hpa, error) {
+	if ph.Min < 1 {
+		return nil, errors.Errorf("invalid min %d for the hpa pattern, must be >= 1 as the replicas are scaled by the metric ratio", ph.Min)
+	}
+	if ph.TargetValue <= 0 || math.IsInf(ph.TargetValue, 0) || math.IsNaN(ph.TargetValue) {
+		return nil, errors.Errorf("invalid target value %v for the hpa pattern, must be > 0", ph.TargetValue)
+	}
+	if ph.Tolerance < 0 || ph.Tolerance >= 1 {
+		return nil, errors.Errorf("invalid tolerance %v for the hpa pattern, must be >= 0 and < 1", ph.Tolerance)
+	}
+	if ph.StabilizationWindow < 0 {
+		return nil, errors.Errorf("invalid stabilization window %s for the hpa pattern, must be >= 0", ph.StabilizationWindow)
+	}
+	return &hpa{
+		min:       ph.Min,
+		max:       ph.Max,
+		target:    ph.TargetValue,
+		tolerance: ph.Tolerance,
+		window:    int(ph.StabilizationWindow / ph.Interval),
+		query:     query,
+	}, nil
+}
+
+func (h *hpa) replicas(step int) int32 {
+	// A phase reloaded from the ConfigMap gets a new pattern, which starts over as well.
+	if step == 0 || h.current == 0 {
+		h.current, h.recommendations = h.min, nil
+	}
+	recommendation, raw := h.current, float64(h.current)
+	if metric, err := h.query(); err != nil {
+		// Like the HPA the replicas are kept when the metric is unavailable.
+		log.Printf("Error getting the metric of the hpa pattern, keeping %d replicas: %v", h.current, err)
+	} else {
+		recommendation, raw = hpaRecommendation(h.current, metric, h.target, h.tolerance)
+	}
+	h.lastRaw = raw
+	h.recommendations = append(h.recommendations, clampReplicas(float64(recommendation), h.min, h.max))
+	if len(h.recommendations) > h.window+1 {
+		h.recommendations = h.recommendations[len(h.recommendations)-h.window-1:]
+	}
+	desired := h.recommendations[0]
+	for _, r := range h.recommendations[1:] {
+		if r > desired {
+			desired = r
+		}
+	}
+	h.current = desired
+	return desired
+}
+
+// raw is the unrounded replicas of the metric ratio of the last step, before the tolerance, the stabilization and the bounds.
+func (h *hpa) raw(int) float64 {
+	return h.lastRaw
+}
+
+// hpaRecommendation returns the replicas the HPA recommends for the metric and the unrounded replicas of the ratio.
+// The current replicas are kept while the ratio of the metric to the target is within the tolerance of 1.
+func hpaRecommendation(current int32, metric, target, tolerance float64) (int32, float64) {
+	ratio := metric / target
+	raw := float64(current) * ratio
+	if math.Abs(1-ratio) <= tolerance {
+		return current, raw
+	}
+	r := math.Ceil(raw)
+	if r > math.MaxInt32 {
+		return math.MaxInt32, raw
+	}
+	return int32(r), raw
+}
+
+// queryMetric returns the current value of a query that must return a single sample, e.g. the average CPU usage per pod.
+func queryMetric(client promV1.API, query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), hpaQueryTimeout)
+	defer cancel()
+	result, warnings, err := client.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, errors.Wrapf(err, "querying %q", query)
+	}
+	for _, w := range warnings {
+		log.Printf("Warning from the hpa query %q: %v", query, w)
+	}
+	var v float64
+	switch r := result.(type) {
+	case model.Vector:
+		if len(r) != 1 {
+			return 0, errors.Errorf("the hpa query %q must return a single series, got %d, aggregate it e.g. with avg()", query, len(r))
+		}
+		v = float64(r[0].Value)
+	case *model.Scalar:
+		v = float64(r.Value)
+	default:
+		return 0, errors.Errorf("the hpa query %q must return an instant vector or a scalar, got %v", query, result.Type())
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+		return 0, errors.Errorf("invalid value %v of the hpa query %q, must be >= 0", v, query)
+	}
+	return v, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHPARecommendation(t *testing.T) {
+	for _, tc := range []struct {
+		current        int32
+		metric, target float64
+		want           int32
+	}{
+		{current: 4, metric: 0.5, target: 0.5, want: 4},
+		{current: 4, metric: 0.54, target: 0.5, want: 4},
+		{current: 4, metric: 0.46, target: 0.5, want: 4},
+		{current: 4, metric: 0.6, target: 0.5, want: 5},
+		{current: 4, metric: 1, target: 0.5, want: 8},
+		{current: 4, metric: 0.2, target: 0.5, want: 2},
+		{current: 3, metric: 0, target: 0.5, want: 0},
+	} {
+		if got, _ := hpaRecommendation(tc.current, tc.metric, tc.target, 0.1); got != tc.want {
+			t.Errorf("%d replicas at %v of %v: want %d, got %d", tc.current, tc.metric, tc.target, tc.want, got)
+		}
+	}
+}
+
+func TestHPAPattern(t *testing.T) {
+	// The metric is the load divided by the replicas of the previous step, like the CPU usage per pod of a fixed load.
+	loads := []float64{2, 2, 4, 4, 4, 1, 1, 1, 1, 1, 1}
+	var step int
+	var h *hpa
+	p, err := newHPAWithQuery(&phase{Pattern: "hpa", Min: 1, Max: 6, Interval: time.Minute, TargetValue: 0.5, Tolerance: 0.1, StabilizationWindow: 3 * time.Minute}, func() (float64, error) {
+		if step == 3 {
+			return 0, errors.New("unavailable")
+		}
+		return loads[step] / float64(h.current), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h = p
+	var got []int32
+	for step = range loads {
+		got = append(got, h.replicas(step))
+	}
+	// It scales up at once, up to max, keeps the replicas while the metric is missing, and scales down
+	// to the recommendation of 2 replicas for the low load only after the 3 steps of the window.
+	want := []int32{4, 4, 6, 6, 6, 6, 6, 6, 2, 2, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want the replicas %v, got %v", want, got)
+	}
+
+	step = 0
+	if r := h.replicas(0); r != 4 {
+		t.Errorf("want the pattern to start over from min at the first step, got %d", r)
+	}
+
+	for _, invalid := range []*phase{
+		{Min: 0, Max: 5, Interval: time.Minute, TargetValue: 1},
+		{Min: 1, Max: 5, Interval: time.Minute},
+		{Min: 1, Max: 5, Interval: time.Minute, TargetValue: 1, Tolerance: 1},
+		{Min: 1, Max: 5, Interval: time.Minute, TargetValue: 1, StabilizationWindow: -time.Minute},
+	} {
+		if _, err := newHPAWithQuery(invalid, nil); err == nil {
+			t.Errorf("%+v: expected an error", invalid)
+		}
+	}
+	if _, err := newPattern(&phase{Pattern: "hpa", Min: 1, Max: 5, Interval: time.Minute, TargetValue: 1}); err == nil {
+		t.Error("want an error without a Prometheus url and a query")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loadIntegral accumulates the replica-seconds of a run, the applied replicas multiplied by how long they were held,
+// a single number to compare the load generated by different runs or patterns.
+type loadIntegral struct {
+	mtx sync.Mutex
+	// replicaSeconds are the replica-seconds by deployment of a per-deployment plan, by the empty name otherwise.
+	replicaSeconds map[string]float64
+}
+
+func newLoadIntegral() *loadIntegral {
+	return &loadIntegral{replicaSeconds: map[string]float64{}}
+}
+
+// add adds the replicas held by the deployment for the duration and returns the added replica-seconds.
+func (l *loadIntegral) add(deployment string, replicas int32, held time.Duration) float64 {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	v := float64(replicas) * held.Seconds()
+	l.replicaSeconds[deployment] += v
+	return v
+}
+
+// summary returns the total replica-seconds of the run and those of every deployment of a per-deployment plan.
+func (l *loadIntegral) summary() string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	var total float64
+	names := make([]string, 0, len(l.replicaSeconds))
+	for name, v := range l.replicaSeconds {
+		total += v
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%.0f replica-seconds, %.2f replica-hours", total, total/time.Hour.Seconds())
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n  %s: %.0f replica-seconds", name, l.replicaSeconds[name])
+	}
+	return b.String()
+}
+
+// accumulateLoad adds the replicas applied last, held since they were applied until now, to the load integral.
+func (s *scale) accumulateLoad(now time.Time) {
+	if s.applied == nil {
+		return
+	}
+	if held := now.Sub(s.appliedSince); held > 0 {
+		s.metrics.replicaSeconds.Add(s.loadIntegral.add(s.deployment, *s.applied, held))
+		s.appliedSince = now
+	}
+}
+
+// endLoad accumulates the replicas held until the end of the run, the end of a simulation at the latest.
+func (s *scale) endLoad() {
+	now := s.clock.Now()
+	if s.simulation != nil && now.After(s.simulation.until) {
+		now = s.simulation.until
+	}
+	s.accumulateLoad(now)
+	s.metrics.push()
+}
+
+// logLoad logs the replica-seconds of the run, by deployment with a per-deployment plan.
+func (s *scale) logLoad() {
+	log.Printf("Load summary: %s", s.loadIntegral.summary())
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLoadIntegral(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(f, []byte(simulatedPlan), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newScaler()
+	s.planFile = f
+	s.transitionSteps = 1
+	s.simulate = time.Hour
+	s.simulateStart = "2026-10-14T00:00:00Z"
+	s.out = io.Discard
+	if err := s.scale(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 3 replicas for 20m, 5 and 1 for 10m each and 3 again until the end of the simulation after 1h.
+	if v := testutil.ToFloat64(s.metrics.replicaSeconds); v != 10800 {
+		t.Errorf("want 10800 replica-seconds, got %v", v)
+	}
+	if want, got := "10800 replica-seconds, 3.00 replica-hours", s.loadIntegral.summary(); got != want {
+		t.Errorf("want the summary %q, got %q", want, got)
+	}
+}
+
+func TestLoadIntegralDeployments(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(f, []byte(`
+deployments:
+  prometheus:
+    phases:
+    - pattern: hold
+      max: 2
+      interval: 10m
+      duration: 30m
+  loadgen:
+    phases:
+    - pattern: burst
+      max: 4
+      min: 2
+      interval: 10m
+      duration: 40m
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newScaler()
+	s.planFile = f
+	s.transitionSteps = 1
+	s.simulate = time.Hour
+	s.simulateStart = "2026-10-14T00:00:00Z"
+	s.out = io.Discard
+	if err := s.scale(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Every deployment holds its last replicas until its plan completes.
+	want := `10800 replica-seconds, 3.00 replica-hours
+  loadgen: 7200 replica-seconds
+  prometheus: 3600 replica-seconds`
+	if got := s.loadIntegral.summary(); got != want {
+		t.Errorf("want the summary\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestAccumulateLoad(t *testing.T) {
+	s := newScaler()
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	s.accumulateLoad(start)
+	if v := testutil.ToFloat64(s.metrics.replicaSeconds); v != 0 {
+		t.Errorf("want no replica-seconds before the first apply, got %v", v)
+	}
+
+	replicas := int32(4)
+	s.applied, s.appliedSince = &replicas, start
+	s.accumulateLoad(start.Add(time.Minute))
+	// A clock that went backwards adds nothing.
+	s.accumulateLoad(start)
+	s.accumulateLoad(start.Add(90 * time.Second))
+	if v := testutil.ToFloat64(s.metrics.replicaSeconds); v != 360 {
+		t.Errorf("want 360 replica-seconds, got %v", v)
+	}
+}
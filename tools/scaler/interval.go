@@ -0,0 +1,71 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// validateIntervalRamp checks the interval ramp of the phase, the interval is the start of the ramp after this.
+func (ph *phase) validateIntervalRamp() error {
+	if ph.IntervalStart < 0 || ph.IntervalEnd < 0 || ph.IntervalRamp < 0 {
+		return errors.Errorf("phase %q: the intervalStart, intervalEnd and intervalRamp must be >= 0", ph.Name)
+	}
+	if ph.IntervalEnd == 0 {
+		if ph.IntervalStart > 0 || ph.IntervalRamp > 0 {
+			return errors.Errorf("phase %q: the intervalStart and intervalRamp require an intervalEnd > 0", ph.Name)
+		}
+		return nil
+	}
+	if ph.IntervalRamp == 0 && ph.Duration == 0 {
+		return errors.Errorf("phase %q: the interval ramp of a phase without a duration requires an intervalRamp > 0", ph.Name)
+	}
+	if ph.IntervalStart > 0 {
+		ph.Interval = ph.IntervalStart
+	}
+	return nil
+}
+
+// stepInterval returns the interval of the step that starts at elapsed into the phase.
+// With an interval ramp it moves linearly from the start to the end interval over the ramp, or the phase duration,
+// and stays at the end interval after that. The start can be longer or shorter than the end.
+func (ph *phase) stepInterval(elapsed time.Duration) time.Duration {
+	if ph.IntervalEnd == 0 {
+		return ph.Interval
+	}
+	ramp := ph.IntervalRamp
+	if ramp == 0 {
+		ramp = ph.Duration
+	}
+	if elapsed >= ramp {
+		return ph.IntervalEnd
+	}
+	f := float64(elapsed) / float64(ramp)
+	return ph.Interval + time.Duration(f*float64(ph.IntervalEnd-ph.Interval))
+}
+
+// intervalString describes the interval of the phase for the logs.
+func (ph *phase) intervalString() string {
+	if ph.IntervalEnd == 0 {
+		return ph.Interval.String()
+	}
+	ramp := ph.IntervalRamp
+	if ramp == 0 {
+		ramp = ph.Duration
+	}
+	return fmt.Sprintf("%s -> %s over %s", ph.Interval, ph.IntervalEnd, ramp)
+}
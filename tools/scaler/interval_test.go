@@ -0,0 +1,76 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStepInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		ph    *phase
+		steps map[time.Duration]time.Duration
+	}{
+		{
+			name:  "constant",
+			ph:    &phase{Interval: time.Minute},
+			steps: map[time.Duration]time.Duration{0: time.Minute, time.Hour: time.Minute},
+		},
+		{
+			name: "accelerating over the duration",
+			ph:   &phase{Interval: time.Minute, IntervalStart: 10 * time.Minute, IntervalEnd: time.Minute, Duration: time.Hour},
+			steps: map[time.Duration]time.Duration{
+				0:                10 * time.Minute,
+				30 * time.Minute: 330 * time.Second,
+				time.Hour:        time.Minute,
+			},
+		},
+		{
+			name: "decelerating over the ramp",
+			ph:   &phase{Interval: time.Minute, IntervalEnd: 5 * time.Minute, IntervalRamp: 2 * time.Hour},
+			steps: map[time.Duration]time.Duration{
+				0:             time.Minute,
+				time.Hour:     3 * time.Minute,
+				2 * time.Hour: 5 * time.Minute,
+				5 * time.Hour: 5 * time.Minute,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.ph.validateIntervalRamp(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for elapsed, want := range tc.steps {
+				if got := tc.ph.stepInterval(elapsed); got != want {
+					t.Errorf("after %s: want %s, got %s", elapsed, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateIntervalRamp(t *testing.T) {
+	for _, ph := range []*phase{
+		{Name: "negative end", Interval: time.Minute, IntervalEnd: -time.Minute, Duration: time.Hour},
+		{Name: "start without end", Interval: time.Minute, IntervalStart: time.Minute},
+		{Name: "ramp without end", Interval: time.Minute, IntervalRamp: time.Hour},
+		{Name: "endless phase without ramp", Interval: time.Minute, IntervalEnd: 10 * time.Second},
+	} {
+		if err := ph.validateIntervalRamp(); err == nil {
+			t.Errorf("%v: expected an error", ph.Name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// jitter randomly moves every target of a pattern up or down by up to percent of the target, never below min or above max.
+// The move only depends on the seed and the step, so the same step always gets the same replicas,
+// and every repetition of the phase gets a seed of its own.
+type jitter struct {
+	pattern  pattern
+	min, max int32
+	percent  int32
+	seed     int64
+}
+
+func (j jitter) replicas(step int) int32 {
+	target := j.pattern.replicas(step)
+	r := rand.New(rand.NewSource(j.seed + 1 + int64(step)))
+	moved := target + int32(math.Round(jitterShare(r, j.percent)*float64(target)))
+	if moved < j.min {
+		return j.min
+	}
+	if moved > j.max {
+		return j.max
+	}
+	return moved
+}
+
+// jitterShare returns a random share between -percent and +percent.
+func jitterShare(r *rand.Rand, percent int32) float64 {
+	return (2*r.Float64() - 1) * float64(percent) / 100
+}
+
+// withJitter wraps the pattern of a phase in a jitter when the phase sets one, otherwise it returns the pattern as is.
+func withJitter(ph *phase, pat pattern) (pattern, error) {
+	if ph.Jitter < 0 || ph.Jitter > 100 {
+		return nil, errors.Errorf("invalid jitter %d, must be between 0 and 100", ph.Jitter)
+	}
+	if ph.Jitter == 0 {
+		return pat, nil
+	}
+	switch pat.(type) {
+	case chaos, canary, *hpa:
+		return nil, errors.Errorf("the %s pattern doesn't compute its targets from the steps and can't be used with a jitter", ph.Pattern)
+	}
+	return jitter{pattern: pat, min: ph.Min, max: ph.Max, percent: ph.Jitter, seed: time.Now().UnixNano()}, nil
+}
+
+// repetitions returns how many times the phase runs in a row.
+func (ph *phase) repetitions() int {
+	if ph.Repeat > 1 {
+		return ph.Repeat
+	}
+	return 1
+}
+
+// repetition returns the phase to run for the repetition r, starting from 0.
+// With a jitter its duration is randomly moved up or down by up to the jitter percentage
+// and its targets get a new seed, otherwise every repetition is the phase itself.
+func (ph *phase) repetition(r int) *phase {
+	j, ok := ph.pattern.(jitter)
+	if !ok {
+		return ph
+	}
+	run := *ph
+	j.seed += int64(r) << 32
+	run.Duration += time.Duration(jitterShare(rand.New(rand.NewSource(j.seed)), j.percent) * float64(ph.Duration))
+	run.pattern = j
+	return &run
+}
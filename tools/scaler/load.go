@@ -0,0 +1,77 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// loadTarget runs a pattern whose min and max are request rates instead of replicas,
+// and converts the rate of every step to the replicas that serve it at perReplica requests/s each.
+// The replicas are kept within the hard bounds minReplicas and maxReplicas, maxReplicas 0 has no upper bound.
+type loadTarget struct {
+	pattern                  pattern
+	perReplica               float64
+	minReplicas, maxReplicas int32
+}
+
+func (l loadTarget) replicas(step int) int32 {
+	r := math.Ceil(float64(l.pattern.replicas(step)) / l.perReplica)
+	if r < float64(l.minReplicas) {
+		return l.minReplicas
+	}
+	if l.maxReplicas > 0 && r > float64(l.maxReplicas) {
+		return l.maxReplicas
+	}
+	if r > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(r)
+}
+
+// raw is the fractional replicas of the rate of the step, before they are rounded up and bounded.
+func (l loadTarget) raw(step int) float64 {
+	rate := float64(l.pattern.replicas(step))
+	if p, ok := l.pattern.(rawPattern); ok {
+		rate = p.raw(step)
+	}
+	return rate / l.perReplica
+}
+
+// withLoadTarget wraps the pattern of a phase in a loadTarget when the phase sets the per-replica capacity,
+// otherwise it returns the pattern as is.
+func withLoadTarget(ph *phase, pat pattern) (pattern, error) {
+	if ph.PerReplicaRPS == 0 {
+		if ph.MinReplicas != 0 || ph.MaxReplicas != 0 {
+			return nil, errors.New("the min and max replicas bound the replicas of a load target and require the per-replica rps")
+		}
+		return pat, nil
+	}
+	if ph.PerReplicaRPS < 0 || math.IsInf(ph.PerReplicaRPS, 0) || math.IsNaN(ph.PerReplicaRPS) {
+		return nil, errors.Errorf("invalid per-replica rps %v, must be > 0", ph.PerReplicaRPS)
+	}
+	if ph.MinReplicas < 0 || ph.MaxReplicas < 0 {
+		return nil, errors.Errorf("invalid min replicas: %d, max replicas: %d, must be >= 0", ph.MinReplicas, ph.MaxReplicas)
+	}
+	if ph.MaxReplicas > 0 && ph.MinReplicas > ph.MaxReplicas {
+		return nil, errors.Errorf("invalid min replicas: %d is bigger than max replicas: %d", ph.MinReplicas, ph.MaxReplicas)
+	}
+	switch pat.(type) {
+	case chaos, canary, *hpa:
+		return nil, errors.Errorf("the %s pattern works on replicas and can't be used with a per-replica rps", ph.Pattern)
+	}
+	return loadTarget{pattern: pat, perReplica: ph.PerReplicaRPS, minReplicas: ph.MinReplicas, maxReplicas: ph.MaxReplicas}, nil
+}
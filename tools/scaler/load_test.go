@@ -0,0 +1,59 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadTarget(t *testing.T) {
+	// The step pattern ramps from 100 to 1000 requests/s by 300, 250 requests/s per replica.
+	ph := &phase{Name: "load", Pattern: "step", Min: 100, Max: 1000, ScalingFactor: 300, Interval: time.Minute, PerReplicaRPS: 250}
+	if err := ph.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var replicas []int32
+	for i := 0; i < 7; i++ {
+		replicas = append(replicas, ph.pattern.replicas(i))
+	}
+	// 100, 400, 700 and 1000 requests/s need 1, 2, 3 and 4 replicas, the rate is rounded up to whole replicas.
+	// The ramp then goes back down to 100 requests/s.
+	if want := []int32{1, 2, 3, 4, 3, 2, 1}; !reflect.DeepEqual(want, replicas) {
+		t.Errorf("want %v, got %v", want, replicas)
+	}
+	if raw := ph.pattern.(rawPattern).raw(1); raw != 1.6 {
+		t.Errorf("want the raw replicas 1.6, got %v", raw)
+	}
+
+	bounded := &phase{Name: "bounded", Pattern: "burst", Min: 0, Max: 1000, Interval: time.Minute, PerReplicaRPS: 100, MinReplicas: 2, MaxReplicas: 5}
+	if err := bounded.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if max, min := bounded.pattern.replicas(0), bounded.pattern.replicas(1); max != 5 || min != 2 {
+		t.Errorf("want the replicas clamped to 5 and 2, got %d and %d", max, min)
+	}
+
+	for _, invalid := range []*phase{
+		{Name: "negative", Pattern: "hold", Max: 100, Interval: time.Minute, PerReplicaRPS: -1},
+		{Name: "bounds without rps", Pattern: "hold", Max: 100, Interval: time.Minute, MaxReplicas: 5},
+		{Name: "min over max", Pattern: "hold", Max: 100, Interval: time.Minute, PerReplicaRPS: 10, MinReplicas: 6, MaxReplicas: 5},
+		{Name: "chaos", Pattern: "chaos", Max: 100, Interval: time.Minute, KillRate: 1, MaxUnavailable: 1, PerReplicaRPS: 10},
+	} {
+		if err := invalid.validate(); err == nil {
+			t.Errorf("%s: expected an error", invalid.Name)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/model"
+)
+
+// deploymentLabel is added to the scaling metrics of every deployment of a per-deployment plan.
+const deploymentLabel = "deployment"
+
+// scalerMetrics holds the metrics describing the scaling timeline.
+type scalerMetrics struct {
+	registry *prometheus.Registry
+	// registerer adds the constant labels to every metric registered with it, nil until register is called.
+	registerer      prometheus.Registerer
+	targetReplicas  prometheus.Gauge
+	appliedReplicas prometheus.Gauge
+	applies         *prometheus.CounterVec
+	killedPods      prometheus.Counter
+	replicaDrifts   prometheus.Counter
+	replicaSeconds  prometheus.Counter
+	readyTimeouts   prometheus.Counter
+	// readyReplicas, convergenceError and converged are set by the convergence check at the end of every cycle.
+	readyReplicas    prometheus.Gauge
+	convergenceError prometheus.Gauge
+	converged        prometheus.Gauge
+	// paused is 1 while the health gate holds the replicas.
+	paused        prometheus.Gauge
+	configReloads *prometheus.CounterVec
+	warmup        prometheus.Gauge
+	activeWindow  prometheus.Gauge
+	// exemplars adds the trace ID of the scaling event as an exemplar to the applies and killed pods counters.
+	exemplars bool
+	// pusher is nil when the metrics are not pushed to a Pushgateway.
+	pusher *push.Pusher
+}
+
+func newScalerMetrics() *scalerMetrics {
+	m := &scalerMetrics{
+		registry: prometheus.NewRegistry(),
+		targetReplicas: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_target_replicas",
+			Help: "The number of replicas requested by the scaling pattern.",
+		}),
+		appliedReplicas: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_applied_replicas",
+			Help: "The number of replicas last applied successfully.",
+		}),
+		applies: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scaler_applies_total",
+			Help: "The number of replica applies, by result: success or failure.",
+		}, []string{"result"}),
+		killedPods: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "scaler_killed_pods_total",
+			Help: "The number of pods deleted by the chaos pattern.",
+		}),
+		replicaDrifts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "scaler_replica_drifts_total",
+			Help: "The number of times the replicas were found changed outside of the scaler.",
+		}),
+		replicaSeconds: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "scaler_replica_seconds_total",
+			Help: "The applied replicas multiplied by the seconds they were held, added when they change and at the end of the run.",
+		}),
+		readyTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "scaler_ready_timeouts_total",
+			Help: "The number of steps that continued after the ready timeout without the applied replicas being ready.",
+		}),
+		readyReplicas: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_ready_replicas",
+			Help: "The number of ready pods of the scaled objects at the end of the last cycle.",
+		}),
+		convergenceError: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_convergence_error",
+			Help: "The applied replicas minus the ready pods of the scaled objects at the end of the last cycle.",
+		}),
+		converged: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_converged",
+			Help: "1 when the ready pods were within the convergence tolerance of the applied replicas at the end of the last cycle, 0 otherwise.",
+		}),
+		paused: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_paused",
+			Help: "1 while the scaling is paused because the Prometheus under test is unhealthy, 0 otherwise.",
+		}),
+		configReloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scaler_config_reloads_total",
+			Help: "The number of config changes read from the ConfigMap, by result: success or invalid.",
+		}, []string{"result"}),
+		warmup: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_warmup",
+			Help: "1 during the warmup at the start of the run, whose measurements should be ignored, 0 afterwards.",
+		}),
+		activeWindow: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "scaler_active_window",
+			Help: "1 while the scaler changes the replicas, 0 while it holds them outside of the active windows.",
+		}),
+	}
+	return m
+}
+
+// register registers the metrics with the constant labels added to all of them,
+// e.g. to tell apart the metrics of several scalers on the same dashboard.
+// The job and instance labels are set by the scrape or the Pushgateway grouping key, so they can't be constant labels.
+func (m *scalerMetrics) register(labels map[string]string) error {
+	return m.registerWith(labels, append(m.scalingCollectors(), m.configReloads, m.warmup, m.activeWindow))
+}
+
+// registerShared registers only the metrics shared by the deployments of a per-deployment plan,
+// the scaling metrics are registered by forDeployment with the deployment label.
+func (m *scalerMetrics) registerShared(labels map[string]string) error {
+	if _, ok := labels[deploymentLabel]; ok {
+		return errors.Errorf("the metric label %q is set by the per-deployment plan", deploymentLabel)
+	}
+	return m.registerWith(labels, []prometheus.Collector{m.configReloads, m.warmup, m.activeWindow})
+}
+
+// forDeployment returns the scaling metrics of a deployment of a per-deployment plan, with the deployment label.
+// The registry, the pusher and the shared metrics are the ones of m.
+func (m *scalerMetrics) forDeployment(name string) (*scalerMetrics, error) {
+	d := newScalerMetrics()
+	d.registry, d.pusher, d.exemplars = m.registry, m.pusher, m.exemplars
+	d.configReloads, d.warmup, d.activeWindow = m.configReloads, m.warmup, m.activeWindow
+	d.registerer = prometheus.WrapRegistererWith(prometheus.Labels{deploymentLabel: name}, m.registerer)
+	for _, c := range d.scalingCollectors() {
+		if err := d.registerer.Register(c); err != nil {
+			return nil, errors.Wrapf(err, "registering the scaler metrics of deployment %q", name)
+		}
+	}
+	return d, nil
+}
+
+// scalingCollectors are the metrics of the scaling timeline of a single pattern.
+func (m *scalerMetrics) scalingCollectors() []prometheus.Collector {
+	return []prometheus.Collector{m.targetReplicas, m.appliedReplicas, m.applies, m.killedPods, m.replicaDrifts, m.replicaSeconds, m.readyTimeouts, m.readyReplicas, m.convergenceError, m.converged, m.paused}
+}
+
+func (m *scalerMetrics) registerWith(labels map[string]string, collectors []prometheus.Collector) error {
+	for name := range labels {
+		if !model.LabelName(name).IsValid() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+			return errors.Errorf("invalid metric label name %q", name)
+		}
+		if name == model.JobLabel || name == model.InstanceLabel {
+			return errors.Errorf("the metric label %q is reserved, use --pushgateway-job or --metric-instance for pushed metrics", name)
+		}
+	}
+	m.registerer = prometheus.WrapRegistererWith(labels, m.registry)
+	for _, c := range collectors {
+		if err := m.registerer.Register(c); err != nil {
+			return errors.Wrapf(err, "registering the scaler metrics")
+		}
+	}
+	return nil
+}
+
+// inc increments the counter of a scaling event,
+// with the trace ID of the event as the trace_id exemplar when the exemplars are enabled.
+func (m *scalerMetrics) inc(c prometheus.Counter, traceID string) {
+	if e, ok := c.(prometheus.ExemplarAdder); ok && m.exemplars && traceID != "" {
+		e.AddWithExemplar(1, prometheus.Labels{"trace_id": traceID})
+		return
+	}
+	c.Inc()
+}
+
+// newTraceID returns a random trace ID in the W3C trace context format, 16 bytes as hex.
+func newTraceID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		log.Printf("Error generating a trace ID: %v", err)
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// enablePush pushes the metrics to a Pushgateway on every change.
+// The grouping key is the job and the instance, the hostname when not set, so that
+// every scaler replaces its own previous values.
+// Every push gives up after the timeout, so a Pushgateway that doesn't respond can't hold up the scaling.
+func (m *scalerMetrics) enablePush(url, job, instance string, timeout time.Duration) {
+	if instance == "" {
+		var err error
+		if instance, err = os.Hostname(); err != nil {
+			instance = "unknown"
+		}
+	}
+	m.pusher = push.New(url, job).Client(&http.Client{Timeout: timeout}).Grouping("instance", instance).Gatherer(m.registry)
+}
+
+// push sends the current metrics to the Pushgateway, if enabled.
+// Push failures are only logged as they shouldn't interrupt the scaling.
+func (m *scalerMetrics) push() {
+	if m.pusher == nil {
+		return
+	}
+	if err := m.pusher.Push(); err != nil {
+		log.Printf("Error pushing metrics to the Pushgateway: %v", err)
+	}
+}
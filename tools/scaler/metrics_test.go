@@ -0,0 +1,152 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricLabels(t *testing.T) {
+	m := newScalerMetrics()
+	if err := m.register(map[string]string{"scaler": "loadgen-a", "cluster": "prombench-10"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.targetReplicas.Set(10)
+
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		for _, metric := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range metric.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["scaler"] != "loadgen-a" || labels["cluster"] != "prombench-10" {
+				t.Errorf("%v: want the constant labels, got %v", f.GetName(), labels)
+			}
+		}
+	}
+
+	for _, name := range []string{"job", "instance", "__name", "invalid-name"} {
+		if err := newScalerMetrics().register(map[string]string{name: "value"}); err == nil {
+			t.Errorf("expected an error for the label %q", name)
+		}
+	}
+}
+
+func TestPushTimeout(t *testing.T) {
+	// A Pushgateway that never responds.
+	hang := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hang
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(hang) })
+
+	m := newScalerMetrics()
+	if err := m.register(nil); err != nil {
+		t.Fatal(err)
+	}
+	m.enablePush(srv.URL, "scaler", "test", 50*time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		m.push()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("want the push to give up after the timeout")
+	}
+}
+
+func TestExemplars(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		m := newScalerMetrics()
+		if err := m.register(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		m.exemplars = enabled
+		traceID := newTraceID()
+		if len(traceID) != 32 {
+			t.Fatalf("want a trace ID of 32 hex characters, got %q", traceID)
+		}
+		m.inc(m.applies.WithLabelValues("success"), traceID)
+		m.inc(m.killedPods, traceID)
+
+		families, err := m.registry.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+		counters := 0
+		for _, f := range families {
+			if f.GetName() != "scaler_applies_total" && f.GetName() != "scaler_killed_pods_total" {
+				continue
+			}
+			for _, metric := range f.GetMetric() {
+				counters++
+				if metric.GetCounter().GetValue() != 1 {
+					t.Errorf("%v: want the counter incremented once, got %v", f.GetName(), metric.GetCounter().GetValue())
+				}
+				e := metric.GetCounter().GetExemplar()
+				if !enabled {
+					if e != nil {
+						t.Errorf("%v: want no exemplar when disabled, got %v", f.GetName(), e)
+					}
+					continue
+				}
+				if len(e.GetLabel()) != 1 || e.GetLabel()[0].GetName() != "trace_id" || e.GetLabel()[0].GetValue() != traceID {
+					t.Errorf("%v: want the trace_id exemplar %v, got %v", f.GetName(), traceID, e)
+				}
+			}
+		}
+		if counters != 2 {
+			t.Errorf("want the applies and killed pods counters, got %d", counters)
+		}
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	s := newScaler()
+	if err := s.metrics.register(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timer := s.startWarmup(); timer != nil {
+		t.Fatal("want no warmup without --warmup")
+	}
+	if v := testutil.ToFloat64(s.metrics.warmup); v != 0 {
+		t.Errorf("want scaler_warmup 0 without --warmup, got %v", v)
+	}
+
+	s.warmup = 50 * time.Millisecond
+	timer := s.startWarmup()
+	defer timer.Stop()
+	if v := testutil.ToFloat64(s.metrics.warmup); v != 1 {
+		t.Errorf("want scaler_warmup 1 during the warmup, got %v", v)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for testutil.ToFloat64(s.metrics.warmup) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("want scaler_warmup 0 after the warmup")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
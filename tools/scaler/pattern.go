@@ -0,0 +1,415 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// patternNames lists the supported scaling patterns.
+var patternNames = []string{"burst", "step", "sawtooth", "ramp-down", "random-walk", "hold", "sine", "chaos", "weighted", "daily", "canary", "replay", "soak-burst", "hpa"}
+
+// pattern computes the number of replicas for each scaling step.
+type pattern interface {
+	// replicas returns the target replicas for the given step, starting from 0.
+	replicas(step int) int32
+}
+
+// newPattern returns the pattern of a phase after validating its parameters.
+func newPattern(ph *phase) (pattern, error) {
+	name, min, max, scalingFactor := ph.Pattern, ph.Min, ph.Max, ph.ScalingFactor
+	if min < 0 || max < 0 {
+		return nil, errors.Errorf("invalid replicas min: %d, max: %d, must be >= 0", min, max)
+	}
+	if min > max {
+		return nil, errors.Errorf("invalid replicas min: %d is bigger than max: %d", min, max)
+	}
+
+	switch name {
+	case "burst":
+		if ph.AmplitudeJitter < 0 || ph.AmplitudeJitter > 100 {
+			return nil, errors.Errorf("invalid amplitude jitter %d for the burst pattern, must be between 0 and 100", ph.AmplitudeJitter)
+		}
+		return burst{min: min, max: max, jitter: ph.AmplitudeJitter, seed: time.Now().UnixNano()}, nil
+	case "step", "sawtooth", "ramp-down", "random-walk":
+		if scalingFactor <= 0 || scalingFactor >= max {
+			return nil, errors.Errorf("invalid scaling factor %d for the %s pattern, must be > 0 and < max", scalingFactor, name)
+		}
+		switch name {
+		case "ramp-down":
+			return rampDown{min: min, max: max, scalingFactor: scalingFactor}, nil
+		case "random-walk":
+			return newRandomWalk(min, max, scalingFactor, time.Now().UnixNano()), nil
+		}
+		return step{min: min, max: max, scalingFactor: scalingFactor, repeat: name == "sawtooth"}, nil
+	case "hold":
+		return hold{count: max}, nil
+	case "sine":
+		if ph.Period <= 0 {
+			return nil, errors.Errorf("invalid period %s for the sine pattern, must be > 0", ph.Period)
+		}
+		offset, err := parsePhaseOffset(ph.PhaseOffset, ph.Period)
+		if err != nil {
+			return nil, err
+		}
+		return sine{min: min, max: max, interval: ph.Interval, period: ph.Period, offset: offset}, nil
+	case "chaos":
+		if ph.KillRate <= 0 {
+			return nil, errors.Errorf("invalid kill rate %d for the chaos pattern, must be > 0", ph.KillRate)
+		}
+		if ph.MaxUnavailable <= 0 {
+			return nil, errors.Errorf("invalid max unavailable %d for the chaos pattern, must be > 0", ph.MaxUnavailable)
+		}
+		return chaos{count: max, killRate: ph.KillRate, maxUnavailable: ph.MaxUnavailable}, nil
+	case "weighted":
+		levels, err := parseLevels(ph.Levels, min, max)
+		if err != nil {
+			return nil, err
+		}
+		return newWeighted(levels, rand.New(rand.NewSource(time.Now().UnixNano()))), nil
+	case "daily":
+		factors, err := parseDailyFactors(ph.DailyFactors)
+		if err != nil {
+			return nil, err
+		}
+		if ph.DailyBase < 0 {
+			return nil, errors.Errorf("invalid base %d for the daily pattern, must be >= 0", ph.DailyBase)
+		}
+		base := ph.DailyBase
+		if base == 0 {
+			base = max
+		}
+		return daily{min: min, max: max, base: base, factors: factors, now: time.Now}, nil
+	case "canary":
+		weights, err := parseCanaryWeights(ph.CanaryWeights)
+		if err != nil {
+			return nil, err
+		}
+		if ph.StableDeployment == "" || ph.CanaryDeployment == "" {
+			return nil, errors.New("the canary pattern requires the stable and the canary deployment names")
+		}
+		if ph.StableDeployment == ph.CanaryDeployment {
+			return nil, errors.Errorf("the stable and the canary deployment must be different, got %q for both", ph.StableDeployment)
+		}
+		return canary{total: max, stable: ph.StableDeployment, canary: ph.CanaryDeployment, weights: weights}, nil
+	case "replay":
+		return newReplay(ph, time.Now())
+	case "soak-burst":
+		return newSoakBurst(ph)
+	case "hpa":
+		return newHPA(ph)
+	default:
+		return nil, errors.Errorf("unknown pattern %q, must be one of %v", name, patternNames)
+	}
+}
+
+// burst switches between max and min replicas, starting with max.
+// With a jitter the max of every cycle is lowered by a random share of up to jitter percent of max, but not below min.
+// The share only depends on the seed and the cycle, so the same step always gets the same replicas.
+type burst struct {
+	min, max int32
+	jitter   int32
+	seed     int64
+}
+
+func (b burst) replicas(step int) int32 {
+	if step%2 != 0 {
+		return b.min
+	}
+	if b.jitter == 0 {
+		return b.max
+	}
+	r := rand.New(rand.NewSource(b.seed + int64(step/2)))
+	peak := b.max - int32(r.Float64()*float64(b.jitter)/100*float64(b.max))
+	if peak < b.min {
+		return b.min
+	}
+	return peak
+}
+
+// step ramps up from min to max adding scalingFactor replicas at each step,
+// then ramps back down to min removing scalingFactor replicas at each step and stays at min.
+// With repeat, the sawtooth pattern, the ramp starts over from min instead.
+// When scalingFactor doesn't divide max-min the last step of each ramp is clamped to max and min,
+// and the ramp turns at the next step so neither is applied twice in a row.
+type step struct {
+	min, max, scalingFactor int32
+	repeat                  bool
+}
+
+func (s step) replicas(i int) int32 {
+	return clampReplicas(s.raw(i), s.min, s.max)
+}
+
+// raw is the replicas of the ramp at step i before they are clamped to min and max.
+func (s step) raw(i int) float64 {
+	// steps is the number of steps from min to max, the last one clamped.
+	steps := int((s.max - s.min + s.scalingFactor - 1) / s.scalingFactor)
+	if steps == 0 {
+		return float64(s.max)
+	}
+	if s.repeat {
+		i %= 2 * steps
+	} else if i > 2*steps {
+		return float64(s.min)
+	}
+	if i <= steps {
+		return float64(s.min) + float64(i)*float64(s.scalingFactor)
+	}
+	return float64(s.max) - float64(i-steps)*float64(s.scalingFactor)
+}
+
+// rampDown starts at max and removes scalingFactor replicas at each step until min is reached, then stays at min.
+type rampDown struct {
+	min, max, scalingFactor int32
+}
+
+func (r rampDown) replicas(i int) int32 {
+	return clampReplicas(r.raw(i), r.min, r.max)
+}
+
+func (r rampDown) raw(i int) float64 {
+	return float64(r.max) - float64(i)*float64(r.scalingFactor)
+}
+
+// randomWalk starts halfway between min and max and moves by a random number of replicas
+// between -scalingFactor and scalingFactor at each step, clamped to min and max.
+// The walk only depends on the seed, so the same step always gets the same replicas.
+type randomWalk struct {
+	min, max, scalingFactor int32
+	seed                    int64
+	// walk are the replicas of the steps so far, extended by rand when a later step is asked for.
+	walk []int32
+	rand *rand.Rand
+}
+
+func newRandomWalk(min, max, scalingFactor int32, seed int64) *randomWalk {
+	return &randomWalk{min: min, max: max, scalingFactor: scalingFactor, seed: seed}
+}
+
+func (w *randomWalk) replicas(step int) int32 {
+	if w.walk == nil {
+		w.walk = []int32{w.min + (w.max-w.min)/2}
+		w.rand = rand.New(rand.NewSource(w.seed))
+	}
+	for len(w.walk) <= step {
+		move := w.rand.Int31n(2*w.scalingFactor+1) - w.scalingFactor
+		w.walk = append(w.walk, clampReplicas(float64(w.walk[len(w.walk)-1]+move), w.min, w.max))
+	}
+	return w.walk[step]
+}
+
+// reseed restarts the walk with the seed.
+func (w *randomWalk) reseed(seed int64) {
+	w.seed, w.walk, w.rand = seed, nil, nil
+}
+
+// hold keeps max replicas.
+type hold struct {
+	count int32
+}
+
+func (h hold) replicas(int) int32 {
+	return h.count
+}
+
+// sine follows a sine wave between min and max replicas, starting in the middle when the offset is 0.
+type sine struct {
+	min, max int32
+	interval time.Duration
+	period   time.Duration
+	// offset shifts the wave, in radians.
+	offset float64
+}
+
+func (s sine) replicas(step int) int32 {
+	return int32(math.Round(s.raw(step)))
+}
+
+func (s sine) raw(step int) float64 {
+	t := float64(step) * float64(s.interval)
+	v := (1 + math.Sin(2*math.Pi*t/float64(s.period)+s.offset)) / 2
+	return float64(s.min) + v*float64(s.max-s.min)
+}
+
+// parsePhaseOffset parses a sine phase offset given either as a duration, e.g. 15m,
+// or as radians, e.g. 1.57, and returns it in radians.
+// A duration offset is a time shift along the period, so 15m with a 1h period is a quarter of a cycle.
+func parsePhaseOffset(offset string, period time.Duration) (float64, error) {
+	if offset == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(offset); err == nil {
+		return 2 * math.Pi * float64(d) / float64(period), nil
+	}
+	r, err := strconv.ParseFloat(offset, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid phase offset %q, must be a duration like 15m or radians like 1.57", offset)
+	}
+	return r, nil
+}
+
+// level is a number of replicas picked by the weighted pattern with the given weight.
+type level struct {
+	replicas int32
+	weight   int
+}
+
+// weighted picks one of the levels at random at every step, in proportion to their weights.
+type weighted struct {
+	levels []level
+	total  int
+	rand   *rand.Rand
+}
+
+func newWeighted(levels []level, r *rand.Rand) weighted {
+	w := weighted{levels: levels, rand: r}
+	for _, l := range levels {
+		w.total += l.weight
+	}
+	return w
+}
+
+func (w weighted) replicas(int) int32 {
+	n := w.rand.Intn(w.total)
+	for _, l := range w.levels {
+		if n < l.weight {
+			return l.replicas
+		}
+		n -= l.weight
+	}
+	return w.levels[len(w.levels)-1].replicas
+}
+
+// parseLevels parses the levels of the weighted pattern in the replicas:weight format,
+// e.g. 1:80,10:15,50:5. The replicas must be within min and max and the weights > 0.
+func parseLevels(levels string, min, max int32) ([]level, error) {
+	if strings.TrimSpace(levels) == "" {
+		return nil, errors.New("the weighted pattern requires levels in the replicas:weight format, e.g. 1:80,10:15,50:5")
+	}
+	var parsed []level
+	for _, l := range strings.Split(levels, ",") {
+		parts := strings.Split(strings.TrimSpace(l), ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid level %q, must be replicas:weight", l)
+		}
+		replicas, err := strconv.ParseInt(parts[0], 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("invalid replicas in level %q", l)
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight <= 0 {
+			return nil, errors.Errorf("invalid weight in level %q, must be an integer > 0", l)
+		}
+		if int32(replicas) < min || int32(replicas) > max {
+			return nil, errors.Errorf("invalid level %q, the replicas must be between min: %d and max: %d", l, min, max)
+		}
+		parsed = append(parsed, level{replicas: int32(replicas), weight: weight})
+	}
+	return parsed, nil
+}
+
+// daily multiplies the base replicas by the factor of the current hour of the day, clamped to min and max.
+// It follows the wall clock instead of the step so the curve repeats every day, whatever the interval.
+type daily struct {
+	min, max, base int32
+	factors        [24]float64
+	now            func() time.Time
+}
+
+func (d daily) replicas(step int) int32 {
+	r := math.Round(d.raw(step))
+	if r < float64(d.min) {
+		return d.min
+	}
+	if r > float64(d.max) {
+		return d.max
+	}
+	return int32(r)
+}
+
+func (d daily) raw(int) float64 {
+	return float64(d.base) * d.factors[d.now().Hour()]
+}
+
+// parseDailyFactors parses the comma separated multipliers of the daily pattern,
+// one for every hour of the day starting at midnight. The factors must be >= 0.
+func parseDailyFactors(factors string) ([24]float64, error) {
+	var parsed [24]float64
+	if strings.TrimSpace(factors) == "" {
+		return parsed, errors.New("the daily pattern requires 24 comma separated factors, one for every hour starting at midnight")
+	}
+	parts := strings.Split(factors, ",")
+	if len(parts) != len(parsed) {
+		return parsed, errors.Errorf("invalid daily factors, want 24 values, one for every hour, got %d", len(parts))
+	}
+	for i, f := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+			return parsed, errors.Errorf("invalid daily factor %q for hour %d, must be a number >= 0", f, i)
+		}
+		parsed[i] = v
+	}
+	return parsed, nil
+}
+
+// soakBurst holds the baseline replicas and bursts to burstTo for the last burst steps of every cycle of every steps,
+// so the run starts with a soak and returns to the baseline after each burst.
+type soakBurst struct {
+	baseline, burstTo int32
+	every, burst      int
+}
+
+func (b soakBurst) replicas(step int) int32 {
+	if step%b.every >= b.every-b.burst {
+		return b.burstTo
+	}
+	return b.baseline
+}
+
+// newSoakBurst returns the soak-burst pattern of a phase. The baseline defaults to min and the burst to max,
+// both must be within min and max, and the burst duration and period must be multiples of the interval.
+func newSoakBurst(ph *phase) (soakBurst, error) {
+	baseline, burstTo := ph.Baseline, ph.BurstTo
+	if baseline == 0 {
+		baseline = ph.Min
+	}
+	if burstTo == 0 {
+		burstTo = ph.Max
+	}
+	if baseline < ph.Min || burstTo > ph.Max {
+		return soakBurst{}, errors.Errorf("invalid baseline %d and burst to %d for the soak-burst pattern, must be between min: %d and max: %d", baseline, burstTo, ph.Min, ph.Max)
+	}
+	if baseline > burstTo {
+		return soakBurst{}, errors.Errorf("invalid baseline %d for the soak-burst pattern, must be <= the burst to %d", baseline, burstTo)
+	}
+	if ph.BurstDuration <= 0 || ph.BurstDuration%ph.Interval != 0 {
+		return soakBurst{}, errors.Errorf("invalid burst duration %s for the soak-burst pattern, must be a multiple of the interval %s", ph.BurstDuration, ph.Interval)
+	}
+	if ph.BurstEvery <= ph.BurstDuration || ph.BurstEvery%ph.Interval != 0 {
+		return soakBurst{}, errors.Errorf("invalid burst every %s for the soak-burst pattern, must be a multiple of the interval %s and longer than the burst duration %s", ph.BurstEvery, ph.Interval, ph.BurstDuration)
+	}
+	return soakBurst{
+		baseline: baseline,
+		burstTo:  burstTo,
+		every:    int(ph.BurstEvery / ph.Interval),
+		burst:    int(ph.BurstDuration / ph.Interval),
+	}, nil
+}
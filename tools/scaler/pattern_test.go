@@ -0,0 +1,357 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSinePattern(t *testing.T) {
+	testCases := []struct {
+		offset   string
+		replicas []int32
+	}{
+		{offset: "0", replicas: []int32{11, 21, 11, 1, 11}},
+		{offset: "15m", replicas: []int32{21, 11, 1, 11, 21}},
+		{offset: "1h15m", replicas: []int32{21, 11, 1, 11, 21}},
+		{offset: "3.141592653589793", replicas: []int32{11, 1, 11, 21, 11}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.offset, func(t *testing.T) {
+			p, err := newPattern(&phase{Pattern: "sine", Min: 1, Max: 21, Interval: 15 * time.Minute, Period: time.Hour, PhaseOffset: tc.offset})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var replicas []int32
+			for i := range tc.replicas {
+				replicas = append(replicas, p.replicas(i))
+			}
+			if !reflect.DeepEqual(tc.replicas, replicas) {
+				t.Errorf("want %v, got %v", tc.replicas, replicas)
+			}
+		})
+	}
+
+	if _, err := newPattern(&phase{Pattern: "sine", Min: 1, Max: 21, Interval: time.Minute}); err == nil {
+		t.Error("expected an error for a sine pattern without a period")
+	}
+}
+
+func TestParsePhaseOffset(t *testing.T) {
+	for offset, radians := range map[string]float64{
+		"":     0,
+		"30m":  math.Pi,
+		"-15m": -math.Pi / 2,
+		"1.5":  1.5,
+	} {
+		r, err := parsePhaseOffset(offset, time.Hour)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", offset, err)
+		}
+		if math.Abs(r-radians) > 1e-9 {
+			t.Errorf("%q: want %v radians, got %v", offset, radians, r)
+		}
+	}
+	if _, err := parsePhaseOffset("quarter", time.Hour); err == nil {
+		t.Error("expected an error for an invalid offset")
+	}
+}
+
+func TestZeroMinPattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern  string
+		replicas []int32
+	}{
+		{pattern: "burst", replicas: []int32{4, 0, 4, 0}},
+		{pattern: "step", replicas: []int32{0, 2, 4, 2, 0, 0}},
+		{pattern: "sawtooth", replicas: []int32{0, 2, 4, 2, 0, 2}},
+		{pattern: "sine", replicas: []int32{2, 4, 2, 0}},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			p, err := newPattern(&phase{Pattern: tc.pattern, Min: 0, Max: 4, ScalingFactor: 2, Interval: 15 * time.Minute, Period: time.Hour})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var replicas []int32
+			for i := range tc.replicas {
+				replicas = append(replicas, p.replicas(i))
+			}
+			if !reflect.DeepEqual(tc.replicas, replicas) {
+				t.Errorf("want %v, got %v", tc.replicas, replicas)
+			}
+		})
+	}
+
+	// A hold phase at 0 stops the workload.
+	p, err := newPattern(&phase{Pattern: "hold", Max: 0, Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := p.replicas(0); r != 0 {
+		t.Errorf("want 0 replicas, got %v", r)
+	}
+}
+
+func TestStepPattern(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		min, max, factor int32
+		step, sawtooth   []int32
+	}{
+		{name: "even", min: 1, max: 7, factor: 2, step: []int32{1, 3, 5, 7, 5, 3, 1, 1, 1}, sawtooth: []int32{1, 3, 5, 7, 5, 3, 1, 3, 5}},
+		// The ramp is clamped to max and min and turns at the next step without applying them twice.
+		{name: "uneven", min: 0, max: 5, factor: 2, step: []int32{0, 2, 4, 5, 3, 1, 0, 0, 0}, sawtooth: []int32{0, 2, 4, 5, 3, 1, 0, 2, 4}},
+		{name: "single step", min: 2, max: 5, factor: 4, step: []int32{2, 5, 2, 2}, sawtooth: []int32{2, 5, 2, 5}},
+		{name: "min is max", min: 5, max: 5, factor: 2, step: []int32{5, 5, 5}, sawtooth: []int32{5, 5, 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, want := range map[string][]int32{"step": tc.step, "sawtooth": tc.sawtooth} {
+				p, err := newPattern(&phase{Pattern: name, Min: tc.min, Max: tc.max, ScalingFactor: tc.factor, Interval: time.Minute})
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", name, err)
+				}
+				var replicas []int32
+				for i := range want {
+					replicas = append(replicas, p.replicas(i))
+				}
+				if !reflect.DeepEqual(want, replicas) {
+					t.Errorf("%s: want %v, got %v", name, want, replicas)
+				}
+			}
+		})
+	}
+
+	for _, name := range []string{"step", "sawtooth", "ramp-down", "random-walk"} {
+		for _, factor := range []int32{0, -1, 10, 11} {
+			if _, err := newPattern(&phase{Pattern: name, Min: 1, Max: 10, ScalingFactor: factor, Interval: time.Minute}); err == nil {
+				t.Errorf("%s: expected an error for the scaling factor %d", name, factor)
+			}
+		}
+	}
+}
+
+func TestRampDownPattern(t *testing.T) {
+	p, err := newPattern(&phase{Pattern: "ramp-down", Min: 1, Max: 10, ScalingFactor: 4, Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var replicas []int32
+	for i := 0; i < 5; i++ {
+		replicas = append(replicas, p.replicas(i))
+	}
+	if want := []int32{10, 6, 2, 1, 1}; !reflect.DeepEqual(want, replicas) {
+		t.Errorf("want %v, got %v", want, replicas)
+	}
+}
+
+func TestRandomWalkPattern(t *testing.T) {
+	walk := func(w *randomWalk) []int32 {
+		var replicas []int32
+		for i := 0; i < 1000; i++ {
+			replicas = append(replicas, w.replicas(i))
+		}
+		return replicas
+	}
+	w := newRandomWalk(10, 50, 5, 1)
+	replicas := walk(w)
+	if replicas[0] != 30 {
+		t.Errorf("want the walk to start halfway at 30, got %d", replicas[0])
+	}
+	seen := map[int32]bool{}
+	for i, r := range replicas {
+		if r < 10 || r > 50 {
+			t.Fatalf("step %d: want the replicas between 10 and 50, got %d", i, r)
+		}
+		if i > 0 && (r-replicas[i-1] > 5 || replicas[i-1]-r > 5) {
+			t.Fatalf("step %d: want a move of at most 5 replicas, got %d to %d", i, replicas[i-1], r)
+		}
+		seen[r] = true
+	}
+	if !seen[10] || !seen[50] {
+		t.Error("want the walk to reach min and max in 1000 steps")
+	}
+
+	// Asking for an earlier step or restarting with the same seed returns the same walk.
+	if r := w.replicas(10); r != replicas[10] {
+		t.Errorf("want the replicas %d of step 10 again, got %d", replicas[10], r)
+	}
+	if again := walk(newRandomWalk(10, 50, 5, 1)); !reflect.DeepEqual(replicas, again) {
+		t.Error("want the same walk for the same seed")
+	}
+	w.reseed(2)
+	if other := walk(w); reflect.DeepEqual(replicas, other) {
+		t.Error("want another walk for another seed")
+	}
+}
+
+func TestWeightedPattern(t *testing.T) {
+	levels, err := parseLevels("1:80, 10:15, 50:5", 1, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := newWeighted(levels, rand.New(rand.NewSource(1)))
+	const steps = 10000
+	counts := map[int32]int{}
+	for i := 0; i < steps; i++ {
+		counts[p.replicas(i)]++
+	}
+	for replicas, weight := range map[int32]float64{1: 0.80, 10: 0.15, 50: 0.05} {
+		if got := float64(counts[replicas]) / steps; math.Abs(got-weight) > 0.02 {
+			t.Errorf("%d replicas: want a share of %v, got %v", replicas, weight, got)
+		}
+	}
+	if len(counts) != 3 {
+		t.Errorf("want only the 3 levels, got %v", counts)
+	}
+
+	for _, invalid := range []string{"", "1:80,10", "1:0", "1:-5", "a:10", "100:5", "0:5"} {
+		if _, err := parseLevels(invalid, 1, 50); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
+
+func TestBurstAmplitudeJitter(t *testing.T) {
+	p, err := newPattern(&phase{Pattern: "burst", Min: 30, Max: 50, Interval: time.Minute, AmplitudeJitter: 60})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b := p.(burst)
+	b.seed = 1
+	peaks := map[int32]bool{}
+	for i := 0; i < 200; i++ {
+		got := b.replicas(i)
+		if i%2 != 0 {
+			if got != 30 {
+				t.Fatalf("step %d: want min 30, got %d", i, got)
+			}
+			continue
+		}
+		if got < 30 || got > 50 {
+			t.Fatalf("step %d: want a peak between 30 and 50, got %d", i, got)
+		}
+		if got != b.replicas(i) {
+			t.Fatalf("step %d: the same step got different peaks", i)
+		}
+		peaks[got] = true
+	}
+	if !peaks[30] || len(peaks) < 10 {
+		t.Errorf("want varied peaks clamped to min, got %v", peaks)
+	}
+
+	reseeded := b
+	reseeded.seed = 2
+	same := true
+	for i := 0; i < 20; i += 2 {
+		same = same && b.replicas(i) == reseeded.replicas(i)
+	}
+	if same {
+		t.Error("want different peaks for a different seed")
+	}
+
+	b.jitter = 0
+	if got := b.replicas(4); got != 50 {
+		t.Errorf("without jitter: want max 50, got %d", got)
+	}
+
+	for _, jitter := range []int32{-1, 101} {
+		if _, err := newPattern(&phase{Pattern: "burst", Min: 1, Max: 10, Interval: time.Minute, AmplitudeJitter: jitter}); err == nil {
+			t.Errorf("jitter %d: want an error", jitter)
+		}
+	}
+}
+
+func TestDailyPattern(t *testing.T) {
+	factors := "0,0,0,0,0,0,0.5,1,1.5,2,2,2,2,2,2,2,2,2,1.5,1,0.5,0.25,0.1,0"
+	p, err := newPattern(&phase{Pattern: "daily", Min: 1, Max: 30, Interval: time.Minute, DailyBase: 10, DailyFactors: factors})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := p.(daily)
+	for hour, want := range map[int]int32{
+		0:  1,  // 0 is clamped to min.
+		6:  5,  // round(10 * 0.5)
+		8:  15, // round(10 * 1.5)
+		9:  20, // round(10 * 2)
+		21: 3,  // round(10 * 0.25) rounds half away from zero.
+		22: 1,  // round(10 * 0.1)
+	} {
+		d.now = func() time.Time { return time.Date(2026, 10, 14, hour, 30, 0, 0, time.Local) }
+		if got := d.replicas(0); got != want {
+			t.Errorf("hour %d: want %d replicas, got %d", hour, want, got)
+		}
+	}
+
+	// The base defaults to max and the result is clamped to max.
+	p, err = newPattern(&phase{Pattern: "daily", Min: 1, Max: 30, Interval: time.Minute, DailyFactors: factors})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d = p.(daily)
+	d.now = func() time.Time { return time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local) }
+	if got := d.replicas(0); got != 30 {
+		t.Errorf("want the replicas clamped to max 30, got %d", got)
+	}
+
+	for _, invalid := range []string{"", "1,1,1", strings.Repeat("1,", 24) + "1", strings.Repeat("1,", 23) + "-1", strings.Repeat("1,", 23) + "a", strings.Repeat("1,", 23) + "NaN"} {
+		if _, err := parseDailyFactors(invalid); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+	if _, err := newPattern(&phase{Pattern: "daily", Max: 10, Interval: time.Minute, DailyBase: -1, DailyFactors: factors}); err == nil {
+		t.Error("expected an error for a negative base")
+	}
+}
+
+func TestSoakBurstPattern(t *testing.T) {
+	p, err := newPattern(&phase{Pattern: "soak-burst", Min: 1, Max: 50, Interval: 5 * time.Minute, Baseline: 5, BurstTo: 40, BurstEvery: 20 * time.Minute, BurstDuration: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var replicas []int32
+	for i := 0; i < 8; i++ {
+		replicas = append(replicas, p.replicas(i))
+	}
+	if want := []int32{5, 5, 40, 40, 5, 5, 40, 40}; !reflect.DeepEqual(want, replicas) {
+		t.Errorf("want %v, got %v", want, replicas)
+	}
+
+	// The baseline defaults to min and the burst to max.
+	p, err = newPattern(&phase{Pattern: "soak-burst", Min: 1, Max: 50, Interval: time.Minute, BurstEvery: 3 * time.Minute, BurstDuration: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := []int32{p.replicas(0), p.replicas(1), p.replicas(2)}; !reflect.DeepEqual([]int32{1, 1, 50}, got) {
+		t.Errorf("want the min baseline and the max burst, got %v", got)
+	}
+
+	for name, ph := range map[string]*phase{
+		"baseline above the burst": {Baseline: 30, BurstTo: 20, BurstEvery: time.Hour, BurstDuration: 10 * time.Minute},
+		"baseline below min":       {Min: 5, Baseline: 2, BurstEvery: time.Hour, BurstDuration: 10 * time.Minute},
+		"burst above max":          {BurstTo: 60, BurstEvery: time.Hour, BurstDuration: 10 * time.Minute},
+		"no burst duration":        {BurstEvery: time.Hour},
+		"burst as long as every":   {BurstEvery: time.Hour, BurstDuration: time.Hour},
+		"not interval multiples":   {BurstEvery: time.Hour, BurstDuration: 90 * time.Second},
+	} {
+		ph.Pattern, ph.Max, ph.Interval = "soak-burst", 50, time.Minute
+		if _, err := newPattern(ph); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	yamlGo "gopkg.in/yaml.v2"
+)
+
+// plan is an ordered list of phases executed one after the other.
+type plan struct {
+	Phases []*phase `yaml:"phases"`
+	// Loop restarts the plan from the first phase after the last phase completes.
+	Loop bool `yaml:"loop"`
+	// Deployments are the plans of the deployments from the files by name, each run concurrently on its own schedule,
+	// instead of the phases applied to all deployments.
+	Deployments map[string]*plan `yaml:"deployments"`
+}
+
+// phase runs a single pattern for a given duration.
+type phase struct {
+	Name          string        `yaml:"name"`
+	Pattern       string        `yaml:"pattern"`
+	Min           int32         `yaml:"min"`
+	Max           int32         `yaml:"max"`
+	ScalingFactor int32         `yaml:"scalingFactor"`
+	Interval      time.Duration `yaml:"interval"`
+	// Period and PhaseOffset are used by the sine pattern.
+	Period      time.Duration `yaml:"period"`
+	PhaseOffset string        `yaml:"phaseOffset"`
+	// KillRate and MaxUnavailable are used by the chaos pattern.
+	KillRate       int `yaml:"killRate"`
+	MaxUnavailable int `yaml:"maxUnavailable"`
+	// Levels of the weighted pattern in the replicas:weight format, e.g. 1:80,10:15,50:5.
+	Levels string `yaml:"levels"`
+	// DailyFactors of the daily pattern, 24 comma separated multipliers of DailyBase, one for every hour.
+	// DailyBase defaults to max.
+	DailyFactors string `yaml:"dailyFactors"`
+	DailyBase    int32  `yaml:"dailyBase"`
+	// StableDeployment and CanaryDeployment are the deployments of the canary pattern,
+	// CanaryWeights the comma separated canary percentages of max, one per step.
+	StableDeployment string `yaml:"stableDeployment"`
+	CanaryDeployment string `yaml:"canaryDeployment"`
+	CanaryWeights    string `yaml:"canaryWeights"`
+	// PrometheusURL and Query select the series of the replay and the hpa pattern, replayed From To at Speed intervals of the history per interval.
+	// From and To are RFC3339 times or durations before now, To defaults to now and Speed to 1.
+	// The values are multiplied by ReplayScale, 0 maps the range of the series to min and max.
+	PrometheusURL string  `yaml:"prometheusURL"`
+	Query         string  `yaml:"query"`
+	From          string  `yaml:"from"`
+	To            string  `yaml:"to"`
+	Speed         float64 `yaml:"speed"`
+	ReplayScale   float64 `yaml:"replayScale"`
+	// TargetValue is the target of the metric the hpa pattern gets with the Query of PrometheusURL, the replicas stay the same
+	// while the ratio of the metric to the target is within the Tolerance of 1, and they only go down to the highest
+	// replicas of the StabilizationWindow.
+	TargetValue         float64       `yaml:"targetValue"`
+	Tolerance           float64       `yaml:"tolerance"`
+	StabilizationWindow time.Duration `yaml:"stabilizationWindow"`
+	// AmplitudeJitter is the percentage of max by which the burst pattern randomly lowers the peak of each cycle.
+	AmplitudeJitter int32 `yaml:"amplitudeJitter"`
+	// Baseline and BurstTo are the replicas of the soak-burst pattern, which bursts for BurstDuration every BurstEvery.
+	// Baseline defaults to min and BurstTo to max.
+	Baseline      int32         `yaml:"baseline"`
+	BurstTo       int32         `yaml:"burstTo"`
+	BurstEvery    time.Duration `yaml:"burstEvery"`
+	BurstDuration time.Duration `yaml:"burstDuration"`
+	// IntervalStart and IntervalEnd ramp the interval linearly over IntervalRamp, the phase duration when not set.
+	// IntervalStart defaults to Interval, the interval stays at IntervalEnd once the ramp is done.
+	IntervalStart time.Duration `yaml:"intervalStart"`
+	IntervalEnd   time.Duration `yaml:"intervalEnd"`
+	IntervalRamp  time.Duration `yaml:"intervalRamp"`
+	// PerReplicaRPS turns min, max and the other replicas of the pattern into request rates,
+	// converted to replicas that serve PerReplicaRPS requests/s each and kept within MinReplicas and MaxReplicas.
+	// 0 disables it, MaxReplicas 0 has no upper bound.
+	PerReplicaRPS float64 `yaml:"perReplicaRPS"`
+	MinReplicas   int32   `yaml:"minReplicas"`
+	MaxReplicas   int32   `yaml:"maxReplicas"`
+	// MinDwell is the minimum time each replica level is held, --min-dwell when not set.
+	MinDwell time.Duration `yaml:"minDwell"`
+	// Duration of the phase, 0 runs the phase forever.
+	Duration time.Duration `yaml:"duration"`
+	// Repeat runs the phase that many times in a row, 0 and 1 run it once.
+	Repeat int `yaml:"repeat"`
+	// Jitter is the percentage by which every target of the pattern and the duration of every repetition
+	// are randomly moved up or down, the targets never below min or above max. 0 disables it.
+	Jitter int32 `yaml:"jitter"`
+
+	pattern pattern
+}
+
+// loadPlan reads and validates a plan file.
+func loadPlan(filename string) (*plan, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading the plan file")
+	}
+	p := &plan{}
+	if err := yamlGo.UnmarshalStrict(content, p); err != nil {
+		return nil, errors.Wrapf(err, "parsing the plan file %v", filename)
+	}
+	if len(p.Deployments) == 0 {
+		if err := p.validate(); err != nil {
+			return nil, errors.Wrapf(err, "the plan file %v", filename)
+		}
+		return p, nil
+	}
+
+	if len(p.Phases) > 0 || p.Loop {
+		return nil, errors.Errorf("the plan file %v sets both deployments and phases, the phases go in the plan of each deployment", filename)
+	}
+	for _, name := range p.deploymentNames() {
+		d := p.Deployments[name]
+		if d == nil {
+			return nil, errors.Errorf("the plan file %v: deployment %q has no plan", filename, name)
+		}
+		if len(d.Deployments) > 0 {
+			return nil, errors.Errorf("the plan file %v: deployment %q can't have deployments of its own", filename, name)
+		}
+		if err := d.validate(); err != nil {
+			return nil, errors.Wrapf(err, "the plan file %v: deployment %q", filename, name)
+		}
+		for _, ph := range d.Phases {
+			if _, ok := ph.pattern.(canary); ok {
+				return nil, errors.Errorf("the plan file %v: deployment %q phase %q: the canary pattern scales two deployments and can't be used in the plan of a single deployment", filename, name, ph.Name)
+			}
+		}
+	}
+	return p, nil
+}
+
+// validate checks the phases of the plan and sets up their patterns.
+func (p *plan) validate() error {
+	if len(p.Phases) == 0 {
+		return errors.New("no phases")
+	}
+	for i, ph := range p.Phases {
+		if ph.Name == "" {
+			ph.Name = fmt.Sprintf("phase-%d", i)
+		}
+		if ph.Duration <= 0 && (i < len(p.Phases)-1 || p.Loop) {
+			return errors.Errorf("phase %q: the duration must be > 0 unless it is the last phase of a plan that doesn't loop", ph.Name)
+		}
+		if ph.Duration <= 0 && ph.Repeat > 1 {
+			return errors.Errorf("phase %q: a repeated phase requires a duration > 0", ph.Name)
+		}
+		if err := ph.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deploymentNames returns the sorted names of the deployments of a per-deployment plan.
+func (p *plan) deploymentNames() []string {
+	names := make([]string, 0, len(p.Deployments))
+	for name := range p.Deployments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validate checks the phase parameters and sets up its pattern.
+func (ph *phase) validate() error {
+	if err := ph.validateIntervalRamp(); err != nil {
+		return err
+	}
+	if ph.Interval <= 0 {
+		return errors.Errorf("phase %q: the interval must be > 0", ph.Name)
+	}
+	if ph.MinDwell < 0 {
+		return errors.Errorf("phase %q: the minDwell must be >= 0", ph.Name)
+	}
+	if ph.Repeat < 0 {
+		return errors.Errorf("phase %q: the repeat must be >= 0", ph.Name)
+	}
+	pat, err := newPattern(ph)
+	if err != nil {
+		return errors.Wrapf(err, "phase %q", ph.Name)
+	}
+	if pat, err = withJitter(ph, pat); err != nil {
+		return errors.Wrapf(err, "phase %q", ph.Name)
+	}
+	if pat, err = withLoadTarget(ph, pat); err != nil {
+		return errors.Wrapf(err, "phase %q", ph.Name)
+	}
+	ph.pattern = pat
+	return nil
+}
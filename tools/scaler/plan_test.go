@@ -0,0 +1,302 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePlan(t *testing.T, content string) string {
+	t.Helper()
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(f, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestLoadPlan(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		// err is a part of the expected error, empty for a valid plan.
+		err string
+		// names and durations are the expected phases of a valid plan.
+		names     []string
+		durations []time.Duration
+	}{
+		{
+			name: "phases",
+			content: `
+phases:
+- name: warmup
+  pattern: hold
+  max: 1
+  interval: 1m
+  duration: 10m
+- pattern: step
+  min: 1
+  max: 10
+  scalingFactor: 3
+  interval: 30s
+  duration: 1h
+- pattern: burst
+  min: 1
+  max: 20
+  interval: 5m
+`,
+			names:     []string{"warmup", "phase-1", "phase-2"},
+			durations: []time.Duration{10 * time.Minute, time.Hour, 0},
+		},
+		{
+			name:      "loop",
+			content:   "loop: true\nphases:\n- pattern: sine\n  max: 10\n  interval: 1m\n  period: 30m\n  duration: 2h\n",
+			names:     []string{"phase-0"},
+			durations: []time.Duration{2 * time.Hour},
+		},
+		{
+			name:      "json",
+			content:   `{"phases": [{"name": "steady", "pattern": "hold", "max": 3, "interval": "1m", "duration": "10m"}, {"pattern": "burst", "max": 5, "interval": "1m"}]}`,
+			names:     []string{"steady", "phase-1"},
+			durations: []time.Duration{10 * time.Minute, 0},
+		},
+		{
+			name:      "repeat and jitter",
+			content:   "phases:\n- pattern: hold\n  min: 5\n  max: 12\n  interval: 5m\n  duration: 30m\n  jitter: 20\n  repeat: 4\n",
+			names:     []string{"phase-0"},
+			durations: []time.Duration{30 * time.Minute},
+		},
+		{name: "missing file", err: "reading the plan file"},
+		{name: "unknown key", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  replicas: 3\n", err: "field replicas not found"},
+		{name: "unknown top level key", content: "loops: true\nphases:\n- pattern: hold\n  max: 1\n  interval: 1m\n", err: "field loops not found"},
+		{name: "empty phases", content: "phases: []\n", err: "no phases"},
+		{name: "no phases", content: "loop: true\n", err: "no phases"},
+		{name: "bad duration", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  duration: an hour\n", err: "parsing the plan file"},
+		{name: "bad interval", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1 minute\n", err: "parsing the plan file"},
+		{name: "no interval", content: "phases:\n- pattern: hold\n  max: 1\n", err: `phase "phase-0": the interval must be > 0`},
+		{name: "negative min dwell", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  minDwell: -1m\n", err: "the minDwell must be >= 0"},
+		{
+			name:    "first phase without duration",
+			content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n- pattern: hold\n  max: 2\n  interval: 1m\n",
+			err:     `phase "phase-0": the duration must be > 0`,
+		},
+		{name: "loop without duration", content: "loop: true\nphases:\n- pattern: hold\n  max: 1\n  interval: 1m\n", err: "the duration must be > 0"},
+		{name: "interval ramp without end", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  intervalStart: 2m\n", err: "require an intervalEnd > 0"},
+		{name: "negative repeat", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  duration: 1h\n  repeat: -1\n", err: "the repeat must be >= 0"},
+		{name: "repeat without duration", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  repeat: 2\n", err: "a repeated phase requires a duration > 0"},
+		{name: "jitter above 100", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  jitter: 101\n", err: "invalid jitter 101, must be between 0 and 100"},
+		{name: "negative jitter", content: "phases:\n- pattern: hold\n  max: 1\n  interval: 1m\n  jitter: -5\n", err: "invalid jitter -5"},
+		{
+			name:    "chaos jitter",
+			content: "phases:\n- pattern: chaos\n  max: 10\n  interval: 1m\n  killRate: 1\n  maxUnavailable: 1\n  jitter: 10\n",
+			err:     "the chaos pattern doesn't compute its targets from the steps and can't be used with a jitter",
+		},
+		{name: "unknown pattern", content: "phases:\n- pattern: square\n  max: 1\n  interval: 1m\n", err: `unknown pattern "square"`},
+		{name: "min above max", content: "phases:\n- pattern: burst\n  min: 5\n  max: 1\n  interval: 1m\n", err: "min: 5 is bigger than max: 1"},
+		{name: "negative replicas", content: "phases:\n- pattern: burst\n  min: -1\n  max: 1\n  interval: 1m\n", err: "must be >= 0"},
+		{name: "burst jitter", content: "phases:\n- pattern: burst\n  max: 10\n  interval: 1m\n  amplitudeJitter: 101\n", err: "invalid amplitude jitter 101"},
+		{name: "step scaling factor", content: "phases:\n- pattern: step\n  max: 10\n  interval: 1m\n", err: "invalid scaling factor 0 for the step pattern"},
+		{name: "sawtooth scaling factor", content: "phases:\n- pattern: sawtooth\n  max: 10\n  scalingFactor: 10\n  interval: 1m\n", err: "invalid scaling factor 10 for the sawtooth pattern"},
+		{name: "sine period", content: "phases:\n- pattern: sine\n  max: 10\n  interval: 1m\n", err: "invalid period 0s for the sine pattern"},
+		{name: "chaos kill rate", content: "phases:\n- pattern: chaos\n  max: 10\n  interval: 1m\n  maxUnavailable: 1\n", err: "invalid kill rate 0"},
+		{name: "chaos max unavailable", content: "phases:\n- pattern: chaos\n  max: 10\n  interval: 1m\n  killRate: 1\n", err: "invalid max unavailable 0"},
+		{name: "weighted levels", content: "phases:\n- pattern: weighted\n  max: 10\n  interval: 1m\n", err: `phase "phase-0"`},
+		{name: "daily base", content: "phases:\n- pattern: daily\n  max: 10\n  interval: 1m\n  dailyBase: -1\n  dailyFactors: " + strings.Repeat("1,", 23) + "1\n", err: "invalid base -1"},
+		{
+			name:    "canary deployments",
+			content: "phases:\n- pattern: canary\n  max: 10\n  interval: 1m\n  canaryWeights: 0,50\n  stableDeployment: loadgen\n  canaryDeployment: loadgen\n",
+			err:     "the stable and the canary deployment must be different",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := filepath.Join(t.TempDir(), "missing.yaml")
+			if tc.content != "" {
+				f = writePlan(t, tc.content)
+			}
+			p, err := loadPlan(f)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(p.Phases) != len(tc.names) {
+				t.Fatalf("want %d phases, got %d", len(tc.names), len(p.Phases))
+			}
+			for i, ph := range p.Phases {
+				if ph.Name != tc.names[i] || ph.Duration != tc.durations[i] {
+					t.Errorf("phase %d: want %s for %s, got %s for %s", i, tc.names[i], tc.durations[i], ph.Name, ph.Duration)
+				}
+				if ph.pattern == nil {
+					t.Errorf("phase %q: the pattern wasn't set up", ph.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	j := jitter{pattern: hold{count: 12}, min: 5, max: 12, percent: 20, seed: 1}
+	var moved bool
+	for step := 0; step < 100; step++ {
+		r := j.replicas(step)
+		if r < 10 || r > 12 {
+			t.Fatalf("step %d: want the target of 12 moved by up to 20%% within max, got %d", step, r)
+		}
+		if r != j.replicas(step) {
+			t.Fatalf("step %d: want the same replicas for the same step", step)
+		}
+		moved = moved || r != 12
+	}
+	if !moved {
+		t.Error("want some targets moved by the jitter")
+	}
+
+	// The targets are kept within min and max.
+	j = jitter{pattern: step{min: 1, max: 10, scalingFactor: 3}, min: 1, max: 10, percent: 100, seed: 1}
+	for i := 0; i < 100; i++ {
+		if r := j.replicas(i); r < 1 || r > 10 {
+			t.Fatalf("step %d: want the replicas within min and max, got %d", i, r)
+		}
+	}
+}
+
+func TestRepetition(t *testing.T) {
+	ph := &phase{Name: "churn", Pattern: "hold", Min: 5, Max: 12, Interval: 5 * time.Minute, Duration: 30 * time.Minute, Jitter: 20, Repeat: 4}
+	if err := ph.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := ph.repetitions(); n != 4 {
+		t.Fatalf("want 4 repetitions, got %d", n)
+	}
+	seeds := map[int64]bool{}
+	for r := 0; r < ph.repetitions(); r++ {
+		run := ph.repetition(r)
+		if run.Duration < 24*time.Minute || run.Duration > 36*time.Minute {
+			t.Errorf("repetition %d: want the duration of 30m moved by up to 20%%, got %s", r, run.Duration)
+		}
+		seeds[run.pattern.(jitter).seed] = true
+	}
+	if len(seeds) != 4 {
+		t.Errorf("want a seed per repetition, got %v", seeds)
+	}
+	if ph.Duration != 30*time.Minute {
+		t.Errorf("want the duration of the phase kept, got %s", ph.Duration)
+	}
+
+	// Without a jitter every repetition is the phase itself.
+	ph = &phase{Name: "steady", Pattern: "hold", Max: 3, Interval: time.Minute, Duration: time.Hour, Repeat: 2}
+	if err := ph.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run := ph.repetition(1); run != ph {
+		t.Errorf("want the phase itself without a jitter, got %+v", run)
+	}
+	if n := (&phase{}).repetitions(); n != 1 {
+		t.Errorf("want a phase without repeat to run once, got %d", n)
+	}
+}
+
+func TestJitterVirtualClock(t *testing.T) {
+	ph := &phase{Name: "burst", pattern: jitter{pattern: burst{min: 1, max: 10, jitter: 50, seed: 7}, min: 1, max: 10, percent: 10, seed: 7}}
+	s := newScaler()
+	s.simulateSeed = 42
+	s.useVirtualClock(&plan{Phases: []*phase{ph}}, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))
+	j := ph.pattern.(jitter)
+	if j.seed != 42 || j.pattern.(burst).seed != 42 {
+		t.Errorf("want the jitter and its pattern seeded by the simulation, got %+v", j)
+	}
+}
+
+func TestRunPlanRepeat(t *testing.T) {
+	s := newScaler()
+	s.profileFile = writePlan(t, `
+phases:
+- pattern: hold
+  max: 3
+  interval: 10m
+  duration: 10m
+  repeat: 3
+- pattern: hold
+  max: 5
+  interval: 10m
+`)
+	s.simulate = 40 * time.Minute
+	s.simulateStart = "2026-10-14T00:00:00Z"
+	s.deploymentFiles = []string{"loadgen.yaml"}
+	s.transitionSteps = 1
+	p, err := s.checkArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.startSimulation(p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.started = s.clock.Now()
+
+	var out bytes.Buffer
+	if err := s.simulationResult(&out, s.runPlan(p)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var applies []simulatedApply
+	if err := json.Unmarshal(out.Bytes(), &applies); err != nil {
+		t.Fatalf("invalid json %q: %v", out.String(), err)
+	}
+	want := []simulatedApply{
+		{T: 0, Target: 3, Applied: 3},
+		{T: 600, Target: 3, Applied: 3},
+		{T: 1200, Target: 3, Applied: 3},
+		{T: 1800, Target: 5, Applied: 5},
+	}
+	if len(applies) != len(want) {
+		t.Fatalf("want %d applies, got %s", len(want), out.String())
+	}
+	for i := range want {
+		if applies[i].T != want[i].T || applies[i].Target != want[i].Target || applies[i].Applied != want[i].Applied {
+			t.Errorf("apply %d: want %+v, got %+v", i, want[i], applies[i])
+		}
+	}
+}
+
+func TestProfileAlias(t *testing.T) {
+	f := writePlan(t, "phases:\n- pattern: hold\n  max: 3\n  interval: 1m\n")
+	s := newScaler()
+	s.profileFile = f
+	s.deploymentFiles = []string{"loadgen.yaml"}
+	s.transitionSteps = 1
+	if _, err := s.checkArgs(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.planFile != f {
+		t.Errorf("want --profile to set the plan file, got %q", s.planFile)
+	}
+
+	s = newScaler()
+	s.planFile, s.profileFile = f, f
+	s.deploymentFiles = []string{"loadgen.yaml"}
+	s.transitionSteps = 1
+	if _, err := s.checkArgs(); err == nil || !strings.Contains(err.Error(), "--profile is an alias of --plan") {
+		t.Errorf("want an error for both --plan and --profile, got %v", err)
+	}
+}
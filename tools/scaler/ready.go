@@ -0,0 +1,80 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// readyPollInterval is how often the ready pods are checked while waiting for them between the steps.
+const readyPollInterval = 5 * time.Second
+
+// waitForReady holds the replicas of the last apply until the ready pods of the scaled objects match them within the
+// convergence tolerance, so the next step doesn't outrun the cluster scheduling the pods and the pattern reflects the achieved load.
+// ready returns the ready and the expected replicas. The wait gives up after the ready timeout and the scaling continues,
+// a failed apply or a failure to read the pods doesn't wait at all.
+func (s *scale) waitForReady(ready func() (int32, int32, error)) {
+	if !s.waitReady || s.applied == nil || s.errStats.consecutive > 0 {
+		return
+	}
+	start := s.clock.Now()
+	s.health.progress(s.readyTimeout)
+	for !s.stopped() {
+		n, expected, err := ready()
+		if err != nil {
+			s.logf("Error reading the ready pods, not waiting for them: %v", err)
+			return
+		}
+		waited := s.clock.Now().Sub(start)
+		if withinTolerance(expected-n, s.convergenceTolerance) {
+			if waited > 0 {
+				s.logf("%d of %d replicas ready after %s", n, expected, waited.Round(time.Second))
+			}
+			return
+		}
+		if waited >= s.readyTimeout {
+			s.logf("WARNING: %d of %d replicas ready after the ready timeout of %s, continuing", n, expected, s.readyTimeout)
+			s.metrics.inc(s.metrics.readyTimeouts, s.traceID)
+			s.metrics.push()
+			return
+		}
+		if waited == 0 {
+			s.logf("Waiting for %d replicas to be ready, %d ready", expected, n)
+		}
+		wait := readyPollInterval
+		if left := s.readyTimeout - waited; left < wait {
+			wait = left
+		}
+		s.clock.Sleep(wait)
+	}
+}
+
+// readyReplicas returns the ready pods of the scaled objects and the replicas applied to them last.
+func (s *scale) readyReplicas() (ready, expected int32, err error) {
+	targets := s.replicaTargets()
+	expected = expectedReplicas(targets, *s.applied, s.appliedSplit)
+	for _, t := range targets {
+		if _, ok := s.appliedSplit[t.Name]; s.appliedSplit != nil && !ok {
+			continue
+		}
+		n, err := s.readyPods(t)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "reading the ready pods of %v", t)
+		}
+		ready += n
+	}
+	return ready, expected, nil
+}
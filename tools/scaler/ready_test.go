@@ -0,0 +1,81 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWaitForReady(t *testing.T) {
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	replicas := int32(5)
+	for name, tc := range map[string]struct {
+		// ready are the ready pods of the consecutive checks, the last one repeats.
+		ready     []int32
+		err       error
+		tolerance int32
+		failed    bool
+		waited    time.Duration
+		timeouts  float64
+	}{
+		"ready right away":    {ready: []int32{5}},
+		"ready after a while": {ready: []int32{1, 3, 5}, waited: 2 * readyPollInterval},
+		"within tolerance":    {ready: []int32{2, 4}, tolerance: 1, waited: readyPollInterval},
+		"terminating pods":    {ready: []int32{7, 5}, waited: readyPollInterval},
+		"stuck rollout":       {ready: []int32{3}, waited: time.Minute, timeouts: 1},
+		"read error":          {err: errors.New("forbidden")},
+		"failed apply":        {ready: []int32{0}, failed: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := newScaler()
+			s.clock = newVirtualClock(start)
+			s.waitReady = true
+			s.readyTimeout = time.Minute
+			s.convergenceTolerance = tc.tolerance
+			s.applied = &replicas
+			if tc.failed {
+				s.errStats.record(errors.New("conflict"), start)
+			}
+			checks := 0
+			s.waitForReady(func() (int32, int32, error) {
+				if tc.err != nil {
+					return 0, 0, tc.err
+				}
+				n := tc.ready[len(tc.ready)-1]
+				if checks < len(tc.ready) {
+					n = tc.ready[checks]
+				}
+				checks++
+				return n, replicas, nil
+			})
+			if waited := s.clock.Now().Sub(start); waited != tc.waited {
+				t.Errorf("want to wait %s, waited %s", tc.waited, waited)
+			}
+			if v := testutil.ToFloat64(s.metrics.readyTimeouts); v != tc.timeouts {
+				t.Errorf("want %v ready timeouts, got %v", tc.timeouts, v)
+			}
+		})
+	}
+
+	s := newScaler()
+	s.applied = &replicas
+	s.waitForReady(func() (int32, int32, error) {
+		t.Error("want no ready checks without wait for ready")
+		return 0, 0, nil
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	promV1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// replayQueryTimeout is how long the query of the replayed series may take.
+const replayQueryTimeout = time.Minute
+
+// replay follows a historical series queried from Prometheus, e.g. the replicas or the request rate during an incident,
+// one point per step. Every interval replays speed intervals of the history and the last point is kept once it is done.
+type replay struct {
+	min, max int32
+	// values are the scaled values by step, before they are rounded to replicas.
+	values []float64
+}
+
+func (r replay) replicas(step int) int32 {
+	return clampReplicas(r.raw(step), r.min, r.max)
+}
+
+func (r replay) raw(step int) float64 {
+	if step >= len(r.values) {
+		step = len(r.values) - 1
+	}
+	return r.values[step]
+}
+
+// newReplay queries the series of the replay pattern and maps its values to replicas.
+func newReplay(ph *phase, now time.Time) (replay, error) {
+	if ph.PrometheusURL == "" || ph.Query == "" {
+		return replay{}, errors.New("the replay pattern requires a Prometheus url and a query")
+	}
+	speed := ph.Speed
+	if speed == 0 {
+		speed = 1
+	}
+	if speed < 0 {
+		return replay{}, errors.Errorf("invalid speed %v for the replay pattern, must be > 0", ph.Speed)
+	}
+	if ph.ReplayScale < 0 {
+		return replay{}, errors.Errorf("invalid scale %v for the replay pattern, must be >= 0", ph.ReplayScale)
+	}
+	from, err := parseReplayTime(ph.From, now)
+	if err != nil {
+		return replay{}, err
+	}
+	to := now
+	if ph.To != "" {
+		if to, err = parseReplayTime(ph.To, now); err != nil {
+			return replay{}, err
+		}
+	}
+	if !from.Before(to) {
+		return replay{}, errors.Errorf("invalid replay range from %v to %v, from must be before to", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	step := time.Duration(float64(ph.Interval) * speed)
+	if step < time.Second {
+		return replay{}, errors.Errorf("invalid speed %v for the replay pattern, with the interval %s it replays less than 1s of the history per interval", speed, ph.Interval)
+	}
+
+	client, err := api.NewClient(api.Config{Address: ph.PrometheusURL})
+	if err != nil {
+		return replay{}, errors.Wrapf(err, "creating the Prometheus client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), replayQueryTimeout)
+	defer cancel()
+	result, warnings, err := promV1.NewAPI(client).QueryRange(ctx, ph.Query, promV1.Range{Start: from, End: to, Step: step})
+	if err != nil {
+		return replay{}, errors.Wrapf(err, "querying %q", ph.Query)
+	}
+	for _, w := range warnings {
+		log.Printf("Warning from the replay query %q: %v", ph.Query, w)
+	}
+	matrix, ok := result.(model.Matrix)
+	if !ok || len(matrix) != 1 {
+		return replay{}, errors.Errorf("the replay query %q must return a single series, got %d, aggregate it e.g. with sum()", ph.Query, len(matrix))
+	}
+
+	values, err := resampleReplay(matrix[0].Values, from, to, step)
+	if err != nil {
+		return replay{}, errors.Wrapf(err, "the replay query %q", ph.Query)
+	}
+	log.Printf("Replaying %d points of %q from %v to %v, %s of the history per interval", len(values), ph.Query, from.Format(time.RFC3339), to.Format(time.RFC3339), step)
+	return replay{min: ph.Min, max: ph.Max, values: scaleReplay(values, ph.Min, ph.Max, ph.ReplayScale)}, nil
+}
+
+// parseReplayTime parses a time as RFC3339, e.g. 2026-10-01T12:00:00Z, or as a duration before now, e.g. 24h.
+func parseReplayTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("the replay pattern requires the start of the replayed range")
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid replay time %q, must be RFC3339 like 2026-10-01T12:00:00Z or a duration before now like 24h", value)
+	}
+	return t, nil
+}
+
+// resampleReplay returns a value for every step of the range.
+// Gaps and NaN values repeat the previous value, the values before the first sample are the first sample.
+func resampleReplay(samples []model.SamplePair, from, to time.Time, step time.Duration) ([]float64, error) {
+	values := make([]float64, int(to.Sub(from)/step)+1)
+	set := make([]bool, len(values))
+	for _, s := range samples {
+		v := float64(s.Value)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		i := int(math.Round(float64(s.Timestamp.Time().Sub(from)) / float64(step)))
+		if i < 0 || i >= len(values) {
+			continue
+		}
+		values[i], set[i] = v, true
+	}
+
+	first := -1
+	for i := range values {
+		if set[i] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil, errors.New("no samples in the replayed range")
+	}
+	for i := range values {
+		switch {
+		case i < first:
+			values[i] = values[first]
+		case !set[i]:
+			values[i] = values[i-1]
+		}
+	}
+	return values, nil
+}
+
+// scaleReplay multiplies the replayed values by the scale,
+// a scale of 0 maps the lowest value of the series to min and the highest to max.
+func scaleReplay(values []float64, min, max int32, scale float64) []float64 {
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	scaled := make([]float64, len(values))
+	for i, v := range values {
+		switch {
+		case scale > 0:
+			scaled[i] = v * scale
+		case hi == lo:
+			// A flat series has no shape to fit, it replays the peak.
+			scaled[i] = float64(max)
+		default:
+			scaled[i] = float64(min) + (v-lo)/(hi-lo)*float64(max-min)
+		}
+	}
+	return scaled
+}
+
+// clampReplicas rounds a value to replicas between min and max.
+func clampReplicas(v float64, min, max int32) int32 {
+	r := math.Round(v)
+	if r < float64(min) {
+		return min
+	}
+	if r > float64(max) {
+		return max
+	}
+	return int32(r)
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestReplayPattern(t *testing.T) {
+	from := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var query, step string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			return
+		}
+		query, step = r.Form.Get("query"), r.Form.Get("step")
+		// A gap at the third minute.
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[%d,"2"],[%d,"4"],[%d,"10"]]}]}}`,
+			from.Unix(), from.Add(time.Minute).Unix(), from.Add(3*time.Minute).Unix())
+	}))
+	defer srv.Close()
+
+	ph := &phase{
+		Pattern:       "replay",
+		Min:           1,
+		Max:           8,
+		Interval:      time.Second,
+		PrometheusURL: srv.URL,
+		Query:         "sum(rate(http_requests_total[5m]))",
+		From:          from.Format(time.RFC3339),
+		To:            from.Add(3 * time.Minute).Format(time.RFC3339),
+		Speed:         60,
+		ReplayScale:   0.5,
+	}
+	p, err := newReplay(ph, from.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query != ph.Query || step != "60" {
+		t.Errorf("want the query %q with a 60s step, got %q with %q", ph.Query, query, step)
+	}
+	var replicas []int32
+	for i := 0; i < 5; i++ {
+		replicas = append(replicas, p.replicas(i))
+	}
+	// The gap repeats the previous value, 10*0.5 is clamped to max and the last point is kept.
+	if want := []int32{1, 2, 2, 5, 5}; !reflect.DeepEqual(want, replicas) {
+		t.Errorf("want %v, got %v", want, replicas)
+	}
+
+	for _, tc := range []struct {
+		name string
+		edit func(ph *phase)
+		err  string
+	}{
+		{name: "no query", edit: func(ph *phase) { ph.Query = "" }, err: "requires a Prometheus url and a query"},
+		{name: "negative speed", edit: func(ph *phase) { ph.Speed = -1 }, err: "invalid speed"},
+		{name: "slow speed", edit: func(ph *phase) { ph.Speed = 0.5 }, err: "less than 1s"},
+		{name: "no from", edit: func(ph *phase) { ph.From = "" }, err: "requires the start"},
+		{name: "reversed range", edit: func(ph *phase) { ph.To = "2h" }, err: "from must be before to"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ph := *ph
+			tc.edit(&ph)
+			if _, err := newReplay(&ph, from.Add(time.Hour)); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("want an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReplayReplicas(t *testing.T) {
+	replicas := func(values []float64, scale float64) []int32 {
+		r := replay{min: 2, max: 10, values: scaleReplay(values, 2, 10, scale)}
+		var replicas []int32
+		for i := range values {
+			replicas = append(replicas, r.replicas(i))
+		}
+		return replicas
+	}
+	values := []float64{100, 300, 500}
+	if want, got := []int32{2, 6, 10}, replicas(values, 0); !reflect.DeepEqual(want, got) {
+		t.Errorf("fit: want %v, got %v", want, got)
+	}
+	if want, got := []int32{2, 3, 5}, replicas(values, 0.01); !reflect.DeepEqual(want, got) {
+		t.Errorf("scale: want %v, got %v", want, got)
+	}
+	if want, got := []int32{10, 10}, replicas([]float64{7, 7}, 0); !reflect.DeepEqual(want, got) {
+		t.Errorf("flat: want %v, got %v", want, got)
+	}
+}
+
+func TestResampleReplay(t *testing.T) {
+	from := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(m int, v float64) model.SamplePair {
+		return model.SamplePair{Timestamp: model.TimeFromUnixNano(from.Add(time.Duration(m) * time.Minute).UnixNano()), Value: model.SampleValue(v)}
+	}
+	values, err := resampleReplay([]model.SamplePair{at(2, 3), at(4, 5)}, from, from.Add(5*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []float64{3, 3, 3, 3, 5, 5}; !reflect.DeepEqual(want, values) {
+		t.Errorf("want %v, got %v", want, values)
+	}
+	if _, err := resampleReplay(nil, from, from.Add(time.Minute), time.Minute); err == nil {
+		t.Error("expected an error for an empty series")
+	}
+}
+
+func TestParseReplayTime(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Time{
+		"24h":                  now.Add(-24 * time.Hour),
+		"2026-10-01T12:00:00Z": time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	} {
+		got, err := parseReplayTime(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("%v: want %v, got %v %v", value, want, got, err)
+		}
+	}
+	if _, err := parseReplayTime("yesterday", now); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
@@ -16,78 +16,959 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+	// The busybox image has no zoneinfo, the daily pattern needs it to follow the TZ env variable.
+	_ "time/tzdata"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/alecthomas/kingpin.v2"
 	appsV1 "k8s.io/api/apps/v1"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
 
+	"github.com/prometheus/test-infra/pkg/provider"
 	"github.com/prometheus/test-infra/pkg/provider/k8s"
 )
 
+// Exit codes returned by the scaler.
+const (
+	// exitFailure is returned when the scaling fails for any other reason once the arguments are valid.
+	exitFailure = 1
+	// exitUsage is returned for invalid arguments or an invalid scaling pattern.
+	exitUsage = 2
+	// exitK8sConnection is returned when the k8s client can't be created or connect to the cluster.
+	exitK8sConnection = 3
+	// exitApplyFailures is returned when the number of consecutive failed applies reaches the threshold.
+	exitApplyFailures = 4
+	// exitHookFailure is returned when a cycle hook fails with strict hooks.
+	exitHookFailure = 5
+	// exitReplicaDrift is returned when the replicas were changed outside of the scaler with fail on drift.
+	exitReplicaDrift = 6
+)
+
+var (
+	errApplyFailures = errors.New("too many consecutive apply failures")
+	errK8sConnection = errors.New("k8s connection error")
+)
+
+// usageError is an invalid argument found by an action, reported with the usage like the parse errors of kingpin.
+type usageError struct{ error }
+
+func (e usageError) Unwrap() error { return e.error }
+
+// actionError is an error returned by an action, to tell it apart from the parse errors of kingpin.
+type actionError struct{ error }
+
+func (e actionError) Unwrap() error { return e.error }
+
+// action marks the errors of the action as action errors.
+func action(fn kingpin.Action) kingpin.Action {
+	return func(ctx *kingpin.ParseContext) error {
+		if err := fn(ctx); err != nil {
+			return actionError{err}
+		}
+		return nil
+	}
+}
+
+// exitCode returns the exit code of an error returned by the parsing of the command line.
+// The parse errors of kingpin and the usage errors of the actions return exitUsage,
+// the other errors of the actions exitFailure unless they match one of the exit code sentinels.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, errApplyFailures):
+		return exitApplyFailures
+	case errors.Is(err, errHookFailure):
+		return exitHookFailure
+	case errors.Is(err, errReplicaDrift):
+		return exitReplicaDrift
+	case errors.Is(err, errK8sConnection):
+		return exitK8sConnection
+	case errors.As(err, new(usageError)):
+		return exitUsage
+	case errors.As(err, new(actionError)):
+		return exitFailure
+	}
+	return exitUsage
+}
+
 type scale struct {
+	// k8sClient is created by connect once the cli args are validated.
 	k8sClient *k8s.K8s
-	min       int32
-	max       int32
-	interval  time.Duration
+	// deploymentFiles and deploymentVars are parsed by the k8s client after connecting.
+	deploymentFiles []string
+	deploymentVars  map[string]string
+	// connectTimeout is how long creating the k8s client and connecting to the cluster are retried.
+	connectTimeout time.Duration
+	// k8sRetries are the retry policies of the k8s requests by operation class.
+	k8sRetries provider.RetryPolicies
+	// scaleTargets are scaled through their scale subresource instead of applying the deployments from the files,
+	// the scaleTargetArg object or the deployments in the scaleNamespace matching the selector, listed after connecting.
+	scaleTargets   []k8s.ScaleTarget
+	scaleTargetArg string
+	selector       string
+	scaleNamespace string
+
+	min           int32
+	max           int32
+	interval      time.Duration
+	patternName   string
+	scalingFactor int32
+	// period and phaseOffset configure the sine pattern.
+	period      time.Duration
+	phaseOffset string
+	// killRate and maxUnavailable configure the chaos pattern.
+	killRate       int
+	maxUnavailable int
+	rand           *rand.Rand
+	// levels configures the weighted pattern.
+	levels string
+	// dailyFactors and dailyBase configure the daily pattern.
+	dailyFactors string
+	dailyBase    int32
+	// stableDeployment, canaryDeployment and canaryWeights configure the canary pattern.
+	stableDeployment string
+	canaryDeployment string
+	canaryWeights    string
+	// prometheusURL, query, from, to, speed and replayScale configure the replay pattern.
+	prometheusURL string
+	query         string
+	from, to      string
+	speed         float64
+	replayScale   float64
+	// targetValue, tolerance and stabilizationWindow configure the hpa pattern.
+	targetValue         float64
+	tolerance           float64
+	stabilizationWindow time.Duration
+	// amplitudeJitter configures the burst pattern.
+	amplitudeJitter int32
+	// baseline, burstTo, burstEvery and burstDuration configure the soak-burst pattern.
+	baseline, burstTo         int32
+	burstEvery, burstDuration time.Duration
+	// split are the replicas by deployment name of the current canary step, nil for the other patterns.
+	// appliedSplit is the split of the last successful apply, for the drift detection.
+	split        map[string]int32
+	appliedSplit map[string]int32
+	// deployment is the only deployment from the files scaled by a worker of a per-deployment plan, empty otherwise.
+	deployment string
+	// planFile describes a sequence of phases, used instead of the single pattern from the cli args.
+	// profileFile is the same set with --profile, an alias of --plan.
+	planFile    string
+	profileFile string
+	// configMap holds the min, max and interval of the cli args phase, reloaded when it changes.
+	configMap string
+	// reload passes the reloaded phase to the scaling loop, nil without a configMap.
+	reload chan *phase
+	// confirmDestructive allows the phases that delete pods or scale to zero replicas, they are refused otherwise.
+	confirmDestructive bool
+	// downscaleStep limits how many replicas are removed per interval.
+	// 0 means no limit.
+	downscaleStep int32
+	// transitionSteps is the number of applies used to reach each target within an interval.
+	transitionSteps int
+	// intervalStart and intervalEnd ramp the interval of the cli args phase over intervalRamp.
+	intervalStart time.Duration
+	intervalEnd   time.Duration
+	intervalRamp  time.Duration
+	// minDwell is the minimum time each replica level is held before the pattern moves to the next level,
+	// the default of the phases that don't set their own.
+	minDwell time.Duration
+	// perReplicaRPS, minReplicas and maxReplicas turn the replicas of the cli args phase into request rates.
+	perReplicaRPS            float64
+	minReplicas, maxReplicas int32
+	// level is the target last reached and levelSince when it was reached, zero before the first apply.
+	level      int32
+	levelSince time.Time
+	current    int32
+	// warmup is how long the scaler_warmup gauge is 1 after the scaling starts, 0 disables it.
+	warmup time.Duration
+	// activeWindows are the windows during which the replicas are changed, they are held outside of them.
+	// The windows given as durations are offsets from started, the start of the scaling.
+	activeWindowSpecs []string
+	activeWindows     []activeWindow
+	started           time.Time
+	// clock is the time source of the scaling loop, a virtual clock with simulate.
+	clock clock
+	// simulate runs the plan on a virtual clock from simulateStart for at most this long, without a cluster,
+	// and prints the applies as JSON. 0 disables it, simulation records the applies otherwise.
+	simulate      time.Duration
+	simulateStart string
+	simulateSeed  int64
+	simulation    *simulation
+	// scheduleFormat and scheduleOutput are the format and the file of the applies printed by schedule,
+	// out is where they are written, stdout by default.
+	scheduleFormat string
+	scheduleOutput string
+	out            io.Writer
+	// maxConsecutiveErrors is the number of consecutive failed applies after which the scaler exits.
+	// 0 means the scaler never gives up.
+	maxConsecutiveErrors int
+	errStats             errorStats
+	// stop is closed when another worker of a per-deployment plan failed, nil otherwise.
+	stop <-chan struct{}
+	// detectDrift reads the replicas before every apply and logs when they differ from the applied replicas.
+	detectDrift bool
+	failOnDrift bool
+	// convergence compares the ready pods with the applied replicas at the end of every cycle,
+	// converged is whether they were within the convergenceTolerance at the last check.
+	convergence          bool
+	convergenceTolerance int32
+	converged            bool
+	// waitReady holds every step of scaleTo until the ready pods match the applied replicas within the convergenceTolerance,
+	// at most for the readyTimeout.
+	waitReady    bool
+	readyTimeout time.Duration
+	// globalMaxReplicas caps the sum of the replicas of the deployments of a per-deployment plan through replicaCap, 0 disables it.
+	globalMaxReplicas int32
+	replicaCap        *replicaCap
+	// emitEvents creates a Kubernetes Event for the scaled objects on every replica change through events,
+	// at most eventBurst events at once refilled one every eventInterval.
+	emitEvents    bool
+	eventBurst    int
+	eventInterval time.Duration
+	events        *eventRecorder
+	// healthGate pauses the scaling while the Prometheus under test is unhealthy, nil without a health gate url.
+	healthGate         *healthGate
+	healthGateURL      string
+	healthGateQuery    string
+	healthGateInterval time.Duration
+	healthGateTimeout  time.Duration
+	// applied is the number of replicas last applied successfully, nil before the first apply,
+	// and appliedSince when they were last added to the loadIntegral shared by the deployments of a per-deployment plan.
+	applied      *int32
+	appliedSince time.Time
+	loadIntegral *loadIntegral
+
+	metrics        *scalerMetrics
+	pushgatewayURL string
+	pushgatewayJob string
+	// pushgatewayTimeout bounds every push.
+	pushgatewayTimeout time.Duration
+	// metricInstance is the instance label of the pushed metrics, the hostname when empty.
+	metricInstance string
+	// metricLabels are constant labels added to all scaler metrics.
+	metricLabels map[string]string
+	// exemplars generates a trace ID for every scaling event, logged, passed to the hooks
+	// and added as an exemplar to the counters, so the metrics can be linked to the traces of the event.
+	exemplars bool
+	// traceID is the trace ID of the current scaling event, empty without exemplars.
+	traceID string
+	// traceSteps logs the computed and applied replicas of every step through the tracer, nil without it.
+	traceSteps bool
+	tracer     *log.Logger
+	// outputSinks are the stdout or file:PATH destinations of the scale events written through sinks,
+	// nil without output sinks. outputFileMode is how the files of a previous run are treated.
+	outputSinks    []string
+	outputFileMode string
+	sinks          *eventSinks
+	// listenAddress serves the health and metrics endpoints, disabled when empty.
+	listenAddress string
+	health        *health
+
+	// preCycleHook and postCycleHook are commands or urls run at the start and the end of every cycle.
+	preCycleHook  string
+	postCycleHook string
+	hookTimeout   time.Duration
+	// strictHooks stops the scaler when a hook fails instead of only logging the failure.
+	strictHooks bool
 }
 
 func newScaler() *scale {
-	k, err := k8s.New(context.Background(), nil)
+	return &scale{
+		deploymentVars: map[string]string{},
+		metricLabels:   map[string]string{},
+		metrics:        newScalerMetrics(),
+		health:         newHealth(),
+		loadIntegral:   newLoadIntegral(),
+		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:          realClock{},
+		k8sRetries:     provider.DefaultRetryPolicies(),
+		out:            os.Stdout,
+	}
+}
+
+// connect creates the k8s client inside the k8s cluster and parses the deployment files.
+// The client creation and the connection are retried until the connect timeout expires.
+func (s *scale) connect() error {
+	k, err := k8s.Connect(context.Background(), nil, k8s.RateLimits{Retries: s.k8sRetries}, k8s.TLSOptions{}, s.connectTimeout)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error creating k8s client inside the k8s cluster"))
-		os.Exit(2)
+		return errors.Wrapf(errK8sConnection, "%v", err)
 	}
-	return &scale{
-		k8sClient: k,
+	if err := k.RegisterMetrics(s.metrics.registerer); err != nil {
+		return errors.Wrapf(err, "registering the k8s provider metrics")
 	}
+	k.DeploymentFiles = s.deploymentFiles
+	k.DeploymentVars = s.deploymentVars
+	if err := k.DeploymentsParse(nil); err != nil {
+		return err
+	}
+	s.k8sClient = k
+	return nil
 }
 
-func (s *scale) updateReplicas(replicas *int32) []k8s.Resource {
-	var k8sResource []k8s.Resource
-	for _, deployment := range s.k8sClient.GetResources() {
-		k8sObjects := make([]runtime.Object, 0)
+// updateReplicas returns copies of the deployments scaled by this scaler with the given replicas,
+// or with their share of the replicas while a canary split is active.
+func (s *scale) updateReplicas(replicas int32) ([]k8s.Resource, error) {
+	deployments := s.scaledDeployments()
+	err := k8s.SetReplicasFunc(deployments, "", func(name string) int32 {
+		if s.split != nil {
+			return s.split[name]
+		}
+		return replicas
+	})
+	return deployments, err
+}
 
-		for _, resource := range deployment.Objects {
-			if kind := strings.ToLower(resource.GetObjectKind().GroupVersionKind().Kind); kind == "deployment" {
-				req := resource.(*appsV1.Deployment)
-				req.Spec.Replicas = replicas
-				k8sObjects = append(k8sObjects, req.DeepCopyObject())
+// scaledDeployments returns copies of the deployments, statefulsets and replicasets from the files scaled by this scaler,
+// only the deployments of the canary split while it is active.
+func (s *scale) scaledDeployments() []k8s.Resource {
+	return k8s.FilterObjects(s.k8sClient.GetResources(), func(obj runtime.Object) bool {
+		o, ok := scalableObject(obj)
+		if !ok || s.skipDeployment(o.name) {
+			return false
+		}
+		_, split := s.split[o.name]
+		return s.split == nil || split
+	})
+}
+
+// scalable is a deployment, statefulset or replicaset from the files.
+type scalable struct {
+	name, namespace string
+	// resource is the resource of its kind, e.g. to read the replicas through the scale subresource.
+	resource schema.GroupVersionResource
+	selector *apiMetaV1.LabelSelector
+}
+
+// scalableObject returns the object as a scalable, ok is false when it isn't a deployment, statefulset or replicaset.
+// The namespace defaults to the default namespace like the applies.
+func scalableObject(obj runtime.Object) (o scalable, ok bool) {
+	var meta apiMetaV1.ObjectMeta
+	switch req := obj.(type) {
+	case *appsV1.Deployment:
+		meta, o.selector, o.resource.Resource = req.ObjectMeta, req.Spec.Selector, "deployments"
+	case *appsV1.StatefulSet:
+		meta, o.selector, o.resource.Resource = req.ObjectMeta, req.Spec.Selector, "statefulsets"
+	case *appsV1.ReplicaSet:
+		meta, o.selector, o.resource.Resource = req.ObjectMeta, req.Spec.Selector, "replicasets"
+	default:
+		return scalable{}, false
+	}
+	o.resource.Group, o.resource.Version = "apps", "v1"
+	o.name, o.namespace = meta.Name, meta.Namespace
+	if o.namespace == "" {
+		o.namespace = "default"
+	}
+	return o, true
+}
+
+func (s *scale) scale(*kingpin.ParseContext) error {
+	p, err := s.checkArgs()
+	if err != nil {
+		return usageError{err}
+	}
+	if s.simulate != 0 {
+		if err := s.startSimulation(p); err != nil {
+			return usageError{err}
+		}
+	}
+	if s.sinks, err = s.openSinks(); err != nil {
+		return err
+	}
+	defer s.sinks.Close()
+	register := s.metrics.register
+	if len(p.Deployments) > 0 {
+		register = s.metrics.registerShared
+	}
+	if err := register(s.metricLabels); err != nil {
+		return err
+	}
+	s.metrics.exemplars = s.exemplars
+	if s.pushgatewayURL != "" {
+		s.metrics.enablePush(s.pushgatewayURL, s.pushgatewayJob, s.metricInstance, s.pushgatewayTimeout)
+	}
+	if s.listenAddress != "" {
+		if err := s.serve(); err != nil {
+			return err
+		}
+	}
+	if s.simulation == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+		if s.selector != "" {
+			if s.scaleTargets, err = s.k8sClient.SelectScaleTargets(deploymentsResource, s.scaleNamespace, s.selector); err != nil {
+				return err
+			}
+			log.Printf("Scaling %d deployment(s) matching %q: %v", len(s.scaleTargets), s.selector, s.scaleTargets)
+		}
+		for _, t := range s.scaleTargets {
+			if err := s.k8sClient.CheckScaleTarget(t); err != nil {
+				return err
 			}
 		}
-		if len(k8sObjects) > 0 {
-			k8sResource = append(k8sResource, k8s.Resource{FileName: deployment.FileName, Objects: k8sObjects})
+		if err := s.checkCanaryTargets(p); err != nil {
+			return err
+		}
+		if s.emitEvents {
+			s.events = newEventRecorder(s.k8sClient, s.eventBurst, s.eventInterval)
 		}
 	}
-	return k8sResource
+	s.started = s.clock.Now()
+	if len(p.Deployments) > 0 {
+		workers, err := s.deploymentWorkers(p)
+		if err != nil {
+			return err
+		}
+		log.Printf("Starting Prombench-Scaler:\n\t deployments: %d\n\t downscale-step: %d\n\t transition-steps: %d", len(workers), s.downscaleStep, s.transitionSteps)
+		s.startWarmup()
+		err = s.runDeployments(workers)
+		s.logLoad()
+		return s.simulationResult(s.out, err)
+	}
+	if s.configMap != "" {
+		ph, err := s.watchConfig(p.Phases[0])
+		if err != nil {
+			return err
+		}
+		p.Phases[0] = ph
+	}
+	log.Printf("Starting Prombench-Scaler:\n\t phases: %d\n\t loop: %v\n\t downscale-step: %d\n\t transition-steps: %d", len(p.Phases), p.Loop, s.downscaleStep, s.transitionSteps)
+	s.startWarmup()
+	err = s.runPlan(p)
+	s.endLoad()
+	s.logLoad()
+	return s.simulationResult(s.out, err)
 }
 
-func (s *scale) scale(*kingpin.ParseContext) error {
-	log.Printf("Starting Prombench-Scaler:\n\t max: %d\n\t min: %d\n\t interval: %s", s.max, s.min, s.interval)
+// checkArgs checks the flags and args of the scale and schedule commands and returns the plan to run.
+func (s *scale) checkArgs() (*plan, error) {
+	if s.downscaleStep < 0 {
+		return nil, errors.Errorf("invalid downscale-step %d, must be >= 0", s.downscaleStep)
+	}
+	if s.transitionSteps < 1 {
+		return nil, errors.Errorf("invalid transition-steps %d, must be >= 1", s.transitionSteps)
+	}
+	if s.transitionSteps > 1 && s.downscaleStep > 0 {
+		return nil, errors.New("downscale-step and transition-steps can't be used together")
+	}
+	if s.minDwell < 0 {
+		return nil, errors.Errorf("invalid min-dwell %s, must be >= 0", s.minDwell)
+	}
+	if s.warmup < 0 {
+		return nil, errors.Errorf("invalid warmup %s, must be >= 0", s.warmup)
+	}
+	windows, err := parseActiveWindows(s.activeWindowSpecs)
+	if err != nil {
+		return nil, err
+	}
+	s.activeWindows = windows
+	if s.maxConsecutiveErrors < 0 {
+		return nil, errors.Errorf("invalid max-consecutive-errors %d, must be >= 0", s.maxConsecutiveErrors)
+	}
+	if s.failOnDrift {
+		s.detectDrift = true
+	}
+	if s.waitReady && s.readyTimeout <= 0 {
+		return nil, errors.Errorf("invalid ready-timeout %s, must be > 0", s.readyTimeout)
+	}
+	if s.connectTimeout < 0 {
+		return nil, errors.Errorf("invalid connect-timeout %s, must be >= 0", s.connectTimeout)
+	}
+	if s.pushgatewayURL != "" && s.pushgatewayTimeout <= 0 {
+		return nil, errors.Errorf("invalid pushgateway-timeout %s, must be > 0", s.pushgatewayTimeout)
+	}
+	if s.traceSteps {
+		s.tracer = newTracer()
+	}
+	if s.healthGate, err = newHealthGate(s.healthGateURL, s.healthGateQuery, s.healthGateInterval, s.healthGateTimeout); err != nil {
+		return nil, err
+	}
+	switch {
+	case s.scaleTargetArg != "" && len(s.deploymentFiles) > 0:
+		return nil, errors.New("--file and --scale-target can't be used together")
+	case s.selector != "" && len(s.deploymentFiles) > 0:
+		return nil, errors.New("--file and --selector can't be used together")
+	case s.selector != "" && s.scaleTargetArg != "":
+		return nil, errors.New("--scale-target and --selector can't be used together")
+	case s.scaleTargetArg != "":
+		t, err := k8s.ParseScaleTarget(s.scaleTargetArg, s.scaleNamespace)
+		if err != nil {
+			return nil, err
+		}
+		s.scaleTargets = []k8s.ScaleTarget{t}
+	case len(s.deploymentFiles) == 0 && s.selector == "" && s.simulate == 0:
+		return nil, errors.New("either --file, --scale-target or --selector is required")
+	}
+	if s.profileFile != "" {
+		if s.planFile != "" {
+			return nil, errors.New("--profile is an alias of --plan, they can't be used together")
+		}
+		s.planFile = s.profileFile
+	}
+	if s.configMap != "" && s.planFile != "" {
+		return nil, errors.New("--config-configmap and --plan can't be used together")
+	}
+	if s.configMap != "" && s.intervalEnd > 0 {
+		return nil, errors.New("--config-configmap sets the interval and can't be used with --interval-end")
+	}
+	if (s.preCycleHook != "" || s.postCycleHook != "") && s.hookTimeout <= 0 {
+		return nil, errors.Errorf("invalid hook-timeout %s, must be > 0", s.hookTimeout)
+	}
+	p, err := s.plan()
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Deployments) > 0 && (s.scaleTargetArg != "" || s.selector != "") {
+		return nil, errors.New("a per-deployment plan scales the deployments from the files and can't be used with --scale-target or --selector")
+	}
+	// A simulation runs without a cluster, so it can't destroy anything.
+	if s.simulate == 0 {
+		if err := s.checkDestructive(p); err != nil {
+			return nil, err
+		}
+	}
+	if s.globalMaxReplicas < 0 {
+		return nil, errors.Errorf("invalid global-max-replicas %d, must be >= 0", s.globalMaxReplicas)
+	}
+	if s.globalMaxReplicas > 0 {
+		if len(p.Deployments) == 0 {
+			return nil, errors.New("--global-max-replicas caps the deployments of a per-deployment plan and requires one")
+		}
+		s.replicaCap = newReplicaCap(s.globalMaxReplicas)
+	}
+	if s.emitEvents && s.eventBurst < 1 {
+		return nil, errors.Errorf("invalid event-burst %d, must be >= 1", s.eventBurst)
+	}
+	if s.emitEvents && s.eventInterval <= 0 {
+		return nil, errors.Errorf("invalid event-interval %s, must be > 0", s.eventInterval)
+	}
+	return p, nil
+}
 
-	maxResourceObjects := s.updateReplicas(&s.max)
-	minResourceObjects := s.updateReplicas(&s.min)
+// runPlan runs the phases of the plan one after the other, from the first phase again when the plan loops.
+func (s *scale) runPlan(p *plan) error {
+	for {
+		for _, ph := range p.Phases {
+			s.logf("Starting phase %q:\n\t pattern: %s\n\t max: %d\n\t min: %d\n\t interval: %s\n\t duration: %s", ph.Name, ph.Pattern, ph.Max, ph.Min, ph.intervalString(), ph.Duration)
+			for r := 0; r < ph.repetitions(); r++ {
+				run := ph.repetition(r)
+				if ph.repetitions() > 1 {
+					s.logf("Starting repetition %d/%d of phase %q for %s", r+1, ph.repetitions(), ph.Name, run.Duration.Round(time.Second))
+				}
+				if err := s.runPhase(run); err != nil {
+					return err
+				}
+				if s.stopped() {
+					return nil
+				}
+			}
+			s.logf("Phase %q completed", ph.Name)
+		}
+		if s.simulationDone() || s.stopped() {
+			return nil
+		}
+		if !p.Loop {
+			s.logf("All phases completed")
+			return nil
+		}
+	}
+}
+
+// startWarmup sets the scaler_warmup gauge to 1 for the warmup and back to 0 once it has passed.
+// The load is applied normally during the warmup, the gauge only marks the window for the dashboards.
+func (s *scale) startWarmup() *time.Timer {
+	if s.warmup == 0 {
+		return nil
+	}
+	log.Printf("Warming up for %s, scaler_warmup is 1 until then", s.warmup)
+	s.metrics.warmup.Set(1)
+	s.metrics.push()
+	return time.AfterFunc(s.warmup, func() {
+		log.Printf("Warmup completed")
+		s.metrics.warmup.Set(0)
+		s.metrics.push()
+	})
+}
+
+// serve starts the http server for the health and metrics endpoints.
+func (s *scale) serve() error {
+	l, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		return errors.Wrapf(err, "listening on %v", s.listenAddress)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.health.healthz)
+	mux.HandleFunc("/readyz", s.health.readyz)
+	// The exemplars are only exposed in the OpenMetrics format.
+	mux.Handle("/metrics", promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{EnableOpenMetrics: s.exemplars}))
+	go func() {
+		if err := http.Serve(l, mux); err != nil {
+			log.Printf("http server error: %v", err)
+		}
+	}()
+	log.Printf("Serving the health and metrics endpoints on %v", s.listenAddress)
+	return nil
+}
+
+// plan returns the plan from the plan file
+// or a plan with a single endless phase built from the cli args.
+func (s *scale) plan() (*plan, error) {
+	if s.planFile != "" {
+		p, err := loadPlan(s.planFile)
+		if err != nil {
+			return nil, err
+		}
+		plans := []*plan{p}
+		for _, d := range p.Deployments {
+			plans = append(plans, d)
+		}
+		for _, d := range plans {
+			for _, ph := range d.Phases {
+				if ph.MinDwell == 0 {
+					ph.MinDwell = s.minDwell
+				}
+			}
+		}
+		return p, nil
+	}
+	interval := s.interval
+	if s.intervalStart > 0 {
+		interval = s.intervalStart
+	}
+	if s.max == 0 || interval == 0 {
+		return nil, errors.New("the max, min and interval args are required when a plan file is not set")
+	}
+	ph := &phase{
+		Name:                s.patternName,
+		Pattern:             s.patternName,
+		Min:                 s.min,
+		Max:                 s.max,
+		ScalingFactor:       s.scalingFactor,
+		Interval:            interval,
+		IntervalStart:       s.intervalStart,
+		IntervalEnd:         s.intervalEnd,
+		IntervalRamp:        s.intervalRamp,
+		Period:              s.period,
+		PhaseOffset:         s.phaseOffset,
+		KillRate:            s.killRate,
+		MaxUnavailable:      s.maxUnavailable,
+		Levels:              s.levels,
+		DailyFactors:        s.dailyFactors,
+		DailyBase:           s.dailyBase,
+		StableDeployment:    s.stableDeployment,
+		CanaryDeployment:    s.canaryDeployment,
+		CanaryWeights:       s.canaryWeights,
+		PrometheusURL:       s.prometheusURL,
+		Query:               s.query,
+		From:                s.from,
+		To:                  s.to,
+		Speed:               s.speed,
+		ReplayScale:         s.replayScale,
+		TargetValue:         s.targetValue,
+		Tolerance:           s.tolerance,
+		StabilizationWindow: s.stabilizationWindow,
+		AmplitudeJitter:     s.amplitudeJitter,
+		Baseline:            s.baseline,
+		BurstTo:             s.burstTo,
+		BurstEvery:          s.burstEvery,
+		BurstDuration:       s.burstDuration,
+		PerReplicaRPS:       s.perReplicaRPS,
+		MinReplicas:         s.minReplicas,
+		MaxReplicas:         s.maxReplicas,
+		MinDwell:            s.minDwell,
+	}
+	if err := ph.validate(); err != nil {
+		return nil, err
+	}
+	return &plan{Phases: []*phase{ph}}, nil
+}
+
+// runPhase applies the phase pattern step by step until the phase duration has passed.
+// A phase without a duration runs forever.
+// The cycle hooks run before each step is applied and after its interval has passed.
+// A step that moves to another replica level waits until the current level was held for the min dwell.
+// With an interval ramp every step waits for the interval at its start.
+func (s *scale) runPhase(ph *phase) error {
+	start := s.clock.Now()
+	s.health.progress(ph.Interval)
+	for i := 0; ph.Duration == 0 || s.clock.Now().Sub(start) < ph.Duration; i++ {
+		if s.simulationDone() || s.stopped() {
+			return nil
+		}
+		ph = s.reloadedPhase(ph)
+		elapsed := s.clock.Now().Sub(start)
+		interval := ph.stepInterval(elapsed)
+		if s.holdOutsideActiveWindows(ph, start) {
+			return nil
+		}
+		if s.holdWhileUnhealthy(ph, start) {
+			return nil
+		}
+		target := ph.pattern.replicas(i)
+		if s.exemplars {
+			s.traceID = newTraceID()
+		}
+		if wait := s.dwellRemaining(ph.MinDwell, target, s.clock.Now()); wait > 0 {
+			// The next phase decides the level when this one ends first.
+			if left := ph.Duration - s.clock.Now().Sub(start); ph.Duration > 0 && left < wait {
+				wait = left
+			}
+			s.logf("Holding %d replicas for %s more before scaling to %d, min dwell is %s", s.level, wait.Round(time.Second), target, ph.MinDwell)
+			s.health.progress(wait)
+			s.clock.Sleep(wait)
+			if ph.Duration > 0 && s.clock.Now().Sub(start) >= ph.Duration {
+				return nil
+			}
+		}
+		if err := s.runHook("pre", s.preCycleHook, hookVars("pre", ph, i, target, s.current, interval)); err != nil {
+			return err
+		}
+		var err error
+		switch p := ph.pattern.(type) {
+		case chaos:
+			s.split = nil
+			err = s.chaosStep(p, interval)
+		case canary:
+			err = s.canaryStep(p, i, interval)
+		default:
+			s.split = nil
+			err = s.scaleTo(target, interval)
+		}
+		s.traceStep(ph, i, elapsed, target, err)
+		if err != nil {
+			return err
+		}
+		s.checkConvergence()
+		if err := s.runHook("post", s.postCycleHook, hookVars("post", ph, i, target, s.current, interval)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scaleTo applies the target number of replicas and waits for an interval.
+// When a downscale step is set the replicas are removed gradually,
+// one interval per step, until the target is reached.
+// When transition steps are set the target is reached over that many applies within the interval.
+// With wait ready every apply first waits for its replicas to be ready.
+func (s *scale) scaleTo(target int32, interval time.Duration) error {
+	if s.transitionSteps > 1 {
+		stepInterval := interval / time.Duration(s.transitionSteps)
+		for _, replicas := range transitionReplicas(s.current, target, s.transitionSteps) {
+			if err := s.apply(replicas, target); err != nil {
+				return err
+			}
+			s.waitForReady(s.readyReplicas)
+			s.health.progress(stepInterval)
+			s.clock.Sleep(stepInterval)
+			if s.stopped() {
+				return nil
+			}
+		}
+		return nil
+	}
 
 	for {
-		log.Printf("Scaling Deployment to %d", s.max)
-		if err := s.k8sClient.ResourceApply(maxResourceObjects); err != nil {
-			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error scaling deployment"))
+		replicas := nextReplicas(s.current, target, s.downscaleStep)
+		if err := s.apply(replicas, target); err != nil {
+			return err
 		}
+		s.waitForReady(s.readyReplicas)
+		s.health.progress(interval)
 
-		time.Sleep(s.interval)
+		s.clock.Sleep(interval)
 
-		log.Printf("Scaling Deployment to %d", s.min)
-		if err := s.k8sClient.ResourceApply(minResourceObjects); err != nil {
-			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error scaling deployment"))
+		if replicas == target || s.stopped() {
+			return nil
+		}
+	}
+}
+
+// apply applies the given number of replicas and records the result.
+// Failed applies are only logged until the max consecutive errors are reached.
+func (s *scale) apply(replicas, target int32) error {
+	if err := s.checkDrift(); err != nil {
+		return err
+	}
+	replicas = s.capReplicas(replicas)
+	if s.traceID != "" {
+		s.logf("Scaling Deployment to %d, trace_id=%s", replicas, s.traceID)
+	} else {
+		s.logf("Scaling Deployment to %d", replicas)
+	}
+	s.metrics.targetReplicas.Set(float64(target))
+	err := s.applyReplicas(replicas)
+	s.recordEvent(replicas, target, err)
+	s.recordScaleEvent(replicas, target, err)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error scaling deployment"))
+		s.metrics.inc(s.metrics.applies.WithLabelValues("failure"), s.traceID)
+		if err := s.recordError(err); err != nil {
+			return err
+		}
+	} else {
+		s.errStats.reset()
+		s.metrics.inc(s.metrics.applies.WithLabelValues("success"), s.traceID)
+		s.metrics.appliedReplicas.Set(float64(replicas))
+		s.accumulateLoad(s.clock.Now())
+		s.applied = &replicas
+		s.appliedSince = s.clock.Now()
+		s.appliedSplit = s.split
+		if s.simulation != nil {
+			s.simulation.record(s.clock.Now(), s.deployment, target, replicas, s.split)
+		}
+		if replicas == target && (target != s.level || s.levelSince.IsZero()) {
+			s.level = target
+			s.levelSince = s.clock.Now()
+		}
+		s.current = replicas
+	}
+	s.metrics.push()
+	return nil
+}
+
+// dwellRemaining returns how much longer the current level has to be held before moving to the target.
+func (s *scale) dwellRemaining(minDwell time.Duration, target int32, now time.Time) time.Duration {
+	if minDwell <= 0 || s.levelSince.IsZero() || target == s.level {
+		return 0
+	}
+	if held := now.Sub(s.levelSince); held < minDwell {
+		return minDwell - held
+	}
+	return 0
+}
+
+// recordError counts a failed operation and returns an error once the max consecutive errors are reached.
+// With max consecutive errors a forbidden operation returns the error right away, it fails the same way until the RBAC role is fixed.
+func (s *scale) recordError(err error) error {
+	s.errStats.record(err, s.clock.Now())
+	if s.maxConsecutiveErrors > 0 && (s.errStats.consecutive >= s.maxConsecutiveErrors || k8s.IsForbidden(err)) {
+		return errors.Wrapf(errApplyFailures, "%d failed applies, last err: %v", s.errStats.consecutive, err)
+	}
+	return nil
+}
+
+// errorStats counts the failed operations for the max consecutive errors and the summary printed on exit.
+type errorStats struct {
+	consecutive int
+	total       int
+	// since is the time of the first of the consecutive failures.
+	since time.Time
+	// messages counts the consecutive failures by error message.
+	messages map[string]int
+}
+
+func (e *errorStats) record(err error, now time.Time) {
+	if e.consecutive == 0 {
+		e.since = now
+		e.messages = map[string]int{}
+	}
+	e.consecutive++
+	e.total++
+	e.messages[err.Error()]++
+}
+
+// reset clears the consecutive failures after a successful operation.
+func (e *errorStats) reset() {
+	e.consecutive = 0
+	e.messages = nil
+}
+
+// merge adds the failures of other, e.g. of a worker of a per-deployment plan, with its messages prefixed by prefix.
+// The consecutive failures start at the earliest of them.
+func (e *errorStats) merge(prefix string, other errorStats) {
+	e.total += other.total
+	if other.consecutive == 0 {
+		return
+	}
+	if e.consecutive == 0 || other.since.Before(e.since) {
+		e.since = other.since
+	}
+	if e.messages == nil {
+		e.messages = map[string]int{}
+	}
+	e.consecutive += other.consecutive
+	for m, n := range other.messages {
+		e.messages[prefix+m] += n
+	}
+}
+
+// summary lists the distinct errors of the consecutive failures, the most frequent first.
+func (e *errorStats) summary(now time.Time) string {
+	msgs := make([]string, 0, len(e.messages))
+	for m := range e.messages {
+		msgs = append(msgs, m)
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		if e.messages[msgs[i]] != e.messages[msgs[j]] {
+			return e.messages[msgs[i]] > e.messages[msgs[j]]
+		}
+		return msgs[i] < msgs[j]
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d consecutive failures over %s, %d failures in total", e.consecutive, now.Sub(e.since).Round(time.Second), e.total)
+	for _, m := range msgs {
+		fmt.Fprintf(&b, "\n  %dx %v", e.messages[m], m)
+	}
+	return b.String()
+}
+
+// applyReplicas scales the target through its scale subresource when set,
+// otherwise it applies the deployments from the files with the given replicas.
+// Conflicts, e.g. with an HPA updating the same object, are retried right away.
+func (s *scale) applyReplicas(replicas int32) error {
+	if s.simulation != nil {
+		return nil
+	}
+	return retry.OnError(retry.DefaultRetry, k8s.IsConflict, func() error {
+		if len(s.scaleTargets) > 0 {
+			for _, t := range s.scaleTargets {
+				if err := s.k8sClient.Scale(t, replicas); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		deployments, err := s.updateReplicas(replicas)
+		if err != nil {
+			return err
 		}
+		return s.k8sClient.ResourceApply(deployments)
+	})
+}
+
+// nextReplicas returns the number of replicas to apply when moving from current to target.
+// Scaling up is always immediate while scaling down removes at most step replicas.
+func nextReplicas(current, target, step int32) int32 {
+	if step > 0 && current-target > step {
+		return current - step
+	}
+	return target
+}
 
-		time.Sleep(s.interval)
+// transitionReplicas splits the move from current to target into evenly spaced applies.
+// The last apply is always the target.
+func transitionReplicas(current, target int32, steps int) []int32 {
+	replicas := make([]int32, 0, steps)
+	diff := int64(target) - int64(current)
+	for i := 1; i <= steps; i++ {
+		replicas = append(replicas, current+int32(diff*int64(i)/int64(steps)))
 	}
+	return replicas
 }
 
 func main() {
@@ -97,29 +978,285 @@ func main() {
 
 	s := newScaler()
 
-	k8sApp := app.Command("scale", "Scale a Kubernetes deployment object periodically up and down. \nex: ./scaler scale -v NAMESPACE:scale -f fake-webserver.yaml 20 1 15m").
-		Action(s.k8sClient.DeploymentsParse).
-		Action(s.scale)
+	k8sApp := app.Command("scale", "Scale a Kubernetes deployment object periodically up and down. \nex: ./scaler scale -v NAMESPACE:scale -f fake-webserver.yaml 20 1 15m\nex: ./scaler scale -v NAMESPACE:scale -f fake-webserver.yaml --plan plan.yaml").
+		Action(action(s.scale))
 	k8sApp.Flag("file", "yaml file or folder that describes the parameters for the deployment.").
-		Required().
 		Short('f').
-		ExistingFilesOrDirsVar(&s.k8sClient.DeploymentFiles)
+		ExistingFilesOrDirsVar(&s.deploymentFiles)
 	k8sApp.Flag("vars", "When provided it will substitute the token holders in the yaml file. Follows the standard golang template formating - {{ .hashStable }}.").
 		Short('v').
-		StringMapVar(&s.k8sClient.DeploymentVars)
-	k8sApp.Arg("max", "Number of Replicas to scale up.").
-		Required().
-		Int32Var(&s.max)
-	k8sApp.Arg("min", "Number of Replicas to scale down.").
-		Required().
-		Int32Var(&s.min)
-	k8sApp.Arg("interval", "Time to wait before changing the number of replicas.").
+		StringMapVar(&s.deploymentVars)
+	k8sApp.Flag("scale-target", "Object to scale through its scale subresource instead of the deployments from --file, e.g. argoproj.io/v1alpha1/rollouts/loadgen. Use core as the group of core resources.").
+		PlaceHolder("group/version/resource/name").
+		StringVar(&s.scaleTargetArg)
+	k8sApp.Flag("selector", "Label selector of existing deployments in --scale-namespace scaled through their scale subresource instead of the deployments from --file, e.g. app=loadgen. At least one deployment must match at start.").
+		Short('l').
+		StringVar(&s.selector)
+	k8sApp.Flag("scale-namespace", "Namespace of the --scale-target object or the --selector deployments.").
+		Default("default").
+		StringVar(&s.scaleNamespace)
+	k8sApp.Flag("connect-timeout", "How long to retry creating the k8s client and connecting to the cluster at start, e.g. while the in-cluster config isn't ready yet. 0 tries once.").
+		Default("1m").
+		DurationVar(&s.connectTimeout)
+	k8sApp.Flag("k8s-retry", "Retry policy of a class of k8s requests, read, create, update or delete, as operation:key=value,... with the attempts, base-delay, max-delay and jitter keys, e.g. update:attempts=5. Unset keys keep the defaults. Can be repeated.").
+		PlaceHolder("read:attempts=5").
+		SetValue(s.k8sRetries)
+	addStepFlags(k8sApp, s)
+	k8sApp.Flag("warmup", "Time after the start during which the load is applied normally but scaler_warmup is 1, so dashboards can exclude it. 0 disables it.").
+		Default("0").
+		DurationVar(&s.warmup)
+	k8sApp.Flag("global-max-replicas", "Cap the sum of the replicas of the deployments of a per-deployment plan, their targets are reduced by the same ratio when the sum would exceed it. 0 disables it.").
+		Default("0").
+		Int32Var(&s.globalMaxReplicas)
+	k8sApp.Flag("events", "Create a Kubernetes Event for the scaled objects on every replica change and failed apply, so kubectl get events shows what the scaler did.").
+		BoolVar(&s.emitEvents)
+	k8sApp.Flag("event-burst", "Maximum number of scaling events created at once with --events, the events beyond the rate limit are dropped and counted in the next one.").
+		Default("5").
+		IntVar(&s.eventBurst)
+	k8sApp.Flag("event-interval", "Average time between the scaling events with --events once the burst is used up.").
+		Default("1m").
+		DurationVar(&s.eventInterval)
+	k8sApp.Flag("max-consecutive-errors", "Exit with code 4 after this many consecutive failed applies, or on the first apply that is forbidden. 0 never exits.").
+		Default("0").
+		IntVar(&s.maxConsecutiveErrors)
+	k8sApp.Flag("detect-drift", "Read the replicas before every apply and log when they were changed outside of the scaler, e.g. by an HPA.").
+		BoolVar(&s.detectDrift)
+	k8sApp.Flag("fail-on-drift", "Exit with code 6 when the replicas were changed outside of the scaler. Implies --detect-drift.").
+		BoolVar(&s.failOnDrift)
+	k8sApp.Flag("convergence", "Compare the ready pods of the scaled objects with the applied replicas at the end of every cycle and export the difference and whether they converged within --convergence-tolerance.").
+		BoolVar(&s.convergence)
+	k8sApp.Flag("convergence-tolerance", "Number of replicas the ready pods may differ from the applied replicas and still count as converged.").
+		Default("0").
+		Int32Var(&s.convergenceTolerance)
+	k8sApp.Flag("wait-for-ready-between-steps", "Hold every step until the ready pods of the scaled objects match the applied replicas within --convergence-tolerance before its interval starts, so the pattern doesn't outrun the cluster scheduling the pods.").
+		BoolVar(&s.waitReady)
+	k8sApp.Flag("ready-timeout", "How long --wait-for-ready-between-steps waits for the ready pods of a step before the scaling continues anyway, e.g. when a rollout is stuck.").
+		Default("5m").
+		DurationVar(&s.readyTimeout)
+	k8sApp.Flag("health-gate-url", "Prometheus under test whose /-/healthy endpoint is checked before every cycle, the replicas are held while it is unhealthy and the scaling resumes once it recovers.").
+		PlaceHolder("http://prometheus:9090").
+		StringVar(&s.healthGateURL)
+	k8sApp.Flag("health-gate-query", "PromQL query run on --health-gate-url instead of checking /-/healthy, healthy when it returns samples and none is 0, e.g. up{job=\"prometheus\"} on a meta-monitoring Prometheus.").
+		StringVar(&s.healthGateQuery)
+	k8sApp.Flag("health-gate-interval", "How often the health gate is checked again while the scaling is paused.").
+		Default("30s").
+		DurationVar(&s.healthGateInterval)
+	k8sApp.Flag("health-gate-timeout", "Timeout of a single health gate check, a check that times out counts as unhealthy.").
+		Default("5s").
+		DurationVar(&s.healthGateTimeout)
+	k8sApp.Flag("pushgateway-url", "When set the target and applied replicas are pushed to this Prometheus Pushgateway on every change.").
+		PlaceHolder("http://pushgateway:9091").
+		StringVar(&s.pushgatewayURL)
+	k8sApp.Flag("pushgateway-job", "The job label used when pushing to the Pushgateway.").
+		Default("scaler").
+		StringVar(&s.pushgatewayJob)
+	k8sApp.Flag("pushgateway-timeout", "Timeout of a single push to the Pushgateway, a push that times out is only logged.").
+		Default("5s").
+		DurationVar(&s.pushgatewayTimeout)
+	k8sApp.Flag("metric-instance", "The instance label used when pushing to the Pushgateway. Defaults to the hostname.").
+		StringVar(&s.metricInstance)
+	k8sApp.Flag("metric-label", "Constant label added to all scaler metrics, e.g. scaler=loadgen-a. Can be repeated.").
+		StringMapVar(&s.metricLabels)
+	k8sApp.Flag("exemplars", "Generate a trace ID for every scaling event and add it as an exemplar to the applies and killed pods counters. The exemplars are only served on /metrics in the OpenMetrics format.").
+		BoolVar(&s.exemplars)
+	k8sApp.Flag("trace", "Log the phase, the elapsed time, the computed value before rounding, the target and the applied replicas of every step as trace: lines, e.g. to check the math of a pattern.").
+		BoolVar(&s.traceSteps)
+	k8sApp.Flag("output-sink", "Destination of the scale events, every apply and pod deletion of the chaos pattern as a JSON line: stdout or file:PATH. Can be repeated to write to several.").
+		StringsVar(&s.outputSinks)
+	k8sApp.Flag("output-file-mode", "How the file:PATH output sinks treat the file of a previous run: truncate, append or rotate, which renames it with its modification time as suffix.").
+		Default("truncate").
+		EnumVar(&s.outputFileMode, outputFileModes...)
+	addPatternFlags(k8sApp, s)
+	k8sApp.Flag("listen-address", "Address to serve the /healthz, /readyz and /metrics endpoints on. Empty disables the endpoints.").
+		Default(":8080").
+		StringVar(&s.listenAddress)
+	k8sApp.Flag("pre-cycle-hook", "Command or http(s) url run at the start of every cycle, before the replicas are applied. Commands get the cycle as SCALER_* env vars, urls as a json POST body.").
+		StringVar(&s.preCycleHook)
+	k8sApp.Flag("post-cycle-hook", "Command or http(s) url run at the end of every cycle, after the interval has passed.").
+		StringVar(&s.postCycleHook)
+	k8sApp.Flag("hook-timeout", "Timeout of a single cycle hook run.").
+		Default("30s").
+		DurationVar(&s.hookTimeout)
+	k8sApp.Flag("strict-hooks", "Exit with code 5 when a cycle hook fails. By default failures are only logged.").
+		BoolVar(&s.strictHooks)
+	k8sApp.Flag("plan", "yaml file that describes a sequence of scaling phases. When set the cli args are ignored.").
+		ExistingFileVar(&s.planFile)
+	k8sApp.Flag("profile", "Alias of --plan.").
+		ExistingFileVar(&s.profileFile)
+	k8sApp.Flag("config-configmap", "ConfigMap as [namespace/]name with the min, max and interval keys. They take precedence over the cli args and are reloaded when the ConfigMap changes.").
+		StringVar(&s.configMap)
+	k8sApp.Flag("confirm-destructive", "Allow the phases that delete pods or can scale to zero replicas, the chaos pattern or a min of 0. Without it they refuse to start.").
+		BoolVar(&s.confirmDestructive)
+	k8sApp.Flag("simulate", "Run the plan on a virtual clock for at most this long, or until it completes, without a cluster, then print the applies as JSON to stdout and exit, e.g. to assert the schedule of a pattern in CI.").
+		PlaceHolder("DURATION").
+		DurationVar(&s.simulate)
+	k8sApp.Flag("simulate-start", "Start of the virtual clock of --simulate as RFC3339, e.g. 2026-10-14T00:00:00Z. Defaults to the last midnight in local time.").
+		StringVar(&s.simulateStart)
+	k8sApp.Flag("simulate-seed", "Seed of the random levels of the weighted pattern, the amplitude jitter of the burst pattern, the random-walk pattern and the jitter of the phases with --simulate, so every run picks the same replicas.").
+		Default("1").
+		Int64Var(&s.simulateSeed)
+	addPatternArgs(k8sApp, s)
+
+	scheduleApp := app.Command("schedule", "Print the applies of the cli args or of a plan over a duration without a cluster, to review the schedule of a run before starting it. \nex: ./scaler schedule --duration 24h 20 1 15m step 5\nex: ./scaler schedule --duration 24h --plan plan.yaml --format json --output schedule.json").
+		Action(action(s.schedule))
+	scheduleApp.Flag("duration", "Time covered by the schedule, it ends earlier when the plan completes.").
 		Required().
-		DurationVar(&s.interval)
+		DurationVar(&s.simulate)
+	scheduleApp.Flag("start", "Start of the schedule as RFC3339, e.g. 2026-10-14T00:00:00Z. Defaults to the last midnight in local time.").
+		StringVar(&s.simulateStart)
+	scheduleApp.Flag("seed", "Seed of the random levels of the weighted pattern, the amplitude jitter of the burst pattern and the random-walk pattern, so every schedule picks the same replicas.").
+		Default("1").
+		Int64Var(&s.simulateSeed)
+	scheduleApp.Flag("format", "Format of the schedule, a text table or a JSON array like the one of scale --simulate.").
+		Default("text").
+		EnumVar(&s.scheduleFormat, "text", "json")
+	scheduleApp.Flag("output", "File the schedule is written to instead of stdout.").
+		Short('o').
+		StringVar(&s.scheduleOutput)
+	scheduleApp.Flag("plan", "yaml file that describes a sequence of scaling phases. When set the cli args are ignored.").
+		ExistingFileVar(&s.planFile)
+	scheduleApp.Flag("profile", "Alias of --plan.").
+		ExistingFileVar(&s.profileFile)
+	addStepFlags(scheduleApp, s)
+	addPatternFlags(scheduleApp, s)
+	addPatternArgs(scheduleApp, s)
 
 	if _, err := app.Parse(os.Args[1:]); err != nil {
-		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error parsing commandline arguments"))
-		app.Usage(os.Args[1:])
-		os.Exit(2)
+		code := exitCode(err)
+		switch code {
+		case exitApplyFailures:
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error scaling deployment"))
+			fmt.Fprintln(os.Stderr, "Error summary:", s.errStats.summary(time.Now()))
+		case exitHookFailure:
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error running the cycle hooks"))
+		case exitReplicaDrift:
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error scaling deployment"))
+		case exitK8sConnection:
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error connecting to the k8s cluster"))
+		case exitFailure:
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error scaling deployment"))
+		default:
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error parsing commandline arguments"))
+			// app.Usage parses the args again and exits 1 on the same parse errors,
+			// the usage of the command parsed so far is printed instead.
+			if ctx, _ := app.ParseContext(os.Args[1:]); ctx != nil {
+				if err := app.UsageForContext(ctx); err != nil {
+					fmt.Fprintln(os.Stderr, errors.Wrapf(err, "Error printing the usage"))
+				}
+			}
+		}
+		os.Exit(code)
 	}
 }
+
+// addStepFlags adds the flags that shape how the targets of the patterns are applied over time.
+func addStepFlags(cmd *kingpin.CmdClause, s *scale) {
+	cmd.Flag("downscale-step", "Maximum number of replicas to remove per interval when scaling down. 0 removes them all at once.").
+		Default("0").
+		Int32Var(&s.downscaleStep)
+	cmd.Flag("transition-steps", "Number of applies used to reach each new target, evenly spaced within the interval. 1 applies the target at once.").
+		Default("1").
+		IntVar(&s.transitionSteps)
+	cmd.Flag("interval-start", "Interval at the start of the interval ramp, instead of the interval arg. Requires --interval-end.").
+		DurationVar(&s.intervalStart)
+	cmd.Flag("interval-end", "Ramp the interval linearly to this interval over --interval-ramp, shorter than the start accelerates the scaling, longer slows it down. 0 keeps the interval.").
+		DurationVar(&s.intervalEnd)
+	cmd.Flag("interval-ramp", "Time over which the interval moves from --interval-start to --interval-end, it stays at the end after that.").
+		DurationVar(&s.intervalRamp)
+	cmd.Flag("min-dwell", "Minimum time each replica level is held before the pattern moves to the next level, independent of the interval. 0 disables it.").
+		Default("0").
+		DurationVar(&s.minDwell)
+	cmd.Flag("active-window", "Only change the replicas within this window and hold them outside of it, as HH:MM-HH:MM local clock times, e.g. 22:00-02:00, or START-END durations since the start, e.g. 30m-1h30m. Can be repeated.").
+		StringsVar(&s.activeWindowSpecs)
+	cmd.Flag("per-replica-rps", "Requests/s served by a single replica. When set min, max and the other replicas of the pattern are request rates, converted to the replicas that serve them. 0 disables it.").
+		Default("0").
+		Float64Var(&s.perReplicaRPS)
+	cmd.Flag("min-replicas", "Lowest number of replicas applied for the request rates of --per-replica-rps.").
+		Default("0").
+		Int32Var(&s.minReplicas)
+	cmd.Flag("max-replicas", "Highest number of replicas applied for the request rates of --per-replica-rps. 0 has no upper bound.").
+		Default("0").
+		Int32Var(&s.maxReplicas)
+}
+
+// addPatternFlags adds the parameters of the scaling patterns.
+func addPatternFlags(cmd *kingpin.CmdClause, s *scale) {
+	cmd.Flag("period", "Period of the sine pattern.").
+		Default("1h").
+		DurationVar(&s.period)
+	cmd.Flag("phase", "Phase offset of the sine pattern, as a duration like 15m or in radians like 1.57. Use a different offset per scaler to desync their peaks.").
+		Default("0").
+		StringVar(&s.phaseOffset)
+	cmd.Flag("kill-rate", "Number of random pods deleted per interval by the chaos pattern.").
+		Default("1").
+		IntVar(&s.killRate)
+	cmd.Flag("max-unavailable", "Maximum number of pods of the chaos pattern that are not ready at the same time. No pods are deleted while it is reached.").
+		Default("1").
+		IntVar(&s.maxUnavailable)
+	cmd.Flag("levels", "Replica levels of the weighted pattern with their weights in the replicas:weight format, e.g. 1:80,10:15,50:5 is mostly idle with occasional spikes.").
+		StringVar(&s.levels)
+	cmd.Flag("stable-deployment", "Name of the stable deployment from --file of the canary pattern.").
+		StringVar(&s.stableDeployment)
+	cmd.Flag("canary-deployment", "Name of the canary deployment from --file of the canary pattern.").
+		StringVar(&s.canaryDeployment)
+	cmd.Flag("canary-weights", "Percentages of max that run as canary, one per interval, e.g. 0,10,25,50,100. The last one is kept once the schedule is done.").
+		StringVar(&s.canaryWeights)
+	cmd.Flag("daily-factors", "Multipliers of the daily pattern, 24 comma separated values for the hours of the day in local time starting at midnight, e.g. 0.2,0.1,...,1,0.8.").
+		StringVar(&s.dailyFactors)
+	cmd.Flag("daily-base", "Replicas multiplied by the factor of the current hour in the daily pattern. 0 uses max.").
+		Default("0").
+		Int32Var(&s.dailyBase)
+	cmd.Flag("prometheus-url", "Prometheus queried for the series of the replay pattern and the metric of the hpa pattern.").
+		PlaceHolder("http://prometheus:9090").
+		StringVar(&s.prometheusURL)
+	cmd.Flag("query", "PromQL query of the replay or the hpa pattern, it must return a single series, e.g. sum(kube_deployment_status_replicas{deployment=\"loadgen\"}).").
+		StringVar(&s.query)
+	cmd.Flag("from", "Start of the range replayed by the replay pattern, as RFC3339 like 2026-10-01T12:00:00Z or a duration before now like 24h.").
+		StringVar(&s.from)
+	cmd.Flag("to", "End of the range replayed by the replay pattern, in the --from format. Defaults to now.").
+		StringVar(&s.to)
+	cmd.Flag("speed", "Intervals of the history replayed per interval by the replay pattern, e.g. 60 replays an hour in a minute with a 1m interval.").
+		Default("1").
+		Float64Var(&s.speed)
+	cmd.Flag("replay-scale", "Multiplier of the replayed values, e.g. 0.01 for one replica per 100 requests/s. 0 maps the lowest value of the series to min and the highest to max.").
+		Default("0").
+		Float64Var(&s.replayScale)
+	cmd.Flag("target-value", "Target of the --query metric of the hpa pattern, e.g. 0.5 for half a CPU core per pod. The replicas are scaled by the ratio of the metric to the target.").
+		Default("0").
+		Float64Var(&s.targetValue)
+	cmd.Flag("tolerance", "Ratio of the metric to the target within which the hpa pattern keeps the replicas, the HPA default is 0.1.").
+		Default("0.1").
+		Float64Var(&s.tolerance)
+	cmd.Flag("stabilization-window", "Time the hpa pattern keeps the highest replicas recommended within before scaling down, the HPA default is 5m.").
+		Default("5m").
+		DurationVar(&s.stabilizationWindow)
+	cmd.Flag("amplitude-jitter", "Percentage of max by which the burst pattern randomly lowers the peak of each cycle, never below min. 0 bursts to max every time.").
+		Default("0").
+		Int32Var(&s.amplitudeJitter)
+	cmd.Flag("baseline", "Replicas held between the bursts of the soak-burst pattern. 0 uses min.").
+		Default("0").
+		Int32Var(&s.baseline)
+	cmd.Flag("burst-to", "Replicas of the bursts of the soak-burst pattern. 0 uses max.").
+		Default("0").
+		Int32Var(&s.burstTo)
+	cmd.Flag("burst-every", "Time from the start of one burst of the soak-burst pattern to the next, a multiple of the interval. The first burst starts after a soak of burst-every minus burst-duration.").
+		DurationVar(&s.burstEvery)
+	cmd.Flag("burst-duration", "How long each burst of the soak-burst pattern lasts, a multiple of the interval.").
+		DurationVar(&s.burstDuration)
+}
+
+// addPatternArgs adds the args of the single pattern run when no plan is given.
+func addPatternArgs(cmd *kingpin.CmdClause, s *scale) {
+	cmd.Arg("max", "Number of Replicas to scale up.").
+		Int32Var(&s.max)
+	cmd.Arg("min", "Number of Replicas to scale down.").
+		Int32Var(&s.min)
+	cmd.Arg("interval", "Time to wait before changing the number of replicas.").
+		DurationVar(&s.interval)
+	cmd.Arg("patternName", "Scaling pattern - burst: switch between max and min, step: ramp up from min to max and back down to min by scalingFactor, sawtooth: repeat the step ramp, ramp-down: ramp down from max to min by scalingFactor, random-walk: move by up to scalingFactor replicas at random, hold: keep max replicas, sine: follow a sine wave between min and max, chaos: keep max replicas and delete random pods, weighted: pick one of the --levels at random, daily: multiply --daily-base by the --daily-factors of the current hour, canary: move max replicas from the --stable-deployment to the --canary-deployment by the --canary-weights, replay: follow the --query series of the --prometheus-url from --from to --to at --speed, soak-burst: hold --baseline and burst to --burst-to for --burst-duration every --burst-every, hpa: scale by the ratio of the --query metric to the --target-value like the HorizontalPodAutoscaler.").
+		Default("burst").
+		EnumVar(&s.patternName, patternNames...)
+	cmd.Arg("scalingFactor", "Number of replicas added or removed at each step of the step, sawtooth and ramp-down pattern, the largest move of the random-walk pattern.").
+		Default("1").
+		Int32Var(&s.scalingFactor)
+}
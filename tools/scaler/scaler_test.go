@@ -0,0 +1,278 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+	appsV1 "k8s.io/api/apps/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+
+	"github.com/prometheus/test-infra/pkg/provider/k8s"
+)
+
+// newFakeScaler returns a scaler of the loadgen deployment with the given replicas,
+// applied to a fake clientset that already has it.
+func newFakeScaler(replicas int32) (*scale, *fake.Clientset) {
+	deployment := &appsV1.Deployment{
+		TypeMeta:   apiMetaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen", Namespace: "prombench"},
+		Spec:       appsV1.DeploymentSpec{Replicas: &replicas},
+	}
+	clt := fake.NewSimpleClientset(deployment.DeepCopy())
+	k := k8s.NewForClientset(context.Background(), clt, []k8s.Resource{{FileName: "loadgen.yaml", Objects: []runtime.Object{deployment}}})
+	k.NoWait = true
+	s := newScaler()
+	s.k8sClient = k
+	s.deployment = "loadgen"
+	s.out = io.Discard
+	s.current = replicas
+	return s, clt
+}
+
+// liveReplicas returns the replicas of the loadgen deployment in the fake clientset.
+func liveReplicas(t *testing.T, clt *fake.Clientset) int32 {
+	t.Helper()
+	d, err := clt.AppsV1().Deployments("prombench").Get(context.Background(), "loadgen", apiMetaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *d.Spec.Replicas
+}
+
+func TestExitCode(t *testing.T) {
+	failWith := func(err error) func() error {
+		app := kingpin.New("scaler", "")
+		app.Command("scale", "").Action(action(func(*kingpin.ParseContext) error { return err }))
+		return func() error {
+			_, err := app.Parse([]string{"scale"})
+			return err
+		}
+	}
+	testCases := []struct {
+		name string
+		err  func() error
+		code int
+	}{
+		{
+			name: "parse error",
+			err: func() error {
+				app := kingpin.New("scaler", "")
+				app.Command("scale", "")
+				_, err := app.Parse([]string{"scale", "--unknown"})
+				return err
+			},
+			code: exitUsage,
+		},
+		{name: "usage error", err: failWith(usageError{errors.New("invalid downscale-step -1, must be >= 0")}), code: exitUsage},
+		{name: "runtime error", err: failWith(errors.New("listening on :8080: address already in use")), code: exitFailure},
+		{name: "apply failures", err: failWith(errors.Wrap(errApplyFailures, "10 consecutive failures")), code: exitApplyFailures},
+		{name: "hook failure", err: failWith(errors.Wrap(errHookFailure, "pre-cycle hook")), code: exitHookFailure},
+		{name: "replica drift", err: failWith(errors.Wrap(errReplicaDrift, "loadgen")), code: exitReplicaDrift},
+		{name: "k8s connection", err: failWith(errors.Wrap(errK8sConnection, "no in-cluster config")), code: exitK8sConnection},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exitCode(err); code != tc.code {
+				t.Errorf("%v: want exit code %d, got %d", err, tc.code, code)
+			}
+		})
+	}
+}
+
+func TestNextReplicas(t *testing.T) {
+	testCases := []struct {
+		current, target, step int32
+		next                  int32
+	}{
+		{current: 10, target: 1, step: 0, next: 1},
+		{current: 10, target: 1, step: 3, next: 7},
+		{current: 4, target: 1, step: 3, next: 1},
+		{current: 3, target: 1, step: 3, next: 1},
+		// Scaling up is never limited by the step.
+		{current: 1, target: 10, step: 3, next: 10},
+		{current: 5, target: 5, step: 3, next: 5},
+	}
+	for _, tc := range testCases {
+		if next := nextReplicas(tc.current, tc.target, tc.step); next != tc.next {
+			t.Errorf("%d -> %d with a step of %d: want %d, got %d", tc.current, tc.target, tc.step, tc.next, next)
+		}
+	}
+}
+
+func TestApplyKeepsCurrentOnFailure(t *testing.T) {
+	s, clt := newFakeScaler(10)
+	failing := true
+	clt.PrependReactor("update", "deployments", func(k8sTesting.Action) (bool, runtime.Object, error) {
+		if failing {
+			return true, nil, errors.New("the api server is unavailable")
+		}
+		return false, nil, nil
+	})
+
+	if err := s.apply(nextReplicas(s.current, 1, 3), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.current != 10 || liveReplicas(t, clt) != 10 {
+		t.Fatalf("want the current replicas kept at 10 after a failed apply, got %d with %d live", s.current, liveReplicas(t, clt))
+	}
+
+	// The retry takes the same step from the replicas the cluster actually has.
+	failing = false
+	if err := s.apply(nextReplicas(s.current, 1, 3), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.current != 7 || liveReplicas(t, clt) != 7 {
+		t.Errorf("want 7 replicas after the step, got %d with %d live", s.current, liveReplicas(t, clt))
+	}
+}
+
+func TestTransitionReplicas(t *testing.T) {
+	testCases := []struct {
+		current, target int32
+		steps           int
+		replicas        []int32
+	}{
+		{current: 1, target: 20, steps: 1, replicas: []int32{20}},
+		{current: 1, target: 20, steps: 4, replicas: []int32{5, 10, 15, 20}},
+		{current: 20, target: 1, steps: 4, replicas: []int32{16, 11, 6, 1}},
+		{current: 0, target: 2, steps: 4, replicas: []int32{0, 1, 1, 2}},
+		{current: 5, target: 5, steps: 2, replicas: []int32{5, 5}},
+	}
+	for _, tc := range testCases {
+		replicas := transitionReplicas(tc.current, tc.target, tc.steps)
+		if !reflect.DeepEqual(tc.replicas, replicas) {
+			t.Errorf("%d -> %d in %d steps: want %v, got %v", tc.current, tc.target, tc.steps, tc.replicas, replicas)
+		}
+	}
+}
+
+func TestErrorStats(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var e errorStats
+	e.record(errors.New("old"), start)
+	e.reset()
+
+	e.record(errors.New("timeout"), start)
+	e.record(errors.New("forbidden"), start.Add(time.Minute))
+	e.record(errors.New("timeout"), start.Add(2*time.Minute))
+	if e.consecutive != 3 || e.total != 4 {
+		t.Fatalf("want 3 consecutive and 4 total failures, got %d and %d", e.consecutive, e.total)
+	}
+
+	want := "3 consecutive failures over 2m30s, 4 failures in total\n  2x timeout\n  1x forbidden"
+	if got := e.summary(start.Add(150 * time.Second)); got != want {
+		t.Errorf("want summary:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	s := newScaler()
+	s.maxConsecutiveErrors = 3
+	for i := 0; i < 2; i++ {
+		if err := s.recordError(errors.New("timeout")); err != nil {
+			t.Fatalf("unexpected error after %d failures: %v", i+1, err)
+		}
+	}
+	if err := s.recordError(errors.New("timeout")); !errors.Is(err, errApplyFailures) {
+		t.Errorf("want the apply failures error after 3 failures, got %v", err)
+	}
+
+	forbidden := errors.Wrap(apiErrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "loadgen", errors.New("no rbac")), "Error scaling deployment")
+	s.errStats.reset()
+	if err := s.recordError(forbidden); !errors.Is(err, errApplyFailures) {
+		t.Errorf("want the apply failures error on the first forbidden apply, got %v", err)
+	}
+	s.errStats.reset()
+	s.maxConsecutiveErrors = 0
+	if err := s.recordError(forbidden); err != nil {
+		t.Errorf("want no error without max consecutive errors, got %v", err)
+	}
+}
+
+func TestDwellRemaining(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &scale{}
+	if wait := s.dwellRemaining(10*time.Minute, 5, start); wait != 0 {
+		t.Errorf("want no wait before the first apply, got %s", wait)
+	}
+
+	s.level, s.levelSince = 5, start
+	testCases := []struct {
+		minDwell time.Duration
+		target   int32
+		now      time.Time
+		wait     time.Duration
+	}{
+		{minDwell: 10 * time.Minute, target: 10, now: start.Add(4 * time.Minute), wait: 6 * time.Minute},
+		{minDwell: 10 * time.Minute, target: 10, now: start.Add(10 * time.Minute), wait: 0},
+		{minDwell: 10 * time.Minute, target: 10, now: start.Add(15 * time.Minute), wait: 0},
+		// Staying at the same level never waits.
+		{minDwell: 10 * time.Minute, target: 5, now: start.Add(time.Minute), wait: 0},
+		{minDwell: 0, target: 10, now: start.Add(time.Minute), wait: 0},
+	}
+	for _, tc := range testCases {
+		if wait := s.dwellRemaining(tc.minDwell, tc.target, tc.now); wait != tc.wait {
+			t.Errorf("min dwell %s, %d -> %d after %s: want a wait of %s, got %s", tc.minDwell, s.level, tc.target, tc.now.Sub(start), tc.wait, wait)
+		}
+	}
+}
+
+func TestScalableObject(t *testing.T) {
+	selector := &apiMetaV1.LabelSelector{MatchLabels: map[string]string{"app": "loadgen"}}
+	for _, tc := range []struct {
+		obj      runtime.Object
+		want     scalable
+		scalable bool
+	}{
+		{
+			obj:      &appsV1.Deployment{ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen"}, Spec: appsV1.DeploymentSpec{Selector: selector}},
+			want:     scalable{name: "loadgen", namespace: "default", resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, selector: selector},
+			scalable: true,
+		},
+		{
+			obj:      &appsV1.StatefulSet{ObjectMeta: apiMetaV1.ObjectMeta{Name: "prometheus", Namespace: "prombench"}, Spec: appsV1.StatefulSetSpec{Selector: selector}},
+			want:     scalable{name: "prometheus", namespace: "prombench", resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, selector: selector},
+			scalable: true,
+		},
+		{
+			obj:      &appsV1.ReplicaSet{ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen"}, Spec: appsV1.ReplicaSetSpec{Selector: selector}},
+			want:     scalable{name: "loadgen", namespace: "default", resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}, selector: selector},
+			scalable: true,
+		},
+		{obj: &appsV1.DaemonSet{ObjectMeta: apiMetaV1.ObjectMeta{Name: "node-exporter"}}},
+		{obj: &apiCoreV1.Service{ObjectMeta: apiMetaV1.ObjectMeta{Name: "loadgen"}}},
+	} {
+		o, ok := scalableObject(tc.obj)
+		if ok != tc.scalable || !reflect.DeepEqual(tc.want, o) {
+			t.Errorf("%T: want %+v scalable %v, got %+v %v", tc.obj, tc.want, tc.scalable, o, ok)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// schedule prints the applies of the cli args or of the plan over the schedule duration without touching the cluster.
+// It runs the same pattern computation as scale on the virtual clock of --simulate.
+// The output file is only written once the schedule succeeded, so invalid args leave an existing file untouched.
+func (s *scale) schedule(ctx *kingpin.ParseContext) error {
+	if s.simulate <= 0 {
+		return usageError{errors.Errorf("invalid duration %s, must be > 0", s.simulate)}
+	}
+	if s.scheduleOutput == "" {
+		return s.scale(ctx)
+	}
+	var out bytes.Buffer
+	s.out = &out
+	if err := s.scale(ctx); err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(s.scheduleOutput, out.Bytes(), 0o666), "writing the schedule output file")
+}
+
+// writeText writes the recorded applies as a table ordered by time, with the time of every apply,
+// the offset since the start and the deployment and canary split columns only when they are set.
+func (sim *simulation) writeText(w io.Writer) error {
+	applies := sim.sorted()
+	var deployments, splits bool
+	for _, a := range applies {
+		deployments = deployments || a.Deployment != ""
+		splits = splits || a.Split != nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"TIME", "OFFSET"}
+	if deployments {
+		header = append(header, "DEPLOYMENT")
+	}
+	header = append(header, "TARGET", "APPLIED")
+	if splits {
+		header = append(header, "SPLIT")
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, a := range applies {
+		offset := time.Duration(a.T * float64(time.Second))
+		row := []string{sim.start.Add(offset).Format(time.RFC3339), offset.String()}
+		if deployments {
+			row = append(row, a.Deployment)
+		}
+		row = append(row, fmt.Sprint(a.Target), fmt.Sprint(a.Applied))
+		if splits {
+			row = append(row, formatSplit(a.Split))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return errors.Wrap(tw.Flush(), "writing the schedule")
+}
+
+// formatSplit formats the replicas of a canary split as name=replicas ordered by name.
+func formatSplit(split map[string]int32) string {
+	names := make([]string, 0, len(split))
+	for name := range split {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%v=%d", name, split[name]))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	dir := t.TempDir()
+	f := filepath.Join(dir, "plan.yaml")
+	if err := os.WriteFile(f, []byte(simulatedPlan), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newScaler()
+	s.planFile = f
+	s.transitionSteps = 1
+	s.simulate = 30 * time.Minute
+	s.simulateStart = "2026-10-14T00:00:00Z"
+	s.scheduleFormat = "text"
+	s.scheduleOutput = filepath.Join(dir, "schedule.txt")
+	if err := s.schedule(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := os.ReadFile(s.scheduleOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `TIME                  OFFSET  TARGET  APPLIED
+2026-10-14T00:00:00Z  0s      3       3
+2026-10-14T00:10:00Z  10m0s   3       3
+2026-10-14T00:20:00Z  20m0s   5       5
+`
+	if string(out) != want {
+		t.Errorf("want the schedule\n%s\ngot\n%s", want, out)
+	}
+
+	s = newScaler()
+	if err := s.schedule(nil); err == nil {
+		t.Error("expected an error without a duration")
+	}
+
+	s = newScaler()
+	s.planFile = f
+	s.simulate = 30 * time.Minute
+	s.scheduleOutput = filepath.Join(dir, "schedule.txt")
+	if err := s.schedule(nil); exitCode(err) != exitUsage {
+		t.Errorf("want a usage error without transition steps, got %v", err)
+	}
+	if kept, err := os.ReadFile(s.scheduleOutput); err != nil || string(kept) != want {
+		t.Errorf("want the output file untouched by a failed schedule, got %q: %v", kept, err)
+	}
+}
+
+func TestScheduleText(t *testing.T) {
+	sim := &simulation{start: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)}
+	sim.record(sim.start.Add(time.Minute), "stable", 10, 10, map[string]int32{"stable": 9, "canary": 1})
+	sim.record(sim.start, "stable", 10, 10, map[string]int32{"stable": 10, "canary": 0})
+
+	var out strings.Builder
+	if err := sim.writeText(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := `TIME                  OFFSET  DEPLOYMENT  TARGET  APPLIED  SPLIT
+2026-10-14T00:00:00Z  0s      stable      10      10       canary=0,stable=10
+2026-10-14T00:01:00Z  1m0s    stable      10      10       canary=1,stable=9
+`
+	if out.String() != want {
+		t.Errorf("want the schedule\n%s\ngot\n%s", want, out.String())
+	}
+}
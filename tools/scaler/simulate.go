@@ -0,0 +1,197 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// simulatedApply is an apply recorded by --simulate, at T seconds after the start of the simulation.
+type simulatedApply struct {
+	T          float64          `json:"t"`
+	Deployment string           `json:"deployment,omitempty"`
+	Target     int32            `json:"target"`
+	Applied    int32            `json:"applied"`
+	Split      map[string]int32 `json:"split,omitempty"`
+}
+
+// simulation records the applies of a --simulate run, which ends at until unless the plan completes first.
+type simulation struct {
+	mtx          sync.Mutex
+	start, until time.Time
+	applies      []simulatedApply
+}
+
+func (sim *simulation) record(now time.Time, deployment string, target, applied int32, split map[string]int32) {
+	sim.mtx.Lock()
+	defer sim.mtx.Unlock()
+	sim.applies = append(sim.applies, simulatedApply{
+		T:          now.Sub(sim.start).Seconds(),
+		Deployment: deployment,
+		Target:     target,
+		Applied:    applied,
+		Split:      split,
+	})
+}
+
+// sorted returns the recorded applies ordered by time,
+// the applies of concurrent deployments at the same time are ordered by deployment.
+func (sim *simulation) sorted() []simulatedApply {
+	sim.mtx.Lock()
+	defer sim.mtx.Unlock()
+	sort.SliceStable(sim.applies, func(i, j int) bool {
+		if sim.applies[i].T != sim.applies[j].T {
+			return sim.applies[i].T < sim.applies[j].T
+		}
+		return sim.applies[i].Deployment < sim.applies[j].Deployment
+	})
+	return sim.applies
+}
+
+// write writes the recorded applies as a JSON array ordered by time.
+func (sim *simulation) write(w io.Writer) error {
+	applies := sim.sorted()
+	if applies == nil {
+		applies = []simulatedApply{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return errors.Wrap(enc.Encode(applies), "writing the simulated applies")
+}
+
+// startSimulation switches the scaler to a virtual clock for --simulate and turns off everything that needs the cluster
+// or has effects outside of the scaler: the hooks, the pushgateway, the endpoints and the warmup.
+// The options that read the cluster during the run can't be simulated.
+func (s *scale) startSimulation(p *plan) error {
+	if s.simulate < 0 {
+		return errors.Errorf("invalid simulate %s, must be >= 0", s.simulate)
+	}
+	if s.configMap != "" {
+		return errors.New("--simulate can't be used with --config-configmap, which is read from the cluster")
+	}
+	if s.detectDrift {
+		return errors.New("--simulate can't be used with --detect-drift, which reads the replicas from the cluster")
+	}
+	if s.convergence {
+		return errors.New("--simulate can't be used with --convergence, which reads the pods from the cluster")
+	}
+	if s.waitReady {
+		return errors.New("--simulate can't be used with --wait-for-ready-between-steps, which reads the pods from the cluster")
+	}
+	if s.globalMaxReplicas > 0 {
+		return errors.New("--simulate can't be used with --global-max-replicas, the deployments run on separate virtual clocks and their applies don't interleave as in a real run")
+	}
+	if s.emitEvents {
+		return errors.New("--simulate can't be used with --events, which are created in the cluster")
+	}
+	if s.healthGate != nil {
+		return errors.New("--simulate can't be used with --health-gate-url, which checks the Prometheus under test")
+	}
+	plans := []*plan{p}
+	for _, d := range p.Deployments {
+		plans = append(plans, d)
+	}
+	for _, d := range plans {
+		for _, ph := range d.Phases {
+			if _, ok := ph.pattern.(chaos); ok {
+				return errors.Errorf("phase %q: the chaos pattern deletes pods of the cluster and can't be simulated", ph.Name)
+			}
+			if _, ok := ph.pattern.(*hpa); ok {
+				return errors.Errorf("phase %q: the hpa pattern queries the current metric of the cluster and can't be simulated", ph.Name)
+			}
+		}
+	}
+
+	start := time.Now()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	if s.simulateStart != "" {
+		t, err := time.Parse(time.RFC3339, s.simulateStart)
+		if err != nil {
+			return errors.Errorf("invalid simulate-start %q, must be RFC3339 like 2026-10-14T00:00:00Z", s.simulateStart)
+		}
+		start = t
+	}
+	if s.preCycleHook != "" || s.postCycleHook != "" {
+		log.Printf("WARNING: the cycle hooks aren't run by --simulate")
+	}
+	s.preCycleHook, s.postCycleHook = "", ""
+	s.pushgatewayURL, s.listenAddress, s.warmup = "", "", 0
+	s.simulation = &simulation{start: start, until: start.Add(s.simulate)}
+	s.useVirtualClock(p, start)
+	log.Printf("Simulating %s from %s on a virtual clock", s.simulate, start.Format(time.RFC3339))
+	return nil
+}
+
+// useVirtualClock sets a new virtual clock that starts at start for the scaler and the time based patterns of the plan.
+// The weighted, the burst and the random-walk pattern and the jitter get a fixed seed so every simulation picks the same replicas,
+// also when they are the pattern of a load target.
+func (s *scale) useVirtualClock(p *plan, start time.Time) {
+	c := newVirtualClock(start)
+	s.clock = c
+	s.health.now = c.Now
+	for _, ph := range p.Phases {
+		ph.pattern = s.virtualPattern(ph.pattern, c)
+	}
+}
+
+// virtualPattern returns the pattern on the virtual clock c with the seed of the simulation,
+// the pattern of a jitter or a load target is set up the same way.
+func (s *scale) virtualPattern(pat pattern, c *virtualClock) pattern {
+	switch p := pat.(type) {
+	case daily:
+		p.now = c.Now
+		return p
+	case weighted:
+		p.rand = rand.New(rand.NewSource(s.simulateSeed))
+		return p
+	case burst:
+		p.seed = s.simulateSeed
+		return p
+	case *randomWalk:
+		p.reseed(s.simulateSeed)
+	case jitter:
+		p.pattern = s.virtualPattern(p.pattern, c)
+		p.seed = s.simulateSeed
+		return p
+	case loadTarget:
+		p.pattern = s.virtualPattern(p.pattern, c)
+		return p
+	}
+	return pat
+}
+
+// simulationDone returns true once the virtual clock of --simulate reached the end of the simulation.
+func (s *scale) simulationDone() bool {
+	return s.simulation != nil && !s.clock.Now().Before(s.simulation.until)
+}
+
+// simulationResult writes the applies of a successful simulation to w, as a table for schedule --format=text,
+// and returns the error of the run otherwise.
+func (s *scale) simulationResult(w io.Writer, err error) error {
+	if err != nil || s.simulation == nil {
+		return err
+	}
+	if s.scheduleFormat == "text" {
+		return s.simulation.writeText(w)
+	}
+	return s.simulation.write(w)
+}
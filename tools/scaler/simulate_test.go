@@ -0,0 +1,204 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const simulatedPlan = `
+loop: true
+phases:
+- pattern: hold
+  max: 3
+  interval: 10m
+  duration: 20m
+- pattern: burst
+  max: 5
+  min: 1
+  interval: 10m
+  duration: 20m
+`
+
+func TestSimulate(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(f, []byte(simulatedPlan), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newScaler()
+	s.planFile = f
+	s.simulate = time.Hour
+	s.simulateStart = "2026-10-14T00:00:00Z"
+	s.listenAddress = ":8080"
+	p, err := s.plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.startSimulation(p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.listenAddress != "" {
+		t.Error("want the endpoints disabled by the simulation")
+	}
+	s.started = s.clock.Now()
+
+	var out bytes.Buffer
+	start := time.Now()
+	if err := s.simulationResult(&out, s.runPlan(p)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("want the simulation to run on the virtual clock, took %s", d)
+	}
+	if want := time.Date(2026, 10, 14, 1, 0, 0, 0, time.UTC); !s.clock.Now().Equal(want) {
+		t.Errorf("want the looping plan to end at %s, got %s", want, s.clock.Now())
+	}
+
+	var applies []simulatedApply
+	if err := json.Unmarshal(out.Bytes(), &applies); err != nil {
+		t.Fatalf("invalid json %q: %v", out.String(), err)
+	}
+	want := []simulatedApply{
+		{T: 0, Target: 3, Applied: 3},
+		{T: 600, Target: 3, Applied: 3},
+		{T: 1200, Target: 5, Applied: 5},
+		{T: 1800, Target: 1, Applied: 1},
+		{T: 2400, Target: 3, Applied: 3},
+		{T: 3000, Target: 3, Applied: 3},
+	}
+	if len(applies) != len(want) {
+		t.Fatalf("want %d applies, got %s", len(want), out.String())
+	}
+	for i := range want {
+		if applies[i].T != want[i].T || applies[i].Target != want[i].Target || applies[i].Applied != want[i].Applied {
+			t.Errorf("apply %d: want %+v, got %+v", i, want[i], applies[i])
+		}
+	}
+}
+
+func TestSimulateDailyLoadTarget(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	factors := strings.TrimSuffix(strings.Repeat("0.5,", 12)+strings.Repeat("1,", 12), ",")
+	content := "phases:\n- pattern: daily\n  min: 10\n  max: 500\n  interval: 1h\n  perReplicaRPS: 10\n  dailyFactors: " + factors + "\n"
+	if err := os.WriteFile(f, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newScaler()
+	s.planFile = f
+	s.simulate = 24 * time.Hour
+	// The daily factors follow the local time.
+	s.simulateStart = time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local).Format(time.RFC3339)
+	p, err := s.plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.startSimulation(p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.started = s.clock.Now()
+
+	var out bytes.Buffer
+	if err := s.simulationResult(&out, s.runPlan(p)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var applies []simulatedApply
+	if err := json.Unmarshal(out.Bytes(), &applies); err != nil {
+		t.Fatalf("invalid json %q: %v", out.String(), err)
+	}
+	if len(applies) != 24 {
+		t.Fatalf("want an apply per virtual hour, got %s", out.String())
+	}
+	for i, a := range applies {
+		// 250 requests/s in the morning and 500 requests/s in the afternoon at 10 requests/s per replica.
+		want := int32(25)
+		if i >= 12 {
+			want = 50
+		}
+		if a.Target != want {
+			t.Errorf("hour %d: want the target %d of the virtual hour, got %d", i, want, a.Target)
+		}
+	}
+}
+
+func TestStartSimulationErrors(t *testing.T) {
+	chaosPlan := &plan{Phases: []*phase{{Name: "chaos", pattern: chaos{count: 3}}}}
+	for name, tc := range map[string]struct {
+		set func(s *scale)
+		p   *plan
+	}{
+		"negative":     {set: func(s *scale) { s.simulate = -time.Minute }},
+		"configmap":    {set: func(s *scale) { s.configMap = "scaler-config" }},
+		"drift":        {set: func(s *scale) { s.detectDrift = true }},
+		"global max":   {set: func(s *scale) { s.globalMaxReplicas = 10 }},
+		"wait ready":   {set: func(s *scale) { s.waitReady = true }},
+		"events":       {set: func(s *scale) { s.emitEvents = true }},
+		"start":        {set: func(s *scale) { s.simulateStart = "2026-10-14" }},
+		"chaos":        {p: chaosPlan},
+		"chaos worker": {p: &plan{Deployments: map[string]*plan{"loadgen": chaosPlan}}},
+		"hpa":          {p: &plan{Phases: []*phase{{Name: "hpa", pattern: &hpa{min: 1, max: 5}}}}},
+	} {
+		s := newScaler()
+		s.simulate = time.Hour
+		if tc.set != nil {
+			tc.set(s)
+		}
+		p := tc.p
+		if p == nil {
+			p = &plan{}
+		}
+		if err := s.startSimulation(p); err == nil {
+			t.Errorf("%v: expected an error", name)
+		}
+	}
+}
+
+func TestSimulateUsageErrors(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "plan.yaml")
+	if err := os.WriteFile(f, []byte(simulatedPlan), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, set := range map[string]func(s *scale){
+		"negative": func(s *scale) { s.simulate = -time.Minute },
+		"drift":    func(s *scale) { s.detectDrift = true },
+		"start":    func(s *scale) { s.simulateStart = "2026-10-14" },
+		"stdout":   func(s *scale) { s.outputSinks = []string{"stdout"} },
+	} {
+		s := newScaler()
+		s.planFile = f
+		s.transitionSteps = 1
+		s.simulate = time.Hour
+		set(s)
+		if err := s.scale(nil); exitCode(err) != exitUsage {
+			t.Errorf("%v: want a usage error, got %v", name, err)
+		}
+	}
+}
+
+func TestVirtualClock(t *testing.T) {
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	c := newVirtualClock(start)
+	c.Sleep(90 * time.Second)
+	c.Sleep(-time.Minute)
+	if got := c.Now().Sub(start); got != 90*time.Second {
+		t.Errorf("want the clock to only move forward by the sleeps, moved %s", got)
+	}
+}
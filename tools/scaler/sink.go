@@ -0,0 +1,241 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// outputFileModes are how a file sink treats the file of a previous run:
+// truncate overwrites it, append adds to it and rotate renames it with its modification time as suffix.
+var outputFileModes = []string{"truncate", "append", "rotate"}
+
+// scaleEvent is a scale action written to the output sinks as a JSON line:
+// a scale apply or the pods deleted by the chaos pattern.
+type scaleEvent struct {
+	Time       time.Time `json:"time"`
+	Action     string    `json:"action"`
+	Deployment string    `json:"deployment,omitempty"`
+	// From is the replicas of the last successful apply, nil before the first one.
+	From     *int32           `json:"from,omitempty"`
+	Replicas int32            `json:"replicas"`
+	Target   int32            `json:"target"`
+	Split    map[string]int32 `json:"split,omitempty"`
+	Pods     []string         `json:"pods,omitempty"`
+	Result   string           `json:"result"`
+	Error    string           `json:"error,omitempty"`
+	TraceID  string           `json:"trace_id,omitempty"`
+}
+
+// sink is a destination of the scale events.
+type sink interface {
+	io.Writer
+	io.Closer
+}
+
+// nopCloser is a sink that doesn't close its writer, for stdout.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// eventSinks fans the scale events out to all sinks, shared by the workers of a per-deployment plan.
+type eventSinks struct {
+	mtx   sync.Mutex
+	names []string
+	sinks []sink
+}
+
+// newEventSinks opens the sinks of the specs, stdout or file:PATH, the file in the given mode.
+// No specs returns nil, which drops the events.
+func newEventSinks(specs []string, fileMode string, stdout io.Writer) (*eventSinks, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	if !isOutputFileMode(fileMode) {
+		return nil, errors.Errorf("invalid output file mode %q, must be one of %v", fileMode, outputFileModes)
+	}
+	s := &eventSinks{}
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		if seen[spec] {
+			s.Close()
+			return nil, errors.Errorf("output sink %q set twice", spec)
+		}
+		seen[spec] = true
+		out, err := openSink(spec, fileMode, stdout)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		s.names = append(s.names, spec)
+		s.sinks = append(s.sinks, out)
+	}
+	return s, nil
+}
+
+func openSink(spec, fileMode string, stdout io.Writer) (sink, error) {
+	if spec == "stdout" {
+		return nopCloser{stdout}, nil
+	}
+	kind, path, ok := strings.Cut(spec, ":")
+	if !ok || kind != "file" || path == "" {
+		return nil, errors.Errorf("invalid output sink %q, must be stdout or file:PATH", spec)
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch fileMode {
+	case "append":
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	case "rotate":
+		if err := rotateFile(path); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening the output sink %q", spec)
+	}
+	return f, nil
+}
+
+// rotateFile renames an existing non-empty file with its modification time as suffix, e.g. events.jsonl.20261014T150405.
+func rotateFile(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "rotating the output file %v", path)
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	rotated := path + "." + info.ModTime().UTC().Format("20060102T150405")
+	if err := os.Rename(path, rotated); err != nil {
+		return errors.Wrapf(err, "rotating the output file %v", path)
+	}
+	log.Printf("Rotated the output file %v to %v", path, rotated)
+	return nil
+}
+
+func isOutputFileMode(mode string) bool {
+	for _, m := range outputFileModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// openSinks opens the output sinks of the scale command.
+// The applies of --simulate are printed to stdout, so it can't be a sink of a simulation.
+func (s *scale) openSinks() (*eventSinks, error) {
+	if s.simulation != nil {
+		for _, spec := range s.outputSinks {
+			if spec == "stdout" {
+				return nil, usageError{errors.New("--simulate prints the applies to stdout and can't be used with --output-sink=stdout")}
+			}
+		}
+	}
+	return newEventSinks(s.outputSinks, s.outputFileMode, s.out)
+}
+
+// write writes the event as a JSON line to all sinks.
+// Failures are only logged as they shouldn't interrupt the scaling.
+func (s *eventSinks) write(e scaleEvent) {
+	if s == nil {
+		return
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("Error encoding the scale event: %v", err)
+		return
+	}
+	line = append(line, '\n')
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for i, out := range s.sinks {
+		if _, err := out.Write(line); err != nil {
+			log.Printf("Error writing the scale event to the output sink %q: %v", s.names[i], err)
+		}
+	}
+}
+
+// Close closes the files of the sinks.
+func (s *eventSinks) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var firstErr error
+	for i, out := range s.sinks {
+		if err := out.Close(); err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "closing the output sink %q", s.names[i])
+		}
+	}
+	return firstErr
+}
+
+// recordScaleEvent writes an apply to the output sinks, before the applied replicas are updated.
+func (s *scale) recordScaleEvent(replicas, target int32, applyErr error) {
+	if s.sinks == nil {
+		return
+	}
+	e := scaleEvent{
+		Time:       s.clock.Now(),
+		Action:     "scale",
+		Deployment: s.deployment,
+		From:       s.applied,
+		Replicas:   replicas,
+		Target:     target,
+		Split:      s.split,
+		Result:     "success",
+		TraceID:    s.traceID,
+	}
+	if applyErr != nil {
+		e.Result, e.Error = "failure", applyErr.Error()
+	}
+	s.sinks.write(e)
+}
+
+// recordKillEvent writes the pods deleted by the chaos pattern to the output sinks.
+func (s *scale) recordKillEvent(pods []string, killErr error) {
+	if s.sinks == nil || (len(pods) == 0 && killErr == nil) {
+		return
+	}
+	e := scaleEvent{
+		Time:       s.clock.Now(),
+		Action:     "kill",
+		Deployment: s.deployment,
+		Replicas:   s.current,
+		Target:     s.current,
+		Pods:       pods,
+		Result:     "success",
+		TraceID:    s.traceID,
+	}
+	if killErr != nil {
+		e.Result, e.Error = "failure", killErr.Error()
+	}
+	s.sinks.write(e)
+}
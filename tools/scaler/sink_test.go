@@ -0,0 +1,138 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestEventSinks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	var stdout bytes.Buffer
+	sinks, err := newEventSinks([]string{"stdout", "file:" + path}, "truncate", &stdout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	s := newScaler()
+	s.clock = newVirtualClock(start)
+	s.sinks = sinks
+	s.deployment = "loadgen"
+	s.recordScaleEvent(10, 10, nil)
+	applied := int32(10)
+	s.applied, s.current = &applied, 10
+	s.recordScaleEvent(1, 1, errors.New("forbidden"))
+	s.recordKillEvent([]string{"loadgen-1"}, nil)
+	if err := sinks.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, stdout.Bytes()) {
+		t.Errorf("want the same events on stdout and in the file, got %q and %q", stdout.String(), content)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("want 3 event lines, got %d: %q", len(lines), content)
+	}
+	var events []scaleEvent
+	for _, line := range lines {
+		var e scaleEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid event line %q: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	if e := events[0]; e.Action != "scale" || e.From != nil || e.Replicas != 10 || e.Result != "success" || e.Deployment != "loadgen" || !e.Time.Equal(start) {
+		t.Errorf("unexpected first apply event: %+v", e)
+	}
+	if e := events[1]; e.From == nil || *e.From != 10 || e.Replicas != 1 || e.Result != "failure" || e.Error != "forbidden" {
+		t.Errorf("unexpected failed apply event: %+v", e)
+	}
+	if e := events[2]; e.Action != "kill" || len(e.Pods) != 1 || e.Pods[0] != "loadgen-1" {
+		t.Errorf("unexpected kill event: %+v", e)
+	}
+}
+
+func TestEventSinksFileModes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	writeRun := func(mode, line string) {
+		t.Helper()
+		sinks, err := newEventSinks([]string{"file:" + path}, mode, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", mode, err)
+		}
+		if _, err := sinks.sinks[0].Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		if err := sinks.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(p string) string {
+		t.Helper()
+		content, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	writeRun("truncate", "run-1")
+	writeRun("truncate", "run-2")
+	if got := read(path); got != "run-2\n" {
+		t.Errorf("want the file truncated, got %q", got)
+	}
+	writeRun("append", "run-3")
+	if got := read(path); got != "run-2\nrun-3\n" {
+		t.Errorf("want the run appended, got %q", got)
+	}
+	modTime := time.Date(2026, 10, 14, 15, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	writeRun("rotate", "run-4")
+	if got := read(path); got != "run-4\n" {
+		t.Errorf("want a new file after the rotation, got %q", got)
+	}
+	if got := read(path + ".20261014T150405"); got != "run-2\nrun-3\n" {
+		t.Errorf("want the previous run in the rotated file, got %q", got)
+	}
+
+	for _, invalid := range [][]string{{"stderr"}, {"file:"}, {"http://example.com"}, {"stdout", "stdout"}} {
+		if _, err := newEventSinks(invalid, "truncate", nil); err == nil {
+			t.Errorf("%v: expected an error", invalid)
+		}
+	}
+	if _, err := newEventSinks([]string{"stdout"}, "compress", nil); err == nil {
+		t.Error("expected an error for an unknown file mode")
+	}
+	if sinks, err := newEventSinks(nil, "", nil); sinks != nil || err != nil {
+		t.Errorf("want no sinks without specs, got %v, %v", sinks, err)
+	}
+}
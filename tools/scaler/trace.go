@@ -0,0 +1,65 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// rawPattern is implemented by the patterns that compute the replicas from a fractional value,
+// so --trace can log the value before it is rounded and clamped.
+type rawPattern interface {
+	raw(step int) float64
+}
+
+// newTracer returns the logger of the --trace lines, separate from the normal log lines by their prefix.
+func newTracer() *log.Logger {
+	return log.New(os.Stderr, "trace: ", log.LstdFlags|log.Lmicroseconds)
+}
+
+// traceStep logs the computed and applied replicas of a step of the phase, a no-op without --trace.
+// elapsed is the time since the start of the phase when the step started.
+func (s *scale) traceStep(ph *phase, step int, elapsed time.Duration, target int32, err error) {
+	if s.tracer == nil {
+		return
+	}
+	raw := float64(target)
+	if p, ok := ph.pattern.(rawPattern); ok {
+		raw = p.raw(step)
+	}
+	var b strings.Builder
+	if s.deployment != "" {
+		fmt.Fprintf(&b, "deployment=%q ", s.deployment)
+	}
+	fmt.Fprintf(&b, "phase=%q pattern=%s step=%d elapsed=%s raw=%.3f target=%d", ph.Name, ph.Pattern, step, elapsed.Round(time.Millisecond), raw, target)
+	if s.applied != nil {
+		fmt.Fprintf(&b, " applied=%d", *s.applied)
+	} else {
+		b.WriteString(" applied=none")
+	}
+	if s.split != nil {
+		fmt.Fprintf(&b, " split=%v", s.split)
+	}
+	if s.errStats.consecutive > 0 {
+		fmt.Fprintf(&b, " consecutive_errors=%d", s.errStats.consecutive)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, " err=%q", err)
+	}
+	s.tracer.Print(b.String())
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTraceStep(t *testing.T) {
+	ph := &phase{Name: "wave", Pattern: "sine", Min: 1, Max: 4, Interval: 15 * time.Minute, Period: time.Hour}
+	if err := ph.validate(); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	s := newScaler()
+	s.tracer = log.New(&out, "trace: ", 0)
+
+	s.traceStep(ph, 0, 1500*time.Millisecond, ph.pattern.replicas(0), nil)
+	applied := int32(3)
+	s.applied = &applied
+	s.deployment = "loadgen"
+	s.traceStep(ph, 1, 15*time.Minute, ph.pattern.replicas(1), errors.New("apply failed"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`trace: phase="wave" pattern=sine step=0 elapsed=1.5s raw=2.500 target=3 applied=none`,
+		`trace: deployment="loadgen" phase="wave" pattern=sine step=1 elapsed=15m0s raw=4.000 target=4 applied=3 err="apply failed"`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("want %d lines, got:\n%s", len(want), out.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("\nwant %s\ngot  %s", want[i], lines[i])
+		}
+	}
+
+	// Patterns without a fractional value trace the target and nothing is logged without --trace.
+	out.Reset()
+	hold := &phase{Name: "hold", Pattern: "hold", Max: 5, Interval: time.Minute}
+	if err := hold.validate(); err != nil {
+		t.Fatal(err)
+	}
+	s.traceStep(hold, 0, 0, 5, nil)
+	if !strings.Contains(out.String(), "raw=5.000 target=5") {
+		t.Errorf("want the target as the raw value, got %s", out.String())
+	}
+	out.Reset()
+	s.tracer = nil
+	s.traceStep(hold, 0, 0, 5, nil)
+	if out.Len() != 0 {
+		t.Errorf("want no trace lines without --trace, got %s", out.String())
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// activeWindow is a time window during which the scaler changes the replicas.
+// Daily windows are clock times of the local time zone, the end can be before the start for a window that crosses midnight.
+// The other windows are offsets from the start of the scaler.
+type activeWindow struct {
+	spec       string
+	daily      bool
+	start, end time.Duration
+}
+
+// parseActiveWindow parses a window as HH:MM-HH:MM clock times, e.g. 22:00-02:00,
+// or as START-END durations since the scaler start, e.g. 30m-1h30m.
+func parseActiveWindow(spec string) (activeWindow, error) {
+	startValue, endValue, ok := strings.Cut(spec, "-")
+	if !ok {
+		return activeWindow{}, errors.Errorf("invalid active window %q, expected HH:MM-HH:MM or START-END durations", spec)
+	}
+	w := activeWindow{spec: spec}
+	if strings.Contains(startValue, ":") || strings.Contains(endValue, ":") {
+		w.daily = true
+		for _, v := range []struct {
+			value string
+			d     *time.Duration
+		}{{startValue, &w.start}, {endValue, &w.end}} {
+			t, err := time.Parse("15:04", v.value)
+			if err != nil {
+				return activeWindow{}, errors.Errorf("invalid active window %q, %q isn't a HH:MM clock time", spec, v.value)
+			}
+			*v.d = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		}
+		if w.start == w.end {
+			return activeWindow{}, errors.Errorf("invalid active window %q, the start and end are the same", spec)
+		}
+		return w, nil
+	}
+	var err error
+	if w.start, err = time.ParseDuration(startValue); err != nil || w.start < 0 {
+		return activeWindow{}, errors.Errorf("invalid active window %q, the start %q must be a duration >= 0", spec, startValue)
+	}
+	if w.end, err = time.ParseDuration(endValue); err != nil || w.end <= w.start {
+		return activeWindow{}, errors.Errorf("invalid active window %q, the end %q must be a duration after the start", spec, endValue)
+	}
+	return w, nil
+}
+
+// parseActiveWindows parses the windows and checks that windows of the same kind don't overlap.
+func parseActiveWindows(specs []string) ([]activeWindow, error) {
+	type segment struct {
+		start, end time.Duration
+		w          activeWindow
+	}
+	var windows []activeWindow
+	segments := map[bool][]segment{}
+	for _, spec := range specs {
+		w, err := parseActiveWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+		if w.daily && w.end < w.start {
+			// A window that crosses midnight is the end of one day and the start of the next.
+			segments[true] = append(segments[true], segment{w.start, 24 * time.Hour, w}, segment{0, w.end, w})
+			continue
+		}
+		segments[w.daily] = append(segments[w.daily], segment{w.start, w.end, w})
+	}
+	for _, s := range segments {
+		sort.Slice(s, func(i, j int) bool { return s[i].start < s[j].start })
+		for i := 1; i < len(s); i++ {
+			if s[i].start < s[i-1].end {
+				return nil, errors.Errorf("the active windows %q and %q overlap, merge them into one", s[i-1].w.spec, s[i].w.spec)
+			}
+		}
+	}
+	return windows, nil
+}
+
+// contains returns whether now is in the window, started is the start of the scaler.
+func (w activeWindow) contains(now, started time.Time) bool {
+	if !w.daily {
+		elapsed := now.Sub(started)
+		return elapsed >= w.start && elapsed < w.end
+	}
+	t := clockTime(now)
+	if w.start < w.end {
+		return t >= w.start && t < w.end
+	}
+	return t >= w.start || t < w.end
+}
+
+// opens returns how long until the window opens next, false when it doesn't open again.
+func (w activeWindow) opens(now, started time.Time) (time.Duration, bool) {
+	if !w.daily {
+		wait := started.Add(w.start).Sub(now)
+		return wait, wait > 0
+	}
+	y, m, d := now.Date()
+	next := time.Date(y, m, d, int(w.start/time.Hour), int(w.start%time.Hour/time.Minute), 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(y, m, d+1, int(w.start/time.Hour), int(w.start%time.Hour/time.Minute), 0, 0, now.Location())
+	}
+	return next.Sub(now), true
+}
+
+// clockTime returns the time since midnight of the local time of t.
+func clockTime(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// inActiveWindow returns true without windows or when now is in one of them.
+// Otherwise it also returns how long until the next window opens, false when none opens again.
+func inActiveWindow(windows []activeWindow, now, started time.Time) (bool, time.Duration, bool) {
+	if len(windows) == 0 {
+		return true, 0, false
+	}
+	var next time.Duration
+	opens := false
+	for _, w := range windows {
+		if w.contains(now, started) {
+			return true, 0, false
+		}
+		if wait, ok := w.opens(now, started); ok && (!opens || wait < next) {
+			next, opens = wait, true
+		}
+	}
+	return false, next, opens
+}
+
+// holdOutsideActiveWindows waits while the scaler is outside of the active windows, so the replicas are held,
+// and returns true when the phase ended while waiting.
+func (s *scale) holdOutsideActiveWindows(ph *phase, start time.Time) bool {
+	logged := false
+	for {
+		if s.stopped() {
+			return true
+		}
+		active, wait, opens := inActiveWindow(s.activeWindows, s.clock.Now(), s.started)
+		if active {
+			s.metrics.activeWindow.Set(1)
+			return false
+		}
+		s.metrics.activeWindow.Set(0)
+		if !logged {
+			if opens {
+				s.logf("Holding %d replicas outside the active windows, the next one opens in %s", s.current, wait.Round(time.Second))
+			} else {
+				s.logf("Holding %d replicas, no active window opens again", s.current)
+			}
+			logged = true
+		}
+		if !opens {
+			// Check again later, the phase ends or the replicas are held forever.
+			wait = time.Hour
+		}
+		if ph.Duration > 0 {
+			left := ph.Duration - s.clock.Now().Sub(start)
+			if left <= 0 {
+				return true
+			}
+			if left < wait {
+				wait = left
+			}
+		}
+		s.metrics.push()
+		s.health.progress(wait)
+		s.clock.Sleep(wait)
+	}
+}
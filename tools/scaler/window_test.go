@@ -0,0 +1,109 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParseActiveWindows(t *testing.T) {
+	windows, err := parseActiveWindows([]string{"22:00-02:00", "09:30-12:00", "10m-1h", "1h-2h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []activeWindow{
+		{spec: "22:00-02:00", daily: true, start: 22 * time.Hour, end: 2 * time.Hour},
+		{spec: "09:30-12:00", daily: true, start: 9*time.Hour + 30*time.Minute, end: 12 * time.Hour},
+		{spec: "10m-1h", start: 10 * time.Minute, end: time.Hour},
+		{spec: "1h-2h", start: time.Hour, end: 2 * time.Hour},
+	}
+	for i := range want {
+		if windows[i] != want[i] {
+			t.Errorf("want window %+v, got %+v", want[i], windows[i])
+		}
+	}
+
+	for _, specs := range [][]string{
+		{"22:00"},
+		{"25:00-26:00"},
+		{"10:00-10:00"},
+		{"1h-30m"},
+		{"-1h-30m"},
+		{"10:00-12:00", "11:00-13:00"},
+		// Crossing midnight overlaps with the early morning window.
+		{"22:00-02:00", "01:00-03:00"},
+		{"0s-1h", "30m-2h"},
+	} {
+		if _, err := parseActiveWindows(specs); err == nil {
+			t.Errorf("%v: expected an error", specs)
+		}
+	}
+}
+
+func TestInActiveWindow(t *testing.T) {
+	started := time.Date(2026, 10, 14, 8, 0, 0, 0, time.Local)
+	windows, err := parseActiveWindows([]string{"22:00-02:00", "30m-1h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		now    time.Time
+		active bool
+		wait   time.Duration
+		opens  bool
+	}{
+		{now: started, wait: 30 * time.Minute, opens: true},
+		{now: started.Add(45 * time.Minute), active: true},
+		{now: started.Add(time.Hour), wait: 13 * time.Hour, opens: true},
+		{now: time.Date(2026, 10, 14, 23, 0, 0, 0, time.Local), active: true},
+		{now: time.Date(2026, 10, 15, 1, 59, 0, 0, time.Local), active: true},
+		{now: time.Date(2026, 10, 15, 2, 0, 0, 0, time.Local), wait: 20 * time.Hour, opens: true},
+	} {
+		active, wait, opens := inActiveWindow(windows, tc.now, started)
+		if active != tc.active || wait != tc.wait || opens != tc.opens {
+			t.Errorf("at %v: want active %v, wait %s, opens %v, got %v, %s, %v", tc.now, tc.active, tc.wait, tc.opens, active, wait, opens)
+		}
+	}
+
+	if active, _, _ := inActiveWindow(nil, started, started); !active {
+		t.Error("want always active without windows")
+	}
+	if _, _, opens := inActiveWindow(windows[1:], started.Add(2*time.Hour), started); opens {
+		t.Error("want no window opening after the last offset window")
+	}
+}
+
+func TestHoldOutsideActiveWindows(t *testing.T) {
+	s := newScaler()
+	s.started = time.Now()
+	s.activeWindows, _ = parseActiveWindows([]string{"1h-2h"})
+	ph := &phase{Name: "burst", Duration: 50 * time.Millisecond}
+	if !s.holdOutsideActiveWindows(ph, time.Now()) {
+		t.Error("want the phase to end while holding")
+	}
+	if v := testutil.ToFloat64(s.metrics.activeWindow); v != 0 {
+		t.Errorf("want scaler_active_window 0 outside of the windows, got %v", v)
+	}
+
+	s.activeWindows, _ = parseActiveWindows([]string{"0s-1h"})
+	if s.holdOutsideActiveWindows(ph, time.Now()) {
+		t.Error("want no hold within a window")
+	}
+	if v := testutil.ToFloat64(s.metrics.activeWindow); v != 1 {
+		t.Errorf("want scaler_active_window 1 within a window, got %v", v)
+	}
+}